@@ -0,0 +1,48 @@
+package app
+
+import (
+	"fmt"
+	"os"
+
+	"git.parallelcoin.io/dev/pod/pkg/chain/fork"
+	"git.parallelcoin.io/dev/pod/pkg/chain/fork/bench"
+	"gopkg.in/urfave/cli.v1"
+)
+
+func benchHandle(c *cli.Context) error {
+
+	asJSON := c.Bool("json")
+
+	if !asJSON {
+
+		fmt.Println("hashing each algorithm of each hard fork for", c.Duration("duration"), "...")
+	}
+	profile := bench.Run(fork.List, c.Int("threads"), c.Duration("duration"),
+		func(r bench.Result) {
+
+			if !asJSON {
+
+				fmt.Printf("fork %d %-10s %12d ns/op\n", r.Fork, r.Algo, r.NSPerOp)
+			}
+		})
+
+	if path := c.String("profile"); path != "" {
+
+		if err := profile.Save(path); err != nil {
+
+			return err
+		}
+
+		if !asJSON {
+
+			fmt.Println("saved profile to", path, "- start the node with --benchprofile", path, "to mine with it")
+		}
+	}
+
+	if asJSON {
+
+		return profile.WriteJSON(os.Stdout)
+	}
+	fmt.Println()
+	return profile.WriteTable(os.Stdout)
+}
@@ -8,7 +8,6 @@ import (
 
 	"git.parallelcoin.io/dev/pod/cmd/node"
 	netparams "git.parallelcoin.io/dev/pod/pkg/chain/config/params"
-	"git.parallelcoin.io/dev/pod/pkg/chain/fork"
 	cl "git.parallelcoin.io/dev/pod/pkg/util/cl"
 	"github.com/pelletier/go-toml"
 	"gopkg.in/urfave/cli.v1"
@@ -80,7 +79,6 @@ func Configure() {
 		*podConfig.SimNet = false
 		*podConfig.RegressionTest = false
 		activeNetParams = &netparams.TestNet3Params
-		fork.IsTestnet = true
 
 	case "regtestnet", "regressiontest", "r":
 		log <- cl.Debug{"on regression testnet"}
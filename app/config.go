@@ -57,6 +57,7 @@ func podDefConfig() pod.Config {
 		RPCMaxClients:            new(int),
 		RPCMaxWebsockets:         new(int),
 		RPCMaxConcurrentReqs:     new(int),
+		RPCMaxBatchSize:          new(int),
 		RPCQuirks:                new(bool),
 		DisableRPC:               new(bool),
 		TLS:                      new(bool),
@@ -75,21 +76,31 @@ func podDefConfig() pod.Config {
 		SimNet:                   new(bool),
 		AddCheckpoints:           new(cli.StringSlice),
 		DisableCheckpoints:       new(bool),
+		AssumeValid:              new(string),
+		SkipCheckpointPoW:        new(bool),
+		ScriptThreads:            new(int),
+		Prune:                    new(int),
+		LoadSnapshot:             new(string),
+		SnapshotHash:             new(string),
 		DbType:                   new(string),
 		Profile:                  new(string),
 		CPUProfile:               new(string),
+		MetricsListener:          new(string),
 		Upnp:                     new(bool),
 		MinRelayTxFee:            new(float64),
 		FreeTxRelayLimit:         new(float64),
 		NoRelayPriority:          new(bool),
 		TrickleInterval:          new(time.Duration),
 		MaxOrphanTxs:             new(int),
+		MaxMempool:               new(int),
+		RejectReplacement:        new(bool),
 		Algo:                     new(string),
 		Generate:                 new(bool),
 		GenThreads:               new(int),
 		MiningAddrs:              new(cli.StringSlice),
 		MinerListener:            new(string),
 		MinerPass:                new(string),
+		BenchProfile:             new(string),
 		BlockMinSize:             new(int),
 		BlockMaxSize:             new(int),
 		BlockMinWeight:           new(int),
@@ -109,12 +120,17 @@ func podDefConfig() pod.Config {
 		WalletServer:             new(string),
 		NoInitialLoad:            new(bool),
 		WalletPass:               new(string),
+		CoinSelection:            new(string),
+		WalletRBF:                new(bool),
+		WalletBackend:            new(string),
 		CAFile:                   new(string),
 		OneTimeTLSKey:            new(bool),
 		ServerTLS:                new(bool),
 		LegacyRPCListeners:       new(cli.StringSlice),
 		LegacyRPCMaxClients:      new(int),
 		LegacyRPCMaxWebsockets:   new(int),
+		LegacyRPCMaxBatchSize:    new(int),
+		WalletMetricsListener:    new(string),
 		ExperimentalRPCListeners: new(cli.StringSlice),
 	}
 }
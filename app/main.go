@@ -11,6 +11,7 @@ import (
 	"git.parallelcoin.io/dev/pod/cmd/node"
 	"git.parallelcoin.io/dev/pod/cmd/node/mempool"
 	walletmain "git.parallelcoin.io/dev/pod/cmd/walletmain"
+	"git.parallelcoin.io/dev/pod/pkg/chain/fork/bench"
 	"git.parallelcoin.io/dev/pod/pkg/util/cl"
 	"gopkg.in/urfave/cli.v1"
 )
@@ -141,14 +142,38 @@ func GetApp() (a *cli.App) {
 				Aliases: []string{"w"},
 				Usage:   "start parallelcoin wallet server",
 				Action:  walletHandle,
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "backend",
+						Usage: "how the wallet follows the chain: rpc (connect to a full node) or spv (sync compact filters from peers without a full node), overrides walletbackend",
+					},
+				},
 				Subcommands: []cli.Command{
 
 					{
 						Name:  "create",
 						Usage: "Create the wallet if it does not exist",
+						Flags: []cli.Flag{
+							cli.StringFlag{
+								Name:  "watchonly",
+								Usage: "create a watch-only wallet from the extended public key of an account of another wallet",
+							},
+						},
 						Action: func(c *cli.Context) error {
 
 							Configure()
+							if xpub := c.String("watchonly"); xpub != "" {
+
+								if err := walletmain.CreateWatchingOnlyWallet(&podConfig, activeNetParams, xpub); err != nil {
+
+									log <- cl.Error{"failed to create watch-only wallet", err}
+
+									return err
+								}
+
+								return nil
+							}
+
 							if err := walletmain.CreateWallet(&podConfig, activeNetParams); err != nil {
 
 								log <- cl.Error{"failed to create wallet", err}
@@ -176,6 +201,11 @@ func GetApp() (a *cli.App) {
 
 				},
 			},
+			{
+				Name:   "spv",
+				Usage:  "start parallelcoin light client syncing block headers and compact filters without a full node",
+				Action: spvHandle,
+			},
 			{
 				Name:    "conf",
 				Aliases: []string{"C"},
@@ -191,6 +221,27 @@ func GetApp() (a *cli.App) {
 						Usage: "number of test? profiles to make based ",
 					}},
 			},
+			{
+				Name:    "bench",
+				Aliases: []string{"b"},
+				Usage:   "measure the cost of the hash function of each algorithm and hard fork on this machine",
+				Action:  benchHandle,
+				Flags: []cli.Flag{
+					cli.IntFlag{
+						Name:  "threads, t",
+						Usage: "number of threads hashing at the same time, 0 for all cores",
+					}, cli.DurationFlag{
+						Name:  "duration, d",
+						Usage: "how long to hash each algorithm",
+						Value: bench.DefaultDuration,
+					}, cli.BoolFlag{
+						Name:  "json, j",
+						Usage: "print the results as JSON instead of a table",
+					}, cli.StringFlag{
+						Name:  "profile, p",
+						Usage: "file to save the profile to, for use with the benchprofile option of the node",
+					}},
+			},
 			{
 				Name:    "shell",
 				Aliases: []string{"s"},
@@ -377,6 +428,11 @@ func GetApp() (a *cli.App) {
 			Value:       node.DefaultMaxRPCConcurrentReqs,
 			Usage:       "Max number of concurrent RPC requests that may be processed concurrently",
 			Destination: podConfig.RPCMaxConcurrentReqs,
+		}), altsrc.NewIntFlag(cli.IntFlag{
+			Name:        "rpcmaxbatchsize",
+			Value:       node.DefaultMaxRPCBatchSize,
+			Usage:       "Max number of requests in a JSON-RPC batch request -- 0 to disable batch requests",
+			Destination: podConfig.RPCMaxBatchSize,
 		}), altsrc.NewBoolFlag(cli.BoolFlag{
 			Name:        "rpcquirks",
 			Usage:       "Mirror some JSON-RPC quirks of Bitcoin Core -- NOTE: Discouraged unless interoperability issues need to be worked around",
@@ -401,6 +457,30 @@ func GetApp() (a *cli.App) {
 			Name:        "nocheckpoints",
 			Usage:       "Disable built-in checkpoints.  Don't do this unless you know what you're doing.",
 			Destination: podConfig.DisableCheckpoints,
+		}), altsrc.NewStringFlag(cli.StringFlag{
+			Name:        "assumevalid",
+			Usage:       "Skip validating the scripts of this block and its ancestors -- no network sets a default yet, so every script is validated unless a block is given.  Format: '<height>:<hash>'",
+			Destination: podConfig.AssumeValid,
+		}), altsrc.NewBoolFlag(cli.BoolFlag{
+			Name:        "skipcheckpointpow",
+			Usage:       "Skip checking the proof of work of blocks linked to a checkpoint by their headers during the initial sync -- no network ships checkpoints yet, so this only applies to ones added with addcheckpoint",
+			Destination: podConfig.SkipCheckpointPoW,
+		}), altsrc.NewIntFlag(cli.IntFlag{
+			Name:        "scriptthreads",
+			Usage:       "Number of goroutines used to validate the scripts of a block -- 0 for three per CPU core",
+			Destination: podConfig.ScriptThreads,
+		}), altsrc.NewIntFlag(cli.IntFlag{
+			Name:        "prune",
+			Usage:       "Delete the oldest blocks to keep the stored blocks below this size in MiB, at least 550 -- 0 to keep every block.  Requires notxindex and noaddrindex",
+			Destination: podConfig.Prune,
+		}), altsrc.NewStringFlag(cli.StringFlag{
+			Name:        "loadsnapshot",
+			Usage:       "Bootstrap a new chain from a utxo snapshot written by dumptxoutset, validating the blocks before it in the background -- requires the optional indexes to be disabled",
+			Destination: podConfig.LoadSnapshot,
+		}), altsrc.NewStringFlag(cli.StringFlag{
+			Name:        "snapshothash",
+			Usage:       "Trust the utxo snapshot loaded with loadsnapshot, which no network ships yet, when it matches this block and the utxo set hash reported by dumptxoutset or gettxoutsetinfo on a node you trust.  Format: '<height>:<hash>:<set hash>'",
+			Destination: podConfig.SnapshotHash,
 		}), altsrc.NewStringFlag(cli.StringFlag{
 			Name:        "dbtype",
 			Value:       node.DefaultDbType,
@@ -414,6 +494,10 @@ func GetApp() (a *cli.App) {
 			Name:        "cpuprofile",
 			Usage:       "Write CPU profile to the specified file",
 			Destination: podConfig.CPUProfile,
+		}), altsrc.NewStringFlag(cli.StringFlag{
+			Name:        "metricslisten",
+			Usage:       "Export Prometheus metrics on /metrics at this address, such as 127.0.0.1:11099 -- disabled when empty",
+			Destination: podConfig.MetricsListener,
 		}), altsrc.NewBoolFlag(cli.BoolFlag{
 			Name:        "upnp",
 			Usage:       "Use UPnP to map our listening port outside of NAT",
@@ -442,10 +526,19 @@ func GetApp() (a *cli.App) {
 			Value:       node.DefaultMaxOrphanTransactions,
 			Usage:       "Max number of orphan transactions to keep in memory",
 			Destination: podConfig.MaxOrphanTxs,
+		}), altsrc.NewIntFlag(cli.IntFlag{
+			Name:        "maxmempool",
+			Value:       node.DefaultMaxMempool,
+			Usage:       "Max size of the transaction memory pool in megabytes, the transactions paying the lowest fees are evicted when it is full -- 0 for no limit",
+			Destination: podConfig.MaxMempool,
+		}), altsrc.NewBoolFlag(cli.BoolFlag{
+			Name:        "rejectreplacement",
+			Usage:       "Reject transactions that double spend transactions in the memory pool, even when those signal that they may be replaced (BIP 125)",
+			Destination: podConfig.RejectReplacement,
 		}), altsrc.NewStringFlag(cli.StringFlag{
 			Name:        "algo",
-			Value:       "random",
-			Usage:       "Sets the algorithm for the CPU miner ( blake14lr, cryptonight7v2, keccak, lyra2rev2, scrypt, sha256d, stribog, skein, x11 default is 'random')",
+			Value:       node.DefaultAlgo,
+			Usage:       "Sets the algorithm for the CPU miner ( blake14lr, cryptonight7v2, keccak, lyra2rev2, scrypt, sha256d, stribog, skein, x11, 'random' for a random algorithm per block, default is 'auto', which mines the algorithm that is expected to find a block soonest)",
 			Destination: podConfig.Algo,
 		}), altsrc.NewBoolFlag(cli.BoolFlag{
 			Name:        "generate",
@@ -466,8 +559,12 @@ func GetApp() (a *cli.App) {
 			Destination: podConfig.MinerListener,
 		}), altsrc.NewStringFlag(cli.StringFlag{
 			Name:        "minerpass",
-			Usage:       "Encryption password required for miner clients to subscribe to work updates, for use over insecure connections",
+			Usage:       "Encryption password required for miner clients to subscribe to work updates, for use over insecure connections -- NOTE: Required when minerlistener is not a loopback address",
 			Destination: podConfig.MinerPass,
+		}), altsrc.NewStringFlag(cli.StringFlag{
+			Name:        "benchprofile",
+			Usage:       "Hash cost profile written by 'pod bench --profile', used instead of the built in hash costs to choose the algorithm for mining",
+			Destination: podConfig.BenchProfile,
 		}), altsrc.NewIntFlag(cli.IntFlag{
 			Name:        "blockminsize",
 			Value:       node.BlockMaxSizeMin,
@@ -546,6 +643,20 @@ func GetApp() (a *cli.App) {
 			Name:        "walletpass",
 			Usage:       "The public wallet password -- Only required if the wallet was created with one",
 			Destination: podConfig.WalletPass,
+		}), altsrc.NewStringFlag(cli.StringFlag{
+			Name:        "coinselection",
+			Value:       "largest",
+			Usage:       "How the wallet chooses the outputs spent by its transactions: largest, bnb (avoid change), privacy (spend each address together) or consolidate (spend small outputs)",
+			Destination: podConfig.CoinSelection,
+		}), altsrc.NewBoolFlag(cli.BoolFlag{
+			Name:        "walletrbf",
+			Usage:       "Signal that the transactions the wallet sends may be replaced by one paying a higher fee (BIP 125), unless the send request says otherwise",
+			Destination: podConfig.WalletRBF,
+		}), altsrc.NewStringFlag(cli.StringFlag{
+			Name:        "walletbackend",
+			Value:       "rpc",
+			Usage:       "How the wallet follows the chain: rpc (connect to a full node) or spv (sync compact filters from peers without a full node)",
+			Destination: podConfig.WalletBackend,
 		}), altsrc.NewBoolFlag(cli.BoolFlag{
 			Name:        "onetimetlskey",
 			Usage:       "Generate a new TLS certpair at startup, but only write the certificate to disk",
@@ -564,6 +675,15 @@ func GetApp() (a *cli.App) {
 			Value:       8,
 			Usage:       "Max number of legacy RPC websocket connections",
 			Destination: podConfig.LegacyRPCMaxWebsockets,
+		}), altsrc.NewIntFlag(cli.IntFlag{
+			Name:        "walletrpcmaxbatchsize",
+			Value:       walletmain.DefaultRPCMaxBatchSize,
+			Usage:       "Max number of requests in a legacy RPC JSON-RPC batch request -- 0 to disable batch requests",
+			Destination: podConfig.LegacyRPCMaxBatchSize,
+		}), altsrc.NewStringFlag(cli.StringFlag{
+			Name:        "walletmetricslisten",
+			Usage:       "Export the wallet Prometheus metrics on /metrics at this address, such as 127.0.0.1:11098 -- disabled when empty",
+			Destination: podConfig.WalletMetricsListener,
 		}), altsrc.NewStringSliceFlag(cli.StringSliceFlag{
 			Name:  "experimentalrpclisten",
 			Usage: "Listen for RPC connections on this interface/port",
@@ -13,6 +13,7 @@ import (
 	"git.parallelcoin.io/dev/pod/cmd/node"
 	blockchain "git.parallelcoin.io/dev/pod/pkg/chain"
 	"git.parallelcoin.io/dev/pod/pkg/chain/fork"
+	"git.parallelcoin.io/dev/pod/pkg/chain/mining"
 	"git.parallelcoin.io/dev/pod/pkg/peer/connmgr"
 	"git.parallelcoin.io/dev/pod/pkg/util"
 	cl "git.parallelcoin.io/dev/pod/pkg/util/cl"
@@ -63,13 +64,13 @@ func nodeHandle(c *cli.Context) error {
 		podConfig.AddPeers = nil
 	}
 
-	// Set the mining algorithm correctly, default to random if unrecognised
+	// Set the mining algorithm correctly, default to auto if unrecognised
 	switch *podConfig.Algo {
 
-	case fork.P9AlgoVers[0], fork.P9AlgoVers[1], fork.P9AlgoVers[2], fork.P9AlgoVers[3], fork.P9AlgoVers[4], fork.P9AlgoVers[5], fork.P9AlgoVers[6], fork.P9AlgoVers[7], fork.P9AlgoVers[8], "random", "easy":
+	case fork.P9AlgoVers[0], fork.P9AlgoVers[1], fork.P9AlgoVers[2], fork.P9AlgoVers[3], fork.P9AlgoVers[4], fork.P9AlgoVers[5], fork.P9AlgoVers[6], fork.P9AlgoVers[7], fork.P9AlgoVers[8], "random", mining.AutoAlgo, "easy":
 
 	default:
-		*podConfig.Algo = "random"
+		*podConfig.Algo = mining.AutoAlgo
 	}
 	log <- cl.Debug{"mining algorithm", *podConfig.Algo}
 
@@ -138,6 +139,24 @@ func nodeHandle(c *cli.Context) error {
 
 	}
 
+	// Validate metrics listener address
+	log <- cl.Debug{"validating metrics listener address"}
+	if *podConfig.MetricsListener != "" {
+
+		_, _, err := net.SplitHostPort(*podConfig.MetricsListener)
+
+		if err != nil {
+
+			str := "%s: The metricslisten option must be a host:port address -- parsed [%s]"
+			err := fmt.Errorf(str, funcName, *podConfig.MetricsListener)
+
+			log <- cl.Error{err}
+
+			return err
+		}
+
+	}
+
 	// Don't allow ban durations that are too short.
 	log <- cl.Debug{"validating ban duration"}
 	if *podConfig.BanDuration < time.Second {
@@ -315,6 +334,18 @@ func nodeHandle(c *cli.Context) error {
 		return err
 	}
 
+	log <- cl.Debug{"checking rpc max batch size"}
+	if *podConfig.RPCMaxBatchSize < 0 {
+
+		str := "%s: The rpcmaxbatchsize option may not be less than 0 -- parsed [%d]"
+		err := fmt.Errorf(str, funcName, *podConfig.RPCMaxBatchSize)
+
+		log <- cl.Error{err}
+
+		// fmt.Fprintln(os.Stderr, usageMessage)
+		return err
+	}
+
 	var err error
 
 	// Validate the the minrelaytxfee.
@@ -375,6 +406,19 @@ func nodeHandle(c *cli.Context) error {
 		return err
 	}
 
+	// The mempool size limit may not be negative, 0 means no limit.
+	log <- cl.Debug{"checking max mempool size"}
+	if *podConfig.MaxMempool < 0 {
+
+		str := "%s: The maxmempool option may not be less than 0 -- parsed [%d]"
+		err := fmt.Errorf(str, funcName, *podConfig.MaxMempool)
+
+		log <- cl.Error{err}
+
+		// fmt.Fprintln(os.Stderr, usageMessage)
+		return err
+	}
+
 	// Limit the block priority and minimum block sizes to max block size.
 	log <- cl.Debug{"checking validating block priority and minimium size/weight"}
 	*podConfig.BlockPrioritySize = int(minUint32(
@@ -494,6 +538,74 @@ func nodeHandle(c *cli.Context) error {
 		return err
 	}
 
+	// Check the assumed valid block for syntax errors.
+	log <- cl.Debug{"checking the assumed valid block"}
+	StateCfg.ActiveAssumeValid, StateCfg.NoAssumeValid, err =
+		node.ParseAssumeValid(*podConfig.AssumeValid)
+
+	if err != nil {
+
+		str := "%s: Error parsing assumevalid: %v"
+		err := fmt.Errorf(str, funcName, err)
+
+		log <- cl.Err(err.Error())
+
+		// fmt.Fprintln(os.Stderr, usageMessage)
+		return err
+	}
+
+	// Check the trusted utxo snapshot for syntax errors.
+	log <- cl.Debug{"checking the trusted utxo snapshot"}
+	StateCfg.ActiveUtxoSnapshot, err = node.ParseUtxoSnapshot(*podConfig.SnapshotHash)
+
+	if err != nil {
+
+		str := "%s: Error parsing snapshothash: %v"
+		err := fmt.Errorf(str, funcName, err)
+
+		log <- cl.Err(err.Error())
+
+		// fmt.Fprintln(os.Stderr, usageMessage)
+		return err
+	}
+
+	// The number of script validation threads may not be negative, 0 means three per CPU core.
+	log <- cl.Debug{"checking script threads"}
+	if *podConfig.ScriptThreads < 0 {
+
+		str := "%s: The scriptthreads option may not be less than 0 -- parsed [%d]"
+		err := fmt.Errorf(str, funcName, *podConfig.ScriptThreads)
+
+		log <- cl.Error{err}
+
+		// fmt.Fprintln(os.Stderr, usageMessage)
+		return err
+	}
+
+	// Pruning keeps at least 550 MiB of blocks, and the transaction and address indexes need every block.
+	log <- cl.Debug{"checking prune"}
+	if *podConfig.Prune != 0 && *podConfig.Prune < node.PruneTargetMin {
+
+		str := "%s: The prune option must be 0 or at least %d MiB -- parsed [%d]"
+		err := fmt.Errorf(str, funcName, node.PruneTargetMin, *podConfig.Prune)
+
+		log <- cl.Error{err}
+
+		// fmt.Fprintln(os.Stderr, usageMessage)
+		return err
+	}
+
+	if *podConfig.Prune != 0 && (*podConfig.TxIndex || *podConfig.AddrIndex) {
+
+		str := "%s: The prune option requires the notxindex and noaddrindex options"
+		err := fmt.Errorf(str, funcName)
+
+		log <- cl.Error{err}
+
+		// fmt.Fprintln(os.Stderr, usageMessage)
+		return err
+	}
+
 	// Check the checkpoints for syntax errors.
 	log <- cl.Debug{"checking the checkpoints"}
 	StateCfg.AddedCheckpoints, err = node.ParseCheckpoints(*podConfig.AddCheckpoints)
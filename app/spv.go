@@ -0,0 +1,15 @@
+package app
+
+import (
+	"fmt"
+
+	"git.parallelcoin.io/dev/pod/cmd/spv"
+
+	"gopkg.in/urfave/cli.v1"
+)
+
+func spvHandle(c *cli.Context) error {
+	fmt.Println("starting spv light client")
+	Configure()
+	return spv.Main(&podConfig, activeNetParams)
+}
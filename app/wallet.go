@@ -10,6 +10,9 @@ import (
 
 func walletHandle(c *cli.Context) error {
 	fmt.Println("starting wallet")
+	if backend := c.String("backend"); backend != "" {
+		*podConfig.WalletBackend = backend
+	}
 	Configure()
 	return walletmain.Main(&podConfig, activeNetParams)
 }
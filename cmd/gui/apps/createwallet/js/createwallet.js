@@ -0,0 +1,40 @@
+var CreateWalletC = {
+  template: vuedata.data.pages.createwallet,
+  props:{
+    vicons:Object,
+    vlng:Object,
+  },
+  data () {
+    return {
+      restore: false,
+      mnemonic: "",
+      privpass: "",
+      privpassconfirm: "",
+      seedpass: "",
+      stored: false,
+      cw: createwallet.data,
+    }
+  },
+  components: {
+},
+  methods: {
+    generate: function() {
+      this.restore = false;
+      createwallet.generateMnemonic();
+      this.mnemonic = createwallet.data.mnemonic;
+    },
+    createForm: function() {
+      if (this.privpass != this.privpassconfirm) {
+        this.$toast.open({ message: 'The passphrases do not match', type: 'is-danger' });
+        return;
+      }
+      createwallet.create(this.mnemonic, this.privpass, this.seedpass, this.restore);
+      if (createwallet.data.created) {
+        this.$toast.open('Wallet created!');
+        this.$root.swapComponent(HomeC);
+      } else {
+        this.$toast.open({ message: createwallet.data.err, type: 'is-danger' });
+      }
+    },
+  }
+}
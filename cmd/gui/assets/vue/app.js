@@ -19,12 +19,13 @@ var app = new Vue({
     // vpage: vdt.data.pages.home,
     timer: '',
     // component: Home,
-    component: HomeC,
+    component: createwallet.data.created ? HomeC : CreateWalletC,
     updateAvailable: false,
   }
 },
 components: {
   HomeC,
+  CreateWalletC,
   // SendC,
 },
 created: function() {
@@ -12,6 +12,8 @@ import (
 	"git.parallelcoin.io/dev/pod/cmd/gui/libs"
 	"git.parallelcoin.io/dev/pod/cmd/gui/vue"
 	"git.parallelcoin.io/dev/pod/cmd/shell"
+	walletmain "git.parallelcoin.io/dev/pod/cmd/walletmain"
+	"git.parallelcoin.io/dev/pod/pkg/wallet"
 	scribble "github.com/nanobox-io/golang-scribble"
 	"github.com/zserge/webview"
 )
@@ -51,6 +53,10 @@ func GUI(
 	defer w.Exit()
 
 	// Here we need to check for and create wallet :
+	activeNet := sh.GetWalletActiveNet()
+	loader := wallet.NewLoader(activeNet.Params,
+		walletmain.NetworkDir(sh.DataDir, activeNet.Params), 250)
+	createWallet := vue.NewCreateWallet(loader)
 
 	// Next start up shell
 
@@ -61,6 +67,7 @@ func GUI(
 
 	w.Dispatch(func() {
 
+		w.Bind("createwallet", createWallet)
 		w.Bind("blockchaindata", &vue.BlockChain{})
 
 		// w.Bind("sendtoaddress", &vue.SendToAddress{})
@@ -0,0 +1,90 @@
+package vue
+
+import (
+	"strings"
+	"time"
+
+	"git.parallelcoin.io/dev/pod/pkg/util/bip39"
+	"git.parallelcoin.io/dev/pod/pkg/wallet"
+)
+
+// CreateWallet creates the wallet from the GUI when there is none yet, either from newly generated seed words or by restoring from the seed words of an existing wallet.
+type CreateWallet struct {
+	Mnemonic string `json:"mnemonic"`
+	Created  bool   `json:"created"`
+	Err      string `json:"err"`
+	loader   *wallet.Loader
+}
+
+// NewCreateWallet returns a CreateWallet that opens and creates wallets with loader.
+func NewCreateWallet(
+	loader *wallet.Loader) *CreateWallet {
+
+	created, err := loader.WalletExists()
+	cw := &CreateWallet{Created: created, loader: loader}
+
+	if err != nil {
+
+		cw.Err = err.Error()
+	}
+
+	return cw
+}
+
+// GenerateMnemonic generates new seed words for the wallet to be created from.
+func (cw *CreateWallet) GenerateMnemonic() {
+
+	cw.Err = ""
+	entropy, err := bip39.NewEntropy(bip39.RecommendedEntropyBits)
+
+	if err != nil {
+
+		cw.Err = err.Error()
+		return
+	}
+
+	cw.Mnemonic, err = bip39.NewMnemonic(entropy)
+
+	if err != nil {
+
+		cw.Err = err.Error()
+	}
+}
+
+// Create creates the wallet from the seed words mnemonic protected by the seed passphrase seedPass, encrypting its private keys with privPass.  When restore is true the wallet rescans the whole chain for the addresses of the seed.
+func (cw *CreateWallet) Create(
+	mnemonic, privPass, seedPass string, restore bool) {
+
+	cw.Err = ""
+	mnemonic = strings.Join(strings.Fields(mnemonic), " ")
+	seed, err := bip39.NewSeed(mnemonic, seedPass)
+
+	if err != nil {
+
+		cw.Err = err.Error()
+		return
+	}
+
+	pubPass := []byte(wallet.InsecurePubPassphrase)
+	var w *wallet.Wallet
+
+	if restore {
+
+		w, err = cw.loader.RestoreWallet(pubPass, []byte(privPass), seed)
+
+	} else {
+
+		w, err = cw.loader.CreateNewWallet(pubPass, []byte(privPass), seed,
+			time.Now())
+	}
+
+	if err != nil {
+
+		cw.Err = err.Error()
+		return
+	}
+
+	WLT = w
+	cw.Mnemonic = ""
+	cw.Created = true
+}
@@ -22,6 +22,7 @@ import (
 	"git.parallelcoin.io/dev/pod/pkg/wallet"
 	waddrmgr "git.parallelcoin.io/dev/pod/pkg/wallet/addrmgr"
 	chain "git.parallelcoin.io/dev/pod/pkg/wallet/chain"
+	wmeta "git.parallelcoin.io/dev/pod/pkg/wallet/meta"
 	"github.com/google/martian/log"
 )
 
@@ -1692,9 +1693,11 @@ func makeOutputs(pairs map[string]util.Amount, chainParams *chaincfg.Params) ([]
 // sendPairs creates and sends payment transactions.
 // It returns the transaction hash in string format upon success
 // All errors are returned in json.RPCError format
+// The comments in meta, if any, are recorded for the sent transaction.
 func sendPairs(w *wallet.Wallet, amounts map[string]util.Amount,
 
-	account uint32, minconf int32, feeSatPerKb util.Amount) (string, error) {
+	account uint32, minconf int32, feeSatPerKb util.Amount,
+	meta *wmeta.TxMeta) (string, error) {
 
 	outputs, err := makeOutputs(amounts, WLT.ChainParams())
 
@@ -1733,6 +1736,20 @@ func sendPairs(w *wallet.Wallet, amounts map[string]util.Amount,
 
 	txHashStr := txHash.String()
 	log.Infof("Successfully sent transaction %v", txHashStr)
+
+	// The transaction has already been sent, so a failure to record the
+	// comments is not reported as a failure of the send.
+	if meta != nil && !meta.IsEmpty() {
+
+		err = WLT.PutTxMeta(txHash, meta)
+
+		if err != nil {
+
+			log.Errorf("Failed to record comments of transaction %v: %v",
+				txHashStr, err)
+		}
+	}
+
 	return txHashStr, nil
 }
 func isNilOrEmpty(s *string) bool {
@@ -1740,6 +1757,25 @@ func isNilOrEmpty(s *string) bool {
 	return s == nil || *s == ""
 }
 
+// txComments returns the metadata holding the comment and comment to
+// parameters of a send request.
+func txComments(comment, commentTo *string) *wmeta.TxMeta {
+
+	meta := new(wmeta.TxMeta)
+
+	if !isNilOrEmpty(comment) {
+
+		meta.Comment = *comment
+	}
+
+	if !isNilOrEmpty(commentTo) {
+
+		meta.CommentTo = *commentTo
+	}
+
+	return meta
+}
+
 // sendFrom handles a sendfrom RPC request by creating a new transaction
 // spending unspent transaction outputs for a wallet to another payment
 // address.  Leftover inputs not sent to the payment address or a fee for
@@ -1749,19 +1785,6 @@ func sendFrom(icmd interface{}, chainClient *chain.RPCClient) (interface{}, erro
 
 	cmd := icmd.(*json.SendFromCmd)
 
-	// Transaction comments are not yet supported.  Error instead of
-	// pretending to save them.
-
-	if !isNilOrEmpty(cmd.Comment) || !isNilOrEmpty(cmd.CommentTo) {
-
-		return nil, &json.RPCError{
-
-			Code:    json.ErrRPCUnimplemented,
-			Message: "Transaction comments are not yet supported",
-		}
-
-	}
-
 	account, err := WLT.AccountNumber(
 		waddrmgr.KeyScopeBIP0044, cmd.FromAccount,
 	)
@@ -1799,7 +1822,7 @@ func sendFrom(icmd interface{}, chainClient *chain.RPCClient) (interface{}, erro
 	}
 
 	return sendPairs(WLT, pairs, account, minConf,
-		txrules.DefaultRelayFeePerKb)
+		txrules.DefaultRelayFeePerKb, txComments(cmd.Comment, cmd.CommentTo))
 }
 
 // sendMany handles a sendmany RPC request by creating a new transaction
@@ -1811,19 +1834,6 @@ func sendMany(icmd interface{}) (interface{}, error) {
 
 	cmd := icmd.(*json.SendManyCmd)
 
-	// Transaction comments are not yet supported.  Error instead of
-	// pretending to save them.
-
-	if !isNilOrEmpty(cmd.Comment) {
-
-		return nil, &json.RPCError{
-
-			Code:    json.ErrRPCUnimplemented,
-			Message: "Transaction comments are not yet supported",
-		}
-
-	}
-
 	account, err := WLT.AccountNumber(waddrmgr.KeyScopeBIP0044, cmd.FromAccount)
 
 	if err != nil {
@@ -1854,7 +1864,8 @@ func sendMany(icmd interface{}) (interface{}, error) {
 		pairs[k] = amt
 	}
 
-	return sendPairs(WLT, pairs, account, minConf, txrules.DefaultRelayFeePerKb)
+	return sendPairs(WLT, pairs, account, minConf, txrules.DefaultRelayFeePerKb,
+		txComments(cmd.Comment, nil))
 }
 
 // sendToAddress handles a sendtoaddress RPC request by creating a new
@@ -1887,8 +1898,12 @@ func (rpc *RPCInterface) SendToAddress(vaddress string, vlabel string, vamount i
 		vaddress: amt,
 	}
 
+	// The label entered in the send form is recorded as the label of the
+	// transaction.
+	meta := &wmeta.TxMeta{Label: vlabel}
+
 	// sendtoaddress always spends from the default account, this matches bitcoind
-	txid, _ := sendPairs(WLT, pairs, waddrmgr.DefaultAccountNum, 1, txrules.DefaultRelayFeePerKb)
+	txid, _ := sendPairs(WLT, pairs, waddrmgr.DefaultAccountNum, 1, txrules.DefaultRelayFeePerKb, meta)
 
 	if txid != "" {
 
@@ -0,0 +1,280 @@
+package node
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	cl "git.parallelcoin.io/dev/pod/pkg/util/cl"
+)
+
+// banListFileName is the name of the file in the data directory that the banned addresses and subnets are stored in.
+const banListFileName = "banlist.json"
+
+var (
+
+	// errAlreadyBanned is returned when banning an address or subnet that is already banned.
+	errAlreadyBanned = errors.New("address or subnet is already banned")
+
+	// errNotBanned is returned when unbanning an address or subnet that is not banned.
+	errNotBanned = errors.New("address or subnet is not banned")
+)
+
+// banEntry is a banned address or subnet along with when and why it was banned and when the ban expires.
+type banEntry struct {
+	Subnet  string `json:"subnet"`
+	Created int64  `json:"created"`
+	Until   int64  `json:"until"`
+	Reason  string `json:"reason"`
+	ipNet   *net.IPNet
+}
+
+// banList is the set of banned addresses and subnets of the server, which is stored in a file so that bans outlast restarts.  Expired bans are removed as they are found.  It is safe for concurrent access.
+type banList struct {
+	mtx  sync.Mutex
+	path string
+	bans map[string]*banEntry
+}
+
+// parseBanSubnet parses a single address or a subnet in CIDR notation into the subnet to ban.  A single address is a subnet of only that address.
+func parseBanSubnet(
+	s string) (*net.IPNet, error) {
+
+	_, ipNet, err := net.ParseCIDR(s)
+
+	if err == nil {
+
+		return ipNet, nil
+	}
+
+	ip := net.ParseIP(s)
+
+	if ip == nil {
+
+		return nil, fmt.Errorf("invalid address or subnet %q", s)
+	}
+
+	if ip4 := ip.To4(); ip4 != nil {
+
+		return &net.IPNet{IP: ip4, Mask: net.CIDRMask(32, 32)}, nil
+	}
+
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(128, 128)}, nil
+}
+
+// newBanList returns the ban list stored in the file at path, which is empty if there is no file yet.  Bans that have expired since it was stored are dropped.
+func newBanList(
+	path string) (*banList, error) {
+
+	b := &banList{path: path, bans: make(map[string]*banEntry)}
+	serialized, err := ioutil.ReadFile(path)
+
+	if os.IsNotExist(err) {
+
+		return b, nil
+	}
+
+	if err != nil {
+
+		return nil, err
+	}
+
+	var entries []*banEntry
+
+	if err := json.Unmarshal(serialized, &entries); err != nil {
+
+		return nil, fmt.Errorf("unable to decode %s: %v", path, err)
+	}
+
+	now := time.Now().Unix()
+
+	for _, entry := range entries {
+
+		if entry.Until <= now {
+
+			continue
+		}
+
+		ipNet, err := parseBanSubnet(entry.Subnet)
+
+		if err != nil {
+
+			return nil, fmt.Errorf("unable to decode %s: %v", path, err)
+		}
+
+		entry.ipNet = ipNet
+		entry.Subnet = ipNet.String()
+		b.bans[entry.Subnet] = entry
+	}
+
+	return b, nil
+}
+
+// Ban bans the subnet until the passed time for the reason given and stores the ban list.  Banning a subnet that is already banned returns errAlreadyBanned.
+func (
+	b *banList,
+) Ban(
+
+	ipNet *net.IPNet, until time.Time, reason string) error {
+
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	key := ipNet.String()
+
+	if entry, ok := b.bans[key]; ok && entry.Until > time.Now().Unix() {
+
+		return errAlreadyBanned
+	}
+
+	b.bans[key] = &banEntry{
+
+		Subnet:  key,
+		Created: time.Now().Unix(),
+		Until:   until.Unix(),
+		Reason:  reason,
+		ipNet:   ipNet,
+	}
+
+	return b.save()
+}
+
+// Unban lifts the ban of the subnet and stores the ban list.  Unbanning a subnet that is not banned returns errNotBanned.
+func (
+	b *banList,
+) Unban(
+
+	ipNet *net.IPNet) error {
+
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	key := ipNet.String()
+
+	if _, ok := b.bans[key]; !ok {
+
+		return errNotBanned
+	}
+
+	delete(b.bans, key)
+	return b.save()
+}
+
+// Clear lifts all bans and stores the empty ban list.
+func (
+	b *banList,
+) Clear() error {
+
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	b.bans = make(map[string]*banEntry)
+	return b.save()
+}
+
+// IsBanned returns the ban covering the address and whether there is one.
+func (
+	b *banList,
+) IsBanned(
+
+	ip net.IP) (banEntry, bool) {
+
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	b.expire()
+
+	for _, entry := range b.bans {
+
+		if entry.ipNet.Contains(ip) {
+
+			return *entry, true
+		}
+	}
+
+	return banEntry{}, false
+}
+
+// List returns the bans that have not expired, ordered by subnet.
+func (
+	b *banList,
+) List() []banEntry {
+
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	b.expire()
+
+	entries := make([]banEntry, 0, len(b.bans))
+
+	for _, entry := range b.bans {
+
+		entries = append(entries, *entry)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+
+		return entries[i].Subnet < entries[j].Subnet
+	})
+
+	return entries
+}
+
+// expire removes the bans that have expired.  The file is not rewritten, as expired bans are dropped when it is loaded.  The mutex must be held.
+func (
+	b *banList,
+) expire() {
+
+	now := time.Now().Unix()
+
+	for key, entry := range b.bans {
+
+		if entry.Until <= now {
+
+			log <- cl.Infof{"ban of %s has expired", key}
+
+			delete(b.bans, key)
+		}
+	}
+}
+
+// save writes the ban list to a new file which then replaces the stored one.  The mutex must be held.
+func (
+	b *banList,
+) save() error {
+
+	entries := make([]*banEntry, 0, len(b.bans))
+
+	for _, entry := range b.bans {
+
+		entries = append(entries, entry)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+
+		return entries[i].Subnet < entries[j].Subnet
+	})
+
+	serialized, err := json.MarshalIndent(entries, "", "  ")
+
+	if err != nil {
+
+		return err
+	}
+
+	tmpPath := b.path + ".new"
+
+	if err := ioutil.WriteFile(tmpPath, serialized, 0600); err != nil {
+
+		os.Remove(tmpPath)
+		return err
+	}
+
+	return os.Rename(tmpPath, b.path)
+}
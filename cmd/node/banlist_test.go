@@ -0,0 +1,180 @@
+package node
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestParseBanSubnet ensures single addresses and subnets parse into the subnets banned.
+func TestParseBanSubnet(
+	t *testing.T,
+
+) {
+
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "192.168.1.7", want: "192.168.1.7/32"},
+		{in: "192.168.1.7/24", want: "192.168.1.0/24"},
+		{in: "::ffff:10.0.0.1", want: "10.0.0.1/32"},
+		{in: "2001:db8::1", want: "2001:db8::1/128"},
+		{in: "2001:db8::/32", want: "2001:db8::/32"},
+	}
+
+	for _, test := range tests {
+
+		ipNet, err := parseBanSubnet(test.in)
+
+		if err != nil {
+
+			t.Errorf("%s: unexpected error: %v", test.in, err)
+			continue
+		}
+
+		if ipNet.String() != test.want {
+
+			t.Errorf("%s: got %s, want %s", test.in, ipNet, test.want)
+		}
+	}
+
+	for _, in := range []string{"", "host.example", "10.0.0.1/33"} {
+
+		if _, err := parseBanSubnet(in); err == nil {
+
+			t.Errorf("%q: expected an error", in)
+		}
+	}
+}
+
+// TestBanList ensures bans cover the addresses in their subnets, are kept across loads of the ban list and are dropped once they expire.
+func TestBanList(
+	t *testing.T,
+
+) {
+
+	tmpDir, err := ioutil.TempDir("", "banlist")
+
+	if err != nil {
+
+		t.Fatalf("Failed creating a temporary directory: %v", err)
+	}
+
+	defer os.RemoveAll(tmpDir)
+
+	path := filepath.Join(tmpDir, banListFileName)
+	bans, err := newBanList(path)
+
+	if err != nil {
+
+		t.Fatalf("newBanList: unexpected error: %v", err)
+	}
+
+	subnet, _ := parseBanSubnet("10.1.0.0/16")
+	single, _ := parseBanSubnet("192.168.1.7")
+	until := time.Now().Add(time.Hour)
+
+	if err := bans.Ban(subnet, until, "manually added"); err != nil {
+
+		t.Fatalf("Ban: unexpected error: %v", err)
+	}
+
+	if err := bans.Ban(single, until, "ban score 101: sent an invalid block"); err != nil {
+
+		t.Fatalf("Ban: unexpected error: %v", err)
+	}
+
+	if err := bans.Ban(single, until, "again"); err != errAlreadyBanned {
+
+		t.Fatalf("Ban: got error %v, want %v", err, errAlreadyBanned)
+	}
+
+	// Bans are loaded from the file they were stored in.
+	bans, err = newBanList(path)
+
+	if err != nil {
+
+		t.Fatalf("newBanList: unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		ip     string
+		banned bool
+		reason string
+	}{
+		{ip: "10.1.200.3", banned: true, reason: "manually added"},
+		{ip: "10.2.0.1", banned: false},
+		{ip: "192.168.1.7", banned: true, reason: "ban score 101: sent an invalid block"},
+		{ip: "192.168.1.8", banned: false},
+	}
+
+	for _, test := range tests {
+
+		ban, banned := bans.IsBanned(net.ParseIP(test.ip))
+
+		if banned != test.banned {
+
+			t.Errorf("%s: got banned %v, want %v", test.ip, banned, test.banned)
+			continue
+		}
+
+		if banned && (ban.Reason != test.reason || ban.Until != until.Unix()) {
+
+			t.Errorf("%s: got ban %+v", test.ip, ban)
+		}
+	}
+
+	if list := bans.List(); len(list) != 2 || list[0].Subnet != "10.1.0.0/16" {
+
+		t.Fatalf("List: got %+v", list)
+	}
+
+	if err := bans.Unban(subnet); err != nil {
+
+		t.Fatalf("Unban: unexpected error: %v", err)
+	}
+
+	if err := bans.Unban(subnet); err != errNotBanned {
+
+		t.Fatalf("Unban: got error %v, want %v", err, errNotBanned)
+	}
+
+	// An expired ban no longer covers its addresses and is dropped.
+	expired, _ := parseBanSubnet("172.16.0.1")
+
+	if err := bans.Ban(expired, time.Now().Add(-time.Second), "expired"); err != nil {
+
+		t.Fatalf("Ban: unexpected error: %v", err)
+	}
+
+	if _, banned := bans.IsBanned(net.ParseIP("172.16.0.1")); banned {
+
+		t.Fatalf("IsBanned: expired ban still applies")
+	}
+
+	if list := bans.List(); len(list) != 1 || list[0].Subnet != "192.168.1.7/32" {
+
+		t.Fatalf("List: got %+v", list)
+	}
+
+	if err := bans.Clear(); err != nil {
+
+		t.Fatalf("Clear: unexpected error: %v", err)
+	}
+
+	bans, err = newBanList(path)
+
+	if err != nil {
+
+		t.Fatalf("newBanList: unexpected error: %v", err)
+	}
+
+	if list := bans.List(); len(list) != 0 {
+
+		t.Fatalf("List: got %+v after clearing", list)
+	}
+}
@@ -55,6 +55,7 @@ type Config struct {
 	RPCMaxClients        *int             `long:"rpcmaxclients" description:"Max number of RPC clients for standard connections"`
 	RPCMaxWebsockets     *int             `long:"rpcmaxwebsockets" description:"Max number of RPC websocket connections"`
 	RPCMaxConcurrentReqs *int             `long:"rpcmaxconcurrentreqs" description:"Max number of concurrent RPC requests that may be processed concurrently"`
+	RPCMaxBatchSize      *int             `long:"rpcmaxbatchsize" description:"Max number of requests in a JSON-RPC batch request -- 0 to disable batch requests"`
 	RPCQuirks            *bool            `long:"rpcquirks" description:"Mirror some JSON-RPC quirks of Bitcoin Core -- NOTE: Discouraged unless interoperability issues need to be worked around"`
 	DisableRPC           *bool            `long:"norpc" description:"Disable built-in RPC server -- NOTE: The RPC server is disabled by default if no rpcuser/rpcpass or rpclimituser/rpclimitpass is specified"`
 	TLS                  *bool            `long:"tls" description:"Enable TLS for the RPC server"`
@@ -73,21 +74,31 @@ type Config struct {
 	SimNet               *bool            `long:"simnet" description:"Use the simulation test network"`
 	AddCheckpoints       *cli.StringSlice `long:"addcheckpoint" description:"Add a custom checkpoint.  Format: '<height>:<hash>'"`
 	DisableCheckpoints   *bool            `long:"nocheckpoints" description:"Disable built-in checkpoints.  Don't do this unless you know what you're doing."`
+	AssumeValid          *string          `long:"assumevalid" description:"Skip validating the scripts of this block and its ancestors -- no network sets a default yet, so every script is validated unless a block is given.  Format: '<height>:<hash>'"`
+	SkipCheckpointPoW    *bool            `long:"skipcheckpointpow" description:"Skip checking the proof of work of blocks linked to a checkpoint by their headers during the initial sync -- no network ships checkpoints yet, so this only applies to ones added with addcheckpoint"`
+	ScriptThreads        *int             `long:"scriptthreads" description:"Number of goroutines used to validate the scripts of a block -- 0 for three per CPU core"`
+	Prune                *int             `long:"prune" description:"Delete the oldest blocks to keep the stored blocks below this size in MiB, at least 550 -- 0 to keep every block.  Requires notxindex and noaddrindex"`
+	LoadSnapshot         *string          `long:"loadsnapshot" description:"Bootstrap a new chain from a utxo snapshot written by dumptxoutset, validating the blocks before it in the background -- requires the optional indexes to be disabled"`
+	SnapshotHash         *string          `long:"snapshothash" description:"Trust the utxo snapshot loaded with loadsnapshot, which no network ships yet, when it matches this block and the utxo set hash reported by dumptxoutset or gettxoutsetinfo on a node you trust.  Format: '<height>:<hash>:<set hash>'"`
 	DbType               *string          `long:"dbtype" description:"Database backend to use for the Block Chain"`
 	Profile              *string          `long:"profile" description:"Enable HTTP profiling on given port -- NOTE port must be between 1024 and 65536"`
 	CPUProfile           *string          `long:"cpuprofile" description:"Write CPU profile to the specified file"`
+	MetricsListener      *string          `long:"metricslisten" description:"Export Prometheus metrics on /metrics at this address, such as 127.0.0.1:11099 -- disabled when empty"`
 	Upnp                 *bool            `long:"upnp" description:"Use UPnP to map our listening port outside of NAT"`
 	MinRelayTxFee        *float64         `long:"minrelaytxfee" description:"The minimum transaction fee in DUO/kB to be considered a non-zero fee."`
 	FreeTxRelayLimit     *float64         `long:"limitfreerelay" description:"Limit relay of transactions with no transaction fee to the given amount in thousands of bytes per minute"`
 	NoRelayPriority      *bool            `long:"norelaypriority" description:"Do not require free or low-fee transactions to have high priority for relaying"`
 	TrickleInterval      *time.Duration   `long:"trickleinterval" description:"Minimum time between attempts to send new inventory to a connected peer"`
 	MaxOrphanTxs         *int             `long:"maxorphantx" description:"Max number of orphan transactions to keep in memory"`
-	Algo                 *string          `long:"algo" description:"Sets the algorithm for the CPU miner ( blake14lr, cryptonight7v2, keccak, lyra2rev2, scrypt, sha256d, stribog, skein, x11 default is 'random')"`
+	MaxMempool           *int             `long:"maxmempool" description:"Max size of the transaction memory pool in megabytes, the transactions paying the lowest fees are evicted when it is full -- 0 for no limit"`
+	RejectReplacement    *bool            `long:"rejectreplacement" description:"Reject transactions that double spend transactions in the memory pool, even when those signal that they may be replaced (BIP 125)"`
+	Algo                 *string          `long:"algo" description:"Sets the algorithm for the CPU miner ( blake14lr, cryptonight7v2, keccak, lyra2rev2, scrypt, sha256d, stribog, skein, x11, 'random' for a random algorithm per block, default is 'auto', which mines the algorithm that is expected to find a block soonest)"`
 	Generate             *bool            `long:"generate" description:"Generate (mine) bitcoins using the CPU"`
 	GenThreads           *int             `long:"genthreads" description:"Number of CPU threads to use with CPU miner -1 = all cores"`
 	MiningAddrs          *cli.StringSlice `long:"miningaddr" description:"Add the specified payment address to the list of addresses to use for generated blocks, at least one is required if generate or minerport are set"`
 	MinerListener        *string          `long:"minerlistener" description:"listen address for miner controller"`
-	MinerPass            *string          `long:"minerpass" description:"Encryption password required for miner clients to subscribe to work updates, for use over insecure connections"`
+	MinerPass            *string          `long:"minerpass" description:"Encryption password required for miner clients to subscribe to work updates, for use over insecure connections -- NOTE: Required when minerlistener is not a loopback address"`
+	BenchProfile         *string          `long:"benchprofile" description:"Hash cost profile written by pod bench, used instead of the built in hash costs to choose the algorithm for mining"`
 	BlockMinSize         *int             `long:"blockminsize" description:"Mininum block size in bytes to be used when creating a block"`
 	BlockMaxSize         *int             `long:"blockmaxsize" description:"Maximum block size in bytes to be used when creating a block"`
 	BlockMinWeight       *int             `long:"blockminweight" description:"Mininum block weight to be used when creating a block"`
@@ -114,6 +125,9 @@ type StateConfig struct {
 	Oniondial           func(string, string, time.Duration) (net.Conn, error)
 	Dial                func(string, string, time.Duration) (net.Conn, error)
 	AddedCheckpoints    []chaincfg.Checkpoint
+	ActiveAssumeValid   *chaincfg.Checkpoint
+	NoAssumeValid       bool
+	ActiveUtxoSnapshot  *chaincfg.UtxoSnapshot
 	ActiveMiningAddrs   []util.Address
 	ActiveMinerKey      []byte
 	ActiveMinRelayTxFee util.Amount
@@ -150,6 +164,7 @@ const (
 	DefaultMaxRPCClients         = 10
 	DefaultMaxRPCWebsockets      = 25
 	DefaultMaxRPCConcurrentReqs  = 20
+	DefaultMaxRPCBatchSize       = 1000
 	DefaultDbType                = "ffldb"
 	DefaultFreeTxRelayLimit      = 15.0
 	DefaultTrickleInterval       = peer.DefaultTrickleInterval
@@ -166,12 +181,14 @@ const (
 	DefaultMinerListener         = "127.0.0.1:11011"
 	DefaultMaxOrphanTransactions = 100
 	DefaultMaxOrphanTxSize       = 100000
+	DefaultMaxMempool            = 300
 	DefaultSigCacheMaxSize       = 100000
+	PruneTargetMin               = 550
 
 	// These are set to default on because more often one wants them than not
 	DefaultTxIndex   = true
 	DefaultAddrIndex = true
-	DefaultAlgo      = "random"
+	DefaultAlgo      = "auto"
 )
 
 var DefaultConfigFile = filepath.Join(
@@ -363,6 +380,81 @@ func ParseCheckpoints(
 	return checkpoints, nil
 }
 
+// ParseAssumeValid parses the assumed valid block in the '<height>:<hash>' format.  It returns a nil block for an empty string, which leaves the default of the network in place, and true when the string is 0 to disable the assumed valid block.
+func ParseAssumeValid(
+	assumeValid string,
+) (
+	*chaincfg.Checkpoint,
+	bool,
+	error,
+
+) {
+
+	switch assumeValid {
+
+	case "":
+		return nil, false, nil
+
+	case "0":
+		return nil, true, nil
+	}
+
+	checkpoint, err := NewCheckpointFromStr(assumeValid)
+
+	if err != nil {
+
+		return nil, false, err
+	}
+
+	return &checkpoint, false, nil
+}
+
+// ParseUtxoSnapshot parses a trusted utxo snapshot in the '<height>:<hash>:<set hash>' format.  It returns nil for an empty string.
+func ParseUtxoSnapshot(
+	snapshot string,
+) (
+	*chaincfg.UtxoSnapshot,
+	error,
+
+) {
+
+	if snapshot == "" {
+
+		return nil, nil
+	}
+
+	sep := strings.LastIndex(snapshot, ":")
+
+	if sep < 0 {
+
+		return nil, fmt.Errorf("unable to parse utxo snapshot %q -- use the "+
+			"syntax <height>:<hash>:<set hash>", snapshot)
+	}
+
+	checkpoint, err := NewCheckpointFromStr(snapshot[:sep])
+
+	if err != nil {
+
+		return nil, fmt.Errorf("unable to parse utxo snapshot %q -- use the "+
+			"syntax <height>:<hash>:<set hash>", snapshot)
+	}
+
+	setHash, err := chainhash.NewHashFromStr(snapshot[sep+1:])
+
+	if err != nil || len(snapshot[sep+1:]) == 0 {
+
+		return nil, fmt.Errorf("unable to parse utxo snapshot %q due to "+
+			"malformed set hash", snapshot)
+	}
+
+	return &chaincfg.UtxoSnapshot{
+
+		Height:  checkpoint.Height,
+		Hash:    checkpoint.Hash,
+		SetHash: setHash,
+	}, nil
+}
+
 // RemoveDuplicateAddresses returns a new slice with all duplicate entries in addrs removed.
 func RemoveDuplicateAddresses(
 	addrs []string,
@@ -547,6 +639,7 @@ func loadConfig() (
 		BlockMaxWeight:       DefaultBlockMaxWeight,
 		BlockPrioritySize:    mempool.DefaultBlockPrioritySize,
 		MaxOrphanTxs:         DefaultMaxOrphanTransactions,
+		MaxMempool:           DefaultMaxMempool,
 		SigCacheMaxSize:      DefaultSigCacheMaxSize,
 		Generate:             DefaultGenerate,
 		GenThreads:           1,
@@ -787,9 +880,6 @@ func loadConfig() (
 		numNets++
 		ActiveNetParams = &TestNet3Params
 
-
-		fork.IsTestnet = true
-
 	}
 
 
@@ -802,9 +892,6 @@ func loadConfig() (
 		numNets++
 		ActiveNetParams = &RegressionNetParams
 
-
-		fork.IsTestnet = true
-
 	}
 
 
@@ -819,9 +906,6 @@ func loadConfig() (
 		ActiveNetParams = &SimNetParams
 		cfg.DisableDNSSeed = true
 
-
-		fork.IsTestnet = true
-
 	}
 
 
@@ -847,7 +931,7 @@ func loadConfig() (
 
 	switch cfg.Algo {
 
-	case "blake14lr", "cryptonight7v2", "keccak", "lyra2rev2", "scrypt", "skein", "x11", "stribog", "random", "easy":
+	case "blake14lr", "cryptonight7v2", "keccak", "lyra2rev2", "scrypt", "skein", "x11", "stribog", "random", "auto", "easy":
 	default:
 		cfg.Algo = "sha256d"
 	}
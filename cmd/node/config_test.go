@@ -99,3 +99,112 @@ func TestCreateDefaultConfigFile(
 	}
 
 }
+
+func TestParseAssumeValid(
+	t *testing.T,
+
+) {
+
+	hash := "000000000000000000000000000000000000000000000000000000000000beef"
+
+	tests := []struct {
+		name     string
+		str      string
+		height   int32
+		disabled bool
+		wantErr  bool
+	}{
+		{name: "default", str: ""},
+		{name: "disabled", str: "0", disabled: true},
+		{name: "block", str: "1000:" + hash, height: 1000},
+		{name: "missing hash", str: "1000:", wantErr: true},
+		{name: "bad height", str: "x:" + hash, wantErr: true},
+		{name: "no separator", str: hash, wantErr: true},
+	}
+
+	for _, test := range tests {
+
+		checkpoint, disabled, err := ParseAssumeValid(test.str)
+
+		if (err != nil) != test.wantErr {
+
+			t.Errorf("%s: unexpected error %v", test.name, err)
+			continue
+		}
+
+		if disabled != test.disabled {
+
+			t.Errorf("%s: got disabled %v, want %v", test.name, disabled,
+				test.disabled)
+		}
+
+		if test.height == 0 {
+
+			if checkpoint != nil {
+
+				t.Errorf("%s: got block %v, want none", test.name, checkpoint)
+			}
+
+			continue
+		}
+
+		if checkpoint == nil || checkpoint.Height != test.height ||
+			checkpoint.Hash.String() != hash {
+
+			t.Errorf("%s: got block %v, want %d:%s", test.name, checkpoint,
+				test.height, hash)
+		}
+	}
+}
+
+func TestParseUtxoSnapshot(
+	t *testing.T,
+
+) {
+
+	hash := "000000000000000000000000000000000000000000000000000000000000beef"
+	setHash := "000000000000000000000000000000000000000000000000000000000000cafe"
+
+	tests := []struct {
+		name    string
+		str     string
+		height  int32
+		wantErr bool
+	}{
+		{name: "none", str: ""},
+		{name: "snapshot", str: "1000:" + hash + ":" + setHash, height: 1000},
+		{name: "missing set hash", str: "1000:" + hash + ":", wantErr: true},
+		{name: "no set hash", str: "1000:" + hash, wantErr: true},
+		{name: "bad height", str: "x:" + hash + ":" + setHash, wantErr: true},
+		{name: "bad set hash", str: "1000:" + hash + ":xyz", wantErr: true},
+	}
+
+	for _, test := range tests {
+
+		snapshot, err := ParseUtxoSnapshot(test.str)
+
+		if (err != nil) != test.wantErr {
+
+			t.Errorf("%s: unexpected error %v", test.name, err)
+			continue
+		}
+
+		if test.height == 0 {
+
+			if snapshot != nil {
+
+				t.Errorf("%s: got snapshot %v, want none", test.name, snapshot)
+			}
+
+			continue
+		}
+
+		if snapshot == nil || snapshot.Height != test.height ||
+			snapshot.Hash.String() != hash ||
+			snapshot.SetHash.String() != setHash {
+
+			t.Errorf("%s: got snapshot %v, want %d:%s:%s", test.name,
+				snapshot, test.height, hash, setHash)
+		}
+	}
+}
@@ -12,7 +12,6 @@ import (
 	cl "git.parallelcoin.io/dev/pod/pkg/util/cl"
 
 	blockchain "git.parallelcoin.io/dev/pod/pkg/chain"
-	"git.parallelcoin.io/dev/pod/pkg/chain/fork"
 	chainhash "git.parallelcoin.io/dev/pod/pkg/chain/hash"
 	"git.parallelcoin.io/dev/pod/pkg/chain/wire"
 	"git.parallelcoin.io/dev/pod/pkg/rpc/json"
@@ -373,11 +372,11 @@ func handleGetWorkSubmission(
 	msgBlock.Header.MerkleRoot = *merkles[len(merkles)-1]
 
 	// Ensure the submitted block hash is less than the target difficulty.
-	pl := fork.GetMinDiff(s.cfg.Algo, s.cfg.Chain.BestSnapshot().Height)
+	pl := s.cfg.Chain.HardForks().GetMinDiff(s.cfg.Algo, s.cfg.Chain.BestSnapshot().Height)
 
 	log <- cl.Info{"powlimit", pl}
 
-	err = blockchain.CheckProofOfWork(block, pl, s.cfg.Chain.BestSnapshot().Height)
+	err = blockchain.CheckProofOfWork(block, pl, s.cfg.Chain.BestSnapshot().Height, s.cfg.Chain.HardForks())
 
 	if err != nil {
 
@@ -9,10 +9,10 @@ import (
 	"runtime/pprof"
 
 	netparams "git.parallelcoin.io/dev/pod/pkg/chain/config/params"
-	"git.parallelcoin.io/dev/pod/pkg/chain/fork"
 	"git.parallelcoin.io/dev/pod/pkg/pod"
 	cl "git.parallelcoin.io/dev/pod/pkg/util/cl"
 	"git.parallelcoin.io/dev/pod/pkg/util/interrupt"
+	"git.parallelcoin.io/dev/pod/pkg/util/metrics"
 
 	indexers "git.parallelcoin.io/dev/pod/pkg/chain/index"
 	database "git.parallelcoin.io/dev/pod/pkg/db"
@@ -37,8 +37,6 @@ func Main(c *pod.Config, activeNet *netparams.Params, serverChan chan<- *server)
 
 	case "testnet", "testnet3", "t":
 
-		fork.IsTestnet = true
-
 		ActiveNetParams = &TestNet3Params
 
 	case "simnet", "s":
@@ -85,6 +83,23 @@ func Main(c *pod.Config, activeNet *netparams.Params, serverChan chan<- *server)
 
 	}
 
+	// Export metrics if requested.
+	if *cfg.MetricsListener != "" {
+
+		metricsServer, err := metrics.Serve(*cfg.MetricsListener)
+
+		if err != nil {
+
+			log <- cl.Error{"unable to start metrics server:", err}
+
+			return err
+		}
+
+		log <- cl.Info{"metrics server listening on", *cfg.MetricsListener}
+
+		defer metricsServer.Close()
+	}
+
 	// Write cpu profile if requested.
 	if *cfg.CPUProfile != "" {
 
@@ -1,6 +1,7 @@
 package mempool
 
 import (
+	"container/heap"
 	"container/list"
 	"fmt"
 	"math"
@@ -69,6 +70,10 @@ type Policy struct {
 	MaxSigOpCostPerTx int
 	// MinRelayTxFee defines the minimum transaction fee in DUO/kB to be considered a non-zero fee.
 	MinRelayTxFee util.Amount
+	// MaxPoolSize is the maximum estimated memory usage in bytes of the transactions in the main pool. When it is exceeded the transactions with the lowest fee rate, counting their descendants, are evicted. Zero means no limit.
+	MaxPoolSize int64
+	// RejectReplacement defines whether to reject transactions that spend outputs already spent by transactions in the pool, even when those signal that they may be replaced as described by BIP 125.
+	RejectReplacement bool
 }
 
 // Tag represents an identifier to use for tagging orphan transactions.  The caller may choose any scheme it desires, however it is common to use peer IDs so that orphans can be identified by which peer first relayed them.
@@ -81,6 +86,9 @@ type TxDesc struct {
 	mining.TxDesc
 	// StartingPriority is the priority of the transaction when it was added to the pool.
 	StartingPriority float64
+	// descendantFees and descendantSize are the fees and the virtual size of the transaction together with all of its descendants in the pool, which are kept up to date as transactions are added and removed so the pool can be trimmed without walking the descendants of every transaction.
+	descendantFees int64
+	descendantSize int64
 }
 
 // TxPool is used as a source of transactions that need to be mined into blocks and relayed to other peers.  It is safe for concurrent access from multiple peers.
@@ -99,6 +107,12 @@ type TxPool struct {
 	lastPennyUnix int64   // unix time of last ``penny spend''
 	// nextExpireScan is the time after which the orphan pool will be scanned in order to evict orphans.  This is NOT a hard deadline as the scan will only run when an orphan is added to the pool as opposed to on an unconditional timer.
 	nextExpireScan time.Time
+	// usage is the estimated memory usage in bytes of the transactions in the main pool.
+	usage int64
+	// rollingMinFee is the fee rate in Satoshi/kB that new transactions must pay since transactions were evicted from the full pool. It decays by half every rollingFeeHalfLife.
+	rollingMinFee float64
+	// lastFeeUpdate is the time rollingMinFee was last raised or decayed.
+	lastFeeUpdate time.Time
 }
 
 // orphanTx is normal transaction that references an ancestor transaction that is not yet available.  It also contains additional information related to it such as an expiration time to help prevent caching the orphan forever.
@@ -109,6 +123,17 @@ type orphanTx struct {
 	expiration time.Time
 }
 
+// evictionCandidate is a transaction in the pool with the descendant fee rate it was ranked by when the pool was trimmed.
+
+type evictionCandidate struct {
+	desc  *TxDesc
+	score float64
+}
+
+// evictionHeap is a min-heap of eviction candidates ordered by their descendant fee rate, so the pool can be trimmed without sorting all of its transactions.
+
+type evictionHeap []evictionCandidate
+
 const (
 	// DefaultBlockPrioritySize is the default size in bytes for high- priority / low-fee transactions.  It is used to help determine which are allowed into the mempool and consequently affects their relay and inclusion when generating block templates.
 	DefaultBlockPrioritySize = 50000
@@ -116,6 +141,13 @@ const (
 	orphanTTL = time.Minute * 15
 	// orphanExpireScanInterval is the minimum amount of time in between scans of the orphan pool to evict expired transactions.
 	orphanExpireScanInterval = time.Minute * 5
+	// rollingFeeHalfLife is the time it takes for the minimum fee raised by evictions to fall by half.  It is shorter while the pool is less than half full, as the spam that filled it has then mostly been mined.
+	rollingFeeHalfLife = time.Hour * 2
+	// txUsageOverhead and txInUsageOverhead estimate the memory used by a transaction in the pool in addition to its serialized size, for the descriptor and index entries of the transaction and of each of its inputs.
+	txUsageOverhead   = 512
+	txInUsageOverhead = 160
+	// MaxReplacementEvictions is the maximum number of transactions, counting their descendants, that a replacement transaction may evict from the pool.
+	MaxReplacementEvictions = 100
 )
 
 // Ensure the TxPool type implements the mining.TxSource interface.
@@ -203,6 +235,13 @@ func (
 	return time.Unix(atomic.LoadInt64(&mp.lastUpdated), 0)
 }
 
+// MaxSize returns the maximum estimated memory usage in bytes of the transactions in the main pool, or zero if the pool is not limited.
+func (
+	mp *TxPool,
+) MaxSize() int64 {
+	return mp.cfg.Policy.MaxPoolSize
+}
+
 // MaybeAcceptTransaction is the main workhorse for handling insertion of new free-standing transactions into a memory pool.  It includes functionality such as rejecting duplicate transactions, ensuring transactions follow all rules, detecting orphan transactions, and insertion into the memory pool. If the transaction is an orphan (missing parent transactions), the transaction is NOT added to the orphan pool, but each unknown referenced parent is returned.  Use ProcessTransaction instead if new orphans should be added to the orphan pool. This function is safe for concurrent access.
 func (
 	mp *TxPool,
@@ -216,6 +255,106 @@ func (
 	return hashes, txD, err
 }
 
+// MempoolEntry returns a data structure with the fee, size and time of the transaction with the given hash in the main pool along with the number, size and fees of its in-pool ancestors and descendants.  The counts, sizes and fees of the ancestors and descendants include the transaction itself. This function is safe for concurrent access.
+func (
+	mp *TxPool,
+) MempoolEntry(
+	hash *chainhash.Hash) (*json.GetMempoolEntryResult, error) {
+
+	mp.mtx.RLock()
+	defer mp.mtx.RUnlock()
+	desc, exists := mp.pool[*hash]
+
+	if !exists {
+
+		return nil, fmt.Errorf("transaction %v is not in the pool", hash)
+	}
+	tx := desc.Tx
+	var currentPriority float64
+	utxos, err := mp.fetchInputUtxos(tx)
+
+	if err == nil {
+
+		currentPriority = mining.CalcPriority(tx.MsgTx(), utxos,
+			desc.Height+1)
+	}
+	vsize := GetTxVirtualSize(tx)
+	fee := util.Amount(desc.Fee).ToDUO()
+	entry := &json.GetMempoolEntryResult{
+		Size:             int32(vsize),
+		VSize:            int32(vsize),
+		Weight:           int32(blockchain.GetTransactionWeight(tx)),
+		Fee:              fee,
+		ModifiedFee:      fee,
+		Time:             desc.Added.Unix(),
+		Height:           int64(desc.Height),
+		StartingPriority: desc.StartingPriority,
+		CurrentPriority:  currentPriority,
+		WTxID:            tx.WitnessHash().String(),
+		Depends:          make([]string, 0),
+		SpentBy:          make([]string, 0),
+	}
+	ancestorSize, ancestorFees := vsize, desc.Fee
+	replaceable := signalsReplacement(tx)
+
+	for _, ancestor := range mp.txAncestors(tx) {
+
+		ancestorSize += GetTxVirtualSize(ancestor.Tx)
+		ancestorFees += ancestor.Fee
+		replaceable = replaceable || signalsReplacement(ancestor.Tx)
+		entry.AncestorCount++
+	}
+	descendantSize, descendantFees := vsize, desc.Fee
+
+	for _, descendant := range mp.txDescendants(tx) {
+
+		descendantSize += GetTxVirtualSize(descendant.Tx)
+		descendantFees += descendant.Fee
+		entry.DescendantCount++
+	}
+	entry.AncestorCount++
+	entry.AncestorSize = ancestorSize
+	entry.AncestorFees = float64(ancestorFees)
+	entry.DescendantCount++
+	entry.DescendantSize = descendantSize
+	entry.DescendantFees = float64(descendantFees)
+	entry.BIP125Replaceable = replaceable
+	entry.Fees = json.MempoolFees{
+		Base:       fee,
+		Modified:   fee,
+		Ancestor:   util.Amount(ancestorFees).ToDUO(),
+		Descendant: util.Amount(descendantFees).ToDUO(),
+	}
+	seen := make(map[chainhash.Hash]struct{})
+
+	for _, txIn := range tx.MsgTx().TxIn {
+
+		parentHash := txIn.PreviousOutPoint.Hash
+
+		if _, ok := seen[parentHash]; ok {
+
+			continue
+		}
+
+		if mp.haveTransaction(&parentHash) {
+
+			seen[parentHash] = struct{}{}
+			entry.Depends = append(entry.Depends, parentHash.String())
+		}
+	}
+
+	for i := range tx.MsgTx().TxOut {
+
+		prevOut := wire.OutPoint{Hash: *hash, Index: uint32(i)}
+
+		if spender, exists := mp.outpoints[prevOut]; exists {
+
+			entry.SpentBy = append(entry.SpentBy, spender.Hash().String())
+		}
+	}
+	return entry, nil
+}
+
 // MiningDescs returns a slice of mining descriptors for all the transactions in the pool. This is part of the mining.TxSource interface implementation and is safe for concurrent access as required by the interface contract.
 func (
 	mp *TxPool,
@@ -233,6 +372,29 @@ func (
 	return descs
 }
 
+// MinFee returns the minimum fee rate in Satoshi/kB a new transaction must pay to be accepted into the pool.  This is the minimum relay fee, unless transactions have been evicted from the full pool recently. This function is safe for concurrent access.
+func (
+	mp *TxPool,
+) MinFee() util.Amount {
+	mp.mtx.Lock()
+	minFee := mp.rollingMinFeeRate(time.Now())
+	mp.mtx.Unlock()
+
+	if minFee < mp.cfg.Policy.MinRelayTxFee {
+
+		return mp.cfg.Policy.MinRelayTxFee
+	}
+	return minFee
+}
+
+// IncrementalRelayFee returns the fee rate in Satoshi/kB a transaction must pay on top of the transactions it replaces or the pool evicts to make room for it.  The pool uses the minimum relay fee for this. This function is safe for concurrent access.
+func (
+	mp *TxPool,
+) IncrementalRelayFee() util.Amount {
+
+	return mp.cfg.Policy.MinRelayTxFee
+}
+
 // ProcessOrphans determines if there are any orphans which depend on the passed transaction hash (it is possible that they are no longer orphans) and potentially accepts them to the memory pool.  It repeats the process for the newly accepted transactions (to detect further orphans which may no longer be orphans) until there are no more. It returns a slice of transactions added to the mempool.  A nil slice means no transactions were moved from the orphan pool to the mempool. This function is safe for concurrent access.
 func (
 	mp *TxPool,
@@ -439,6 +601,36 @@ func (
 	return hashes
 }
 
+// Usage returns the estimated memory usage in bytes of the transactions in the main pool.  It does not include the orphan pool. This function is safe for concurrent access.
+func (
+	mp *TxPool,
+) Usage() int64 {
+	mp.mtx.RLock()
+	usage := mp.usage
+	mp.mtx.RUnlock()
+	return usage
+}
+
+// addDescendantStats initialises the descendant fees and size of a transaction that was just added to the pool and adds them to those of its ancestors.  A transaction usually has no descendants when it is added, but one that returns to the pool from a disconnected block may already be spent by transactions in the pool, and the descendants of its ancestors are then counted again from scratch so that none are counted twice. This function MUST be called with the mempool lock held (for writes).
+func (
+	mp *TxPool,
+) addDescendantStats(
+	txD *TxDesc) {
+
+	descendants := mp.calcDescendantStats(txD)
+
+	for _, ancestor := range mp.txAncestors(txD.Tx) {
+
+		if descendants > 0 {
+
+			mp.calcDescendantStats(ancestor)
+			continue
+		}
+		ancestor.descendantFees += txD.descendantFees
+		ancestor.descendantSize += txD.descendantSize
+	}
+}
+
 // addOrphan adds an orphan transaction to the orphan pool. This function MUST be called with the mempool lock held (for writes).
 func (
 	mp *TxPool,
@@ -498,11 +690,13 @@ func (
 		StartingPriority: mining.CalcPriority(tx.MsgTx(), utxoView, height),
 	}
 	mp.pool[*tx.Hash()] = txD
+	mp.usage += txUsage(tx)
 
 	for _, txIn := range tx.MsgTx().TxIn {
 
 		mp.outpoints[txIn.PreviousOutPoint] = tx
 	}
+	mp.addDescendantStats(txD)
 	atomic.StoreInt64(&mp.lastUpdated, time.Now().Unix())
 	// Add unconfirmed address index entries associated with the transaction if enabled.
 
@@ -519,23 +713,56 @@ func (
 	return txD
 }
 
-// checkPoolDoubleSpend checks whether or not the passed transaction is attempting to spend coins already spent by other transactions in the pool. Note it does not check for double spends against transactions already in the main chain. This function MUST be called with the mempool lock held (for reads).
+// calcDescendantStats sets the descendant fees and size of the passed transaction from those of all of its descendants in the pool, and returns the number of descendants. This function MUST be called with the mempool lock held (for writes).
+func (
+	mp *TxPool,
+) calcDescendantStats(
+	desc *TxDesc) int {
+
+	desc.descendantFees = desc.Fee
+	desc.descendantSize = GetTxVirtualSize(desc.Tx)
+	descendants := mp.txDescendants(desc.Tx)
+
+	for _, descendant := range descendants {
+
+		desc.descendantFees += descendant.Fee
+		desc.descendantSize += GetTxVirtualSize(descendant.Tx)
+	}
+	return len(descendants)
+}
+
+// checkPoolDoubleSpend checks whether or not the passed transaction is attempting to spend coins already spent by other transactions in the pool, and returns those transactions if they may all be replaced by it as described by BIP 125. Note it does not check for double spends against transactions already in the main chain. This function MUST be called with the mempool lock held (for reads).
 func (
 	mp *TxPool,
 ) checkPoolDoubleSpend(
-	tx *util.Tx) error {
+	tx *util.Tx) (map[chainhash.Hash]*TxDesc, error) {
+
+	var conflicts map[chainhash.Hash]*TxDesc
 
 	for _, txIn := range tx.MsgTx().TxIn {
 
-		if txR, exists := mp.outpoints[txIn.PreviousOutPoint]; exists {
+		txR, exists := mp.outpoints[txIn.PreviousOutPoint]
+
+		if !exists {
+
+			continue
+		}
+
+		if mp.cfg.Policy.RejectReplacement || !mp.isReplaceable(txR) {
 
 			str := fmt.Sprintf("output %v already spent by "+
 				"transaction %v in the memory pool",
 				txIn.PreviousOutPoint, txR.Hash())
-			return txRuleError(wire.RejectDuplicate, str)
+			return nil, txRuleError(wire.RejectDuplicate, str)
+		}
+
+		if conflicts == nil {
+
+			conflicts = make(map[chainhash.Hash]*TxDesc)
 		}
+		conflicts[*txR.Hash()] = mp.pool[*txR.Hash()]
 	}
-	return nil
+	return conflicts, nil
 }
 
 // fetchInputUtxos loads utxo details about the input transactions referenced by the passed transaction.  First, it loads the details form the viewpoint of the main chain, then it adjusts them based upon the contents of the transaction pool. This function MUST be called with the mempool lock held (for reads).
@@ -606,6 +833,27 @@ func (
 	return false
 }
 
+// isReplaceable returns whether the passed transaction in the main pool may be replaced as described by BIP 125, which is the case when it or any of its ancestors in the pool signals replacement. This function MUST be called with the mempool lock held (for reads).
+func (
+	mp *TxPool,
+) isReplaceable(
+	tx *util.Tx) bool {
+
+	if signalsReplacement(tx) {
+
+		return true
+	}
+
+	for _, ancestor := range mp.txAncestors(tx) {
+
+		if signalsReplacement(ancestor.Tx) {
+
+			return true
+		}
+	}
+	return false
+}
+
 // limitNumOrphans limits the number of orphan transactions by evicting a random orphan if adding a new one would cause it to overflow the max allowed. This function MUST be called with the mempool lock held (for writes).
 func (
 	mp *TxPool,
@@ -734,8 +982,8 @@ func (
 			return nil, nil, txRuleError(rejectCode, str)
 		}
 	}
-	// The transaction may not use any of the same outputs as other transactions already in the pool as that would ultimately result in a double spend.  This check is intended to be quick and therefore only detects double spends within the transaction pool itself.  The transaction could still be double spending coins from the main chain at this point.  There is a more in-depth check that happens later after fetching the referenced transaction inputs from the main chain which examines the actual spend data and prevents double spends.
-	err = mp.checkPoolDoubleSpend(tx)
+	// The transaction may not use any of the same outputs as other transactions already in the pool as that would ultimately result in a double spend, unless it replaces all of those transactions.  This check is intended to be quick and therefore only detects double spends within the transaction pool itself.  The transaction could still be double spending coins from the main chain at this point.  There is a more in-depth check that happens later after fetching the referenced transaction inputs from the main chain which examines the actual spend data and prevents double spends.
+	conflicts, err := mp.checkPoolDoubleSpend(tx)
 
 	if err != nil {
 
@@ -865,6 +1113,32 @@ func (
 			minFee)
 		return nil, nil, txRuleError(wire.RejectInsufficientFee, str)
 	}
+	// Once transactions have been evicted from the full pool, new transactions must pay more than the evicted ones did, so that they do not just take the place of transactions of equal value and get evicted in turn.  Transactions which are being added back to the memory pool from blocks that have been disconnected during a reorg are exempted.
+
+	if isNew {
+
+		poolMinFee := calcMinRequiredTxRelayFee(serializedSize,
+			mp.rollingMinFeeRate(time.Now()))
+
+		if txFee < poolMinFee {
+
+			str := fmt.Sprintf("transaction %v has %d fees which is under "+
+				"the mempool minimum fee of %d", txHash, txFee,
+				poolMinFee)
+			return nil, nil, txRuleError(wire.RejectInsufficientFee, str)
+		}
+	}
+	// A transaction that double spends transactions in the pool must pay enough more than them to be worth relaying in their place.
+
+	if len(conflicts) > 0 {
+
+		err = mp.validateReplacement(tx, txFee, conflicts)
+
+		if err != nil {
+
+			return nil, nil, err
+		}
+	}
 	// Require that free transactions have sufficient priority to be mined in the next block.  Transactions which are being added back to the memory pool from blocks that have been disconnected during a reorg are exempted.
 
 	if isNew && !mp.cfg.Policy.DisableRelayPriority && txFee < minFee {
@@ -921,8 +1195,34 @@ func (
 		}
 		return nil, nil, err
 	}
+	// Evict the transactions being replaced, together with their descendants, before adding the replacement so that it takes over the outputs they spent.
+
+	for _, conflict := range conflicts {
+
+		mp.removeTransaction(conflict.Tx, true)
+
+		log <- cl.Debugf{
+
+			"replaced transaction %v with %v",
+			conflict.Tx.Hash(),
+			txHash,
+		}
+	}
 	// Add to transaction pool.
 	txD := mp.addTransaction(utxoView, tx, bestHeight, txFee)
+	// Make room for the transaction if the pool has grown over its size limit.  It may turn out to be the cheapest transaction in the pool itself.
+
+	if mp.cfg.Policy.MaxPoolSize > 0 && mp.usage > mp.cfg.Policy.MaxPoolSize {
+
+		mp.trimToSize(time.Now())
+
+		if !mp.isTransactionInPool(txHash) {
+
+			str := fmt.Sprintf("transaction %v does not pay enough fees "+
+				"to stay in the full mempool", txHash)
+			return nil, nil, txRuleError(wire.RejectInsufficientFee, str)
+		}
+	}
 
 	log <- cl.Debugf{
 
@@ -1020,6 +1320,24 @@ func (
 	return acceptedTxns
 }
 
+// removeDescendantStats takes a transaction that was just removed from the pool out of the descendant fees and size of its ancestors.  When descendants of the transaction stay in the pool, as when it is mined, they may no longer descend from its ancestors, whose descendants are then counted again from scratch. This function MUST be called with the mempool lock held (for writes).
+func (
+	mp *TxPool,
+) removeDescendantStats(
+	txD *TxDesc, ancestors []*TxDesc, relink bool) {
+
+	for _, ancestor := range ancestors {
+
+		if relink {
+
+			mp.calcDescendantStats(ancestor)
+			continue
+		}
+		ancestor.descendantFees -= txD.Fee
+		ancestor.descendantSize -= GetTxVirtualSize(txD.Tx)
+	}
+}
+
 // removeOrphan is the internal function which implements the public RemoveOrphan.  See the comment for RemoveOrphan for more details. This function MUST be called with the mempool lock held (for writes).
 func (
 	mp *TxPool,
@@ -1122,15 +1440,311 @@ func (
 		}
 		// Mark the referenced outpoints as unspent by the pool.
 
+		ancestors := mp.txAncestors(tx)
+		relink := len(ancestors) > 0 && len(mp.txDescendants(tx)) > 0
+
 		for _, txIn := range txDesc.Tx.MsgTx().TxIn {
 
 			delete(mp.outpoints, txIn.PreviousOutPoint)
 		}
 		delete(mp.pool, *txHash)
+		mp.usage -= txUsage(txDesc.Tx)
+		mp.removeDescendantStats(txDesc, ancestors, relink)
 		atomic.StoreInt64(&mp.lastUpdated, time.Now().Unix())
 	}
 }
 
+// rollingMinFeeRate decays the minimum fee rate raised by evictions and returns it. It is dropped altogether once it has fallen below half the minimum relay fee. This function MUST be called with the mempool lock held (for writes).
+func (
+	mp *TxPool,
+) rollingMinFeeRate(
+	now time.Time) util.Amount {
+
+	if mp.rollingMinFee == 0 {
+
+		return 0
+	}
+	halfLife := rollingFeeHalfLife
+
+	if maxSize := mp.cfg.Policy.MaxPoolSize; mp.usage < maxSize/4 {
+
+		halfLife /= 4
+	} else if mp.usage < maxSize/2 {
+
+		halfLife /= 2
+	}
+	mp.rollingMinFee /= math.Pow(2, now.Sub(mp.lastFeeUpdate).Seconds()/
+		halfLife.Seconds())
+	mp.lastFeeUpdate = now
+
+	if mp.rollingMinFee < 1 ||
+		mp.rollingMinFee < float64(mp.cfg.Policy.MinRelayTxFee)/2 {
+
+		mp.rollingMinFee = 0
+	}
+	return util.Amount(mp.rollingMinFee)
+}
+
+// trimToSize evicts the transactions with the lowest descendant fee rate, together with their descendants, until the pool is within its size limit, and raises the minimum fee rate for new transactions above that of the evicted ones. The descendant fee rate of a transaction is the greater of its own fee rate and that of the transaction together with its descendants, so that cheap descendants are evicted on their own before a transaction that pays well itself, while a transaction that a descendant pays for is kept.  The candidates are ranked once in a heap from the descendant fees and sizes kept with each transaction, and a candidate whose descendants were evicted before it is ranked again when it comes up. This function MUST be called with the mempool lock held (for writes).
+func (
+	mp *TxPool,
+) trimToSize(
+	now time.Time) {
+
+	candidates := make(evictionHeap, 0, len(mp.pool))
+
+	for _, desc := range mp.pool {
+
+		candidates = append(candidates, evictionCandidate{desc, descendantScore(desc)})
+	}
+	heap.Init(&candidates)
+	var evicted int
+	var maxScore float64
+
+	for mp.usage > mp.cfg.Policy.MaxPoolSize && candidates.Len() > 0 {
+
+		c := heap.Pop(&candidates).(evictionCandidate)
+
+		// Transactions evicted as descendants of an earlier candidate are gone already.
+		if !mp.isTransactionInPool(c.desc.Tx.Hash()) {
+
+			continue
+		}
+
+		// The descendant fee rate changes when descendants were evicted.
+		if score := descendantScore(c.desc); score != c.score {
+
+			heap.Push(&candidates, evictionCandidate{c.desc, score})
+			continue
+		}
+		before := len(mp.pool)
+		mp.removeTransaction(c.desc.Tx, true)
+		evicted += before - len(mp.pool)
+
+		if c.score > maxScore {
+
+			maxScore = c.score
+		}
+	}
+
+	if evicted == 0 {
+
+		return
+	}
+	// Require new transactions to pay at least the minimum relay fee more than the evicted ones, so each eviction makes room for a transaction that is worth more.
+	mp.rollingMinFeeRate(now)
+
+	if minFee := maxScore + float64(mp.cfg.Policy.MinRelayTxFee); minFee > mp.rollingMinFee {
+
+		mp.rollingMinFee = minFee
+	}
+	mp.lastFeeUpdate = now
+
+	log <- cl.Debugf{
+
+		"evicted %d %s from the full mempool, minimum fee is now %v Satoshi/kB",
+		evicted,
+		pickNoun(evicted, "transaction", "transactions"),
+		int64(mp.rollingMinFee),
+	}
+}
+
+// txAncestors returns the descriptors of all transactions in the main pool that the passed transaction spends outputs of, directly or through other transactions in the pool. This function MUST be called with the mempool lock held (for reads).
+func (
+	mp *TxPool,
+) txAncestors(
+	tx *util.Tx) []*TxDesc {
+
+	var ancestors []*TxDesc
+	seen := make(map[chainhash.Hash]struct{})
+	queue := []*util.Tx{tx}
+
+	for len(queue) > 0 {
+
+		next := queue[0]
+		queue = queue[1:]
+
+		for _, txIn := range next.MsgTx().TxIn {
+
+			parentHash := txIn.PreviousOutPoint.Hash
+
+			if _, ok := seen[parentHash]; ok {
+
+				continue
+			}
+			parent, exists := mp.pool[parentHash]
+
+			if !exists {
+
+				continue
+			}
+			seen[parentHash] = struct{}{}
+			ancestors = append(ancestors, parent)
+			queue = append(queue, parent.Tx)
+		}
+	}
+	return ancestors
+}
+
+// txDescendants returns the descriptors of all transactions in the main pool that spend outputs of the passed transaction, directly or through other transactions in the pool. This function MUST be called with the mempool lock held (for reads).
+func (
+	mp *TxPool,
+) txDescendants(
+	tx *util.Tx) []*TxDesc {
+
+	var descendants []*TxDesc
+	seen := make(map[chainhash.Hash]struct{})
+	queue := []*util.Tx{tx}
+
+	for len(queue) > 0 {
+
+		next := queue[0]
+		queue = queue[1:]
+		prevOut := wire.OutPoint{Hash: *next.Hash()}
+
+		for i := range next.MsgTx().TxOut {
+
+			prevOut.Index = uint32(i)
+			spender, exists := mp.outpoints[prevOut]
+
+			if !exists {
+
+				continue
+			}
+			spenderHash := *spender.Hash()
+
+			if _, ok := seen[spenderHash]; ok {
+
+				continue
+			}
+			child, exists := mp.pool[spenderHash]
+
+			if !exists {
+
+				continue
+			}
+			seen[spenderHash] = struct{}{}
+			descendants = append(descendants, child)
+			queue = append(queue, child.Tx)
+		}
+	}
+	return descendants
+}
+
+// signalsReplacement returns whether the transaction signals that it may be replaced by a transaction paying a higher fee as described by BIP 125, which is the case when any of its inputs has a sequence number below 0xfffffffe.
+func signalsReplacement(
+	tx *util.Tx) bool {
+
+	for _, txIn := range tx.MsgTx().TxIn {
+
+		if txIn.Sequence < wire.MaxTxInSequenceNum-1 {
+
+			return true
+		}
+	}
+	return false
+}
+
+// txUsage returns the estimated memory usage in bytes of a transaction in the main pool.
+func txUsage(
+	tx *util.Tx) int64 {
+
+	msgTx := tx.MsgTx()
+	return int64(msgTx.SerializeSize()) + txUsageOverhead +
+		int64(len(msgTx.TxIn))*txInUsageOverhead
+}
+
+// validateReplacement checks that the passed transaction, which pays txFee and double spends the passed conflicting transactions in the pool, may replace them as described by BIP 125.  It may not evict more than MaxReplacementEvictions transactions counting the descendants of the conflicts, it may not spend outputs of the transactions it evicts nor unconfirmed outputs that none of the conflicts spent already, it must pay a higher fee rate than each conflict and it must pay at least the fees of all the evicted transactions plus the minimum relay fee for its own size. This function MUST be called with the mempool lock held (for reads).
+func (
+	mp *TxPool,
+) validateReplacement(
+	tx *util.Tx, txFee int64, conflicts map[chainhash.Hash]*TxDesc) error {
+
+	txHash := tx.Hash()
+	evicted := make(map[chainhash.Hash]*TxDesc)
+	conflictParents := make(map[chainhash.Hash]struct{})
+
+	for hash, conflict := range conflicts {
+
+		evicted[hash] = conflict
+
+		for _, descendant := range mp.txDescendants(conflict.Tx) {
+
+			evicted[*descendant.Tx.Hash()] = descendant
+		}
+
+		for _, txIn := range conflict.Tx.MsgTx().TxIn {
+
+			conflictParents[txIn.PreviousOutPoint.Hash] = struct{}{}
+		}
+	}
+
+	if len(evicted) > MaxReplacementEvictions {
+
+		str := fmt.Sprintf("replacement transaction %v evicts %d "+
+			"transactions, more than the limit of %d", txHash,
+			len(evicted), MaxReplacementEvictions)
+		return txRuleError(wire.RejectNonstandard, str)
+	}
+
+	for _, txIn := range tx.MsgTx().TxIn {
+
+		parentHash := txIn.PreviousOutPoint.Hash
+
+		if _, ok := evicted[parentHash]; ok {
+
+			str := fmt.Sprintf("replacement transaction %v spends "+
+				"output %v of a transaction it replaces", txHash,
+				txIn.PreviousOutPoint)
+			return txRuleError(wire.RejectInvalid, str)
+		}
+
+		if _, ok := conflictParents[parentHash]; ok {
+
+			continue
+		}
+
+		if mp.isTransactionInPool(&parentHash) {
+
+			str := fmt.Sprintf("replacement transaction %v spends new "+
+				"unconfirmed output %v", txHash, txIn.PreviousOutPoint)
+			return txRuleError(wire.RejectNonstandard, str)
+		}
+	}
+	size := GetTxVirtualSize(tx)
+	feePerKB := txFee * 1000 / size
+
+	for hash, conflict := range conflicts {
+
+		if feePerKB <= conflict.FeePerKB {
+
+			str := fmt.Sprintf("replacement transaction %v has a fee "+
+				"rate of %d which is not higher than the %d of "+
+				"transaction %v", txHash, feePerKB, conflict.FeePerKB,
+				hash)
+			return txRuleError(wire.RejectInsufficientFee, str)
+		}
+	}
+	var evictedFees int64
+
+	for _, desc := range evicted {
+
+		evictedFees += desc.Fee
+	}
+	minFee := evictedFees + calcMinRequiredTxRelayFee(size,
+		mp.cfg.Policy.MinRelayTxFee)
+
+	if txFee < minFee {
+
+		str := fmt.Sprintf("replacement transaction %v has %d fees "+
+			"which is under the required amount of %d to replace %d "+
+			"%s", txHash, txFee, minFee, len(evicted),
+			pickNoun(len(evicted), "transaction", "transactions"))
+		return txRuleError(wire.RejectInsufficientFee, str)
+	}
+	return nil
+}
+
 // New returns a new memory pool for validating and storing standalone transactions until they are mined into a block.
 func New(
 	cfg *Config) *TxPool {
@@ -1143,3 +1757,52 @@ func New(
 		outpoints:      make(map[wire.OutPoint]*util.Tx),
 	}
 }
+
+// descendantScore returns the descendant fee rate of a transaction in the pool that trimToSize ranks it by.
+func descendantScore(
+	desc *TxDesc) float64 {
+
+	score := float64(desc.descendantFees) * 1000 / float64(desc.descendantSize)
+
+	if own := float64(desc.FeePerKB); own > score {
+
+		score = own
+	}
+	return score
+}
+func (
+	h evictionHeap,
+) Len() int {
+
+	return len(h)
+}
+func (
+	h evictionHeap,
+) Less(
+	i, j int) bool {
+	return h[i].score < h[j].score
+}
+func (
+	h evictionHeap,
+) Swap(
+	i, j int) {
+
+	h[i], h[j] = h[j], h[i]
+}
+func (
+	h *evictionHeap,
+) Push(
+	x interface{}) {
+
+	*h = append(*h, x.(evictionCandidate))
+}
+func (
+	h *evictionHeap,
+) Pop() interface{} {
+
+	old := *h
+	n := len(old)
+	c := old[n-1]
+	*h = old[:n-1]
+	return c
+}
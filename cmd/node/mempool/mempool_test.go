@@ -164,8 +164,21 @@ func (p *poolHarness) CreateCoinbaseTx(blockHeight int32, numOutputs uint32) (*u
 // CreateSignedTx creates a new signed transaction that consumes the provided inputs and generates the provided number of outputs by evenly splitting the total input amount.  All outputs will be to the payment script associated with the harness and all inputs are assumed to do the same.
 func (p *poolHarness) CreateSignedTx(inputs []spendableOutput, numOutputs uint32) (*util.Tx, error) {
 
-	// Calculate the total input amount and split it amongst the requested number of outputs.
-	var totalInput util.Amount
+	return p.CreateSignedTxWithFee(inputs, numOutputs, 0)
+}
+
+// CreateSignedTxWithFee creates a new signed transaction like CreateSignedTx, but splits the total input amount less the provided fee amongst the outputs.
+func (p *poolHarness) CreateSignedTxWithFee(inputs []spendableOutput, numOutputs uint32, fee util.Amount) (*util.Tx, error) {
+
+	return p.CreateSignedTxWithSequence(inputs, numOutputs, fee,
+		wire.MaxTxInSequenceNum)
+}
+
+// CreateSignedTxWithSequence creates a new signed transaction like CreateSignedTxWithFee, with the provided sequence number on all of its inputs.
+func (p *poolHarness) CreateSignedTxWithSequence(inputs []spendableOutput, numOutputs uint32, fee util.Amount, sequence uint32) (*util.Tx, error) {
+
+	// Calculate the total input amount less the fee and split it amongst the requested number of outputs.
+	totalInput := -fee
 
 	for _, input := range inputs {
 		totalInput += input.amount
@@ -178,7 +191,7 @@ func (p *poolHarness) CreateSignedTx(inputs []spendableOutput, numOutputs uint32
 		tx.AddTxIn(&wire.TxIn{
 			PreviousOutPoint: input.outPoint,
 			SignatureScript:  nil,
-			Sequence:         wire.MaxTxInSequenceNum,
+			Sequence:         sequence,
 		})
 	}
 
@@ -467,7 +480,7 @@ func TestOrphanReject(
 
 		if len(acceptedTxns) != 0 {
 
-			t.Fatal("ProcessTransaction: reported %d accepted "+
+			t.Fatalf("ProcessTransaction: reported %d accepted "+
 				"transactions from failed orphan attempt",
 				len(acceptedTxns))
 		}
@@ -826,3 +839,391 @@ func TestCheckSpend(
 		t.Fatalf("Unexpeced spend found in pool: %v", spend)
 	}
 }
+
+// TestMempoolEntry ensures that the ancestors and descendants of a transaction in the pool are reported correctly.
+func TestMempoolEntry(
+	t *testing.T) {
+
+	t.Parallel()
+	harness, outputs, err := newPoolHarness(&chaincfg.MainNetParams)
+
+	if err != nil {
+		t.Fatalf("unable to create test pool: %v", err)
+	}
+	// Create a chain of transactions and add them to the pool, so that the middle transaction has one ancestor and one descendant.
+	chainedTxns, err := harness.CreateTxChain(outputs[0], 3)
+
+	if err != nil {
+		t.Fatalf("unable to create transaction chain: %v", err)
+	}
+
+	for _, tx := range chainedTxns {
+		_, err := harness.txPool.ProcessTransaction(tx, true,
+			false, 0)
+
+		if err != nil {
+			t.Fatalf("ProcessTransaction: failed to accept "+
+				"tx: %v", err)
+		}
+	}
+	entry, err := harness.txPool.MempoolEntry(chainedTxns[1].Hash())
+
+	if err != nil {
+		t.Fatalf("MempoolEntry: unexpected error: %v", err)
+	}
+
+	if entry.AncestorCount != 2 || entry.DescendantCount != 2 {
+		t.Fatalf("MempoolEntry: got %d ancestors and %d descendants, "+
+			"want 2 and 2", entry.AncestorCount, entry.DescendantCount)
+	}
+	vsize := GetTxVirtualSize(chainedTxns[1])
+
+	if entry.VSize != int32(vsize) {
+		t.Fatalf("MempoolEntry: got vsize %d, want %d", entry.VSize,
+			vsize)
+	}
+	wantAncestorSize := vsize + GetTxVirtualSize(chainedTxns[0])
+
+	if entry.AncestorSize != wantAncestorSize {
+		t.Fatalf("MempoolEntry: got ancestor size %d, want %d",
+			entry.AncestorSize, wantAncestorSize)
+	}
+
+	if len(entry.Depends) != 1 ||
+		entry.Depends[0] != chainedTxns[0].Hash().String() {
+		t.Fatalf("MempoolEntry: unexpected depends %v", entry.Depends)
+	}
+
+	if len(entry.SpentBy) != 1 ||
+		entry.SpentBy[0] != chainedTxns[2].Hash().String() {
+		t.Fatalf("MempoolEntry: unexpected spentby %v", entry.SpentBy)
+	}
+
+	if entry.BIP125Replaceable {
+		t.Fatalf("MempoolEntry: transaction with final sequence " +
+			"numbers reported as replaceable")
+	}
+	// The last transaction in the chain has both transactions before it as ancestors.
+	entry, err = harness.txPool.MempoolEntry(chainedTxns[2].Hash())
+
+	if err != nil {
+		t.Fatalf("MempoolEntry: unexpected error: %v", err)
+	}
+
+	if entry.AncestorCount != 3 || entry.DescendantCount != 1 {
+		t.Fatalf("MempoolEntry: got %d ancestors and %d descendants, "+
+			"want 3 and 1", entry.AncestorCount, entry.DescendantCount)
+	}
+	// Transactions that are not in the pool are reported as an error.
+	harness.txPool.RemoveTransaction(chainedTxns[0], true)
+
+	if _, err := harness.txPool.MempoolEntry(chainedTxns[1].Hash()); err == nil {
+		t.Fatalf("MempoolEntry: no error for a transaction that is " +
+			"not in the pool")
+	}
+}
+
+// TestPoolSizeLimit ensures that the transactions paying the lowest fee rates are evicted when the pool grows over its size limit, and that the minimum fee for new transactions rises after evictions and decays back.
+func TestPoolSizeLimit(
+	t *testing.T) {
+
+	t.Parallel()
+	harness, outputs, err := newPoolHarness(&chaincfg.MainNetParams)
+
+	if err != nil {
+		t.Fatalf("unable to create test pool: %v", err)
+	}
+	tc := &testContext{t, harness}
+	txPool := harness.txPool
+	// Split the coinbase into outputs that each fund a transaction paying a different fee.
+	fanOut, err := harness.CreateSignedTx(outputs, 6)
+
+	if err != nil {
+		t.Fatalf("unable to create transaction: %v", err)
+	}
+	spend := func(i uint32, fee util.Amount) (*util.Tx, error) {
+		tx, err := harness.CreateSignedTxWithFee(
+			[]spendableOutput{txOutToSpendableOut(fanOut, i)}, 1, fee)
+
+		if err != nil {
+			t.Fatalf("unable to create transaction: %v", err)
+		}
+		_, err = txPool.ProcessTransaction(tx, false, false, 0)
+		return tx, err
+	}
+	_, err = txPool.ProcessTransaction(fanOut, false, false, 0)
+
+	if err != nil {
+		t.Fatalf("ProcessTransaction: failed to accept tx: %v", err)
+	}
+	var children []*util.Tx
+
+	for i := uint32(0); i < 3; i++ {
+		tx, err := spend(i, util.Amount(i+1)*10000)
+
+		if err != nil {
+			t.Fatalf("ProcessTransaction: failed to accept tx: %v", err)
+		}
+		children = append(children, tx)
+	}
+	wantUsage := txUsage(fanOut)
+
+	for _, tx := range children {
+		wantUsage += txUsage(tx)
+	}
+
+	if usage := txPool.Usage(); usage != wantUsage {
+		t.Fatalf("Usage: got %d, want %d", usage, wantUsage)
+	}
+
+	if minFee := txPool.MinFee(); minFee != txPool.cfg.Policy.MinRelayTxFee {
+		t.Fatalf("MinFee: got %v before any eviction, want the minimum "+
+			"relay fee", minFee)
+	}
+	// Limit the pool to its current contents, so that the next transaction evicts the one paying the lowest fee rate.
+	txPool.cfg.Policy.MaxPoolSize = wantUsage
+	tx, err := spend(3, 40000)
+
+	if err != nil {
+		t.Fatalf("ProcessTransaction: failed to accept tx: %v", err)
+	}
+	children = append(children, tx)
+	testPoolMembership(tc, children[0], false, false)
+
+	for _, tx := range append(children[1:], fanOut) {
+		testPoolMembership(tc, tx, false, true)
+	}
+
+	if usage := txPool.Usage(); usage > txPool.MaxSize() {
+		t.Fatalf("Usage: got %d over the limit of %d", usage,
+			txPool.MaxSize())
+	}
+	evictedRate := util.Amount(10000 * 1000 / GetTxVirtualSize(children[0]))
+	minFee := txPool.MinFee()
+
+	if minFee < evictedRate+txPool.cfg.Policy.MinRelayTxFee {
+		t.Fatalf("MinFee: got %v after evicting a transaction paying "+
+			"%v", minFee, evictedRate)
+	}
+	// A transaction paying less than the raised minimum fee is rejected outright.
+	_, err = spend(4, 5000)
+
+	if code, _ := extractRejectCode(err); code != wire.RejectInsufficientFee {
+		t.Fatalf("ProcessTransaction: got error %v for a transaction "+
+			"under the mempool minimum fee", err)
+	}
+	// A transaction paying the minimum fee, but less than the transactions in the pool, is evicted again straight away.
+	tx, err = spend(5, 15000)
+
+	if code, _ := extractRejectCode(err); code != wire.RejectInsufficientFee {
+		t.Fatalf("ProcessTransaction: got error %v for a transaction "+
+			"that does not fit in the full pool", err)
+	}
+	testPoolMembership(tc, tx, false, false)
+	testPoolMembership(tc, children[1], false, true)
+
+	if txPool.MinFee() <= minFee {
+		t.Fatalf("MinFee: did not rise after evicting a transaction " +
+			"paying more")
+	}
+	// The minimum fee halves every half life while the pool is full, and falls back to the minimum relay fee eventually.
+	minFee = txPool.MinFee()
+	txPool.mtx.Lock()
+	txPool.lastFeeUpdate = txPool.lastFeeUpdate.Add(-rollingFeeHalfLife)
+	txPool.mtx.Unlock()
+
+	if got := txPool.MinFee(); got < minFee/2-1 || got > minFee/2+1 {
+		t.Fatalf("MinFee: got %v after one half life, want %v", got,
+			minFee/2)
+	}
+	txPool.mtx.Lock()
+	txPool.lastFeeUpdate = txPool.lastFeeUpdate.Add(-10 * rollingFeeHalfLife)
+	txPool.mtx.Unlock()
+
+	if got := txPool.MinFee(); got != txPool.cfg.Policy.MinRelayTxFee {
+		t.Fatalf("MinFee: got %v after ten half lives, want the minimum "+
+			"relay fee", got)
+	}
+	// Evicting a transaction evicts its descendants with it, and leaves no usage behind.
+	txPool.cfg.Policy.MaxPoolSize = 1
+	spend(4, 50000)
+
+	if count, usage := txPool.Count(), txPool.Usage(); count != 0 || usage != 0 {
+		t.Fatalf("got %d transactions using %d bytes in a pool limited "+
+			"to 1 byte", count, usage)
+	}
+}
+
+// TestDescendantStats ensures that the descendant fees and sizes kept with each transaction in the pool match those of its descendants as transactions are added and removed, including a transaction that returns to the pool with descendants and descendants that are reachable along more than one path.
+func TestDescendantStats(
+	t *testing.T) {
+
+	t.Parallel()
+	harness, outputs, err := newPoolHarness(&chaincfg.MainNetParams)
+
+	if err != nil {
+		t.Fatalf("unable to create test pool: %v", err)
+	}
+	txPool := harness.txPool
+	checkStats := func(step string) {
+		txPool.mtx.RLock()
+		defer txPool.mtx.RUnlock()
+
+		for hash, desc := range txPool.pool {
+			fees, size := desc.Fee, GetTxVirtualSize(desc.Tx)
+
+			for _, descendant := range txPool.txDescendants(desc.Tx) {
+				fees += descendant.Fee
+				size += GetTxVirtualSize(descendant.Tx)
+			}
+
+			if desc.descendantFees != fees || desc.descendantSize != size {
+				t.Fatalf("%s: transaction %v has descendant fees %d and "+
+					"size %d, want %d and %d", step, hash,
+					desc.descendantFees, desc.descendantSize, fees, size)
+			}
+		}
+	}
+	create := func(inputs []spendableOutput, numOutputs uint32) *util.Tx {
+		tx, err := harness.CreateSignedTxWithFee(inputs, numOutputs, 10000)
+
+		if err != nil {
+			t.Fatalf("unable to create transaction: %v", err)
+		}
+		return tx
+	}
+	accept := func(tx *util.Tx) {
+		_, err := txPool.ProcessTransaction(tx, false, false, 0)
+
+		if err != nil {
+			t.Fatalf("ProcessTransaction: failed to accept tx: %v", err)
+		}
+	}
+	// The grandchild spends both children, so it descends from the parent along two paths.
+	parent := create(outputs[:1], 2)
+	left := create([]spendableOutput{txOutToSpendableOut(parent, 0)}, 1)
+	right := create([]spendableOutput{txOutToSpendableOut(parent, 1)}, 1)
+	grandchild := create([]spendableOutput{
+		txOutToSpendableOut(left, 0),
+		txOutToSpendableOut(right, 0),
+	}, 1)
+
+	for _, tx := range []*util.Tx{parent, left, right, grandchild} {
+		accept(tx)
+		checkStats("add")
+	}
+	// Removing a child without its redeemers leaves the grandchild descending from the parent through the other child, and removing the other child too leaves it no longer descending from the parent at all.
+	txPool.RemoveTransaction(left, false)
+	checkStats("remove without redeemers")
+	txPool.RemoveTransaction(right, false)
+	checkStats("remove the last path")
+	// The removed children return to the pool with the grandchild already spending them.
+	accept(left)
+	checkStats("add with descendants")
+	accept(right)
+	checkStats("add with shared descendants")
+	txPool.RemoveTransaction(right, true)
+	checkStats("remove with redeemers")
+	txPool.RemoveTransaction(parent, true)
+	checkStats("remove all")
+
+	if count := txPool.Count(); count != 0 {
+		t.Fatalf("Count: got %d transactions, want none", count)
+	}
+}
+
+// TestReplaceByFee ensures that transactions signalling replaceability as described by BIP 125 are replaced by double spends paying enough higher fees, together with their descendants, and that other double spends are rejected.
+func TestReplaceByFee(
+	t *testing.T) {
+
+	t.Parallel()
+	harness, outputs, err := newPoolHarness(&chaincfg.MainNetParams)
+
+	if err != nil {
+		t.Fatalf("unable to create test pool: %v", err)
+	}
+	tc := &testContext{t, harness}
+	txPool := harness.txPool
+	replaceable := wire.MaxTxInSequenceNum - 2
+	fanOut, err := harness.CreateSignedTx(outputs, 3)
+
+	if err != nil {
+		t.Fatalf("unable to create transaction: %v", err)
+	}
+	_, err = txPool.ProcessTransaction(fanOut, false, false, 0)
+
+	if err != nil {
+		t.Fatalf("ProcessTransaction: failed to accept tx: %v", err)
+	}
+	spend := func(inputs []spendableOutput, fee util.Amount,
+		sequence uint32) *util.Tx {
+		tx, err := harness.CreateSignedTxWithSequence(inputs, 1, fee,
+			sequence)
+
+		if err != nil {
+			t.Fatalf("unable to create transaction: %v", err)
+		}
+		return tx
+	}
+	accept := func(tx *util.Tx) {
+		_, err := txPool.ProcessTransaction(tx, false, false, 0)
+
+		if err != nil {
+			t.Fatalf("ProcessTransaction: failed to accept tx: %v", err)
+		}
+	}
+	reject := func(tx *util.Tx, wantCode wire.RejectCode, reason string) {
+		_, err := txPool.ProcessTransaction(tx, false, false, 0)
+
+		if code, _ := extractRejectCode(err); code != wantCode {
+			t.Fatalf("ProcessTransaction: got error %v for a "+
+				"replacement %s, want code %v", err, reason, wantCode)
+		}
+		testPoolMembership(tc, tx, false, false)
+	}
+	// A transaction that does not signal replaceability can not be double spent.
+	input := []spendableOutput{txOutToSpendableOut(fanOut, 0)}
+	final := spend(input, 1000, wire.MaxTxInSequenceNum)
+	accept(final)
+	reject(spend(input, 50000, wire.MaxTxInSequenceNum), wire.RejectDuplicate,
+		"of a transaction that does not signal replaceability")
+	// A transaction that signals replaceability is replaced, with its descendants, by one paying higher fees.
+	input = []spendableOutput{txOutToSpendableOut(fanOut, 1)}
+	original := spend(input, 1000, replaceable)
+	accept(original)
+	child := spend([]spendableOutput{txOutToSpendableOut(original, 0)},
+		1000, wire.MaxTxInSequenceNum)
+	accept(child)
+	reject(spend(input, 1000, replaceable-1), wire.RejectInsufficientFee,
+		"paying the same fee")
+	reject(spend(input, 2000, replaceable), wire.RejectInsufficientFee,
+		"paying less than the fees of the evicted descendants and the "+
+			"relay fee")
+	reject(spend(append(input, txOutToSpendableOut(child, 0)), 50000,
+		replaceable), wire.RejectInvalid, "spending an output of a "+
+		"transaction it replaces")
+	reject(spend(append(input, txOutToSpendableOut(final, 0)), 50000,
+		replaceable), wire.RejectNonstandard, "spending a new "+
+		"unconfirmed output")
+	replacement := spend(input, 5000, replaceable)
+	accept(replacement)
+	testPoolMembership(tc, original, false, false)
+	testPoolMembership(tc, child, false, false)
+	testPoolMembership(tc, replacement, false, true)
+
+	if spender := txPool.CheckSpend(input[0].outPoint); spender == nil ||
+		*spender.Hash() != *replacement.Hash() {
+		t.Fatalf("CheckSpend: output is not spent by the replacement")
+	}
+	// Children of a transaction signalling replaceability inherit it, and no transaction is replaced when replacement is disabled.
+	input = []spendableOutput{txOutToSpendableOut(fanOut, 2)}
+	parent := spend(input, 1000, replaceable)
+	accept(parent)
+	input = []spendableOutput{txOutToSpendableOut(parent, 0)}
+	accept(spend(input, 1000, wire.MaxTxInSequenceNum))
+	txPool.cfg.Policy.RejectReplacement = true
+	reject(spend(input, 50000, wire.MaxTxInSequenceNum), wire.RejectDuplicate,
+		"while replacement is disabled")
+	txPool.cfg.Policy.RejectReplacement = false
+	accept(spend(input, 50000, wire.MaxTxInSequenceNum))
+}
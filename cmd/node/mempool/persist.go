@@ -0,0 +1,197 @@
+package mempool
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"time"
+
+	"git.parallelcoin.io/dev/pod/pkg/chain/wire"
+	"git.parallelcoin.io/dev/pod/pkg/util"
+)
+
+// DefaultFileName is the name of the file in the data directory that the mempool is saved to at shutdown and loaded from at startup.
+const DefaultFileName = "mempool.dat"
+
+// mempoolSaveVersion is the version of the format of saved mempools.
+const mempoolSaveVersion = 1
+
+var errLoadInterrupted = errors.New("loading the mempool was interrupted")
+
+// Save writes the transactions in the main pool to w along with the times they were accepted, with each transaction after the transactions in the pool it spends. It returns the number of transactions written. This function is safe for concurrent access.
+func (
+	mp *TxPool,
+) Save(
+	w io.Writer) (int, error) {
+
+	type savedTx struct {
+		tx    *util.Tx
+		added time.Time
+		depth int
+	}
+	mp.mtx.RLock()
+	saved := make([]savedTx, 0, len(mp.pool))
+
+	for _, desc := range mp.pool {
+
+		saved = append(saved, savedTx{desc.Tx, desc.Added,
+			len(mp.txAncestors(desc.Tx))})
+	}
+	mp.mtx.RUnlock()
+	// A transaction has more ancestors in the pool than any of its parents, so ordering by the number of ancestors puts parents first.
+	sort.Slice(saved, func(i, j int) bool {
+
+		return saved[i].depth < saved[j].depth
+	})
+	err := binary.Write(w, binary.BigEndian, uint32(mempoolSaveVersion))
+
+	if err != nil {
+
+		return 0, err
+	}
+	err = binary.Write(w, binary.BigEndian, uint64(len(saved)))
+
+	if err != nil {
+
+		return 0, err
+	}
+
+	for i := range saved {
+
+		err = binary.Write(w, binary.BigEndian, saved[i].added.UnixNano())
+
+		if err != nil {
+
+			return i, err
+		}
+
+		if err = saved[i].tx.MsgTx().Serialize(w); err != nil {
+
+			return i, err
+		}
+	}
+	return len(saved), nil
+}
+
+// Load reads transactions written by Save from r and validates each of them again to accept it into the pool, keeping the time it was first accepted, and accepts any orphans that depend on them like ProcessTransaction does. It returns the transactions added to the pool, which the caller should relay, and the number that were not accepted, because they are no longer valid, conflict with or are already in the pool.  Loading stops early if interrupt is closed or the data is damaged, and the transactions accepted until then are returned along with the error. This function is safe for concurrent access.
+func (
+	mp *TxPool,
+) Load(
+	r io.Reader, interrupt <-chan struct{}) (accepted []*TxDesc, failed int, err error) {
+
+	var version uint32
+
+	if err = binary.Read(r, binary.BigEndian, &version); err != nil {
+
+		return
+	}
+
+	if version != mempoolSaveVersion {
+
+		err = fmt.Errorf("unsupported mempool file version %d", version)
+		return
+	}
+	var count uint64
+
+	if err = binary.Read(r, binary.BigEndian, &count); err != nil {
+
+		return
+	}
+
+	for i := uint64(0); i < count; i++ {
+
+		select {
+
+		case <-interrupt:
+			err = errLoadInterrupted
+			return
+		default:
+		}
+		var added int64
+
+		if err = binary.Read(r, binary.BigEndian, &added); err != nil {
+
+			return
+		}
+		msgTx := new(wire.MsgTx)
+
+		if err = msgTx.Deserialize(r); err != nil {
+
+			return
+		}
+		tx := util.NewTx(msgTx)
+		mp.mtx.Lock()
+		missing, txD, e := mp.maybeAcceptTransaction(tx, true, false, true)
+
+		if e == nil && len(missing) == 0 {
+
+			txD.Added = time.Unix(0, added)
+			accepted = append(accepted, txD)
+			accepted = append(accepted, mp.processOrphans(tx)...)
+		} else {
+
+			failed++
+		}
+		mp.mtx.Unlock()
+	}
+	return
+}
+
+// SaveFile saves the transactions in the main pool to a file, replacing it only once all of them have been written. It returns the number of transactions saved.
+func (
+	mp *TxPool,
+) SaveFile(
+	path string) (int, error) {
+
+	tmpPath := path + ".new"
+	f, err := os.Create(tmpPath)
+
+	if err != nil {
+
+		return 0, err
+	}
+	w := bufio.NewWriter(f)
+	count, err := mp.Save(w)
+
+	if err == nil {
+
+		err = w.Flush()
+	}
+
+	if err == nil {
+
+		err = f.Sync()
+	}
+
+	if e := f.Close(); err == nil {
+
+		err = e
+	}
+
+	if err != nil {
+
+		os.Remove(tmpPath)
+		return 0, err
+	}
+	return count, os.Rename(tmpPath, path)
+}
+
+// LoadFile loads the transactions saved in a file by SaveFile into the pool. See Load for details.
+func (
+	mp *TxPool,
+) LoadFile(
+	path string, interrupt <-chan struct{}) (accepted []*TxDesc, failed int, err error) {
+
+	f, err := os.Open(path)
+
+	if err != nil {
+
+		return
+	}
+	defer f.Close()
+	return mp.Load(bufio.NewReader(f), interrupt)
+}
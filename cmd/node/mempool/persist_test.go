@@ -0,0 +1,145 @@
+package mempool
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	chaincfg "git.parallelcoin.io/dev/pod/pkg/chain/config"
+)
+
+// TestSaveLoad ensures that the transactions saved from a pool are accepted into another pool with their accept times along with the orphans that spend them, and that damaged data is refused.
+func TestSaveLoad(
+	t *testing.T) {
+
+	t.Parallel()
+	harness, outputs, err := newPoolHarness(&chaincfg.MainNetParams)
+
+	if err != nil {
+		t.Fatalf("unable to create test pool: %v", err)
+	}
+	chainedTxns, err := harness.CreateTxChain(outputs[0], 4)
+
+	if err != nil {
+		t.Fatalf("unable to create transaction chain: %v", err)
+	}
+	// The last transaction of the chain is not saved, but waits as an orphan for the others to be loaded.
+	orphan := chainedTxns[3]
+	chainedTxns = chainedTxns[:3]
+
+	for _, tx := range chainedTxns {
+		_, err := harness.txPool.ProcessTransaction(tx, false, false, 0)
+
+		if err != nil {
+			t.Fatalf("ProcessTransaction: failed to accept tx: %v", err)
+		}
+	}
+	var buf bytes.Buffer
+	count, err := harness.txPool.Save(&buf)
+
+	if err != nil || count != len(chainedTxns) {
+		t.Fatalf("Save: got %d transactions with error %v, want %d",
+			count, err, len(chainedTxns))
+	}
+	saved := buf.Bytes()
+	// Every transaction is accepted into an empty pool, as parents are saved before their children.
+	txPool := New(&harness.txPool.cfg)
+	accepted, failed, err := txPool.Load(bytes.NewReader(saved), nil)
+
+	if err != nil || len(accepted) != len(chainedTxns) || failed != 0 {
+		t.Fatalf("Load: got %d accepted and %d failed with error %v, "+
+			"want %d accepted", len(accepted), failed, err, len(chainedTxns))
+	}
+
+	for _, tx := range chainedTxns {
+		want, _ := harness.txPool.pool[*tx.Hash()]
+		got, ok := txPool.pool[*tx.Hash()]
+
+		if !ok {
+			t.Fatalf("Load: transaction %v not in pool", tx.Hash())
+		}
+
+		if !got.Added.Equal(want.Added) {
+			t.Fatalf("Load: got accept time %v, want %v", got.Added,
+				want.Added)
+		}
+	}
+	// Transactions already in the pool are not accepted again.
+	accepted, failed, err = txPool.Load(bytes.NewReader(saved), nil)
+
+	if err != nil || len(accepted) != 0 || failed != len(chainedTxns) {
+		t.Fatalf("Load: got %d accepted and %d failed with error %v "+
+			"loading transactions already in the pool", len(accepted),
+			failed, err)
+	}
+	// An orphan that spends a loaded transaction is accepted after it and returned to be relayed.
+	txPool = New(&harness.txPool.cfg)
+
+	if _, err := txPool.ProcessTransaction(orphan, true, false, 0); err != nil {
+		t.Fatalf("ProcessTransaction: failed to accept orphan: %v", err)
+	}
+	accepted, _, err = txPool.Load(bytes.NewReader(saved), nil)
+
+	if err != nil || len(accepted) != len(chainedTxns)+1 ||
+		!accepted[len(accepted)-1].Tx.Hash().IsEqual(orphan.Hash()) {
+		t.Fatalf("Load: got %d accepted with error %v, want the %d saved "+
+			"transactions followed by the orphan", len(accepted), err,
+			len(chainedTxns))
+	}
+
+	if txPool.IsOrphanInPool(orphan.Hash()) ||
+		!txPool.IsTransactionInPool(orphan.Hash()) {
+		t.Fatalf("Load: orphan was not moved into the pool")
+	}
+	damaged := map[string][]byte{
+		"version":   append([]byte{0, 0, 0, 2}, saved[4:]...),
+		"truncated": saved[:len(saved)-1],
+		"empty":     nil,
+	}
+
+	for name, data := range damaged {
+		txPool := New(&harness.txPool.cfg)
+
+		if _, _, err := txPool.Load(bytes.NewReader(data), nil); err == nil {
+			t.Fatalf("Load: no error for %s data", name)
+		}
+	}
+	interrupt := make(chan struct{})
+	close(interrupt)
+	txPool = New(&harness.txPool.cfg)
+	accepted, _, err = txPool.Load(bytes.NewReader(saved), interrupt)
+
+	if err != errLoadInterrupted || len(accepted) != 0 {
+		t.Fatalf("Load: got %d accepted with error %v when interrupted",
+			len(accepted), err)
+	}
+	// Saving to a file replaces it as a whole.
+	dir, err := ioutil.TempDir("", "mempool")
+
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, DefaultFileName)
+
+	if err := ioutil.WriteFile(path, []byte("old"), 0600); err != nil {
+		t.Fatalf("unable to write file: %v", err)
+	}
+
+	if _, err := harness.txPool.SaveFile(path); err != nil {
+		t.Fatalf("SaveFile: unexpected error: %v", err)
+	}
+
+	if _, err := os.Stat(path + ".new"); !os.IsNotExist(err) {
+		t.Fatalf("SaveFile: temporary file left behind")
+	}
+	txPool = New(&harness.txPool.cfg)
+	accepted, _, err = txPool.LoadFile(path, nil)
+
+	if err != nil || len(accepted) != len(chainedTxns) {
+		t.Fatalf("LoadFile: got %d accepted with error %v, want %d",
+			len(accepted), err, len(chainedTxns))
+	}
+}
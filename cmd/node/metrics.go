@@ -0,0 +1,142 @@
+package node
+
+import (
+	"time"
+
+	"git.parallelcoin.io/dev/pod/pkg/rpc/json"
+	"git.parallelcoin.io/dev/pod/pkg/util/metrics"
+)
+
+// metricsAlgoWindow is the number of blocks back from the best block that are searched for the newest block of each algorithm for the per algorithm chain height metric.
+const metricsAlgoWindow = 1000
+
+var (
+	chainHeight = metrics.NewGauge("pod_chain_height",
+		"Height of the best block of the main chain")
+	chainAlgoHeight = metrics.NewGauge("pod_chain_algo_height",
+		"Height of the newest block of each algorithm within the last 1000 blocks of the main chain", "algo")
+	mempoolTransactions = metrics.NewGauge("pod_mempool_transactions",
+		"Number of transactions in the memory pool, not counting orphans")
+	mempoolBytes = metrics.NewGauge("pod_mempool_bytes",
+		"Estimated memory usage in bytes of the transactions in the memory pool")
+	mempoolMaxBytes = metrics.NewGauge("pod_mempool_max_bytes",
+		"Memory usage in bytes the memory pool is limited to, zero when it is not limited")
+	peerCount = metrics.NewGauge("pod_peers",
+		"Number of connected peers by direction", "direction")
+	networkReceivedBytes = metrics.NewCounter("pod_network_received_bytes_total",
+		"Total bytes received from all peers")
+	networkSentBytes = metrics.NewCounter("pod_network_sent_bytes_total",
+		"Total bytes sent to all peers")
+	rpcRequestSeconds = metrics.NewHistogram("pod_rpc_request_duration_seconds",
+		"Time taken to handle RPC requests, by method", nil, "method")
+	minerRunning = metrics.NewGauge("pod_miner_controller_running",
+		"Whether the miner controller is running and serving jobs to workers")
+	minerWorkers = metrics.NewGauge("pod_miner_workers",
+		"Number of workers with at least one session logged in to the miner controller")
+	minerSessions = metrics.NewGauge("pod_miner_sessions",
+		"Number of worker sessions logged in to the miner controller")
+	minerShares = metrics.NewGauge("pod_miner_shares",
+		"Number of shares submitted to the miner controller since it started, by result", "result")
+)
+
+// observeRPCRequest adds the time taken to handle a request to the RPC latency metrics.  Methods that are not registered are counted together so clients can't create any number of series.
+func observeRPCRequest(
+	method string, elapsed time.Duration) {
+
+	if _, err := json.MethodUsageFlags(method); err != nil {
+
+		method = "unknown"
+	}
+	rpcRequestSeconds.Observe(elapsed.Seconds(), method)
+}
+
+// collectMetrics sets the gauges that mirror the state of the chain, the memory pool, the peers and the miner controller.  It runs before each scrape of the metrics while the server is running.
+func (
+	s *server,
+) collectMetrics() {
+
+	best := s.chain.BestSnapshot()
+	chainHeight.Set(float64(best.Height))
+	startHeight := best.Height - metricsAlgoWindow + 1
+
+	if startHeight < 0 {
+
+		startHeight = 0
+	}
+
+	if stats, err := s.chain.CalcAlgoStats(startHeight, best.Height); err == nil {
+
+		// The algorithms change with the hard forks, so the series are rebuilt rather than leaving those of retired algorithms behind.
+		chainAlgoHeight.Reset()
+
+		for i := range stats {
+
+			if stats[i].LastHeight >= 0 {
+
+				chainAlgoHeight.Set(float64(stats[i].LastHeight), stats[i].Name)
+			}
+		}
+	}
+
+	mempoolTransactions.Set(float64(s.txMemPool.Count()))
+	mempoolBytes.Set(float64(s.txMemPool.Usage()))
+	mempoolMaxBytes.Set(float64(s.txMemPool.MaxSize()))
+
+	// The peer handler answers queries until the server quits.
+	replyChan := make(chan []*serverPeer)
+
+	select {
+
+	case s.query <- getPeersMsg{reply: replyChan}:
+		var inbound, outbound int
+
+		for _, sp := range <-replyChan {
+
+			if sp.Inbound() {
+
+				inbound++
+			} else {
+
+				outbound++
+			}
+		}
+		peerCount.Set(float64(inbound), "inbound")
+		peerCount.Set(float64(outbound), "outbound")
+
+	case <-s.quit:
+	}
+
+	var workers, sessions int
+	var accepted, stale, duplicate, invalid int64
+
+	for _, w := range s.minerController.WorkerStats() {
+
+		if w.Sessions > 0 {
+
+			workers++
+		}
+		sessions += w.Sessions
+		accepted += w.Accepted
+		stale += w.Stale
+		duplicate += w.Duplicate
+		invalid += w.Invalid
+	}
+	minerRunning.Set(boolToFloat(s.minerController.IsMining()))
+	minerWorkers.Set(float64(workers))
+	minerSessions.Set(float64(sessions))
+	minerShares.Set(float64(accepted), "accepted")
+	minerShares.Set(float64(stale), "stale")
+	minerShares.Set(float64(duplicate), "duplicate")
+	minerShares.Set(float64(invalid), "invalid")
+}
+
+// boolToFloat returns 1 for true and 0 for false, the usual gauge values of flags.
+func boolToFloat(
+	b bool) float64 {
+
+	if b {
+
+		return 1
+	}
+	return 0
+}
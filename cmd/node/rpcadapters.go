@@ -1,7 +1,9 @@
 package node
 
 import (
+	"net"
 	"sync/atomic"
+	"time"
 
 	"git.parallelcoin.io/dev/pod/cmd/node/mempool"
 	blockchain "git.parallelcoin.io/dev/pod/pkg/chain"
@@ -9,6 +11,7 @@ import (
 	netsync "git.parallelcoin.io/dev/pod/pkg/chain/sync"
 	"git.parallelcoin.io/dev/pod/pkg/chain/wire"
 	"git.parallelcoin.io/dev/pod/pkg/peer"
+	"git.parallelcoin.io/dev/pod/pkg/peer/addrmgr"
 	"git.parallelcoin.io/dev/pod/pkg/util"
 )
 
@@ -134,6 +137,12 @@ func (cm *rpcConnManager) ConnectedCount() int32 {
 	return cm.server.ConnectedCount()
 }
 
+// NetworkActive returns whether the node is taking part in the peer to peer network, by listening for peers or connecting to them. This function is safe for concurrent access and is part of the rpcserverConnManager interface implementation.
+func (cm *rpcConnManager) NetworkActive() bool {
+
+	return cm.server.connManager.Active()
+}
+
 // NetTotals returns the sum of all bytes received and sent across the network for all peers. This function is safe for concurrent access and is part of the rpcserverConnManager interface implementation.
 func (cm *rpcConnManager) NetTotals() (uint64, uint64) {
 
@@ -194,6 +203,42 @@ func (cm *rpcConnManager) RelayTransactions(txns []*mempool.TxDesc) {
 	cm.server.relayTransactions(txns)
 }
 
+// LocalAddresses returns the addresses the node advertises to its peers along with their scores. This function is safe for concurrent access and is part of the rpcserverConnManager interface implementation.
+func (cm *rpcConnManager) LocalAddresses() []addrmgr.LocalAddr {
+
+	return cm.server.addrManager.LocalAddresses()
+}
+
+// Ban bans the subnet until the passed time for the reason given and disconnects the connected peers in it.  Banning a subnet that is already banned will return an error. This function is safe for concurrent access and is part of the rpcserverConnManager interface implementation.
+func (cm *rpcConnManager) Ban(subnet *net.IPNet, until time.Time, reason string) error {
+
+	if err := cm.server.banList.Ban(subnet, until, reason); err != nil {
+
+		return err
+	}
+
+	cm.server.DisconnectBanned()
+	return nil
+}
+
+// Unban lifts the ban of the subnet.  Unbanning a subnet that is not banned will return an error. This function is safe for concurrent access and is part of the rpcserverConnManager interface implementation.
+func (cm *rpcConnManager) Unban(subnet *net.IPNet) error {
+
+	return cm.server.banList.Unban(subnet)
+}
+
+// ListBanned returns the bans that have not expired. This function is safe for concurrent access and is part of the rpcserverConnManager interface implementation.
+func (cm *rpcConnManager) ListBanned() []banEntry {
+
+	return cm.server.banList.List()
+}
+
+// ClearBanned lifts all bans. This function is safe for concurrent access and is part of the rpcserverConnManager interface implementation.
+func (cm *rpcConnManager) ClearBanned() error {
+
+	return cm.server.banList.Clear()
+}
+
 // rpcSyncMgr provides a block manager for use with the RPC server and implements the rpcserverSyncManager interface.
 
 type rpcSyncMgr struct {
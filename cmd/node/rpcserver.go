@@ -16,6 +16,7 @@ import (
 	"net"
 	"net/http"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"sync"
@@ -25,15 +26,16 @@ import (
 	"git.parallelcoin.io/dev/pod/cmd/node/mempool"
 	blockchain "git.parallelcoin.io/dev/pod/pkg/chain"
 	chaincfg "git.parallelcoin.io/dev/pod/pkg/chain/config"
-	"git.parallelcoin.io/dev/pod/pkg/chain/fork"
 	chainhash "git.parallelcoin.io/dev/pod/pkg/chain/hash"
 	indexers "git.parallelcoin.io/dev/pod/pkg/chain/index"
 	"git.parallelcoin.io/dev/pod/pkg/chain/mining"
 	cpuminer "git.parallelcoin.io/dev/pod/pkg/chain/mining/cpu"
+	controller "git.parallelcoin.io/dev/pod/pkg/chain/mining/dispatch"
 	txscript "git.parallelcoin.io/dev/pod/pkg/chain/tx/script"
 	"git.parallelcoin.io/dev/pod/pkg/chain/wire"
 	database "git.parallelcoin.io/dev/pod/pkg/db"
 	p "git.parallelcoin.io/dev/pod/pkg/peer"
+	"git.parallelcoin.io/dev/pod/pkg/peer/addrmgr"
 	"git.parallelcoin.io/dev/pod/pkg/rpc/json"
 	"git.parallelcoin.io/dev/pod/pkg/util"
 	cl "git.parallelcoin.io/dev/pod/pkg/util/cl"
@@ -60,10 +62,11 @@ type gbtWorkState struct {
 // parsedRPCCmd represents a JSON-RPC request object that has been parsed into a known concrete command along with any error that might have happened while parsing it.
 
 type parsedRPCCmd struct {
-	id     interface{}
-	method string
-	cmd    interface{}
-	err    *json.RPCError
+	jsonrpc string
+	id      interface{}
+	method  string
+	cmd     interface{}
+	err     *json.RPCError
 }
 
 // retrievedTx represents a transaction that was either loaded from the transaction memory pool or from the database.  When a transaction is loaded from the database, it is loaded with the raw serialized bytes while the mempool has the fully deserialized structure.  This structure therefore will have one of the two fields set depending on where is was retrieved from. This is mainly done for efficiency to avoid extra serialization steps when possible.
@@ -122,6 +125,9 @@ type rpcserverConfig struct {
 	Generator *mining.BlkTmplGenerator
 	CPUMiner  *cpuminer.CPUMiner
 
+	// MinerController issues jobs to external miner workers and keeps their share accounting.
+	MinerController *controller.Controller
+
 	// These fields define any optional indexes the RPC server can make use of to provide additional data when queried.
 	TxIndex   *indexers.TxIndex
 	AddrIndex *indexers.AddrIndex
@@ -130,6 +136,18 @@ type rpcserverConfig struct {
 	// The fee estimator keeps track of how long transactions are left in the mempool before they are mined into blocks.
 	FeeEstimator *mempool.FeeEstimator
 
+	// Services defines the service flags the node advertises to its peers.
+	Services wire.ServiceFlag
+
+	// MempoolFile is the file the mempool is saved to at shutdown and loaded from at startup, which savemempool writes.
+	MempoolFile string
+
+	// DataDir is the data directory that relative dumptxoutset paths are resolved against.
+	DataDir string
+
+	// MempoolLoaded returns whether the mempool saved at the last shutdown has been loaded.
+	MempoolLoaded func() bool
+
 	// Algo sets the algorithm expected from the RPC endpoint. This allows multiple ports to serve multiple types of miners with one main node per algorithm. Currently 514 for scrypt and anything else passes for sha256d. After hard fork 1 there is 9, and may be expanded in the future (equihash, cuckoo and cryptonight all require substantial block header/tx formatting changes)
 	Algo string
 }
@@ -156,6 +174,9 @@ type rpcserverConnManager interface {
 	// ConnectedCount returns the number of currently connected peers.
 	ConnectedCount() int32
 
+	// NetworkActive returns whether the node is taking part in the peer to peer network, by listening for peers or connecting to them.
+	NetworkActive() bool
+
 	// NetTotals returns the sum of all bytes received and sent across the network for all peers.
 	NetTotals() (uint64, uint64)
 
@@ -173,6 +194,21 @@ type rpcserverConnManager interface {
 
 	// RelayTransactions generates and relays inventory vectors for all of the passed transactions to all connected peers.
 	RelayTransactions(txns []*mempool.TxDesc)
+
+	// LocalAddresses returns the addresses the node advertises to its peers along with their scores.
+	LocalAddresses() []addrmgr.LocalAddr
+
+	// Ban bans the subnet until the passed time for the reason given and disconnects the connected peers in it.  Banning a subnet that is already banned will return an error.
+	Ban(subnet *net.IPNet, until time.Time, reason string) error
+
+	// Unban lifts the ban of the subnet.  Unbanning a subnet that is not banned will return an error.
+	Unban(subnet *net.IPNet) error
+
+	// ListBanned returns the bans that have not expired.
+	ListBanned() []banEntry
+
+	// ClearBanned lifts all bans.
+	ClearBanned() error
 }
 
 // rpcserverPeer represents a peer for use with the RPC server. The interface contract requires that all of these methods are safe for concurrent access.
@@ -309,7 +345,6 @@ var rpcAskWallet = map[string]struct{}{
 	"getreceivedbyaccount":   {},
 	"getreceivedbyaddress":   {},
 	"gettransaction":         {},
-	"gettxoutsetinfo":        {},
 	"getunconfirmedbalance":  {},
 	"getwalletinfo":          {},
 	"importprivkey":          {},
@@ -343,14 +378,17 @@ var rpcHandlers map[string]commandHandler
 var rpcHandlersBeforeInit = map[string]commandHandler{
 
 	"addnode":              handleAddNode,
+	"clearbanned":          handleClearBanned,
 	"createrawtransaction": handleCreateRawTransaction,
 
 	// "debuglevel":            handleDebugLevel,
 	"decoderawtransaction":  handleDecodeRawTransaction,
 	"decodescript":          handleDecodeScript,
+	"dumptxoutset":          handleDumpTxOutSet,
 	"estimatefee":           handleEstimateFee,
 	"generate":              handleGenerate,
 	"getaddednodeinfo":      handleGetAddedNodeInfo,
+	"getalgostats":          handleGetAlgoStats,
 	"getbestblock":          handleGetBestBlock,
 	"getbestblockhash":      handleGetBestBlockHash,
 	"getblock":              handleGetBlock,
@@ -361,6 +399,7 @@ var rpcHandlersBeforeInit = map[string]commandHandler{
 	"getblocktemplate":      handleGetBlockTemplate,
 	"getcfilter":            handleGetCFilter,
 	"getcfilterheader":      handleGetCFilterHeader,
+	"getchaintips":          handleGetChainTips,
 	"getconnectioncount":    handleGetConnectionCount,
 	"getcurrentnet":         handleGetCurrentNet,
 	"getdifficulty":         handleGetDifficulty,
@@ -368,20 +407,31 @@ var rpcHandlersBeforeInit = map[string]commandHandler{
 	"gethashespersec":       handleGetHashesPerSec,
 	"getheaders":            handleGetHeaders,
 	"getinfo":               handleGetInfo,
+	"getmempoolentry":       handleGetMempoolEntry,
 	"getmempoolinfo":        handleGetMempoolInfo,
 	"getmininginfo":         handleGetMiningInfo,
 	"getnettotals":          handleGetNetTotals,
 	"getnetworkhashps":      handleGetNetworkHashPS,
+	"getnetworkinfo":        handleGetNetworkInfo,
 	"getpeerinfo":           handleGetPeerInfo,
 	"getrawmempool":         handleGetRawMempool,
 	"getrawtransaction":     handleGetRawTransaction,
 	"gettxout":              handleGetTxOut,
+	"gettxoutsetinfo":       handleGetTxOutSetInfo,
 	"getwork":               handleGetWork,
+	"getworkerstats":        handleGetWorkerStats,
 	"help":                  handleHelp,
+	"importmempool":         handleImportMempool,
+	"invalidateblock":       handleInvalidateBlock,
+	"listbanned":            handleListBanned,
 	"node":                  handleNode,
 	"ping":                  handlePing,
+	"preciousblock":         handlePreciousBlock,
+	"reconsiderblock":       handleReconsiderBlock,
+	"savemempool":           handleSaveMempool,
 	"searchrawtransactions": handleSearchRawTransactions,
 	"sendrawtransaction":    handleSendRawTransaction,
+	"setban":                handleSetBan,
 	"setgenerate":           handleSetGenerate,
 	"stop":                  handleStop,
 	"submitblock":           handleSubmitBlock,
@@ -414,6 +464,7 @@ var rpcLimited = map[string]struct{}{
 	"decoderawtransaction":  {},
 	"decodescript":          {},
 	"estimatefee":           {},
+	"getalgostats":          {},
 	"getbestblock":          {},
 	"getbestblockhash":      {},
 	"getblock":              {},
@@ -422,15 +473,19 @@ var rpcLimited = map[string]struct{}{
 	"getblockheader":        {},
 	"getcfilter":            {},
 	"getcfilterheader":      {},
+	"getchaintips":          {},
 	"getcurrentnet":         {},
 	"getdifficulty":         {},
 	"getheaders":            {},
 	"getinfo":               {},
+	"getmempoolentry":       {},
 	"getnettotals":          {},
 	"getnetworkhashps":      {},
+	"getnetworkinfo":        {},
 	"getrawmempool":         {},
 	"getrawtransaction":     {},
 	"gettxout":              {},
+	"gettxoutsetinfo":       {},
 	"searchrawtransactions": {},
 	"sendrawtransaction":    {},
 	"submitblock":           {},
@@ -445,13 +500,7 @@ var rpcLimited = map[string]struct{}{
 var rpcUnimplemented = map[string]struct{}{
 
 	"estimatepriority": {},
-	"getchaintips":     {},
-	"getmempoolentry":  {},
-	"getnetworkinfo":   {},
 	"getwork":          {},
-	"invalidateblock":  {},
-	"preciousblock":    {},
-	"reconsiderblock":  {},
 }
 
 // NotifyBlockConnected uses the newly-connected block to notify any long poll clients with a new block template when their existing block template is stale due to the newly connected block.
@@ -1274,7 +1323,158 @@ func (
 	defer buf.Flush()
 	conn.SetReadDeadline(timeZeroVal)
 
-	// Attempt to parse the raw body into a JSON-RPC request.
+	// Setup a close notifier.  Since the connection is hijacked, the CloseNotifer on the ResponseWriter is not available.
+	closeChan := make(chan struct{}, 1)
+
+	go func() {
+
+		_, err := conn.Read(make([]byte, 1))
+
+		if err != nil {
+
+			close(closeChan)
+		}
+
+	}()
+
+	// The body is either a single JSON-RPC request or a batch of them.
+	var msg []byte
+
+	if json.IsBatchRequest(body) {
+
+		msg = s.processBatchRequest(body, isAdmin, closeChan)
+
+	} else {
+
+		msg = s.processRequest(body, isAdmin, closeChan)
+	}
+
+	// Nothing is written back for notifications.
+
+	if msg == nil {
+
+		return
+	}
+
+	// Write the response.
+	err = s.writeHTTPResponseHeaders(r, w.Header(), http.StatusOK, buf)
+
+	if err != nil {
+
+		log <- cl.Error{err.Error()}
+
+		return
+	}
+
+	if _, err := buf.Write(msg); err != nil {
+
+		log <- cl.Error{"failed to write marshalled reply:", err}
+
+	}
+
+	// Terminate with newline to maintain compatibility with Bitcoin Core.
+
+	if err := buf.WriteByte('\n'); err != nil {
+
+		log <- cl.Error{"failed to append terminating newline to reply:", err}
+
+	}
+
+}
+
+// processBatchRequest runs the requests of a JSON-RPC batch in order and returns the marshalled array of their replies, leaving out notifications, or nil when there is nothing to reply.  Batches that are empty or larger than the rpcmaxbatchsize option get a single error reply.
+func (
+	s *rpcServer,
+) processBatchRequest(
+	body []byte,
+	isAdmin bool,
+	closeChan <-chan struct{},
+
+) []byte {
+
+	var requests []js.RawMessage
+	var jsonErr error
+
+	if err := js.Unmarshal(body, &requests); err != nil {
+
+		jsonErr = &json.RPCError{
+
+			Code:    json.ErrRPCParse.Code,
+			Message: "Failed to parse request: " + err.Error(),
+		}
+
+	} else if len(requests) == 0 {
+
+		jsonErr = &json.RPCError{
+
+			Code:    json.ErrRPCInvalidRequest.Code,
+			Message: "Empty batch request",
+		}
+
+	} else if len(requests) > *cfg.RPCMaxBatchSize {
+
+		jsonErr = &json.RPCError{
+
+			Code: json.ErrRPCInvalidRequest.Code,
+			Message: fmt.Sprintf("Batch of %d requests exceeds the maximum "+
+				"of %d", len(requests), *cfg.RPCMaxBatchSize),
+		}
+
+	}
+
+	if jsonErr != nil {
+
+		msg, err := createMarshalledReply(json.RPCVersion2, nil, nil, jsonErr)
+
+		if err != nil {
+
+			log <- cl.Error{"failed to marshal reply:", err}
+
+			return nil
+		}
+
+		return msg
+	}
+
+	replies := make([][]byte, 0, len(requests))
+
+	for _, request := range requests {
+
+		if reply := s.processRequest(request, isAdmin, closeChan); reply != nil {
+
+			replies = append(replies, reply)
+		}
+
+	}
+
+	if len(replies) == 0 {
+
+		return nil
+	}
+
+	msg, err := json.MarshalBatchResponse(replies)
+
+	if err != nil {
+
+		log <- cl.Error{"failed to marshal batch reply:", err}
+
+		return nil
+	}
+
+	return msg
+}
+
+// processRequest runs a single JSON-RPC request, on its own or from a batch, and returns the marshalled reply, or nil when the request is a notification.
+func (
+	s *rpcServer,
+) processRequest(
+	body []byte,
+	isAdmin bool,
+	closeChan <-chan struct{},
+
+) []byte {
+
+	// Attempt to parse the raw body into a JSON-RPC request.  Valid JSON that is not a request object is an invalid request rather than a parse error.
 	var responseID interface{}
 	var jsonErr error
 	var result interface{}
@@ -1282,9 +1482,16 @@ func (
 
 	if err := js.Unmarshal(body, &request); err != nil {
 
+		code := json.ErrRPCInvalidRequest.Code
+
+		if _, ok := err.(*js.SyntaxError); ok {
+
+			code = json.ErrRPCParse.Code
+		}
+
 		jsonErr = &json.RPCError{
 
-			Code:    json.ErrRPCParse.Code,
+			Code:    code,
 			Message: "Failed to parse request: " + err.Error(),
 		}
 
@@ -1296,33 +1503,20 @@ func (
 
 		The specification states that notifications must not be responded to. JSON-RPC 2.0 permits the null value as a valid request id, therefore such requests are not notifications.
 
-		Bitcoin Core serves requests with "id":null or even an absent "id", and responds to such requests with "id":null in the response. Pod does not respond to any request without and "id" or "id":null, regardless the indicated JSON-RPC protocol version unless RPC quirks are enabled.
+		Bitcoin Core serves requests with "id":null or even an absent "id", and responds to such requests with "id":null in the response. Pod does not respond to JSON-RPC 1.0 requests without an "id" or with "id":null, nor to JSON-RPC 2.0 requests without an "id", unless RPC quirks are enabled.
 
 		With RPC quirks enabled, such requests will be responded to if the reqeust does not indicate JSON-RPC version. RPC quirks can be enabled by the user to avoid compatibility issues with software relying on Core's behavior.
 		*/
 
-		if request.ID == nil && !(*cfg.RPCQuirks && request.Jsonrpc == "") {
+		if json.IsNotification(body, &request) &&
+			!(*cfg.RPCQuirks && request.Jsonrpc == "") {
 
-			return
+			return nil
 		}
 
 		// The parse was at least successful enough to have an ID so set it for the response.
 		responseID = request.ID
 
-		// Setup a close notifier.  Since the connection is hijacked, the CloseNotifer on the ResponseWriter is not available.
-		closeChan := make(chan struct{}, 1)
-
-		go func() {
-
-			_, err := conn.Read(make([]byte, 1))
-
-			if err != nil {
-
-				close(closeChan)
-			}
-
-		}()
-
 		// Check if the user is limited and set error if method unauthorized
 
 		if !isAdmin {
@@ -1358,39 +1552,17 @@ func (
 	}
 
 	// Marshal the response.
-	msg, err := createMarshalledReply(responseID, result, jsonErr)
+	msg, err := createMarshalledReply(request.Jsonrpc, responseID, result,
+		jsonErr)
 
 	if err != nil {
 
 		log <- cl.Error{"failed to marshal reply:", err}
 
-		return
-	}
-
-	// Write the response.
-	err = s.writeHTTPResponseHeaders(r, w.Header(), http.StatusOK, buf)
-
-	if err != nil {
-
-		log <- cl.Error{err.Error()}
-
-		return
-	}
-
-	if _, err := buf.Write(msg); err != nil {
-
-		log <- cl.Error{"failed to write marshalled reply:", err}
-
-	}
-
-	// Terminate with newline to maintain compatibility with Bitcoin Core.
-
-	if err := buf.WriteByte('\n'); err != nil {
-
-		log <- cl.Error{"failed to append terminating newline to reply:", err}
-
+		return nil
 	}
 
+	return msg
 }
 
 // limitConnections responds with a 503 service unavailable and returns true if adding another client would exceed the maximum allow RPC clients. This function is safe for concurrent access.
@@ -1453,7 +1625,10 @@ func (
 
 	return nil, json.ErrRPCMethodNotFound
 handled:
-	return handler(s, cmd.cmd, closeChan)
+	start := time.Now()
+	result, err := handler(s, cmd.cmd, closeChan)
+	observeRPCRequest(cmd.method, time.Since(start))
+	return result, err
 }
 
 // writeHTTPResponseHeaders writes the necessary response headers prior to writing an HTTP body given a request to use for protocol negotiation, headers to write, a status code, and a writer.
@@ -1608,8 +1783,9 @@ func chainErrToGBTErrString(
 	return "rejected: " + err.Error()
 }
 
-// createMarshalledReply returns a new marshalled JSON-RPC response given the passed parameters.  It will automatically convert errors that are not of the type *json.RPCError to the appropriate type as needed.
+// createMarshalledReply returns a new marshalled JSON-RPC response of the passed JSON-RPC version given the passed parameters.  It will automatically convert errors that are not of the type *json.RPCError to the appropriate type as needed.
 func createMarshalledReply(
+	rpcVersion string,
 	id,
 	result interface{},
 	replyErr error,
@@ -1634,7 +1810,7 @@ func createMarshalledReply(
 
 	}
 
-	return json.MarshalResponse(id, result, jsonErr)
+	return json.MarshalResponseVersion(rpcVersion, id, result, jsonErr)
 }
 
 // createTxRawResult converts the passed transaction and associated parameters to a raw transaction JSON object.
@@ -2221,6 +2397,19 @@ func handleAskWallet(
 	return nil, ErrRPCNoWallet
 }
 
+// handleClearBanned implements the clearbanned command.
+func handleClearBanned(
+
+	s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+
+	if err := s.cfg.ConnMgr.ClearBanned(); err != nil {
+
+		return nil, internalRPCError("Failed to clear the ban list: "+err.Error(), "")
+	}
+
+	return nil, nil
+}
+
 // handleCreateRawTransaction handles createrawtransaction commands.
 func handleCreateRawTransaction(
 	s *rpcServer,
@@ -2478,6 +2667,68 @@ func handleDecodeScript(
 	return reply, nil
 }
 
+// handleDumpTxOutSet implements the dumptxoutset command.
+func handleDumpTxOutSet(
+
+	s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+
+	c := cmd.(*json.DumpTxOutSetCmd)
+	path := c.Path
+
+	if !filepath.IsAbs(path) {
+
+		path = filepath.Join(s.cfg.DataDir, path)
+	}
+
+	if _, err := os.Stat(path); err == nil {
+
+		return nil, &json.RPCError{
+
+			Code:    json.ErrRPCInvalidParameter,
+			Message: path + " already exists",
+		}
+
+	}
+
+	// The snapshot is written to a temporary file first so an interrupted dump doesn't leave a truncated snapshot behind.
+	tmpPath := path + ".incomplete"
+	f, err := os.Create(tmpPath)
+
+	if err != nil {
+
+		return nil, internalRPCError("Failed to create utxo snapshot: "+
+			err.Error(), "")
+	}
+
+	info, err := s.cfg.Chain.DumpUtxoSnapshot(f)
+
+	if closeErr := f.Close(); err == nil {
+
+		err = closeErr
+	}
+
+	if err == nil {
+
+		err = os.Rename(tmpPath, path)
+	}
+
+	if err != nil {
+
+		os.Remove(tmpPath)
+		return nil, internalRPCError("Failed to write utxo snapshot: "+
+			err.Error(), "")
+	}
+
+	return &json.DumpTxOutSetResult{
+
+		CoinsWritten: info.Outputs,
+		BaseHash:     info.Hash.String(),
+		BaseHeight:   info.Height,
+		Path:         path,
+		TxOutSetHash: info.SetHash.String(),
+	}, nil
+}
+
 // handleEstimateFee handles estimatefee commands.
 func handleEstimateFee(
 
@@ -2696,59 +2947,142 @@ func handleGetAddedNodeInfo(
 	return results, nil
 }
 
-// handleGetBestBlock implements the getbestblock command.
-func handleGetBestBlock(
+// handleGetAlgoStats implements the getalgostats command. It reports the block count, current difficulty, estimated network hash rate and average block interval of each mining algorithm over a range of blocks ending at the given height.
+func handleGetAlgoStats(
 
 	s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
 
-	// All other "get block" commands give either the height, the hash, or both but require the block SHA.  This gets both for the best block.
+	c := cmd.(*json.GetAlgoStatsCmd)
 	best := s.cfg.Chain.BestSnapshot()
+	endHeight := int32(-1)
 
-	result := &json.GetBestBlockResult{
+	if c.Height != nil {
 
-		Hash:   best.Hash.String(),
-		Height: best.Height,
+		endHeight = int32(*c.Height)
 	}
 
-	return result, nil
-}
-
-// handleGetBestBlockHash implements the getbestblockhash command.
-func handleGetBestBlockHash(
+	if endHeight < 0 || endHeight > best.Height {
 
-	s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+		endHeight = best.Height
+	}
 
-	best := s.cfg.Chain.BestSnapshot()
-	return best.Hash.String(), nil
-}
+	numBlocks := int32(1000)
 
-// handleGetBlock implements the getblock command.
-func handleGetBlock(
+	if c.Blocks != nil {
 
-	s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+		numBlocks = int32(*c.Blocks)
+	}
 
-	c := cmd.(*json.GetBlockCmd)
+	if numBlocks <= 0 {
 
-	// Load the raw block bytes from the database.
-	hash, err := chainhash.NewHashFromStr(c.Hash)
+		return nil, &json.RPCError{
 
-	if err != nil {
+			Code:    json.ErrRPCInvalidParameter,
+			Message: "Number of blocks must be greater than zero",
+		}
 
-		return nil, rpcDecodeHexError(c.Hash)
 	}
 
-	var blkBytes []byte
+	startHeight := endHeight - numBlocks + 1
 
-	err = s.cfg.DB.View(func(dbTx database.Tx) error {
+	if startHeight < 0 {
 
-		var err error
-		blkBytes, err = dbTx.FetchBlock(hash)
-		return err
-	})
+		startHeight = 0
+	}
+
+	stats, err := s.cfg.Chain.CalcAlgoStats(startHeight, endHeight)
 
 	if err != nil {
 
-		return nil, &json.RPCError{
+		context := "Failed to calculate algorithm statistics"
+		return nil, internalRPCError(err.Error(), context)
+	}
+
+	result := &json.GetAlgoStatsResult{
+
+		StartHeight: startHeight,
+		EndHeight:   endHeight,
+		Algos:       make([]json.AlgoStatsResult, 0, len(stats)),
+	}
+
+	for i := range stats {
+
+		a := &stats[i]
+		r := json.AlgoStatsResult{
+
+			Algo:          a.Name,
+			Version:       a.Version,
+			Blocks:        a.Blocks,
+			LastHeight:    a.LastHeight,
+			NetworkHashPS: a.HashesPerSec(),
+			AvgInterval:   a.AvgInterval(),
+		}
+
+		if a.Blocks > 0 {
+
+			r.Bits = strconv.FormatInt(int64(a.Bits), 16)
+			r.Difficulty = getDifficultyRatio(a.Bits, s.cfg.ChainParams, a.Version)
+		}
+
+		result.Algos = append(result.Algos, r)
+	}
+
+	return result, nil
+}
+
+// handleGetBestBlock implements the getbestblock command.
+func handleGetBestBlock(
+
+	s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+
+	// All other "get block" commands give either the height, the hash, or both but require the block SHA.  This gets both for the best block.
+	best := s.cfg.Chain.BestSnapshot()
+
+	result := &json.GetBestBlockResult{
+
+		Hash:   best.Hash.String(),
+		Height: best.Height,
+	}
+
+	return result, nil
+}
+
+// handleGetBestBlockHash implements the getbestblockhash command.
+func handleGetBestBlockHash(
+
+	s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+
+	best := s.cfg.Chain.BestSnapshot()
+	return best.Hash.String(), nil
+}
+
+// handleGetBlock implements the getblock command.
+func handleGetBlock(
+
+	s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+
+	c := cmd.(*json.GetBlockCmd)
+
+	// Load the raw block bytes from the database.
+	hash, err := chainhash.NewHashFromStr(c.Hash)
+
+	if err != nil {
+
+		return nil, rpcDecodeHexError(c.Hash)
+	}
+
+	var blkBytes []byte
+
+	err = s.cfg.DB.View(func(dbTx database.Tx) error {
+
+		var err error
+		blkBytes, err = dbTx.FetchBlock(hash)
+		return err
+	})
+
+	if err != nil {
+
+		return nil, &json.RPCError{
 
 			Code:    json.ErrRPCBlockNotFound,
 			Message: "Block not found",
@@ -2802,9 +3136,10 @@ func handleGetBlock(
 
 	params := s.cfg.ChainParams
 	blockHeader := &blk.MsgBlock().Header
-	algoname := fork.GetAlgoName(blockHeader.Version, blockHeight)
-	a := fork.GetAlgoVer(algoname, blockHeight)
-	algoid := fork.GetAlgoID(algoname, blockHeight)
+	forks := s.cfg.Chain.HardForks()
+	algoname := forks.GetAlgoName(blockHeader.Version, blockHeight)
+	a := forks.GetAlgoVer(algoname, blockHeight)
+	algoid := forks.GetAlgoID(algoname, blockHeight)
 
 	blockReply := json.GetBlockVerboseResult{
 
@@ -2813,7 +3148,7 @@ func handleGetBlock(
 		VersionHex:    fmt.Sprintf("%08x", blockHeader.Version),
 		PowAlgoID:     algoid,
 		PowAlgo:       algoname,
-		PowHash:       blk.MsgBlock().BlockHashWithAlgos(blockHeight).String(),
+		PowHash:       blk.MsgBlock().BlockHashWithSchedule(forks, blockHeight).String(),
 		MerkleRoot:    blockHeader.MerkleRoot.String(),
 		PreviousHash:  blockHeader.PrevBlock.String(),
 		Nonce:         blockHeader.Nonce,
@@ -2874,6 +3209,7 @@ func handleGetBlockChainInfo(
 	params := s.cfg.ChainParams
 	chain := s.cfg.Chain
 	chainSnapshot := chain.BestSnapshot()
+	pruned, pruneHeight := chain.PruneState()
 
 	chainInfo := &json.GetBlockChainInfoResult{
 
@@ -2883,10 +3219,27 @@ func handleGetBlockChainInfo(
 		BestBlockHash: chainSnapshot.Hash.String(),
 		Difficulty:    getDifficultyRatio(chainSnapshot.Bits, params, 2),
 		MedianTime:    chainSnapshot.MedianTime.Unix(),
-		Pruned:        false,
+		Pruned:        pruned,
 		Bip9SoftForks: make(map[string]*json.Bip9SoftForkDescription),
 	}
 
+	if pruned {
+
+		chainInfo.PruneHeight = pruneHeight
+	}
+
+	// A loaded utxo snapshot is reported until the blocks before it are validated in the background.
+
+	if validation := chain.SnapshotValidation(); validation != nil {
+
+		chainInfo.UtxoSnapshot = &json.UtxoSnapshotValidationResult{
+
+			Height:          validation.Height,
+			ValidatedHeight: validation.Validated,
+			Failed:          validation.Failed,
+		}
+	}
+
 	// Next, populate the response with information describing the current status of soft-forks deployed via the super-majority block signalling mechanism.
 	height := chainSnapshot.Height
 
@@ -3532,6 +3885,28 @@ func handleGetCFilterHeader(
 	return hash.String(), nil
 }
 
+// handleGetChainTips implements the getchaintips command.
+func handleGetChainTips(
+
+	s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+
+	tips := s.cfg.Chain.ChainTips()
+	results := make([]json.GetChainTipsResult, 0, len(tips))
+
+	for _, tip := range tips {
+
+		results = append(results, json.GetChainTipsResult{
+
+			Height:    tip.Height,
+			Hash:      tip.Hash.String(),
+			BranchLen: tip.BranchLen,
+			Status:    tip.Status.String(),
+		})
+	}
+
+	return results, nil
+}
+
 // handleGetConnectionCount implements the getconnectioncount command.
 func handleGetConnectionCount(
 
@@ -3707,14 +4082,15 @@ func handleGetInfo(
 	best := s.cfg.Chain.BestSnapshot()
 	v := s.cfg.Chain.Index.LookupNode(&best.Hash)
 	foundcount, height := 0, best.Height
+	forks := s.cfg.Chain.HardForks()
 
-	switch fork.GetCurrent(height) {
+	switch forks.GetCurrent(height) {
 
 	case 0:
 
 		for foundcount < 9 && height > 0 {
 
-			switch fork.GetAlgoName(v.Header().Version, height) {
+			switch forks.GetAlgoName(v.Header().Version, height) {
 
 			case "sha256d":
 
@@ -3758,7 +4134,7 @@ func handleGetInfo(
 			TimeOffset:        int64(s.cfg.TimeSource.Offset().Seconds()),
 			Connections:       s.cfg.ConnMgr.ConnectedCount(),
 			Proxy:             *cfg.Proxy,
-			PowAlgoID:         fork.GetAlgoID(s.cfg.Algo, height),
+			PowAlgoID:         forks.GetAlgoID(s.cfg.Algo, height),
 			PowAlgo:           s.cfg.Algo,
 			Difficulty:        Difficulty,
 			DifficultySHA256D: dSHA256D,
@@ -3772,9 +4148,9 @@ func handleGetInfo(
 
 		for foundcount < 9 &&
 
-			height > fork.List[fork.GetCurrent(height)].ActivationHeight-512 {
+			height > forks[forks.GetCurrent(height)].ActivationHeight-512 {
 
-			switch fork.GetAlgoName(v.Header().Version, height) {
+			switch forks.GetAlgoName(v.Header().Version, height) {
 
 			case "blake2b":
 
@@ -3895,7 +4271,7 @@ func handleGetInfo(
 			TimeOffset:          int64(s.cfg.TimeSource.Offset().Seconds()),
 			Connections:         s.cfg.ConnMgr.ConnectedCount(),
 			Proxy:               *cfg.Proxy,
-			PowAlgoID:           fork.GetAlgoID(s.cfg.Algo, height),
+			PowAlgoID:           forks.GetAlgoID(s.cfg.Algo, height),
 			PowAlgo:             s.cfg.Algo,
 			Difficulty:          Difficulty,
 			DifficultyBlake2b:   dBlake2b,
@@ -3916,12 +4292,40 @@ func handleGetInfo(
 	return ret, nil
 }
 
+// handleGetMempoolEntry implements the getmempoolentry command.
+func handleGetMempoolEntry(
+
+	s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+
+	c := cmd.(*json.GetMempoolEntryCmd)
+	txHash, err := chainhash.NewHashFromStr(c.TxID)
+
+	if err != nil {
+
+		return nil, rpcDecodeHexError(c.TxID)
+	}
+
+	entry, err := s.cfg.TxMemPool.MempoolEntry(txHash)
+
+	if err != nil {
+
+		return nil, &json.RPCError{
+
+			Code:    json.ErrRPCNoTxInfo,
+			Message: "Transaction not in mempool",
+		}
+	}
+
+	return entry, nil
+}
+
 // handleGetMempoolInfo implements the getmempoolinfo command.
 func handleGetMempoolInfo(
 
 	s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
 
-	mempoolTxns := s.cfg.TxMemPool.TxDescs()
+	mp := s.cfg.TxMemPool
+	mempoolTxns := mp.TxDescs()
 	var numBytes int64
 
 	for _, txD := range mempoolTxns {
@@ -3931,8 +4335,12 @@ func handleGetMempoolInfo(
 
 	ret := &json.GetMempoolInfoResult{
 
-		Size:  int64(len(mempoolTxns)),
-		Bytes: numBytes,
+		Size:          int64(len(mempoolTxns)),
+		Bytes:         numBytes,
+		Usage:         mp.Usage(),
+		MaxMempool:    mp.MaxSize(),
+		MempoolMinFee: mp.MinFee().ToDUO(),
+		MinRelayTxFee: StateCfg.ActiveMinRelayTxFee.ToDUO(),
 	}
 
 	return ret, nil
@@ -3969,14 +4377,41 @@ func handleGetMiningInfo(
 	best := s.cfg.Chain.BestSnapshot()
 	v := s.cfg.Chain.Index.LookupNode(&best.Hash)
 	foundcount, height := 0, best.Height
+	forks := s.cfg.Chain.HardForks()
+
+	// The algorithm the node mines with is chosen per template by the scheduler of the template generator when the configured algorithm is "auto".
+	choice := s.cfg.Generator.AlgoScheduler().Choice()
+	schedule := &json.AlgoScheduleResult{
+		Height:  choice.Height,
+		Algo:    choice.Algo,
+		Version: choice.Version,
+		Scores:  make([]json.AlgoScoreResult, len(choice.Scores)),
+	}
 
-	switch fork.GetCurrent(height) {
+	for i, score := range choice.Scores {
+
+		schedule.Scores[i] = json.AlgoScoreResult{
+			Algo:         score.Algo,
+			Version:      score.Version,
+			Bits:         strconv.FormatInt(int64(score.Bits), 16),
+			NSPerOp:      score.NSPerOp,
+			SecsPerBlock: score.SecsPerBlock,
+		}
+	}
+	powAlgo := s.cfg.Algo
+
+	if powAlgo == mining.AutoAlgo {
+
+		powAlgo = choice.Algo
+	}
+
+	switch forks.GetCurrent(height) {
 
 	case 0:
 
 		for foundcount < 2 && height > 0 {
 
-			switch fork.GetAlgoName(v.Header().Version, height) {
+			switch forks.GetAlgoName(v.Header().Version, height) {
 
 			case "sha256d":
 
@@ -4003,7 +4438,7 @@ func handleGetMiningInfo(
 			height--
 		}
 
-		switch s.cfg.Algo {
+		switch powAlgo {
 
 		case "sha256d":
 			Difficulty = dSHA256D
@@ -4018,8 +4453,8 @@ func handleGetMiningInfo(
 			CurrentBlockSize:   best.BlockSize,
 			CurrentBlockWeight: best.BlockWeight,
 			CurrentBlockTx:     best.NumTxns,
-			PowAlgoID:          fork.GetAlgoID(s.cfg.Algo, height),
-			PowAlgo:            s.cfg.Algo,
+			PowAlgoID:          forks.GetAlgoID(powAlgo, height),
+			PowAlgo:            powAlgo,
 			Difficulty:         Difficulty,
 			DifficultySHA256D:  dSHA256D,
 			DifficultyScrypt:   dScrypt,
@@ -4029,14 +4464,15 @@ func handleGetMiningInfo(
 			NetworkHashPS:      networkHashesPerSec,
 			PooledTx:           uint64(s.cfg.TxMemPool.Count()),
 			TestNet:            *cfg.TestNet3,
+			AlgoSchedule:       schedule,
 		}
 
 	case 1:
 		foundcount, height := 0, best.Height
 
-		for foundcount < 9 && height > fork.List[fork.GetCurrent(height)].ActivationHeight-512 {
+		for foundcount < 9 && height > forks[forks.GetCurrent(height)].ActivationHeight-512 {
 
-			switch fork.GetAlgoName(v.Header().Version, height) {
+			switch forks.GetAlgoName(v.Header().Version, height) {
 
 			case "blake2b":
 
@@ -4126,7 +4562,7 @@ func handleGetMiningInfo(
 			height--
 		}
 
-		switch s.cfg.Algo {
+		switch powAlgo {
 
 		case "blake2b":
 			Difficulty = dBlake2b
@@ -4155,8 +4591,8 @@ func handleGetMiningInfo(
 			CurrentBlockSize:    best.BlockSize,
 			CurrentBlockWeight:  best.BlockWeight,
 			CurrentBlockTx:      best.NumTxns,
-			PowAlgoID:           fork.GetAlgoID(s.cfg.Algo, height),
-			PowAlgo:             s.cfg.Algo,
+			PowAlgoID:           forks.GetAlgoID(powAlgo, height),
+			PowAlgo:             powAlgo,
 			Difficulty:          Difficulty,
 			DifficultyBlake2b:   dBlake2b,
 			DifficultyBlake14lr: dBlake14lr,
@@ -4174,6 +4610,7 @@ func handleGetMiningInfo(
 			NetworkHashPS:       networkHashesPerSec,
 			PooledTx:            uint64(s.cfg.TxMemPool.Count()),
 			TestNet:             *cfg.TestNet3,
+			AlgoSchedule:        schedule,
 		}
 
 	}
@@ -4289,7 +4726,7 @@ func handleGetNetworkHashPS(
 
 		} else {
 
-			totalWork.Add(totalWork, blockchain.CalcWork(header.Bits, best.Height+1, header.Version))
+			totalWork.Add(totalWork, blockchain.CalcWork(s.cfg.Chain.HardForks(), header.Bits, best.Height+1, header.Version))
 
 			if minTimestamp.After(header.Timestamp) {
 
@@ -4317,6 +4754,173 @@ func handleGetNetworkHashPS(
 	return hashesPerSec.Int64(), nil
 }
 
+// handleGetNetworkInfo implements the getnetworkinfo command.
+func handleGetNetworkInfo(
+
+	s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+
+	var inbound, outbound int32
+
+	for _, peer := range s.cfg.ConnMgr.ConnectedPeers() {
+
+		if peer.ToPeer().Inbound() {
+
+			inbound++
+
+		} else {
+
+			outbound++
+		}
+	}
+
+	msg := wire.MsgVersion{UserAgent: wire.DefaultUserAgent}
+	err := msg.AddUserAgent(userAgentName, userAgentVersion,
+		*cfg.UserAgentComments...)
+
+	if err != nil {
+
+		return nil, internalRPCError(err.Error(), "Failed to build user agent")
+	}
+
+	// Tor hidden services can be reached through the onion proxy or, when there is none, through the regular proxy.
+	onionProxy := *cfg.OnionProxy
+
+	if onionProxy == "" {
+
+		onionProxy = *cfg.Proxy
+	}
+
+	onionReachable := *cfg.Onion && onionProxy != ""
+	ipv4Reachable, ipv6Reachable := reachableNetworks()
+	networks := []json.NetworksResult{
+
+		{
+			Name:                      "ipv4",
+			Limited:                   !ipv4Reachable,
+			Reachable:                 ipv4Reachable,
+			Proxy:                     *cfg.Proxy,
+			ProxyRandomizeCredentials: *cfg.TorIsolation,
+		},
+		{
+			Name:                      "ipv6",
+			Limited:                   !ipv6Reachable,
+			Reachable:                 ipv6Reachable,
+			Proxy:                     *cfg.Proxy,
+			ProxyRandomizeCredentials: *cfg.TorIsolation,
+		},
+		{
+			Name:                      "onion",
+			Limited:                   !onionReachable,
+			Reachable:                 onionReachable,
+			Proxy:                     onionProxy,
+			ProxyRandomizeCredentials: *cfg.TorIsolation,
+		},
+	}
+
+	localAddrs := s.cfg.ConnMgr.LocalAddresses()
+	localAddresses := make([]json.LocalAddressesResult, 0, len(localAddrs))
+
+	for _, addr := range localAddrs {
+
+		localAddresses = append(localAddresses, json.LocalAddressesResult{
+
+			Address: addr.Address,
+			Port:    addr.Port,
+			Score:   int32(addr.Score),
+		})
+	}
+
+	connections := s.cfg.ConnMgr.ConnectedCount()
+	reply := &json.GetNetworkInfoResult{
+
+		Version:         int32(1000000*appMajor + 10000*appMinor + 100*appPatch),
+		SubVersion:      msg.UserAgent,
+		ProtocolVersion: int32(maxProtocolVersion),
+		LocalServices:   fmt.Sprintf("%016x", uint64(s.cfg.Services)),
+		LocalRelay:      !*cfg.BlocksOnly,
+		TimeOffset:      int64(s.cfg.TimeSource.Offset().Seconds()),
+		Connections:     connections,
+		ConnectionsIn:   inbound,
+		ConnectionsOut:  outbound,
+		NetworkActive:   s.cfg.ConnMgr.NetworkActive(),
+		Networks:        networks,
+		RelayFee:        StateCfg.ActiveMinRelayTxFee.ToDUO(),
+		IncrementalFee:  s.cfg.TxMemPool.IncrementalRelayFee().ToDUO(),
+		LocalAddresses:  localAddresses,
+	}
+
+	return reply, nil
+}
+
+// reachableNetworks returns whether peers on the IPv4 and IPv6 networks can be reached. All connections go through the proxy when one is configured, otherwise a network is reachable when the node listens on a routable address of it, or the host has one when it listens on all interfaces or not at all.
+func reachableNetworks() (ipv4, ipv6 bool) {
+
+	if *cfg.Proxy != "" {
+
+		return true, true
+	}
+
+	var ips []net.IP
+	listenAll := *cfg.DisableListen
+
+	if !*cfg.DisableListen {
+
+		for _, addr := range *cfg.Listeners {
+
+			host, _, err := net.SplitHostPort(addr)
+
+			if err != nil {
+
+				continue
+			}
+
+			if ip := net.ParseIP(host); ip != nil && !ip.IsUnspecified() {
+
+				ips = append(ips, ip)
+
+			} else {
+
+				listenAll = true
+			}
+		}
+	}
+
+	if listenAll {
+
+		ifaceAddrs, err := net.InterfaceAddrs()
+
+		if err == nil {
+
+			for _, addr := range ifaceAddrs {
+
+				if ipNet, ok := addr.(*net.IPNet); ok {
+
+					ips = append(ips, ipNet.IP)
+				}
+			}
+		}
+	}
+
+	for _, ip := range ips {
+
+		if ip.IsLoopback() || ip.IsLinkLocalUnicast() {
+
+			continue
+		}
+
+		if ip.To4() != nil {
+
+			ipv4 = true
+
+		} else {
+
+			ipv6 = true
+		}
+	}
+
+	return
+}
+
 // handleGetPeerInfo implements the getpeerinfo command.
 func handleGetPeerInfo(
 
@@ -4352,6 +4956,16 @@ func handleGetPeerInfo(
 			BanScore:       int32(p.BanScore()),
 			FeeFilter:      p.FeeFilter(),
 			SyncNode:       statsSnap.ID == syncPeerID,
+
+			CmpctBlockVersion:        statsSnap.CmpctBlockVersion,
+			CmpctHBTo:                statsSnap.CmpctHighBandwidthTo,
+			CmpctHBFrom:              statsSnap.CmpctHighBandwidthFrom,
+			CmpctBlocksSent:          statsSnap.CmpctBlocksSent,
+			CmpctBlocksRecv:          statsSnap.CmpctBlocksRecv,
+			CmpctBlocksReconstructed: statsSnap.CmpctBlocksReconstructed,
+			CmpctTxnRequested:        statsSnap.CmpctTxnRequested,
+			CmpctBlockLatency: float64(
+				statsSnap.CmpctBlockLatency.Nanoseconds()) / 1000,
 		}
 
 		if p.ToPeer().LastPingNonce() != 0 {
@@ -4670,6 +5284,63 @@ func handleGetTxOut(
 	return txOutReply, nil
 }
 
+// handleGetTxOutSetInfo implements the gettxoutsetinfo command.
+func handleGetTxOutSetInfo(
+
+	s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+
+	info, err := s.cfg.Chain.FetchUtxoSetInfo()
+
+	if err != nil {
+
+		return nil, internalRPCError("Failed to read utxo set: "+err.Error(),
+			"")
+	}
+
+	return &json.GetTxOutSetInfoResult{
+
+		Height:          info.Height,
+		BestBlock:       info.Hash.String(),
+		Transactions:    info.Transactions,
+		TxOuts:          info.Outputs,
+		BytesSerialized: info.SerializedSize,
+		HashSerialized:  info.SetHash.String(),
+		TotalAmount:     util.Amount(info.TotalAmount).ToDUO(),
+	}, nil
+}
+
+// handleGetWorkerStats implements the getworkerstats command.
+func handleGetWorkerStats(
+
+	s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+
+	stats := s.cfg.MinerController.WorkerStats()
+	result := make([]json.GetWorkerStatsResult, len(stats))
+
+	for i := range stats {
+
+		var lastShare int64
+
+		if !stats[i].LastShare.IsZero() {
+
+			lastShare = stats[i].LastShare.Unix()
+		}
+		result[i] = json.GetWorkerStatsResult{
+			Worker:     stats[i].Worker,
+			Sessions:   stats[i].Sessions,
+			LoginTime:  stats[i].LoginTime.Unix(),
+			LastShare:  lastShare,
+			Accepted:   stats[i].Accepted,
+			Stale:      stats[i].Stale,
+			Duplicate:  stats[i].Duplicate,
+			Invalid:    stats[i].Invalid,
+			Blocks:     stats[i].Blocks,
+			AlgoShares: stats[i].AlgoShares,
+		}
+	}
+	return result, nil
+}
+
 // handleHelp implements the help command.
 func handleHelp(
 
@@ -4722,6 +5393,69 @@ func handleHelp(
 	return help, nil
 }
 
+// handleImportMempool implements the importmempool command.
+func handleImportMempool(
+
+	s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+
+	c := cmd.(*json.ImportMempoolCmd)
+	accepted, failed, err := s.cfg.TxMemPool.LoadFile(c.FilePath, closeChan)
+
+	// Relay the transactions that were accepted, also those before an error stopped the import.
+	if len(accepted) > 0 {
+
+		s.cfg.ConnMgr.RelayTransactions(accepted)
+		s.NotifyNewTransactions(accepted)
+	}
+
+	if err != nil {
+
+		return nil, &json.RPCError{
+
+			Code:    json.ErrRPCMisc,
+			Message: "Failed to import mempool: " + err.Error(),
+		}
+
+	}
+
+	return &json.ImportMempoolResult{
+
+		Accepted: len(accepted),
+		Failed:   failed,
+	}, nil
+}
+
+// handleInvalidateBlock implements the invalidateblock command.
+func handleInvalidateBlock(
+
+	s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+
+	c := cmd.(*json.InvalidateBlockCmd)
+	return changeBlockStatus(s, c.BlockHash, s.cfg.Chain.InvalidateBlock)
+}
+
+// handleListBanned implements the listbanned command.
+func handleListBanned(
+
+	s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+
+	bans := s.cfg.ConnMgr.ListBanned()
+	results := make([]json.ListBannedResult, 0, len(bans))
+
+	for _, ban := range bans {
+
+		results = append(results, json.ListBannedResult{
+
+			Address:     ban.Subnet,
+			BanCreated:  ban.Created,
+			BannedUntil: ban.Until,
+			BanReason:   ban.Reason,
+		})
+	}
+
+	return results, nil
+}
+
 // handleNode handles node commands.
 func handleNode(
 
@@ -4872,6 +5606,90 @@ func handlePing(
 	return nil, nil
 }
 
+// handlePreciousBlock implements the preciousblock command.
+func handlePreciousBlock(
+
+	s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+
+	c := cmd.(*json.PreciousBlockCmd)
+	return changeBlockStatus(s, c.BlockHash, s.cfg.Chain.PreciousBlock)
+}
+
+// handleReconsiderBlock implements the reconsiderblock command.
+func handleReconsiderBlock(
+
+	s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+
+	c := cmd.(*json.ReconsiderBlockCmd)
+	return changeBlockStatus(s, c.BlockHash, s.cfg.Chain.ReconsiderBlock)
+}
+
+// changeBlockStatus decodes the block hash of the invalidateblock, preciousblock and reconsiderblock commands and applies the matching chain operation to the block, which returns nothing unless there is an error.
+func changeBlockStatus(
+
+	s *rpcServer, hashStr string, change func(*chainhash.Hash) error) (interface{}, error) {
+
+	hash, err := chainhash.NewHashFromStr(hashStr)
+
+	if err != nil {
+
+		return nil, rpcDecodeHexError(hashStr)
+	}
+
+	if !s.cfg.Chain.Index.HaveBlock(hash) {
+
+		return nil, &json.RPCError{
+
+			Code:    json.ErrRPCBlockNotFound,
+			Message: "Block not found",
+		}
+
+	}
+
+	if err := change(hash); err != nil {
+
+		return nil, &json.RPCError{
+
+			Code:    json.ErrRPCDatabase,
+			Message: err.Error(),
+		}
+
+	}
+
+	return nil, nil
+}
+
+// handleSaveMempool implements the savemempool command.
+func handleSaveMempool(
+
+	s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+
+	// Saving before the mempool of the last shutdown is loaded would lose the transactions not loaded yet.
+
+	if !s.cfg.MempoolLoaded() {
+
+		return nil, &json.RPCError{
+
+			Code:    json.ErrRPCMisc,
+			Message: "The mempool was not loaded yet",
+		}
+
+	}
+
+	count, err := s.cfg.TxMemPool.SaveFile(s.cfg.MempoolFile)
+
+	if err != nil {
+
+		return nil, internalRPCError("Failed to save mempool: "+err.Error(), "")
+	}
+
+	return &json.SaveMempoolResult{
+
+		Filename:     s.cfg.MempoolFile,
+		Transactions: count,
+	}, nil
+}
+
 // handleSearchRawTransactions implements the searchrawtransactions command.
 func handleSearchRawTransactions(
 
@@ -5277,6 +6095,97 @@ func handleSendRawTransaction(
 	return tx.Hash().String(), nil
 }
 
+// handleSetBan implements the setban command.
+func handleSetBan(
+
+	s *rpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+
+	c := cmd.(*json.SetBanCmd)
+	subnet, err := parseBanSubnet(c.Subnet)
+
+	if err != nil {
+
+		return nil, &json.RPCError{
+
+			Code:    json.ErrRPCClientInvalidIPOrSubnet,
+			Message: err.Error(),
+		}
+
+	}
+
+	switch c.SubCmd {
+
+	case json.SBAdd:
+		// A ban time of zero bans for the configured ban duration.
+		until := time.Now().Add(*cfg.BanDuration)
+
+		if c.BanTime != nil && *c.BanTime != 0 {
+
+			if c.Absolute != nil && *c.Absolute {
+
+				until = time.Unix(*c.BanTime, 0)
+
+			} else {
+
+				until = time.Now().Add(time.Duration(*c.BanTime) * time.Second)
+			}
+
+		}
+
+		if !until.After(time.Now()) {
+
+			return nil, &json.RPCError{
+
+				Code:    json.ErrRPCInvalidParameter,
+				Message: "The ban time must be in the future",
+			}
+
+		}
+
+		err = s.cfg.ConnMgr.Ban(subnet, until, "manually added")
+
+		if err == errAlreadyBanned {
+
+			return nil, &json.RPCError{
+
+				Code:    json.ErrRPCClientNodeAlreadyAdded,
+				Message: "The address or subnet is already banned",
+			}
+
+		}
+
+	case json.SBRemove:
+		err = s.cfg.ConnMgr.Unban(subnet)
+
+		if err == errNotBanned {
+
+			return nil, &json.RPCError{
+
+				Code:    json.ErrRPCClientInvalidIPOrSubnet,
+				Message: "The address or subnet is not banned",
+			}
+
+		}
+
+	default:
+
+		return nil, &json.RPCError{
+
+			Code:    json.ErrRPCInvalidParameter,
+			Message: "invalid subcommand for setban",
+		}
+
+	}
+
+	if err != nil {
+
+		return nil, internalRPCError("Failed to store the ban list: "+err.Error(), "")
+	}
+
+	// no data returned unless an error.
+	return nil, nil
+}
+
 // handleSetGenerate implements the setgenerate command.
 func handleSetGenerate(
 
@@ -5681,6 +6590,7 @@ func parseCmd(
 ) *parsedRPCCmd {
 
 	var parsedCmd parsedRPCCmd
+	parsedCmd.jsonrpc = request.Jsonrpc
 	parsedCmd.id = request.ID
 	parsedCmd.method = request.Method
 	cmd, err := json.UnmarshalCmd(request)
@@ -5783,6 +6693,7 @@ func verifyChain(
 ) error {
 
 	best := s.cfg.Chain.BestSnapshot()
+	forks := s.cfg.Chain.HardForks()
 	finishHeight := best.Height - depth
 
 	if finishHeight < 0 {
@@ -5814,12 +6725,12 @@ func verifyChain(
 			return err
 		}
 
-		powLimit := fork.GetMinDiff(fork.GetAlgoName(block.MsgBlock().Header.Version, height), height)
+		powLimit := forks.GetMinDiff(forks.GetAlgoName(block.MsgBlock().Header.Version, height), height)
 		// Level 1 does basic chain sanity checks.
 
 		if level > 0 {
 
-			err := blockchain.CheckBlockSanity(block, powLimit, s.cfg.TimeSource, true, block.Height(), s.cfg.ChainParams.Name == "testnet")
+			err := blockchain.CheckBlockSanity(block, powLimit, s.cfg.TimeSource, true, block.Height(), s.cfg.Chain.HardForks())
 
 			if err != nil {
 
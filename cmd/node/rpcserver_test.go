@@ -0,0 +1,144 @@
+package node
+
+import (
+	js "encoding/json"
+	"testing"
+
+	"git.parallelcoin.io/dev/pod/pkg/pod"
+	"git.parallelcoin.io/dev/pod/pkg/rpc/json"
+)
+
+// TestProcessBatchRequest ensures the requests of a batch are answered in order with their own results and errors, that notifications are left out of the reply, and that empty and oversized batches get a single error reply.
+func TestProcessBatchRequest(
+	t *testing.T,
+
+) {
+
+	maxBatchSize := 4
+	quirks := false
+	oldCfg := cfg
+	cfg = &pod.Config{
+
+		RPCMaxBatchSize: &maxBatchSize,
+		RPCQuirks:       &quirks,
+	}
+
+	defer func() {
+
+		cfg = oldCfg
+	}()
+
+	s := &rpcServer{}
+	closeChan := make(chan struct{})
+	batch := []byte(`[
+		{"jsonrpc":"2.0","method":"uptime","params":[],"id":1},
+		{"jsonrpc":"2.0","method":"nosuchmethod","params":[],"id":2},
+		{"jsonrpc":"2.0","method":"uptime","params":[]},
+		5
+	]`)
+
+	if !json.IsBatchRequest(batch) {
+
+		t.Fatal("IsBatchRequest: batch not recognised")
+	}
+
+	var replies []json.Response
+
+	if err := js.Unmarshal(s.processBatchRequest(batch, true, closeChan),
+		&replies); err != nil {
+
+		t.Fatalf("processBatchRequest: unmarshalling reply: %v", err)
+	}
+
+	if len(replies) != 3 {
+
+		t.Fatalf("processBatchRequest: got %d replies, want 3 with the "+
+			"notification left out", len(replies))
+	}
+
+	tests := []struct {
+		name string
+		id   interface{}
+		code json.RPCErrorCode
+	}{
+		{"known method", float64(1), 0},
+		{"unknown method", float64(2), json.ErrRPCMethodNotFound.Code},
+		{"not a request object", nil, json.ErrRPCInvalidRequest.Code},
+	}
+
+	for i, test := range tests {
+
+		reply := replies[i]
+		var id interface{}
+
+		if reply.ID != nil {
+
+			id = *reply.ID
+		}
+
+		if id != test.id {
+
+			t.Errorf("%s: got id %v, want %v", test.name, id, test.id)
+		}
+
+		switch {
+
+		case test.code == 0 && reply.Error != nil:
+
+			t.Errorf("%s: unexpected error %v", test.name, reply.Error)
+
+		case test.code == 0 && reply.Result == nil:
+
+			t.Errorf("%s: missing result", test.name)
+
+		case test.code != 0 && (reply.Error == nil ||
+			reply.Error.Code != test.code):
+
+			t.Errorf("%s: got error %v, want code %d", test.name,
+				reply.Error, test.code)
+		}
+
+	}
+
+	// A batch of nothing but notifications gets no reply at all.
+	notifications := []byte(`[{"jsonrpc":"2.0","method":"uptime","params":[]}]`)
+
+	if reply := s.processBatchRequest(notifications, true,
+		closeChan); reply != nil {
+
+		t.Errorf("processBatchRequest: got reply %s to notifications, "+
+			"want none", reply)
+	}
+
+	// Empty and oversized batches are refused as a whole.
+	request := `{"jsonrpc":"2.0","method":"uptime","params":[],"id":1}`
+	oversized := "[" + request
+
+	for i := 0; i < maxBatchSize; i++ {
+
+		oversized += "," + request
+	}
+
+	oversized += "]"
+
+	for _, body := range []string{"[]", oversized} {
+
+		var reply json.Response
+
+		if err := js.Unmarshal(s.processBatchRequest([]byte(body), true,
+			closeChan), &reply); err != nil {
+
+			t.Fatalf("processBatchRequest: unmarshalling reply: %v", err)
+		}
+
+		if reply.Error == nil ||
+			reply.Error.Code != json.ErrRPCInvalidRequest.Code {
+
+			t.Errorf("processBatchRequest: got error %v for a batch of "+
+				"%d bytes, want code %d", reply.Error, len(body),
+				json.ErrRPCInvalidRequest.Code)
+		}
+
+	}
+
+}
@@ -31,6 +31,9 @@ var helpDescsEnUS = map[string]string{
 	"addnode-addr":      "IP address and port of the peer to operate on",
 	"addnode-subcmd":    "'add' to add a persistent peer, 'remove' to remove a persistent peer, or 'onetry' to try a single connection to a peer",
 
+	// ClearBannedCmd help.
+	"clearbanned--synopsis": "Lifts all bans of addresses and subnets.",
+
 	// NodeCmd help.
 	"node--synopsis":     "Attempts to add or remove a peer.",
 	"node-subcmd":        "'disconnect' to remove all matching non-persistent peers, 'remove' to remove a persistent peer, or 'connect' to connect to a peer",
@@ -112,6 +115,18 @@ var helpDescsEnUS = map[string]string{
 	"decodescript--synopsis": "Returns a JSON object with information about the provided hex-encoded script.",
 	"decodescript-hexscript": "Hex-encoded script",
 
+	// DumpTxOutSetResult help.
+	"dumptxoutsetresult-coins_written": "Number of unspent transaction outputs written",
+	"dumptxoutsetresult-base_hash":     "Hash of the block the snapshot is taken at",
+	"dumptxoutsetresult-base_height":   "Height of the block the snapshot is taken at",
+	"dumptxoutsetresult-path":          "Path of the snapshot file",
+	"dumptxoutsetresult-txoutset_hash": "Hash of the unspent transaction output set, as reported by gettxoutsetinfo",
+
+	// DumpTxOutSetCmd help.
+	"dumptxoutset--synopsis": "Writes a snapshot of the unspent transaction output set at the best block, which a new node can be bootstrapped from with the loadsnapshot option.\n" +
+		"The snapshot can only be loaded when its block and hash are in the chain parameters of the node.",
+	"dumptxoutset-path": "Path of the snapshot file, relative to the data directory unless absolute",
+
 	// EstimateFeeCmd help.
 	"estimatefee--synopsis": "Estimate the fee per kilobyte in satoshis " +
 		"required for a transaction to be mined before a certain number of " +
@@ -144,6 +159,26 @@ var helpDescsEnUS = map[string]string{
 	"getaddednodeinfo--condition1": "dns=true",
 	"getaddednodeinfo--result0":    "List of added peers",
 
+	// AlgoStatsResult help.
+	"algostatsresult-algo":          "The name of the algorithm",
+	"algostatsresult-version":       "The block version number that identifies the algorithm",
+	"algostatsresult-blocks":        "The number of blocks found by the algorithm in the range",
+	"algostatsresult-lastheight":    "The height of the newest block of the algorithm in the range, or -1 if there is none",
+	"algostatsresult-bits":          "The difficulty bits of the newest block of the algorithm in the range",
+	"algostatsresult-difficulty":    "The proof-of-work difficulty of the newest block of the algorithm as a multiple of the minimum difficulty",
+	"algostatsresult-networkhashps": "Estimated network hashes per second of the algorithm over the range",
+	"algostatsresult-avginterval":   "The average number of seconds between blocks of the algorithm over the range",
+
+	// GetAlgoStatsResult help.
+	"getalgostatsresult-startheight": "The height of the first block in the range",
+	"getalgostatsresult-endheight":   "The height of the last block in the range",
+	"getalgostatsresult-algos":       "Statistics for each algorithm active at the end height, ordered by block version",
+
+	// GetAlgoStatsCmd help.
+	"getalgostats--synopsis": "Returns the block count, difficulty, estimated network hashes per second and average block interval of each mining algorithm over a range of blocks.",
+	"getalgostats-blocks":    "The number of blocks in the range",
+	"getalgostats-height":    "The height of the last block in the range or -1 for current best chain block height",
+
 	// GetBestBlockResult help.
 	"getbestblockresult-hash":   "Hex-encoded bytes of the best block hash",
 	"getbestblockresult-height": "Height of the best block",
@@ -184,6 +219,12 @@ var helpDescsEnUS = map[string]string{
 	"getblockchaininforesult-bip9_softforks--key":   "bip9_softforks",
 	"getblockchaininforesult-bip9_softforks--value": "An object describing a particular BIP009 deployment",
 	"getblockchaininforesult-bip9_softforks--desc":  "The status of any defined BIP0009 soft-fork deployments",
+	"getblockchaininforesult-utxosnapshot":          "The background validation of the blocks before a loaded utxo snapshot, until they are validated",
+
+	// UtxoSnapshotValidationResult help.
+	"utxosnapshotvalidationresult-height":          "The height of the block the utxo snapshot was taken at",
+	"utxosnapshotvalidationresult-validatedheight": "The height up to which the blocks before the snapshot are validated",
+	"utxosnapshotvalidationresult-failed":          "Whether the blocks before the snapshot are invalid, in which case the node stops and the chain must be synced from scratch",
 
 	// SoftForkDescription help.
 	"softforkdescription-reject":  "The current activation status of the softfork",
@@ -341,6 +382,15 @@ var helpDescsEnUS = map[string]string{
 	"getcfilterheader-hash":       "The hash of the block",
 	"getcfilterheader--result0":   "The block's gcs filter header",
 
+	// GetChainTipsResult help.
+	"getchaintipsresult-height":    "The height of the chain tip",
+	"getchaintipsresult-hash":      "The hash of the chain tip",
+	"getchaintipsresult-branchlen": "The number of blocks between the chain tip and the point where its chain forks from the main chain, which is 0 for the main chain",
+	"getchaintipsresult-status":    "The status of the chain (active, valid-fork, valid-headers, headers-only or invalid)",
+
+	// GetChainTipsCmd help.
+	"getchaintips--synopsis": "Returns information about the tips of the main chain and of all known side chains.",
+
 	// GetConnectionCountCmd help.
 	"getconnectioncount--synopsis": "Returns the number of active connections to other peers.",
 	"getconnectioncount--result0":  "The number of connections",
@@ -402,12 +452,48 @@ var helpDescsEnUS = map[string]string{
 	// GetInfoCmd help.
 	"getinfo--synopsis": "Returns a JSON object containing various state info.",
 
+	// GetMempoolEntryCmd help.
+	"getmempoolentry--synopsis": "Returns mempool data for the given transaction.",
+	"getmempoolentry-txid":      "The hash of the transaction, which must be in the mempool",
+
+	// GetMempoolEntryResult help.
+	"getmempoolentryresult-size":               "Virtual transaction size as defined in BIP 141 (deprecated, same as vsize)",
+	"getmempoolentryresult-vsize":              "Virtual transaction size as defined in BIP 141",
+	"getmempoolentryresult-weight":             "Transaction weight as defined in BIP 141",
+	"getmempoolentryresult-fee":                "Transaction fee in DUO (deprecated, see fees.base)",
+	"getmempoolentryresult-modifiedfee":        "Transaction fee with fee deltas used for mining priority in DUO (deprecated, see fees.modified)",
+	"getmempoolentryresult-time":               "Local time the transaction entered the pool in seconds since 1 Jan 1970 GMT",
+	"getmempoolentryresult-height":             "Block height when the transaction entered the pool",
+	"getmempoolentryresult-startingpriority":   "Priority when the transaction entered the pool",
+	"getmempoolentryresult-currentpriority":    "Current priority",
+	"getmempoolentryresult-descendantcount":    "Number of in-mempool descendant transactions, including this one",
+	"getmempoolentryresult-descendantsize":     "Virtual size of in-mempool descendants, including this one",
+	"getmempoolentryresult-descendantfees":     "Fees of in-mempool descendants, including this one, in satoshis (deprecated, see fees.descendant)",
+	"getmempoolentryresult-ancestorcount":      "Number of in-mempool ancestor transactions, including this one",
+	"getmempoolentryresult-ancestorsize":       "Virtual size of in-mempool ancestors, including this one",
+	"getmempoolentryresult-ancestorfees":       "Fees of in-mempool ancestors, including this one, in satoshis (deprecated, see fees.ancestor)",
+	"getmempoolentryresult-wtxid":              "Hash of the serialized transaction, including witness data",
+	"getmempoolentryresult-fees":               "Fees of the transaction and of its in-mempool ancestors and descendants",
+	"getmempoolentryresult-depends":            "Unconfirmed transactions used as inputs for this transaction",
+	"getmempoolentryresult-spentby":            "Unconfirmed transactions spending outputs from this transaction",
+	"getmempoolentryresult-bip125-replaceable": "Whether this transaction could be replaced due to BIP 125 (replace-by-fee)",
+
+	// MempoolFees help.
+	"mempoolfees-base":       "Transaction fee in DUO",
+	"mempoolfees-modified":   "Transaction fee with fee deltas used for mining priority in DUO",
+	"mempoolfees-ancestor":   "Fees of in-mempool ancestors, including this one, in DUO",
+	"mempoolfees-descendant": "Fees of in-mempool descendants, including this one, in DUO",
+
 	// GetMempoolInfoCmd help.
 	"getmempoolinfo--synopsis": "Returns memory pool information",
 
 	// GetMempoolInfoResult help.
-	"getmempoolinforesult-bytes": "Size in bytes of the mempool",
-	"getmempoolinforesult-size":  "Number of transactions in the mempool",
+	"getmempoolinforesult-bytes":         "Size in bytes of the mempool",
+	"getmempoolinforesult-size":          "Number of transactions in the mempool",
+	"getmempoolinforesult-usage":         "Estimated memory usage in bytes of the mempool",
+	"getmempoolinforesult-maxmempool":    "Maximum memory usage in bytes of the mempool, beyond which the transactions paying the lowest fees are evicted, 0 for no limit",
+	"getmempoolinforesult-mempoolminfee": "Minimum fee rate in DUO/kB for a transaction to be accepted, which rises above minrelaytxfee after evictions and decays back over time",
+	"getmempoolinforesult-minrelaytxfee": "Minimum fee rate in DUO/kB for a transaction to be relayed",
 
 	// GetMiningInfoResult help.
 	"getmininginforesult-blocks":             "Height of the latest best block",
@@ -422,6 +508,20 @@ var helpDescsEnUS = map[string]string{
 	"getmininginforesult-networkhashps":      "Estimated network hashes per second for the most recent blocks",
 	"getmininginforesult-pooledtx":           "Number of transactions in the memory pool",
 	"getmininginforesult-testnet":            "Whether or not server is using testnet",
+	"getmininginforesult-algoschedule":       "The algorithm chosen by the algorithm scheduler for the next block template",
+
+	// AlgoScheduleResult help.
+	"algoscheduleresult-height":  "Height of the block the choice is for",
+	"algoscheduleresult-algo":    "Name of the chosen algorithm",
+	"algoscheduleresult-version": "Block version of the chosen algorithm",
+	"algoscheduleresult-scores":  "Evaluation of all algorithms active at the height, best first",
+
+	// AlgoScoreResult help.
+	"algoscoreresult-algo":         "Name of the algorithm",
+	"algoscoreresult-version":      "Block version of the algorithm",
+	"algoscoreresult-bits":         "Required difficulty of the next block of the algorithm in compact form",
+	"algoscoreresult-nsperop":      "Cost of one hash of the algorithm in nanoseconds",
+	"algoscoreresult-secsperblock": "Expected seconds for one thread to find a block with the algorithm",
 
 	// GetMiningInfoCmd help.
 	"getmininginfo--synopsis": "Returns a JSON object containing mining-related information.",
@@ -432,6 +532,38 @@ var helpDescsEnUS = map[string]string{
 	"getnetworkhashps-height":    "Perform estimate ending with this height or -1 for current best chain block height",
 	"getnetworkhashps--result0":  "Estimated hashes per second",
 
+	// GetNetworkInfoCmd help.
+	"getnetworkinfo--synopsis": "Returns a JSON object containing various state info regarding P2P networking.",
+
+	// GetNetworkInfoResult help.
+	"getnetworkinforesult-version":         "The version of the server",
+	"getnetworkinforesult-subversion":      "The user agent the server advertises to its peers",
+	"getnetworkinforesult-protocolversion": "The latest supported protocol version",
+	"getnetworkinforesult-localservices":   "The services the server offers to the network, as a hex string",
+	"getnetworkinforesult-localrelay":      "Whether transactions are relayed to peers",
+	"getnetworkinforesult-timeoffset":      "The time offset in seconds",
+	"getnetworkinforesult-connections":     "The total number of connected peers",
+	"getnetworkinforesult-connections_in":  "The number of inbound connections",
+	"getnetworkinforesult-connections_out": "The number of outbound connections",
+	"getnetworkinforesult-networkactive":   "Whether the node is listening for peers or connecting to them",
+	"getnetworkinforesult-networks":        "Information per network",
+	"getnetworkinforesult-relayfee":        "Minimum relay fee for transactions in DUO/kB",
+	"getnetworkinforesult-incrementalfee":  "Minimum fee increment for mempool limiting or replacement in DUO/kB",
+	"getnetworkinforesult-localaddresses":  "The addresses the server advertises to its peers",
+	"getnetworkinforesult-warnings":        "Any network and blockchain warnings",
+
+	// NetworksResult help.
+	"networksresult-name":                        "The network (ipv4, ipv6 or onion)",
+	"networksresult-limited":                     "Whether connections to the network are disabled",
+	"networksresult-reachable":                   "Whether connections to the network can be made",
+	"networksresult-proxy":                       "The proxy used for the network, or empty if there is none",
+	"networksresult-proxy_randomize_credentials": "Whether random credentials are used with the proxy",
+
+	// LocalAddressesResult help.
+	"localaddressesresult-address": "The network address",
+	"localaddressesresult-port":    "The network port",
+	"localaddressesresult-score":   "The relative score of the address",
+
 	// GetNetTotalsCmd help.
 	"getnettotals--synopsis": "Returns a JSON object containing network traffic statistics.",
 
@@ -463,6 +595,15 @@ var helpDescsEnUS = map[string]string{
 	"getpeerinforesult-feefilter":      "The requested minimum fee a transaction must have to be announced to the peer",
 	"getpeerinforesult-syncnode":       "Whether or not the peer is the sync peer",
 
+	"getpeerinforesult-cmpctblockversion":        "The compact block version announced by the peer, omitted when compact blocks are not supported",
+	"getpeerinforesult-bip152_hb_to":             "Whether new blocks are announced to the peer by sending the compact block directly",
+	"getpeerinforesult-bip152_hb_from":           "Whether the peer was asked to announce new blocks by sending the compact block directly",
+	"getpeerinforesult-cmpctblockssent":          "Number of compact blocks sent to the peer",
+	"getpeerinforesult-cmpctblocksrecv":          "Number of compact blocks received from the peer",
+	"getpeerinforesult-cmpctblocksreconstructed": "Number of compact blocks from the peer rebuilt from the memory pool without requesting transactions",
+	"getpeerinforesult-cmpcttxnrequested":        "Number of transactions of compact blocks requested from the peer as they were not in the memory pool",
+	"getpeerinforesult-cmpctblocklatency":        "Average number of microseconds from receiving a compact block from the peer until it was rebuilt",
+
 	// GetPeerInfoCmd help.
 	"getpeerinfo--synopsis": "Returns data about each connected network peer as an array of json objects.",
 
@@ -505,6 +646,36 @@ var helpDescsEnUS = map[string]string{
 	"gettxout-vout":           "The index of the output",
 	"gettxout-includemempool": "Include the mempool when true",
 
+	// GetTxOutSetInfoResult help.
+	"gettxoutsetinforesult-height":           "Height of the best block",
+	"gettxoutsetinforesult-bestblock":        "Hash of the best block",
+	"gettxoutsetinforesult-transactions":     "Number of transactions with unspent outputs",
+	"gettxoutsetinforesult-txouts":           "Number of unspent transaction outputs",
+	"gettxoutsetinforesult-bytes_serialized": "Size of the serialized unspent transaction output set",
+	"gettxoutsetinforesult-hash_serialized":  "Hash of the serialized unspent transaction output set, which is compared when loading snapshots",
+	"gettxoutsetinforesult-total_amount":     "Total amount of the unspent transaction outputs in DUO",
+
+	// GetTxOutSetInfoCmd help.
+	"gettxoutsetinfo--synopsis": "Returns statistics about the unspent transaction output set.",
+
+	// GetWorkerStatsResult help.
+	"getworkerstatsresult-worker":            "The name the worker logs in with",
+	"getworkerstatsresult-sessions":          "The number of sessions the worker has open",
+	"getworkerstatsresult-logintime":         "The time of the most recent login of the worker in seconds since 1 Jan 1970 GMT",
+	"getworkerstatsresult-lastshare":         "The time of the most recent accepted share of the worker in seconds since 1 Jan 1970 GMT, or 0 if there is none",
+	"getworkerstatsresult-accepted":          "The number of accepted shares",
+	"getworkerstatsresult-stale":             "The number of shares for jobs that were no longer current",
+	"getworkerstatsresult-duplicate":         "The number of shares that were submitted more than once",
+	"getworkerstatsresult-invalid":           "The number of shares that did not meet the share difficulty",
+	"getworkerstatsresult-blocks":            "The number of shares that solved a block accepted by the chain",
+	"getworkerstatsresult-algoshares":        "JSON object of accepted share counts",
+	"getworkerstatsresult-algoshares--key":   "algo",
+	"getworkerstatsresult-algoshares--value": "The number of accepted shares of the algorithm",
+	"getworkerstatsresult-algoshares--desc":  "The number of accepted shares by algorithm name",
+
+	// GetWorkerStatsCmd help.
+	"getworkerstats--synopsis": "Returns the share accounting of each worker that has logged in to the miner controller since it was started.",
+
 	// HelpCmd help.
 	"help--synopsis":   "Returns a list of all commands or help for a specified command.",
 	"help-command":     "The command to retrieve help for",
@@ -513,10 +684,50 @@ var helpDescsEnUS = map[string]string{
 	"help--result0":    "List of commands",
 	"help--result1":    "Help for specified command",
 
+	// ImportMempoolCmd help.
+	"importmempool--synopsis": "Loads the transactions in a mempool file written by savemempool, for instance on another node, into the mempool.\n" +
+		"Each transaction is validated again and only accepted if it is still valid and does not conflict with the mempool.",
+	"importmempool-filepath": "Path of the mempool file",
+
+	// ImportMempoolResult help.
+	"importmempoolresult-accepted": "Number of transactions accepted into the mempool",
+	"importmempoolresult-failed":   "Number of transactions that are no longer valid, conflict with or are already in the mempool",
+
+	// InvalidateBlockCmd help.
+	"invalidateblock--synopsis": "Permanently marks a block and all of its descendants as invalid, reorganizing the chain away from it if it is part of the main chain.\n" +
+		"The block stays invalid across restarts until reconsiderblock is called for it.",
+	"invalidateblock-blockhash": "The hash of the block to mark as invalid",
+
+	// ListBannedCmd help.
+	"listbanned--synopsis": "Returns the addresses and subnets that are banned, along with when and why they were banned.",
+
+	// ListBannedResult help.
+	"listbannedresult-address":      "The banned address or subnet",
+	"listbannedresult-ban_created":  "The time the ban was created in seconds since 1 Jan 1970 GMT",
+	"listbannedresult-banned_until": "The time the ban expires in seconds since 1 Jan 1970 GMT",
+	"listbannedresult-ban_reason":   "Why the address or subnet was banned",
+
 	// PingCmd help.
 	"ping--synopsis": "Queues a ping to be sent to each connected peer.\n" +
 		"Ping times are provided by getpeerinfo via the pingtime and pingwait fields.",
 
+	// PreciousBlockCmd help.
+	"preciousblock--synopsis": "Treats a block as if it was received before other blocks with the same amount of work, reorganizing the chain onto it if it has at least as much work as the current best block.\n" +
+		"The preference is not kept across restarts.",
+	"preciousblock-blockhash": "The hash of the block to prefer",
+
+	// ReconsiderBlockCmd help.
+	"reconsiderblock--synopsis": "Removes the invalid status from a block and its descendants set by invalidateblock or by a failed validation, reorganizing the chain onto the best valid chain.",
+	"reconsiderblock-blockhash": "The hash of the block to reconsider",
+
+	// SaveMempoolCmd help.
+	"savemempool--synopsis": "Writes the transactions in the mempool to the mempool file in the data directory, which is loaded at startup.\n" +
+		"This is done at shutdown too. The file can be loaded into another node with importmempool.",
+
+	// SaveMempoolResult help.
+	"savemempoolresult-filename":     "Path of the mempool file",
+	"savemempoolresult-transactions": "Number of transactions written",
+
 	// SearchRawTransactionsCmd help.
 	"searchrawtransactions--synopsis": "Returns raw data for transactions involving the passed address.\n" +
 		"Returned transactions are pulled from both the database, and transactions currently in the mempool.\n" +
@@ -540,6 +751,14 @@ var helpDescsEnUS = map[string]string{
 	"sendrawtransaction-allowhighfees": "Whether or not to allow insanely high fees (pod does not yet implement this parameter, so it has no effect)",
 	"sendrawtransaction--result0":      "The hash of the transaction",
 
+	// SetBanCmd help.
+	"setban--synopsis": "Bans an address or subnet, disconnecting the connected peers in it and refusing connections to and from it until the ban expires, or lifts a ban.\n" +
+		"Bans are stored in the data directory and kept across restarts.",
+	"setban-subnet":   "The address or subnet in CIDR notation to operate on",
+	"setban-subcmd":   "'add' to ban the address or subnet, 'remove' to lift its ban",
+	"setban-bantime":  "How long to ban for in seconds, or 0 for the configured ban duration",
+	"setban-absolute": "Whether the ban time is the time the ban expires in seconds since 1 Jan 1970 GMT instead",
+
 	// SetGenerateCmd help.
 	"setgenerate--synopsis":    "Set the server to generate coins (mine) or not.",
 	"setgenerate-generate":     "Use true to enable generation, false to disable it",
@@ -674,13 +893,16 @@ var helpDescsEnUS = map[string]string{
 var rpcResultTypes = map[string][]interface{}{
 
 	"addnode":               nil,
+	"clearbanned":           nil,
 	"createrawtransaction":  {(*string)(nil)},
 	"debuglevel":            {(*string)(nil), (*string)(nil)},
 	"decoderawtransaction":  {(*json.TxRawDecodeResult)(nil)},
 	"decodescript":          {(*json.DecodeScriptResult)(nil)},
+	"dumptxoutset":          {(*json.DumpTxOutSetResult)(nil)},
 	"estimatefee":           {(*float64)(nil)},
 	"generate":              {(*[]string)(nil)},
 	"getaddednodeinfo":      {(*[]string)(nil), (*[]json.GetAddedNodeInfoResult)(nil)},
+	"getalgostats":          {(*json.GetAlgoStatsResult)(nil)},
 	"getbestblock":          {(*json.GetBestBlockResult)(nil)},
 	"getbestblockhash":      {(*string)(nil)},
 	"getblock":              {(*string)(nil), (*json.GetBlockVerboseResult)(nil)},
@@ -691,6 +913,7 @@ var rpcResultTypes = map[string][]interface{}{
 	"getblockchaininfo":     {(*json.GetBlockChainInfoResult)(nil)},
 	"getcfilter":            {(*string)(nil)},
 	"getcfilterheader":      {(*string)(nil)},
+	"getchaintips":          {(*[]json.GetChainTipsResult)(nil)},
 	"getconnectioncount":    {(*int32)(nil)},
 	"getcurrentnet":         {(*uint32)(nil)},
 	"getdifficulty":         {(*float64)(nil)},
@@ -698,19 +921,30 @@ var rpcResultTypes = map[string][]interface{}{
 	"gethashespersec":       {(*float64)(nil)},
 	"getheaders":            {(*[]string)(nil)},
 	"getinfo":               {(*json.InfoChainResult)(nil)},
+	"getmempoolentry":       {(*json.GetMempoolEntryResult)(nil)},
 	"getmempoolinfo":        {(*json.GetMempoolInfoResult)(nil)},
 	"getmininginfo":         {(*json.GetMiningInfoResult)(nil)},
 	"getnettotals":          {(*json.GetNetTotalsResult)(nil)},
 	"getnetworkhashps":      {(*int64)(nil)},
+	"getnetworkinfo":        {(*json.GetNetworkInfoResult)(nil)},
 	"getpeerinfo":           {(*[]json.GetPeerInfoResult)(nil)},
 	"getrawmempool":         {(*[]string)(nil), (*json.GetRawMempoolVerboseResult)(nil)},
 	"getrawtransaction":     {(*string)(nil), (*json.TxRawResult)(nil)},
 	"gettxout":              {(*json.GetTxOutResult)(nil)},
+	"gettxoutsetinfo":       {(*json.GetTxOutSetInfoResult)(nil)},
+	"getworkerstats":        {(*[]json.GetWorkerStatsResult)(nil)},
 	"node":                  nil,
 	"help":                  {(*string)(nil), (*string)(nil)},
+	"importmempool":         {(*json.ImportMempoolResult)(nil)},
+	"invalidateblock":       nil,
+	"listbanned":            {(*[]json.ListBannedResult)(nil)},
 	"ping":                  nil,
+	"preciousblock":         nil,
+	"reconsiderblock":       nil,
+	"savemempool":           {(*json.SaveMempoolResult)(nil)},
 	"searchrawtransactions": {(*string)(nil), (*[]json.SearchRawTransactionsResult)(nil)},
 	"sendrawtransaction":    {(*string)(nil)},
+	"setban":                nil,
 	"setgenerate":           nil,
 	"stop":                  {(*string)(nil)},
 	"submitblock":           {nil, (*string)(nil)},
@@ -438,7 +438,7 @@ out:
 				Message: "Failed to parse request: " + err.Error(),
 			}
 
-			reply, err := createMarshalledReply(nil, nil, jsonErr)
+			reply, err := createMarshalledReply("", nil, nil, jsonErr)
 
 			if err != nil {
 
@@ -457,10 +457,11 @@ out:
 		// The JSON-RPC 1.0 spec defines that notifications must have their "id" set to null and states that notifications do not have a response.
 		// A JSON-RPC 2.0 notification is a request with "json-rpc":"2.0", and without an "id" member. The specification states that notifications must not be responded to. JSON-RPC 2.0 permits the null value as a valid request id, therefore such requests are not notifications.
 		// Bitcoin Core serves requests with "id":null or even an absent "id", and responds to such requests with "id":null in the response.
-		// Pod does not respond to any request without and "id" or "id":null, regardless the indicated JSON-RPC protocol version unless RPC quirks are enabled. With RPC quirks enabled, such requests will be responded to if the reqeust does not indicate JSON-RPC version.
+		// Pod does not respond to JSON-RPC 1.0 requests without an "id" or with "id":null, nor to JSON-RPC 2.0 requests without an "id", unless RPC quirks are enabled. With RPC quirks enabled, such requests will be responded to if the reqeust does not indicate JSON-RPC version.
 		// RPC quirks can be enabled by the user to avoid compatibility issues with software relying on Core's behavior.
 
-		if request.ID == nil && !(*cfg.RPCQuirks && request.Jsonrpc == "") {
+		if json.IsNotification(msg, &request) &&
+			!(*cfg.RPCQuirks && request.Jsonrpc == "") {
 
 			if !c.authenticated {
 
@@ -479,7 +480,7 @@ out:
 				break out
 			}
 
-			reply, err := createMarshalledReply(cmd.id, nil, cmd.err)
+			reply, err := createMarshalledReply(cmd.jsonrpc, cmd.id, nil, cmd.err)
 
 			if err != nil {
 
@@ -536,7 +537,7 @@ out:
 			c.authenticated = true
 			c.isAdmin = cmp == 1
 			// Marshal and send response.
-			reply, err := createMarshalledReply(cmd.id, nil, nil)
+			reply, err := createMarshalledReply(cmd.jsonrpc, cmd.id, nil, nil)
 
 			if err != nil {
 
@@ -562,7 +563,8 @@ out:
 				}
 
 				// Marshal and send response.
-				reply, err := createMarshalledReply(request.ID, nil, jsonErr)
+				reply, err := createMarshalledReply(request.Jsonrpc, request.ID, nil,
+					jsonErr)
 
 				if err != nil {
 
@@ -760,14 +762,16 @@ func (
 
 	if ok {
 
+		start := time.Now()
 		result, err = wsHandler(c, r.cmd)
+		observeRPCRequest(r.method, time.Since(start))
 
 	} else {
 
 		result, err = c.server.standardCmdResult(r, nil)
 	}
 
-	reply, err := createMarshalledReply(r.id, result, err)
+	reply, err := createMarshalledReply(r.jsonrpc, r.id, result, err)
 
 	if err != nil {
 
@@ -30,6 +30,7 @@ var samplePodConf = []byte(`[Application Options]
 ;rpcmaxclients=        ;;; Max number of RPC clients for standard connections (default: 10)
 ;rpcmaxwebsockets=     ;;; Max number of RPC websocket connections (default: 25)
 ;rpcmaxconcurrentreqs= ;;; Max number of concurrent RPC requests that may be processed concurrently (default: 20)
+;rpcmaxbatchsize=      ;;; Max number of requests in a JSON-RPC batch request -- 0 to disable batch requests (default: 1000)
 ;rpcquirks             ;;; Mirror some JSON-RPC quirks of Bitcoin Core -- NOTE: Discouraged unless interoperability issues need to be worked around
 ;norpc                 ;;; Disable built-in RPC server -- NOTE: The RPC server is disabled by default if no rpcuser/rpcpass or rpclimituser/rpclimitpass is specified
 ;tls                   ;;; Enable TLS for the RPC server
@@ -48,9 +49,16 @@ var samplePodConf = []byte(`[Application Options]
 ;simnet                ;;; Use the simulation test network
 ;addcheckpoint=        ;;; Add a custom checkpoint.  Format: '<height>:<hash>'
 ;nocheckpoints         ;;; Disable built-in checkpoints.  Don't do this unless you know what you're doing.
+;assumevalid=          ;;; Skip validating the scripts of this block and its ancestors -- no network sets a default yet, so every script is validated unless a block is given.  Format: '<height>:<hash>'
+;skipcheckpointpow     ;;; Skip checking the proof of work of blocks linked to a checkpoint by their headers during the initial sync -- no network ships checkpoints yet, so this only applies to ones added with addcheckpoint
+;scriptthreads=        ;;; Number of goroutines used to validate the scripts of a block -- 0 for three per CPU core (default: 0)
+;prune=                ;;; Delete the oldest blocks to keep the stored blocks below this size in MiB, at least 550 -- 0 to keep every block.  Requires notxindex and noaddrindex (default: 0)
+;loadsnapshot=         ;;; Bootstrap a new chain from a utxo snapshot written by dumptxoutset, validating the blocks before it in the background -- requires the optional indexes to be disabled
+;snapshothash=         ;;; Trust the utxo snapshot loaded with loadsnapshot, which no network ships yet, when it matches this block and the utxo set hash reported by dumptxoutset or gettxoutsetinfo on a node you trust.  Format: '<height>:<hash>:<set hash>'
 ;dbtype=               ;;; Database backend to use for the Block Chain (default: ffldb)
 ;profile=              ;;; Enable HTTP profiling on given port -- NOTE port must be between 1024 and 65536
 ;cpuprofile=           ;;; Write CPU profile to the specified file
+;metricslisten=        ;;; Export Prometheus metrics on /metrics at this address, such as 127.0.0.1:11099 -- disabled when empty
 ;debuglevel=           ;;; Logging level for all subsystems {trace, debug, info, warn, error, critical} -- You may also specify <subsystem>=<level>,<subsystem2>=<level>,... to set the log level for individual subsystems -- Use show to list available subsystems (default: info)
 ;upnp                  ;;; Use UPnP to map our listening port outside of NAT
 ;minrelaytxfee=        ;;; The minimum transaction fee in DUO/kB to be considered a non-zero fee. (default: 1e-05)
@@ -58,6 +66,8 @@ var samplePodConf = []byte(`[Application Options]
 ;norelaypriority       ;;; Do not require free or low-fee transactions to have high priority for relaying
 ;trickleinterval=      ;;; Minimum time between attempts to send new inventory to a connected peer (default: 10s)
 ;maxorphantx=          ;;; Max number of orphan transactions to keep in memory (default: 100)
+;maxmempool=           ;;; Max size of the transaction memory pool in megabytes, the transactions paying the lowest fees are evicted when it is full -- 0 for no limit (default: 300)
+;rejectreplacement     ;;; Reject transactions that double spend transactions in the memory pool, even when those signal that they may be replaced (BIP 125)
 ;algo=                 ;;; Sets the algorithm for the CPU miner ( blake14lr, blake2b, keccak, lyra2rev2, scrypt, skein, x11, x13, sha256d, scrypt default sha256d) (default: sha256d)
 ;generate              ;;; Generate (mine) bitcoins using the CPU
 ;genthreads=           ;;; Number of CPU threads to use with CPU miner -1 = all cores (default: 1)
@@ -9,6 +9,8 @@ import (
 	"fmt"
 	"math"
 	"net"
+	"os"
+	"path/filepath"
 	"runtime"
 	"sort"
 	"strconv"
@@ -20,6 +22,7 @@ import (
 	"git.parallelcoin.io/dev/pod/cmd/node/mempool"
 	blockchain "git.parallelcoin.io/dev/pod/pkg/chain"
 	chaincfg "git.parallelcoin.io/dev/pod/pkg/chain/config"
+	"git.parallelcoin.io/dev/pod/pkg/chain/fork/bench"
 	chainhash "git.parallelcoin.io/dev/pod/pkg/chain/hash"
 	indexers "git.parallelcoin.io/dev/pod/pkg/chain/index"
 	"git.parallelcoin.io/dev/pod/pkg/chain/mining"
@@ -36,6 +39,7 @@ import (
 	"git.parallelcoin.io/dev/pod/pkg/util/bloom"
 	cl "git.parallelcoin.io/dev/pod/pkg/util/cl"
 	"git.parallelcoin.io/dev/pod/pkg/util/interrupt"
+	"git.parallelcoin.io/dev/pod/pkg/util/metrics"
 )
 
 // broadcastInventoryAdd is a type used to declare that the InvVect it contains needs to be added to the rebroadcast map
@@ -64,6 +68,13 @@ type cfHeaderKV struct {
 
 type checkpointSorter []chaincfg.Checkpoint
 
+// banPeerMsg packages a peer to ban along with the reason it is banned.
+
+type banPeerMsg struct {
+	sp     *serverPeer
+	reason string
+}
+
 type connectNodeMsg struct {
 	addr      string
 	permanent bool
@@ -75,6 +86,10 @@ type disconnectNodeMsg struct {
 	reply chan error
 }
 
+type disconnectBannedMsg struct {
+	reply chan int
+}
+
 type getAddedNodesMsg struct {
 	reply chan []*serverPeer
 }
@@ -98,13 +113,12 @@ type onionAddr struct {
 	addr string
 }
 
-// peerState maintains state of inbound, persistent, outbound peers as well as banned peers and outbound groups.
+// peerState maintains state of inbound, persistent, outbound peers as well as outbound groups.
 
 type peerState struct {
 	inboundPeers    map[int32]*serverPeer
 	outboundPeers   map[int32]*serverPeer
 	persistentPeers map[int32]*serverPeer
-	banned          map[string]time.Time
 	outboundGroups  map[string]int
 }
 
@@ -130,6 +144,7 @@ type server struct {
 	started              int32
 	shutdown             int32
 	shutdownSched        int32
+	mempoolLoaded        int32 // Set once the mempool saved at the last shutdown has been loaded.
 	startupTime          int64
 	chainParams          *chaincfg.Params
 	addrManager          *addrmgr.AddrManager
@@ -145,7 +160,8 @@ type server struct {
 	modifyRebroadcastInv chan interface{}
 	newPeers             chan *serverPeer
 	donePeers            chan *serverPeer
-	banPeers             chan *serverPeer
+	banPeers             chan banPeerMsg
+	banList              *banList
 	query                chan interface{}
 	relayInv             chan relayMsg
 	broadcast            chan broadcastMsg
@@ -170,6 +186,9 @@ type server struct {
 	cfCheckptCachesMtx sync.RWMutex
 	algo               string
 	numthreads         uint32
+
+	// removeMetrics stops the metrics of the server from being collected, it is set when the server is started.
+	removeMetrics func()
 }
 
 // serverPeer extends the peer to maintain state shared by the server and the blockmanager.
@@ -218,6 +237,9 @@ const defaultServices = wire.SFNodeNetwork | wire.SFNodeBloom |
 // defaultRequiredServices describes the default services that are required to be supported by outbound peers.
 const defaultRequiredServices = wire.SFNodeNetwork
 
+// maxCmpctBlockDepth is the maximum number of blocks a block requested as a compact block can be below the best block, deeper blocks are sent whole as their transactions are unlikely to be in the memory pool of the peer.
+const maxCmpctBlockDepth = 10
+
 // defaultTargetOutbound is the default number of outbound peers to target.
 const defaultTargetOutbound = 125
 
@@ -306,6 +328,7 @@ func (
 	bytesReceived uint64) {
 
 	atomic.AddUint64(&s.bytesReceived, bytesReceived)
+	networkReceivedBytes.Add(float64(bytesReceived))
 }
 
 // AddBytesSent adds the passed number of bytes to the total bytes sent counter for the server.  It is safe for concurrent access.
@@ -316,6 +339,7 @@ func (
 	bytesSent uint64) {
 
 	atomic.AddUint64(&s.bytesSent, bytesSent)
+	networkSentBytes.Add(float64(bytesSent))
 }
 
 // AddPeer adds a new peer that has already been connected to the server.
@@ -368,14 +392,48 @@ func (
 
 }
 
-// BanPeer bans a peer that has already been connected to the server by ip.
+// BanPeer bans a peer that has already been connected to the server by ip, recording the reason it is banned.
 func (
 	s *server,
 ) BanPeer(
 
-	sp *serverPeer) {
+	sp *serverPeer, reason string) {
 
-	s.banPeers <- sp
+	s.banPeers <- banPeerMsg{sp: sp, reason: reason}
+}
+
+// bannedAddr returns the ban covering the host of an address in host:port form and whether there is one.  Addresses that are not IP addresses, such as onion addresses, are never banned.
+func (
+	s *server,
+) bannedAddr(
+
+	addr string) (banEntry, bool) {
+
+	host, _, err := net.SplitHostPort(addr)
+
+	if err != nil {
+
+		return banEntry{}, false
+	}
+
+	ip := net.ParseIP(host)
+
+	if ip == nil {
+
+		return banEntry{}, false
+	}
+
+	return s.banList.IsBanned(ip)
+}
+
+// DisconnectBanned disconnects all connected peers whose addresses are banned and returns how many were disconnected.
+func (
+	s *server,
+) DisconnectBanned() int {
+
+	replyChan := make(chan int)
+	s.query <- disconnectBannedMsg{reply: replyChan}
+	return <-replyChan
 }
 
 // BroadcastMessage sends msg to all peers currently connected to the server except those in the passed peers to exclude.
@@ -541,6 +599,10 @@ func (
 	s.wg.Add(1)
 	go s.peerHandler()
 
+	// Load the mempool saved at the last shutdown in the background, as validating all of its transactions again can take a while.
+	s.wg.Add(1)
+	go s.loadMempool()
+
 	if s.nat != nil {
 
 		s.wg.Add(1)
@@ -576,6 +638,7 @@ func (
 		s.minerController.Start()
 	}
 
+	s.removeMetrics = metrics.OnCollect(s.collectMetrics)
 }
 
 // Stop gracefully shuts down the server by stopping and disconnecting all peers and the main listener.
@@ -595,6 +658,13 @@ func (
 
 	log <- cl.Wrn("server shutting down")
 
+	// Stop collecting the metrics of the server as the state they are collected from is going away.
+
+	if s.removeMetrics != nil {
+
+		s.removeMetrics()
+	}
+
 	// Stop the CPU miner if needed
 	s.cpuMiner.Stop()
 
@@ -621,6 +691,22 @@ func (
 		return nil
 	})
 
+	// Save the mempool, unless the one saved at the last shutdown has not been loaded completely, which would lose the rest of it.
+
+	if atomic.LoadInt32(&s.mempoolLoaded) != 0 {
+
+		path := filepath.Join(*cfg.DataDir, mempool.DefaultFileName)
+		count, err := s.txMemPool.SaveFile(path)
+
+		if err != nil {
+
+			log <- cl.Error{"failed to save mempool:", err}
+		} else {
+
+			log <- cl.Infof{"saved %d mempool transactions to %s", count, path}
+		}
+	}
+
 	// Signal the remaining goroutines to quit.
 	close(s.quit)
 	return nil
@@ -718,23 +804,16 @@ func (
 		return false
 	}
 
-	if banEnd, ok := state.banned[host]; ok {
+	if ban, ok := s.banList.IsBanned(net.ParseIP(host)); ok {
 
-		if time.Now().Before(banEnd) {
-
-			log <- cl.Debugf{
-
-				"peer %s is banned for another %v - disconnecting",
-				host, time.Until(banEnd),
-			}
+		log <- cl.Debugf{
 
-			sp.Disconnect()
-			return false
+			"peer %s is banned for another %v - disconnecting",
+			host, time.Until(time.Unix(ban.Until, 0)),
 		}
 
-		log <- cl.Infof{"peer %s is no longer banned", host}
-
-		delete(state.banned, host)
+		sp.Disconnect()
+		return false
 	}
 
 	// TODO: Check for max peers from a single IP. Limit max number of total peers.
@@ -778,13 +857,14 @@ func (
 	return true
 }
 
-// handleBanPeerMsg deals with banning peers.  It is invoked from the peerHandler goroutine.
+// handleBanPeerMsg deals with banning peers, adding the address of the peer to the ban list along with the reason.  It is invoked from the peerHandler goroutine.
 func (
 	s *server,
 ) handleBanPeerMsg(
 
-	state *peerState, sp *serverPeer) {
+	state *peerState, msg banPeerMsg) {
 
+	sp := msg.sp
 	host, _, err := net.SplitHostPort(sp.Addr())
 
 	if err != nil {
@@ -794,14 +874,33 @@ func (
 		return
 	}
 
-	direction := directionString(sp.Inbound())
+	ipNet, err := parseBanSubnet(host)
 
-	log <- cl.Infof{
+	if err != nil {
 
-		"banned peer %s (%s) for %v", host, direction, *cfg.BanDuration,
+		log <- cl.Debugf{"can't ban peer %s %v", sp.Addr(), err}
+
+		return
 	}
 
-	state.banned[host] = time.Now().Add(*cfg.BanDuration)
+	direction := directionString(sp.Inbound())
+	err = s.banList.Ban(ipNet, time.Now().Add(*cfg.BanDuration), msg.reason)
+
+	switch {
+
+	case err == errAlreadyBanned:
+		log <- cl.Debugf{"peer %s (%s) is already banned", host, direction}
+
+	case err != nil:
+		log <- cl.Error{"unable to store the ban list:", err}
+
+	default:
+		log <- cl.Infof{
+
+			"banned peer %s (%s) for %v: %s", host, direction,
+			*cfg.BanDuration, msg.reason,
+		}
+	}
 }
 
 // handleBroadcastMsg deals with broadcasting messages to peers.  It is invoked from the peerHandler goroutine.
@@ -1040,6 +1139,22 @@ func (s *server) handleQuery(state *peerState, querymsg interface{}) {
 		}
 
 		msg.reply <- errors.New("peer not found")
+	case disconnectBannedMsg:
+		// Disconnect every peer whose address is banned, including persistent peers, which the connection manager will not reconnect to while the ban lasts.
+		count := 0
+
+		state.forAllPeers(func(sp *serverPeer) {
+
+			if _, ok := s.bannedAddr(sp.Addr()); ok {
+
+				log <- cl.Infof{"disconnecting banned peer %s", sp}
+
+				sp.Disconnect()
+				count++
+			}
+		})
+
+		msg.reply <- count
 	}
 
 }
@@ -1051,6 +1166,9 @@ func (
 
 	state *peerState, msg relayMsg) {
 
+	// The compact block is only built once for all the peers that want new blocks announced with compact blocks.
+	var cmpctBlock *wire.MsgCmpctBlock
+
 	state.forAllPeers(func(sp *serverPeer) {
 
 		if !sp.Connected() {
@@ -1058,22 +1176,49 @@ func (
 			return
 		}
 
-		// If the inventory is a block and the peer prefers headers, generate and send a headers message instead of an inventory message.
+		// If the inventory is a block and the peer asked for compact blocks, send the compact block directly (high-bandwidth mode), and if it prefers headers, generate and send a headers message instead of an inventory message.
 
-		if msg.invVect.Type == wire.InvTypeBlock && sp.WantsHeaders() {
+		if msg.invVect.Type == wire.InvTypeBlock &&
+			(sp.WantsCmpctBlocks() || sp.WantsHeaders()) {
 
-			blockHeader, ok := msg.data.(wire.BlockHeader)
+			msgBlock, ok := msg.data.(*wire.MsgBlock)
 
 			if !ok {
 
-				log <- cl.Wrn("underlying data for headers is not a block header")
+				log <- cl.Wrn("underlying data for block relay is not a block")
 
 				return
 			}
 
+			if sp.WantsCmpctBlocks() {
+
+				if sp.HasKnownInventory(msg.invVect) {
+
+					return
+				}
+
+				if cmpctBlock == nil {
+
+					nonce, err := wire.RandomUint64()
+
+					if err != nil {
+
+						log <- cl.Error{"failed to generate compact block nonce:", err}
+
+						return
+					}
+
+					cmpctBlock = wire.NewMsgCmpctBlock(msgBlock, nonce)
+				}
+
+				sp.AddKnownInventory(msg.invVect)
+				sp.QueueMessage(cmpctBlock, nil)
+				return
+			}
+
 			msgHeaders := wire.NewMsgHeaders()
 
-			if err := msgHeaders.AddBlockHeader(&blockHeader); err != nil {
+			if err := msgHeaders.AddBlockHeader(&msgBlock.Header); err != nil {
 
 				log <- cl.Error{"failed to add block header:", err}
 
@@ -1184,6 +1329,37 @@ func (
 	go s.peerDoneHandler(sp)
 }
 
+// loadMempool loads the mempool saved at the last shutdown. Until it is done the mempool is not saved at shutdown, so that the transactions that were not loaded yet are kept for the next start. A file that can not be read is replaced at the next shutdown. It must be run as a goroutine.
+func (
+	s *server,
+) loadMempool() {
+
+	defer s.wg.Done()
+	path := filepath.Join(*cfg.DataDir, mempool.DefaultFileName)
+	accepted, failed, err := s.txMemPool.LoadFile(path, s.quit)
+
+	switch {
+
+	case os.IsNotExist(err):
+	case err != nil:
+		log <- cl.Warn{"failed to load mempool:", err}
+
+	default:
+		log <- cl.Infof{
+			"loaded %d mempool transactions from %s, %d no longer valid",
+			len(accepted), path, failed,
+		}
+	}
+
+	// The peers connected meanwhile have not heard of the loaded transactions.
+	if len(accepted) > 0 {
+
+		s.AnnounceNewTransactions(accepted)
+	}
+
+	atomic.StoreInt32(&s.mempoolLoaded, 1)
+}
+
 // outboundPeerConnected is invoked by the connection manager when a new outbound connection is established.  It initializes a new outbound server peer instance, associates it with the relevant state such as the connection request instance and the connection itself, and finally notifies the address manager of the attempt.
 func (
 	s *server,
@@ -1260,7 +1436,6 @@ func (
 		inboundPeers:    make(map[int32]*serverPeer),
 		persistentPeers: make(map[int32]*serverPeer),
 		outboundPeers:   make(map[int32]*serverPeer),
-		banned:          make(map[string]time.Time),
 		outboundGroups:  make(map[string]int),
 	}
 
@@ -1301,9 +1476,9 @@ out:
 			// fmt.Println("chan:umsg := <-s.peerHeightsUpdate")
 			s.handleUpdatePeerHeights(state, umsg)
 		// Peer to ban.
-		case p := <-s.banPeers:
-			// fmt.Println("chan:p := <-s.banPeers")
-			s.handleBanPeerMsg(state, p)
+		case msg := <-s.banPeers:
+			// fmt.Println("chan:msg := <-s.banPeers")
+			s.handleBanPeerMsg(state, msg)
 		// New inventory to potentially be relayed to other peers.
 		case invMsg := <-s.relayInv:
 			// fmt.Println("chan:invMsg := <-s.relayInv")
@@ -1450,6 +1625,65 @@ func (
 	return nil
 }
 
+// pushCmpctBlockMsg sends a cmpctblock message for the provided block hash to the connected peer.  Blocks that are more than maxCmpctBlockDepth blocks below the best block are sent whole instead.  An error is returned if the block hash is not known.
+func (
+	s *server,
+) pushCmpctBlockMsg(
+	sp *serverPeer, hash *chainhash.Hash,
+
+	doneChan chan<- struct{}, waitChan <-chan struct{}) error {
+
+	height, err := sp.server.chain.BlockHeightByHash(hash)
+
+	if err != nil ||
+		sp.server.chain.BestSnapshot().Height-height > maxCmpctBlockDepth {
+
+		return s.pushBlockMsg(sp, hash, doneChan, waitChan, wire.BaseEncoding)
+	}
+
+	// Fetch the block from the database.
+	blk, err := sp.server.chain.BlockByHash(hash)
+
+	if err != nil {
+
+		log <- cl.Tracef{
+
+			"unable to fetch requested block hash %v: %v",
+			hash, err,
+		}
+
+		if doneChan != nil {
+
+			doneChan <- struct{}{}
+		}
+
+		return err
+	}
+
+	// Each compact block gets its own nonce so the short ids of colliding transactions differ between peers.
+	nonce, err := wire.RandomUint64()
+
+	if err != nil {
+
+		if doneChan != nil {
+
+			doneChan <- struct{}{}
+		}
+
+		return err
+	}
+
+	// Once we have fetched data wait for any previous operation to finish.
+
+	if waitChan != nil {
+
+		<-waitChan
+	}
+
+	sp.QueueMessage(wire.NewMsgCmpctBlock(blk.MsgBlock(), nonce), doneChan)
+	return nil
+}
+
 // pushMerkleBlockMsg sends a merkleblock message for the provided block hash to the connected peer.  Since a merkle block requires the peer to have a filter loaded, this call will simply be ignored if there is no filter loaded.  An error is returned if the block hash is not known.
 func (
 	s *server,
@@ -1823,6 +2057,33 @@ func (
 	<-sp.blockProcessed
 }
 
+// OnBlockTxn is invoked when a peer receives a blocktxn bitcoin message.  It carries the transactions of a compact block that were missing from the memory pool, and is processed the same way as a block.
+func (
+	sp *serverPeer,
+) OnBlockTxn(
+
+	_ *peer.Peer, msg *wire.MsgBlockTxn) {
+
+	sp.server.syncManager.QueueBlockTxn(msg, sp.Peer, sp.blockProcessed)
+	<-sp.blockProcessed
+}
+
+// OnCmpctBlock is invoked when a peer receives a cmpctblock bitcoin message. The block is rebuilt from the transactions in the memory pool by the sync manager, and further receives are blocked until it was processed, the same as for a whole block.
+func (
+	sp *serverPeer,
+) OnCmpctBlock(
+
+	_ *peer.Peer, msg *wire.MsgCmpctBlock) {
+
+	// Add the block to the known inventory for the peer.
+	blockHash := msg.Header.BlockHash()
+	iv := wire.NewInvVect(wire.InvTypeBlock, &blockHash)
+	sp.AddKnownInventory(iv)
+
+	sp.server.syncManager.QueueCmpctBlock(msg, sp.Peer, sp.blockProcessed)
+	<-sp.blockProcessed
+}
+
 // OnFeeFilter is invoked when a peer receives a feefilter bitcoin message and is used by remote peers to request that no transactions which have a fee rate lower than provided value are inventoried to them.  The peer will be disconnected if an invalid fee filter value is provided.
 func (
 	sp *serverPeer,
@@ -1999,6 +2260,51 @@ func (
 
 }
 
+// OnGetBlockTxn is invoked when a peer receives a getblocktxn bitcoin message. It is used by peers to request the transactions of a compact block they couldn't find in their memory pool.  Blocks that are more than maxCmpctBlockDepth blocks below the best block are sent whole instead, as they are not sent as compact blocks either.  Requesting transactions that are not in the block increases the ban score of the peer.
+func (
+	sp *serverPeer,
+) OnGetBlockTxn(
+
+	_ *peer.Peer, msg *wire.MsgGetBlockTxn) {
+
+	height, err := sp.server.chain.BlockHeightByHash(&msg.BlockHash)
+
+	if err != nil ||
+		sp.server.chain.BestSnapshot().Height-height > maxCmpctBlockDepth {
+
+		sp.server.pushBlockMsg(sp, &msg.BlockHash, nil, nil, wire.BaseEncoding)
+		return
+	}
+
+	blk, err := sp.server.chain.BlockByHash(&msg.BlockHash)
+
+	if err != nil {
+
+		log <- cl.Tracef{
+
+			"unable to fetch requested block hash %v: %v",
+			msg.BlockHash, err,
+		}
+		return
+	}
+
+	blkTransactions := blk.MsgBlock().Transactions
+	txs := make([]*wire.MsgTx, 0, len(msg.Indexes))
+
+	for _, index := range msg.Indexes {
+
+		if index >= uint32(len(blkTransactions)) {
+
+			sp.addBanScore(100, 0, "getblocktxn")
+			return
+		}
+
+		txs = append(txs, blkTransactions[index])
+	}
+
+	sp.QueueMessage(wire.NewMsgBlockTxn(&msg.BlockHash, txs), nil)
+}
+
 // OnGetCFCheckpt is invoked when a peer receives a getcfcheckpt bitcoin message.
 func (
 	sp *serverPeer,
@@ -2462,6 +2768,8 @@ func (
 			err = sp.server.pushBlockMsg(sp, &iv.Hash, c, waitChan, wire.WitnessEncoding)
 		case wire.InvTypeBlock:
 			err = sp.server.pushBlockMsg(sp, &iv.Hash, c, waitChan, wire.BaseEncoding)
+		case wire.InvTypeCmpctBlock:
+			err = sp.server.pushCmpctBlockMsg(sp, &iv.Hash, c, waitChan)
 		case wire.InvTypeFilteredWitnessBlock:
 			err = sp.server.pushMerkleBlockMsg(sp, &iv.Hash, c, waitChan, wire.WitnessEncoding)
 		case wire.InvTypeFilteredBlock:
@@ -2881,7 +3189,7 @@ func (
 				"misbehaving peer %s -- banning and disconnecting", sp,
 			}
 
-			sp.server.BanPeer(sp)
+			sp.server.BanPeer(sp, fmt.Sprintf("ban score %d: %s", score, reason))
 			sp.Disconnect()
 		}
 
@@ -3468,6 +3776,32 @@ func mergeCheckpoints(
 	return checkpoints
 }
 
+// loadUtxoSnapshot bootstraps the passed chain from the utxo snapshot in the passed file.
+func loadUtxoSnapshot(
+
+	chain *blockchain.BlockChain, path string) error {
+
+	f, err := os.Open(CleanAndExpandPath(path))
+
+	if err != nil {
+
+		return err
+	}
+
+	defer f.Close()
+
+	log <- cl.Info{"loading utxo snapshot from", path}
+
+	_, err = chain.LoadUtxoSnapshot(f, StateCfg.ActiveUtxoSnapshot)
+
+	if err != nil {
+
+		return fmt.Errorf("failed to load utxo snapshot %s: %v", path, err)
+	}
+
+	return nil
+}
+
 // newPeerConfig returns the configuration for the given serverPeer.
 func newPeerConfig(
 
@@ -3481,6 +3815,9 @@ func newPeerConfig(
 			OnMemPool:      sp.OnMemPool,
 			OnTx:           sp.OnTx,
 			OnBlock:        sp.OnBlock,
+			OnCmpctBlock:   sp.OnCmpctBlock,
+			OnBlockTxn:     sp.OnBlockTxn,
+			OnGetBlockTxn:  sp.OnGetBlockTxn,
 			OnInv:          sp.OnInv,
 			OnHeaders:      sp.OnHeaders,
 			OnGetData:      sp.OnGetData,
@@ -3534,7 +3871,22 @@ func newServer(
 		services &^= wire.SFNodeCF
 	}
 
+	// A pruned node can only serve the most recent blocks.
+
+	if *cfg.Prune != 0 {
+
+		services &^= wire.SFNodeNetwork
+		services |= wire.SFNodeNetworkLimited
+	}
+
 	amgr := addrmgr.New(*cfg.DataDir, podLookup)
+	bans, banErr := newBanList(filepath.Join(*cfg.DataDir, banListFileName))
+
+	if banErr != nil {
+
+		return nil, banErr
+	}
+
 	var listeners []net.Listener
 	var nat NAT
 
@@ -3573,7 +3925,8 @@ func newServer(
 		addrManager:          amgr,
 		newPeers:             make(chan *serverPeer, *cfg.MaxPeers),
 		donePeers:            make(chan *serverPeer, *cfg.MaxPeers),
-		banPeers:             make(chan *serverPeer, *cfg.MaxPeers),
+		banPeers:             make(chan banPeerMsg, *cfg.MaxPeers),
+		banList:              bans,
 		query:                make(chan interface{}),
 		relayInv:             make(chan relayMsg, *cfg.MaxPeers),
 		broadcast:            make(chan broadcastMsg, *cfg.MaxPeers),
@@ -3641,6 +3994,34 @@ func newServer(
 		indexManager = indexers.NewManager(db, indexes)
 	}
 
+	// The blocks before a utxo snapshot are not available to the optional indexes.
+
+	if *cfg.LoadSnapshot != "" && indexManager != nil {
+
+		return nil, errors.New("the optional indexes must be disabled to " +
+			"load a utxo snapshot (--nocfilters without --txindex or --addrindex)")
+	}
+
+	// The pruned blocks can't be restored without syncing the chain again, and the transaction and address indexes need every block.
+	pruned, err := db.BeenPruned()
+
+	if err != nil {
+
+		return nil, err
+	}
+
+	if pruned && *cfg.Prune == 0 {
+
+		return nil, errors.New("blocks have been pruned, so prune must stay " +
+			"enabled unless the chain is synced again from scratch")
+	}
+
+	if (pruned || *cfg.Prune != 0) && (*cfg.TxIndex || *cfg.AddrIndex) {
+
+		return nil, errors.New("the transaction and address indexes can't " +
+			"be enabled with pruned blocks (--prune requires --notxindex and --noaddrindex)")
+	}
+
 	// Merge given checkpoints with the default ones unless they are disabled.
 	var checkpoints []chaincfg.Checkpoint
 
@@ -3651,19 +4032,22 @@ func newServer(
 	}
 
 	// Create a new block chain instance with the appropriate configuration.
-	var err error
 	s.chain, err = blockchain.New(
 
 		&blockchain.Config{
 
-			DB:           s.db,
-			Interrupt:    interruptChan,
-			ChainParams:  s.chainParams,
-			Checkpoints:  checkpoints,
-			TimeSource:   s.timeSource,
-			SigCache:     s.sigCache,
-			IndexManager: indexManager,
-			HashCache:    s.hashCache,
+			DB:            s.db,
+			Interrupt:     interruptChan,
+			ChainParams:   s.chainParams,
+			Checkpoints:   checkpoints,
+			TimeSource:    s.timeSource,
+			SigCache:      s.sigCache,
+			IndexManager:  indexManager,
+			HashCache:     s.hashCache,
+			AssumeValid:   StateCfg.ActiveAssumeValid,
+			NoAssumeValid: StateCfg.NoAssumeValid,
+			ScriptThreads: *cfg.ScriptThreads,
+			Prune:         uint64(*cfg.Prune) * 1024 * 1024,
 		},
 	)
 
@@ -3674,6 +4058,22 @@ func newServer(
 
 	s.chain.DifficultyAdjustments = make(map[string]float64)
 
+	// Bootstrap the chain from a utxo snapshot when asked to, which is only possible while the chain is new, so the option is ignored once the snapshot is loaded.
+
+	if *cfg.LoadSnapshot != "" {
+
+		if s.chain.BestSnapshot().Height == 0 {
+
+			if err := loadUtxoSnapshot(s.chain, *cfg.LoadSnapshot); err != nil {
+
+				return nil, err
+			}
+		} else {
+
+			log <- cl.Warn{"the chain is not new, ignoring loadsnapshot"}
+		}
+	}
+
 	// Search for a FeeEstimator state in the database. If none can be found or if it cannot be loaded, create a new one.
 
 	e := db.Update(func(tx database.Tx) error {
@@ -3729,6 +4129,8 @@ func newServer(
 			AcceptNonStd:         *cfg.RelayNonStd,
 			FreeTxRelayLimit:     *cfg.FreeTxRelayLimit,
 			MaxOrphanTxs:         *cfg.MaxOrphanTxs,
+			MaxPoolSize:          int64(*cfg.MaxMempool) * 1000000,
+			RejectReplacement:    *cfg.RejectReplacement,
 			MaxOrphanTxSize:      DefaultMaxOrphanTxSize,
 			MaxSigOpCostPerTx:    blockchain.MaxBlockSigOpsCost / 4,
 			MinRelayTxFee:        StateCfg.ActiveMinRelayTxFee,
@@ -3771,8 +4173,10 @@ func newServer(
 				TxMemPool:          s.txMemPool,
 				ChainParams:        s.chainParams,
 				DisableCheckpoints: *cfg.DisableCheckpoints,
+				SkipCheckpointPoW:  *cfg.SkipCheckpointPoW,
 				MaxPeers:           *cfg.MaxPeers,
 				FeeEstimator:       s.feeEstimator,
+				RequestShutdown:    interrupt.Request,
 			},
 		)
 
@@ -3799,6 +4203,20 @@ func newServer(
 		s.chainParams, s.txMemPool, s.chain, s.timeSource,
 		s.sigCache, s.hashCache, s.algo)
 
+	// Both the CPU miner and the miner controller take templates from the generator, so a benchmark profile of this machine makes the algorithm scheduler of the generator choose by the measured hash costs for both.
+	if *cfg.BenchProfile != "" {
+
+		profile, err := bench.Load(*cfg.BenchProfile)
+
+		if err != nil {
+
+			return nil, err
+		}
+		blockTemplateGenerator.AlgoScheduler().SetHashCosts(profile.Costs())
+
+		log <- cl.Info{"using hash costs from benchmark profile", *cfg.BenchProfile}
+	}
+
 	s.cpuMiner = cpuminer.New(&cpuminer.Config{
 
 		Blockchain:             s.chain,
@@ -3876,6 +4294,12 @@ func newServer(
 				}
 
 				addrString := addrmgr.NetAddressKey(addr.NetAddress())
+
+				if _, banned := s.bannedAddr(addrString); banned {
+
+					continue
+				}
+
 				return addrStringToNetAddr(addrString)
 			}
 
@@ -3904,6 +4328,11 @@ func newServer(
 				Dial:           podDial,
 				OnConnection:   s.outboundPeerConnected,
 				GetNewAddress:  newAddressFunc,
+				IsBanned: func(addr net.Addr) bool {
+
+					_, banned := s.bannedAddr(addr.String())
+					return banned
+				},
 			},
 		)
 
@@ -3967,22 +4396,31 @@ func newServer(
 
 			rp, err := newRPCServer(&rpcserverConfig{
 
-				Listeners:    rpcListeners,
-				StartupTime:  s.startupTime,
-				ConnMgr:      &rpcConnManager{&s},
-				SyncMgr:      &rpcSyncMgr{&s, s.syncManager},
-				TimeSource:   s.timeSource,
-				Chain:        s.chain,
-				ChainParams:  chainParams,
-				DB:           db,
-				TxMemPool:    s.txMemPool,
-				Generator:    blockTemplateGenerator,
-				CPUMiner:     s.cpuMiner,
-				TxIndex:      s.txIndex,
-				AddrIndex:    s.addrIndex,
-				CfIndex:      s.cfIndex,
-				FeeEstimator: s.feeEstimator,
-				Algo:         l,
+				Listeners:       rpcListeners,
+				StartupTime:     s.startupTime,
+				ConnMgr:         &rpcConnManager{&s},
+				SyncMgr:         &rpcSyncMgr{&s, s.syncManager},
+				TimeSource:      s.timeSource,
+				Chain:           s.chain,
+				ChainParams:     chainParams,
+				DB:              db,
+				TxMemPool:       s.txMemPool,
+				Generator:       blockTemplateGenerator,
+				CPUMiner:        s.cpuMiner,
+				MinerController: s.minerController,
+				TxIndex:         s.txIndex,
+				AddrIndex:       s.addrIndex,
+				CfIndex:         s.cfIndex,
+				FeeEstimator:    s.feeEstimator,
+				Services:        s.services,
+				Algo:            l,
+				MempoolFile:     filepath.Join(*cfg.DataDir, mempool.DefaultFileName),
+				DataDir:         *cfg.DataDir,
+
+				MempoolLoaded: func() bool {
+
+					return atomic.LoadInt32(&s.mempoolLoaded) != 0
+				},
 			})
 
 			if err != nil {
@@ -3219,7 +3219,7 @@ func (b *blockManager) handleHeadersMsg(hmsg *headersMsg) {
 				}
 
 				totalWork.Add(totalWork,
-					blockchain.CalcWork(reorgHeader.Bits, prevNode.Height+1, reorgHeader.Version))
+					blockchain.CalcWork(blockchain.HardForkSchedule(&b.server.chainParams), reorgHeader.Bits, prevNode.Height+1, reorgHeader.Version))
 				b.reorgList.PushBack(headerlist.Node{
 					Header: *reorgHeader,
 					Height: int32(backHeight+1) + int32(j),
@@ -3270,7 +3270,7 @@ func (b *blockManager) handleHeadersMsg(hmsg *headersMsg) {
 
 				knownWork.Add(
 					knownWork,
-					blockchain.CalcWork(knownHead.Bits, knownEl.Height, knownHead.Version),
+					blockchain.CalcWork(blockchain.HardForkSchedule(&b.server.chainParams), knownHead.Bits, knownEl.Height, knownHead.Version),
 				)
 			}
 
@@ -3497,7 +3497,8 @@ func (b *blockManager) checkHeaderSanity(blockHeader *wire.BlockHeader,
 		Header: *blockHeader,
 	})
 
-	err = blockchain.CheckProofOfWork(stubBlock, blockchain.CompactToBig(diff), height)
+	err = blockchain.CheckProofOfWork(stubBlock, blockchain.CompactToBig(diff), height,
+		blockchain.HardForkSchedule(&b.server.chainParams))
 
 	if err != nil {
 
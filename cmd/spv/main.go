@@ -0,0 +1,202 @@
+package spv
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	chaincfg "git.parallelcoin.io/dev/pod/pkg/chain/config"
+	netparams "git.parallelcoin.io/dev/pod/pkg/chain/config/params"
+	chainhash "git.parallelcoin.io/dev/pod/pkg/chain/hash"
+	"git.parallelcoin.io/dev/pod/pkg/chain/wire"
+	"git.parallelcoin.io/dev/pod/pkg/pod"
+	cl "git.parallelcoin.io/dev/pod/pkg/util/cl"
+	"git.parallelcoin.io/dev/pod/pkg/util/interrupt"
+	walletdb "git.parallelcoin.io/dev/pod/pkg/wallet/db"
+
+	// The light client database uses the same bolt driver as the wallet.
+	_ "git.parallelcoin.io/dev/pod/pkg/wallet/db/bdb"
+)
+
+const (
+
+	// DBName is the name of the database of the light client in its data directory.
+	DBName = "neutrino.db"
+
+	// statusInterval is how often the sync status is logged by Main.
+	statusInterval = time.Minute
+)
+
+// SyncStatus describes how far a chain service has synced the block headers and the compact filter headers of the chain.
+type SyncStatus struct {
+
+	// BlockHeaderHeight and BlockHeaderHash identify the last block header synced.
+	BlockHeaderHeight int32
+	BlockHeaderHash   chainhash.Hash
+
+	// FilterHeaderHeight is the height of the last compact filter header synced, which trails the block headers while they are syncing.
+	FilterHeaderHeight int32
+
+	// Current is true when both the block headers and the filter headers are synced with the peers.
+	Current bool
+
+	// Peers is the number of connected peers.
+	Peers int32
+}
+
+// SyncStatus returns how far the chain service has synced the block headers and the compact filter headers.  It is safe for concurrent access.
+func (s *ChainService) SyncStatus() (*SyncStatus, error) {
+
+	header, height, err := s.BlockHeaders.ChainTip()
+
+	if err != nil {
+
+		return nil, err
+	}
+
+	_, filterHeight, err := s.RegFilterHeaders.ChainTip()
+
+	if err != nil {
+
+		return nil, err
+	}
+
+	return &SyncStatus{
+
+		BlockHeaderHeight:  int32(height),
+		BlockHeaderHash:    header.BlockHash(),
+		FilterHeaderHeight: int32(filterHeight),
+		Current:            s.IsCurrent(),
+		Peers:              s.ConnectedCount(),
+	}, nil
+}
+
+// DataDir returns the directory the light client stores its headers and database in for the passed network, inside the data directory of the wallet of the network.
+func DataDir(
+
+	dataDir string, params *chaincfg.Params) string {
+
+	netname := params.Name
+
+	// The wallet names the testnet directory "testnet", so the light client has to as well.
+	if params.Net == wire.TestNet3 {
+
+		netname = "testnet"
+	}
+
+	return filepath.Join(dataDir, netname, "spv")
+}
+
+// NewChainServiceFromConfig opens the database of the light client in its data directory and creates a chain service that syncs with the peers of the configuration, or with peers found through the DNS seeds when none are configured.  The database must be closed once the chain service is stopped.
+func NewChainServiceFromConfig(
+
+	cfg *pod.Config, params *chaincfg.Params) (*ChainService, walletdb.DB, error) {
+
+	dataDir := DataDir(*cfg.DataDir, params)
+
+	if err := os.MkdirAll(dataDir, 0700); err != nil {
+
+		return nil, nil, err
+	}
+
+	db, err := walletdb.Create("bdb", filepath.Join(dataDir, DBName))
+
+	if err != nil {
+
+		return nil, nil, err
+	}
+
+	spvConfig := Config{
+
+		DataDir:     dataDir,
+		Database:    db,
+		ChainParams: *params,
+	}
+
+	if cfg.ConnectPeers != nil {
+
+		spvConfig.ConnectPeers = *cfg.ConnectPeers
+	}
+
+	if cfg.AddPeers != nil {
+
+		spvConfig.AddPeers = *cfg.AddPeers
+	}
+
+	chainService, err := NewChainService(spvConfig)
+
+	if err != nil {
+
+		db.Close()
+		return nil, nil, err
+	}
+
+	return chainService, db, nil
+}
+
+// Main runs the light client on its own, syncing the block headers and compact filter headers of the chain into its data directory and logging its progress, until it is interrupted.  A wallet started later with the spv backend continues from the headers synced.
+func Main(
+
+	cfg *pod.Config, activeNet *netparams.Params) error {
+
+	chainService, db, err := NewChainServiceFromConfig(cfg, activeNet.Params)
+
+	if err != nil {
+
+		log <- cl.Error{"unable to create the light client:", err}
+
+		return err
+	}
+
+	chainService.Start()
+
+	interrupt.AddHandler(func() {
+
+		log <- cl.Wrn("stopping the light client...")
+
+		if err := chainService.Stop(); err != nil {
+
+			log <- cl.Error{"failed to stop the light client:", err}
+		}
+
+		if err := db.Close(); err != nil {
+
+			log <- cl.Error{"failed to close the light client database:", err}
+		}
+	})
+
+	log <- cl.Info{"light client syncing into", DataDir(*cfg.DataDir, activeNet.Params)}
+
+	ticker := time.NewTicker(statusInterval)
+	defer ticker.Stop()
+
+	for {
+
+		select {
+
+		case <-ticker.C:
+
+			status, err := chainService.SyncStatus()
+
+			if err != nil {
+
+				log <- cl.Error{"failed to read the sync status:", err}
+
+				continue
+			}
+
+			log <- cl.Infof{
+
+				"block headers %d, filter headers %d, current %v, %d peers",
+				status.BlockHeaderHeight, status.FilterHeaderHeight,
+				status.Current, status.Peers,
+			}
+
+		case <-interrupt.HandlersDone:
+
+			log <- cl.Inf("light client shutdown complete")
+
+			return nil
+		}
+	}
+}
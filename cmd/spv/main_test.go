@@ -0,0 +1,29 @@
+package spv
+
+import (
+	"path/filepath"
+	"testing"
+
+	chaincfg "git.parallelcoin.io/dev/pod/pkg/chain/config"
+)
+
+// TestDataDir ensures the light client stores its data inside the data directory of the wallet of each network.
+func TestDataDir(t *testing.T) {
+
+	tests := []struct {
+		params *chaincfg.Params
+		want   string
+	}{
+		{&chaincfg.MainNetParams, filepath.Join("data", chaincfg.MainNetParams.Name, "spv")},
+		{&chaincfg.TestNet3Params, filepath.Join("data", "testnet", "spv")},
+		{&chaincfg.SimNetParams, filepath.Join("data", chaincfg.SimNetParams.Name, "spv")},
+	}
+
+	for _, test := range tests {
+
+		if got := DataDir("data", test.params); got != test.want {
+
+			t.Errorf("%s: got %s, want %s", test.params.Name, got, test.want)
+		}
+	}
+}
@@ -1395,7 +1395,7 @@ func (s *ChainService) GetBlock(blockHash chainhash.Hash,
 					s.timeSource,
 					false,
 					block.Height(),
-					true,
+					blockchain.HardForkSchedule(&s.chainParams),
 				); err != nil {
 
 					log <- cl.Warnf{
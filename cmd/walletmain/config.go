@@ -54,6 +54,8 @@ type Config struct {
 	LegacyRPCListeners     *cli.StringSlice `long:"rpclisten" description:"Listen for legacy RPC connections on this interface/port (default port: 11046, testnet: 21046, simnet: 41046)"`
 	LegacyRPCMaxClients    *int             `long:"rpcmaxclients" description:"Max number of legacy RPC clients for standard connections"`
 	LegacyRPCMaxWebsockets *int             `long:"rpcmaxwebsockets" description:"Max number of legacy RPC websocket connections"`
+	LegacyRPCMaxBatchSize  *int             `long:"rpcmaxbatchsize" description:"Max number of requests in a legacy RPC JSON-RPC batch request -- 0 to disable batch requests"`
+	WalletMetricsListener  *string          `long:"metricslisten" description:"Export the wallet Prometheus metrics on /metrics at this address, such as 127.0.0.1:11098 -- disabled when empty"`
 	Username               *string          `short:"u" long:"username" description:"Username for legacy RPC and pod authentication (if podusername is unset)"`
 	Password               *string          `short:"P" long:"password" default-mask:"-" description:"Password for legacy RPC and pod authentication (if podpassword is unset)"`
 	// EXPERIMENTAL RPC server options
@@ -73,6 +75,7 @@ const DefaultLogDirname = ""
 const DefaultLogFilename = "wallet/log"
 const DefaultRPCMaxClients = 10
 const DefaultRPCMaxWebsockets = 25
+const DefaultRPCMaxBatchSize = 1000
 const WalletDbName = "wallet.db"
 
 /*
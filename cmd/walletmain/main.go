@@ -7,9 +7,10 @@ import (
 	"net/http"
 	_ "net/http/pprof"
 	"sync"
+	"time"
 
+	"git.parallelcoin.io/dev/pod/cmd/spv"
 	netparams "git.parallelcoin.io/dev/pod/pkg/chain/config/params"
-	"git.parallelcoin.io/dev/pod/pkg/chain/fork"
 	"git.parallelcoin.io/dev/pod/pkg/pod"
 	legacyrpc "git.parallelcoin.io/dev/pod/pkg/rpc/legacy"
 	cl "git.parallelcoin.io/dev/pod/pkg/util/cl"
@@ -22,6 +23,22 @@ var (
 	cfg *pod.Config
 )
 
+const (
+
+	// BackendRPC is the wallet backend that syncs with a full node over RPC.
+	BackendRPC = "rpc"
+
+	// BackendSPV is the wallet backend that syncs with the compact filters
+	// of peers through a light client, without a full node.
+	BackendSPV = "spv"
+
+	// lightClientRetryDelay is how long the wallet first waits to start the
+	// light client chain source again after it fails to start, doubling on
+	// each failure up to maxLightClientRetryDelay.
+	lightClientRetryDelay    = time.Second
+	maxLightClientRetryDelay = time.Minute
+)
+
 // Main is a work-around main function that is required since deferred functions (such as log flushing) are not called with calls to os.Exit.
 
 // Instead, main runs this function and checks for a non-nil error, at point any defers have already run, and if the error is non-nil, the program can be exited with an error exit status.
@@ -31,12 +48,6 @@ func Main(c *pod.Config, activeNet *netparams.Params) error {
 	cfg = c
 	ActiveNet = activeNet
 
-	if ActiveNet.Name == "testnet" {
-
-		fork.IsTestnet = true
-
-	}
-
 	if *cfg.Profile != "" {
 
 		go func() {
@@ -64,6 +75,92 @@ func Main(c *pod.Config, activeNet *netparams.Params) error {
 
 	loader := wallet.NewLoader(activeNet.Params, dbDir, 250)
 
+	// The coin selection strategy is checked before anything is started so
+	// a mistyped option does not leave the wallet running with another one.
+	var coinSelection string
+
+	if cfg.CoinSelection != nil {
+
+		coinSelection = *cfg.CoinSelection
+	}
+
+	strategy, err := wallet.ParseCoinSelectionStrategy(coinSelection)
+
+	if err != nil {
+
+		log <- cl.Error{err}
+
+		return err
+	}
+
+	replaceable := cfg.WalletRBF != nil && *cfg.WalletRBF
+
+	loader.RunAfterLoad(func(w *wallet.Wallet) {
+
+		w.SetCoinSelection(strategy)
+		w.SetReplaceable(replaceable)
+	})
+
+	// The metrics server is stopped last so the shutdown can be watched.
+	metricsServer, err := startMetricsServer(loader)
+
+	if err != nil {
+
+		log <- cl.Error{
+
+			"unable to start metrics server:", err,
+		}
+
+		return err
+	}
+
+	if metricsServer != nil {
+
+		log <- cl.Info{
+
+			"metrics server listening on", *cfg.WalletMetricsListener,
+		}
+
+		interrupt.AddHandler(func() {
+
+			metricsServer.Close()
+		})
+
+	}
+
+	// The light client of the spv backend is started before the wallet is
+	// loaded, and its interrupt handler is added before the one unloading
+	// the wallet so it is stopped after the wallet.
+	var chainService *spv.ChainService
+
+	if cfg.WalletBackend != nil {
+
+		switch *cfg.WalletBackend {
+
+		case "", BackendRPC:
+
+		case BackendSPV:
+
+			chainService, err = startChainService()
+
+			if err != nil {
+
+				log <- cl.Error{"unable to start the light client:", err}
+
+				return err
+			}
+
+		default:
+
+			err = fmt.Errorf("unknown wallet backend %q, use %s or %s",
+				*cfg.WalletBackend, BackendRPC, BackendSPV)
+
+			log <- cl.Error{err}
+
+			return err
+		}
+	}
+
 	// Create and start HTTP server to serve wallet client connections.
 	// This will be updated with the wallet and chain server RPC client
 	// created below after each is created.
@@ -88,7 +185,7 @@ func Main(c *pod.Config, activeNet *netparams.Params) error {
 
 		log <- cl.Trc("starting rpcClientConnectLoop")
 
-		go rpcClientConnectLoop(legacyRPCServer, loader)
+		go rpcClientConnectLoop(legacyRPCServer, loader, chainService)
 	}
 
 	loader.RunAfterLoad(func(w *wallet.Wallet) {
@@ -223,18 +320,23 @@ func readCAFile() []byte {
 // When a connection is established, the client is used to sync the loaded wallet, either
 // immediately or when loaded at a later time.
 //
+// When a light client is passed, the wallet syncs with it instead of the
+// consensus RPC server.
+//
 // The legacy RPC is optional.  If set, the connected RPC client will be
 // associated with the server for RPC passthrough and to enable additional
 // methods.
-func rpcClientConnectLoop(legacyRPCServer *legacyrpc.Server, loader *wallet.Loader) {
+func rpcClientConnectLoop(legacyRPCServer *legacyrpc.Server, loader *wallet.Loader,
+	chainService *spv.ChainService) {
 
 	var certs []byte
 
-	// if !cfg.UseSPV {
+	if chainService == nil {
 
-	certs = readCAFile()
+		certs = readCAFile()
+	}
 
-	// }
+	retryDelay := lightClientRetryDelay
 
 	for {
 
@@ -243,80 +345,55 @@ func rpcClientConnectLoop(legacyRPCServer *legacyrpc.Server, loader *wallet.Load
 			err         error
 		)
 
-		// if cfg.UseSPV {
-
-		// 	var (
-
-		// 		chainService *neutrino.ChainService
-
-		// 		spvdb        walletdb.DB
-
-		// 	)
-
-		// 	netDir := networkDir(cfg.AppDataDir.Value, ActiveNet.Params)
-
-		// 	spvdb, err = walletdb.Create("bdb",
+		if chainService != nil {
 
-		// 		filepath.Join(netDir, "neutrino.db"))
+			chainClient, err = startNeutrinoClient(chainService)
 
-		// 	defer spvdb.Close()
+			if err != nil {
 
-		// 	if err != nil {
+				if interrupt.Requested() {
 
-		// 		log<-cl.Errorf{"unable to create Neutrino DB: %s", err)
+					return
+				}
 
-		// 		continue
+				log <- cl.Error{
 
-		// 	}
+					"unable to start the light client chain source, retrying in",
+					retryDelay, ":", err}
 
-		// 	chainService, err = neutrino.NewChainService(
+				select {
 
-		// 		neutrino.Config{
+				case <-time.After(retryDelay):
+				case <-interrupt.HandlersDone:
+					return
+				}
 
-		// 			DataDir:      netDir,
+				retryDelay *= 2
 
-		// 			Database:     spvdb,
+				if retryDelay > maxLightClientRetryDelay {
 
-		// 			ChainParams:  *ActiveNet.Params,
+					retryDelay = maxLightClientRetryDelay
+				}
 
-		// 			ConnectPeers: cfg.ConnectPeers,
-
-		// 			AddPeers:     cfg.AddPeers,
-
-		// 		})
-
-		// 	if err != nil {
-
-		// 		log<-cl.Errorf{"couldn't create Neutrino ChainService: %s", err)
-
-		// 		continue
-
-		// 	}
-
-		// 	chainClient = chain.NewNeutrinoClient(ActiveNet.Params, chainService)
-
-		// 	err = chainClient.Start()
-
-		// 	if err != nil {
+				continue
+			}
 
-		// 		log<-cl.Errorf{"couldn't start Neutrino client: %s", err)
+			retryDelay = lightClientRetryDelay
 
-		// 	}
+		} else {
 
-		// } else {
+			chainClient, err = startChainRPC(certs)
 
-		chainClient, err = startChainRPC(certs)
+			if err != nil {
 
-		if err != nil {
+				log <- cl.Error{
 
-			log <- cl.Error{
+					"unable to open connection to consensus RPC server:", err}
+				continue
+			}
 
-				"unable to open connection to consensus RPC server:", err}
-			continue
 		}
 
-		// }
-
 		// Rather than inlining this logic directly into the loader
 
 		// callback, a function variable is used to avoid running any of
@@ -384,6 +461,55 @@ func rpcClientConnectLoop(legacyRPCServer *legacyrpc.Server, loader *wallet.Load
 
 }
 
+// startChainService creates and starts the light client used by the spv
+// backend, adding an interrupt handler to stop it.
+func startChainService() (*spv.ChainService, error) {
+
+	chainService, db, err := spv.NewChainServiceFromConfig(cfg, ActiveNet.Params)
+
+	if err != nil {
+
+		return nil, err
+	}
+
+	log <- cl.Info{
+
+		"starting light client in", spv.DataDir(*cfg.DataDir, ActiveNet.Params),
+	}
+
+	chainService.Start()
+
+	interrupt.AddHandler(func() {
+
+		log <- cl.Wrn("stopping light client...")
+
+		if err := chainService.Stop(); err != nil {
+
+			log <- cl.Error{"failed to stop light client:", err}
+		}
+
+		if err := db.Close(); err != nil {
+
+			log <- cl.Error{"failed to close light client database:", err}
+		}
+
+		log <- cl.Inf("light client shutdown")
+	})
+
+	return chainService, nil
+}
+
+// startNeutrinoClient starts a chain client syncing the wallet with the
+// compact filters of the passed light client.
+func startNeutrinoClient(
+
+	chainService *spv.ChainService) (*chain.NeutrinoClient, error) {
+
+	client := chain.NewNeutrinoClient(ActiveNet.Params, chainService)
+	err := client.Start()
+	return client, err
+}
+
 // startChainRPC opens a RPC client connection to a pod server for blockchain
 
 // services.  This function uses the RPC options from the global config and
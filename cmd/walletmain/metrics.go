@@ -0,0 +1,75 @@
+package walletmain
+
+import (
+	"net/http"
+
+	"git.parallelcoin.io/dev/pod/pkg/util/metrics"
+	"git.parallelcoin.io/dev/pod/pkg/wallet"
+)
+
+var (
+	walletLoaded = metrics.NewGauge("pod_wallet_loaded",
+		"Whether a wallet is loaded")
+	walletChainConnected = metrics.NewGauge("pod_wallet_chain_connected",
+		"Whether the wallet is attached to a chain backend")
+	walletSynced = metrics.NewGauge("pod_wallet_synced",
+		"Whether the wallet is synced to the best block of the chain backend")
+	walletSyncedHeight = metrics.NewGauge("pod_wallet_synced_height",
+		"Height of the block the wallet is synced to")
+	walletSyncedTime = metrics.NewGauge("pod_wallet_synced_timestamp_seconds",
+		"Unix time of the block the wallet is synced to")
+)
+
+// startMetricsServer starts exporting the metrics of the wallet, including
+// the sync state of the wallet loaded by loader, on the metrics listener.
+// The returned server is nil when no metrics listener is configured.
+func startMetricsServer(loader *wallet.Loader) (*http.Server, error) {
+
+	if cfg.WalletMetricsListener == nil || *cfg.WalletMetricsListener == "" {
+
+		return nil, nil
+	}
+	server, err := metrics.Serve(*cfg.WalletMetricsListener)
+
+	if err != nil {
+
+		return nil, err
+	}
+	metrics.OnCollect(func() {
+
+		collectWalletMetrics(loader)
+	})
+	return server, nil
+}
+
+// collectWalletMetrics sets the wallet sync state gauges from the wallet
+// loaded by loader.
+func collectWalletMetrics(loader *wallet.Loader) {
+
+	w, loaded := loader.LoadedWallet()
+
+	if !loaded {
+
+		walletLoaded.Set(0)
+		walletChainConnected.Set(0)
+		walletSynced.Set(0)
+		return
+	}
+	syncedTo := w.Manager.SyncedTo()
+	walletLoaded.Set(1)
+	walletChainConnected.Set(boolToFloat(w.SynchronizingToNetwork()))
+	walletSynced.Set(boolToFloat(w.ChainSynced()))
+	walletSyncedHeight.Set(float64(syncedTo.Height))
+	walletSyncedTime.Set(float64(syncedTo.Timestamp.Unix()))
+}
+
+// boolToFloat returns 1 for true and 0 for false, the usual gauge values of
+// flags.
+func boolToFloat(b bool) float64 {
+
+	if b {
+
+		return 1
+	}
+	return 0
+}
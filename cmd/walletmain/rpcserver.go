@@ -338,6 +338,7 @@ func startRPCServers(
 			Password:            *cfg.Password,
 			MaxPOSTClients:      int64(*cfg.LegacyRPCMaxClients),
 			MaxWebsocketClients: int64(*cfg.LegacyRPCMaxWebsockets),
+			MaxBatchSize:        *cfg.LegacyRPCMaxBatchSize,
 		}
 
 		legacyServer = legacyrpc.NewServer(&opts, walletLoader, listeners)
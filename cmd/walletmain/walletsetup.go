@@ -2,6 +2,7 @@ package walletmain
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -14,6 +15,7 @@ import (
 	"git.parallelcoin.io/dev/pod/pkg/util"
 	cl "git.parallelcoin.io/dev/pod/pkg/util/cl"
 	ec "git.parallelcoin.io/dev/pod/pkg/util/elliptic"
+	"git.parallelcoin.io/dev/pod/pkg/util/hdkeychain"
 	"git.parallelcoin.io/dev/pod/pkg/util/legacy/keystore"
 	"git.parallelcoin.io/dev/pod/pkg/util/prompt"
 	"git.parallelcoin.io/dev/pod/pkg/wallet"
@@ -181,12 +183,14 @@ func CreateWallet(
 		return err
 	}
 
-	// Ascertain the wallet generation seed.  This will either be an
+	// Ascertain the wallet generation seed.  This will either be derived
 
-	// automatically generated value the user has already confirmed or a
+	// from generated seed words the user has already confirmed or from seed
 
-	// value the user has entered which has already been validated.
-	seed, err := prompt.Seed(reader)
+	// words or a hex seed the user has entered which have already been
+
+	// validated.
+	seed, restore, err := prompt.Seed(reader)
 
 	if err != nil {
 
@@ -196,9 +200,20 @@ func CreateWallet(
 		return err
 	}
 
-	log <- cl.Dbg("Creating the wallet...")
+	var w *wallet.Wallet
+
+	if restore {
+
+		// The wallet rescans the chain for the addresses of the seed the
+		// first time it is synced.
+		log <- cl.Dbg("Restoring the wallet...")
+		w, err = loader.RestoreWallet(pubPass, privPass, seed)
 
-	w, err := loader.CreateNewWallet(pubPass, privPass, seed, time.Now())
+	} else {
+
+		log <- cl.Dbg("Creating the wallet...")
+		w, err = loader.CreateNewWallet(pubPass, privPass, seed, time.Now())
+	}
 
 	if err != nil {
 
@@ -215,6 +230,63 @@ func CreateWallet(
 	return nil
 }
 
+// CreateWatchingOnlyWallet creates a watching-only wallet whose default account is created from the passed extended public key of an account of another wallet, prompting the user for the public passphrase.  The wallet holds no private keys, so it can track the balance and history of the account and create unsigned transactions, but not sign them.
+func CreateWatchingOnlyWallet(
+
+	cfg *pod.Config, activeNet *netparams.Params, xpub string) error {
+
+	acctKeyPub, err := hdkeychain.NewKeyFromString(xpub)
+
+	if err != nil {
+
+		return err
+	}
+
+	if acctKeyPub.IsPrivate() {
+
+		return errors.New("the watching-only wallet must be created from an extended public key")
+	}
+
+	if !acctKeyPub.IsForNet(activeNet.Params) {
+
+		return fmt.Errorf("the extended public key is not for %s",
+			activeNet.Params.Name)
+	}
+
+	dbDir := NetworkDir(*cfg.DataDir, activeNet.Params)
+	loader := wallet.NewLoader(activeNet.Params, dbDir, 250)
+	reader := bufio.NewReader(os.Stdin)
+	pubPass, err := prompt.PublicPass(reader, nil,
+		[]byte(""), []byte(*cfg.WalletPass))
+
+	if err != nil {
+
+		log <- cl.Debug{err}
+
+		time.Sleep(time.Second * 5)
+		return err
+	}
+
+	// The wallet rescans the chain for the addresses of the account the
+	// first time it is synced.
+	log <- cl.Dbg("Creating the watching-only wallet...")
+	w, err := loader.CreateWatchingOnlyWallet(pubPass, acctKeyPub)
+
+	if err != nil {
+
+		log <- cl.Debug{err}
+
+		time.Sleep(time.Second * 5)
+		return err
+	}
+
+	w.Manager.Close()
+
+	log <- cl.Dbg("The watching-only wallet has been created successfully.")
+
+	return nil
+}
+
 // NetworkDir returns the directory name of a network directory to hold wallet files.
 func NetworkDir(
 
@@ -19,7 +19,6 @@ require (
 	github.com/dave/dst v0.23.1
 	github.com/davecgh/go-spew v1.1.1
 	github.com/dchest/blake256 v1.0.0
-	github.com/dogeerf/rpcx v0.0.0-20170210022235-46193ffbf85c
 	github.com/ebfe/keccak v0.0.0-20150115210727-5cc570678d1b
 	github.com/gogo/protobuf v1.2.1 // indirect
 	github.com/golang/protobuf v1.3.1
@@ -33,7 +32,6 @@ require (
 	github.com/lightninglabs/gozmq v0.0.0-20180324010646-462a8a753885
 	github.com/mitchellh/colorstring v0.0.0-20190213212951-d06e56a500db
 	github.com/nanobox-io/golang-scribble v0.0.0-20190309225732-aa3e7c118975
-	github.com/obsilp/rmnp v0.0.0-20181020095946-f937c1003aae
 	github.com/pelletier/go-toml v1.2.0
 	github.com/pierrec/lz4 v2.0.5+incompatible // indirect
 	github.com/programmer10110/gostreebog v0.0.0-20170704145444-a3e1d28291b2
@@ -50,6 +48,7 @@ require (
 	go.uber.org/atomic v1.3.2
 	golang.org/x/crypto v0.0.0-20190313024323-a1f597ede03a
 	golang.org/x/net v0.0.0-20190320064053-1272bf9dcd53
+	golang.org/x/text v0.3.0
 	google.golang.org/grpc v1.19.0
 	gopkg.in/urfave/cli.v1 v1.20.0
 	launchpad.net/gocheck v0.0.0-20140225173054-000000000087 // indirect
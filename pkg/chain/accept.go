@@ -52,7 +52,7 @@ func (b *BlockChain) maybeAcceptBlock(block *util.Block, flags BehaviorFlags) (b
 
 		for ; i < b.chainParams.AveragingInterval-1; i++ {
 
-			pn = pn.GetLastWithAlgo(a)
+			pn = pn.GetLastWithAlgo(a, b.HardForks())
 
 			if pn == nil {
 
@@ -87,7 +87,7 @@ func (b *BlockChain) maybeAcceptBlock(block *util.Block, flags BehaviorFlags) (b
 
 	// Create a new block node for the block and add it to the node index. Even if the block ultimately gets connected to the main chain, it starts out on a side chain.
 	blockHeader := &block.MsgBlock().Header
-	newNode := newBlockNode(blockHeader, prevNode)
+	newNode := newBlockNode(blockHeader, prevNode, b.HardForks())
 	newNode.status = statusDataStored
 	b.Index.AddNode(newNode)
 	err = b.Index.flushToDB()
@@ -105,6 +105,13 @@ func (b *BlockChain) maybeAcceptBlock(block *util.Block, flags BehaviorFlags) (b
 		return false, err
 	}
 
+	// Delete the oldest blocks if the new block takes the stored blocks over the prune target.
+
+	if isMainChain {
+
+		b.maybePrune()
+	}
+
 	// Notify the caller that the new block was accepted into the block chain.  The caller would typically want to react by relaying the inventory to other peers.
 	b.chainLock.Unlock()
 	b.sendNotification(NTBlockAccepted, block)
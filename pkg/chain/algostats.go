@@ -0,0 +1,171 @@
+package blockchain
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+
+	"git.parallelcoin.io/dev/pod/pkg/chain/fork"
+)
+
+// AlgoStats is the summary of the blocks found by one mining algorithm within a range of the main chain.
+
+type AlgoStats struct {
+
+	// Name is the name of the algorithm as used in the fork package.
+	Name string
+
+	// Version is the block version that identifies the algorithm.
+	Version int32
+
+	// Blocks is the number of blocks of this algorithm within the range.
+	Blocks int32
+
+	// LastHeight is the height of the newest block of this algorithm within the range, or -1 if there is none.
+	LastHeight int32
+
+	// Bits is the difficulty target of the newest block of this algorithm within the range.
+	Bits uint32
+
+	// Hashes is the expected number of hashes that were required to find all but the oldest block of the algorithm within the range.
+	Hashes *big.Int
+
+	// FirstTimestamp and LastTimestamp are the times of the oldest and newest block of the algorithm within the range.
+	FirstTimestamp int64
+	LastTimestamp  int64
+}
+
+// HashesPerSec returns the estimated network hash rate of the algorithm over the range, or zero if there are not enough blocks to measure it.
+func (a *AlgoStats) HashesPerSec() int64 {
+
+	timeDiff := a.LastTimestamp - a.FirstTimestamp
+
+	if a.Blocks < 2 || timeDiff <= 0 {
+
+		return 0
+	}
+
+	return new(big.Int).Div(a.Hashes, big.NewInt(timeDiff)).Int64()
+}
+
+// AvgInterval returns the average number of seconds between two blocks of the algorithm over the range, or zero if there are not enough blocks to measure it.
+func (a *AlgoStats) AvgInterval() float64 {
+
+	if a.Blocks < 2 {
+
+		return 0
+	}
+
+	return float64(a.LastTimestamp-a.FirstTimestamp) / float64(a.Blocks-1)
+}
+
+// CalcAlgoStats walks the main chain backwards from endHeight to startHeight (both inclusive) for each of the algorithms active at endHeight and returns a summary of the blocks found by each, ordered by block version. A negative endHeight means the current best chain height. This function is safe for concurrent access.
+func (b *BlockChain) CalcAlgoStats(startHeight, endHeight int32) ([]AlgoStats, error) {
+
+	b.chainLock.RLock()
+	defer b.chainLock.RUnlock()
+
+	tip := b.bestChain.Tip()
+
+	if endHeight < 0 || endHeight > tip.height {
+
+		endHeight = tip.height
+	}
+
+	if startHeight < 0 {
+
+		startHeight = 0
+	}
+
+	if startHeight > endHeight {
+
+		return nil, fmt.Errorf("start height %d of algo stats range is after the end height %d", startHeight, endHeight)
+	}
+
+	endNode := b.bestChain.NodeByHeight(endHeight)
+
+	if endNode == nil {
+
+		return nil, errNotInMainChain(fmt.Sprintf("no block at height %d exists", endHeight))
+	}
+
+	forks := b.HardForks()
+	hf := forks[forks.GetCurrent(endHeight)]
+	stats := make([]AlgoStats, 0, len(hf.AlgoVers))
+	byVersion := make(map[int32]int, len(hf.AlgoVers))
+
+	for version, name := range hf.AlgoVers {
+
+		byVersion[version] = len(stats)
+		stats = append(stats, AlgoStats{
+
+			Name:       name,
+			Version:    version,
+			LastHeight: -1,
+			Hashes:     big.NewInt(0),
+		})
+	}
+
+	// Walk the range once from the newest block, keeping the newer block of each algorithm found so far.
+	newer := make(map[int32]*blockNode, len(hf.AlgoVers))
+
+	for node := endNode; node != nil && node.height >= startHeight; node = node.parent {
+
+		version := statsAlgoVersion(node, forks)
+		i, ok := byVersion[version]
+
+		if !ok {
+
+			continue
+		}
+
+		s := &stats[i]
+
+		if newer[version] == nil {
+
+			s.LastHeight = node.height
+			s.Bits = node.bits
+			s.LastTimestamp = node.timestamp
+
+		} else {
+
+			// The work of the oldest block in the range was done before the measured interval started, so only the newer block of each pair is counted.
+			s.Hashes.Add(s.Hashes, calcHashes(newer[version].bits))
+		}
+
+		s.FirstTimestamp = node.timestamp
+		s.Blocks++
+		newer[version] = node
+	}
+
+	sort.Slice(stats, func(i, j int) bool {
+
+		return stats[i].Version < stats[j].Version
+	})
+
+	return stats, nil
+}
+
+// statsAlgoVersion returns the algorithm version of the block for the algo stats, counting the irregular versions of blocks from before the first hard fork as sha256d.
+func statsAlgoVersion(node *blockNode, forks fork.Schedule) int32 {
+
+	if forks.GetCurrent(node.height) == 0 && node.version != 514 && node.version != 2 {
+
+		return 2
+	}
+
+	return node.version
+}
+
+// calcHashes returns the expected number of hashes required to find a block with the given difficulty target, which is 2^256 / (target+1).
+func calcHashes(bits uint32) *big.Int {
+
+	target := CompactToBig(bits)
+
+	if target.Sign() <= 0 {
+
+		return big.NewInt(0)
+	}
+
+	return new(big.Int).Div(oneLsh256, new(big.Int).Add(target, bigOne))
+}
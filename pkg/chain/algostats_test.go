@@ -0,0 +1,76 @@
+package blockchain
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	chaincfg "git.parallelcoin.io/dev/pod/pkg/chain/config"
+)
+
+// TestCalcAlgoStats ensures the blocks of each algorithm within a range of the main chain are counted, including irregular versions from before the first hard fork as sha256d, and that the hashes of all but the oldest block of each algorithm are summed.
+func TestCalcAlgoStats(t *testing.T) {
+
+	chain := newFakeChain(&chaincfg.MainNetParams)
+	const bits = 0x207fffff
+	tip := chain.bestChain.Tip()
+	timestamp := time.Unix(tip.timestamp, 0)
+
+	for _, version := range []int32{514, 2, 7, 514, 2} {
+
+		timestamp = timestamp.Add(time.Minute)
+		tip = newFakeNode(tip, version, bits, timestamp)
+		chain.Index.AddNode(tip)
+	}
+
+	chain.bestChain.SetTip(tip)
+
+	stats, err := chain.CalcAlgoStats(1, -1)
+
+	if err != nil {
+
+		t.Fatalf("CalcAlgoStats: unexpected error: %v", err)
+	}
+
+	blockHashes := calcHashes(bits)
+	tests := []struct {
+		name       string
+		version    int32
+		blocks     int32
+		lastHeight int32
+		hashes     *big.Int
+	}{
+		{"sha256d", 2, 3, 5, new(big.Int).Mul(blockHashes, big.NewInt(2))},
+		{"scrypt", 514, 2, 4, blockHashes},
+	}
+
+	if len(stats) != len(tests) {
+
+		t.Fatalf("CalcAlgoStats: got %d algorithms, want %d", len(stats), len(tests))
+	}
+
+	for i, test := range tests {
+
+		s := stats[i]
+
+		if s.Name != test.name || s.Version != test.version ||
+			s.Blocks != test.blocks || s.LastHeight != test.lastHeight ||
+			s.Hashes.Cmp(test.hashes) != 0 {
+
+			t.Errorf("CalcAlgoStats %s: got %+v, want %d blocks, last height %d and %v hashes", test.name, s, test.blocks, test.lastHeight, test.hashes)
+		}
+	}
+
+	// The genesis block is counted when the range starts at it.
+	stats, err = chain.CalcAlgoStats(0, 5)
+
+	if err != nil {
+
+		t.Fatalf("CalcAlgoStats: unexpected error: %v", err)
+	}
+
+	if stats[0].Blocks != 4 {
+
+		t.Errorf("CalcAlgoStats from genesis: got %d sha256d blocks, want 4", stats[0].Blocks)
+	}
+}
@@ -90,10 +90,11 @@ type blockNode struct {
 	status blockStatus
 }
 
-// initBlockNode initializes a block node from the given header and parent node, calculating the height and workSum from the respective fields on the parent. This function is NOT safe for concurrent access.  It must only be called when initially creating a node.
+// initBlockNode initializes a block node from the given header and parent node, calculating the height and workSum from the respective fields on the parent using the algorithm parameters of the given hard fork schedule. This function is NOT safe for concurrent access.  It must only be called when initially creating a node.
 func initBlockNode(
 
-	node *blockNode, blockHeader *wire.BlockHeader, parent *blockNode) {
+	node *blockNode, blockHeader *wire.BlockHeader, parent *blockNode,
+	forks fork.Schedule) {
 
 	*node = blockNode{
 
@@ -109,8 +110,8 @@ func initBlockNode(
 
 		node.parent = parent
 		node.height = parent.height + 1
-		node.workSum = CalcWork(blockHeader.Bits, node.height, node.version)
-		parent.workSum = CalcWork(parent.bits, parent.height, parent.version)
+		node.workSum = calcWork(forks, blockHeader.Bits, node.height, node.version)
+		parent.workSum = calcWork(forks, parent.bits, parent.height, parent.version)
 		node.workSum = node.workSum.Add(parent.workSum, node.workSum)
 	}
 
@@ -119,10 +120,11 @@ func initBlockNode(
 // newBlockNode returns a new block node for the given block header and parent node, calculating the height and workSum from the respective fields on the parent. This function is NOT safe for concurrent access.
 func newBlockNode(
 
-	blockHeader *wire.BlockHeader, parent *blockNode) *blockNode {
+	blockHeader *wire.BlockHeader, parent *blockNode,
+	forks fork.Schedule) *blockNode {
 
 	var node blockNode
-	initBlockNode(&node, blockHeader, parent)
+	initBlockNode(&node, blockHeader, parent, forks)
 	return &node
 }
 
@@ -210,6 +212,9 @@ type blockIndex struct {
 	sync.RWMutex
 	index map[chainhash.Hash]*blockNode
 	dirty map[*blockNode]struct{}
+
+	// tips is the set of nodes that have no children in the index, which are the heads of the main chain and of every side chain.
+	tips map[*blockNode]struct{}
 }
 
 // newBlockIndex returns a new empty instance of a block index.  The index will be dynamically populated as block nodes are loaded from the database and manually added.
@@ -223,6 +228,7 @@ func newBlockIndex(
 		chainParams: chainParams,
 		index:       make(map[chainhash.Hash]*blockNode),
 		dirty:       make(map[*blockNode]struct{}),
+		tips:        make(map[*blockNode]struct{}),
 	}
 
 }
@@ -259,6 +265,25 @@ func (bi *blockIndex) AddNode(node *blockNode) {
 func (bi *blockIndex) addNode(node *blockNode) {
 
 	bi.index[node.hash] = node
+
+	// The parent of the node is no longer the head of a chain.
+	delete(bi.tips, node.parent)
+	bi.tips[node] = struct{}{}
+}
+
+// Tips returns the nodes that have no children in the index, which are the heads of the main chain and of every side chain. This function is safe for concurrent access.
+func (bi *blockIndex) Tips() []*blockNode {
+
+	bi.RLock()
+	tips := make([]*blockNode, 0, len(bi.tips))
+
+	for node := range bi.tips {
+
+		tips = append(tips, node)
+	}
+
+	bi.RUnlock()
+	return tips
 }
 
 // NodeStatus provides concurrent-safe access to the status field of a node. This function is safe for concurrent access.
@@ -338,15 +363,16 @@ func (node *blockNode) GetAlgo() int32 {
 	return node.version
 }
 
-// GetLastWithAlgo returns the newest block from node with specified algo
-func (node *blockNode) GetLastWithAlgo(algo int32) (prev *blockNode) {
+// GetLastWithAlgo returns the newest block from node with specified algo, using the given hard fork schedule to identify blocks from before the first hard fork
+func (node *blockNode) GetLastWithAlgo(
+	algo int32, forks fork.Schedule) (prev *blockNode) {
 
 	if prev == nil {
 
 		return nil
 	}
 
-	if fork.GetCurrent(prev.height) == 0 {
+	if forks.GetCurrent(prev.height) == 0 {
 
 		if algo != 514 &&
 
@@ -370,7 +396,7 @@ func (node *blockNode) GetLastWithAlgo(algo int32) (prev *blockNode) {
 		// log <- cl.Debugf{"node %d %d %8x",prev.height, prev.version, prev.bits}
 
 		prevversion := prev.version
-		if fork.GetCurrent(prev.height) == 0 {
+		if forks.GetCurrent(prev.height) == 0 {
 
 			if prev.version != 514 &&
 
@@ -9,6 +9,7 @@ import (
 	cl "git.parallelcoin.io/dev/pod/pkg/util/cl"
 
 	chaincfg "git.parallelcoin.io/dev/pod/pkg/chain/config"
+	"git.parallelcoin.io/dev/pod/pkg/chain/fork"
 	chainhash "git.parallelcoin.io/dev/pod/pkg/chain/hash"
 	txscript "git.parallelcoin.io/dev/pod/pkg/chain/tx/script"
 	"git.parallelcoin.io/dev/pod/pkg/chain/wire"
@@ -85,6 +86,9 @@ type BlockChain struct {
 	sigCache            *txscript.SigCache
 	indexManager        IndexManager
 	hashCache           *txscript.HashCache
+	assumeValid         *chaincfg.Checkpoint
+	scriptThreads       int
+	pruneTarget         uint64
 
 	// The following fields are calculated based upon the provided chain parameters.  They are also set when the instance is created and can't be changed afterwards, so there is no need to protect them with
 
@@ -114,6 +118,13 @@ type BlockChain struct {
 	nextCheckpoint *chaincfg.Checkpoint
 	checkpointNode *blockNode
 
+	// snapshot is the state of the background validation of a loaded utxo snapshot, which is nil when no snapshot was loaded or the blocks before it are validated.  It is protected by the chain lock.
+	snapshot *snapshotState
+
+	// pruned is whether blocks have been deleted from the database to keep it below the prune target, and pruneHeight is the height of the oldest block of the main chain that is still stored.  They are protected by the chain lock.
+	pruned      bool
+	pruneHeight int32
+
 	// The state is used as a fairly efficient way to cache information about the current best chain state that is returned to callers when requested.  It operates on the principle of MVCC such that any time a new block becomes the best block, the state pointer is replaced with a new struct and the old state is left untouched.  In this way, multiple callers can be pointing to different best chain states. This is acceptable for most callers because the state is only being queried at a specific point in time. In addition, some of the fields are stored in the database so the chain state can be quickly reconstructed on load.
 	stateLock     sync.RWMutex
 	stateSnapshot *BestState
@@ -948,7 +959,7 @@ func (b *BlockChain) reorganizeChain(detachNodes, attachNodes *list.List) error
 
 		// Notice the spent txout details are not requested here and thus will not be generated.  This is done because the state is not being immediately written to the database, so it is not needed.
 		// In the case the block is determined to be invalid due to a rule violation, mark it as invalid and mark all of its descendants as having an invalid ancestor.
-		err = b.checkConnectBlock(n, block, view, nil)
+		err = b.checkConnectBlock(n, block, view, nil, BFNone)
 
 		if err != nil {
 
@@ -1112,7 +1123,7 @@ func (b *BlockChain) connectBestChain(node *blockNode, block *util.Block, flags
 
 		if !fastAdd {
 
-			err := b.checkConnectBlock(node, block, view, &stxos)
+			err := b.checkConnectBlock(node, block, view, &stxos, flags)
 
 			if err == nil {
 
@@ -1198,7 +1209,7 @@ func (b *BlockChain) connectBestChain(node *blockNode, block *util.Block, flags
 
 	}
 
-	node.workSum = CalcWork(node.bits, node.height, node.version)
+	node.workSum = calcWork(b.HardForks(), node.bits, node.height, node.version)
 
 	// We're extending (or creating) a side chain, but the cumulative work for this new side chain is not enough to make it the new chain.
 
@@ -1304,6 +1315,23 @@ func (b *BlockChain) BestSnapshot() *BestState {
 	return snapshot
 }
 
+// HardForks returns the hard fork schedule of the network the chain is running on. This function is safe for concurrent access.
+func (b *BlockChain) HardForks() fork.Schedule {
+
+	return HardForkSchedule(b.chainParams)
+}
+
+// HardForkSchedule returns the hard fork schedule of the given chain parameters, or the default schedule of the fork package if the parameters do not specify one.
+func HardForkSchedule(
+	params *chaincfg.Params) fork.Schedule {
+
+	if len(params.HardForks) == 0 {
+
+		return fork.List
+	}
+	return params.HardForks
+}
+
 // HeaderByHash returns the block header identified by the given hash or an error if it doesn't exist. Note that this will return headers from both the main and side chains.
 func (b *BlockChain) HeaderByHash(hash *chainhash.Hash) (wire.BlockHeader, error) {
 
@@ -1694,6 +1722,18 @@ type Config struct {
 
 	// HashCache defines a transaction hash mid-state cache to use when validating transactions. This cache has the potential to greatly speed up transaction validation as re-using the pre-calculated mid-state eliminates the O(N^2) validation complexity due to the SigHashAll flag. This field can be nil if the caller is not interested in using a signature cache.
 	HashCache *txscript.HashCache
+
+	// AssumeValid overrides the assumed valid block in ChainParams, under which transaction scripts are not validated.  This field can be nil to use the default from ChainParams.
+	AssumeValid *chaincfg.Checkpoint
+
+	// NoAssumeValid disables the assumed valid block so that every transaction script is validated.
+	NoAssumeValid bool
+
+	// ScriptThreads is the number of goroutines used to validate the transaction scripts of a block.  This field can be zero to use three per processor core.
+	ScriptThreads int
+
+	// Prune is the target size in bytes of the stored blocks, above which the oldest blocks are deleted.  At least MinBlocksToKeep blocks below the tip are always kept.  This field can be zero to keep every block.
+	Prune uint64
 }
 
 // New returns a BlockChain instance using the provided configuration details.
@@ -1743,6 +1783,18 @@ func New(
 	}
 
 	params := config.ChainParams
+	assumeValid := params.AssumeValid
+
+	if config.AssumeValid != nil {
+
+		assumeValid = config.AssumeValid
+	}
+
+	if config.NoAssumeValid {
+
+		assumeValid = nil
+	}
+
 	targetTimespan := int64(params.TargetTimespan)
 	targetTimePerBlock := int64(params.TargetTimePerBlock)
 	adjustmentFactor := params.RetargetAdjustmentFactor
@@ -1761,6 +1813,9 @@ func New(
 		blocksPerRetarget:     int32(targetTimespan / targetTimePerBlock),
 		Index:                 newBlockIndex(config.DB, params),
 		hashCache:             config.HashCache,
+		assumeValid:           assumeValid,
+		scriptThreads:         config.ScriptThreads,
+		pruneTarget:           config.Prune,
 		bestChain:             newChainView(nil),
 		orphans:               make(map[chainhash.Hash]*orphanBlock),
 		prevOrphans:           make(map[chainhash.Hash][]*orphanBlock),
@@ -1776,6 +1831,26 @@ func New(
 		return nil, err
 	}
 
+	// Resume the background validation of a loaded utxo snapshot.  The optional indexes need the blocks before the snapshot, so they can't be enabled until the blocks are validated.
+
+	if err := b.loadSnapshotState(); err != nil {
+
+		return nil, err
+	}
+
+	if b.snapshot != nil && config.IndexManager != nil {
+
+		return nil, fmt.Errorf("indexes can't be enabled until the blocks " +
+			"before the loaded utxo snapshot are validated")
+	}
+
+	// Find the oldest block still stored if blocks have been pruned.
+
+	if err := b.initPruneState(); err != nil {
+
+		return nil, err
+	}
+
 	// Perform any upgrades to the various chain-specific buckets as needed.
 
 	if err := b.maybeUpgradeDbBuckets(config.Interrupt); err != nil {
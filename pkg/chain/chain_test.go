@@ -6,6 +6,7 @@ import (
 	"time"
 
 	chaincfg "git.parallelcoin.io/dev/pod/pkg/chain/config"
+	"git.parallelcoin.io/dev/pod/pkg/chain/fork"
 	chainhash "git.parallelcoin.io/dev/pod/pkg/chain/hash"
 	"git.parallelcoin.io/dev/pod/pkg/chain/wire"
 	"git.parallelcoin.io/dev/pod/pkg/util"
@@ -1143,3 +1144,110 @@ func TestIntervalBlockHashes(
 	}
 
 }
+
+// TestHardForks ensures the chain consults the hard fork schedule of its own chain parameters rather than the package default.
+func TestHardForks(
+	t *testing.T) {
+
+	// Create a network that activates the second hard fork at height 10.
+	params := chaincfg.RegressionNetParams
+	params.HardForks = make(fork.Schedule, len(fork.List))
+	copy(params.HardForks, fork.List)
+	params.HardForks[1].ActivationHeight = 10
+	chain := newFakeChain(&params)
+
+	tests := []struct {
+		height int32
+		want   int
+	}{
+		{height: 10, want: 0},
+		{height: 11, want: 1},
+	}
+
+	for _, test := range tests {
+
+		got := chain.HardForks().GetCurrent(test.height)
+
+		if got != test.want {
+
+			t.Errorf("GetCurrent(%d): unexpected hard fork -- got %d, want %d", test.height, got, test.want)
+		}
+	}
+
+	// The package default schedule must not be affected by the network schedule.
+
+	if fork.List.GetCurrent(11) != 0 {
+
+		t.Errorf("default schedule was modified by the network schedule")
+	}
+
+	// The subsidy is calculated with the hard fork schedule of the network.
+
+	if CalcBlockSubsidy(11, &params) == CalcBlockSubsidy(11, &chaincfg.RegressionNetParams) {
+
+		t.Errorf("CalcBlockSubsidy: subsidy did not follow the network hard fork schedule")
+	}
+
+	// Parameters without a schedule fall back to the package default.
+	params.HardForks = nil
+
+	if got := newFakeChain(&params).HardForks().GetCurrent(11); got != 0 {
+
+		t.Errorf("GetCurrent(11): unexpected hard fork with default schedule -- got %d, want 0", got)
+	}
+}
+
+// TestNetworkHardForks ensures the hard fork schedule of each network activates Plan 9 at the main network height, and that the algorithm ids and names follow the hard fork that is active at a height.
+func TestNetworkHardForks(
+	t *testing.T) {
+
+	networks := []*chaincfg.Params{
+		&chaincfg.MainNetParams,
+		&chaincfg.TestNet3Params,
+		&chaincfg.RegressionNetParams,
+		&chaincfg.SimNetParams,
+	}
+	tests := []struct {
+		height   int32
+		fork     int
+		algo     string
+		algoID   uint32
+		algoVer  int32
+		algoName string
+	}{
+		{height: 0, fork: 0, algo: "sha256d", algoID: 0, algoVer: 2, algoName: "sha256d"},
+		{height: 101, fork: 0, algo: "scrypt", algoID: 1, algoVer: 514, algoName: "scrypt"},
+		{height: 250000, fork: 0, algo: "scrypt", algoID: 1, algoVer: 514, algoName: "scrypt"},
+		{height: 250001, fork: 1, algo: "sha256d", algoID: 5, algoVer: 5, algoName: "sha256d"},
+		{height: 250001, fork: 1, algo: "scrypt", algoID: 4, algoVer: 4, algoName: "scrypt"},
+		{height: 250001, fork: 1, algo: "skein", algoID: 7, algoVer: 6, algoName: "skein"},
+	}
+
+	for _, params := range networks {
+
+		forks := newFakeChain(params).HardForks()
+
+		for _, test := range tests {
+
+			if got := forks.GetCurrent(test.height); got != test.fork {
+
+				t.Errorf("%s GetCurrent(%d): got %d, want %d", params.Name, test.height, got, test.fork)
+			}
+
+			if got := forks.GetAlgoID(test.algo, test.height); got != test.algoID {
+
+				t.Errorf("%s GetAlgoID(%s, %d): got %d, want %d", params.Name, test.algo, test.height, got, test.algoID)
+			}
+
+			if got := forks.GetAlgoVer(test.algo, test.height); got != test.algoVer {
+
+				t.Errorf("%s GetAlgoVer(%s, %d): got %d, want %d", params.Name, test.algo, test.height, got, test.algoVer)
+			}
+
+			if got := forks.GetAlgoName(test.algoVer, test.height); got != test.algoName {
+
+				t.Errorf("%s GetAlgoName(%d, %d): got %s, want %s", params.Name, test.algoVer, test.height, got, test.algoName)
+			}
+		}
+	}
+}
@@ -711,9 +711,17 @@ func dbFetchUtxoEntry(
 
 	dbTx database.Tx, outpoint wire.OutPoint) (*UtxoEntry, error) {
 
+	return dbFetchUtxoEntryFromBucket(
+		dbTx.Metadata().Bucket(utxoSetBucketName), outpoint)
+}
+
+// dbFetchUtxoEntryFromBucket fetches the specified transaction output from the utxo set in the passed bucket, which is either the utxo set of the main chain or the one of the background validation of a utxo snapshot. When there is no entry for the provided output, nil will be returned for both the entry and the error.
+func dbFetchUtxoEntryFromBucket(
+
+	utxoBucket database.Bucket, outpoint wire.OutPoint) (*UtxoEntry, error) {
+
 	// Fetch the unspent transaction output information for the passed transaction output.  Return now when there is no entry.
 	key := outpointKey(outpoint)
-	utxoBucket := dbTx.Metadata().Bucket(utxoSetBucketName)
 	serializedUtxo := utxoBucket.Get(*key)
 	recycleOutpointKey(key)
 
@@ -760,7 +768,14 @@ func dbPutUtxoView(
 
 	dbTx database.Tx, view *UtxoViewpoint) error {
 
-	utxoBucket := dbTx.Metadata().Bucket(utxoSetBucketName)
+	return dbPutUtxoViewToBucket(dbTx.Metadata().Bucket(utxoSetBucketName),
+		view)
+}
+
+// dbPutUtxoViewToBucket updates the utxo set in the passed bucket based on the provided utxo view contents and state in the same way as dbPutUtxoView.
+func dbPutUtxoViewToBucket(
+
+	utxoBucket database.Bucket, view *UtxoViewpoint) error {
 
 	for outpoint, entry := range view.entries {
 
@@ -1015,7 +1030,7 @@ func (b *BlockChain) createChainState() error {
 
 	genesisBlock.SetHeight(0)
 	header := &genesisBlock.MsgBlock().Header
-	node := newBlockNode(header, nil)
+	node := newBlockNode(header, nil, b.HardForks())
 	node.status = statusDataStored | statusValid
 	b.bestChain.SetTip(node)
 
@@ -1098,7 +1113,7 @@ func (b *BlockChain) createChainState() error {
 			return err
 		}
 		// Store the current best chain state into the database.
-		node.workSum = CalcWork(node.bits, node.height, node.version)
+		node.workSum = calcWork(b.HardForks(), node.bits, node.height, node.version)
 		err = dbPutBestState(dbTx, b.stateSnapshot, node.workSum)
 
 		if err != nil {
@@ -1220,7 +1235,7 @@ func (b *BlockChain) initChainState() error {
 			}
 			// Initialize the block node for the block, connect it, and add it to the block index.
 			node := &blockNodes[i]
-			initBlockNode(node, header, parent)
+			initBlockNode(node, header, parent, b.HardForks())
 			node.status = status
 			b.Index.addNode(node)
 			lastNode = node
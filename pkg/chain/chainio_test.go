@@ -7,6 +7,7 @@ import (
 	"reflect"
 	"testing"
 
+	"git.parallelcoin.io/dev/pod/pkg/chain/fork"
 	"git.parallelcoin.io/dev/pod/pkg/chain/wire"
 	database "git.parallelcoin.io/dev/pod/pkg/db"
 )
@@ -839,7 +840,7 @@ func TestBestChainStateSerialization(
 
 				workSum: func() *big.Int {
 
-					workSum.Add(workSum, CalcWork(486604799, 0, 2))
+					workSum.Add(workSum, CalcWork(fork.List, 486604799, 0, 2))
 					return new(big.Int).Set(workSum)
 				}(),
 				// 0x0100010001
@@ -860,7 +861,7 @@ func TestBestChainStateSerialization(
 
 				workSum: func() *big.Int {
 
-					workSum.Add(workSum, CalcWork(486604799, 1, 2))
+					workSum.Add(workSum, CalcWork(fork.List, 486604799, 1, 2))
 					return new(big.Int).Set(workSum)
 				}(),
 				// 0x0200020002
@@ -0,0 +1,405 @@
+package blockchain
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+
+	chainhash "git.parallelcoin.io/dev/pod/pkg/chain/hash"
+	cl "git.parallelcoin.io/dev/pod/pkg/util/cl"
+)
+
+// TipStatus describes the validation state of the chain that ends at a chain tip.
+
+type TipStatus byte
+
+// These constants are used to identify the state of a chain tip.
+const (
+
+	// TipActive is the status of the tip of the main chain.
+	TipActive TipStatus = iota
+
+	// TipValidFork is the status of the tip of a side chain whose blocks have all been fully validated.
+	TipValidFork
+
+	// TipValidHeaders is the status of the tip of a side chain whose block data is stored but has not been fully validated, because the side chain has never had more work than the main chain.
+	TipValidHeaders
+
+	// TipHeadersOnly is the status of the tip of a side chain for which the block data is not stored.
+	TipHeadersOnly
+
+	// TipInvalid is the status of the tip of a side chain that contains a block that failed validation or was invalidated with InvalidateBlock.
+	TipInvalid
+)
+
+// tipStatusStrings is a map of TipStatus values to the names used for them by the getchaintips RPC.
+
+var tipStatusStrings = map[TipStatus]string{
+
+	TipActive:       "active",
+	TipValidFork:    "valid-fork",
+	TipValidHeaders: "valid-headers",
+	TipHeadersOnly:  "headers-only",
+	TipInvalid:      "invalid",
+}
+
+// String returns the TipStatus as a human-readable name.
+func (s TipStatus) String() string {
+
+	if str := tipStatusStrings[s]; str != "" {
+
+		return str
+	}
+	return fmt.Sprintf("Unknown TipStatus (%d)", int(s))
+}
+
+// ChainTip describes the head of the main chain or of one of the side chains in the block index.
+
+type ChainTip struct {
+
+	// Height is the height of the tip.
+	Height int32
+
+	// Hash is the hash of the tip.
+	Hash chainhash.Hash
+
+	// BranchLen is the number of blocks between the tip and the point where its chain forks from the main chain, which is zero for the main chain.
+	BranchLen int32
+
+	// Status is the validation state of the chain that ends at the tip.
+	Status TipStatus
+}
+
+// ChainTips returns the heads of the main chain and of all known side chains, ordered by descending height. This function is safe for concurrent access.
+func (b *BlockChain) ChainTips() []ChainTip {
+
+	b.chainLock.RLock()
+	defer b.chainLock.RUnlock()
+
+	mainTip := b.bestChain.Tip()
+	nodes := b.Index.Tips()
+	tips := make([]ChainTip, 0, len(nodes)+1)
+
+	// The main chain tip has children when a side chain extends it without having more work, in which case it is not among the index tips.
+	haveMainTip := false
+
+	for _, node := range nodes {
+
+		if node == mainTip {
+
+			haveMainTip = true
+		}
+
+		tips = append(tips, b.chainTip(node))
+	}
+
+	if !haveMainTip {
+
+		tips = append(tips, b.chainTip(mainTip))
+	}
+
+	sort.SliceStable(tips, func(i, j int) bool {
+
+		return tips[i].Height > tips[j].Height
+	})
+
+	return tips
+}
+
+// chainTip returns the description of the chain that ends at the given node. This function MUST be called with the chain state lock held (for reads).
+func (b *BlockChain) chainTip(node *blockNode) ChainTip {
+
+	tip := ChainTip{
+
+		Height:    node.height,
+		Hash:      node.hash,
+		BranchLen: node.height - b.bestChain.FindFork(node).height,
+	}
+
+	status := b.Index.NodeStatus(node)
+
+	switch {
+
+	case node == b.bestChain.Tip():
+		tip.Status = TipActive
+	case status.KnownInvalid():
+		tip.Status = TipInvalid
+	case status.KnownValid():
+		tip.Status = TipValidFork
+	case status.HaveData():
+		tip.Status = TipValidHeaders
+	default:
+		tip.Status = TipHeadersOnly
+	}
+
+	return tip
+}
+
+// InvalidateBlock marks the block with the given hash as invalid and all of its descendants as having an invalid ancestor. If the block is part of the main chain, the chain is reorganized onto the best chain that remains valid. The status is stored in the block index so that it is kept across restarts until ReconsiderBlock is called for the block. This function is safe for concurrent access.
+func (b *BlockChain) InvalidateBlock(hash *chainhash.Hash) error {
+
+	b.chainLock.Lock()
+	defer b.chainLock.Unlock()
+
+	node := b.Index.LookupNode(hash)
+
+	if node == nil {
+
+		return fmt.Errorf("block %s is not known", hash)
+	}
+
+	if node.parent == nil {
+
+		return fmt.Errorf("the genesis block %s cannot be invalidated", hash)
+	}
+
+	b.Index.SetStatusFlags(node, statusValidateFailed)
+
+	for _, n := range b.descendants(node) {
+
+		b.Index.SetStatusFlags(n, statusInvalidAncestor)
+	}
+
+	log <- cl.Infof{
+
+		"INVALIDATE: block %v (height %d) marked as invalid", node.hash, node.height,
+	}
+
+	var err error
+
+	if b.bestChain.Contains(node) {
+
+		err = b.activateBestChain()
+	}
+
+	if flushErr := b.Index.flushToDB(); err == nil {
+
+		err = flushErr
+	}
+
+	return err
+}
+
+// ReconsiderBlock removes the invalid status from the block with the given hash, from the ancestors it inherited the status from and from all of its descendants, and then reorganizes the chain onto the best valid chain. Blocks that have not been fully validated before are validated again when they become part of the main chain, so a block that really is invalid is marked as such again. This function is safe for concurrent access.
+func (b *BlockChain) ReconsiderBlock(hash *chainhash.Hash) error {
+
+	b.chainLock.Lock()
+	defer b.chainLock.Unlock()
+
+	node := b.Index.LookupNode(hash)
+
+	if node == nil {
+
+		return fmt.Errorf("block %s is not known", hash)
+	}
+
+	invalid := statusValidateFailed | statusInvalidAncestor
+
+	for n := node; n != nil && b.Index.NodeStatus(n).KnownInvalid(); n = n.parent {
+
+		b.Index.UnsetStatusFlags(n, invalid)
+	}
+
+	for _, n := range b.descendants(node) {
+
+		if b.Index.NodeStatus(n).KnownInvalid() {
+
+			b.Index.UnsetStatusFlags(n, invalid)
+		}
+	}
+
+	log <- cl.Infof{
+
+		"RECONSIDER: invalid status of block %v (height %d) removed", node.hash, node.height,
+	}
+
+	err := b.activateBestChain()
+
+	if flushErr := b.Index.flushToDB(); err == nil {
+
+		err = flushErr
+	}
+
+	return err
+}
+
+// PreciousBlock treats the block with the given hash as if it had been received before any other block with the same amount of work, which reorganizes the chain onto it if it has at least as much work as the main chain tip. Blocks with less work than the main chain tip are not affected. Unlike the status set by InvalidateBlock, the preference is not stored. This function is safe for concurrent access.
+func (b *BlockChain) PreciousBlock(hash *chainhash.Hash) error {
+
+	b.chainLock.Lock()
+	defer b.chainLock.Unlock()
+
+	node := b.Index.LookupNode(hash)
+
+	if node == nil {
+
+		return fmt.Errorf("block %s is not known", hash)
+	}
+
+	if b.bestChain.Contains(node) {
+
+		return nil
+	}
+
+	status := b.Index.NodeStatus(node)
+
+	if status.KnownInvalid() {
+
+		return fmt.Errorf("block %s is known to be invalid", hash)
+	}
+
+	if !status.HaveData() {
+
+		return fmt.Errorf("the data of block %s is not available", hash)
+	}
+
+	if nodeWork(node).Cmp(nodeWork(b.bestChain.Tip())) < 0 {
+
+		return nil
+	}
+
+	detachNodes, attachNodes := b.getReorganizeNodes(node)
+
+	var err error
+
+	if attachNodes.Len() == 0 {
+
+		err = fmt.Errorf("block %s has an invalid ancestor", hash)
+
+	} else {
+
+		log <- cl.Infof{
+
+			"REORGANIZE: precious block %v is causing a reorganize", node.hash,
+		}
+
+		err = b.reorganizeChain(detachNodes, attachNodes)
+	}
+
+	if flushErr := b.Index.flushToDB(); err == nil {
+
+		err = flushErr
+	}
+
+	return err
+}
+
+// activateBestChain reorganizes the chain onto the valid chain with the most work. The current main chain is kept unless another chain has more work or the main chain tip is known to be invalid. Chains that turn out to be invalid while connecting them are marked as such and the next best chain is tried. This function may modify node statuses in the block index without flushing. This function MUST be called with the chain state lock held (for writes).
+func (b *BlockChain) activateBestChain() error {
+
+	for {
+
+		best := b.bestChainCandidate()
+
+		if best == nil || best == b.bestChain.Tip() {
+
+			return nil
+		}
+
+		detachNodes, attachNodes := b.getReorganizeNodes(best)
+
+		// An empty attach list for a side chain means getReorganizeNodes found an invalid ancestor and marked the candidate, so try the next best chain.
+
+		if attachNodes.Len() == 0 && !b.bestChain.Contains(best) {
+
+			continue
+		}
+
+		log <- cl.Infof{
+
+			"REORGANIZE: block %v is the best valid chain tip", best.hash,
+		}
+
+		err := b.reorganizeChain(detachNodes, attachNodes)
+
+		if err != nil {
+
+			// A rule violation marks the failing block and its descendants as invalid, so try the next best chain.
+
+			if _, ok := err.(RuleError); ok && b.Index.NodeStatus(best).KnownInvalid() {
+
+				log <- cl.Warn{"best chain candidate failed to connect:", err}
+
+				continue
+			}
+
+			return err
+		}
+	}
+}
+
+// bestChainCandidate returns the node with the most work among the heads of all chains in the block index, where the head of a chain that contains blocks that are known to be invalid or whose data is not stored is its newest ancestor that is not. The main chain tip is returned when it is valid and no other candidate has more work. This function MUST be called with the chain state lock held (for reads).
+func (b *BlockChain) bestChainCandidate() *blockNode {
+
+	var best *blockNode
+
+	if tip := b.bestChain.Tip(); !b.Index.NodeStatus(tip).KnownInvalid() {
+
+		best = tip
+	}
+
+	for _, n := range b.Index.Tips() {
+
+		for ; n != nil; n = n.parent {
+
+			status := b.Index.NodeStatus(n)
+
+			if status.HaveData() && !status.KnownInvalid() {
+
+				break
+			}
+		}
+
+		if n == nil {
+
+			continue
+		}
+
+		if best == nil || nodeWork(n).Cmp(nodeWork(best)) > 0 {
+
+			best = n
+		}
+	}
+
+	return best
+}
+
+// descendants returns all nodes in the block index that have the given node as an ancestor. This function MUST be called with the chain state lock held (for reads).
+func (b *BlockChain) descendants(node *blockNode) []*blockNode {
+
+	found := make(map[*blockNode]struct{})
+	var nodes []*blockNode
+
+	for _, tip := range b.Index.Tips() {
+
+		if tip.Ancestor(node.height) != node {
+
+			continue
+		}
+
+		// Branches share their nodes below the point where they split, so stop at the first node that has already been found.
+
+		for n := tip; n != node; n = n.parent {
+
+			if _, ok := found[n]; ok {
+
+				break
+			}
+
+			found[n] = struct{}{}
+			nodes = append(nodes, n)
+		}
+	}
+
+	return nodes
+}
+
+// nodeWork returns the work sum of a node, which is zero for the genesis block.
+func nodeWork(node *blockNode) *big.Int {
+
+	if node.workSum == nil {
+
+		return new(big.Int)
+	}
+	return node.workSum
+}
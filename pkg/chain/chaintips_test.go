@@ -0,0 +1,110 @@
+package blockchain
+
+import (
+	"math/big"
+	"testing"
+
+	chaincfg "git.parallelcoin.io/dev/pod/pkg/chain/config"
+)
+
+// TestChainTips ensures the chain tips and their statuses are reported as expected and that the best valid chain is selected once the main chain is invalid.
+func TestChainTips(
+	t *testing.T) {
+
+	// Construct a synthetic block chain with a block index consisting of the following structure.
+
+	// 	genesis -> 1 -> 2 -> ... -> 15 -> 16  -> 17  -> 18
+
+	// 	                              \-> 16a -> 17a
+
+	// 	                                    \-> 17b
+	tip := tstTip
+	chain := newFakeChain(&chaincfg.MainNetParams)
+	branch0Nodes := chainedNodes(chain.bestChain.Genesis(), 18)
+	branch1Nodes := chainedNodes(branch0Nodes[14], 2)
+	branch2Nodes := chainedNodes(branch1Nodes[0], 1)
+
+	for _, nodes := range [][]*blockNode{branch0Nodes, branch1Nodes, branch2Nodes} {
+
+		for _, node := range nodes {
+
+			node.status = statusDataStored
+			chain.Index.AddNode(node)
+		}
+	}
+
+	chain.bestChain.SetTip(tip(branch0Nodes))
+	branch1Nodes[1].status |= statusValid
+	branch2Nodes[0].status |= statusValidateFailed
+
+	tips := chain.ChainTips()
+
+	wantTips := []ChainTip{
+
+		{Height: 18, Hash: tip(branch0Nodes).hash, BranchLen: 0, Status: TipActive},
+		{Height: 17, Hash: tip(branch1Nodes).hash, BranchLen: 2, Status: TipValidFork},
+		{Height: 17, Hash: tip(branch2Nodes).hash, BranchLen: 2, Status: TipInvalid},
+	}
+
+	if len(tips) != len(wantTips) {
+
+		t.Fatalf("ChainTips: unexpected number of tips -- got %d, want %d", len(tips), len(wantTips))
+	}
+
+	for _, want := range wantTips {
+
+		found := false
+
+		for _, got := range tips {
+
+			if got == want {
+
+				found = true
+			}
+		}
+
+		if !found {
+
+			t.Errorf("ChainTips: missing tip %v at height %d with status %v", want.Hash, want.Height, want.Status)
+		}
+	}
+
+	if tips[0].Status != TipActive {
+
+		t.Errorf("ChainTips: tips are not ordered by height -- first tip has status %v", tips[0].Status)
+	}
+
+	// The descendants of block 16a are the tips of both side chains.
+	descendants := chain.descendants(branch1Nodes[0])
+
+	if len(descendants) != 2 {
+
+		t.Errorf("descendants: unexpected number of descendants -- got %d, want 2", len(descendants))
+	}
+
+	// While the main chain is valid it remains the best chain.
+
+	if best := chain.bestChainCandidate(); best != tip(branch0Nodes) {
+
+		t.Errorf("bestChainCandidate: got block at height %d, want main chain tip", best.height)
+	}
+
+	// Once block 17 is invalid, the valid side chain with the most work is the best chain.
+	branch0Nodes[16].status |= statusValidateFailed
+	branch0Nodes[17].status |= statusInvalidAncestor
+	branch0Nodes[15].workSum = big.NewInt(1)
+	branch1Nodes[1].workSum = big.NewInt(2)
+
+	if best := chain.bestChainCandidate(); best != tip(branch1Nodes) {
+
+		t.Errorf("bestChainCandidate: got block at height %d, want tip of valid side chain", best.height)
+	}
+
+	// Without the extra work on the side chain, the newest valid block of the main chain is the best chain.
+	branch1Nodes[1].workSum = big.NewInt(0)
+
+	if best := chain.bestChainCandidate(); best != branch0Nodes[15] {
+
+		t.Errorf("bestChainCandidate: got block at height %d, want newest valid main chain block", best.height)
+	}
+}
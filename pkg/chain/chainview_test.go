@@ -6,6 +6,7 @@ import (
 	"reflect"
 	"testing"
 
+	"git.parallelcoin.io/dev/pod/pkg/chain/fork"
 	"git.parallelcoin.io/dev/pod/pkg/chain/wire"
 )
 
@@ -30,7 +31,7 @@ func chainedNodes(
 			header.PrevBlock = tip.hash
 		}
 
-		nodes[i] = newBlockNode(&header, tip)
+		nodes[i] = newBlockNode(&header, tip, fork.List)
 		tip = nodes[i]
 	}
 
@@ -46,6 +46,30 @@ func (b *BlockChain) LatestCheckpoint() *chaincfg.Checkpoint {
 	return &b.checkpoints[len(b.checkpoints)-1]
 }
 
+// AssumeValid returns the block under which transaction scripts are not validated, or nil when every script is validated. This function is safe for concurrent access.
+func (b *BlockChain) AssumeValid() *chaincfg.Checkpoint {
+
+	return b.assumeValid
+}
+
+// isAssumeValidAncestor returns whether the passed node is the assumed valid block or one of its ancestors.  It returns false when there is no assumed valid block or it is not yet in the block index, or when its height does not match the configured one.
+func (b *BlockChain) isAssumeValidAncestor(node *blockNode) bool {
+
+	if b.assumeValid == nil || node.height > b.assumeValid.Height {
+
+		return false
+	}
+
+	avNode := b.Index.LookupNode(b.assumeValid.Hash)
+
+	if avNode == nil || avNode.height != b.assumeValid.Height {
+
+		return false
+	}
+
+	return avNode.Ancestor(node.height) == node
+}
+
 // verifyCheckpoint returns whether the passed block height and hash combination match the checkpoint data.  It also returns true if there is no checkpoint data for the passed block height.
 func (b *BlockChain) verifyCheckpoint(height int32, hash *chainhash.Hash) bool {
 
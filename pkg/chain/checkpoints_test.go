@@ -0,0 +1,87 @@
+package blockchain
+
+import (
+	"testing"
+
+	chaincfg "git.parallelcoin.io/dev/pod/pkg/chain/config"
+)
+
+// TestAssumeValidAncestor ensures only the assumed valid block and its ancestors skip script validation, and only once the assumed valid block is in the block index at the configured height.
+func TestAssumeValidAncestor(
+	t *testing.T) {
+
+	// Construct a synthetic block chain with a block index consisting of the following structure.
+
+	// 	genesis -> 1 -> 2 -> ... -> 9 -> 10
+
+	// 	                        \-> 6a -> 7a
+	chain := newFakeChain(&chaincfg.MainNetParams)
+	branch0Nodes := chainedNodes(chain.bestChain.Genesis(), 10)
+	branch1Nodes := chainedNodes(branch0Nodes[4], 2)
+
+	for _, nodes := range [][]*blockNode{branch0Nodes, branch1Nodes} {
+
+		for _, node := range nodes {
+
+			chain.Index.AddNode(node)
+		}
+	}
+
+	notIndexed := chainedNodes(branch0Nodes[9], 1)[0]
+
+	tests := []struct {
+		name        string
+		assumeValid *chaincfg.Checkpoint
+		node        *blockNode
+		want        bool
+	}{
+		{
+			name: "no assumed valid block",
+			node: branch0Nodes[0],
+		},
+		{
+			name:        "assumed valid block",
+			assumeValid: &chaincfg.Checkpoint{Height: 8, Hash: &branch0Nodes[7].hash},
+			node:        branch0Nodes[7],
+			want:        true,
+		},
+		{
+			name:        "ancestor",
+			assumeValid: &chaincfg.Checkpoint{Height: 8, Hash: &branch0Nodes[7].hash},
+			node:        branch0Nodes[2],
+			want:        true,
+		},
+		{
+			name:        "descendant",
+			assumeValid: &chaincfg.Checkpoint{Height: 8, Hash: &branch0Nodes[7].hash},
+			node:        branch0Nodes[8],
+		},
+		{
+			name:        "side chain",
+			assumeValid: &chaincfg.Checkpoint{Height: 8, Hash: &branch0Nodes[7].hash},
+			node:        branch1Nodes[0],
+		},
+		{
+			name:        "wrong height",
+			assumeValid: &chaincfg.Checkpoint{Height: 9, Hash: &branch0Nodes[7].hash},
+			node:        branch0Nodes[2],
+		},
+		{
+			name:        "not in index",
+			assumeValid: &chaincfg.Checkpoint{Height: 11, Hash: &notIndexed.hash},
+			node:        branch0Nodes[2],
+		},
+	}
+
+	for _, test := range tests {
+
+		chain.assumeValid = test.assumeValid
+		got := chain.isAssumeValidAncestor(test.node)
+
+		if got != test.want {
+
+			t.Errorf("%s: isAssumeValidAncestor got %v, want %v", test.name,
+				got, test.want)
+		}
+	}
+}
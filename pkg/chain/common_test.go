@@ -11,6 +11,7 @@ import (
 	"time"
 
 	chaincfg "git.parallelcoin.io/dev/pod/pkg/chain/config"
+	"git.parallelcoin.io/dev/pod/pkg/chain/fork"
 	chainhash "git.parallelcoin.io/dev/pod/pkg/chain/hash"
 	txscript "git.parallelcoin.io/dev/pod/pkg/chain/tx/script"
 	"git.parallelcoin.io/dev/pod/pkg/chain/wire"
@@ -410,7 +411,7 @@ func newFakeChain(
 	params *chaincfg.Params) *BlockChain {
 
 	// Create a genesis block node and block index index populated with it for use when creating the fake chain below.
-	node := newBlockNode(&params.GenesisBlock.Header, nil)
+	node := newBlockNode(&params.GenesisBlock.Header, nil, HardForkSchedule(params))
 	index := newBlockIndex(nil, params)
 	index.AddNode(node)
 	targetTimespan := int64(params.TargetTimespan)
@@ -445,5 +446,5 @@ func newFakeNode(
 		Bits:      bits,
 		Timestamp: timestamp,
 	}
-	return newBlockNode(header, parent)
+	return newBlockNode(header, parent, fork.List)
 }
@@ -6,6 +6,7 @@ import (
 	"math/big"
 	"time"
 
+	"git.parallelcoin.io/dev/pod/pkg/chain/fork"
 	chainhash "git.parallelcoin.io/dev/pod/pkg/chain/hash"
 	"git.parallelcoin.io/dev/pod/pkg/chain/wire"
 )
@@ -120,6 +121,14 @@ type Checkpoint struct {
 	Hash   *chainhash.Hash
 }
 
+// UtxoSnapshot identifies a known good unspent transaction output set, by the block it was taken at and the hash of its serialization, which a new node may be bootstrapped from instead of replaying the whole block chain.
+
+type UtxoSnapshot struct {
+	Height  int32
+	Hash    *chainhash.Hash
+	SetHash *chainhash.Hash
+}
+
 // DNSSeed identifies a DNS seed.
 
 type DNSSeed struct {
@@ -223,6 +232,12 @@ type Params struct {
 	// Checkpoints ordered from oldest to newest.
 	Checkpoints []Checkpoint
 
+	// AssumeValid is a block whose ancestors, and the block itself, are assumed to have valid scripts, so script validation is skipped for them when they are connected to the best chain.  It is nil when every script is validated.
+	AssumeValid *Checkpoint
+
+	// UtxoSnapshots are the unspent transaction output sets a new node may be bootstrapped from, ordered from oldest to newest.
+	UtxoSnapshots []UtxoSnapshot
+
 	// These fields are related to voting on consensus rule changes as defined by BIP0009.
 
 	//
@@ -269,4 +284,7 @@ type Params struct {
 	// PowLimit defines the highest allowed proof of work value for a scrypt block as a uint256.
 	ScryptPowLimit     *big.Int
 	ScryptPowLimitBits uint32
+
+	// HardForks is the schedule of hard forks of the network, which defines the set of algorithms, activation heights, target block time and averaging interval in effect at each height.
+	HardForks fork.Schedule
 }
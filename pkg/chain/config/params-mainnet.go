@@ -1,6 +1,9 @@
 package chaincfg
 
-import "git.parallelcoin.io/dev/pod/pkg/chain/wire"
+import (
+	"git.parallelcoin.io/dev/pod/pkg/chain/fork"
+	"git.parallelcoin.io/dev/pod/pkg/chain/wire"
+)
 
 // MainNetParams defines the network parameters for the main Bitcoin network.
 var MainNetParams = Params{
@@ -37,6 +40,16 @@ var MainNetParams = Params{
 		// {11111, newHashFromStr("0000000069e244f73d78e8fd29ba2fd2ed618bd6fa2ee92559f542fdb26e7c1d")},
 	},
 
+	// AssumeValid is the block under which script validation is skipped.  None
+	// has been chosen for this network yet, so every script is validated unless
+	// one is configured with the assumevalid option.
+	AssumeValid: nil,
+
+	// UtxoSnapshots are the utxo sets a new node may be bootstrapped from.  None
+	// has been taken for this network yet, so a snapshot must be trusted with
+	// the snapshothash option to be loaded.
+	UtxoSnapshots: nil,
+
 	// Consensus rule change deployments.
 
 	//
@@ -101,4 +114,7 @@ var MainNetParams = Params{
 	MaxActualTimespan:  3300,
 	ScryptPowLimit:     &scryptPowLimit,
 	ScryptPowLimitBits: ScryptPowLimitBits,
+
+	// Hard fork schedule
+	HardForks: fork.List,
 }
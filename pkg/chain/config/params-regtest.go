@@ -3,6 +3,7 @@ package chaincfg
 import (
 	"math"
 
+	"git.parallelcoin.io/dev/pod/pkg/chain/fork"
 	"git.parallelcoin.io/dev/pod/pkg/chain/wire"
 )
 
@@ -33,6 +34,12 @@ var RegressionNetParams = Params{
 	// Checkpoints ordered from oldest to newest.
 	Checkpoints: nil,
 
+	// AssumeValid is the block under which script validation is skipped.
+	AssumeValid: nil,
+
+	// UtxoSnapshots are the utxo sets a new node may be bootstrapped from.
+	UtxoSnapshots: nil,
+
 	// Consensus rule change deployments.
 
 	//
@@ -94,4 +101,7 @@ var RegressionNetParams = Params{
 	MaxActualTimespan:       AveragingTargetTimespan * (Interval + MaxAdjustDown) / Interval,
 	ScryptPowLimit:          &scryptPowLimit,
 	ScryptPowLimitBits:      ScryptPowLimitBits,
+
+	// Hard fork schedule
+	HardForks: fork.List,
 }
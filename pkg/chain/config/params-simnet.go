@@ -4,6 +4,7 @@ import (
 	"math"
 	"time"
 
+	"git.parallelcoin.io/dev/pod/pkg/chain/fork"
 	"git.parallelcoin.io/dev/pod/pkg/chain/wire"
 )
 
@@ -34,6 +35,12 @@ var SimNetParams = Params{
 	// Checkpoints ordered from oldest to newest.
 	Checkpoints: nil,
 
+	// AssumeValid is the block under which script validation is skipped.
+	AssumeValid: nil,
+
+	// UtxoSnapshots are the utxo sets a new node may be bootstrapped from.
+	UtxoSnapshots: nil,
+
 	// Consensus rule change deployments.
 
 	//
@@ -97,4 +104,7 @@ var SimNetParams = Params{
 	MaxActualTimespan:       10 * 300 * (100 + 10) / 100,
 	ScryptPowLimit:          &scryptPowLimit,
 	ScryptPowLimitBits:      ScryptPowLimitBits,
+
+	// Hard fork schedule
+	HardForks: fork.List,
 }
@@ -36,6 +36,16 @@ var TestNet3Params = Params{
 		// {546, newHashFromStr("000000002a936ca763904c3c35fce2f3556c559c0214345d31b1bcebf76acb70")},
 	},
 
+	// AssumeValid is the block under which script validation is skipped.  None
+	// has been chosen for this network yet, so every script is validated unless
+	// one is configured with the assumevalid option.
+	AssumeValid: nil,
+
+	// UtxoSnapshots are the utxo sets a new node may be bootstrapped from.  None
+	// has been taken for this network yet, so a snapshot must be trusted with
+	// the snapshothash option to be loaded.
+	UtxoSnapshots: nil,
+
 	// Consensus rule change deployments.
 
 	//
@@ -97,4 +107,7 @@ var TestNet3Params = Params{
 	MaxActualTimespan:       TestnetAveragingTargetTimespan * (TestnetInterval + TestnetMaxAdjustDown) / TestnetInterval,
 	ScryptPowLimit:          &scryptPowLimit,
 	ScryptPowLimitBits:      ScryptPowLimitBits,
+
+	// Hard fork schedule, which activates at the same heights as on mainnet
+	HardForks: fork.List,
 }
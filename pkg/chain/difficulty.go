@@ -93,8 +93,9 @@ func (
 ) {
 
 	nH := lastNode.height + 1
+	forks := b.HardForks()
 
-	switch fork.GetCurrent(nH) {
+	switch forks.GetCurrent(nH) {
 
 	// Legacy difficulty adjustment
 	case 0:
@@ -106,16 +107,16 @@ func (
 			return newTargetBits, nil
 		}
 
-		algo := fork.GetAlgoVer(algoname, nH)
-		algoName := fork.GetAlgoName(algo, nH)
-		newTargetBits = fork.GetMinBits(algoName, nH)
+		algo := forks.GetAlgoVer(algoname, nH)
+		algoName := forks.GetAlgoName(algo, nH)
+		newTargetBits = forks.GetMinBits(algoName, nH)
 
 		log <- cl.Debugc(func() string {
 			return fmt.Sprintf("last %d %d %8x",
 				lastNode.height, lastNode.version, lastNode.bits)
 		})
 
-		prevNode := lastNode.GetLastWithAlgo(algo)
+		prevNode := lastNode.GetLastWithAlgo(algo, forks)
 
 		if prevNode == nil {
 
@@ -125,7 +126,7 @@ func (
 		firstNode := prevNode
 
 		for i := int64(0); firstNode != nil &&
-			i < fork.GetAveragingInterval(nH)-1; i++ {
+			i < forks.GetAveragingInterval(nH)-1; i++ {
 
 			log <- cl.Debugc(func() string {
 				return fmt.Sprintf("%d: prev %d %d %8x",
@@ -133,7 +134,7 @@ func (
 			})
 
 			firstNode = firstNode.RelativeAncestor(1)
-			firstNode = firstNode.GetLastWithAlgo(algo)
+			firstNode = firstNode.GetLastWithAlgo(algo, forks)
 		}
 
 		if firstNode == nil {
@@ -204,8 +205,8 @@ func (
 		}
 
 		nH := lastNode.height + 1
-		algo := fork.GetAlgoVer(algoname, nH)
-		newTargetBits = fork.GetMinBits(algoname, nH)
+		algo := forks.GetAlgoVer(algoname, nH)
+		newTargetBits = forks.GetMinBits(algoname, nH)
 		last := lastNode
 
 		// find the most recent block of the same algo
@@ -213,7 +214,7 @@ func (
 		if last.version != algo {
 
 			ln := last.RelativeAncestor(1)
-			ln = ln.GetLastWithAlgo(algo)
+			ln = ln.GetLastWithAlgo(algo, forks)
 
 			// ignore the first block as its time is not a normal timestamp
 
@@ -232,7 +233,7 @@ func (
 
 		// collect the timestamps of all the blocks of the same algo until we pass genesis block or get AveragingInterval blocks
 
-		for ; counter < int(fork.GetAveragingInterval(nH)) && pb.height > 2; counter++ {
+		for ; counter < int(forks.GetAveragingInterval(nH)) && pb.height > 2; counter++ {
 
 			p := pb.RelativeAncestor(1)
 
@@ -243,7 +244,7 @@ func (
 					return fork.SecondPowLimitBits, nil
 				}
 
-				pb = p.GetLastWithAlgo(algo)
+				pb = p.GetLastWithAlgo(algo, forks)
 
 			} else {
 
@@ -262,8 +263,8 @@ func (
 
 		}
 
-		allTimeAverage, trailTimeAverage := float64(fork.GetTargetTimePerBlock(nH)), float64(fork.GetTargetTimePerBlock(nH))
-		startHeight := fork.List[1].ActivationHeight
+		allTimeAverage, trailTimeAverage := float64(forks.GetTargetTimePerBlock(nH)), float64(forks.GetTargetTimePerBlock(nH))
+		startHeight := forks[1].ActivationHeight
 
 		if b.chainParams.Name == "testnet" {
 
@@ -272,7 +273,7 @@ func (
 
 		trailHeight := int32(int64(lastNode.height) -
 
-			fork.GetAveragingInterval(nH)*int64(len(fork.List[1].Algos)))
+			forks.GetAveragingInterval(nH)*int64(len(forks[1].Algos)))
 
 		if trailHeight < 0 {
 
@@ -304,8 +305,8 @@ func (
 
 		if len(timestamps) > 1 {
 
-			numalgos := int64(len(fork.List[1].Algos))
-			target := fork.GetTargetTimePerBlock(nH) * numalgos
+			numalgos := int64(len(forks[1].Algos))
+			target := forks.GetTargetTimePerBlock(nH) * numalgos
 			counter = 0
 
 			for i := 0; i < len(timestamps)-1; i++ {
@@ -357,7 +358,7 @@ func (
 			trailingTimestamps, float64(pb.timestamp))
 		counter = 1
 
-		for ; counter < int(fork.GetAveragingInterval(nH)) &&
+		for ; counter < int(forks.GetAveragingInterval(nH)) &&
 
 			pb.height > 2; counter++ {
 
@@ -373,7 +374,7 @@ func (
 
 		if len(trailingTimestamps) > 1 {
 
-			target := fork.GetTargetTimePerBlock(nH)
+			target := forks.GetTargetTimePerBlock(nH)
 			counter = 0
 
 			for i := 0; i < len(trailingTimestamps)-1; i++ {
@@ -418,7 +419,7 @@ func (
 			trailingAdjusted = 100
 		}
 
-		ttpb := float64(fork.GetTargetTimePerBlock(nH))
+		ttpb := float64(forks.GetTargetTimePerBlock(nH))
 		allTimeDivergence := allTimeAverage / ttpb
 		trailTimeDivergence := trailTimeAverage / ttpb
 		trailingTimeDivergence := trailingAdjusted / trailingTargetAdjusted
@@ -484,7 +485,7 @@ func (
 					counter,
 					(1 - adjustment) * 100,
 
-					fork.List[1].AlgoVers[algo],
+					forks[1].AlgoVers[algo],
 				}
 
 			}
@@ -497,7 +498,7 @@ func (
 	// nH := lastNode.height + 1
 
 	// algo := fork.GetAlgoVer(algoname, nH)
-	return fork.GetMinBits(algoname, nH), nil
+	return forks.GetMinBits(algoname, nH), nil
 }
 
 // BigToCompact converts a whole number N to a compact representation using an unsigned 32-bit number.  The compact representation only provides 23 bits of precision, so values larger than (2^23 - 1) only encode the most significant digits of the number.  See CompactToBig for details.
@@ -545,17 +546,24 @@ func BigToCompact(n *big.Int) uint32 {
 	return compact
 }
 
-// CalcWork calculates a work value from difficulty bits.  Bitcoin increases the difficulty for generating a block by decreasing the value which the generated hash must be less than.  This difficulty target is stored in each block header using a compact representation as described in the documentation for CompactToBig. The main chain is selected by choosing the chain that has the most proof of work (highest difficulty). Since a lower target difficulty value equates to higher actual difficulty, the work value which will be accumulated must be the inverse of the difficulty.  Also, in order to avoid potential division by zero and really small floating point numbers, the result adds 1 to the denominator and multiplies the numerator by 2^256.
-func CalcWork(bits uint32, height int32, algover int32) *big.Int {
+// CalcWork calculates a work value from difficulty bits.  Bitcoin increases the difficulty for generating a block by decreasing the value which the generated hash must be less than.  This difficulty target is stored in each block header using a compact representation as described in the documentation for CompactToBig. The main chain is selected by choosing the chain that has the most proof of work (highest difficulty). Since a lower target difficulty value equates to higher actual difficulty, the work value which will be accumulated must be the inverse of the difficulty.  Also, in order to avoid potential division by zero and really small floating point numbers, the result adds 1 to the denominator and multiplies the numerator by 2^256. The hashes per second of the algorithm are taken from the passed hard fork schedule.
+func CalcWork(forks fork.Schedule, bits uint32, height int32, algover int32) *big.Int {
+
+	return calcWork(forks, bits, height, algover)
+}
+
+// calcWork calculates a work value from difficulty bits using the algorithm parameters of the given hard fork schedule. See CalcWork for details.
+func calcWork(
+	forks fork.Schedule, bits uint32, height int32, algover int32) *big.Int {
 
 	// Return a work value of zero if the passed difficulty bits represent a negative number. Note this should not happen in practice with valid blocks, but an invalid block could trigger it.
 	difficultyNum := CompactToBig(bits)
 
 	// To make the difficulty values correlate to number of hash operations, multiply this difficulty base by the nanoseconds/hash figures in the fork algorithms list
-	current := fork.GetCurrent(height)
-	algoname := fork.List[current].AlgoVers[algover]
-	difficultyNum = new(big.Int).Mul(difficultyNum, big.NewInt(fork.List[current].Algos[algoname].NSperOp))
-	difficultyNum = new(big.Int).Quo(difficultyNum, big.NewInt(fork.List[current].WorkBase))
+	hf := forks[forks.GetCurrent(height)]
+	algoname := hf.AlgoVers[algover]
+	difficultyNum = new(big.Int).Mul(difficultyNum, big.NewInt(hf.Algos[algoname].NSperOp))
+	difficultyNum = new(big.Int).Quo(difficultyNum, big.NewInt(hf.WorkBase))
 
 	if difficultyNum.Sign() <= 0 {
 
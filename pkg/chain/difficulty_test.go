@@ -3,6 +3,8 @@ package blockchain
 import (
 	"math/big"
 	"testing"
+
+	"git.parallelcoin.io/dev/pod/pkg/chain/fork"
 )
 
 // TestBigToCompact ensures BigToCompact converts big integers to the expected compact representation.
@@ -80,7 +82,7 @@ func TestCalcWork(
 	for x, test := range tests {
 
 		bits := uint32(test.in)
-		r := CalcWork(bits, 0, 2)
+		r := CalcWork(fork.List, bits, 0, 2)
 
 		if r.Int64() != test.out {
 
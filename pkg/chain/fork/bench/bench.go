@@ -1,26 +1,175 @@
-package main
+// Package bench measures the cost of the proof of work hash functions of each hard fork on the local machine. The resulting profile replaces the NSperOp values of the hard fork schedule, which were measured once on a single machine, for the algorithm scheduler of the miner.
+package bench
 
 import (
-	"fmt"
+	"runtime"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"git.parallelcoin.io/dev/pod/pkg/chain/fork"
 )
 
-func main() {
+// DefaultDuration is how long each algorithm is hashed when no duration is given
+const DefaultDuration = 2 * time.Second
 
-	h := fork.Hash([]byte{}, "blake14lr", fork.List[1].ActivationHeight)
+// Result is the measured cost of one algorithm in one hard fork
 
-	for i := range fork.P9Algos {
+type Result struct {
 
-		fmt.Print(`"`, i, `": {, FirstPowLimitBits, , `)
-		now := time.Now().UnixNano()
-		var samples int64 = 50
+	// Fork is the number of the hard fork the hash variant belongs to
+	Fork int `json:"fork"`
 
-		for j := int64(0); j < samples; j++ {
+	// Height is the block height the algorithm was hashed at
+	Height int32 `json:"height"`
 
-			h = fork.Hash(h.CloneBytes(), i, fork.List[1].ActivationHeight+1)
+	// Algo is the name of the algorithm
+	Algo string `json:"algo"`
+
+	// Version is the block version that selects the algorithm
+	Version int32 `json:"version"`
+
+	// Hashes is the number of hashes computed by all threads together
+	Hashes int64 `json:"hashes"`
+
+	// NSPerOp is the time one thread takes to compute one hash in nanoseconds
+	NSPerOp int64 `json:"nsperop"`
+
+	// HashesPerSec is the number of hashes one thread computes per second
+	HashesPerSec float64 `json:"hashespersec"`
+
+	// AllocPerOp is the number of bytes allocated by one hash
+	AllocPerOp uint64 `json:"allocperop"`
+
+	// PeakHeap is the largest heap size in bytes seen while the algorithm was hashed
+	PeakHeap uint64 `json:"peakheap"`
+}
+
+// Run hashes each algorithm of each hard fork of schedule for the given duration on the given number of threads, and returns the profile of the machine. Each hard fork is hashed just after its activation height so that its own hash variant is used. A thread count below 1 uses all cores, a duration below 1 uses DefaultDuration. If progress is not nil it is called with each result as it is completed.
+func Run(
+	schedule fork.Schedule, threads int, duration time.Duration,
+	progress func(Result)) *Profile {
+
+	if threads < 1 {
+
+		threads = runtime.NumCPU()
+	}
+
+	if duration < 1 {
+
+		duration = DefaultDuration
+	}
+	p := &Profile{
+		Time:     time.Now().Unix(),
+		OS:       runtime.GOOS,
+		Arch:     runtime.GOARCH,
+		CPUs:     runtime.NumCPU(),
+		Threads:  threads,
+		Duration: duration.Nanoseconds(),
+	}
+
+	for i := range schedule {
+
+		hf := &schedule[i]
+		height := hf.ActivationHeight + 1
+
+		for _, version := range hf.Versions() {
+
+			r := measure(schedule, hf.AlgoVers[version], height, threads, duration)
+			r.Fork = i
+			r.Version = version
+			p.Results = append(p.Results, r)
+
+			if progress != nil {
+
+				progress(r)
+			}
 		}
-		fmt.Println((time.Now().UnixNano()-now)/samples, "},")
 	}
+	return p
+}
+
+// measure hashes one algorithm at a height on threads goroutines until duration has passed. Every thread computes at least one hash, as some algorithms take longer than a short duration.
+func measure(
+	schedule fork.Schedule, algo string, height int32, threads int,
+	duration time.Duration) Result {
+
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	peak := before.HeapAlloc
+	var hashes int64
+	var stop int32
+	var wg sync.WaitGroup
+	start := time.Now()
+
+	for t := 0; t < threads; t++ {
+
+		wg.Add(1)
+		go func(t int) {
+
+			defer wg.Done()
+			// Each thread hashes its own chain of hashes, starting from its thread number, so the input is never constant.
+			h := schedule.Hash([]byte{byte(t)}, algo, height)
+			atomic.AddInt64(&hashes, 1)
+
+			for atomic.LoadInt32(&stop) == 0 {
+
+				h = schedule.Hash(h.CloneBytes(), algo, height)
+				atomic.AddInt64(&hashes, 1)
+			}
+		}(t)
+	}
+	done := make(chan struct{})
+	go func() {
+
+		wg.Wait()
+		close(done)
+	}()
+	interval := duration / 20
+
+	if interval < time.Millisecond {
+
+		interval = time.Millisecond
+	}
+	ticker := time.NewTicker(interval)
+	timer := time.NewTimer(duration)
+
+out:
+	for {
+
+		select {
+
+		case <-ticker.C:
+			var m runtime.MemStats
+			runtime.ReadMemStats(&m)
+
+			if m.HeapAlloc > peak {
+
+				peak = m.HeapAlloc
+			}
+		case <-timer.C:
+			atomic.StoreInt32(&stop, 1)
+		case <-done:
+			break out
+		}
+	}
+	ticker.Stop()
+	timer.Stop()
+	elapsed := time.Since(start)
+	runtime.ReadMemStats(&after)
+	r := Result{
+		Height:   height,
+		Algo:     algo,
+		Hashes:   hashes,
+		PeakHeap: peak,
+	}
+	r.NSPerOp = elapsed.Nanoseconds() * int64(threads) / hashes
+	r.AllocPerOp = (after.TotalAlloc - before.TotalAlloc) / uint64(hashes)
+
+	if r.NSPerOp > 0 {
+
+		r.HashesPerSec = 1e9 / float64(r.NSPerOp)
+	}
+	return r
 }
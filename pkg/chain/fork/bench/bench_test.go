@@ -0,0 +1,119 @@
+package bench
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"git.parallelcoin.io/dev/pod/pkg/chain/fork"
+)
+
+// TestRun ensures a benchmark of the first hard fork measures each of its algorithms and that the profile survives being saved and loaded.
+func TestRun(
+	t *testing.T) {
+
+	schedule := fork.List[:1]
+	var progress []string
+	p := Run(schedule, 2, 10*time.Millisecond, func(r Result) {
+
+		progress = append(progress, r.Algo)
+	})
+
+	if p.Threads != 2 || len(p.Results) != len(schedule[0].AlgoVers) {
+
+		t.Fatalf("got %d threads and %d results, want 2 and %d",
+			p.Threads, len(p.Results), len(schedule[0].AlgoVers))
+	}
+
+	for i, r := range p.Results {
+
+		if r.Algo != schedule[0].AlgoVers[r.Version] || r.Fork != 0 || r.Height != 1 {
+
+			t.Errorf("result %+v does not match the hard fork", r)
+		}
+
+		// Every thread computes at least one hash, however short the duration.
+		if r.Hashes < 2 || r.NSPerOp < 1 || r.HashesPerSec <= 0 {
+
+			t.Errorf("%s: got %d hashes at %d ns/op", r.Algo, r.Hashes, r.NSPerOp)
+		}
+
+		if progress[i] != r.Algo {
+
+			t.Errorf("progress reported %s for result %d, want %s", progress[i], i, r.Algo)
+		}
+	}
+	dir, err := ioutil.TempDir("", "bench")
+
+	if err != nil {
+
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "profile.json")
+
+	if err = p.Save(path); err != nil {
+
+		t.Fatal(err)
+	}
+	loaded, err := Load(path)
+
+	if err != nil {
+
+		t.Fatal(err)
+	}
+	costs := loaded.Costs()
+
+	if len(costs) != 1 || len(costs[0]) != len(p.Results) {
+
+		t.Fatalf("got costs %v, want one hard fork with %d algorithms", costs, len(p.Results))
+	}
+
+	for _, r := range p.Results {
+
+		if costs[0][r.Algo] != r.NSPerOp {
+
+			t.Errorf("%s: got cost %d, want %d", r.Algo, costs[0][r.Algo], r.NSPerOp)
+		}
+	}
+}
+
+// TestLoadInvalid ensures profiles without usable costs are refused.
+func TestLoadInvalid(
+	t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "bench")
+
+	if err != nil {
+
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	tests := []string{
+		`not json`,
+		`{"results": [{"fork": 0, "algo": "sha256d", "nsperop": 0}]}`,
+		`{"results": [{"fork": -1, "algo": "sha256d", "nsperop": 100}]}`,
+	}
+
+	for i, test := range tests {
+
+		path := filepath.Join(dir, "profile.json")
+
+		if err := ioutil.WriteFile(path, []byte(test), 0600); err != nil {
+
+			t.Fatal(err)
+		}
+
+		if _, err := Load(path); err == nil {
+
+			t.Errorf("test %d: loaded invalid profile %s", i, test)
+		}
+	}
+
+	if _, err := Load(filepath.Join(dir, "missing.json")); err == nil {
+
+		t.Error("loaded missing profile")
+	}
+}
@@ -0,0 +1,139 @@
+package bench
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"text/tabwriter"
+	"time"
+)
+
+// Profile is the result of a benchmark run on one machine
+
+type Profile struct {
+
+	// Time is when the benchmark was run as seconds since the epoch
+	Time int64 `json:"time"`
+
+	// OS and Arch are the operating system and architecture of the machine
+	OS   string `json:"os"`
+	Arch string `json:"arch"`
+
+	// CPUs is the number of logical cores of the machine
+	CPUs int `json:"cpus"`
+
+	// Threads is the number of threads that hashed at the same time
+	Threads int `json:"threads"`
+
+	// Duration is how long each algorithm was hashed in nanoseconds
+	Duration int64 `json:"duration"`
+
+	// Results are the measurements of each algorithm of each hard fork
+	Results []Result `json:"results"`
+}
+
+// Costs returns the nanoseconds per hash of each algorithm, indexed by hard fork number and then by algorithm name, in the form taken by the SetHashCosts method of the algorithm scheduler
+func (p *Profile) Costs() []map[string]int64 {
+
+	var costs []map[string]int64
+
+	for _, r := range p.Results {
+
+		for len(costs) <= r.Fork {
+
+			costs = append(costs, make(map[string]int64))
+		}
+		costs[r.Fork][r.Algo] = r.NSPerOp
+	}
+	return costs
+}
+
+// Load reads a profile written by Save
+func Load(
+	path string) (*Profile, error) {
+
+	b, err := ioutil.ReadFile(path)
+
+	if err != nil {
+
+		return nil, err
+	}
+	p := new(Profile)
+
+	if err = json.Unmarshal(b, p); err != nil {
+
+		return nil, fmt.Errorf("invalid benchmark profile %s: %v", path, err)
+	}
+
+	for _, r := range p.Results {
+
+		if r.Fork < 0 || r.NSPerOp < 1 {
+
+			return nil, fmt.Errorf("invalid benchmark profile %s: bad result for %s in fork %d",
+				path, r.Algo, r.Fork)
+		}
+	}
+	return p, nil
+}
+
+// Save writes the profile to a file as JSON
+func (p *Profile) Save(
+	path string) error {
+
+	b, err := json.MarshalIndent(p, "", "  ")
+
+	if err != nil {
+
+		return err
+	}
+	return ioutil.WriteFile(path, append(b, '\n'), 0600)
+}
+
+// WriteJSON writes the profile to w as indented JSON
+func (p *Profile) WriteJSON(
+	w io.Writer) error {
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(p)
+}
+
+// WriteTable writes the profile to w as a table with one row per algorithm and hard fork
+func (p *Profile) WriteTable(
+	w io.Writer) error {
+
+	fmt.Fprintf(w, "%s/%s, %d threads on %d cores, %v per algorithm\n\n",
+		p.OS, p.Arch, p.Threads, p.CPUs, time.Duration(p.Duration))
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "fork\theight\talgo\tversion\tns/op\thashes/s/thread\talloc/op\tpeak heap\t")
+
+	for _, r := range p.Results {
+
+		fmt.Fprintf(tw, "%d\t%d\t%s\t%d\t%d\t%.2f\t%s\t%s\t\n",
+			r.Fork, r.Height, r.Algo, r.Version, r.NSPerOp, r.HashesPerSec,
+			formatBytes(r.AllocPerOp), formatBytes(r.PeakHeap))
+	}
+	return tw.Flush()
+}
+
+// formatBytes returns a byte count in the largest binary unit that keeps it above 1
+func formatBytes(
+	n uint64) string {
+
+	units := []string{"B", "KiB", "MiB", "GiB"}
+	f := float64(n)
+	i := 0
+
+	for f >= 1024 && i < len(units)-1 {
+
+		f /= 1024
+		i++
+	}
+
+	if i == 0 {
+
+		return fmt.Sprintf("%d B", n)
+	}
+	return fmt.Sprintf("%.1f %s", f, units[i])
+}
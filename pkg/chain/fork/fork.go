@@ -3,7 +3,6 @@
 package fork
 
 import (
-	"crypto/rand"
 	"encoding/hex"
 	"math/big"
 	"time"
@@ -54,45 +53,26 @@ var FirstPowLimit = func() big.Int {
 // FirstPowLimitBits is
 var FirstPowLimitBits = BigToCompact(&FirstPowLimit)
 
-// IsTestnet is set at startup here to be accessible to all other libraries
-var IsTestnet bool
-
-// List is the list of existing hard forks and when they activate
-var List = []HardForks{
+// List is the list of existing hard forks and when they activate on the main network, and the base that the schedules of the other networks are derived from
+var List = Schedule{
 	{
-		Number:           0,
-		Name:             "Halcyon days",
-		ActivationHeight: 0,
-		Algos:            Algos,
-		AlgoVers:         AlgoVers,
-		WorkBase: func() (out int64) {
-
-			for i := range Algos {
-
-				out += Algos[i].NSperOp
-			}
-			out /= int64(len(Algos))
-			return
-		}(),
+		Number:             0,
+		Name:               "Halcyon days",
+		ActivationHeight:   0,
+		Algos:              Algos,
+		AlgoVers:           AlgoVers,
+		WorkBase:           workBase(Algos),
 		TargetTimePerBlock: 3 * time.Minute,
 		AveragingInterval:  10, // 50 minutes
 		TestnetStart:       0,
 	},
 	{
-		Number:           1,
-		Name:             "Plan 9 from Crypto Space",
-		ActivationHeight: 250000,
-		Algos:            P9Algos,
-		AlgoVers:         P9AlgoVers,
-		WorkBase: func() (out int64) {
-
-			for i := range P9Algos {
-
-				out += P9Algos[i].NSperOp
-			}
-			out /= int64(len(P9Algos))
-			return
-		}(),
+		Number:             1,
+		Name:               "Plan 9 from Crypto Space",
+		ActivationHeight:   250000,
+		Algos:              P9Algos,
+		AlgoVers:           P9AlgoVers,
+		WorkBase:           workBase(P9Algos),
 		TargetTimePerBlock: 9 * time.Second,
 		AveragingInterval:  9600, // 24 hours
 		TestnetStart:       100,
@@ -142,145 +122,3 @@ var mainPowLimit = func() big.Int {
 }()
 
 var mainPowLimitBits = BigToCompact(&mainPowLimit)
-
-// GetAlgoID returns the 'algo_id' which in pre-hardfork is not the same as the block version number, but is afterwards
-func GetAlgoID(
-	algoname string,
-	height int32,
-) uint32 {
-
-	if GetCurrent(height) > 1 {
-
-		return P9Algos[algoname].AlgoID
-	}
-	return Algos[algoname].AlgoID
-}
-
-// GetAlgoName returns the string identifier of an algorithm depending on hard fork activation status
-func GetAlgoName(
-	algoVer int32,
-	height int32,
-) (
-	name string,
-) {
-
-	hf := GetCurrent(height)
-	name = List[hf].AlgoVers[algoVer]
-	return
-}
-
-// GetAlgoVer returns the version number for a given algorithm (by string name) at a given height. If "random" is given, a random number is taken from the system secure random source (for randomised cpu mining)
-func GetAlgoVer(
-	name string,
-	height int32,
-) (
-	version int32,
-) {
-
-	n := "sha256d"
-	hf := GetCurrent(height)
-
-	if name == "random" {
-
-		rn, _ := rand.Int(rand.Reader,
-			big.NewInt(int64(len(P9AlgoVers)-1)))
-		randomalgover := int32(rn.Uint64())
-
-		switch hf {
-
-		case 0:
-
-			switch randomalgover & 1 {
-
-			case 0:
-				version = 2
-			case 1:
-				version = 514
-			}
-			return
-		case 1:
-			rndalgo := List[1].AlgoVers[randomalgover]
-			algo := List[1].Algos[rndalgo].Version
-			return algo
-		}
-	} else {
-
-		n = name
-	}
-	version = List[hf].Algos[n].Version
-	return
-}
-
-// GetAveragingInterval returns the active block interval target based on hard fork status
-func GetAveragingInterval(
-	height int32,
-) (
-	r int64,
-) {
-
-	r = int64(List[GetCurrent(height)].AveragingInterval)
-	return
-}
-
-// GetCurrent returns the hardfork number code
-func GetCurrent(
-	height int32,
-) (
-	curr int,
-) {
-
-	if IsTestnet {
-
-		for i := range List {
-
-			if height > List[i].TestnetStart {
-
-				curr = i
-			}
-		}
-	}
-
-	for i := range List {
-
-		if height > List[i].ActivationHeight {
-
-			curr = i
-		}
-	}
-	return
-}
-
-// GetMinBits returns the minimum diff bits based on height and testnet
-func GetMinBits(
-	algoname string,
-	height int32,
-) (
-	mb uint32,
-) {
-
-	curr := GetCurrent(height)
-	mb = List[curr].Algos[algoname].MinBits
-	return
-}
-
-// GetMinDiff returns the minimum difficulty in uint256 form
-func GetMinDiff(
-	algoname string,
-	height int32,
-) (
-	md *big.Int,
-) {
-
-	return CompactToBig(GetMinBits(algoname, height))
-}
-
-// GetTargetTimePerBlock returns the active block interval target based on hard fork status
-func GetTargetTimePerBlock(
-	height int32,
-) (
-	r int64,
-) {
-
-	r = int64(List[GetCurrent(height)].TargetTimePerBlock)
-	return
-}
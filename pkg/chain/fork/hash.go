@@ -60,11 +60,9 @@ func Cryptonight7v2(
 	return cryptonight.Sum(bytes, 2)
 }
 
-// Hash computes the hash of bytes using the named hash
-func Hash(
-	bytes []byte, name string, height int32) (out chainhash.Hash) {
-
-	// time.Sleep(time.Millisecond * 2000)
+// hashForFork computes the hash of bytes using the named hash with the variant used by the given hard fork number
+func hashForFork(
+	bytes []byte, name string, hf int) (out chainhash.Hash) {
 
 	switch name {
 
@@ -82,7 +80,7 @@ func Hash(
 		out.SetBytes(rightShift(Lyra2REv2(b)))
 	case "scrypt":
 
-		if GetCurrent(height) > 0 {
+		if hf > 0 {
 
 			b := Argon2i(Cryptonight7v2(Scrypt(bytes)))
 			out.SetBytes(rightShift(Scrypt(b)))
@@ -92,7 +90,7 @@ func Hash(
 		}
 	case "sha256d": // sha256d
 
-		if GetCurrent(height) > 0 {
+		if hf > 0 {
 
 			b := Argon2i(Cryptonight7v2(chainhash.DoubleHashB(bytes)))
 			out.SetBytes(rightShift(chainhash.DoubleHashB(b)))
@@ -0,0 +1,152 @@
+package fork
+
+import (
+	"crypto/rand"
+	"math/big"
+	"sort"
+
+	chainhash "git.parallelcoin.io/dev/pod/pkg/chain/hash"
+)
+
+// Schedule is the ordered list of hard forks of a network. Each network carries its own schedule in its chain parameters so that networks with different fork heights and algorithm sets can be used side by side in one process.
+
+type Schedule []HardForks
+
+// GetCurrent returns the index of the hard fork that is active at a given height
+func (s Schedule) GetCurrent(
+	height int32,
+) (
+	curr int,
+) {
+
+	for i := range s {
+
+		if height > s[i].ActivationHeight {
+
+			curr = i
+		}
+	}
+	return
+}
+
+// GetAlgoID returns the 'algo_id' which in pre-hardfork is not the same as the block version number, but is afterwards
+func (s Schedule) GetAlgoID(
+	algoname string,
+	height int32,
+) uint32 {
+
+	return s[s.GetCurrent(height)].Algos[algoname].AlgoID
+}
+
+// GetAlgoName returns the string identifier of an algorithm depending on hard fork activation status
+func (s Schedule) GetAlgoName(
+	algoVer int32,
+	height int32,
+) (
+	name string,
+) {
+
+	name = s[s.GetCurrent(height)].AlgoVers[algoVer]
+	return
+}
+
+// GetAlgoVer returns the version number for a given algorithm (by string name) at a given height. If "random" is given, a random algorithm of those active at the height is taken from the system secure random source (for randomised cpu mining)
+func (s Schedule) GetAlgoVer(
+	name string,
+	height int32,
+) (
+	version int32,
+) {
+
+	hf := s[s.GetCurrent(height)]
+
+	if name == "random" {
+
+		versions := hf.Versions()
+		rn, _ := rand.Int(rand.Reader, big.NewInt(int64(len(versions))))
+		return versions[rn.Int64()]
+	}
+
+	version = hf.Algos[name].Version
+	return
+}
+
+// GetAveragingInterval returns the active block interval target based on hard fork status
+func (s Schedule) GetAveragingInterval(
+	height int32,
+) (
+	r int64,
+) {
+
+	r = s[s.GetCurrent(height)].AveragingInterval
+	return
+}
+
+// GetMinBits returns the minimum diff bits based on height
+func (s Schedule) GetMinBits(
+	algoname string,
+	height int32,
+) (
+	mb uint32,
+) {
+
+	mb = s[s.GetCurrent(height)].Algos[algoname].MinBits
+	return
+}
+
+// GetMinDiff returns the minimum difficulty in uint256 form
+func (s Schedule) GetMinDiff(
+	algoname string,
+	height int32,
+) (
+	md *big.Int,
+) {
+
+	return CompactToBig(s.GetMinBits(algoname, height))
+}
+
+// GetTargetTimePerBlock returns the active block interval target based on hard fork status
+func (s Schedule) GetTargetTimePerBlock(
+	height int32,
+) (
+	r int64,
+) {
+
+	r = int64(s[s.GetCurrent(height)].TargetTimePerBlock)
+	return
+}
+
+// Hash computes the hash of bytes using the named hash with the variant that is in use at the given height
+func (s Schedule) Hash(
+	bytes []byte, name string, height int32) (out chainhash.Hash) {
+
+	return hashForFork(bytes, name, s.GetCurrent(height))
+}
+
+// Versions returns the block versions of the algorithms of the hard fork in ascending order
+func (hf *HardForks) Versions() (versions []int32) {
+
+	versions = make([]int32, 0, len(hf.AlgoVers))
+
+	for v := range hf.AlgoVers {
+
+		versions = append(versions, v)
+	}
+	sort.Slice(versions, func(i, j int) bool {
+
+		return versions[i] < versions[j]
+	})
+	return
+}
+
+// workBase returns the average of the nanoseconds per hash of a set of algorithms, which is used to scale the work of each algorithm to a comparable value
+func workBase(
+	algos map[string]AlgoParams) (out int64) {
+
+	for i := range algos {
+
+		out += algos[i].NSperOp
+	}
+	out /= int64(len(algos))
+	return
+}
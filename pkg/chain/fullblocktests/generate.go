@@ -12,6 +12,7 @@ import (
 
 	blockchain "git.parallelcoin.io/dev/pod/pkg/chain"
 	chaincfg "git.parallelcoin.io/dev/pod/pkg/chain/config"
+	"git.parallelcoin.io/dev/pod/pkg/chain/fork"
 	chainhash "git.parallelcoin.io/dev/pod/pkg/chain/hash"
 	txscript "git.parallelcoin.io/dev/pod/pkg/chain/tx/script"
 	"git.parallelcoin.io/dev/pod/pkg/chain/wire"
@@ -309,10 +310,10 @@ func calcMerkleRoot(
 	return *merkles[len(merkles)-1]
 }
 
-// solveBlock attempts to find a nonce which makes the passed block header hash to a value less than the target difficulty.  When a successful solution is found true is returned and the nonce field of the passed header is updated with the solution.  False is returned if no solution exists.
+// solveBlock attempts to find a nonce which makes the passed block header hash, with the algorithm the hard fork schedule assigns to it at the height, to a value less than the target difficulty.  When a successful solution is found true is returned and the nonce field of the passed header is updated with the solution.  False is returned if no solution exists.
 // NOTE: This function will never solve blocks with a nonce of 0.  This is done so the 'nextBlock' function can properly detect when a nonce was modified by a munge function.
 func solveBlock(
-	header *wire.BlockHeader, height int32) bool {
+	header *wire.BlockHeader, height int32, forks fork.Schedule) bool {
 
 	// sbResult is used by the solver goroutines to send results.
 
@@ -336,7 +337,7 @@ func solveBlock(
 				return
 			default:
 				hdr.Nonce = i
-				hash := hdr.BlockHashWithAlgos(height)
+				hash := hdr.BlockHashWithSchedule(forks, height)
 
 				if blockchain.HashToBig(&hash).Cmp(
 
@@ -530,7 +531,8 @@ func (g *testGenerator) nextBlock(blockName string, spend *spendableOut, mungers
 	}
 	// Only solve the block if the nonce wasn't manually changed by a munge function.
 
-	if block.Header.Nonce == curNonce && !solveBlock(&block.Header, nextHeight) {
+	if block.Header.Nonce == curNonce && !solveBlock(&block.Header, nextHeight,
+		blockchain.HardForkSchedule(g.params)) {
 
 		panic(fmt.Sprintf("Unable to solve block at height %d",
 			nextHeight))
@@ -0,0 +1,30 @@
+package blockchain
+
+import (
+	"time"
+
+	"git.parallelcoin.io/dev/pod/pkg/util/metrics"
+)
+
+// blockValidationSeconds is the time taken by ProcessBlock by the outcome of processing the block.
+var blockValidationSeconds = metrics.NewHistogram("pod_block_validation_seconds",
+	"Time taken to validate and process a block, by whether it was connected to the main chain, added to a side chain, held as an orphan or rejected",
+	[]float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30}, "result")
+
+// observeBlockValidation adds the time taken to process a block to the block validation metrics under the outcome given by the return values of ProcessBlock.
+func observeBlockValidation(
+	elapsed time.Duration, isMainChain, isOrphan bool, err error) {
+
+	result := "side"
+
+	switch {
+
+	case err != nil:
+		result = "rejected"
+	case isOrphan:
+		result = "orphan"
+	case isMainChain:
+		result = "main"
+	}
+	blockValidationSeconds.Observe(elapsed.Seconds(), result)
+}
@@ -0,0 +1,226 @@
+package mining
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+
+	blockchain "git.parallelcoin.io/dev/pod/pkg/chain"
+	"git.parallelcoin.io/dev/pod/pkg/chain/fork"
+	cl "git.parallelcoin.io/dev/pod/pkg/util/cl"
+)
+
+// AutoAlgo is the algorithm name that makes the block template generator use the algorithm chosen by its AlgoScheduler
+const AutoAlgo = "auto"
+
+// AlgoScore is the evaluation of one algorithm by the AlgoScheduler
+
+type AlgoScore struct {
+
+	// Algo is the name of the algorithm
+	Algo string
+
+	// Version is the block version that selects the algorithm
+	Version int32
+
+	// Bits is the difficulty required of the next block of the algorithm in compact form
+	Bits uint32
+
+	// NSPerOp is the cost of one hash of the algorithm in nanoseconds
+	NSPerOp int64
+
+	// SecsPerBlock is the expected time for one thread to find a block with the algorithm
+	SecsPerBlock float64
+}
+
+// AlgoChoice is a decision of the AlgoScheduler
+
+type AlgoChoice struct {
+
+	// Height is the height of the block the decision is for
+	Height int32
+
+	// Algo is the name of the chosen algorithm
+	Algo string
+
+	// Version is the block version of the chosen algorithm
+	Version int32
+
+	// Scores are the evaluations of all algorithms active at the height, best first
+	Scores []AlgoScore
+}
+
+// AlgoScheduler chooses the algorithm for new block templates. The algorithms differ only in the work required to find a block, so the scheduler picks the one where the expected number of hashes at its current difficulty, multiplied by the cost of one hash, is lowest. The decision is re-evaluated and logged for every block connected to the main chain once the chain is current.
+
+type AlgoScheduler struct {
+	sync.Mutex
+	chain      *blockchain.BlockChain
+	timeSource blockchain.MedianTimeSource
+	costs      []map[string]int64
+	choice     *AlgoChoice
+	stale      bool
+}
+
+// NewAlgoScheduler returns a scheduler for the given chain and subscribes it to the notifications of the chain. The cost of a hash of each algorithm is taken from the NSperOp of the hard fork schedule until it is replaced with SetHashCosts.
+func NewAlgoScheduler(
+	chain *blockchain.BlockChain, timeSource blockchain.MedianTimeSource) *AlgoScheduler {
+
+	s := &AlgoScheduler{
+		chain:      chain,
+		timeSource: timeSource,
+	}
+	chain.Subscribe(s.handleNotification)
+	return s
+}
+
+// SetHashCosts replaces the cost of a hash of the named algorithms with locally measured values in nanoseconds, indexed by hard fork number and then by algorithm name, as produced by a benchmark profile, and re-evaluates the choice. Algorithms that are not in costs keep the cost given by the hard fork schedule.
+func (s *AlgoScheduler) SetHashCosts(costs []map[string]int64) {
+
+	s.Lock()
+	s.costs = make([]map[string]int64, len(costs))
+
+	for i := range costs {
+
+		s.costs[i] = make(map[string]int64, len(costs[i]))
+
+		for algo, ns := range costs[i] {
+
+			s.costs[i][algo] = ns
+		}
+	}
+	s.choice = nil
+	s.Unlock()
+	s.Choice()
+}
+
+// Choice returns the algorithm to use for the next block. The last choice is reused as long as it is for the height of the next block and no block has been connected since it was made.
+func (s *AlgoScheduler) Choice() AlgoChoice {
+
+	s.Lock()
+	defer s.Unlock()
+
+	if s.choice == nil || s.stale || s.choice.Height != s.chain.BestSnapshot().Height+1 {
+
+		s.evaluate()
+	}
+	return *s.choice
+}
+
+// handleNotification re-evaluates the choice, which logs the decision, when a block is connected to the main chain. While the chain is syncing the choice is only marked stale and re-evaluated by the next call to Choice.
+func (s *AlgoScheduler) handleNotification(n *blockchain.Notification) {
+
+	if n.Type != blockchain.NTBlockConnected {
+
+		return
+	}
+	s.Lock()
+	defer s.Unlock()
+
+	if !s.chain.IsCurrent() {
+
+		s.stale = true
+		return
+	}
+	s.evaluate()
+}
+
+// evaluate scores the algorithms for the block after the current best block and records the best one as the choice. It must be called with the scheduler locked.
+func (s *AlgoScheduler) evaluate() {
+
+	best := s.chain.BestSnapshot()
+	height := best.Height + 1
+	forks := s.chain.HardForks()
+	current := forks.GetCurrent(height)
+	hf := forks[current]
+	timestamp := medianAdjustedTime(best, s.timeSource)
+	bits := make(map[int32]uint32, len(hf.AlgoVers))
+
+	for version, algo := range hf.AlgoVers {
+
+		b, err := s.chain.CalcNextRequiredDifficulty(timestamp, algo)
+
+		if err != nil {
+
+			log <- cl.Warn{"algo scheduler failed to get difficulty of", algo, err}
+
+			b = hf.Algos[algo].MinBits
+		}
+		bits[version] = b
+	}
+	var costs map[string]int64
+
+	if current < len(s.costs) {
+
+		costs = s.costs[current]
+	}
+	scores := scoreAlgos(&hf, bits, costs)
+	previous := s.choice
+	s.stale = false
+	s.choice = &AlgoChoice{
+		Height:  height,
+		Algo:    scores[0].Algo,
+		Version: scores[0].Version,
+		Scores:  scores,
+	}
+
+	if previous == nil || previous.Algo != s.choice.Algo {
+
+		log <- cl.Infof{
+			"algo scheduler chose %s for height %d, %.0f seconds per block per thread",
+			s.choice.Algo, height, scores[0].SecsPerBlock,
+		}
+	} else {
+
+		Log.Dbgc(func() string {
+
+			return fmt.Sprintf("algo scheduler kept %s for height %d, %.0f seconds per block per thread",
+				s.choice.Algo, height, scores[0].SecsPerBlock)
+		})
+	}
+}
+
+// scoreAlgos returns the expected time to find a block with each algorithm of a hard fork, given the required difficulty of each algorithm by block version, best first. The cost of a hash is taken from costs when it is there and from the hard fork otherwise. Algorithms with equal scores are ordered by block version.
+func scoreAlgos(
+	hf *fork.HardForks, bits map[int32]uint32, costs map[string]int64) []AlgoScore {
+
+	scores := make([]AlgoScore, 0, len(hf.AlgoVers))
+
+	for _, version := range hf.Versions() {
+
+		algo := hf.AlgoVers[version]
+		ns, ok := costs[algo]
+
+		if !ok {
+
+			ns = hf.Algos[algo].NSperOp
+		}
+		hashes, _ := new(big.Float).SetInt(expectedHashes(bits[version])).Float64()
+		scores = append(scores, AlgoScore{
+			Algo:         algo,
+			Version:      version,
+			Bits:         bits[version],
+			NSPerOp:      ns,
+			SecsPerBlock: hashes * float64(ns) / 1e9,
+		})
+	}
+	sort.SliceStable(scores, func(i, j int) bool {
+
+		return scores[i].SecsPerBlock < scores[j].SecsPerBlock
+	})
+	return scores
+}
+
+// expectedHashes returns the expected number of hashes needed to find a hash that meets the target of the given compact difficulty, which is 2^256 / (target + 1)
+func expectedHashes(
+	bits uint32) *big.Int {
+
+	target := blockchain.CompactToBig(bits)
+
+	if target.Sign() <= 0 {
+
+		return new(big.Int).Lsh(big.NewInt(1), 256)
+	}
+	target.Add(target, big.NewInt(1))
+	return target.Div(new(big.Int).Lsh(big.NewInt(1), 256), target)
+}
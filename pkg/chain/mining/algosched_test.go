@@ -0,0 +1,205 @@
+package mining
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	blockchain "git.parallelcoin.io/dev/pod/pkg/chain"
+	chaincfg "git.parallelcoin.io/dev/pod/pkg/chain/config"
+	"git.parallelcoin.io/dev/pod/pkg/chain/fork"
+	chainhash "git.parallelcoin.io/dev/pod/pkg/chain/hash"
+	database "git.parallelcoin.io/dev/pod/pkg/db"
+	_ "git.parallelcoin.io/dev/pod/pkg/db/ffldb"
+	"git.parallelcoin.io/dev/pod/pkg/util"
+)
+
+// emptyTxSource is a TxSource without transactions, so the templates only hold a coinbase.
+
+type emptyTxSource struct{}
+
+func (emptyTxSource) LastUpdated() time.Time               { return time.Time{} }
+func (emptyTxSource) MiningDescs() []*TxDesc               { return nil }
+func (emptyTxSource) HaveTransaction(*chainhash.Hash) bool { return false }
+
+// TestExpectedHashes ensures the expected number of hashes to find a block is computed correctly for known targets.
+func TestExpectedHashes(
+	t *testing.T) {
+
+	tests := []struct {
+		bits uint32
+		want string
+	}{
+		// Half of all hashes meet the easiest regtest target.
+		{0x207fffff, "2"},
+		// The difficulty 1 target of bitcoin.
+		{0x1d00ffff, "4295032833"},
+		// A zero target can not be met, which is counted as the whole hash space.
+		{0, "115792089237316195423570985008687907853269984665640564039457584007913129639936"},
+	}
+
+	for _, test := range tests {
+
+		got := expectedHashes(test.bits).String()
+
+		if got != test.want {
+
+			t.Errorf("expectedHashes(%08x): got %s, want %s", test.bits, got, test.want)
+		}
+	}
+}
+
+// TestScoreAlgos ensures the algorithms are ordered by the expected time to find a block.
+func TestScoreAlgos(
+	t *testing.T) {
+
+	// The first hard fork has sha256d at version 2 and scrypt at version 514.
+	hf := fork.List[0]
+	tests := []struct {
+		name  string
+		bits  map[int32]uint32
+		costs map[string]int64
+		want  []string
+	}{
+		{
+			name: "equal difficulty",
+			bits: map[int32]uint32{2: 0x1e0fffff, 514: 0x1e0fffff},
+			want: []string{"sha256d", "scrypt"},
+		},
+		{
+			name: "scrypt much easier",
+			bits: map[int32]uint32{2: 0x1c0fffff, 514: 0x1e0fffff},
+			want: []string{"scrypt", "sha256d"},
+		},
+		{
+			name:  "measured costs",
+			bits:  map[int32]uint32{2: 0x1e0fffff, 514: 0x1e0fffff},
+			costs: map[string]int64{"sha256d": 1000, "scrypt": 10},
+			want:  []string{"scrypt", "sha256d"},
+		},
+		{
+			name:  "equal scores",
+			bits:  map[int32]uint32{2: 0x1e0fffff, 514: 0x1e0fffff},
+			costs: map[string]int64{"sha256d": 10, "scrypt": 10},
+			want:  []string{"sha256d", "scrypt"},
+		},
+	}
+
+	for _, test := range tests {
+
+		scores := scoreAlgos(&hf, test.bits, test.costs)
+
+		if len(scores) != len(test.want) {
+
+			t.Errorf("%s: got %d scores, want %d", test.name, len(scores), len(test.want))
+			continue
+		}
+
+		for i, algo := range test.want {
+
+			if scores[i].Algo != algo {
+
+				t.Errorf("%s: got %s at position %d, want %s", test.name, scores[i].Algo, i, algo)
+			}
+
+			if scores[i].Version != hf.Algos[algo].Version || scores[i].Bits != test.bits[scores[i].Version] {
+
+				t.Errorf("%s: score %+v does not match the hard fork", test.name, scores[i])
+			}
+		}
+
+		if test.costs == nil && scores[0].NSPerOp != hf.Algos[scores[0].Algo].NSperOp {
+
+			t.Errorf("%s: got cost %d, want the cost of the hard fork", test.name, scores[0].NSPerOp)
+		}
+	}
+}
+
+// TestAlgoSchedulerBlockConnected ensures the scheduler makes its decision for the next block as soon as a block is connected to a current chain, without waiting for a template to be requested.
+func TestAlgoSchedulerBlockConnected(
+	t *testing.T) {
+
+	params := &chaincfg.RegressionNetParams
+	dbPath, err := ioutil.TempDir("", "algosched")
+
+	if err != nil {
+
+		t.Fatalf("unable to create temporary directory: %v", err)
+	}
+
+	defer os.RemoveAll(dbPath)
+	db, err := database.Create("ffldb", dbPath, params.Net)
+
+	if err != nil {
+
+		t.Fatalf("unable to create database: %v", err)
+	}
+
+	defer db.Close()
+	timeSource := blockchain.NewMedianTime()
+	chain, err := blockchain.New(&blockchain.Config{
+
+		DB:          db,
+		ChainParams: params,
+		TimeSource:  timeSource,
+	})
+
+	if err != nil {
+
+		t.Fatalf("unable to create chain: %v", err)
+	}
+
+	addr, err := util.NewAddressPubKeyHash(make([]byte, 20), params)
+
+	if err != nil {
+
+		t.Fatal(err)
+	}
+
+	policy := Policy{BlockMaxWeight: 4000000, BlockMaxSize: 1000000}
+	g := NewBlkTmplGenerator(&policy, params, emptyTxSource{}, chain,
+		timeSource, nil, nil, "")
+	template, err := g.NewBlockTemplate(addr, AutoAlgo)
+
+	if err != nil {
+
+		t.Fatalf("NewBlockTemplate: unexpected error: %v", err)
+	}
+
+	// Solve the template, which is quick at the regression test difficulty.
+	header := &template.Block.Header
+	target := blockchain.CompactToBig(header.Bits)
+
+	for {
+
+		hash := header.BlockHashWithSchedule(chain.HardForks(), template.Height)
+
+		if blockchain.HashToBig(&hash).Cmp(target) <= 0 {
+
+			break
+		}
+		header.Nonce++
+	}
+
+	block := util.NewBlock(template.Block)
+	block.SetHeight(template.Height)
+	_, isOrphan, err := chain.ProcessBlock(block, blockchain.BFNone,
+		template.Height)
+
+	if err != nil || isOrphan {
+
+		t.Fatalf("ProcessBlock: got orphan %v and error %v", isOrphan, err)
+	}
+
+	s := g.AlgoScheduler()
+	s.Lock()
+	choice := s.choice
+	s.Unlock()
+
+	if choice == nil || choice.Height != template.Height+1 {
+
+		t.Fatalf("scheduler made no choice for height %d after the block "+
+			"was connected, got %+v", template.Height+1, choice)
+	}
+}
@@ -12,7 +12,6 @@ import (
 
 	blockchain "git.parallelcoin.io/dev/pod/pkg/chain"
 	chaincfg "git.parallelcoin.io/dev/pod/pkg/chain/config"
-	"git.parallelcoin.io/dev/pod/pkg/chain/fork"
 	chainhash "git.parallelcoin.io/dev/pod/pkg/chain/hash"
 	"git.parallelcoin.io/dev/pod/pkg/chain/mining"
 	"git.parallelcoin.io/dev/pod/pkg/chain/wire"
@@ -360,10 +359,8 @@ out:
 		rand.Seed(time.Now().UnixNano())
 		payToAddr := m.cfg.MiningAddrs[rand.Intn(len(m.cfg.MiningAddrs))]
 
-		// Create a new block template using the available transactions in the memory pool as a source of transactions to potentially include in the block.
-		algo := fork.GetAlgoVer(m.cfg.Algo, m.b.BestSnapshot().Height)
-		algoname := fork.GetAlgoName(algo, m.b.BestSnapshot().Height)
-		template, err := m.g.NewBlockTemplate(payToAddr, algoname)
+		// Create a new block template using the available transactions in the memory pool as a source of transactions to potentially include in the block.  The template generator resolves the "auto" and "random" algorithm names.
+		template, err := m.g.NewBlockTemplate(payToAddr, m.cfg.Algo)
 
 		m.submitBlockLock.Unlock()
 
@@ -478,7 +475,8 @@ func (
 ) solveBlock(
 	msgBlock *wire.MsgBlock, blockHeight int32, testnet bool, ticker *time.Ticker, quit chan struct{}) bool {
 
-	algoName := fork.GetAlgoName(
+	forks := m.b.HardForks()
+	algoName := forks.GetAlgoName(
 		msgBlock.Header.Version, m.b.BestSnapshot().Height)
 
 	// Choose a random extra nonce offset for this block template and worker.
@@ -559,7 +557,7 @@ func (
 			incr = 1
 
 			header.Nonce = i
-			hash := header.BlockHashWithAlgos(blockHeight)
+			hash := header.BlockHashWithSchedule(forks, blockHeight)
 			hashesCompleted += incr
 
 			// The block is solved when the new block hash is less than the target difficulty.  Yay!
@@ -692,12 +690,12 @@ func (
 			"%s new block height %d %s %10d %08x %v %s %ds since prev",
 			time.Now().Format("2006-01-02 15:04:05.000000"),
 			block.Height(),
-			block.MsgBlock().BlockHashWithAlgos(block.Height()),
+			block.MsgBlock().BlockHashWithSchedule(m.b.HardForks(), block.Height()),
 			block.MsgBlock().Header.Timestamp.Unix(),
 			block.MsgBlock().Header.Bits,
 			util.Amount(coinbaseTx.Value),
 
-			fork.GetAlgoName(block.MsgBlock().Header.Version, block.Height()),
+			m.b.HardForks().GetAlgoName(block.MsgBlock().Header.Version, block.Height()),
 
 			since,
 		)
@@ -709,10 +707,10 @@ func (
 		return fmt.Sprintf(
 			"Block submitted via CPU miner accepted (algo %s, hash %s, amount %v)",
 
-			fork.GetAlgoName(block.MsgBlock().Header.Version,
+			m.b.HardForks().GetAlgoName(block.MsgBlock().Header.Version,
 
 				block.Height()),
-			block.MsgBlock().BlockHashWithAlgos(block.Height()),
+			block.MsgBlock().BlockHashWithSchedule(m.b.HardForks(), block.Height()),
 			util.Amount(coinbaseTx.Value),
 		)
 	})
@@ -2,148 +2,124 @@ package main
 
 import (
 	"fmt"
-	"net"
+	"os"
+	"os/signal"
 	"time"
 
-	"github.com/obsilp/rmnp"
+	blockchain "git.parallelcoin.io/dev/pod/pkg/chain"
+	chaincfg "git.parallelcoin.io/dev/pod/pkg/chain/config"
+	"git.parallelcoin.io/dev/pod/pkg/chain/fork"
+	controller "git.parallelcoin.io/dev/pod/pkg/chain/mining/dispatch"
+	flags "github.com/jessevdk/go-flags"
 )
 
-var (
-	serverAddr = "127.0.0.1:11011"
-	myListener = "127.0.0.1:11012"
-	server     = rmnp.NewServer(myListener)
-	client     *rmnp.Client
-	connected  = false
-	subscribed = false
-	heartbeat  = time.Second * 3 / 2
+const (
+
+	// retryDelay is how long the worker waits before reconnecting after it lost the controller, doubling up to maxRetryDelay while the controller stays unreachable
+	retryDelay    = time.Second
+	maxRetryDelay = time.Minute
 )
 
+type config struct {
+	Controller string `short:"c" long:"controller" description:"Address of the minerlistener of the controller to mine for"`
+	MinerPass  string `short:"p" long:"minerpass" description:"The minerpass of the controller, if it has one"`
+	Name       string `short:"n" long:"name" description:"Name to report shares under"`
+	Network    string `long:"network" description:"Network the controller is on: mainnet, testnet, regtest or simnet"`
+	Threads    int    `short:"t" long:"threads" description:"Number of threads to mine with"`
+}
+
 func main() {
 
-	server.ClientConnect = clientConnect
-	server.ClientDisconnect = clientDisconnect
-	server.ClientTimeout = clientTimeout
-	server.ClientValidation = validateClient
-	server.PacketHandler = handleServerPacket
-	server.Start()
-	defer server.Stop()
+	hostname, _ := os.Hostname()
+	cfg := config{
 
-	for {
+		Controller: "127.0.0.1:11011",
+		Name:       hostname,
+		Network:    "mainnet",
+		Threads:    1,
+	}
+
+	parser := flags.NewParser(&cfg, flags.Default)
+	_, err := parser.Parse()
 
-		if !connected {
+	if err != nil {
 
-			subscribed = false
-			connected = true
-			client = rmnp.NewClient(serverAddr)
-			client.ServerConnect = serverConnect
-			client.ServerDisconnect = serverDisconnect
-			client.ServerTimeout = serverTimeout
-			client.PacketHandler = handleClientPacket
-			client.ConnectWithData([]byte("kopach"))
-		} else {
+		if e, ok := err.(*flags.Error); !ok || e.Type != flags.ErrHelp {
 
-			time.Sleep(heartbeat)
+			parser.WriteHelp(os.Stderr)
 		}
+
+		return
 	}
-}
 
-// Client callbacks
-func serverConnect(
-	conn *rmnp.Connection, data []byte) {
+	params, ok := map[string]*chaincfg.Params{
 
-	// fmt.Println("serverConnect")
-	if !subscribed && connected {
+		"mainnet": &chaincfg.MainNetParams,
+		"testnet": &chaincfg.TestNet3Params,
+		"regtest": &chaincfg.RegressionNetParams,
+		"simnet":  &chaincfg.SimNetParams,
+	}[cfg.Network]
 
-		fmt.Println("subscribe", serverAddr)
-		conn.SendReliableOrdered([]byte("subscribe " + myListener))
-		time.Sleep(heartbeat)
+	if !ok {
+
+		fmt.Fprintf(os.Stderr, "unknown network %q\n", cfg.Network)
+		os.Exit(1)
 	}
 
-	for conn.Addr != nil && connected {
+	workerCfg := &controller.WorkerConfig{
 
-		fmt.Println("ping", conn.Addr)
-		conn.SendReliableOrdered([]byte("ping " + myListener))
-		time.Sleep(heartbeat)
+		Controller: cfg.Controller,
+		Name:       cfg.Name,
+		Schedule:   blockchain.HardForkSchedule(params),
+		Threads:    cfg.Threads,
 	}
-}
-func serverDisconnect(
-	conn *rmnp.Connection, data []byte) {
 
-	// fmt.Println("server disconnect")
-	subscribed = false
-	connected = false
-	conn.Disconnect([]byte("disconn"))
-}
-func serverTimeout(
-	conn *rmnp.Connection, data []byte) {
+	if cfg.MinerPass != "" {
 
-	// fmt.Println("server timeout")
-	subscribed = false
-	connected = false
-	conn.Disconnect([]byte("timeout"))
-}
-func handleClientPacket(
-	conn *rmnp.Connection, data []byte, channel rmnp.Channel) {
+		workerCfg.Key = fork.Argon2i([]byte(cfg.MinerPass))
+	}
 
-	fmt.Println("->" + string(data))
-	if string(data)[:10] == "subscribed" {
+	quit := make(chan struct{})
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
 
-		subscribed = true
-	}
-}
+	go func() {
 
-// Client callbacks
-func clientConnect(
-	conn *rmnp.Connection, data []byte) {
+		<-interrupt
+		close(quit)
+	}()
 
-	// fmt.Println("clientConnection")
-	if string(data) != "nachalnik" {
+	delay := retryDelay
 
-		conn.Disconnect([]byte("wrong handshake"))
-	}
-	subscribed = true
-	connected = true
-}
-func clientDisconnect(
-	conn *rmnp.Connection, data []byte) {
+	for {
 
-	// fmt.Println("client disconnect")
-	subscribed = false
-	connected = false
-}
-func clientTimeout(
-	conn *rmnp.Connection, data []byte) {
+		start := time.Now()
+		err := controller.NewWorker(workerCfg).Run(quit)
 
-	// fmt.Println("client timeout")
-	subscribed = false
-	connected = false
-}
-func validateClient(
-	addr *net.UDPAddr, data []byte) (valid bool) {
+		if err == nil {
 
-	// fmt.Println("validateClient")
-	valid = string(data) == "nachalnik"
-	if !valid {
+			return
+		}
 
-		fmt.Println("Wrong handshake from", addr.IP, addr.Port, addr.Network(), addr.Zone)
-	}
-	return
-}
-func handleServerPacket(
-	conn *rmnp.Connection, data []byte, channel rmnp.Channel) {
-
-	// fmt.Println("handleServerPacket", string(data))
-	str := string(data)
-
-	switch {
-
-	case !connected:
-		conn.Disconnect([]byte("already connected"))
-	case str[:10] == "subscribed":
-		subscribed = true
-	case str == "already subscribed" && connected:
-		subscribed = true
-	case str[:4] == "ping" && subscribed && connected:
-		conn.SendReliableOrdered([]byte("pong " + str[5:]))
+		// A worker that mined for a while lost a controller that was up, so it reconnects quickly again.
+		if time.Since(start) > maxRetryDelay {
+
+			delay = retryDelay
+		}
+
+		fmt.Fprintf(os.Stderr, "lost controller %s: %v, retrying in %v\n",
+			cfg.Controller, err, delay)
+
+		select {
+
+		case <-quit:
+			return
+		case <-time.After(delay):
+		}
+
+		if delay *= 2; delay > maxRetryDelay {
+
+			delay = maxRetryDelay
+		}
 	}
 }
@@ -3,6 +3,8 @@ package controller
 import (
 	"fmt"
 	"math/rand"
+	"net"
+	"net/rpc"
 	"sync"
 	"time"
 
@@ -10,7 +12,6 @@ import (
 
 	blockchain "git.parallelcoin.io/dev/pod/pkg/chain"
 	chaincfg "git.parallelcoin.io/dev/pod/pkg/chain/config"
-	"git.parallelcoin.io/dev/pod/pkg/chain/fork"
 	"git.parallelcoin.io/dev/pod/pkg/chain/mining"
 	"git.parallelcoin.io/dev/pod/pkg/chain/wire"
 	"git.parallelcoin.io/dev/pod/pkg/util"
@@ -48,9 +49,12 @@ type Config struct {
 	// MinerListener is the listener that will accept miner subscriptions and such
 	MinerListener string
 
-	// MinerKey is generated from the password specified in the main configuration for miner port using Stribog hash to derive the nonce, Argon2i to expand the password, and a final pass of Keccak
+	// MinerKey is generated from the password specified in the main configuration for miner port using Stribog hash to derive the nonce, Argon2i to expand the password, and a final pass of Keccak. When set, the KCP transport is encrypted with it and workers must sign a login challenge with it. Without it the controller only listens on loopback addresses
 	MinerKey []byte
 
+	// ShareFactor is how many times easier the share target of each algorithm is than its network target. DefaultShareFactor is used when it is zero
+	ShareFactor int64
+
 	// ConnectedCount defines the function to use to obtain how many other peers the server is connected to.  This is used by the automatic persistent mining routine to determine whether or it should attempt mining.  This is useful because there is no point in mining when not connected to any peers since there would no be anyone to send any found blocks to.
 	ConnectedCount func() int32
 
@@ -72,7 +76,7 @@ type Controller struct {
 	updateNumWorkers chan struct{}
 	quit             chan struct{}
 	listener         *kcp.Listener
-	workers          []*kcp.UDPSession
+	pool             *pool
 }
 
 // submitBlock submits the passed block to network after ensuring it passes all of the consensus validation rules.
@@ -132,12 +136,12 @@ func (c *Controller) submitBlock(block *util.Block) bool {
 		return fmt.Sprintf(
 			"new block height %d %s %10d %08x %v %s %ds since prev",
 			block.Height(),
-			block.MsgBlock().BlockHashWithAlgos(block.Height()),
+			block.MsgBlock().BlockHashWithSchedule(c.b.HardForks(), block.Height()),
 			block.MsgBlock().Header.Timestamp.Unix(),
 			block.MsgBlock().Header.Bits,
 			util.Amount(coinbaseTx.Value),
 
-			fork.GetAlgoName(block.MsgBlock().Header.Version,
+			c.b.HardForks().GetAlgoName(block.MsgBlock().Header.Version,
 
 				block.Height()),
 			since,
@@ -146,84 +150,134 @@ func (c *Controller) submitBlock(block *util.Block) bool {
 	return true
 }
 
-// solveBlock attempts to find some combination of a nonce, extra nonce, and current timestamp which makes the passed block hash to a value less than the target difficulty.  The timestamp is updated periodically and the passed block is modified with all tweaks during this process.  This means that when the function returns true, the block is ready for submission. This function will return early with false when conditions that trigger a stale block such as a new block showing up or periodically when there are new transactions and enough time has elapsed without finding a solution.
-func (c *Controller) solveBlock(msgBlock *wire.MsgBlock, blockHeight int32, testnet bool, ticker *time.Ticker, submissionReceived chan *wire.MsgBlock, quit chan struct{}) bool {
+// isStale returns whether work on the passed block header is stale, which is the case when the best block has changed or when the memory pool has been updated since the block template was generated and it has been at least one minute
+func (c *Controller) isStale(header *wire.BlockHeader, lastGenerated time.Time, lastTxUpdate time.Time) bool {
 
-	// Choose a random extra nonce offset for this block template and worker.
-	enOffset, err := wire.RandomUint64()
-	if err != nil {
+	best := c.g.BestSnapshot()
 
-		log <- cl.Error{"unexpected error while generating random extra nonce offset:", err}
+	if !header.PrevBlock.IsEqual(&best.Hash) {
 
-		enOffset = 0
+		return true
 	}
+	return lastTxUpdate != c.g.TxSource().LastUpdated() &&
+		time.Now().After(lastGenerated.Add(time.Minute))
+}
+
+// waitStale blocks while workers search the passed block until the work becomes stale, or until all workers have left so the controller can mine on its own again. It returns false if quit was closed first.
+func (c *Controller) waitStale(msgBlock *wire.MsgBlock, ticker *time.Ticker, quit chan struct{}) bool {
+
 	header := &msgBlock.Header
-	targetDifficulty := blockchain.CompactToBig(header.Bits)
 	lastGenerated := time.Now()
 	lastTxUpdate := c.g.TxSource().LastUpdated()
 
-	// Note that the entire extra nonce range is iterated and the offset is added relying on the fact that overflow will wrap around 0 as provided by the Go spec.
+	for {
 
-	for extraNonce := uint64(0); extraNonce < maxExtraNonce; extraNonce++ {
+		select {
 
-		// Update the extra nonce in the block template with the new value by regenerating the coinbase script and setting the merkle root to the new value.
-		c.g.UpdateExtraNonce(msgBlock, blockHeight, extraNonce+enOffset)
-		// Search through the entire nonce range for a solution while periodically checking for early quit and stale block conditions along with updates to the speed monitor.
+		case <-quit:
+			return false
+		case <-ticker.C:
 
-		for i := uint32(0); i <= maxNonce; i++ {
+			if c.isStale(header, lastGenerated, lastTxUpdate) {
 
-			select {
+				return true
+			}
+			c.pool.prune(time.Now())
 
-			case <-quit:
-				// fmt.Println("chan:<-quit")
-				return false
-			case <-ticker.C:
-				// fmt.Println("chan:<-ticker.C")
-				// The current block is stale if the best block has changed.
-				best := c.g.BestSnapshot()
+			if c.pool.numSessions() == 0 {
 
-				if !header.PrevBlock.IsEqual(&best.Hash) {
+				return true
+			}
+		}
+	}
+}
 
-					return false
-				}
-				// The current block is stale if the memory pool has been updated since the block template was generated and it has been at least one minute.
+// solveBlock searches the nonce range of the passed block for a solution while no workers are logged in, so that the controller keeps mining when it has no workers. It returns true when the block is solved, and false when the work becomes stale, a worker logs in, the nonce range is exhausted or quit is closed.
+func (c *Controller) solveBlock(msgBlock *wire.MsgBlock, height int32, ticker *time.Ticker, quit chan struct{}) bool {
 
-				if lastTxUpdate != c.g.TxSource().LastUpdated() &&
+	header := &msgBlock.Header
+	target := blockchain.CompactToBig(header.Bits)
+	forks := c.b.HardForks()
+	lastGenerated := time.Now()
+	lastTxUpdate := c.g.TxSource().LastUpdated()
 
-					time.Now().After(lastGenerated.Add(time.Minute)) {
+	for i := uint32(0); ; i++ {
 
-					return false
-				}
-				c.g.UpdateBlockTime(msgBlock)
-			case <-submissionReceived:
-				// fmt.Println("chan:<-submissionReceived")
-				// Here we will send out the updated block to subscribed client workers
-			default:
-			}
-			// Instead of directly attempting to solve blocks, here we send out the new block data to subscribed miner clients and process submissions they return when they find a solution
+		select {
 
-			header.Nonce = i
-			hash := header.BlockHashWithAlgos(int32(fork.GetCurrent(blockHeight)))
-			// The block is solved when the new block hash is less than the target difficulty.  Yay!
+		case <-quit:
+			return false
+		case <-ticker.C:
 
-			if blockchain.HashToBig(&hash).Cmp(targetDifficulty) <= 0 {
+			if c.isStale(header, lastGenerated, lastTxUpdate) ||
+				c.pool.numSessions() > 0 {
 
-				return true
+				return false
 			}
+		default:
+		}
+		header.Nonce = i
+		hash := header.BlockHashWithSchedule(forks, height)
+
+		// The block is solved when the new block hash is less than the target difficulty.
+		if blockchain.HashToBig(&hash).Cmp(target) <= 0 {
+
+			return true
+		}
+
+		if i == maxNonce {
+
+			return false
 		}
 	}
-	return false
 }
 
-// generateBlocks is a worker that is controlled by the miningWorkerController. It is self contained in that it creates block templates and attempts to solve them while detecting when it is performing stale work and reacting accordingly by generating a new block template.  When a block is solved, it is submitted. It must be run as a goroutine.
+// jobAlgos returns the version and network difficulty of each algorithm that may be used for a block at the given height with the given timestamp
+func (c *Controller) jobAlgos(height int32, timestamp time.Time) ([]JobAlgo, error) {
+
+	forks := c.b.HardForks()
+	hf := forks[forks.GetCurrent(height)]
+	versions := hf.Versions()
+	algos := make([]JobAlgo, 0, len(versions))
+
+	for _, v := range versions {
+
+		bits, err := c.b.CalcNextRequiredDifficulty(timestamp, hf.AlgoVers[v])
+
+		if err != nil {
+
+			return nil, err
+		}
+		algos = append(algos, JobAlgo{Version: v, Bits: bits})
+	}
+	return algos, nil
+}
+
+// submitShare checks a share submitted by a worker, and submits the block if the share solves it
+func (c *Controller) submitShare(share *Share) (ShareResult, error) {
+
+	best := c.g.BestSnapshot().Hash
+	result, block, err := c.pool.submit(share, &best, time.Now())
+
+	if err != nil || block == nil {
+
+		return result, err
+	}
+
+	if !c.submitBlock(block) {
+
+		return ShareAccepted, nil
+	}
+	c.pool.creditBlock(share.Session)
+	return result, nil
+}
+
+// generateBlocks is a worker that is controlled by the miningWorkerController. It creates block templates and issues them as jobs to the workers, and generates a new block template when it detects that the current job has become stale. Blocks solved by the workers are submitted as their shares arrive. While no workers are logged in it solves the jobs itself. It must be run as a goroutine.
 func (c *Controller) generateBlocks(quit chan struct{}) {
 
-	// Start a ticker which is used to signal checks for stale work and updates to the speed monitor.
+	// Start a ticker which is used to signal checks for stale work.
 	ticker := time.NewTicker(time.Second / 2)
 	defer ticker.Stop()
-
-	// Create a channel to receive block submissions
-	var submission chan *wire.MsgBlock
 out:
 
 	for {
@@ -265,16 +319,79 @@ out:
 
 			continue
 		}
-		// Attempt to solve the block.  The function will exit early with false when conditions that trigger a stale block, so a new block template can be generated.  When the return is true a solution was found, so submit the solved block.
+		// Offer the block to the workers with each of the algorithms that may be used at its height, and wait until the job becomes stale so a new block template can be generated.
+		algos, err := c.jobAlgos(template.Height, template.Block.Header.Timestamp)
 
-		if c.solveBlock(template.Block, curHeight+1, c.cfg.ChainParams.Name == "testnet", ticker, submission, quit) {
+		if err != nil {
+
+			log <- cl.Error{"failed to calculate job difficulties:", err}
 
-			block := util.NewBlock(template.Block)
-			c.submitBlock(block)
+			continue
+		}
+		c.pool.prune(time.Now())
+		id := c.pool.addJob(template.Block, template.Height, algos)
+
+		log <- cl.Debug{"issued job", id, "at height", template.Height}
+
+		// Without workers the controller solves the job itself, with the algorithm the template was made for, until a worker logs in.
+		if c.pool.numSessions() == 0 {
+
+			block, err := c.pool.localBlock(id)
+
+			if err != nil {
+
+				log <- cl.Error{"failed to prepare block to mine:", err}
+
+				continue
+			}
+
+			if c.solveBlock(block, template.Height, ticker, quit) {
+
+				solved := util.NewBlock(block)
+				solved.SetHeight(template.Height)
+				c.submitBlock(solved)
+				continue
+			}
+
+			if c.pool.numSessions() == 0 {
+
+				continue
+			}
+		}
+
+		if !c.waitStale(template.Block, ticker, quit) {
+
+			break out
 		}
 	}
 	c.workerWg.Done()
 }
+
+// serveWorkers accepts worker connections on the listener and serves the job protocol on them until the listener is closed
+func (c *Controller) serveWorkers() {
+
+	server := rpc.NewServer()
+	err := server.RegisterName("Jobs", &Jobs{c: c})
+
+	if err != nil {
+
+		log <- cl.Error{"failed to register job protocol:", err}
+
+		return
+	}
+
+	for {
+
+		conn, err := c.listener.Accept()
+
+		if err != nil {
+
+			// The listener is closed when the controller stops.
+			return
+		}
+		go server.ServeConn(conn)
+	}
+}
 func (c *Controller) minerController() {
 
 	c.workerWg.Add(1)
@@ -292,6 +409,8 @@ out:
 			break out
 		}
 	}
+	c.workerWg.Wait()
+	c.wg.Done()
 }
 
 // Start begins the miner controller process. Calling this function when the miner controller has already been started will have no effect.
@@ -304,12 +423,48 @@ func (c *Controller) Start() {
 		return
 	}
 
+	if c.cfg.MinerListener != "" {
+
+		// Without a key anyone who can reach the listener can log in and take work, so it is only served to the local host.
+		if len(c.cfg.MinerKey) == 0 && !isLoopback(c.cfg.MinerListener) {
+
+			log <- cl.Error{"refusing to listen for workers on", c.cfg.MinerListener,
+				"without a miner password, set minerpass or use a loopback address"}
+
+			return
+		}
+		var block kcp.BlockCrypt
+
+		if len(c.cfg.MinerKey) > 0 {
+
+			var err error
+			block, err = kcp.NewAESBlockCrypt(c.cfg.MinerKey)
+
+			if err != nil {
+
+				log <- cl.Error{"failed to set up miner listener encryption:", err}
+
+				return
+			}
+		}
+		listener, err := kcp.ListenWithOptions(c.cfg.MinerListener, block, 10, 3)
+
+		if err != nil {
+
+			log <- cl.Error{"failed to start miner listener:", err}
+
+			return
+		}
+		c.listener = listener
+		go c.serveWorkers()
+	}
+
 	c.quit = make(chan struct{})
 	c.wg.Add(1)
 	go c.minerController()
 	c.started = true
 
-	log <- cl.Inf("Miner controller started")
+	log <- cl.Info{"Miner controller started, listening for workers on", c.cfg.MinerListener}
 
 }
 
@@ -322,6 +477,11 @@ func (c *Controller) Stop() {
 
 		return
 	}
+	if c.listener != nil {
+
+		c.listener.Close()
+		c.listener = nil
+	}
 	close(c.quit)
 	c.wg.Wait()
 	c.started = false
@@ -338,7 +498,13 @@ func (c *Controller) IsMining() bool {
 	return c.started
 }
 
-// New returns a new instance of a CPU miner for the provided configuration. Use Start to begin the mining process.  See the documentation for Controller type for more details.
+// WorkerStats returns the share accounting of all workers that have logged in since the controller was created, ordered by worker name. This function is safe for concurrent access.
+func (c *Controller) WorkerStats() []WorkerStats {
+
+	return c.pool.stats()
+}
+
+// New returns a new instance of a miner controller for the provided configuration. Use Start to begin issuing jobs to workers.  See the documentation for Controller type for more details.
 func New(
 	cfg *Config) *Controller {
 
@@ -346,5 +512,26 @@ func New(
 		b:   cfg.Blockchain,
 		g:   cfg.BlockTemplateGenerator,
 		cfg: *cfg,
+		pool: newPool(cfg.MinerKey, cfg.ShareFactor, cfg.Blockchain.HardForks(),
+			cfg.BlockTemplateGenerator.UpdateExtraNonce),
+	}
+}
+
+// isLoopback returns whether a listen address only accepts connections from the local host
+func isLoopback(
+	addr string) bool {
+
+	host, _, err := net.SplitHostPort(addr)
+
+	if err != nil {
+
+		return false
+	}
+
+	if host == "localhost" {
+
+		return true
 	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
 }
@@ -1,74 +1,204 @@
 package controller
 
 import (
-	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
 	"testing"
 	"time"
 
-	"github.com/dogeerf/rpcx"
-	"github.com/xtaci/kcp-go"
-	"golang.org/x/crypto/argon2"
+	blockchain "git.parallelcoin.io/dev/pod/pkg/chain"
+	chaincfg "git.parallelcoin.io/dev/pod/pkg/chain/config"
+	chainhash "git.parallelcoin.io/dev/pod/pkg/chain/hash"
+	"git.parallelcoin.io/dev/pod/pkg/chain/mining"
+	database "git.parallelcoin.io/dev/pod/pkg/db"
+	_ "git.parallelcoin.io/dev/pod/pkg/db/ffldb"
+	"git.parallelcoin.io/dev/pod/pkg/util"
 )
 
-const cryptKey = "rpcx-key"
-const cryptSalt = "rpcx-salt"
+// emptyTxSource is a mining.TxSource without transactions, so the templates only hold a coinbase.
 
-var minerAddr = "127.0.0.1:11012"
-var nodeAddr = "127.0.0.1:11011"
+type emptyTxSource struct{}
 
-var ready = make(chan bool)
-var K Kopach
+func (emptyTxSource) LastUpdated() time.Time               { return time.Time{} }
+func (emptyTxSource) MiningDescs() []*mining.TxDesc        { return nil }
+func (emptyTxSource) HaveTransaction(*chainhash.Hash) bool { return false }
 
-func TestKCPClientServer(
-	t *testing.T) {
+// newTestController returns a controller mining on a regression test chain that holds only the genesis block, and a function that stops it and removes the chain.
+func newTestController(
+	t *testing.T, listener string, key []byte) (*Controller,
+	*blockchain.BlockChain, func()) {
 
-	fmt.Println("Testing KCP client/server connection")
-	go runServer(t)
-	time.Sleep(time.Second)
-	runClient(t)
-}
-func runServer(
-	t *testing.T) {
+	params := &chaincfg.RegressionNetParams
+	dbPath, err := ioutil.TempDir("", "controller")
 
-	server := rpcx.NewServer()
-	server.RegisterName("Kopach", &K)
-	pass := argon2.IDKey([]byte(cryptKey), []byte(cryptSalt), 1, 4096, 32, 32)
-	bc, err := kcp.NewAESBlockCrypt(pass)
 	if err != nil {
 
-		fmt.Println(err)
+		t.Fatalf("unable to create temporary directory: %v", err)
 	}
-	ln, err := kcp.ListenWithOptions(nodeAddr, bc, 10, 3)
+
+	db, err := database.Create("ffldb", dbPath, params.Net)
+
 	if err != nil {
 
-		fmt.Println(err)
+		os.RemoveAll(dbPath)
+		t.Fatalf("unable to create database: %v", err)
+	}
+
+	timeSource := blockchain.NewMedianTime()
+	chain, err := blockchain.New(&blockchain.Config{
+
+		DB:          db,
+		ChainParams: params,
+		TimeSource:  timeSource,
+	})
+
+	if err != nil {
+
+		db.Close()
+		os.RemoveAll(dbPath)
+		t.Fatalf("unable to create chain: %v", err)
+	}
+
+	addr, err := util.NewAddressPubKeyHash(make([]byte, 20), params)
+
+	if err != nil {
+
+		db.Close()
+		os.RemoveAll(dbPath)
+		t.Fatal(err)
+	}
+
+	policy := mining.Policy{BlockMaxWeight: 4000000, BlockMaxSize: 1000000}
+	c := New(&Config{
+
+		Blockchain:  chain,
+		ChainParams: params,
+		BlockTemplateGenerator: mining.NewBlkTmplGenerator(&policy, params,
+			emptyTxSource{}, chain, timeSource, nil, nil, ""),
+		MiningAddrs: []util.Address{addr},
+		ProcessBlock: func(block *util.Block,
+			flags blockchain.BehaviorFlags) (bool, error) {
+
+			_, isOrphan, err := chain.ProcessBlock(block, flags, block.Height())
+			return isOrphan, err
+		},
+		MinerListener: listener,
+		MinerKey:      key,
+		ConnectedCount: func() int32 {
+
+			return 1
+		},
+		IsCurrent: func() bool {
+
+			return true
+		},
+	})
+
+	return c, chain, func() {
+
+		c.Stop()
+		db.Close()
+		os.RemoveAll(dbPath)
+	}
+}
+
+// waitHeight waits until the chain reaches the given height, failing the test after a timeout.
+func waitHeight(
+	t *testing.T, chain *blockchain.BlockChain, height int32) {
+
+	deadline := time.Now().Add(30 * time.Second)
+
+	for chain.BestSnapshot().Height < height {
+
+		if time.Now().After(deadline) {
+
+			t.Fatalf("chain is at height %d, want %d",
+				chain.BestSnapshot().Height, height)
+		}
+		time.Sleep(10 * time.Millisecond)
 	}
-	fmt.Println("Running server")
-	server.ServeListener(ln)
-	ready <- true
-	fmt.Println("Finished running server")
 }
-func runClient(
+
+// TestControllerWithoutWorkers ensures the controller solves its jobs itself while no workers are logged in.
+func TestControllerWithoutWorkers(
+	t *testing.T) {
+
+	c, chain, teardown := newTestController(t, "", nil)
+	defer teardown()
+	c.Start()
+	waitHeight(t, chain, 2)
+}
+
+// TestWorker ensures a worker logs in to the controller with the miner key, takes its jobs and submits shares that are credited to it and solve blocks.
+func TestWorker(
 	t *testing.T) {
 
-	pass := argon2.Key([]byte(cryptKey), []byte(cryptSalt), 1, 4096, 32, 32)
-	bc, _ := kcp.NewAESBlockCrypt(pass)
-
-	s := &rpcx.DirectClientSelector{Network: "kcp", Address: nodeAddr}
-	client := rpcx.NewClient(s)
-	client.Block = bc
-	args := &minerAddr
-	var reply *string
-	fmt.Println("server", nodeAddr, "Calling subscribe", *args, client.PluginContainer)
-	err := client.Call("Kopach.Subscribe", &args, &reply)
-	fmt.Println("Got reply", reply)
+	// Find a free loopback port for the controller to listen on.
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+
 	if err != nil {
 
-		fmt.Printf("error for Kopach: %s, %v\n", *args, err)
-	} else {
+		t.Fatal(err)
+	}
+
+	listener := conn.LocalAddr().String()
+	conn.Close()
+	key := []byte("0123456789abcdef0123456789abcdef")
+	c, chain, teardown := newTestController(t, listener, key)
+	defer teardown()
+	c.Start()
+
+	if !c.IsMining() {
+
+		t.Fatal("controller did not start")
+	}
+
+	quit := make(chan struct{})
+	done := make(chan error, 1)
+
+	go func() {
+
+		done <- NewWorker(&WorkerConfig{
+
+			Controller: listener,
+			Key:        key,
+			Name:       "test",
+			Schedule:   chain.HardForks(),
+			Threads:    2,
+		}).Run(quit)
+	}()
+
+	deadline := time.Now().Add(30 * time.Second)
+
+	for {
+
+		stats := c.WorkerStats()
+
+		if len(stats) == 1 && stats[0].Worker == "test" &&
+			stats[0].Accepted > 0 && stats[0].Blocks > 0 {
+
+			break
+		}
+
+		select {
+
+		case err := <-done:
+			t.Fatalf("worker stopped: %v", err)
+		default:
+		}
+
+		if time.Now().After(deadline) {
+
+			t.Fatalf("worker found no blocks, stats %+v", stats)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	close(quit)
+
+	if err := <-done; err != nil {
 
-		fmt.Printf("Subscribe: %s : %s\n", *args, *reply)
+		t.Fatalf("worker stopped with error: %v", err)
 	}
-	fmt.Println("closing connection")
-	client.Close()
 }
@@ -0,0 +1,72 @@
+package controller
+
+import (
+	"time"
+
+	cl "git.parallelcoin.io/dev/pod/pkg/util/cl"
+)
+
+// Jobs is the RPC service of the job protocol. Workers log in, fetch jobs and submit shares, and the controller keeps per worker share accounting
+
+type Jobs struct {
+	c *Controller
+}
+
+// Challenge issues a login challenge, which the worker signs with the miner key and sends back in its login within a minute
+func (j *Jobs) Challenge(args *ChallengeArgs, reply *ChallengeReply) (err error) {
+
+	reply.Challenge, err = j.c.pool.challenge(time.Now())
+
+	if err != nil {
+
+		log <- cl.Warn{"refused login challenge for worker", args.Worker, err}
+	}
+	return
+}
+
+// Login authenticates a worker and opens a session
+func (j *Jobs) Login(args *LoginArgs, reply *LoginReply) (err error) {
+
+	id, err := j.c.pool.login(args, time.Now())
+
+	if err != nil {
+
+		log <- cl.Warn{"refused login of worker", args.Worker, err}
+
+		return
+	}
+
+	log <- cl.Info{"worker logged in", args.Worker}
+
+	reply.Version = ProtocolVersion
+	reply.Session = id
+	return
+}
+
+// Logout closes a session
+func (j *Jobs) Logout(args *SessionArgs, reply *SessionArgs) (err error) {
+
+	err = j.c.pool.logout(args.Session)
+	*reply = *args
+	return
+}
+
+// GetJob returns the current job for a session. Workers should call it whenever they have exhausted a job and at least every few seconds, as shares for superseded jobs become stale once a new block is connected
+func (j *Jobs) GetJob(args *SessionArgs, reply *Job) (err error) {
+
+	job, err := j.c.pool.work(args.Session, time.Now())
+
+	if err != nil {
+
+		return
+	}
+	*reply = *job
+	return
+}
+
+// Submit checks a share and credits it to the worker of the session. Shares that solve the block are submitted to the network
+func (j *Jobs) Submit(args *Share, reply *ShareReply) (err error) {
+
+	reply.Result, err = j.c.submitShare(args)
+	return
+}
@@ -0,0 +1,513 @@
+package controller
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	blockchain "git.parallelcoin.io/dev/pod/pkg/chain"
+	"git.parallelcoin.io/dev/pod/pkg/chain/fork"
+	chainhash "git.parallelcoin.io/dev/pod/pkg/chain/hash"
+	"git.parallelcoin.io/dev/pod/pkg/chain/wire"
+	"git.parallelcoin.io/dev/pod/pkg/util"
+)
+
+const (
+
+	// DefaultShareFactor is the factor by which the share target of an algorithm is easier than its network target when no factor is configured
+	DefaultShareFactor = 256
+
+	// maxJobs is the number of most recent jobs shares are accepted for. Shares for older jobs are stale
+	maxJobs = 8
+
+	// sessionTimeout is how long a session is kept after the last call made in it
+	sessionTimeout = 10 * time.Minute
+
+	// challengeSize is the number of random bytes in a login challenge
+	challengeSize = 32
+
+	// challengeTimeout is how long a login challenge is accepted after it was issued
+	challengeTimeout = time.Minute
+
+	// maxChallenges is the number of login challenges that may be outstanding at once, which bounds the memory a flood of challenge calls can take up
+	maxChallenges = 1024
+)
+
+var (
+
+	// ErrNoSession is returned for calls that name a session that does not exist or has expired. Workers should log in again
+	ErrNoSession = errors.New("no such session, log in again")
+
+	// ErrNoJob is returned when work is requested before the controller has generated the first job
+	ErrNoJob = errors.New("no job available yet")
+
+	// ErrTooManyChallenges is returned when a challenge is requested while maxChallenges are outstanding. Workers should try again after a while
+	ErrTooManyChallenges = errors.New("too many outstanding login challenges")
+)
+
+// WorkerStats is the share accounting of a worker. The counters cover all sessions that logged in with the worker name since the controller was started
+
+type WorkerStats struct {
+	Worker     string
+	Sessions   int
+	LoginTime  time.Time
+	LastShare  time.Time
+	Accepted   int64
+	Stale      int64
+	Duplicate  int64
+	Invalid    int64
+	Blocks     int64
+	AlgoShares map[string]int64
+}
+
+// session is a logged in worker connection
+
+type session struct {
+	id       uint64
+	index    uint32
+	worker   *WorkerStats
+	lastSeen time.Time
+}
+
+// job is a block template offered to the workers along with the solutions submitted for it so far
+
+type job struct {
+	id        uint64
+	height    int32
+	block     *wire.MsgBlock
+	algos     []JobAlgo
+	solutions map[chainhash.Hash]struct{}
+}
+
+// pool keeps the sessions, jobs and share accounting of the workers connected to the controller. It knows nothing about the chain except the hard fork schedule, the block to build on is passed in by the controller
+
+type pool struct {
+	sync.Mutex
+	key              []byte
+	shareFactor      int64
+	schedule         fork.Schedule
+	updateExtraNonce func(*wire.MsgBlock, int32, uint64) error
+	jobs             map[uint64]*job
+	jobOrder         []uint64
+	lastJob          uint64
+	challenges       map[string]time.Time
+	sessions         map[uint64]*session
+	workers          map[string]*WorkerStats
+	lastIndex        uint32
+}
+
+// newPool returns an empty pool. Workers must sign a challenge with key to log in, unless key is empty
+func newPool(
+	key []byte, shareFactor int64, schedule fork.Schedule,
+	updateExtraNonce func(*wire.MsgBlock, int32, uint64) error) *pool {
+
+	if shareFactor < 1 {
+
+		shareFactor = DefaultShareFactor
+	}
+	return &pool{
+		key:              key,
+		shareFactor:      shareFactor,
+		schedule:         schedule,
+		updateExtraNonce: updateExtraNonce,
+		jobs:             make(map[uint64]*job),
+		challenges:       make(map[string]time.Time),
+		sessions:         make(map[uint64]*session),
+		workers:          make(map[string]*WorkerStats),
+	}
+}
+
+// challenge issues a new login challenge
+func (p *pool) challenge(
+	now time.Time) ([]byte, error) {
+
+	p.Lock()
+	defer p.Unlock()
+
+	if len(p.challenges) >= maxChallenges {
+
+		p.pruneChallenges(now)
+
+		if len(p.challenges) >= maxChallenges {
+
+			return nil, ErrTooManyChallenges
+		}
+	}
+	challenge := make([]byte, challengeSize)
+
+	if _, err := rand.Read(challenge); err != nil {
+
+		return nil, err
+	}
+	p.challenges[string(challenge)] = now
+	return challenge, nil
+}
+
+// login authenticates a worker and opens a session for it. When the pool has a key, the login must carry a challenge issued within challengeTimeout, which is used up by the attempt, and the challenge signed with the key
+func (p *pool) login(
+	args *LoginArgs, now time.Time) (uint64, error) {
+
+	if args.Version != ProtocolVersion {
+
+		return 0, fmt.Errorf("unsupported protocol version %d, controller speaks %d",
+			args.Version, ProtocolVersion)
+	}
+
+	if args.Worker == "" {
+
+		return 0, errors.New("worker name is empty")
+	}
+
+	p.Lock()
+	defer p.Unlock()
+
+	if len(p.key) > 0 {
+
+		issued, ok := p.challenges[string(args.Challenge)]
+		delete(p.challenges, string(args.Challenge))
+
+		if !ok || now.Sub(issued) > challengeTimeout {
+
+			return 0, errors.New("unknown or expired login challenge")
+		}
+
+		if !hmac.Equal(args.Auth, WorkerAuth(p.key, args.Challenge, args.Worker)) {
+
+			return 0, errors.New("worker authentication failed")
+		}
+	}
+	var id uint64
+
+	for id == 0 || p.sessions[id] != nil {
+
+		var err error
+		id, err = wire.RandomUint64()
+
+		if err != nil {
+
+			return 0, err
+		}
+	}
+	w, ok := p.workers[args.Worker]
+
+	if !ok {
+
+		w = &WorkerStats{
+			Worker:     args.Worker,
+			AlgoShares: make(map[string]int64),
+		}
+		p.workers[args.Worker] = w
+	}
+	w.Sessions++
+	w.LoginTime = now
+	p.lastIndex++
+	p.sessions[id] = &session{
+		id:       id,
+		index:    p.lastIndex,
+		worker:   w,
+		lastSeen: now,
+	}
+	return id, nil
+}
+
+// logout closes a session
+func (p *pool) logout(
+	id uint64) error {
+
+	p.Lock()
+	defer p.Unlock()
+	s, ok := p.sessions[id]
+
+	if !ok {
+
+		return ErrNoSession
+	}
+	s.worker.Sessions--
+	delete(p.sessions, id)
+	return nil
+}
+
+// numSessions returns the number of logged in sessions
+func (p *pool) numSessions() int {
+
+	p.Lock()
+	defer p.Unlock()
+	return len(p.sessions)
+}
+
+// prune closes the sessions that have been idle for longer than sessionTimeout and drops expired login challenges
+func (p *pool) prune(
+	now time.Time) {
+
+	p.Lock()
+	defer p.Unlock()
+
+	for id, s := range p.sessions {
+
+		if now.Sub(s.lastSeen) > sessionTimeout {
+
+			s.worker.Sessions--
+			delete(p.sessions, id)
+		}
+	}
+	p.pruneChallenges(now)
+}
+
+// pruneChallenges drops the login challenges that were issued longer than challengeTimeout ago. It must be called with the pool lock held
+func (p *pool) pruneChallenges(
+	now time.Time) {
+
+	for challenge, issued := range p.challenges {
+
+		if now.Sub(issued) > challengeTimeout {
+
+			delete(p.challenges, challenge)
+		}
+	}
+}
+
+// addJob makes a block template the current job. The version and bits of each algorithm the job may be solved with are given in algos, the share bits are filled in from the share factor. The oldest job is dropped when there are more than maxJobs
+func (p *pool) addJob(
+	block *wire.MsgBlock, height int32, algos []JobAlgo) uint64 {
+
+	p.Lock()
+	defer p.Unlock()
+	p.lastJob++
+	j := &job{
+		id:        p.lastJob,
+		height:    height,
+		block:     block,
+		algos:     make([]JobAlgo, len(algos)),
+		solutions: make(map[chainhash.Hash]struct{}),
+	}
+	copy(j.algos, algos)
+
+	for i := range j.algos {
+
+		name := p.schedule.GetAlgoName(j.algos[i].Version, height)
+		minDiff := p.schedule.GetMinDiff(name, height)
+		j.algos[i].ShareBits = shareBits(j.algos[i].Bits, p.shareFactor, minDiff)
+	}
+	p.jobs[j.id] = j
+	p.jobOrder = append(p.jobOrder, j.id)
+
+	if len(p.jobOrder) > maxJobs {
+
+		delete(p.jobs, p.jobOrder[0])
+		p.jobOrder = p.jobOrder[1:]
+	}
+	return j.id
+}
+
+// work returns the current job for a session. Like in submit, the block of the session is built without holding the pool lock
+func (p *pool) work(
+	id uint64, now time.Time) (*Job, error) {
+
+	p.Lock()
+	s, ok := p.sessions[id]
+
+	if !ok {
+
+		p.Unlock()
+		return nil, ErrNoSession
+	}
+	s.lastSeen = now
+
+	if len(p.jobOrder) == 0 {
+
+		p.Unlock()
+		return nil, ErrNoJob
+	}
+	j := p.jobs[p.jobOrder[len(p.jobOrder)-1]]
+	index := s.index
+	p.Unlock()
+	block, err := p.sessionBlock(j, index)
+
+	if err != nil {
+
+		return nil, err
+	}
+	out := &Job{
+		ID:         j.id,
+		Height:     j.height,
+		PrevBlock:  block.Header.PrevBlock,
+		MerkleRoot: block.Header.MerkleRoot,
+		Timestamp:  block.Header.Timestamp.Unix(),
+		Algos:      make([]JobAlgo, len(j.algos)),
+	}
+	copy(out.Algos, j.algos)
+	return out, nil
+}
+
+// submit checks a share against its job. The share is stale if its job has been dropped or does not build on best. When the share also solves the block, the solved block is returned. The block of the share is built and hashed without holding the pool lock, so that other workers are not held up by it
+func (p *pool) submit(
+	share *Share, best *chainhash.Hash, now time.Time) (ShareResult, *util.Block, error) {
+
+	p.Lock()
+	s, ok := p.sessions[share.Session]
+
+	if !ok {
+
+		p.Unlock()
+		return "", nil, ErrNoSession
+	}
+	s.lastSeen = now
+	w := s.worker
+	index := s.index
+	j, ok := p.jobs[share.JobID]
+
+	if !ok || !j.block.Header.PrevBlock.IsEqual(best) {
+
+		w.Stale++
+		p.Unlock()
+		return ShareStale, nil, nil
+	}
+	var algo *JobAlgo
+
+	for i := range j.algos {
+
+		if j.algos[i].Version == share.Version {
+
+			a := j.algos[i]
+			algo = &a
+		}
+	}
+
+	if algo == nil {
+
+		w.Invalid++
+		p.Unlock()
+		return ShareInvalid, nil, nil
+	}
+	p.Unlock()
+
+	// The template, height and algorithms of a job don't change once it is added, so they can be used without the lock.
+	block, err := p.sessionBlock(j, index)
+
+	if err != nil {
+
+		return "", nil, err
+	}
+	block.Header.Version = algo.Version
+	block.Header.Bits = algo.Bits
+	block.Header.Nonce = share.Nonce
+	hash := block.Header.BlockHashWithSchedule(p.schedule, j.height)
+	hashNum := blockchain.HashToBig(&hash)
+
+	p.Lock()
+	defer p.Unlock()
+
+	if _, ok := j.solutions[hash]; ok {
+
+		w.Duplicate++
+		return ShareDuplicate, nil, nil
+	}
+
+	if hashNum.Cmp(blockchain.CompactToBig(algo.ShareBits)) > 0 {
+
+		w.Invalid++
+		return ShareInvalid, nil, nil
+	}
+	j.solutions[hash] = struct{}{}
+	w.Accepted++
+	w.LastShare = now
+	w.AlgoShares[p.schedule.GetAlgoName(algo.Version, j.height)]++
+
+	if hashNum.Cmp(blockchain.CompactToBig(algo.Bits)) > 0 {
+
+		return ShareAccepted, nil, nil
+	}
+	solved := util.NewBlock(block)
+	solved.SetHeight(j.height)
+	return ShareBlock, solved, nil
+}
+
+// localBlock returns a copy of the block of a job for the controller to solve itself. It uses the extra nonce of session index zero, which no session is given
+func (p *pool) localBlock(
+	id uint64) (*wire.MsgBlock, error) {
+
+	p.Lock()
+	j, ok := p.jobs[id]
+	p.Unlock()
+
+	if !ok {
+
+		return nil, ErrNoJob
+	}
+	return p.sessionBlock(j, 0)
+}
+
+// creditBlock records that a share submitted in a session solved a block that was accepted by the chain
+func (p *pool) creditBlock(
+	id uint64) {
+
+	p.Lock()
+	defer p.Unlock()
+
+	if s, ok := p.sessions[id]; ok {
+
+		s.worker.Blocks++
+	}
+}
+
+// stats returns a copy of the share accounting of all workers, ordered by worker name
+func (p *pool) stats() []WorkerStats {
+
+	p.Lock()
+	defer p.Unlock()
+	out := make([]WorkerStats, 0, len(p.workers))
+
+	for _, w := range p.workers {
+
+		ws := *w
+		ws.AlgoShares = make(map[string]int64, len(w.AlgoShares))
+
+		for algo, n := range w.AlgoShares {
+
+			ws.AlgoShares[algo] = n
+		}
+		out = append(out, ws)
+	}
+	sort.Slice(out, func(i, j int) bool {
+
+		return out[i].Worker < out[j].Worker
+	})
+	return out
+}
+
+// sessionBlock returns a copy of the block of a job with the coinbase extra nonce of the session with the given index, so that each session searches its own merkle root. The extra nonce holds the session index in the upper and the job ID in the lower 32 bits, which keeps it unique across jobs built on the same block. It does not need the pool lock
+func (p *pool) sessionBlock(
+	j *job, index uint32) (*wire.MsgBlock, error) {
+
+	block := *j.block
+	block.Transactions = make([]*wire.MsgTx, len(j.block.Transactions))
+	copy(block.Transactions, j.block.Transactions)
+	block.Transactions[0] = j.block.Transactions[0].Copy()
+	extraNonce := uint64(index)<<32 | j.id&0xffffffff
+	err := p.updateExtraNonce(&block, j.height, extraNonce)
+
+	if err != nil {
+
+		return nil, err
+	}
+	return &block, nil
+}
+
+// shareBits returns the compact share target for a network target, which is the network target multiplied by factor but no easier than the minimum difficulty of the algorithm
+func shareBits(
+	bits uint32, factor int64, minDiff *big.Int) uint32 {
+
+	target := blockchain.CompactToBig(bits)
+	target.Mul(target, big.NewInt(factor))
+
+	if target.Cmp(minDiff) > 0 {
+
+		target.Set(minDiff)
+	}
+	return blockchain.BigToCompact(target)
+}
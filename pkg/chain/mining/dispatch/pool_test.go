@@ -0,0 +1,390 @@
+package controller
+
+import (
+	"encoding/binary"
+	"testing"
+	"time"
+
+	blockchain "git.parallelcoin.io/dev/pod/pkg/chain"
+	"git.parallelcoin.io/dev/pod/pkg/chain/fork"
+	chainhash "git.parallelcoin.io/dev/pod/pkg/chain/hash"
+	"git.parallelcoin.io/dev/pod/pkg/chain/wire"
+	"git.parallelcoin.io/dev/pod/pkg/util"
+)
+
+// testHeight is a height before the first hard fork, where sha256d is in use
+const testHeight = 1
+
+// testMinBits is the minimum difficulty of testSchedule, at which half of all hashes are shares
+const testMinBits = 0x207fffff
+
+// testSchedule returns the schedule of the first hard fork with the minimum difficulty of all algorithms lowered to testMinBits, so that shares and blocks are found quickly
+func testSchedule() fork.Schedule {
+
+	hf := fork.List[0]
+	hf.Algos = make(map[string]fork.AlgoParams)
+
+	for name, algo := range fork.List[0].Algos {
+
+		algo.MinBits = testMinBits
+		hf.Algos[name] = algo
+	}
+	return fork.Schedule{hf}
+}
+
+// testExtraNonce stands in for BlkTmplGenerator.UpdateExtraNonce
+func testExtraNonce(
+	msgBlock *wire.MsgBlock, height int32, extraNonce uint64) error {
+
+	script := make([]byte, 12)
+	binary.LittleEndian.PutUint32(script, uint32(height))
+	binary.LittleEndian.PutUint64(script[4:], extraNonce)
+	msgBlock.Transactions[0].TxIn[0].SignatureScript = script
+	merkles := blockchain.BuildMerkleTreeStore(util.NewBlock(msgBlock).Transactions(), false)
+	msgBlock.Header.MerkleRoot = *merkles[len(merkles)-1]
+	return nil
+}
+
+func testTemplate() *wire.MsgBlock {
+
+	coinbase := wire.NewMsgTx(1)
+	coinbase.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{}, wire.MaxPrevOutIndex), nil, nil))
+	coinbase.AddTxOut(wire.NewTxOut(50e8, []byte{0x51}))
+	block := &wire.MsgBlock{
+		Header: wire.BlockHeader{
+			PrevBlock: chainhash.DoubleHashH([]byte("prev")),
+			Timestamp: time.Unix(1554000000, 0),
+		},
+	}
+	block.AddTransaction(coinbase)
+	return block
+}
+
+// meets returns whether the header of a job solved with the given algorithm and nonce meets the target of bits
+func meets(
+	schedule fork.Schedule, job *Job, version int32, nonce, bits uint32) bool {
+
+	header := wire.BlockHeader{
+		Version:    version,
+		PrevBlock:  job.PrevBlock,
+		MerkleRoot: job.MerkleRoot,
+		Timestamp:  time.Unix(job.Timestamp, 0),
+		Bits:       job.Algos[0].Bits,
+		Nonce:      nonce,
+	}
+	hash := header.BlockHashWithSchedule(schedule, job.Height)
+	return blockchain.HashToBig(&hash).Cmp(blockchain.CompactToBig(bits)) <= 0
+}
+
+// find returns the first nonce for which match returns true
+func find(
+	t *testing.T, match func(nonce uint32) bool) uint32 {
+
+	for nonce := uint32(0); nonce < 1<<20; nonce++ {
+
+		if match(nonce) {
+
+			return nonce
+		}
+	}
+	t.Fatal("no matching nonce found")
+	return 0
+}
+
+func TestShareBits(
+	t *testing.T) {
+
+	minDiff := blockchain.CompactToBig(0x1e0fffff)
+	tests := []struct {
+		bits   uint32
+		factor int64
+		want   uint32
+	}{
+		// The target is multiplied by the factor.
+		{0x1b0404cb, 256, 0x1c0404cb},
+		{0x1b0404cb, 1, 0x1b0404cb},
+		// The share target is no easier than the minimum difficulty.
+		{0x1d00ffff, 1 << 16, 0x1e0fffff},
+		{0x1e0fffff, 256, 0x1e0fffff},
+	}
+
+	for _, test := range tests {
+
+		got := shareBits(test.bits, test.factor, minDiff)
+
+		if got != test.want {
+
+			t.Errorf("shareBits(%08x, %d): got %08x, want %08x",
+				test.bits, test.factor, got, test.want)
+		}
+	}
+}
+
+func TestWorkerLogin(
+	t *testing.T) {
+
+	key := []byte("miner key")
+	p := newPool(key, 0, testSchedule(), testExtraNonce)
+	now := time.Now()
+	challenge := func() []byte {
+
+		c, err := p.challenge(now)
+
+		if err != nil {
+
+			t.Fatal(err)
+		}
+		return c
+	}
+	replayed := challenge()
+	other := []byte("other key")
+	tests := []struct {
+		name       string
+		version    uint32
+		worker     string
+		challenge  []byte
+		signKey    []byte
+		signWorker string
+		ok         bool
+	}{
+		{"valid", ProtocolVersion, "rig1", challenge(), key, "rig1", true},
+		{"wrong key", ProtocolVersion, "rig1", challenge(), other, "rig1", false},
+		{"auth of other worker", ProtocolVersion, "rig2", challenge(), key, "rig1", false},
+		{"wrong version", ProtocolVersion + 1, "rig1", challenge(), key, "rig1", false},
+		{"no name", ProtocolVersion, "", challenge(), key, "", false},
+		{"unknown challenge", ProtocolVersion, "rig1", []byte("not issued"), key, "rig1", false},
+		{"first use of challenge", ProtocolVersion, "rig1", replayed, key, "rig1", true},
+		{"replayed challenge", ProtocolVersion, "rig1", replayed, key, "rig1", false},
+	}
+
+	for _, test := range tests {
+
+		args := LoginArgs{
+			Version:   test.version,
+			Worker:    test.worker,
+			Challenge: test.challenge,
+			Auth:      WorkerAuth(test.signKey, test.challenge, test.signWorker),
+		}
+		_, err := p.login(&args, now)
+
+		if (err == nil) != test.ok {
+
+			t.Errorf("%s: login error %v, want success %v", test.name, err, test.ok)
+		}
+	}
+
+	// Challenges expire.
+	expired := challenge()
+	args := LoginArgs{ProtocolVersion, "rig1", expired, WorkerAuth(key, expired, "rig1")}
+
+	if _, err := p.login(&args, now.Add(challengeTimeout+time.Second)); err == nil {
+
+		t.Errorf("login with expired challenge succeeded")
+	}
+	c := challenge()
+	args = LoginArgs{ProtocolVersion, "rig1", c, WorkerAuth(key, c, "rig1")}
+	session, err := p.login(&args, now)
+
+	if err != nil {
+
+		t.Fatal(err)
+	}
+
+	if _, err := p.work(session, now); err != ErrNoJob {
+
+		t.Errorf("work before first job: got error %v, want %v", err, ErrNoJob)
+	}
+
+	if err := p.logout(session); err != nil {
+
+		t.Fatal(err)
+	}
+
+	if _, err := p.work(session, now); err != ErrNoSession {
+
+		t.Errorf("work after logout: got error %v, want %v", err, ErrNoSession)
+	}
+	stats := p.stats()
+
+	if len(stats) != 1 || stats[0].Worker != "rig1" || stats[0].Sessions != 2 {
+
+		t.Errorf("unexpected stats %+v", stats)
+	}
+
+	// The remaining sessions and the challenges that were not used expire.
+	challenge()
+	p.prune(now.Add(sessionTimeout + time.Second))
+
+	if stats := p.stats(); stats[0].Sessions != 0 {
+
+		t.Errorf("got %d sessions after pruning, want 0", stats[0].Sessions)
+	}
+
+	if len(p.challenges) != 0 {
+
+		t.Errorf("got %d challenges after pruning, want 0", len(p.challenges))
+	}
+}
+
+func TestIsLoopback(
+	t *testing.T) {
+
+	tests := []struct {
+		addr string
+		want bool
+	}{
+		{"127.0.0.1:11011", true},
+		{"[::1]:11011", true},
+		{"localhost:11011", true},
+		{":11011", false},
+		{"0.0.0.0:11011", false},
+		{"192.168.1.2:11011", false},
+		{"127.0.0.1", false},
+	}
+
+	for _, test := range tests {
+
+		if got := isLoopback(test.addr); got != test.want {
+
+			t.Errorf("isLoopback(%q): got %v, want %v", test.addr, got, test.want)
+		}
+	}
+}
+
+func TestShares(
+	t *testing.T) {
+
+	schedule := testSchedule()
+	p := newPool(nil, 256, schedule, testExtraNonce)
+	now := time.Now()
+
+	// The network target is 256 times harder than the share target, which is the minimum difficulty.
+	netBits := uint32(0x1f7fffff)
+	template := testTemplate()
+	best := template.Header.PrevBlock
+	p.addJob(template, testHeight, []JobAlgo{{Version: 2, Bits: netBits}})
+
+	login := func(name string) uint64 {
+
+		id, err := p.login(&LoginArgs{Version: ProtocolVersion, Worker: name}, now)
+
+		if err != nil {
+
+			t.Fatal(err)
+		}
+		return id
+	}
+	s1, s2 := login("rig1"), login("rig2")
+	job1, err := p.work(s1, now)
+
+	if err != nil {
+
+		t.Fatal(err)
+	}
+	job2, err := p.work(s2, now)
+
+	if err != nil {
+
+		t.Fatal(err)
+	}
+
+	if job1.ID != job2.ID || job1.MerkleRoot == job2.MerkleRoot {
+
+		t.Fatalf("sessions got jobs %d and %d with merkle roots %v and %v, want the same job with different roots",
+			job1.ID, job2.ID, job1.MerkleRoot, job2.MerkleRoot)
+	}
+
+	if len(job1.Algos) != 1 || job1.Algos[0].ShareBits != testMinBits {
+
+		t.Fatalf("got job algos %+v, want share bits %08x", job1.Algos, testMinBits)
+	}
+	submit := func(share Share, best chainhash.Hash, want ShareResult) *util.Block {
+
+		result, block, err := p.submit(&share, &best, now)
+
+		if err != nil {
+
+			t.Fatal(err)
+		}
+
+		if result != want {
+
+			t.Errorf("share %+v: got %v, want %v", share, result, want)
+		}
+		return block
+	}
+
+	// Find a nonce that is a share but not a block for rig1, and that is not a share for rig2.
+	nonce := find(t, func(nonce uint32) bool {
+
+		return meets(schedule, job1, 2, nonce, testMinBits) &&
+			!meets(schedule, job1, 2, nonce, netBits) &&
+			!meets(schedule, job2, 2, nonce, testMinBits)
+	})
+	share := Share{Session: s1, JobID: job1.ID, Version: 2, Nonce: nonce}
+	submit(share, best, ShareAccepted)
+	submit(share, best, ShareDuplicate)
+
+	// The same nonce is a different solution in another session.
+	share2 := share
+	share2.Session = s2
+	submit(share2, best, ShareInvalid)
+
+	// The job does not offer other algorithms.
+	share3 := share
+	share3.Version = 514
+	submit(share3, best, ShareInvalid)
+
+	// A new best block makes the job stale.
+	submit(share, chainhash.DoubleHashH([]byte("other")), ShareStale)
+
+	// Find a share that solves the block of rig2.
+	nonce = find(t, func(nonce uint32) bool {
+
+		return meets(schedule, job2, 2, nonce, netBits)
+	})
+	block := submit(Share{Session: s2, JobID: job2.ID, Version: 2, Nonce: nonce}, best, ShareBlock)
+
+	if block == nil || block.Height() != testHeight {
+
+		t.Fatalf("got solved block %v, want block at height %d", block, testHeight)
+	}
+	header := &block.MsgBlock().Header
+
+	if header.MerkleRoot != job2.MerkleRoot || header.Nonce != nonce ||
+		header.Bits != netBits {
+
+		t.Errorf("solved block header %+v does not match the job", header)
+	}
+	p.creditBlock(s2)
+
+	// Jobs are dropped once there are more than maxJobs.
+	for i := 0; i < maxJobs; i++ {
+
+		p.addJob(template, testHeight, []JobAlgo{{Version: 2, Bits: netBits}})
+	}
+	submit(share, best, ShareStale)
+	stats := p.stats()
+	want := []WorkerStats{
+		{Worker: "rig1", Accepted: 1, Duplicate: 1, Invalid: 1, Stale: 2},
+		{Worker: "rig2", Accepted: 1, Invalid: 1, Blocks: 1},
+	}
+
+	for i := range want {
+
+		got := stats[i]
+
+		if got.Worker != want[i].Worker || got.Accepted != want[i].Accepted ||
+			got.Duplicate != want[i].Duplicate || got.Invalid != want[i].Invalid ||
+			got.Stale != want[i].Stale || got.Blocks != want[i].Blocks {
+
+			t.Errorf("got stats %+v, want %+v", got, want[i])
+		}
+
+		if got.AlgoShares["sha256d"] != want[i].Accepted {
+
+			t.Errorf("%s: got %d sha256d shares, want %d", got.Worker,
+				got.AlgoShares["sha256d"], want[i].Accepted)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package controller
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+
+	chainhash "git.parallelcoin.io/dev/pod/pkg/chain/hash"
+)
+
+// ProtocolVersion is the version of the job protocol spoken by the controller. Workers send the version they speak when they log in, and a login with a different version is refused, so that incompatible workers fail early instead of submitting shares that can never be valid. Version 2 added the login challenge.
+const ProtocolVersion = 2
+
+// ShareResult is the verdict of the controller on a submitted share
+
+type ShareResult string
+
+// These constants are the possible verdicts on a submitted share
+const (
+
+	// ShareAccepted means the share meets the share difficulty of its algorithm and has been credited to the worker
+	ShareAccepted ShareResult = "accepted"
+
+	// ShareBlock means the share also meets the network difficulty and the block was submitted to the network. It is credited as an accepted share as well
+	ShareBlock ShareResult = "block"
+
+	// ShareStale means the job of the share is no longer current, because a new block was connected since it was issued or because it has expired
+	ShareStale ShareResult = "stale"
+
+	// ShareDuplicate means the same solution was already submitted
+	ShareDuplicate ShareResult = "duplicate"
+
+	// ShareInvalid means the share does not meet the share difficulty or names an algorithm the job does not offer
+	ShareInvalid ShareResult = "invalid"
+)
+
+// ChallengeArgs are the parameters of the challenge call that precedes a login
+
+type ChallengeArgs struct {
+
+	// Worker is the name the worker is going to log in with
+	Worker string
+}
+
+// ChallengeReply carries a challenge the worker signs to log in
+
+type ChallengeReply struct {
+
+	// Challenge is a random nonce that is accepted in one login within a minute of being issued
+	Challenge []byte
+}
+
+// LoginArgs are the parameters of the login call that starts a worker session
+
+type LoginArgs struct {
+
+	// Version is the protocol version the worker speaks
+	Version uint32
+
+	// Worker is the name the worker reports its shares under. Several sessions may use the same name, their shares are then accounted together
+	Worker string
+
+	// Challenge is the challenge the controller issued for the login
+	Challenge []byte
+
+	// Auth proves that the worker knows the miner key, see WorkerAuth
+	Auth []byte
+}
+
+// LoginReply is the reply to a successful login
+
+type LoginReply struct {
+
+	// Version is the protocol version the controller speaks
+	Version uint32
+
+	// Session identifies the session in all following calls
+	Session uint64
+}
+
+// SessionArgs are the parameters of calls that only need the session
+
+type SessionArgs struct {
+	Session uint64
+}
+
+// JobAlgo is the per algorithm part of a job. A worker picks one of the algorithms of a job, sets the block version to Version and searches for a nonce that makes the header hash meet ShareBits
+
+type JobAlgo struct {
+
+	// Version is the block version that selects the algorithm
+	Version int32
+
+	// Bits is the network difficulty of the algorithm in compact form
+	Bits uint32
+
+	// ShareBits is the difficulty a share of the algorithm must meet in compact form. It is never harder than Bits
+	ShareBits uint32
+}
+
+// Job is a unit of work issued to a worker. A job is tied to one block template, and the merkle root is specific to the session it was issued to, so that no two workers search the same space
+
+type Job struct {
+
+	// ID identifies the job in share submissions
+	ID uint64
+
+	// Height is the height of the block being mined
+	Height int32
+
+	// PrevBlock is the hash of the block the job builds on
+	PrevBlock chainhash.Hash
+
+	// MerkleRoot is the merkle root of the block for the session
+	MerkleRoot chainhash.Hash
+
+	// Timestamp is the block timestamp as seconds since the epoch
+	Timestamp int64
+
+	// Algos lists the algorithms that may be used to solve the job
+	Algos []JobAlgo
+}
+
+// Share is a solution submitted by a worker
+
+type Share struct {
+
+	// Session is the session the job was issued to
+	Session uint64
+
+	// JobID is the ID of the job that was solved
+	JobID uint64
+
+	// Version is the block version of the algorithm that was used
+	Version int32
+
+	// Nonce is the header nonce that solves the job
+	Nonce uint32
+}
+
+// ShareReply is the reply to a share submission
+
+type ShareReply struct {
+	Result ShareResult
+}
+
+// WorkerAuth returns the authenticator a worker sends on login, which is a HMAC-SHA256 of the challenge followed by the worker name keyed with the miner key. As each challenge is accepted only once, an authenticator that is overheard can't be used to log in again
+func WorkerAuth(
+	key, challenge []byte, worker string) []byte {
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write(challenge)
+	mac.Write([]byte(worker))
+	return mac.Sum(nil)
+}
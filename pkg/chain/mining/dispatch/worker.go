@@ -0,0 +1,270 @@
+package controller
+
+import (
+	"errors"
+	"net/rpc"
+	"sync"
+	"time"
+
+	"git.parallelcoin.io/dev/pod/pkg/util/cl"
+
+	blockchain "git.parallelcoin.io/dev/pod/pkg/chain"
+	"git.parallelcoin.io/dev/pod/pkg/chain/fork"
+	"git.parallelcoin.io/dev/pod/pkg/chain/wire"
+	"github.com/xtaci/kcp-go"
+)
+
+const (
+
+	// jobRefresh is how long a worker searches a job before it fetches the current one again, so that it picks up new blocks and transactions soon after the controller does
+	jobRefresh = 2 * time.Second
+
+	// noJobDelay is how long a worker waits before asking again when the controller has no job yet
+	noJobDelay = time.Second
+)
+
+// WorkerConfig is a descriptor containing the worker configuration.
+
+type WorkerConfig struct {
+
+	// Controller is the minerlistener address of the controller to take work from
+	Controller string
+
+	// Key is the miner key derived from the minerpass of the controller with fork.Argon2i. It is empty when the controller has no minerpass
+	Key []byte
+
+	// Name is the name the worker reports its shares under
+	Name string
+
+	// Schedule is the hard fork schedule of the network, which selects the hash algorithm of each block version
+	Schedule fork.Schedule
+
+	// Threads is the number of goroutines searching for shares. One is used when it is zero
+	Threads int
+}
+
+// Worker takes jobs from a miner controller over the job protocol, searches them for shares and submits the shares it finds
+
+type Worker struct {
+	cfg     WorkerConfig
+	client  *rpc.Client
+	session uint64
+}
+
+// NewWorker returns a new worker for the provided configuration. Use Run to connect to the controller and start mining.
+func NewWorker(
+	cfg *WorkerConfig) *Worker {
+
+	w := &Worker{cfg: *cfg}
+
+	if w.cfg.Threads < 1 {
+
+		w.cfg.Threads = 1
+	}
+	return w
+}
+
+// Run connects and logs in to the controller and mines its jobs until quit is closed or the connection fails. It returns nil when quit was closed
+func (w *Worker) Run(quit <-chan struct{}) error {
+
+	if err := w.login(); err != nil {
+
+		return err
+	}
+	defer w.client.Close()
+	defer w.client.Call("Jobs.Logout", &SessionArgs{Session: w.session},
+		&SessionArgs{})
+
+	log <- cl.Info{"worker", w.cfg.Name, "logged in to", w.cfg.Controller}
+
+	// The first thread to fail stops the others.
+	stop := make(chan struct{})
+	var once sync.Once
+	var runErr error
+	var wg sync.WaitGroup
+
+	for i := 0; i < w.cfg.Threads; i++ {
+
+		wg.Add(1)
+
+		go func(thread uint32) {
+
+			defer wg.Done()
+			err := w.mine(thread, quit, stop)
+
+			once.Do(func() {
+
+				runErr = err
+				close(stop)
+			})
+		}(uint32(i))
+	}
+	wg.Wait()
+	return runErr
+}
+
+// login connects to the controller and opens a session, signing a login challenge with the miner key
+func (w *Worker) login() error {
+
+	var block kcp.BlockCrypt
+
+	if len(w.cfg.Key) > 0 {
+
+		var err error
+		block, err = kcp.NewAESBlockCrypt(w.cfg.Key)
+
+		if err != nil {
+
+			return err
+		}
+	}
+	conn, err := kcp.DialWithOptions(w.cfg.Controller, block, 10, 3)
+
+	if err != nil {
+
+		return err
+	}
+	w.client = rpc.NewClient(conn)
+	var challenge ChallengeReply
+	err = w.client.Call("Jobs.Challenge", &ChallengeArgs{Worker: w.cfg.Name},
+		&challenge)
+
+	if err != nil {
+
+		w.client.Close()
+		return err
+	}
+	var reply LoginReply
+	err = w.client.Call("Jobs.Login", &LoginArgs{
+		Version:   ProtocolVersion,
+		Worker:    w.cfg.Name,
+		Challenge: challenge.Challenge,
+		Auth:      WorkerAuth(w.cfg.Key, challenge.Challenge, w.cfg.Name),
+	}, &reply)
+
+	if err != nil {
+
+		w.client.Close()
+		return err
+	}
+	w.session = reply.Session
+	return nil
+}
+
+// mine is a search thread of the worker. Each thread searches every Threads-th nonce starting at its own number, and the threads of a job use its algorithms in turn. It returns nil when quit or stop is closed
+func (w *Worker) mine(thread uint32, quit, stop <-chan struct{}) error {
+
+	threads := uint64(w.cfg.Threads)
+
+	// The search of a job continues where it left off when the job is fetched again.
+	var lastJob, next uint64
+
+	for {
+
+		var job Job
+		err := w.client.Call("Jobs.GetJob", &SessionArgs{Session: w.session}, &job)
+
+		if err != nil && err.Error() == ErrNoJob.Error() {
+
+			select {
+
+			case <-quit:
+				return nil
+			case <-stop:
+				return nil
+			case <-time.After(noJobDelay):
+			}
+			continue
+		}
+
+		if err != nil {
+
+			return err
+		}
+
+		if len(job.Algos) == 0 {
+
+			return errors.New("job offers no algorithms")
+		}
+
+		if job.ID != lastJob {
+
+			lastJob = job.ID
+			next = uint64(thread)
+		}
+
+		// Once the nonces of a job are exhausted the thread waits for the next job.
+		if next > uint64(maxNonce) {
+
+			select {
+
+			case <-quit:
+				return nil
+			case <-stop:
+				return nil
+			case <-time.After(jobRefresh):
+			}
+			continue
+		}
+		algo := job.Algos[(int(thread)+int(job.ID))%len(job.Algos)]
+		header := wire.BlockHeader{
+			Version:    algo.Version,
+			PrevBlock:  job.PrevBlock,
+			MerkleRoot: job.MerkleRoot,
+			Timestamp:  time.Unix(job.Timestamp, 0),
+			Bits:       algo.Bits,
+		}
+		target := blockchain.CompactToBig(algo.ShareBits)
+		deadline := time.Now().Add(jobRefresh)
+	search:
+
+		for i := 0; next <= uint64(maxNonce); next, i = next+threads, i+1 {
+
+			if i%16 == 0 {
+
+				select {
+
+				case <-quit:
+					return nil
+				case <-stop:
+					return nil
+				default:
+				}
+
+				if time.Now().After(deadline) {
+
+					break search
+				}
+			}
+			nonce := uint32(next)
+			header.Nonce = nonce
+			hash := header.BlockHashWithSchedule(w.cfg.Schedule, job.Height)
+
+			if blockchain.HashToBig(&hash).Cmp(target) > 0 {
+
+				continue
+			}
+			var reply ShareReply
+			err := w.client.Call("Jobs.Submit", &Share{
+				Session: w.session,
+				JobID:   job.ID,
+				Version: algo.Version,
+				Nonce:   nonce,
+			}, &reply)
+
+			if err != nil {
+
+				return err
+			}
+
+			switch reply.Result {
+
+			case ShareBlock:
+				log <- cl.Info{"worker", w.cfg.Name, "found block at height", job.Height}
+
+			case ShareStale:
+				break search
+			}
+		}
+	}
+}
@@ -10,7 +10,6 @@ import (
 
 	blockchain "git.parallelcoin.io/dev/pod/pkg/chain"
 	chaincfg "git.parallelcoin.io/dev/pod/pkg/chain/config"
-	"git.parallelcoin.io/dev/pod/pkg/chain/fork"
 	chainhash "git.parallelcoin.io/dev/pod/pkg/chain/hash"
 	txscript "git.parallelcoin.io/dev/pod/pkg/chain/tx/script"
 	"git.parallelcoin.io/dev/pod/pkg/chain/wire"
@@ -69,6 +68,8 @@ type txPrioItem struct {
 	tx       *util.Tx
 	fee      int64
 	priority float64
+
+	// feePerKB is the fee per kilobyte the transaction is ordered by.  It is raised above the fee rate of the transaction itself when transactions that depend on it pay for it, see setPackageFeeRates.
 	feePerKB int64
 
 	// dependsOn holds a map of transaction hashes which this one depends on.  It will only be set when the transaction references other transactions in the source pool and hence must come after them in a block.
@@ -301,6 +302,78 @@ func logSkippedDeps(
 	}
 }
 
+// setPackageFeeRates raises the fee per kilobyte of each transaction to the ancestor package fee rate of the best paying transaction that depends on it, which is the fee rate of that transaction together with all of the transactions in the source pool it depends on, directly or through others.  This lets a child pay for its parents, since the parents are ordered as if they paid the fee rate of their package and the child becomes ready once they are included.  It must be called before the dependsOn maps are consumed by the selection of transactions.
+func setPackageFeeRates(
+	items map[chainhash.Hash]*txPrioItem) {
+
+	for _, item := range items {
+
+		ancestors := prioItemAncestors(item, items)
+
+		if len(ancestors) == 0 {
+
+			continue
+		}
+		fee, size := item.fee, txVirtualSize(item.tx)
+
+		for _, ancestor := range ancestors {
+
+			fee += ancestor.fee
+			size += txVirtualSize(ancestor.tx)
+		}
+		packageFeePerKB := fee * 1000 / size
+
+		for _, ancestor := range ancestors {
+
+			if packageFeePerKB > ancestor.feePerKB {
+
+				ancestor.feePerKB = packageFeePerKB
+			}
+		}
+	}
+}
+
+// prioItemAncestors returns the items for all of the transactions the passed item depends on, directly or through other items.
+func prioItemAncestors(
+	item *txPrioItem, items map[chainhash.Hash]*txPrioItem) []*txPrioItem {
+
+	var ancestors []*txPrioItem
+	seen := make(map[chainhash.Hash]struct{})
+	queue := []*txPrioItem{item}
+
+	for len(queue) > 0 {
+
+		next := queue[0]
+		queue = queue[1:]
+
+		for hash := range next.dependsOn {
+
+			if _, ok := seen[hash]; ok {
+
+				continue
+			}
+			seen[hash] = struct{}{}
+			parent, exists := items[hash]
+
+			if !exists {
+
+				continue
+			}
+			ancestors = append(ancestors, parent)
+			queue = append(queue, parent)
+		}
+	}
+	return ancestors
+}
+
+// txVirtualSize returns the virtual size of a transaction, which is its weight scaled down by the witness scale factor and rounded up, as used for fee rates.
+func txVirtualSize(
+	tx *util.Tx) int64 {
+
+	return (blockchain.GetTransactionWeight(tx) +
+		blockchain.WitnessScaleFactor - 1) / blockchain.WitnessScaleFactor
+}
+
 // MinimumMedianTime returns the minimum allowed timestamp for a block building on the end of the provided best chain.  In particular, it is one second after the median timestamp of the last several blocks per the chain consensus rules.
 func MinimumMedianTime(
 	chainState *blockchain.BestState) time.Time {
@@ -334,6 +407,7 @@ type BlkTmplGenerator struct {
 	sigCache    *txscript.SigCache
 	hashCache   *txscript.HashCache
 	algo        string
+	scheduler   *AlgoScheduler
 }
 
 // NewBlkTmplGenerator returns a new block template generator for the given policy using transactions from the provided transaction source. The additional state-related fields are required in order to ensure the templates are built on top of the current best chain and adhere to the consensus rules.
@@ -353,11 +427,12 @@ func NewBlkTmplGenerator(
 		sigCache:    sigCache,
 		hashCache:   hashCache,
 		algo:        algo,
+		scheduler:   NewAlgoScheduler(chain, timeSource),
 	}
 }
 
 // NewBlockTemplate returns a new block template that is ready to be solved using the transactions from the passed transaction source pool and a coinbase that either pays to the passed address if it is not nil, or a coinbase that is redeemable by anyone if the passed address is nil.  The nil address functionality is useful since there are cases such as the getblocktemplate RPC where external mining software is responsible for creating their own coinbase which will replace the one generated for the block template.  Thus the need to have configured address can be avoided. The transactions selected and included are prioritized according to several factors.  First, each transaction has a priority calculated based on its value, age of inputs, and size.
-// Transactions which consist of larger amounts, older inputs, and small sizes have the highest priority.  Second, a fee per kilobyte is calculated for each transaction.  Transactions with a higher fee per kilobyte are preferred.  Finally, the block generation related policy settings are all taken into account.
+// Transactions which consist of larger amounts, older inputs, and small sizes have the highest priority.  Second, a fee per kilobyte is calculated for each transaction, which is raised to the fee rate of the package of a transaction that depends on it together with all of its ancestors when that is higher, so that children can pay for their parents.  Transactions with a higher fee per kilobyte are preferred.  Finally, the block generation related policy settings are all taken into account.
 // Transactions which only spend outputs from other transactions already in the block chain are immediately added to a priority queue which either prioritizes based on the priority (then fee per kilobyte) or the fee per kilobyte (then priority) depending on whether or not the BlockPrioritySize policy setting allots space for high-priority transactions.
 // Transactions which spend outputs from other transactions in the source pool are added to a dependency map so they can be added to the priority queue once the transactions they depend on have been included. Once the high-priority area (if configured) has been filled with transactions, or the priority falls below what is considered high-priority, the priority queue is updated to prioritize by fees per kilobyte (then priority).
 // When the fees per kilobyte drop below the TxMinFreeFee policy setting, the transaction will be skipped unless the BlockMinSize policy setting is nonzero, in which case the block will be filled with the low-fee/free transactions until the block size reaches that minimum size. Any transactions which would cause the block to exceed the BlockMaxSize policy setting, exceed the maximum allowed signature operations per block, or otherwise cause the block to be invalid are skipped.
@@ -386,11 +461,20 @@ func (g *BlkTmplGenerator) NewBlockTemplate(payToAddress util.Address, algo stri
 
 	if algo == "" {
 
-		algo = "random"
+		algo = AutoAlgo
 	}
 	h := g.BestSnapshot().Height + 1
-	vers := fork.GetAlgoVer(algo, h)
-	algo = fork.GetAlgoName(vers, h)
+	forks := g.chain.HardForks()
+	var vers int32
+
+	if algo == AutoAlgo {
+
+		vers = g.scheduler.Choice().Version
+	} else {
+
+		vers = forks.GetAlgoVer(algo, h)
+	}
+	algo = forks.GetAlgoName(vers, h)
 
 	// log <- cl.Info{"selected algo", fork.GetAlgoName(vers, h)}
 
@@ -428,6 +512,9 @@ func (g *BlkTmplGenerator) NewBlockTemplate(payToAddress util.Address, algo stri
 	// dependers is used to track transactions which depend on another transaction in the source pool.  This, in conjunction with the dependsOn map kept with each dependent transaction helps quickly determine which dependent transactions are now eligible for inclusion in the block once each transaction has been included.
 	dependers := make(map[chainhash.Hash]map[chainhash.Hash]*txPrioItem)
 
+	// prioItems holds the transactions that may be included in the block, so that their fee rates can be adjusted for the transactions that depend on them before they are queued.
+	prioItems := make(map[chainhash.Hash]*txPrioItem, len(sourceTxns))
+
 	// Create slices to hold the fees and number of signature operations for each of the selected transactions and add an entry for the coinbase.  This allows the code below to simply append details about a transaction as it is selected for inclusion in the final block. However, since the total fees aren't known yet, use a dummy value for the coinbase fee which will be updated later.
 	txFees := make([]int64, 0, len(sourceTxns))
 	txSigOpCosts := make([]int64, 0, len(sourceTxns))
@@ -522,14 +609,19 @@ mempoolLoop:
 		// Calculate the fee in Satoshi/kB.
 		prioItem.feePerKB = txDesc.FeePerKB
 		prioItem.fee = txDesc.Fee
-		// Add the transaction to the priority queue to mark it ready for inclusion in the block unless it has dependencies.
+		prioItems[*tx.Hash()] = prioItem
+		// Merge the referenced outputs from the input transactions to this transaction into the block utxo view.  This allows the code below to avoid a second lookup.
+		mergeUtxoView(blockUtxos, utxos)
+	}
+	// Order transactions by the fee rate of their ancestor packages, so that a transaction paying a high fee gets the transactions it depends on mined along with it, then add the transactions without dependencies to the priority queue to mark them ready for inclusion in the block.
+	setPackageFeeRates(prioItems)
+
+	for _, prioItem := range prioItems {
 
 		if prioItem.dependsOn == nil {
 
 			heap.Push(priorityQueue, prioItem)
 		}
-		// Merge the referenced outputs from the input transactions to this transaction into the block utxo view.  This allows the code below to avoid a second lookup.
-		mergeUtxoView(blockUtxos, utxos)
 	}
 	log <- cl.Tracec(func() string {
 
@@ -894,6 +986,12 @@ func (g *BlkTmplGenerator) UpdateExtraNonce(msgBlock *wire.MsgBlock, blockHeight
 	return nil
 }
 
+// AlgoScheduler returns the scheduler that chooses the algorithm of templates requested with AutoAlgo.
+func (g *BlkTmplGenerator) AlgoScheduler() *AlgoScheduler {
+
+	return g.scheduler
+}
+
 // BestSnapshot returns information about the current best chain block and related state as of the current point in time using the chain instance associated with the block template generator.  The returned state must be treated as immutable since it is shared by all callers. This function is safe for concurrent access.
 func (g *BlkTmplGenerator) BestSnapshot() *blockchain.BestState {
 
@@ -5,6 +5,8 @@ import (
 	"math/rand"
 	"testing"
 
+	chainhash "git.parallelcoin.io/dev/pod/pkg/chain/hash"
+	"git.parallelcoin.io/dev/pod/pkg/chain/wire"
 	"git.parallelcoin.io/dev/pod/pkg/util"
 )
 
@@ -125,3 +127,75 @@ func TestTxFeePrioHeap(
 		highest = prioItem
 	}
 }
+
+// TestSetPackageFeeRates ensures transactions are ordered by the fee rate of the best paying ancestor package they are part of, so that children pay for their parents.
+func TestSetPackageFeeRates(
+	t *testing.T) {
+
+	items := make(map[chainhash.Hash]*txPrioItem)
+	// newItem adds an item for a transaction paying the passed fee that spends an output of each of the passed parents.
+	newItem := func(fee int64, parents ...*txPrioItem) *txPrioItem {
+
+		msgTx := wire.NewMsgTx(wire.TxVersion)
+		msgTx.LockTime = uint32(len(items))
+		item := &txPrioItem{fee: fee}
+
+		for _, parent := range parents {
+
+			hash := *parent.tx.Hash()
+			msgTx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&hash, 0), nil, nil))
+
+			if item.dependsOn == nil {
+
+				item.dependsOn = make(map[chainhash.Hash]struct{})
+			}
+			item.dependsOn[hash] = struct{}{}
+		}
+
+		if len(parents) == 0 {
+
+			msgTx.AddTxIn(wire.NewTxIn(&wire.OutPoint{}, nil, nil))
+		}
+		msgTx.AddTxOut(wire.NewTxOut(1, []byte{0x51}))
+		item.tx = util.NewTx(msgTx)
+		item.feePerKB = fee * 1000 / txVirtualSize(item.tx)
+		items[*item.tx.Hash()] = item
+		return item
+	}
+	// A free parent with a child paying a high fee, and a grandchild that pays less than the child.
+	parent := newItem(0)
+	child := newItem(20000, parent)
+	grandchild := newItem(100, child)
+	// A parent that pays more than its child keeps its own fee rate.
+	richParent := newItem(50000)
+	poorChild := newItem(0, richParent)
+	lone := newItem(1000)
+	want := map[*txPrioItem]int64{
+		parent:     20000 * 1000 / (txVirtualSize(parent.tx) + txVirtualSize(child.tx)),
+		child:      child.feePerKB,
+		grandchild: grandchild.feePerKB,
+		richParent: richParent.feePerKB,
+		poorChild:  poorChild.feePerKB,
+		lone:       lone.feePerKB,
+	}
+	setPackageFeeRates(items)
+
+	for item, feePerKB := range want {
+
+		if item.feePerKB != feePerKB {
+
+			t.Errorf("transaction paying %d: got fee rate %d, want %d",
+				item.fee, item.feePerKB, feePerKB)
+		}
+	}
+	// Ordering by fee now picks the free parent before a transaction paying less than its package.
+	pq := newTxPriorityQueue(2, true)
+	heap.Push(pq, lone)
+	heap.Push(pq, parent)
+
+	if next := heap.Pop(pq).(*txPrioItem); next != parent {
+
+		t.Errorf("free parent of a child paying a high fee is not " +
+			"selected first")
+	}
+}
@@ -5,7 +5,6 @@ import (
 	"time"
 
 	chaincfg "git.parallelcoin.io/dev/pod/pkg/chain/config"
-	"git.parallelcoin.io/dev/pod/pkg/chain/fork"
 	chainhash "git.parallelcoin.io/dev/pod/pkg/chain/hash"
 	database "git.parallelcoin.io/dev/pod/pkg/db"
 	"git.parallelcoin.io/dev/pod/pkg/util"
@@ -24,6 +23,9 @@ const (
 	// BFNoPoWCheck may be set to indicate the proof of work check which ensures a block hashes to a value less than the required target will not be performed.
 	BFNoPoWCheck
 
+	// BFAssumeValid may be set to indicate the block is known to be an ancestor of the assumed valid block, so its transaction scripts will not be validated when it is connected.  This is primarily used for headers-first mode once the headers up to the assumed valid block have been verified.
+	BFAssumeValid
+
 	// BFNone is a convenience value to specifically indicate no flags.
 	BFNone BehaviorFlags = 0
 )
@@ -40,6 +42,26 @@ func (
 	bool,
 	error,
 
+) {
+
+	start := time.Now()
+	isMainChain, isOrphan, err := b.processBlock(block, flags, height)
+	observeBlockValidation(time.Since(start), isMainChain, isOrphan, err)
+	return isMainChain, isOrphan, err
+}
+
+// processBlock does the work of ProcessBlock, which times it for the block validation metrics.
+func (
+	b *BlockChain,
+) processBlock(
+	block *util.Block,
+	flags BehaviorFlags,
+	height int32,
+) (
+	bool,
+	bool,
+	error,
+
 ) {
 
 	blockHeight := height
@@ -53,11 +75,12 @@ func (
 	defer b.chainLock.Unlock()
 	fastAdd := flags&BFFastAdd == BFFastAdd
 	blockHash := block.Hash()
-	hf := fork.GetCurrent(height)
+	forks := b.HardForks()
+	hf := forks.GetCurrent(height)
 
 	blockHashWithAlgo := func() string {
 
-		return block.MsgBlock().BlockHashWithAlgos(height).String()
+		return block.MsgBlock().Header.BlockHashWithSchedule(forks, height).String()
 	}
 
 	log <- cl.Tracec(func() string {
@@ -107,7 +130,7 @@ func (
 
 	// Perform preliminary sanity checks on the block and its transactions.
 	var DoNotCheckPow bool
-	pl := fork.GetMinDiff(fork.GetAlgoName(algo, height), height)
+	pl := forks.GetMinDiff(forks.GetAlgoName(algo, height), height)
 	ph := &block.MsgBlock().Header.PrevBlock
 	pn := b.Index.LookupNode(ph)
 
@@ -117,14 +140,14 @@ func (
 
 		DoNotCheckPow = true
 	}
-	pb := pn.GetLastWithAlgo(algo)
+	pb := pn.GetLastWithAlgo(algo, forks)
 
 	if pb == nil {
 
 		pl = &chaincfg.AllOnes
 		DoNotCheckPow = true
 	}
-	err = checkBlockSanity(block, pl, b.timeSource, flags, DoNotCheckPow, height)
+	err = checkBlockSanity(block, pl, b.timeSource, flags, DoNotCheckPow, height, forks)
 
 	if err != nil {
 
@@ -210,14 +233,16 @@ func (
 		return false, false, err
 	}
 
-	log <- cl.Debugf{
-
-		"accepted block %d %v %s ",
-		blockHeight,
-		blockHashWithAlgo(),
+	// The hash with the algorithm of the block is only computed when debug logging is enabled, since it is as expensive as checking the proof of work.
+	log <- cl.Debugc(func() string {
 
-		fork.GetAlgoName(block.MsgBlock().Header.Version, blockHeight),
-	}
+		return fmt.Sprintf(
+			"accepted block %d %v %s ",
+			blockHeight,
+			blockHashWithAlgo(),
+			forks.GetAlgoName(block.MsgBlock().Header.Version, blockHeight),
+		)
+	})
 	return isMainChain, false, nil
 }
 
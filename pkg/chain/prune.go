@@ -0,0 +1,119 @@
+package blockchain
+
+import (
+	"sort"
+
+	cl "git.parallelcoin.io/dev/pod/pkg/util/cl"
+)
+
+const (
+
+	// MinBlocksToKeep is the number of blocks below the tip of the main chain that are never pruned, so that reorganizations that deep can still disconnect blocks and peers can still be served recent blocks.
+	MinBlocksToKeep = 288
+
+	// pruneInterval is how many blocks are connected to the main chain between attempts to prune the oldest blocks, so that the sizes of the block files are not totalled for every block.
+	pruneInterval = 10
+)
+
+// initPruneState loads whether blocks have been pruned from the database and finds the height of the oldest block of the main chain that is still stored.
+// This function MUST be called with the chain state lock held (for writes) or before the chain is used.
+func (b *BlockChain) initPruneState() error {
+
+	pruned, err := b.db.BeenPruned()
+
+	if err != nil {
+
+		return err
+	}
+
+	if !pruned {
+
+		return nil
+	}
+
+	b.pruned = true
+
+	// The blocks of the main chain are stored in order of height, so the pruned blocks are all below the oldest one still stored.
+	tipHeight := b.bestChain.Height()
+	b.pruneHeight = int32(sort.Search(int(tipHeight)+1, func(height int) bool {
+
+		node := b.bestChain.NodeByHeight(int32(height))
+		return b.Index.NodeStatus(node).HaveData()
+	}))
+
+	log <- cl.Infof{
+
+		"blocks have been pruned, the oldest block stored is at height %d",
+		b.pruneHeight,
+	}
+
+	return nil
+}
+
+// maybePrune deletes the oldest blocks once the stored blocks take up more than the prune target, keeping at least MinBlocksToKeep blocks below the tip of the main chain.  Blocks aren't pruned while the blocks before a loaded utxo snapshot are validated, as they are needed for it.  Failures are logged rather than returned since the block that triggered the pruning is already connected.
+// This function MUST be called with the chain state lock held (for writes).
+func (b *BlockChain) maybePrune() {
+
+	tipHeight := b.bestChain.Height()
+
+	if b.pruneTarget == 0 || b.snapshot != nil || tipHeight < MinBlocksToKeep ||
+		tipHeight%pruneInterval != 0 {
+
+		return
+	}
+
+	keep := b.bestChain.NodeByHeight(tipHeight - MinBlocksToKeep)
+	pruned, err := b.db.PruneBlocks(b.pruneTarget, &keep.hash)
+
+	if err != nil {
+
+		log <- cl.Warn{"unable to prune blocks:", err}
+
+		return
+	}
+
+	if len(pruned) == 0 {
+
+		return
+	}
+
+	// The pruned blocks are headers only from now on.
+	for i := range pruned {
+
+		node := b.Index.LookupNode(&pruned[i])
+
+		if node == nil {
+
+			continue
+		}
+
+		b.Index.UnsetStatusFlags(node, statusDataStored)
+
+		if b.bestChain.Contains(node) && node.height >= b.pruneHeight {
+
+			b.pruneHeight = node.height + 1
+		}
+	}
+
+	b.pruned = true
+
+	if err := b.Index.flushToDB(); err != nil {
+
+		log <- cl.Warn{"unable to store the status of the pruned blocks:", err}
+	}
+
+	log <- cl.Debugf{
+
+		"pruned %d blocks, the oldest block stored is at height %d",
+		len(pruned), b.pruneHeight,
+	}
+}
+
+// PruneState returns whether blocks have been pruned and the height of the oldest block of the main chain that is still stored.  This function is safe for concurrent access.
+func (b *BlockChain) PruneState() (bool, int32) {
+
+	b.chainLock.RLock()
+	defer b.chainLock.RUnlock()
+
+	return b.pruned, b.pruneHeight
+}
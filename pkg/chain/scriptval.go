@@ -31,6 +31,7 @@ type txValidator struct {
 	flags        txscript.ScriptFlags
 	sigCache     *txscript.SigCache
 	hashCache    *txscript.HashCache
+	threads      int
 }
 
 // sendResult sends the result of a script pair validation on the internal result channel while respecting the quit channel.  This allows orderly shutdown when the validation process is aborted early due to a validation error in one of the other goroutines.
@@ -133,8 +134,13 @@ func (v *txValidator) Validate(items []*txValidateItem) error {
 		return nil
 	}
 
-	// Limit the number of goroutines to do script validation based on the number of processor cores.  This helps ensure the system stays reasonably responsive under heavy load.
-	maxGoRoutines := runtime.NumCPU() * 3
+	// Limit the number of goroutines to do script validation to the configured number, or else based on the number of processor cores.  This helps ensure the system stays reasonably responsive under heavy load.
+	maxGoRoutines := v.threads
+
+	if maxGoRoutines <= 0 {
+
+		maxGoRoutines = runtime.NumCPU() * 3
+	}
 
 	if maxGoRoutines <= 0 {
 
@@ -197,7 +203,8 @@ func (v *txValidator) Validate(items []*txValidateItem) error {
 func newTxValidator(
 	utxoView *UtxoViewpoint, flags txscript.ScriptFlags,
 
-	sigCache *txscript.SigCache, hashCache *txscript.HashCache) *txValidator {
+	sigCache *txscript.SigCache, hashCache *txscript.HashCache,
+	threads int) *txValidator {
 
 	return &txValidator{
 
@@ -208,6 +215,7 @@ func newTxValidator(
 		sigCache:     sigCache,
 		hashCache:    hashCache,
 		flags:        flags,
+		threads:      threads,
 	}
 
 }
@@ -264,7 +272,7 @@ func ValidateTransactionScripts(
 	}
 
 	// Validate all of the inputs.
-	validator := newTxValidator(utxoView, flags, sigCache, hashCache)
+	validator := newTxValidator(utxoView, flags, sigCache, hashCache, 0)
 	return validator.Validate(txValItems)
 }
 
@@ -273,7 +281,7 @@ func checkBlockScripts(
 	block *util.Block, utxoView *UtxoViewpoint,
 	scriptFlags txscript.ScriptFlags, sigCache *txscript.SigCache,
 
-	hashCache *txscript.HashCache) error {
+	hashCache *txscript.HashCache, threads int) error {
 
 	// First determine if segwit is active according to the scriptFlags. If it isn't then we don't need to interact with the HashCache.
 	segwitActive := scriptFlags&txscript.ScriptVerifyWitness == txscript.ScriptVerifyWitness
@@ -339,7 +347,8 @@ func checkBlockScripts(
 	}
 
 	// Validate all of the inputs.
-	validator := newTxValidator(utxoView, scriptFlags, sigCache, hashCache)
+	validator := newTxValidator(utxoView, scriptFlags, sigCache, hashCache,
+		threads)
 	start := time.Now()
 
 	if err := validator.Validate(txValItems); err != nil {
@@ -46,7 +46,7 @@ func TestCheckBlockScripts(
 	}
 
 	scriptFlags := txscript.ScriptBip16
-	err = checkBlockScripts(blocks[0], view, scriptFlags, nil, nil)
+	err = checkBlockScripts(blocks[0], view, scriptFlags, nil, nil, 0)
 
 	if err != nil {
 
@@ -32,8 +32,9 @@ func newBlockProgressLogger(
 	}
 }
 
-// LogBlockHeight logs a new block height as an information message to show progress to the user. In order to prevent spam, it limits logging to one message every 10 seconds with duration and totals included.
-func (b *blockProgressLogger) LogBlockHeight(block *util.Block) {
+// LogBlockHeight logs a new block height as an information message to show progress to the user. In order to prevent spam, it limits logging to one message every 10 seconds with duration and totals included.  When the target height is above the block height the number of blocks left and an estimate of the time to reach it at the current rate are included as well.
+func (b *blockProgressLogger) LogBlockHeight(
+	block *util.Block, targetHeight int32) {
 
 	b.Lock()
 	defer b.Unlock()
@@ -65,7 +66,7 @@ func (b *blockProgressLogger) LogBlockHeight(block *util.Block) {
 		txStr = "transaction "
 	}
 	b.subsystemLogger.Ch <- cl.Infof{
-		"%s %6d %s in the last %s (%6d %s, height %8d, %s)",
+		"%s %6d %s in the last %s (%6d %s, height %8d, %s)%s",
 		b.progressAction,
 		b.receivedLogBlocks,
 		blockStr,
@@ -73,6 +74,8 @@ func (b *blockProgressLogger) LogBlockHeight(block *util.Block) {
 		b.receivedLogTx,
 		txStr, block.Height(),
 		block.MsgBlock().Header.Timestamp,
+		remainingEstimate(block.Height(), targetHeight, b.receivedLogBlocks,
+			duration),
 	}
 	b.receivedLogBlocks = 0
 	b.receivedLogTx = 0
@@ -82,3 +85,19 @@ func (b *blockProgressLogger) SetLastLogTime(time time.Time) {
 
 	b.lastBlockLogTime = time
 }
+
+// remainingEstimate returns the number of blocks left to reach the target height and the time it takes to process them at the rate of blocks processed over the duration, or an empty string when the target height is already reached.
+func remainingEstimate(
+	height, targetHeight int32, blocks int64, duration time.Duration) string {
+
+	remaining := int64(targetHeight) - int64(height)
+
+	if remaining <= 0 || blocks <= 0 || duration <= 0 {
+
+		return ""
+	}
+
+	eta := time.Duration(remaining) * duration / time.Duration(blocks)
+	return fmt.Sprintf(", %d blocks left, about %s to go", remaining,
+		eta.Round(time.Second))
+}
@@ -0,0 +1,379 @@
+package netsync
+
+import (
+	"sync/atomic"
+	"time"
+
+	blockchain "git.parallelcoin.io/dev/pod/pkg/chain"
+	chainhash "git.parallelcoin.io/dev/pod/pkg/chain/hash"
+	"git.parallelcoin.io/dev/pod/pkg/chain/wire"
+	peerpkg "git.parallelcoin.io/dev/pod/pkg/peer"
+	"git.parallelcoin.io/dev/pod/pkg/util"
+	cl "git.parallelcoin.io/dev/pod/pkg/util/cl"
+)
+
+// maxHighBandwidthPeers is the maximum number of peers that are asked to announce new blocks by sending the compact block directly (high-bandwidth mode).  These are the peers that most recently were the first to deliver a new best block.
+const maxHighBandwidthPeers = 3
+
+// blockTxnMsg packages a bitcoin blocktxn message and the peer it came from together so the block handler has access to that information.
+
+type blockTxnMsg struct {
+	blockTxn *wire.MsgBlockTxn
+	peer     *peerpkg.Peer
+	reply    chan struct{}
+}
+
+// cmpctBlockMsg packages a bitcoin cmpctblock message and the peer it came from together so the block handler has access to that information.
+
+type cmpctBlockMsg struct {
+	cmpctBlock *wire.MsgCmpctBlock
+	peer       *peerpkg.Peer
+	reply      chan struct{}
+}
+
+// partialBlock is a block that is being rebuilt from a compact block and is waiting for the transactions that were not found in the memory pool.
+
+type partialBlock struct {
+	hash     chainhash.Hash
+	header   wire.BlockHeader
+	txs      []*wire.MsgTx
+	missing  []uint32
+	received time.Time
+}
+
+// QueueBlockTxn adds the passed blocktxn message and peer to the block handling queue. Responds to the done channel argument after the message is processed.
+func (
+	sm *SyncManager,
+) QueueBlockTxn(
+	blockTxn *wire.MsgBlockTxn, peer *peerpkg.Peer, done chan struct{}) {
+
+	// Don't accept more blocks if we're shutting down.
+
+	if atomic.LoadInt32(&sm.shutdown) != 0 {
+
+		done <- struct{}{}
+		return
+	}
+	sm.msgChan <- &blockTxnMsg{blockTxn: blockTxn, peer: peer, reply: done}
+}
+
+// QueueCmpctBlock adds the passed compact block message and peer to the block handling queue. Responds to the done channel argument after the compact block is processed.
+func (
+	sm *SyncManager,
+) QueueCmpctBlock(
+	cmpctBlock *wire.MsgCmpctBlock, peer *peerpkg.Peer, done chan struct{}) {
+
+	// Don't accept more blocks if we're shutting down.
+
+	if atomic.LoadInt32(&sm.shutdown) != 0 {
+
+		done <- struct{}{}
+		return
+	}
+	sm.msgChan <- &cmpctBlockMsg{cmpctBlock: cmpctBlock, peer: peer, reply: done}
+}
+
+// handleBlockTxnMsg handles the transactions of a compact block that were requested from a peer as they were not in the memory pool, and processes the block once it is complete.
+func (
+	sm *SyncManager,
+) handleBlockTxnMsg(
+	bmsg *blockTxnMsg) {
+
+	peer := bmsg.peer
+	state, exists := sm.peerStates[peer]
+
+	if !exists {
+
+		log <- cl.Warn{"received blocktxn message from unknown peer", peer}
+
+		return
+	}
+
+	partial := state.pendingCmpct
+
+	if partial == nil || partial.hash != bmsg.blockTxn.BlockHash {
+
+		log <- cl.Debugf{
+
+			"got unrequested transactions for block %v from %s",
+			bmsg.blockTxn.BlockHash, peer,
+		}
+		return
+	}
+	state.pendingCmpct = nil
+
+	// The peer must send exactly the requested transactions, otherwise fall back to requesting the whole block.
+
+	if len(bmsg.blockTxn.Transactions) != len(partial.missing) {
+
+		log <- cl.Debugf{
+
+			"got %d transactions for block %v from %s, requested %d",
+			len(bmsg.blockTxn.Transactions), partial.hash, peer,
+			len(partial.missing),
+		}
+		sm.requestFullBlock(peer, state, &partial.hash)
+		return
+	}
+
+	for i, index := range partial.missing {
+
+		partial.txs[index] = bmsg.blockTxn.Transactions[i]
+	}
+	sm.completeCmpctBlock(peer, state, partial)
+}
+
+// handleCmpctBlockMsg handles compact block messages from all peers.  The block is rebuilt from the prefilled transactions and the transactions in the memory pool matching its short transaction ids, and the transactions that are still missing are requested from the peer.  Compact blocks are only used for new blocks once the chain is current, the whole block is requested instead when it can't be rebuilt.
+func (
+	sm *SyncManager,
+) handleCmpctBlockMsg(
+	cmsg *cmpctBlockMsg) {
+
+	peer := cmsg.peer
+	state, exists := sm.peerStates[peer]
+
+	if !exists {
+
+		log <- cl.Warn{"received cmpctblock message from unknown peer", peer}
+
+		return
+	}
+
+	cmpctBlock := cmsg.cmpctBlock
+	blockHash := cmpctBlock.Header.BlockHash()
+
+	// Nothing to do when the block is already known.
+	haveBlock, err := sm.chain.HaveBlock(&blockHash)
+
+	if err != nil || haveBlock {
+
+		delete(state.requestedBlocks, blockHash)
+		delete(sm.requestedBlocks, blockHash)
+		return
+	}
+
+	// The memory pool only holds the transactions that are likely to be in a block extending the chain tip, so the whole block is requested while syncing when it was asked for, or when the parent of the block is not known yet.
+
+	if sm.headersFirstMode || !sm.current() {
+
+		if _, exists := state.requestedBlocks[blockHash]; exists {
+
+			sm.requestFullBlock(peer, state, &blockHash)
+		}
+		return
+	}
+
+	haveParent, err := sm.chain.HaveBlock(&cmpctBlock.Header.PrevBlock)
+
+	if err != nil || !haveParent {
+
+		sm.requestFullBlock(peer, state, &blockHash)
+		return
+	}
+
+	txCount := cmpctBlock.TxCount()
+
+	if txCount == 0 {
+
+		log <- cl.Warnf{
+
+			"got compact block %v without transactions from %s -- disconnecting",
+			blockHash, peer,
+		}
+		peer.Disconnect()
+		return
+	}
+
+	// Place the prefilled transactions at their indexes and assign the short ids to the remaining indexes in order.
+	txs := make([]*wire.MsgTx, txCount)
+
+	for _, prefilled := range cmpctBlock.PrefilledTxs {
+
+		if int(prefilled.Index) >= txCount {
+
+			log <- cl.Warnf{
+
+				"got compact block %v with prefilled transaction index %d " +
+					"out of range from %s -- disconnecting",
+				blockHash, prefilled.Index, peer,
+			}
+			peer.Disconnect()
+			return
+		}
+		txs[prefilled.Index] = prefilled.Tx
+	}
+
+	shortIDIndexes := make(map[uint64]int, len(cmpctBlock.ShortIDs))
+	index := 0
+
+	for _, shortID := range cmpctBlock.ShortIDs {
+
+		for txs[index] != nil {
+
+			index++
+		}
+
+		// Two transactions of the block with the same short id can't be told apart.
+
+		if _, exists := shortIDIndexes[shortID]; exists {
+
+			sm.requestFullBlock(peer, state, &blockHash)
+			return
+		}
+		shortIDIndexes[shortID] = index
+		index++
+	}
+
+	// Fill in the transactions from the memory pool.  When more than one transaction of the pool has the same short id the right one is not known, so it is requested from the peer.
+	key := cmpctBlock.ShortIDKey()
+	collisions := make(map[int]struct{})
+
+	for _, txDesc := range sm.txMemPool.TxDescs() {
+
+		index, exists := shortIDIndexes[wire.ShortTxID(&key, txDesc.Tx.Hash())]
+
+		if !exists {
+
+			continue
+		}
+
+		if txs[index] != nil {
+
+			collisions[index] = struct{}{}
+			continue
+		}
+		txs[index] = txDesc.Tx.MsgTx()
+	}
+
+	for index := range collisions {
+
+		txs[index] = nil
+	}
+
+	partial := &partialBlock{
+		hash:     blockHash,
+		header:   cmpctBlock.Header,
+		txs:      txs,
+		received: time.Now(),
+	}
+
+	for index, tx := range txs {
+
+		if tx == nil {
+
+			partial.missing = append(partial.missing, uint32(index))
+		}
+	}
+
+	if len(partial.missing) == 0 {
+
+		sm.completeCmpctBlock(peer, state, partial)
+		return
+	}
+
+	// Request the missing transactions from the peer.  Only one compact block per peer is rebuilt at a time, a newer one replaces the pending one.
+	state.pendingCmpct = partial
+
+	log <- cl.Debugf{
+
+		"requesting %d of %d transactions of compact block %v from %s",
+		len(partial.missing), txCount, blockHash, peer,
+	}
+	peer.QueueMessage(wire.NewMsgGetBlockTxn(&blockHash, partial.missing), nil)
+}
+
+// completeCmpctBlock checks that the transactions of a rebuilt compact block match its merkle root and processes the block as if it was received whole from the peer.  A wrong transaction could have been picked from the memory pool for a short id, so the whole block is requested instead on a mismatch.
+func (
+	sm *SyncManager,
+) completeCmpctBlock(
+	peer *peerpkg.Peer, state *peerSyncState, partial *partialBlock) {
+
+	block := util.NewBlock(&wire.MsgBlock{
+		Header:       partial.header,
+		Transactions: partial.txs,
+	})
+	merkles := blockchain.BuildMerkleTreeStore(block.Transactions(), false)
+
+	if !merkles[len(merkles)-1].IsEqual(&partial.header.MerkleRoot) {
+
+		log <- cl.Debugf{
+
+			"rebuilt compact block %v from %s does not match its merkle root",
+			partial.hash, peer,
+		}
+		sm.requestFullBlock(peer, state, &partial.hash)
+		return
+	}
+
+	peer.UpdateCmpctBlockStats(len(partial.missing),
+		time.Since(partial.received))
+
+	// The block may have been announced with the compact block without being requested, so it is marked as requested from the peer for handleBlockMsg.
+	state.requestedBlocks[partial.hash] = struct{}{}
+	sm.requestedBlocks[partial.hash] = struct{}{}
+	sm.limitMap(sm.requestedBlocks, maxRequestedBlocks)
+	sm.handleBlockMsg(&blockMsg{block: block, peer: peer})
+}
+
+// removeHighBandwidthPeer removes the passed peer from the peers that announce new blocks with compact blocks.
+func (
+	sm *SyncManager,
+) removeHighBandwidthPeer(
+	peer *peerpkg.Peer) {
+
+	for i, hbPeer := range sm.hbPeers {
+
+		if hbPeer == peer {
+
+			sm.hbPeers = append(sm.hbPeers[:i], sm.hbPeers[i+1:]...)
+			return
+		}
+	}
+}
+
+// requestFullBlock requests the whole block with the passed hash from the peer, for when a compact block can't be rebuilt.
+func (
+	sm *SyncManager,
+) requestFullBlock(
+	peer *peerpkg.Peer, state *peerSyncState, blockHash *chainhash.Hash) {
+
+	state.requestedBlocks[*blockHash] = struct{}{}
+	sm.requestedBlocks[*blockHash] = struct{}{}
+	sm.limitMap(sm.requestedBlocks, maxRequestedBlocks)
+	gdmsg := wire.NewMsgGetData()
+	gdmsg.AddInvVect(wire.NewInvVect(wire.InvTypeBlock, blockHash))
+	peer.QueueMessage(gdmsg, nil)
+}
+
+// updateHighBandwidthPeers moves the passed peer, which was the first to deliver a new best block, to the front of the peers that are asked to announce new blocks with compact blocks.  When the peer is new to the list it is asked to do so, and the peer that delivered a new best block first the longest time ago is asked to stop when there are too many.
+func (
+	sm *SyncManager,
+) updateHighBandwidthPeers(
+	peer *peerpkg.Peer) {
+
+	if peer.CmpctBlockVersion() != wire.CmpctBlockVersion {
+
+		return
+	}
+
+	for i, hbPeer := range sm.hbPeers {
+
+		if hbPeer == peer {
+
+			copy(sm.hbPeers[1:i+1], sm.hbPeers[:i])
+			sm.hbPeers[0] = peer
+			return
+		}
+	}
+
+	peer.QueueMessage(wire.NewMsgSendCmpct(true, wire.CmpctBlockVersion), nil)
+	sm.hbPeers = append([]*peerpkg.Peer{peer}, sm.hbPeers...)
+
+	if len(sm.hbPeers) > maxHighBandwidthPeers {
+
+		evicted := sm.hbPeers[maxHighBandwidthPeers]
+		sm.hbPeers = sm.hbPeers[:maxHighBandwidthPeers]
+		evicted.QueueMessage(wire.NewMsgSendCmpct(false,
+			wire.CmpctBlockVersion), nil)
+	}
+}
@@ -0,0 +1,505 @@
+package netsync
+
+import (
+	"container/list"
+	"io/ioutil"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"git.parallelcoin.io/dev/pod/cmd/node/mempool"
+	blockchain "git.parallelcoin.io/dev/pod/pkg/chain"
+	chaincfg "git.parallelcoin.io/dev/pod/pkg/chain/config"
+	chainhash "git.parallelcoin.io/dev/pod/pkg/chain/hash"
+	txscript "git.parallelcoin.io/dev/pod/pkg/chain/tx/script"
+	"git.parallelcoin.io/dev/pod/pkg/chain/wire"
+	database "git.parallelcoin.io/dev/pod/pkg/db"
+	_ "git.parallelcoin.io/dev/pod/pkg/db/ffldb"
+	peerpkg "git.parallelcoin.io/dev/pod/pkg/peer"
+	"git.parallelcoin.io/dev/pod/pkg/util"
+)
+
+// fixedTimeSource is a blockchain.MedianTimeSource that always returns the same time, so a chain holding only the genesis block is current.
+
+type fixedTimeSource struct {
+	now time.Time
+}
+
+func (s fixedTimeSource) AdjustedTime() time.Time         { return s.now }
+func (s fixedTimeSource) AddTimeSample(string, time.Time) {}
+func (s fixedTimeSource) Offset() time.Duration           { return 0 }
+
+// pipeConn is one end of a net.Pipe with TCP addresses, which the peers need to connect.
+
+type pipeConn struct {
+	net.Conn
+	laddr, raddr *net.TCPAddr
+}
+
+func (c pipeConn) LocalAddr() net.Addr  { return c.laddr }
+func (c pipeConn) RemoteAddr() net.Addr { return c.raddr }
+
+// cmpctBlockHarness holds a sync manager with a chain holding only the genesis block and a memory pool, and a peer connected to a remote end that reports the messages it gets from the sync manager.
+
+type cmpctBlockHarness struct {
+	t        *testing.T
+	sm       *SyncManager
+	peer     *peerpkg.Peer
+	msgs     chan wire.Message
+	funding  *wire.MsgTx
+	teardown func()
+}
+
+// newCmpctBlockHarness returns a new harness for testing the handling of compact blocks.
+func newCmpctBlockHarness(
+	t *testing.T) *cmpctBlockHarness {
+
+	params := &chaincfg.RegressionNetParams
+	dbPath, err := ioutil.TempDir("", "cmpctblock")
+
+	if err != nil {
+
+		t.Fatalf("unable to create temporary directory: %v", err)
+	}
+
+	db, err := database.Create("ffldb", dbPath, params.Net)
+
+	if err != nil {
+
+		os.RemoveAll(dbPath)
+		t.Fatalf("unable to create database: %v", err)
+	}
+
+	genesisTime := params.GenesisBlock.Header.Timestamp
+	chain, err := blockchain.New(&blockchain.Config{
+
+		DB:          db,
+		ChainParams: params,
+		TimeSource:  fixedTimeSource{genesisTime},
+	})
+
+	if err != nil {
+
+		db.Close()
+		os.RemoveAll(dbPath)
+		t.Fatalf("unable to create chain: %v", err)
+	}
+
+	// The transactions of the memory pool spend the outputs of a funding transaction that anyone can spend.
+	funding := wire.NewMsgTx(wire.TxVersion)
+	funding.AddTxIn(wire.NewTxIn(&wire.OutPoint{Hash: chainhash.Hash{1}}, nil,
+		nil))
+
+	for i := 0; i < 4; i++ {
+
+		funding.AddTxOut(wire.NewTxOut(1e8, []byte{txscript.OP_TRUE}))
+	}
+
+	txPool := mempool.New(&mempool.Config{
+
+		Policy: mempool.Policy{
+
+			MaxTxVersion:         wire.TxVersion,
+			DisableRelayPriority: true,
+			AcceptNonStd:         true,
+			MaxOrphanTxs:         10,
+			MaxOrphanTxSize:      100000,
+			MaxSigOpCostPerTx:    blockchain.MaxBlockSigOpsCost,
+			MinRelayTxFee:        mempool.DefaultMinRelayTxFee,
+		},
+		ChainParams: params,
+		FetchUtxoView: func(*util.Tx) (*blockchain.UtxoViewpoint, error) {
+
+			view := blockchain.NewUtxoViewpoint()
+			view.AddTxOuts(util.NewTx(funding), 1)
+			return view, nil
+		},
+		BestHeight: func() int32 {
+
+			return 1
+		},
+		MedianTimePast: func() time.Time {
+
+			return genesisTime
+		},
+		CalcSequenceLock: func(*util.Tx,
+
+			*blockchain.UtxoViewpoint) (*blockchain.SequenceLock, error) {
+
+			return &blockchain.SequenceLock{Seconds: -1, BlockHeight: -1}, nil
+		},
+		IsDeploymentActive: func(uint32) (bool, error) {
+
+			return false, nil
+		},
+	})
+
+	// Connect the peer the compact blocks come from to a remote end that completes the version handshake and reports the messages it gets.
+	localAddr := &net.TCPAddr{IP: net.ParseIP("10.0.0.2"), Port: 11047}
+	remoteAddr := &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 11047}
+	local, remote := net.Pipe()
+	msgs := make(chan wire.Message, 10)
+	verack := make(chan struct{}, 1)
+
+	go func() {
+
+		for {
+
+			msg, _, err := wire.ReadMessage(remote, wire.ProtocolVersion,
+				params.Net)
+
+			if err != nil {
+
+				return
+			}
+
+			switch msg := msg.(type) {
+
+			case *wire.MsgVersion:
+				wire.WriteMessage(remote, wire.NewMsgVersion(
+					wire.NewNetAddress(remoteAddr, 0),
+					wire.NewNetAddress(localAddr, 0), 1, 0),
+					wire.ProtocolVersion, params.Net)
+				wire.WriteMessage(remote, wire.NewMsgVerAck(),
+					wire.ProtocolVersion, params.Net)
+
+			case *wire.MsgVerAck:
+				verack <- struct{}{}
+
+			case *wire.MsgGetData, *wire.MsgGetBlockTxn, *wire.MsgReject:
+				msgs <- msg
+			}
+		}
+	}()
+
+	peer, err := peerpkg.NewOutboundPeer(&peerpkg.Config{
+
+		ChainParams: params,
+	}, remoteAddr.String())
+
+	if err != nil {
+
+		t.Fatalf("unable to create peer: %v", err)
+	}
+
+	peer.AssociateConnection(pipeConn{local, localAddr, remoteAddr})
+
+	select {
+
+	case <-verack:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the peer to connect")
+	}
+
+	sm := &SyncManager{
+
+		chain:           chain,
+		txMemPool:       txPool,
+		chainParams:     params,
+		rejectedTxns:    make(map[chainhash.Hash]struct{}),
+		requestedTxns:   make(map[chainhash.Hash]struct{}),
+		requestedBlocks: make(map[chainhash.Hash]struct{}),
+		peerStates:      make(map[*peerpkg.Peer]*peerSyncState),
+		headerList:      list.New(),
+	}
+
+	sm.peerStates[peer] = &peerSyncState{
+
+		requestedTxns:   make(map[chainhash.Hash]struct{}),
+		requestedBlocks: make(map[chainhash.Hash]struct{}),
+	}
+
+	return &cmpctBlockHarness{
+
+		t:       t,
+		sm:      sm,
+		peer:    peer,
+		msgs:    msgs,
+		funding: funding,
+		teardown: func() {
+
+			peer.Disconnect()
+			remote.Close()
+			db.Close()
+			os.RemoveAll(dbPath)
+		},
+	}
+}
+
+// state returns the sync state of the peer the compact blocks come from.
+func (h *cmpctBlockHarness) state() *peerSyncState {
+
+	return h.sm.peerStates[h.peer]
+}
+
+// stats returns the statistics of the peer the compact blocks come from.
+func (h *cmpctBlockHarness) stats() *peerpkg.StatsSnap {
+
+	return h.peer.StatsSnapshot()
+}
+
+// requested returns whether the block with the passed hash is requested from the peer.
+func (h *cmpctBlockHarness) requested(
+	hash *chainhash.Hash) bool {
+
+	_, exists := h.state().requestedBlocks[*hash]
+	return exists
+}
+
+// spend returns a transaction spending the output of the funding transaction with the passed index, adding it to the memory pool when inPool is set.
+func (h *cmpctBlockHarness) spend(
+	index uint32, inPool bool) *wire.MsgTx {
+
+	tx := wire.NewMsgTx(wire.TxVersion)
+	tx.AddTxIn(wire.NewTxIn(&wire.OutPoint{Hash: h.funding.TxHash(),
+		Index: index}, nil, nil))
+	tx.AddTxOut(wire.NewTxOut(1e8-1e5, []byte{txscript.OP_TRUE}))
+
+	if inPool {
+
+		_, _, err := h.sm.txMemPool.MaybeAcceptTransaction(util.NewTx(tx),
+			true, false)
+
+		if err != nil {
+
+			h.t.Fatalf("unable to add transaction to the memory pool: %v", err)
+		}
+	}
+
+	return tx
+}
+
+// block returns a block on top of the genesis block with a coinbase transaction and the passed transactions.
+func (h *cmpctBlockHarness) block(
+	txs ...*wire.MsgTx) *wire.MsgBlock {
+
+	coinbase := wire.NewMsgTx(wire.TxVersion)
+	coinbase.AddTxIn(wire.NewTxIn(&wire.OutPoint{Index: wire.MaxPrevOutIndex},
+		[]byte{0x51, 0x51}, nil))
+	coinbase.AddTxOut(wire.NewTxOut(1e8, []byte{txscript.OP_TRUE}))
+	params := h.sm.chainParams
+	block := &wire.MsgBlock{
+
+		Header: wire.BlockHeader{
+
+			Version:   2,
+			PrevBlock: h.sm.chain.BestSnapshot().Hash,
+			Timestamp: params.GenesisBlock.Header.Timestamp.Add(time.Minute),
+			Bits:      params.PowLimitBits,
+		},
+		Transactions: append([]*wire.MsgTx{coinbase}, txs...),
+	}
+
+	utilTxs := make([]*util.Tx, len(block.Transactions))
+
+	for i, tx := range block.Transactions {
+
+		utilTxs[i] = util.NewTx(tx)
+	}
+
+	merkles := blockchain.BuildMerkleTreeStore(utilTxs, false)
+	block.Header.MerkleRoot = *merkles[len(merkles)-1]
+	return block
+}
+
+// nextMessage returns the next message the remote end got from the sync manager.
+func (h *cmpctBlockHarness) nextMessage() wire.Message {
+
+	select {
+
+	case msg := <-h.msgs:
+		return msg
+
+	case <-time.After(5 * time.Second):
+		h.t.Fatal("timed out waiting for a message from the sync manager")
+	}
+
+	return nil
+}
+
+// expectGetData checks that the whole block with the passed hash is requested from the peer.
+func (h *cmpctBlockHarness) expectGetData(
+	name string, hash *chainhash.Hash) {
+
+	msg, ok := h.nextMessage().(*wire.MsgGetData)
+
+	if !ok || len(msg.InvList) != 1 || msg.InvList[0].Hash != *hash {
+
+		h.t.Fatalf("%s: got %v, want a getdata message for block %v", name,
+			msg, hash)
+	}
+
+	if !h.requested(hash) {
+
+		h.t.Fatalf("%s: block %v is not marked as requested", name, hash)
+	}
+}
+
+// expectProcessed checks that the rebuilt block with the passed hash was handed to the chain.  The test blocks are not mined, so the chain rejects them.
+func (h *cmpctBlockHarness) expectProcessed(
+	name string, hash *chainhash.Hash) {
+
+	msg, ok := h.nextMessage().(*wire.MsgReject)
+
+	if !ok || msg.Cmd != wire.CmdBlock || msg.Hash != *hash {
+
+		h.t.Fatalf("%s: got %v, want block %v to be processed", name, msg,
+			hash)
+	}
+
+	if h.state().pendingCmpct != nil || h.requested(hash) {
+
+		h.t.Fatalf("%s: block %v is still pending", name, hash)
+	}
+}
+
+// TestCmpctBlockFromMempool ensures a compact block whose transactions are all in the memory pool is rebuilt without requesting anything from the peer.
+func TestCmpctBlockFromMempool(
+	t *testing.T) {
+
+	h := newCmpctBlockHarness(t)
+	defer h.teardown()
+
+	block := h.block(h.spend(0, true), h.spend(1, true))
+	blockHash := block.BlockHash()
+	h.sm.handleCmpctBlockMsg(&cmpctBlockMsg{
+
+		cmpctBlock: wire.NewMsgCmpctBlock(block, 1),
+		peer:       h.peer,
+	})
+	h.expectProcessed("from mempool", &blockHash)
+
+	if stats := h.stats(); stats.CmpctBlocksReconstructed != 1 ||
+		stats.CmpctTxnRequested != 0 {
+
+		t.Fatalf("got %d blocks rebuilt from the mempool and %d "+
+			"transactions requested, want 1 and 0",
+			stats.CmpctBlocksReconstructed, stats.CmpctTxnRequested)
+	}
+}
+
+// TestCmpctBlockGetBlockTxn ensures the transactions of a compact block that are not in the memory pool are requested with getblocktxn, and the block is rebuilt with the ones sent back in blocktxn.
+func TestCmpctBlockGetBlockTxn(
+	t *testing.T) {
+
+	h := newCmpctBlockHarness(t)
+	defer h.teardown()
+
+	missing := h.spend(1, false)
+	block := h.block(h.spend(0, true), missing, h.spend(2, true))
+	blockHash := block.BlockHash()
+	h.sm.handleCmpctBlockMsg(&cmpctBlockMsg{
+
+		cmpctBlock: wire.NewMsgCmpctBlock(block, 2),
+		peer:       h.peer,
+	})
+
+	msg, ok := h.nextMessage().(*wire.MsgGetBlockTxn)
+
+	if !ok || msg.BlockHash != blockHash || len(msg.Indexes) != 1 ||
+		msg.Indexes[0] != 2 {
+
+		t.Fatalf("got %v, want a getblocktxn message for index 2 of %v", msg,
+			blockHash)
+	}
+
+	// Transactions for another block are ignored.
+	h.sm.handleBlockTxnMsg(&blockTxnMsg{
+
+		blockTxn: wire.NewMsgBlockTxn(&chainhash.Hash{1}, []*wire.MsgTx{missing}),
+		peer:     h.peer,
+	})
+
+	if h.state().pendingCmpct == nil {
+
+		t.Fatal("pending compact block dropped for transactions of another " +
+			"block")
+	}
+
+	h.sm.handleBlockTxnMsg(&blockTxnMsg{
+
+		blockTxn: wire.NewMsgBlockTxn(&blockHash, []*wire.MsgTx{missing}),
+		peer:     h.peer,
+	})
+	h.expectProcessed("getblocktxn", &blockHash)
+
+	if stats := h.stats(); stats.CmpctBlocksReconstructed != 0 ||
+		stats.CmpctTxnRequested != 1 {
+
+		t.Fatalf("got %d blocks rebuilt from the mempool and %d "+
+			"transactions requested, want 0 and 1",
+			stats.CmpctBlocksReconstructed, stats.CmpctTxnRequested)
+	}
+}
+
+// TestCmpctBlockFallbacks ensures the whole block is requested when a compact block can't be rebuilt: when its short ids collide, when the peer sends the wrong number of missing transactions, and when the rebuilt block doesn't match its merkle root.
+func TestCmpctBlockFallbacks(
+	t *testing.T) {
+
+	h := newCmpctBlockHarness(t)
+	defer h.teardown()
+
+	// Two transactions of the block with the same short id can't be told apart.
+	block := h.block(h.spend(0, true), h.spend(1, true))
+	blockHash := block.BlockHash()
+	cmpctBlock := wire.NewMsgCmpctBlock(block, 3)
+	cmpctBlock.ShortIDs[1] = cmpctBlock.ShortIDs[0]
+	h.sm.handleCmpctBlockMsg(&cmpctBlockMsg{
+
+		cmpctBlock: cmpctBlock,
+		peer:       h.peer,
+	})
+	h.expectGetData("short id collision", &blockHash)
+
+	// The peer must send exactly the missing transactions.
+	block = h.block(h.spend(2, false))
+	blockHash = block.BlockHash()
+	h.sm.handleCmpctBlockMsg(&cmpctBlockMsg{
+
+		cmpctBlock: wire.NewMsgCmpctBlock(block, 4),
+		peer:       h.peer,
+	})
+
+	if _, ok := h.nextMessage().(*wire.MsgGetBlockTxn); !ok {
+
+		t.Fatal("missing transaction not requested")
+	}
+
+	h.sm.handleBlockTxnMsg(&blockTxnMsg{
+
+		blockTxn: wire.NewMsgBlockTxn(&blockHash, nil),
+		peer:     h.peer,
+	})
+	h.expectGetData("wrong blocktxn", &blockHash)
+
+	// A transaction of the memory pool with the short id of a different transaction of the block leaves the rebuilt block with the wrong merkle root.
+	inBlock := h.spend(3, false)
+	block = h.block(inBlock)
+	blockHash = block.BlockHash()
+	cmpctBlock = wire.NewMsgCmpctBlock(block, 5)
+	key := cmpctBlock.ShortIDKey()
+	inPoolHash := h.funding.TxHash()
+
+	for _, txDesc := range h.sm.txMemPool.TxDescs() {
+
+		inPoolHash = *txDesc.Tx.Hash()
+		break
+	}
+
+	cmpctBlock.ShortIDs[0] = wire.ShortTxID(&key, &inPoolHash)
+	h.sm.handleCmpctBlockMsg(&cmpctBlockMsg{
+
+		cmpctBlock: cmpctBlock,
+		peer:       h.peer,
+	})
+	h.expectGetData("merkle mismatch", &blockHash)
+
+	if stats := h.stats(); stats.CmpctBlocksReconstructed != 0 ||
+		stats.CmpctTxnRequested != 0 {
+
+		t.Fatalf("got %d blocks rebuilt from the mempool and %d "+
+			"transactions requested, want none",
+			stats.CmpctBlocksReconstructed, stats.CmpctTxnRequested)
+	}
+}
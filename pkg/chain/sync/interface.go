@@ -27,6 +27,10 @@ type Config struct {
 	TxMemPool          *mempool.TxPool
 	ChainParams        *chaincfg.Params
 	DisableCheckpoints bool
+	SkipCheckpointPoW  bool
 	MaxPeers           int
 	FeeEstimator       *mempool.FeeEstimator
+
+	// RequestShutdown is called to stop the node when the blocks before a loaded utxo snapshot turn out to be invalid, so it stops serving a chain built on the snapshot.
+	RequestShutdown func()
 }
@@ -40,6 +40,7 @@ type SyncManager struct {
 	requestedBlocks map[chainhash.Hash]struct{}
 	syncPeer        *peerpkg.Peer
 	peerStates      map[*peerpkg.Peer]*peerSyncState
+	hbPeers         []*peerpkg.Peer
 
 	// The following fields are used for headers-first mode.
 	headersFirstMode bool
@@ -47,8 +48,16 @@ type SyncManager struct {
 	startHeader      *list.Element
 	nextCheckpoint   *chaincfg.Checkpoint
 
+	// The following fields are used to download the blocks up to the assumed valid block headers-first and to skip the proof of work check for blocks linked to a checkpoint.
+	assumeValid        *chaincfg.Checkpoint
+	disableCheckpoints bool
+	skipCheckpointPoW  bool
+
 	// An optional fee estimator.
 	feeEstimator *mempool.FeeEstimator
+
+	// requestShutdown stops the node when the blocks before a loaded utxo snapshot are invalid.
+	requestShutdown func()
 }
 
 // blockMsg packages a bitcoin block message and the peer it came from together so the block handler has access to that information.
@@ -117,6 +126,7 @@ type peerSyncState struct {
 	requestQueue    []*wire.InvVect
 	requestedTxns   map[chainhash.Hash]struct{}
 	requestedBlocks map[chainhash.Hash]struct{}
+	pendingCmpct    *partialBlock
 }
 
 // processBlockMsg is a message type to be sent across the message channel for requested a block is processed.  Note this call differs from blockMsg above in that blockMsg is intended for blocks that came from peers and have extra handling whereas this message essentially is just a concurrent safe way to call ProcessBlock on the internal block chain instance.
@@ -147,6 +157,9 @@ const (
 	// minInFlightBlocks is the minimum number of blocks that should be in the request queue for headers-first mode before requesting more.
 	minInFlightBlocks = 10
 
+	// maxBackgroundBlocks is the maximum number of blocks before a loaded utxo snapshot that are requested from a peer at once for their background validation.
+	maxBackgroundBlocks = 128
+
 	// maxRejectedTxns is the maximum number of rejected transactions hashes to store in memory.
 	maxRejectedTxns = 1000
 
@@ -357,6 +370,12 @@ out:
 			case *blockMsg:
 				sm.handleBlockMsg(msg)
 				msg.reply <- struct{}{}
+			case *cmpctBlockMsg:
+				sm.handleCmpctBlockMsg(msg)
+				msg.reply <- struct{}{}
+			case *blockTxnMsg:
+				sm.handleBlockTxnMsg(msg)
+				msg.reply <- struct{}{}
 			case *invMsg:
 				sm.handleInvMsg(msg)
 			case *headersMsg:
@@ -515,13 +534,79 @@ func (
 	}
 }
 
-// findNextHeaderCheckpoint returns the next checkpoint after the passed height. It returns nil when there is not one either because the height is already later than the final checkpoint or some other reason such as disabled checkpoints.
+// headerCheckpoints returns the checkpoints the headers are downloaded up to in headers-first mode, which are the checkpoints of the chain unless they are disabled followed by the assumed valid block when it is after them.
+func (
+	sm *SyncManager,
+) headerCheckpoints() []chaincfg.Checkpoint {
+
+	var checkpoints []chaincfg.Checkpoint
+
+	if !sm.disableCheckpoints {
+
+		checkpoints = sm.chain.Checkpoints()
+	}
+
+	if sm.assumeValid != nil {
+
+		checkpoints = append(checkpoints[:len(checkpoints):len(checkpoints)],
+			*sm.assumeValid)
+	}
+
+	return checkpoints
+}
+
+// findAssumeValid returns the assumed valid block of the chain when it is after the final checkpoint, or nil when there is not one or it is already covered by a checkpoint.
+func (
+	sm *SyncManager,
+) findAssumeValid() *chaincfg.Checkpoint {
+
+	assumeValid := sm.chain.AssumeValid()
+
+	if assumeValid == nil || sm.disableCheckpoints {
+
+		return assumeValid
+	}
+
+	checkpoint := sm.chain.LatestCheckpoint()
+
+	if checkpoint != nil && assumeValid.Height <= checkpoint.Height {
+
+		return nil
+	}
+
+	return assumeValid
+}
+
+// isAssumeValidTarget returns whether the headers are being downloaded up to the assumed valid block rather than a checkpoint.
+func (
+	sm *SyncManager,
+) isAssumeValidTarget() bool {
+
+	return sm.assumeValid != nil && sm.nextCheckpoint != nil &&
+		sm.nextCheckpoint.Height == sm.assumeValid.Height &&
+		sm.nextCheckpoint.Hash.IsEqual(sm.assumeValid.Hash)
+}
+
+// syncHeight returns the height of the best block of the sync peer, which the progress of the sync is measured against, or zero when there is no sync peer.
+func (
+	sm *SyncManager,
+) syncHeight() int32 {
+
+	if sm.syncPeer == nil {
+
+		return 0
+	}
+
+	return sm.syncPeer.LastBlock()
+}
+
+// findNextHeaderCheckpoint returns the next checkpoint, or the assumed valid block after the final checkpoint, after the passed height. It returns nil when there is not one either because the height is already later than the final checkpoint or some other reason such as disabled checkpoints.
 func (
 	sm *SyncManager,
 ) findNextHeaderCheckpoint(
 	height int32) *chaincfg.Checkpoint {
 
-	checkpoints := sm.chain.Checkpoints()
+	checkpoints := sm.headerCheckpoints()
 
 	if len(checkpoints) == 0 {
 
@@ -590,7 +675,17 @@ func (
 		}
 	}
 
-	// When in headers-first mode, if the block matches the hash of the first header in the list of headers that are being fetched, it's eligible for less validation since the headers have already been verified to link together and are valid up to the next checkpoint. Also, remove the list entry for all blocks except the checkpoint since it is needed to verify the next round of headers links properly.
+	// Blocks before a loaded utxo snapshot are validated in the background against their own utxo set instead of being processed as part of the chain.
+
+	if sm.chain.IsBackgroundBlock(blockHash) {
+
+		delete(state.requestedBlocks, *blockHash)
+		delete(sm.requestedBlocks, *blockHash)
+		sm.handleBackgroundBlock(peer, bmsg.block)
+		return
+	}
+
+	// When in headers-first mode, if the block matches the hash of the first header in the list of headers that are being fetched, it's eligible for less validation since the headers have already been verified to link together and are valid up to the next checkpoint.  The blocks linked to the assumed valid block only skip script validation, and the blocks linked to a checkpoint also skip the proof of work check when configured to. Also, remove the list entry for all blocks except the checkpoint since it is needed to verify the next round of headers links properly.
 	isCheckpointBlock := false
 	behaviorFlags := blockchain.BFNone
 
@@ -604,7 +699,17 @@ func (
 
 			if blockHash.IsEqual(firstNode.hash) {
 
-				behaviorFlags |= blockchain.BFFastAdd
+				switch {
+
+				case sm.isAssumeValidTarget():
+					behaviorFlags |= blockchain.BFAssumeValid
+
+				case sm.skipCheckpointPoW:
+					behaviorFlags |= blockchain.BFFastAdd | blockchain.BFNoPoWCheck
+
+				default:
+					behaviorFlags |= blockchain.BFFastAdd
+				}
 
 				if firstNode.hash.IsEqual(sm.nextCheckpoint.Hash) {
 
@@ -644,7 +749,7 @@ func (
 	}
 
 	// Process the block to include validation, best chain selection, orphan handling, etc.
-	_, isOrphan, err := sm.chain.ProcessBlock(bmsg.block, behaviorFlags, heightUpdate)
+	isMainChain, isOrphan, err := sm.chain.ProcessBlock(bmsg.block, behaviorFlags, heightUpdate)
 
 	if err != nil {
 
@@ -716,13 +821,20 @@ func (
 	} else {
 
 		// When the block is not an orphan, log information about it and update the chain state.
-		sm.progressLogger.LogBlockHeight(bmsg.block)
+		sm.progressLogger.LogBlockHeight(bmsg.block, sm.syncHeight())
 		// Update this peer's latest block height, for future potential sync node candidacy.
 		best := sm.chain.BestSnapshot()
 		heightUpdate = best.Height
 		blkHashUpdate = &best.Hash
 		// Clear the rejected transactions.
 		sm.rejectedTxns = make(map[chainhash.Hash]struct{})
+
+		// The peer was the first to deliver a new best block, so ask it to announce the next ones with compact blocks.
+
+		if isMainChain && sm.current() {
+
+			sm.updateHighBandwidthPeers(peer)
+		}
 	}
 
 	// Update the block height for this peer. But only send a message to the server for updating peer heights if this is an orphan or our chain is "current". This avoids sending a spammy amount of messages if we're syncing the chain from scratch.
@@ -836,7 +948,7 @@ func (
 		}
 		// Generate the inventory vector and relay it.
 		iv := wire.NewInvVect(wire.InvTypeBlock, block.Hash())
-		sm.peerNotifier.RelayInventory(iv, block.MsgBlock().Header)
+		sm.peerNotifier.RelayInventory(iv, block.MsgBlock())
 
 	// A block has been connected to the main block chain.
 	case blockchain.NTBlockConnected:
@@ -932,8 +1044,9 @@ func (
 		return
 	}
 
-	// Remove the peer from the list of candidate peers.
+	// Remove the peer from the list of candidate peers and the peers announcing new blocks with compact blocks.
 	delete(sm.peerStates, peer)
+	sm.removeHighBandwidthPeer(peer)
 
 	log <- cl.Info{"lost peer", peer}
 
@@ -1282,6 +1395,12 @@ func (
 				if peer.IsWitnessEnabled() {
 
 					iv.Type = wire.InvTypeWitnessBlock
+
+				} else if sm.current() &&
+					peer.CmpctBlockVersion() == wire.CmpctBlockVersion {
+
+					// Once the chain is current most transactions of a new block are already in the memory pool, so request it as a compact block.
+					iv.Type = wire.InvTypeCmpctBlock
 				}
 				gdmsg.AddInvVect(iv)
 				numRequested++
@@ -1318,6 +1437,102 @@ func (
 
 		peer.QueueMessage(gdmsg, nil)
 	}
+
+	sm.fetchBackgroundBlocks(peer)
+}
+
+// fetchBackgroundBlocks requests the blocks before a loaded utxo snapshot that are still needed for their background validation from the passed peer.  The blocks are only requested once the chain is current, so the download doesn't hold up the sync of the chain.
+func (
+	sm *SyncManager,
+) fetchBackgroundBlocks(
+	peer *peerpkg.Peer) {
+
+	state, exists := sm.peerStates[peer]
+
+	if !exists || sm.headersFirstMode || !sm.current() ||
+		len(state.requestedBlocks) >= minInFlightBlocks {
+
+		return
+	}
+
+	gdmsg := wire.NewMsgGetData()
+
+	for _, hash := range sm.chain.BackgroundBlocksNeeded(maxBackgroundBlocks) {
+
+		if _, exists := sm.requestedBlocks[*hash]; exists {
+
+			continue
+		}
+
+		sm.requestedBlocks[*hash] = struct{}{}
+		sm.limitMap(sm.requestedBlocks, maxRequestedBlocks)
+		state.requestedBlocks[*hash] = struct{}{}
+		iv := wire.NewInvVect(wire.InvTypeBlock, hash)
+
+		if peer.IsWitnessEnabled() {
+
+			iv.Type = wire.InvTypeWitnessBlock
+		}
+		gdmsg.AddInvVect(iv)
+	}
+
+	if len(gdmsg.InvList) > 0 {
+
+		peer.QueueMessage(gdmsg, nil)
+	}
+}
+
+// handleBackgroundBlock hands a block before a loaded utxo snapshot to the chain for its background validation and requests more of them from the peer that sent it.
+func (
+	sm *SyncManager,
+) handleBackgroundBlock(
+	peer *peerpkg.Peer, block *util.Block) {
+
+	err := sm.chain.ProcessBackgroundBlock(block)
+
+	// The block is not to blame when the blocks validated with it are invalid, but the chain built on the snapshot can't be served any longer.
+	if err == blockchain.ErrUtxoSnapshotInvalid {
+
+		log <- cl.Error{"stopping the node:", err}
+
+		if sm.requestShutdown != nil {
+
+			sm.requestShutdown()
+		}
+
+		return
+	}
+
+	if err != nil {
+
+		if _, ok := err.(blockchain.RuleError); ok {
+
+			log <- cl.Infof{
+
+				"rejected block %v before the utxo snapshot from %s: %v",
+				block.Hash(), peer, err,
+			}
+
+			code, reason := mempool.ErrToRejectErr(err)
+			peer.PushRejectMsg(wire.CmdBlock, code, reason, block.Hash(), false)
+			return
+		}
+
+		log <- cl.Errorf{
+
+			"failed to process block %v before the utxo snapshot: %v",
+			block.Hash(), err,
+		}
+
+		if dbErr, ok := err.(database.Error); ok && dbErr.ErrorCode ==
+			database.ErrCorruption {
+
+			panic(dbErr)
+		}
+		return
+	}
+
+	sm.fetchBackgroundBlocks(peer)
 }
 
 // handleNewPeerMsg deals with new peers that have signalled they may be considered as a sync peer (they have already successfully negotiated).  It also starts syncing if needed.  It is invoked from the syncHandler goroutine.
@@ -1343,6 +1558,14 @@ func (
 		requestedBlocks: make(map[chainhash.Hash]struct{}),
 	}
 
+	// Let the peer know compact blocks are supported, announced in low-bandwidth mode until it is picked to announce them directly.
+
+	if peer.ProtocolVersion() >= wire.ShortIDsBlocksVersion {
+
+		peer.QueueMessage(wire.NewMsgSendCmpct(false, wire.CmpctBlockVersion),
+			nil)
+	}
+
 	// Start syncing by choosing the best candidate if needed.
 
 	if isSyncCandidate && sm.syncPeer == nil {
@@ -1665,35 +1888,48 @@ func New(
 	config *Config) (*SyncManager, error) {
 
 	sm := SyncManager{
-		peerNotifier:    config.PeerNotifier,
-		chain:           config.Chain,
-		txMemPool:       config.TxMemPool,
-		chainParams:     config.ChainParams,
-		rejectedTxns:    make(map[chainhash.Hash]struct{}),
-		requestedTxns:   make(map[chainhash.Hash]struct{}),
-		requestedBlocks: make(map[chainhash.Hash]struct{}),
-		peerStates:      make(map[*peerpkg.Peer]*peerSyncState),
-		progressLogger:  newBlockProgressLogger("processed", Log),
-		msgChan:         make(chan interface{}, config.MaxPeers*3),
-		headerList:      list.New(),
-		quit:            make(chan struct{}),
-		feeEstimator:    config.FeeEstimator,
+		peerNotifier:       config.PeerNotifier,
+		chain:              config.Chain,
+		txMemPool:          config.TxMemPool,
+		chainParams:        config.ChainParams,
+		rejectedTxns:       make(map[chainhash.Hash]struct{}),
+		requestedTxns:      make(map[chainhash.Hash]struct{}),
+		requestedBlocks:    make(map[chainhash.Hash]struct{}),
+		peerStates:         make(map[*peerpkg.Peer]*peerSyncState),
+		progressLogger:     newBlockProgressLogger("processed", Log),
+		msgChan:            make(chan interface{}, config.MaxPeers*3),
+		headerList:         list.New(),
+		quit:               make(chan struct{}),
+		feeEstimator:       config.FeeEstimator,
+		disableCheckpoints: config.DisableCheckpoints,
+		skipCheckpointPoW:  config.SkipCheckpointPoW,
+		requestShutdown:    config.RequestShutdown,
 	}
 	best := sm.chain.BestSnapshot()
 
-	if !config.DisableCheckpoints {
+	if config.DisableCheckpoints {
 
-		// Initialize the next checkpoint based on the current height.
-		sm.nextCheckpoint = sm.findNextHeaderCheckpoint(best.Height)
+		log <- cl.Inf("checkpoints are disabled")
+
+	}
 
-		if sm.nextCheckpoint != nil {
+	sm.assumeValid = sm.findAssumeValid()
 
-			sm.resetHeaderState(&best.Hash, best.Height)
+	if sm.assumeValid != nil {
+
+		log <- cl.Infof{
+
+			"assuming valid scripts up to block %d/hash %s",
+			sm.assumeValid.Height, sm.assumeValid.Hash,
 		}
-	} else {
+	}
 
-		log <- cl.Inf("checkpoints are disabled")
+	// Initialize the next checkpoint based on the current height.
+	sm.nextCheckpoint = sm.findNextHeaderCheckpoint(best.Height)
+
+	if sm.nextCheckpoint != nil {
 
+		sm.resetHeaderState(&best.Hash, best.Height)
 	}
 	sm.chain.Subscribe(sm.handleBlockchainNotification)
 	return &sm, nil
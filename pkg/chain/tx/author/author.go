@@ -60,6 +60,17 @@ type AuthoredTx struct {
 	ChangeIndex     int // negative if no change
 }
 
+// ErrChangeRequired describes the condition where the inputs of a
+// transaction created without a change output leave more value than can be
+// added to the fee.
+var ErrChangeRequired = errors.New("transaction inputs leave value that " +
+	"must be returned as change")
+
+// ReplaceableSequence is the sequence number of transaction inputs that
+// signal the transaction may be replaced by one paying a higher fee, as
+// described by BIP 125.
+const ReplaceableSequence = wire.MaxTxInSequenceNum - 2
+
 // ChangeSource provides P2PKH change output scripts for transaction creation.
 
 type ChangeSource func() ([]byte, error)
@@ -106,27 +117,7 @@ func NewUnsignedTransaction(
 			return nil, insufficientFundsError{}
 		}
 
-		// We count the types of inputs, which we'll use to estimate
-		// the vsize of the transaction.
-		var nested, p2wpkh, p2pkh int
-
-		for _, pkScript := range scripts {
-
-			switch {
-
-			// If this is a p2sh output, we assume this is a
-			// nested P2WKH.
-			case txscript.IsPayToScriptHash(pkScript):
-				nested++
-			case txscript.IsPayToWitnessPubKeyHash(pkScript):
-				p2wpkh++
-			default:
-				p2pkh++
-			}
-		}
-
-		maxSignedSize := txsizes.EstimateVirtualSize(p2pkh, p2wpkh,
-			nested, outputs, true)
+		maxSignedSize := estimateSignedSize(scripts, outputs, true)
 		maxRequiredFee := txrules.FeeForSerializeSize(relayFeePerKb, maxSignedSize)
 		remainingAmount := inputAmount - targetAmount
 
@@ -177,6 +168,201 @@ func NewUnsignedTransaction(
 	}
 }
 
+// NewChangelessTransaction creates an unsigned transaction paying to one or
+// more outputs without a change output, so that the value of the inputs
+// beyond the outputs and the fee must be small enough to be added to the fee.
+//
+// Transaction inputs are chosen from repeated calls to fetchInputs.  The first
+// target is the value of the outputs and the fee of the transaction without
+// any inputs, as expected by input sources that account for the fee of each
+// input they choose, such as a branch and bound coin selector.  When the
+// inputs do not pay the fee of the signed transaction, the target is raised by
+// the shortfall and inputs are chosen again.
+//
+// ErrChangeRequired is returned when the inputs leave more value than can be
+// added to the fee, and an InputSourceError when the input source was unable
+// to provide enough input value, in which case the caller is expected to fall
+// back to NewUnsignedTransaction.
+func NewChangelessTransaction(
+	outputs []*wire.TxOut, relayFeePerKb util.Amount,
+	fetchInputs InputSource) (*AuthoredTx, error) {
+
+	targetAmount := h.SumOutputValues(outputs)
+	estimatedSize := txsizes.EstimateVirtualSize(0, 0, 0, outputs, false)
+	targetFee := txrules.FeeForSerializeSize(relayFeePerKb, estimatedSize)
+
+	for {
+
+		inputAmount, inputs, inputValues, scripts, err := fetchInputs(targetAmount + targetFee)
+
+		if err != nil {
+
+			return nil, err
+		}
+
+		if inputAmount < targetAmount+targetFee {
+
+			return nil, insufficientFundsError{}
+		}
+
+		maxSignedSize := estimateSignedSize(scripts, outputs, false)
+		maxRequiredFee := txrules.FeeForSerializeSize(relayFeePerKb, maxSignedSize)
+		remainingAmount := inputAmount - targetAmount
+
+		if remainingAmount < maxRequiredFee {
+
+			targetFee += maxRequiredFee - remainingAmount
+			continue
+		}
+
+		if leftover := remainingAmount - maxRequiredFee; leftover != 0 &&
+			!txrules.IsDustAmount(leftover, txsizes.P2WPKHPkScriptSize,
+				relayFeePerKb) {
+
+			return nil, ErrChangeRequired
+		}
+
+		return &AuthoredTx{
+			Tx: &wire.MsgTx{
+				Version:  wire.TxVersion,
+				TxIn:     inputs,
+				TxOut:    outputs,
+				LockTime: 0,
+			},
+			PrevScripts:     scripts,
+			PrevInputValues: inputValues,
+			TotalInput:      inputAmount,
+			ChangeIndex:     -1,
+		}, nil
+	}
+}
+
+// NewReplacementTransaction creates an unsigned transaction that replaces
+// another one as described by BIP 125, paying the same non-change outputs at a
+// higher fee.
+//
+// The replacement spends every input of the original transaction, whose
+// values and previous output scripts are passed in origValues and
+// origScripts, followed by any more inputs from fetchInputs that are needed to
+// pay the higher fee.  The fee pays at least feeRatePerKb, and exceeds origFee
+// by the relay fee for the size of the replacement as nodes require before
+// they accept it in place of the original.  Remaining value is returned to a
+// change output from fetchChange unless it is dust.  All inputs of the
+// replacement signal that it may be replaced again.
+func NewReplacementTransaction(
+	outputs []*wire.TxOut, origInputs []*wire.TxIn,
+	origValues []util.Amount, origScripts [][]byte,
+	origFee, feeRatePerKb, relayFeePerKb util.Amount,
+	fetchInputs InputSource, fetchChange ChangeSource) (*AuthoredTx, error) {
+
+	if len(origInputs) != len(origValues) ||
+
+		len(origInputs) != len(origScripts) {
+
+		return nil, errors.New("origInputs, origValues and origScripts " +
+			"slices must have equal length")
+	}
+
+	var origTotal util.Amount
+
+	for _, value := range origValues {
+
+		origTotal += value
+	}
+
+	// The original inputs always come first, and only the value they are
+	// short of the target is requested from fetchInputs.
+	inputSource := func(target util.Amount) (util.Amount, []*wire.TxIn,
+
+		[]util.Amount, [][]byte, error) {
+
+		inputs := make([]*wire.TxIn, 0, len(origInputs))
+
+		for _, txIn := range origInputs {
+
+			prevOut := txIn.PreviousOutPoint
+			inputs = append(inputs, wire.NewTxIn(&prevOut, nil, nil))
+		}
+		values := append([]util.Amount(nil), origValues...)
+		scripts := append([][]byte(nil), origScripts...)
+
+		if origTotal >= target {
+
+			return origTotal, inputs, values, scripts, nil
+		}
+
+		total, more, moreValues, moreScripts, err := fetchInputs(
+			target - origTotal)
+
+		if err != nil {
+
+			return 0, nil, nil, nil, err
+		}
+
+		return origTotal + total, append(inputs, more...),
+			append(values, moreValues...),
+			append(scripts, moreScripts...), nil
+	}
+
+	for {
+
+		tx, err := NewUnsignedTransaction(outputs, feeRatePerKb,
+			inputSource, fetchChange)
+
+		if err != nil {
+
+			return nil, err
+		}
+
+		size := estimateSignedSize(tx.PrevScripts, outputs, true)
+		fee := tx.TotalInput - h.SumOutputValues(tx.Tx.TxOut)
+		minFee := origFee + txrules.FeeForSerializeSize(relayFeePerKb, size)
+
+		if fee >= minFee {
+
+			for _, txIn := range tx.Tx.TxIn {
+
+				txIn.Sequence = ReplaceableSequence
+			}
+
+			return tx, nil
+		}
+
+		// Raise the fee rate so that it pays the minimum fee for the
+		// estimated size and try again, as more inputs may be needed.
+		feeRatePerKb = minFee*1000/util.Amount(size) + 1
+	}
+}
+
+// estimateSignedSize returns the estimated virtual size of a signed
+// transaction redeeming the passed previous output scripts and paying to the
+// passed outputs, and a change output if addChange is set.
+func estimateSignedSize(
+	prevScripts [][]byte, outputs []*wire.TxOut, addChange bool) int {
+
+	// We count the types of inputs, which we'll use to estimate
+	// the vsize of the transaction.
+	var nested, p2wpkh, p2pkh int
+
+	for _, pkScript := range prevScripts {
+
+		switch {
+
+		// If this is a p2sh output, we assume this is a
+		// nested P2WKH.
+		case txscript.IsPayToScriptHash(pkScript):
+			nested++
+		case txscript.IsPayToWitnessPubKeyHash(pkScript):
+			p2wpkh++
+		default:
+			p2pkh++
+		}
+	}
+
+	return txsizes.EstimateVirtualSize(p2pkh, p2wpkh, nested, outputs,
+		addChange)
+}
+
 // RandomizeOutputPosition randomizes the position of a transaction's output by
 // swapping it with a random output.  The new index is returned.  This should be
 // done before signing.
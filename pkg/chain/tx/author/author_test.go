@@ -3,6 +3,7 @@
 package txauthor_test
 
 import (
+	"errors"
 	"testing"
 
 	. "git.parallelcoin.io/dev/pod/pkg/chain/tx/author"
@@ -25,6 +26,11 @@ func p2pkhOutputs(
 	}
 	return v
 }
+
+// insufficientFunds stands for any InputSourceError in the expected errors of
+// a test.
+var insufficientFunds = errors.New("insufficient funds")
+
 func makeInputSource(
 	unspents []*wire.TxOut) InputSource {
 
@@ -254,3 +260,176 @@ func TestNewUnsignedTransaction(
 		}
 	}
 }
+func TestNewChangelessTransaction(
+	t *testing.T) {
+
+	outputs := p2pkhOutputs(1e6)
+	fee := txrules.FeeForSerializeSize(1e3,
+		txsizes.EstimateVirtualSize(1, 0, 0, outputs, false))
+	dust := txrules.GetDustThreshold(txsizes.P2WPKHPkScriptSize, 1e3)
+	tests := []struct {
+		name           string
+		UnspentOutputs []*wire.TxOut
+		Fee            util.Amount
+		Err            error
+	}{
+		{
+			name:           "exact match",
+			UnspentOutputs: p2pkhOutputs(1e6 + fee),
+			Fee:            fee,
+		},
+		{
+			name:           "dust added to the fee",
+			UnspentOutputs: p2pkhOutputs(1e6 + fee + dust - 1),
+			Fee:            fee + dust - 1,
+		},
+		{
+			name:           "change required",
+			UnspentOutputs: p2pkhOutputs(1e6 + fee + dust),
+			Err:            ErrChangeRequired,
+		},
+		{
+			name:           "insufficient funds",
+			UnspentOutputs: p2pkhOutputs(1e6 + fee - 1),
+			Err:            insufficientFunds,
+		},
+	}
+
+	for _, test := range tests {
+
+		tx, err := NewChangelessTransaction(outputs, 1e3,
+			makeInputSource(test.UnspentOutputs))
+
+		if test.Err == insufficientFunds {
+
+			if _, ok := err.(InputSourceError); !ok {
+
+				t.Errorf("%s: got error %v, want an InputSourceError",
+					test.name, err)
+			}
+			continue
+		}
+
+		if err != test.Err {
+
+			t.Errorf("%s: got error %v, want %v", test.name, err, test.Err)
+			continue
+		}
+
+		if err != nil {
+
+			continue
+		}
+
+		if tx.ChangeIndex != -1 || len(tx.Tx.TxOut) != len(outputs) {
+
+			t.Errorf("%s: got %d outputs with change index %d, want no "+
+				"change output", test.name, len(tx.Tx.TxOut), tx.ChangeIndex)
+		}
+
+		if got := tx.TotalInput - 1e6; got != test.Fee {
+
+			t.Errorf("%s: got fee %v, want %v", test.name, got, test.Fee)
+		}
+	}
+}
+
+func TestNewReplacementTransaction(
+	t *testing.T) {
+
+	changeSource := func() ([]byte, error) {
+
+		return make([]byte, txsizes.P2WPKHPkScriptSize), nil
+	}
+
+	// The original spends a single output worth 1e6 paying 9e5 to a payee.
+	origPrevOut := wire.OutPoint{Index: 7}
+	origInputs := []*wire.TxIn{wire.NewTxIn(&origPrevOut, []byte{1}, nil)}
+	origValues := []util.Amount{1e6}
+	origScripts := make([][]byte, 1)
+	outputs := p2pkhOutputs(9e5)
+	size := txsizes.EstimateVirtualSize(1, 0, 0, outputs, true)
+	origFee := txrules.FeeForSerializeSize(1e3, size)
+
+	// A higher fee rate is paid from the original inputs, returning the
+	// rest to change.
+	tx, err := NewReplacementTransaction(outputs, origInputs, origValues,
+		origScripts, origFee, 1e4, 1e3, makeInputSource(nil), changeSource)
+
+	if err != nil {
+
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(tx.Tx.TxIn) != 1 || tx.Tx.TxIn[0].PreviousOutPoint != origPrevOut {
+
+		t.Fatalf("Replacement does not spend the original input")
+	}
+
+	if tx.Tx.TxIn[0].SignatureScript != nil {
+
+		t.Errorf("Replacement input keeps the original signature script")
+	}
+
+	if tx.Tx.TxIn[0].Sequence != ReplaceableSequence {
+
+		t.Errorf("Replacement input has sequence %x, want %x",
+			tx.Tx.TxIn[0].Sequence, ReplaceableSequence)
+	}
+
+	if tx.ChangeIndex < 0 {
+
+		t.Fatalf("No change output added")
+	}
+	wantChange := 1e6 - 9e5 - txrules.FeeForSerializeSize(1e4, size)
+
+	if change := util.Amount(tx.Tx.TxOut[tx.ChangeIndex].Value); change != wantChange {
+
+		t.Errorf("Got change amount %v, expected %v", change, wantChange)
+	}
+
+	// A fee rate lower than the original is raised so that the replacement
+	// pays the original fee and the relay fee for its own size.
+	tx, err = NewReplacementTransaction(outputs, origInputs, origValues,
+		origScripts, origFee, 1e3, 1e3, makeInputSource(nil), changeSource)
+
+	if err != nil {
+
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	fee := tx.TotalInput - util.Amount(tx.Tx.TxOut[0].Value)
+
+	if tx.ChangeIndex >= 0 {
+
+		fee -= util.Amount(tx.Tx.TxOut[tx.ChangeIndex].Value)
+	}
+
+	if minFee := origFee + txrules.FeeForSerializeSize(1e3, size); fee < minFee {
+
+		t.Errorf("Got fee %v, expected at least %v", fee, minFee)
+	}
+
+	// More inputs are added when the original ones can not pay the fee.
+	tx, err = NewReplacementTransaction(outputs, origInputs, origValues,
+		origScripts, origFee, 1e6, 1e3, makeInputSource(p2pkhOutputs(1e6)),
+		changeSource)
+
+	if err != nil {
+
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(tx.Tx.TxIn) != 2 {
+
+		t.Errorf("Used %d inputs, expected 2", len(tx.Tx.TxIn))
+	}
+
+	// Running out of inputs is reported like for new transactions.
+	_, err = NewReplacementTransaction(outputs, origInputs, origValues,
+		origScripts, origFee, 1e6, 1e3, makeInputSource(nil), changeSource)
+
+	if _, ok := err.(InputSourceError); !ok {
+
+		t.Errorf("Got error %v, expected an InputSourceError", err)
+	}
+}
@@ -0,0 +1,1297 @@
+package blockchain
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+
+	chaincfg "git.parallelcoin.io/dev/pod/pkg/chain/config"
+	chainhash "git.parallelcoin.io/dev/pod/pkg/chain/hash"
+	"git.parallelcoin.io/dev/pod/pkg/chain/wire"
+	database "git.parallelcoin.io/dev/pod/pkg/db"
+	"git.parallelcoin.io/dev/pod/pkg/util"
+	cl "git.parallelcoin.io/dev/pod/pkg/util/cl"
+)
+
+// A utxo snapshot holds everything a new node needs to continue the block chain from the block it was taken at without replaying the blocks before it: the headers of the main chain, the block itself, and the unspent transaction output set after it.
+// The serialized format is:
+//   <header><block headers><block><utxo records>
+//   Field                Type                Size
+//   magic                [4]byte             4 bytes
+//   version              uint32              4 bytes
+//   network              wire.BitcoinNet     4 bytes
+//   height               int32               4 bytes
+//   block hash           chainhash.Hash      chainhash.HashSize
+//   total transactions   uint64              8 bytes
+//   utxo count           uint64              8 bytes
+//   block headers        []wire.BlockHeader  80 bytes each for heights 1 to height
+//   block                wire.MsgBlock       variable
+//   utxo records         []utxo record       variable
+// The serialized utxo record format is:
+//   <hash><output index><serialized utxo>
+//   Field                Type                Size
+//   hash                 chainhash.Hash      chainhash.HashSize
+//   output index         VarInt              variable
+//   serialized utxo      VarBytes            variable
+// The serialized utxo is the value stored in the utxo set bucket, which is compressed as described in chainio.go, and the records are ordered by the keys of the bucket.  The hash of the utxo set is the double sha256 of the utxo records, so it depends only on the set and can be compared with the hash reported by gettxoutsetinfo.
+// -----------------------------------------------------------------------------
+
+const (
+
+	// utxoSnapshotVersion is the current version of the utxo snapshot format.
+	utxoSnapshotVersion = 1
+
+	// utxoSnapshotHeaderSize is the size of the serialized header of a utxo snapshot.
+	utxoSnapshotHeaderSize = 4 + 4 + 4 + 4 + chainhash.HashSize + 8 + 8
+
+	// utxoSnapshotBufferSize is the size of the buffers used to read and write utxo snapshots.
+	utxoSnapshotBufferSize = 1 << 20
+
+	// utxoSnapshotBatchSize is the number of utxos stored or deleted in each database transaction while a utxo snapshot is loaded.
+	utxoSnapshotBatchSize = 100000
+)
+
+var (
+
+	// utxoSnapshotMagic identifies a utxo snapshot.
+	utxoSnapshotMagic = [4]byte{'u', 't', 'x', 'o'}
+
+	// snapshotStateKeyName is the name of the db key used to store the state of the background validation of a loaded utxo snapshot.
+	snapshotStateKeyName = []byte("utxosnapshotstate")
+
+	// snapshotLoadingKeyName is the name of the db key that is set while the utxo set of a utxo snapshot is stored and not yet part of the chain.
+	snapshotLoadingKeyName = []byte("utxosnapshotloading")
+
+	// backgroundUtxoSetBucketName is the name of the db bucket used to house the unspent transaction output set built by the background validation of a loaded utxo snapshot.
+	backgroundUtxoSetBucketName = []byte("utxobackground")
+)
+
+// UtxoSetInfo describes the unspent transaction output set at the end of the main chain.
+type UtxoSetInfo struct {
+
+	// Height and Hash identify the block the utxo set is taken at.
+	Height int32
+	Hash   chainhash.Hash
+
+	// Transactions is the number of transactions with unspent outputs, Outputs the number of unspent outputs and TotalAmount their total value.
+	Transactions int64
+	Outputs      int64
+	TotalAmount  int64
+
+	// SerializedSize is the size of the utxo records of the set, and SetHash their double sha256 hash.
+	SerializedSize int64
+	SetHash        chainhash.Hash
+}
+
+// utxoSnapshotHeader is the header of a utxo snapshot.
+type utxoSnapshotHeader struct {
+	version   uint32
+	net       wire.BitcoinNet
+	height    int32
+	hash      chainhash.Hash
+	totalTxns uint64
+	numUtxos  uint64
+}
+
+// serializeUtxoSnapshotHeader returns the serialization of the passed utxo snapshot header.  The format is described in detail above.
+func serializeUtxoSnapshotHeader(
+
+	header *utxoSnapshotHeader) []byte {
+
+	serialized := make([]byte, utxoSnapshotHeaderSize)
+	copy(serialized, utxoSnapshotMagic[:])
+	offset := len(utxoSnapshotMagic)
+	byteOrder.PutUint32(serialized[offset:], header.version)
+	offset += 4
+	byteOrder.PutUint32(serialized[offset:], uint32(header.net))
+	offset += 4
+	byteOrder.PutUint32(serialized[offset:], uint32(header.height))
+	offset += 4
+	copy(serialized[offset:], header.hash[:])
+	offset += chainhash.HashSize
+	byteOrder.PutUint64(serialized[offset:], header.totalTxns)
+	offset += 8
+	byteOrder.PutUint64(serialized[offset:], header.numUtxos)
+	return serialized
+}
+
+// deserializeUtxoSnapshotHeader decodes the passed serialized utxo snapshot header.  The format is described in detail above.
+func deserializeUtxoSnapshotHeader(
+
+	serialized []byte) (*utxoSnapshotHeader, error) {
+
+	if len(serialized) != utxoSnapshotHeaderSize ||
+		!bytes.Equal(serialized[:len(utxoSnapshotMagic)], utxoSnapshotMagic[:]) {
+
+		return nil, fmt.Errorf("not a utxo snapshot")
+	}
+
+	header := &utxoSnapshotHeader{}
+	offset := len(utxoSnapshotMagic)
+	header.version = byteOrder.Uint32(serialized[offset:])
+	offset += 4
+
+	if header.version != utxoSnapshotVersion {
+
+		return nil, fmt.Errorf("unsupported utxo snapshot version %d",
+			header.version)
+	}
+
+	header.net = wire.BitcoinNet(byteOrder.Uint32(serialized[offset:]))
+	offset += 4
+	header.height = int32(byteOrder.Uint32(serialized[offset:]))
+	offset += 4
+	copy(header.hash[:], serialized[offset:])
+	offset += chainhash.HashSize
+	header.totalTxns = byteOrder.Uint64(serialized[offset:])
+	offset += 8
+	header.numUtxos = byteOrder.Uint64(serialized[offset:])
+	return header, nil
+}
+
+// writeUtxoRecord writes the utxo record of the serialized utxo of the passed outpoint.  The format is described in detail above.
+func writeUtxoRecord(
+
+	w io.Writer, outpoint wire.OutPoint, serializedUtxo []byte) error {
+
+	if _, err := w.Write(outpoint.Hash[:]); err != nil {
+
+		return err
+	}
+
+	if err := wire.WriteVarInt(w, 0, uint64(outpoint.Index)); err != nil {
+
+		return err
+	}
+
+	return wire.WriteVarBytes(w, 0, serializedUtxo)
+}
+
+// readUtxoRecord reads a utxo record written by writeUtxoRecord and returns the outpoint and the serialized utxo.
+func readUtxoRecord(
+
+	r io.Reader) (wire.OutPoint, []byte, error) {
+
+	var outpoint wire.OutPoint
+
+	if _, err := io.ReadFull(r, outpoint.Hash[:]); err != nil {
+
+		return outpoint, nil, err
+	}
+
+	index, err := wire.ReadVarInt(r, 0)
+
+	if err != nil {
+
+		return outpoint, nil, err
+	}
+
+	if index > 1<<32-1 {
+
+		return outpoint, nil, fmt.Errorf("utxo record output index %d "+
+			"is out of range", index)
+	}
+
+	outpoint.Index = uint32(index)
+	serializedUtxo, err := wire.ReadVarBytes(r, 0, wire.MaxBlockPayload,
+		"serialized utxo")
+	return outpoint, serializedUtxo, err
+}
+
+// utxoSetHasher accumulates the utxo records of a utxo set into its hash and the other details of UtxoSetInfo, writing them to an optional writer as well.
+type utxoSetHasher struct {
+	info   UtxoSetInfo
+	sha    hash.Hash
+	w      io.Writer
+	lastTx chainhash.Hash
+}
+
+// newUtxoSetHasher returns a utxo set hasher which writes the utxo records to the passed writer, which can be nil.
+func newUtxoSetHasher(
+
+	w io.Writer) *utxoSetHasher {
+
+	h := &utxoSetHasher{sha: sha256.New()}
+	h.w = h.sha
+
+	if w != nil {
+
+		h.w = io.MultiWriter(h.sha, w)
+	}
+
+	return h
+}
+
+// Write counts the bytes of the utxo records written to the hasher.
+func (h *utxoSetHasher) Write(p []byte) (int, error) {
+
+	n, err := h.w.Write(p)
+	h.info.SerializedSize += int64(n)
+	return n, err
+}
+
+// add adds the serialized utxo of the passed outpoint to the hash and totals of the set.
+func (h *utxoSetHasher) add(
+	outpoint wire.OutPoint, serializedUtxo []byte) error {
+
+	entry, err := deserializeUtxoEntry(serializedUtxo)
+
+	if err != nil {
+
+		return fmt.Errorf("corrupt utxo entry for %v: %v", outpoint, err)
+	}
+
+	// The records are ordered by outpoint, so the outputs of a transaction follow each other.
+	if h.info.Outputs == 0 || outpoint.Hash != h.lastTx {
+
+		h.info.Transactions++
+		h.lastTx = outpoint.Hash
+	}
+
+	h.info.Outputs++
+	h.info.TotalAmount += entry.Amount()
+	return writeUtxoRecord(h, outpoint, serializedUtxo)
+}
+
+// finish returns the details of the utxo set with the hash of all of the utxo records added.
+func (h *utxoSetHasher) finish() *UtxoSetInfo {
+
+	info := h.info
+	info.SetHash = chainhash.Hash(sha256.Sum256(h.sha.Sum(nil)))
+	return &info
+}
+
+// forEachUtxo calls fn with the outpoint and serialized utxo of each entry of the utxo set in the passed bucket, in the order of the keys of the bucket.
+func forEachUtxo(
+
+	utxoBucket database.Bucket,
+	fn func(outpoint wire.OutPoint, serializedUtxo []byte) error) error {
+
+	cursor := utxoBucket.Cursor()
+
+	for ok := cursor.First(); ok; ok = cursor.Next() {
+
+		key := cursor.Key()
+
+		if len(key) <= chainhash.HashSize {
+
+			return AssertError(fmt.Sprintf("utxo set contains malformed "+
+				"key %x", key))
+		}
+
+		var outpoint wire.OutPoint
+		copy(outpoint.Hash[:], key[:chainhash.HashSize])
+		index, _ := deserializeVLQ(key[chainhash.HashSize:])
+		outpoint.Index = uint32(index)
+
+		if err := fn(outpoint, cursor.Value()); err != nil {
+
+			return err
+		}
+	}
+
+	return nil
+}
+
+// hashUtxoSet returns the details of the utxo set in the passed bucket, writing its utxo records to the passed writer, which can be nil.
+func hashUtxoSet(
+
+	utxoBucket database.Bucket, w io.Writer) (*UtxoSetInfo, error) {
+
+	hasher := newUtxoSetHasher(w)
+	err := forEachUtxo(utxoBucket, hasher.add)
+
+	if err != nil {
+
+		return nil, err
+	}
+
+	return hasher.finish(), nil
+}
+
+// FetchUtxoSetInfo returns the details of the unspent transaction output set at the end of the main chain, including the hash of its serialization which is written to utxo snapshots.  This function is safe for concurrent access.
+func (b *BlockChain) FetchUtxoSetInfo() (*UtxoSetInfo, error) {
+
+	var info *UtxoSetInfo
+
+	err := b.db.View(func(dbTx database.Tx) error {
+
+		state, err := deserializeBestChainState(
+			dbTx.Metadata().Get(chainStateKeyName))
+
+		if err != nil {
+
+			return err
+		}
+
+		info, err = hashUtxoSet(dbTx.Metadata().Bucket(utxoSetBucketName), nil)
+
+		if err != nil {
+
+			return err
+		}
+
+		info.Height = int32(state.height)
+		info.Hash = state.hash
+		return nil
+	})
+
+	return info, err
+}
+
+// DumpUtxoSnapshot writes a utxo snapshot of the end of the main chain to w, which a new node can be bootstrapped from with LoadUtxoSnapshot, and returns the details of its utxo set.  The utxo set is read from a consistent view of the database, so the chain may move on while it is written.  This function is safe for concurrent access.
+func (b *BlockChain) DumpUtxoSnapshot(
+	w io.Writer) (*UtxoSetInfo, error) {
+
+	var info *UtxoSetInfo
+
+	err := b.db.View(func(dbTx database.Tx) error {
+
+		meta := dbTx.Metadata()
+		state, err := deserializeBestChainState(meta.Get(chainStateKeyName))
+
+		if err != nil {
+
+			return err
+		}
+
+		tip := b.Index.LookupNode(&state.hash)
+
+		if tip == nil {
+
+			return AssertError(fmt.Sprintf("chain tip %s is not in the "+
+				"block index", state.hash))
+		}
+
+		blockBytes, err := dbTx.FetchBlock(&state.hash)
+
+		if err != nil {
+
+			return err
+		}
+
+		utxoBucket := meta.Bucket(utxoSetBucketName)
+		var numUtxos uint64
+
+		err = forEachUtxo(utxoBucket, func(wire.OutPoint, []byte) error {
+
+			numUtxos++
+			return nil
+		})
+
+		if err != nil {
+
+			return err
+		}
+
+		bw := bufio.NewWriterSize(w, utxoSnapshotBufferSize)
+		_, err = bw.Write(serializeUtxoSnapshotHeader(&utxoSnapshotHeader{
+
+			version:   utxoSnapshotVersion,
+			net:       b.chainParams.Net,
+			height:    tip.height,
+			hash:      tip.hash,
+			totalTxns: state.totalTxns,
+			numUtxos:  numUtxos,
+		}))
+
+		if err != nil {
+
+			return err
+		}
+
+		// The headers are written from the lowest height up so they can be connected to their parents as they are read.
+		for height := int32(1); height <= tip.height; height++ {
+
+			header := tip.Ancestor(height).Header()
+
+			if err := header.Serialize(bw); err != nil {
+
+				return err
+			}
+		}
+
+		if _, err := bw.Write(blockBytes); err != nil {
+
+			return err
+		}
+
+		info, err = hashUtxoSet(utxoBucket, bw)
+
+		if err != nil {
+
+			return err
+		}
+
+		info.Height = tip.height
+		info.Hash = tip.hash
+		return bw.Flush()
+	})
+
+	return info, err
+}
+
+// findUtxoSnapshot returns the utxo snapshot of the chain parameters, or the passed trusted one, taken at the passed block, or nil when there is none.
+func (b *BlockChain) findUtxoSnapshot(
+	height int32, hash *chainhash.Hash,
+	trusted *chaincfg.UtxoSnapshot) *chaincfg.UtxoSnapshot {
+
+	if trusted != nil && trusted.Height == height &&
+		trusted.Hash.IsEqual(hash) {
+
+		return trusted
+	}
+
+	for i := range b.chainParams.UtxoSnapshots {
+
+		snapshot := &b.chainParams.UtxoSnapshots[i]
+
+		if snapshot.Height == height && snapshot.Hash.IsEqual(hash) {
+
+			return snapshot
+		}
+	}
+
+	return nil
+}
+
+// LoadUtxoSnapshot bootstraps a new chain from a utxo snapshot written by DumpUtxoSnapshot, which must be one of the utxo snapshots of the chain parameters or the passed trusted one, which may be nil.  The headers in the snapshot are verified to link the genesis block to the block of the snapshot and the hash of its utxo set is verified against the chain parameters, after which the chain continues from the block of the snapshot.  The blocks before it are then validated in the background against the utxo set as they are downloaded by ProcessBackgroundBlock.
+// The chain must not contain any blocks other than the genesis block, and since the blocks before the snapshot are not available to them, the optional indexes must not be enabled.  This function is safe for concurrent access.
+func (b *BlockChain) LoadUtxoSnapshot(
+	r io.Reader, trusted *chaincfg.UtxoSnapshot) (*UtxoSetInfo, error) {
+
+	b.chainLock.Lock()
+	defer b.chainLock.Unlock()
+
+	if b.bestChain.Height() != 0 {
+
+		return nil, fmt.Errorf("a utxo snapshot can only be loaded into a " +
+			"new chain")
+	}
+
+	if b.indexManager != nil {
+
+		return nil, fmt.Errorf("a utxo snapshot can't be loaded with " +
+			"indexes enabled")
+	}
+
+	br := bufio.NewReaderSize(r, utxoSnapshotBufferSize)
+	serializedHeader := make([]byte, utxoSnapshotHeaderSize)
+
+	if _, err := io.ReadFull(br, serializedHeader); err != nil {
+
+		return nil, err
+	}
+
+	header, err := deserializeUtxoSnapshotHeader(serializedHeader)
+
+	if err != nil {
+
+		return nil, err
+	}
+
+	if header.net != b.chainParams.Net {
+
+		return nil, fmt.Errorf("the utxo snapshot is for network %v, "+
+			"not %v", header.net, b.chainParams.Net)
+	}
+
+	snapshot := b.findUtxoSnapshot(header.height, &header.hash, trusted)
+
+	if snapshot == nil {
+
+		return nil, fmt.Errorf("the utxo snapshot at block %d/hash %s is "+
+			"neither one of the chain parameters nor trusted",
+			header.height, header.hash)
+	}
+
+	// Connect the headers to the genesis block, allocating the nodes at once since their number is known.
+	forks := b.HardForks()
+	blockNodes := make([]blockNode, header.height)
+	parent := b.bestChain.Genesis()
+
+	for i := range blockNodes {
+
+		var blockHeader wire.BlockHeader
+
+		if err := blockHeader.Deserialize(br); err != nil {
+
+			return nil, err
+		}
+
+		if blockHeader.PrevBlock != parent.hash {
+
+			return nil, fmt.Errorf("utxo snapshot block header at height "+
+				"%d does not connect to the previous one", i+1)
+		}
+
+		node := &blockNodes[i]
+		initBlockNode(node, &blockHeader, parent, forks)
+		node.status = statusValid
+		parent = node
+	}
+
+	tip := parent
+
+	if tip.hash != header.hash {
+
+		return nil, fmt.Errorf("utxo snapshot block headers end at %s "+
+			"instead of %s", tip.hash, header.hash)
+	}
+
+	var msgBlock wire.MsgBlock
+
+	if err := msgBlock.Deserialize(br); err != nil {
+
+		return nil, err
+	}
+
+	block := util.NewBlock(&msgBlock)
+	block.SetHeight(tip.height)
+
+	if !block.Hash().IsEqual(&tip.hash) {
+
+		return nil, fmt.Errorf("utxo snapshot block %s is not the block "+
+			"of the snapshot", block.Hash())
+	}
+
+	// The header of the block is already known to be valid, so only its transactions are checked against it.
+	err = checkBlockSanity(block, b.chainParams.PowLimit, b.timeSource,
+		BFNoPoWCheck, true, tip.height, forks)
+
+	if err != nil {
+
+		return nil, err
+	}
+
+	tip.status = statusDataStored | statusValid
+	blockSize := uint64(msgBlock.SerializeSize())
+	blockWeight := uint64(GetBlockWeight(block))
+	state := newBestState(tip, blockSize, blockWeight,
+		uint64(len(msgBlock.Transactions)), header.totalTxns,
+		tip.CalcPastMedianTime())
+	// The utxo set is far too large to store in a single database transaction, so it is committed in batches, and it only becomes part of the chain with the final transaction that moves the best chain to the block of the snapshot.  Until then the best chain stays at the genesis block, which has no spendable outputs, so the utxos of a load that fails are removed, as are those of one that is interrupted when the chain is next opened.
+	info, err := b.storeUtxoSet(br, header.numUtxos, utxoSnapshotBatchSize)
+
+	if err == nil && !info.SetHash.IsEqual(snapshot.SetHash) {
+
+		err = fmt.Errorf("utxo snapshot hash %s does not match the "+
+			"expected %s", info.SetHash, snapshot.SetHash)
+	}
+
+	if err != nil {
+
+		b.removeUtxoSnapshotSet()
+		return nil, err
+	}
+
+	info.Height = tip.height
+	info.Hash = tip.hash
+
+	err = b.db.Update(func(dbTx database.Tx) error {
+
+		meta := dbTx.Metadata()
+
+		if err := meta.Delete(snapshotLoadingKeyName); err != nil {
+
+			return err
+		}
+
+		for i := range blockNodes {
+
+			node := &blockNodes[i]
+
+			if err := dbStoreBlockNode(dbTx, node); err != nil {
+
+				return err
+			}
+
+			if err := dbPutBlockIndex(dbTx, &node.hash, node.height); err != nil {
+
+				return err
+			}
+		}
+
+		if err := dbStoreBlock(dbTx, block); err != nil {
+
+			return err
+		}
+
+		if err := dbPutBestState(dbTx, state, tip.workSum); err != nil {
+
+			return err
+		}
+
+		if _, err := meta.CreateBucketIfNotExists(
+			backgroundUtxoSetBucketName); err != nil {
+
+			return err
+		}
+
+		return dbPutSnapshotState(dbTx, &snapshotState{
+
+			height:  tip.height,
+			setHash: info.SetHash,
+		})
+	})
+
+	if err != nil {
+
+		b.removeUtxoSnapshotSet()
+		return nil, err
+	}
+
+	for i := range blockNodes {
+
+		b.Index.addNode(&blockNodes[i])
+	}
+
+	b.bestChain.SetTip(tip)
+	b.stateLock.Lock()
+	b.stateSnapshot = state
+	b.stateLock.Unlock()
+	b.snapshot = &snapshotState{height: tip.height, setHash: info.SetHash}
+
+	log <- cl.Infof{
+
+		"loaded utxo snapshot at height %d/hash %s with %d unspent outputs, " +
+			"validating the blocks before it in the background",
+		tip.height, tip.hash, info.Outputs,
+	}
+
+	return info, nil
+}
+
+// storeUtxoSet reads numUtxos utxo records of a utxo snapshot from r and stores them in the utxo set, committing batchSize of them in each database transaction, and returns the details of the set read.
+func (b *BlockChain) storeUtxoSet(
+	r io.Reader, numUtxos uint64, batchSize int) (*UtxoSetInfo, error) {
+
+	hasher := newUtxoSetHasher(nil)
+
+	for stored := uint64(0); stored < numUtxos; {
+
+		err := b.db.Update(func(dbTx database.Tx) error {
+
+			meta := dbTx.Metadata()
+
+			// Mark the utxo set as holding utxos that are not part of the chain, so they are removed if the load is interrupted.
+			if err := meta.Put(snapshotLoadingKeyName, []byte{1}); err != nil {
+
+				return err
+			}
+
+			utxoBucket := meta.Bucket(utxoSetBucketName)
+
+			for i := 0; i < batchSize && stored < numUtxos; i++ {
+
+				outpoint, serializedUtxo, err := readUtxoRecord(r)
+
+				if err != nil {
+
+					return err
+				}
+
+				if err := hasher.add(outpoint, serializedUtxo); err != nil {
+
+					return err
+				}
+
+				err = utxoBucket.Put(*outpointKey(outpoint), serializedUtxo)
+
+				if err != nil {
+
+					return err
+				}
+
+				stored++
+			}
+
+			return nil
+		})
+
+		if err != nil {
+
+			return nil, err
+		}
+
+		log <- cl.Infof{"stored %d of %d utxos of the snapshot", stored,
+			numUtxos}
+	}
+
+	return hasher.finish(), nil
+}
+
+// clearUtxoSet deletes every entry of the utxo set, batchSize of them in each database transaction, and then the mark of a utxo snapshot being stored.  It is only used to remove the utxos stored by a utxo snapshot that was not loaded, while the best chain is still at the genesis block.
+func (b *BlockChain) clearUtxoSet(
+	batchSize int) error {
+
+	for numDeleted := batchSize; numDeleted == batchSize; {
+
+		numDeleted = 0
+
+		err := b.db.Update(func(dbTx database.Tx) error {
+
+			cursor := dbTx.Metadata().Bucket(utxoSetBucketName).Cursor()
+
+			for ok := cursor.First(); ok && numDeleted < batchSize; ok = cursor.Next() {
+
+				if err := cursor.Delete(); err != nil {
+
+					return err
+				}
+
+				numDeleted++
+			}
+
+			return nil
+		})
+
+		if err != nil {
+
+			return err
+		}
+	}
+
+	return b.db.Update(func(dbTx database.Tx) error {
+
+		return dbTx.Metadata().Delete(snapshotLoadingKeyName)
+	})
+}
+
+// removeUtxoSnapshotSet removes the utxos stored by a utxo snapshot that failed to load.  Any that are left are removed when the chain is next opened.
+func (b *BlockChain) removeUtxoSnapshotSet() {
+
+	if err := b.clearUtxoSet(utxoSnapshotBatchSize); err != nil {
+
+		log <- cl.Warn{"failed to remove the utxos of the snapshot:", err}
+	}
+}
+
+// ErrUtxoSnapshotInvalid is returned once the blocks before a loaded utxo snapshot are found to be invalid or to not produce its utxo set.  The chain can't be used from then on and must be synced from scratch.
+var ErrUtxoSnapshotInvalid = errors.New("the blocks before the utxo snapshot " +
+	"are invalid, the chain must be synced from scratch")
+
+// snapshotState is the state of the background validation of a loaded utxo snapshot.
+type snapshotState struct {
+
+	// height is the height of the block the utxo snapshot was taken at and setHash the hash of its utxo set.
+	height  int32
+	setHash chainhash.Hash
+
+	// validated is the height up to which the blocks before the snapshot are validated, and failed is set when one of them is invalid or the utxo set they build does not match the snapshot.
+	validated int32
+	failed    bool
+}
+
+// serializeSnapshotState returns the serialization of the passed snapshot state.  The format is:
+//
+//	<height><set hash><validated><failed>
+//	Field      Type             Size
+//	height     int32            4 bytes
+//	set hash   chainhash.Hash   chainhash.HashSize
+//	validated  int32            4 bytes
+//	failed     bool             1 byte
+func serializeSnapshotState(
+
+	state *snapshotState) []byte {
+
+	serialized := make([]byte, 4+chainhash.HashSize+4+1)
+	byteOrder.PutUint32(serialized, uint32(state.height))
+	copy(serialized[4:], state.setHash[:])
+	byteOrder.PutUint32(serialized[4+chainhash.HashSize:],
+		uint32(state.validated))
+
+	if state.failed {
+
+		serialized[len(serialized)-1] = 1
+	}
+
+	return serialized
+}
+
+// deserializeSnapshotState decodes the passed serialized snapshot state.
+func deserializeSnapshotState(
+
+	serialized []byte) (*snapshotState, error) {
+
+	if len(serialized) != 4+chainhash.HashSize+4+1 {
+
+		return nil, database.Error{
+
+			ErrorCode:   database.ErrCorruption,
+			Description: "corrupt utxo snapshot state",
+		}
+	}
+
+	state := &snapshotState{
+
+		height:    int32(byteOrder.Uint32(serialized)),
+		validated: int32(byteOrder.Uint32(serialized[4+chainhash.HashSize:])),
+		failed:    serialized[len(serialized)-1] != 0,
+	}
+
+	copy(state.setHash[:], serialized[4:])
+	return state, nil
+}
+
+// dbPutSnapshotState uses an existing database transaction to store the state of the background validation of a loaded utxo snapshot.
+func dbPutSnapshotState(
+
+	dbTx database.Tx, state *snapshotState) error {
+
+	return dbTx.Metadata().Put(snapshotStateKeyName,
+		serializeSnapshotState(state))
+}
+
+// loadSnapshotState loads the state of the background validation of a loaded utxo snapshot from the database, if a snapshot was loaded and the blocks before it are not validated yet.  It returns ErrUtxoSnapshotInvalid when they were found to be invalid, so the chain is not opened.  It also removes the utxos of a utxo snapshot that was interrupted while it was loaded.
+func (b *BlockChain) loadSnapshotState() error {
+
+	// A utxo snapshot that was interrupted while its utxo set was stored leaves utxos that are not part of the chain.
+	var interrupted bool
+
+	err := b.db.View(func(dbTx database.Tx) error {
+
+		interrupted = dbTx.Metadata().Get(snapshotLoadingKeyName) != nil
+		return nil
+	})
+
+	if err != nil {
+
+		return err
+	}
+
+	if interrupted {
+
+		log <- cl.Warn{"removing the utxos of a utxo snapshot that was not " +
+			"loaded"}
+
+		if err := b.clearUtxoSet(utxoSnapshotBatchSize); err != nil {
+
+			return err
+		}
+	}
+
+	return b.db.View(func(dbTx database.Tx) error {
+
+		serialized := dbTx.Metadata().Get(snapshotStateKeyName)
+
+		if serialized == nil {
+
+			return nil
+		}
+
+		state, err := deserializeSnapshotState(serialized)
+
+		if err != nil {
+
+			return err
+		}
+
+		b.snapshot = state
+
+		if state.failed {
+
+			log <- cl.Errorf{
+
+				"the blocks before the utxo snapshot at height %d are " +
+					"invalid, the chain must be synced from scratch",
+				state.height,
+			}
+
+			return ErrUtxoSnapshotInvalid
+		}
+
+		log <- cl.Infof{
+
+			"validating the blocks before the utxo snapshot at height %d " +
+				"in the background, validated up to height %d",
+			state.height, state.validated,
+		}
+
+		return nil
+	})
+}
+
+// SnapshotValidationState is the state of the background validation of the blocks before a loaded utxo snapshot.
+type SnapshotValidationState struct {
+
+	// Height is the height of the block the utxo snapshot was taken at.
+	Height int32
+
+	// Validated is the height up to which the blocks before the snapshot are validated.
+	Validated int32
+
+	// Failed is set when the blocks before the snapshot are invalid, so the chain must be synced from scratch.
+	Failed bool
+}
+
+// SnapshotValidation returns the state of the background validation of the blocks before the loaded utxo snapshot, or nil when no snapshot was loaded or its blocks are validated.  This function is safe for concurrent access.
+func (b *BlockChain) SnapshotValidation() *SnapshotValidationState {
+
+	b.chainLock.RLock()
+	defer b.chainLock.RUnlock()
+
+	if b.snapshot == nil {
+
+		return nil
+	}
+
+	return &SnapshotValidationState{
+
+		Height:    b.snapshot.height,
+		Validated: b.snapshot.validated,
+		Failed:    b.snapshot.failed,
+	}
+}
+
+// backgroundNode returns the node of the block with the passed hash when it is a block before the loaded utxo snapshot that is not downloaded yet, or nil otherwise.
+// This function MUST be called with the chain state lock held (for reads).
+func (b *BlockChain) backgroundNode(
+	hash *chainhash.Hash) *blockNode {
+
+	state := b.snapshot
+
+	if state == nil || state.failed {
+
+		return nil
+	}
+
+	node := b.Index.LookupNode(hash)
+
+	if node == nil || node.height <= state.validated ||
+		node.height > state.height || !b.bestChain.Contains(node) ||
+		b.Index.NodeStatus(node).HaveData() {
+
+		return nil
+	}
+
+	return node
+}
+
+// IsBackgroundBlock returns whether the block with the passed hash is a block before the loaded utxo snapshot that still needs to be downloaded for the background validation.  This function is safe for concurrent access.
+func (b *BlockChain) IsBackgroundBlock(
+	hash *chainhash.Hash) bool {
+
+	b.chainLock.RLock()
+	defer b.chainLock.RUnlock()
+	return b.backgroundNode(hash) != nil
+}
+
+// BackgroundBlocksNeeded returns the hashes of up to max of the lowest blocks before the loaded utxo snapshot that still need to be downloaded for the background validation.  This function is safe for concurrent access.
+func (b *BlockChain) BackgroundBlocksNeeded(
+	max int) []*chainhash.Hash {
+
+	b.chainLock.RLock()
+	defer b.chainLock.RUnlock()
+	state := b.snapshot
+
+	if state == nil || state.failed {
+
+		return nil
+	}
+
+	var hashes []*chainhash.Hash
+
+	for height := state.validated + 1; height <= state.height &&
+		len(hashes) < max; height++ {
+
+		node := b.bestChain.NodeByHeight(height)
+
+		if !b.Index.NodeStatus(node).HaveData() {
+
+			hashes = append(hashes, &node.hash)
+		}
+	}
+
+	return hashes
+}
+
+// ProcessBackgroundBlock stores a downloaded block before the loaded utxo snapshot and validates the blocks before the snapshot that are downloaded in order from the lowest one not validated yet.  The validation builds its own utxo set from the transactions of the blocks, which must match the utxo set of the snapshot once the block of the snapshot is connected to it.  It returns ErrUtxoSnapshotInvalid once the blocks before the snapshot are found to be invalid.  This function is safe for concurrent access.
+func (b *BlockChain) ProcessBackgroundBlock(
+	block *util.Block) error {
+
+	b.chainLock.Lock()
+	defer b.chainLock.Unlock()
+
+	if b.snapshot != nil && b.snapshot.failed {
+
+		return ErrUtxoSnapshotInvalid
+	}
+
+	node := b.backgroundNode(block.Hash())
+
+	if node == nil {
+
+		return fmt.Errorf("block %s is not needed for the validation of "+
+			"the utxo snapshot", block.Hash())
+	}
+
+	// The header of the block is already known to be valid, so only its transactions are checked against it before it is stored.
+	block.SetHeight(node.height)
+	err := checkBlockSanity(block, b.chainParams.PowLimit, b.timeSource,
+		BFNoPoWCheck, true, node.height, b.HardForks())
+
+	if err != nil {
+
+		return err
+	}
+
+	err = b.db.Update(func(dbTx database.Tx) error {
+
+		return dbStoreBlock(dbTx, block)
+	})
+
+	if err != nil {
+
+		return err
+	}
+
+	b.Index.SetStatusFlags(node, statusDataStored)
+
+	if err := b.Index.flushToDB(); err != nil {
+
+		return err
+	}
+
+	return b.connectBackgroundBlocks()
+}
+
+// connectBackgroundBlocks validates the stored blocks following the ones already validated in the background, until a block that is not downloaded yet or the block of the utxo snapshot, where the utxo set built is compared with the one of the snapshot.
+// This function MUST be called with the chain state lock held (for writes).
+func (b *BlockChain) connectBackgroundBlocks() error {
+
+	state := b.snapshot
+
+	for state != nil && !state.failed && state.validated < state.height {
+
+		node := b.bestChain.NodeByHeight(state.validated + 1)
+
+		if !b.Index.NodeStatus(node).HaveData() {
+
+			return nil
+		}
+
+		var block *util.Block
+
+		err := b.db.View(func(dbTx database.Tx) error {
+
+			var err error
+			block, err = dbFetchBlockByNode(dbTx, node)
+			return err
+		})
+
+		if err != nil {
+
+			return err
+		}
+
+		if err := b.connectBackgroundBlock(node, block); err != nil {
+
+			return err
+		}
+	}
+
+	return nil
+}
+
+// connectBackgroundBlock validates the passed block against the utxo set built by the background validation and connects it to that set.  When the block is the block of the utxo snapshot, the utxo set built is compared with the one of the snapshot, and the background validation finishes.
+// This function MUST be called with the chain state lock held (for writes).
+func (b *BlockChain) connectBackgroundBlock(
+	node *blockNode, block *util.Block) error {
+
+	state := b.snapshot
+	err := b.checkBlockTransactionsContext(block, node.parent)
+
+	if _, ok := err.(RuleError); ok {
+
+		return b.failSnapshot(state, fmt.Sprintf("block %s at height %d: %v",
+			node.hash, node.height, err))
+	}
+
+	if err != nil {
+
+		return err
+	}
+
+	// Load the outputs spent and created by the block from the background utxo set, so checkConnectBlock doesn't look them up in the utxo set of the main chain, where they are missing or spent.
+	view := NewUtxoViewpoint()
+	view.SetBestHash(&node.parent.hash)
+
+	err = b.db.View(func(dbTx database.Tx) error {
+
+		return view.fetchBackgroundUtxos(
+			dbTx.Metadata().Bucket(backgroundUtxoSetBucketName), block)
+	})
+
+	if err != nil {
+
+		return err
+	}
+
+	err = b.checkConnectBlock(node, block, view, nil, BFNone)
+
+	if _, ok := err.(RuleError); ok {
+
+		return b.failSnapshot(state, fmt.Sprintf("block %s at height %d: %v",
+			node.hash, node.height, err))
+	}
+
+	if err != nil {
+
+		return err
+	}
+
+	next := *state
+	var setHash chainhash.Hash
+
+	err = b.db.Update(func(dbTx database.Tx) error {
+
+		meta := dbTx.Metadata()
+		utxoBucket := meta.Bucket(backgroundUtxoSetBucketName)
+
+		if err := dbPutUtxoViewToBucket(utxoBucket, view); err != nil {
+
+			return err
+		}
+
+		next.validated = node.height
+
+		if next.validated < next.height {
+
+			return dbPutSnapshotState(dbTx, &next)
+		}
+
+		info, err := hashUtxoSet(utxoBucket, nil)
+
+		if err != nil {
+
+			return err
+		}
+
+		// A mismatch leaves the background utxo set as it is, and failSnapshot records the failure below.
+		if setHash = info.SetHash; !setHash.IsEqual(&next.setHash) {
+
+			next.failed = true
+			return nil
+		}
+
+		log <- cl.Infof{
+
+			"validated the blocks before the utxo snapshot at height %d",
+			next.height,
+		}
+
+		if err := meta.DeleteBucket(backgroundUtxoSetBucketName); err != nil {
+
+			return err
+		}
+
+		return meta.Delete(snapshotStateKeyName)
+	})
+
+	if err != nil {
+
+		return err
+	}
+
+	if next.failed {
+
+		return b.failSnapshot(&next, fmt.Sprintf("they produce a utxo set with "+
+			"hash %s instead of %s", setHash, next.setHash))
+	}
+
+	if next.validated == next.height {
+
+		b.snapshot = nil
+		return nil
+	}
+
+	b.snapshot = &next
+	return nil
+}
+
+// failSnapshot records that the blocks before the loaded utxo snapshot with the passed state are invalid for the passed reason and returns ErrUtxoSnapshotInvalid.  The failure is persisted, so the chain refuses to open again until it is synced from scratch.
+// This function MUST be called with the chain state lock held (for writes).
+func (b *BlockChain) failSnapshot(
+	state *snapshotState, reason string) error {
+
+	next := *state
+	next.failed = true
+
+	log <- cl.Errorf{
+
+		"the blocks before the utxo snapshot at height %d are invalid: %s, " +
+			"the chain must be synced from scratch", next.height, reason,
+	}
+
+	err := b.db.Update(func(dbTx database.Tx) error {
+
+		return dbPutSnapshotState(dbTx, &next)
+	})
+
+	if err != nil {
+
+		return err
+	}
+
+	b.snapshot = &next
+	return ErrUtxoSnapshotInvalid
+}
+
+// fetchBackgroundUtxos loads the outputs spent and created by the transactions in the passed block into the view from the utxo set in the passed bucket.  Outputs that are not in the set result in nil entries, so they are not looked up elsewhere.
+func (view *UtxoViewpoint) fetchBackgroundUtxos(
+	utxoBucket database.Bucket, block *util.Block) error {
+
+	fetch := func(outpoint wire.OutPoint) error {
+
+		if _, ok := view.entries[outpoint]; ok {
+
+			return nil
+		}
+
+		entry, err := dbFetchUtxoEntryFromBucket(utxoBucket, outpoint)
+
+		if err != nil {
+
+			return err
+		}
+
+		view.entries[outpoint] = entry
+		return nil
+	}
+
+	for i, tx := range block.Transactions() {
+
+		outpoint := wire.OutPoint{Hash: *tx.Hash()}
+
+		for txOutIdx := range tx.MsgTx().TxOut {
+
+			outpoint.Index = uint32(txOutIdx)
+
+			if err := fetch(outpoint); err != nil {
+
+				return err
+			}
+		}
+
+		// The coinbase has no inputs.
+		if i == 0 {
+
+			continue
+		}
+
+		for _, txIn := range tx.MsgTx().TxIn {
+
+			if err := fetch(txIn.PreviousOutPoint); err != nil {
+
+				return err
+			}
+		}
+	}
+
+	return nil
+}
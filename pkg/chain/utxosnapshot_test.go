@@ -0,0 +1,434 @@
+package blockchain
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"reflect"
+	"testing"
+	"time"
+
+	chaincfg "git.parallelcoin.io/dev/pod/pkg/chain/config"
+	chainhash "git.parallelcoin.io/dev/pod/pkg/chain/hash"
+	"git.parallelcoin.io/dev/pod/pkg/chain/wire"
+	database "git.parallelcoin.io/dev/pod/pkg/db"
+	"git.parallelcoin.io/dev/pod/pkg/util"
+)
+
+// TestUtxoSnapshotHeaderSerialization ensures utxo snapshot headers round trip and malformed headers are rejected.
+func TestUtxoSnapshotHeaderSerialization(
+	t *testing.T) {
+
+	header := &utxoSnapshotHeader{
+
+		version:   utxoSnapshotVersion,
+		net:       wire.MainNet,
+		height:    123456,
+		hash:      *newHashFromStr("000000000019d6689c085ae165831e934ff763ae46a2a6c172b3f1b60a8ce26f"),
+		totalTxns: 7890,
+		numUtxos:  4567,
+	}
+
+	serialized := serializeUtxoSnapshotHeader(header)
+
+	if len(serialized) != utxoSnapshotHeaderSize {
+
+		t.Fatalf("serialized header is %d bytes, want %d", len(serialized),
+			utxoSnapshotHeaderSize)
+	}
+
+	got, err := deserializeUtxoSnapshotHeader(serialized)
+
+	if err != nil {
+
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(got, header) {
+
+		t.Fatalf("mismatched header: got %+v, want %+v", got, header)
+	}
+
+	badMagic := append([]byte(nil), serialized...)
+	badMagic[0] = 'x'
+	badVersion := serializeUtxoSnapshotHeader(&utxoSnapshotHeader{
+
+		version: utxoSnapshotVersion + 1,
+	})
+
+	tests := []struct {
+		name       string
+		serialized []byte
+	}{
+		{name: "short", serialized: serialized[:utxoSnapshotHeaderSize-1]},
+		{name: "bad magic", serialized: badMagic},
+		{name: "unsupported version", serialized: badVersion},
+	}
+
+	for _, test := range tests {
+
+		if _, err := deserializeUtxoSnapshotHeader(test.serialized); err == nil {
+
+			t.Errorf("%s: expected an error", test.name)
+		}
+	}
+}
+
+// TestUtxoSetHasher ensures the utxo records written while hashing a utxo set read back to the same outputs, and the details of the set are totalled correctly.
+func TestUtxoSetHasher(
+	t *testing.T) {
+
+	txA := *newHashFromStr("0437cd7f8525ceed2324359c2d0ba26006d92d856a9c20fa0241106ee5a597c9")
+	txB := *newHashFromStr("a1075db55d416d3ca199f55b6084e2115b9345e16c5cf302fc80e9d5fbf5d48d")
+	entries := []struct {
+		outpoint wire.OutPoint
+		entry    *UtxoEntry
+	}{
+		{
+			outpoint: wire.OutPoint{Hash: txA, Index: 0},
+			entry: &UtxoEntry{
+
+				amount:      5000000000,
+				pkScript:    hexToBytes("410411db93e1dcdb8a016b49840f8c53bc1eb68a382e97b1482ecad7b148a6909a5cb2e0eaddfb84ccf9744464f82e160bfa9b8b64f9d4c03f999b8643f656b412a3ac"),
+				blockHeight: 9,
+				packedFlags: tfCoinBase,
+			},
+		},
+		{
+			outpoint: wire.OutPoint{Hash: txA, Index: 300},
+			entry: &UtxoEntry{
+
+				amount:      1000000,
+				pkScript:    hexToBytes("76a914ee8bd501094a7d5ca318da2506de35e1cb025ddc88ac"),
+				blockHeight: 9,
+				packedFlags: tfCoinBase,
+			},
+		},
+		{
+			outpoint: wire.OutPoint{Hash: txB, Index: 1},
+			entry: &UtxoEntry{
+
+				amount:      13761000000,
+				pkScript:    hexToBytes("76a914b2fb57eadf61e106a100a7445a8c3f67898841ec88ac"),
+				blockHeight: 100001,
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	hasher := newUtxoSetHasher(&buf)
+	serializedUtxos := make([][]byte, len(entries))
+
+	for i, test := range entries {
+
+		serialized, err := serializeUtxoEntry(test.entry)
+
+		if err != nil {
+
+			t.Fatalf("unexpected serialize error: %v", err)
+		}
+
+		serializedUtxos[i] = serialized
+
+		if err := hasher.add(test.outpoint, serialized); err != nil {
+
+			t.Fatalf("unexpected add error: %v", err)
+		}
+	}
+
+	info := hasher.finish()
+
+	if info.Transactions != 2 || info.Outputs != 3 ||
+		info.TotalAmount != 5000000000+1000000+13761000000 {
+
+		t.Fatalf("mismatched totals: %+v", info)
+	}
+
+	if info.SerializedSize != int64(buf.Len()) {
+
+		t.Fatalf("serialized size is %d, want %d", info.SerializedSize,
+			buf.Len())
+	}
+
+	first := sha256.Sum256(buf.Bytes())
+	want := chainhash.Hash(sha256.Sum256(first[:]))
+
+	if info.SetHash != want {
+
+		t.Fatalf("set hash is %s, want %s", info.SetHash, want)
+	}
+
+	for i, test := range entries {
+
+		outpoint, serialized, err := readUtxoRecord(&buf)
+
+		if err != nil {
+
+			t.Fatalf("unexpected read error: %v", err)
+		}
+
+		if outpoint != test.outpoint {
+
+			t.Errorf("record %d: outpoint is %v, want %v", i, outpoint,
+				test.outpoint)
+		}
+
+		if !bytes.Equal(serialized, serializedUtxos[i]) {
+
+			t.Errorf("record %d: mismatched serialized utxo", i)
+		}
+	}
+
+	if buf.Len() != 0 {
+
+		t.Fatalf("%d bytes left after reading the records", buf.Len())
+	}
+}
+
+// TestSnapshotStateSerialization ensures the state of the background validation of a utxo snapshot round trips and corrupt states are rejected.
+func TestSnapshotStateSerialization(
+	t *testing.T) {
+
+	tests := []*snapshotState{
+		{
+			height:  1000,
+			setHash: *newHashFromStr("000000000019d6689c085ae165831e934ff763ae46a2a6c172b3f1b60a8ce26f"),
+		},
+		{
+			height:    1000,
+			validated: 999,
+			failed:    true,
+		},
+	}
+
+	for i, state := range tests {
+
+		serialized := serializeSnapshotState(state)
+		got, err := deserializeSnapshotState(serialized)
+
+		if err != nil {
+
+			t.Fatalf("test %d: unexpected error: %v", i, err)
+		}
+
+		if !reflect.DeepEqual(got, state) {
+
+			t.Fatalf("test %d: got %+v, want %+v", i, got, state)
+		}
+
+		if _, err := deserializeSnapshotState(serialized[1:]); err == nil {
+
+			t.Fatalf("test %d: expected an error for a corrupt state", i)
+		}
+	}
+}
+
+// TestStoreUtxoSet ensures the utxo records of a snapshot are stored in batches with the same details as when they were written, and that the utxos of a snapshot that was interrupted while they were stored are removed when the chain is opened again.
+func TestStoreUtxoSet(
+	t *testing.T) {
+
+	chain, teardownFunc, err := chainSetup("storeutxoset",
+		&chaincfg.MainNetParams)
+
+	if err != nil {
+
+		t.Fatalf("Failed to setup chain instance: %v", err)
+	}
+
+	defer teardownFunc()
+
+	var buf bytes.Buffer
+	writer := newUtxoSetHasher(&buf)
+	const numUtxos = 5
+
+	for i := uint32(0); i < numUtxos; i++ {
+
+		serialized, err := serializeUtxoEntry(&UtxoEntry{
+
+			amount:      int64(i+1) * 1000,
+			pkScript:    hexToBytes("76a914ee8bd501094a7d5ca318da2506de35e1cb025ddc88ac"),
+			blockHeight: 100,
+		})
+
+		if err != nil {
+
+			t.Fatalf("unexpected serialize error: %v", err)
+		}
+
+		outpoint := wire.OutPoint{Hash: chainhash.Hash{byte(i)}, Index: i}
+
+		if err := writer.add(outpoint, serialized); err != nil {
+
+			t.Fatalf("unexpected add error: %v", err)
+		}
+	}
+
+	want := writer.finish()
+	info, err := chain.storeUtxoSet(&buf, numUtxos, 2)
+
+	if err != nil {
+
+		t.Fatalf("storeUtxoSet: unexpected error: %v", err)
+	}
+
+	if *info != *want {
+
+		t.Fatalf("storeUtxoSet: got %+v, want %+v", info, want)
+	}
+
+	countUtxos := func() (count int, loading bool) {
+
+		err := chain.db.View(func(dbTx database.Tx) error {
+
+			meta := dbTx.Metadata()
+			loading = meta.Get(snapshotLoadingKeyName) != nil
+			return forEachUtxo(meta.Bucket(utxoSetBucketName),
+				func(wire.OutPoint, []byte) error {
+
+					count++
+					return nil
+				})
+		})
+
+		if err != nil {
+
+			t.Fatalf("unexpected error reading the utxo set: %v", err)
+		}
+
+		return count, loading
+	}
+
+	if count, loading := countUtxos(); count != numUtxos || !loading {
+
+		t.Fatalf("got %d stored utxos with loading mark %v, want %d with "+
+			"the mark", count, loading, numUtxos)
+	}
+
+	// The snapshot was not loaded, so opening the chain removes its utxos.
+	if err := chain.loadSnapshotState(); err != nil {
+
+		t.Fatalf("loadSnapshotState: unexpected error: %v", err)
+	}
+
+	if count, loading := countUtxos(); count != 0 || loading {
+
+		t.Fatalf("got %d utxos with loading mark %v after opening the "+
+			"chain, want none", count, loading)
+	}
+}
+
+// TestBackgroundValidationFailure ensures an invalid block before a utxo snapshot and a utxo set that does not match the snapshot both fail the background validation for good, so the chain refuses to validate more blocks and to be opened again.
+func TestBackgroundValidationFailure(
+	t *testing.T) {
+
+	tests := []struct {
+		name     string
+		lockTime uint32
+	}{
+		// The coinbase is not final at height 1, which is a rule error.
+		{name: "invalid block", lockTime: 1000},
+		// The block is valid, but the utxo set it builds is not the one of the snapshot.
+		{name: "utxo set mismatch"},
+	}
+
+	for _, test := range tests {
+
+		chain, teardownFunc, err := chainSetup("backgroundfailure",
+			&chaincfg.RegressionNetParams)
+
+		if err != nil {
+
+			t.Fatalf("Failed to setup chain instance: %v", err)
+		}
+
+		// Store a block at height 1 that is part of the main chain but not connected to its utxo set, as blocks before a loaded snapshot are.
+		genesis := chain.bestChain.Tip()
+		coinbase := wire.NewMsgTx(1)
+		coinbase.AddTxIn(&wire.TxIn{
+
+			PreviousOutPoint: wire.OutPoint{Index: wire.MaxPrevOutIndex},
+			SignatureScript:  []byte{0x51, 0x51},
+			Sequence:         0,
+		})
+		coinbase.AddTxOut(&wire.TxOut{Value: 1, PkScript: []byte{0x51}})
+		coinbase.LockTime = test.lockTime
+		msgBlock := &wire.MsgBlock{
+
+			Header: wire.BlockHeader{
+
+				Version:   1,
+				PrevBlock: genesis.hash,
+				Timestamp: genesis.Header().Timestamp.Add(time.Second),
+				Bits:      genesis.bits,
+			},
+			Transactions: []*wire.MsgTx{coinbase},
+		}
+		block := util.NewBlock(msgBlock)
+		block.SetHeight(1)
+		node := newBlockNode(&msgBlock.Header, genesis, chain.HardForks())
+
+		err = chain.db.Update(func(dbTx database.Tx) error {
+
+			_, err := dbTx.Metadata().CreateBucket(backgroundUtxoSetBucketName)
+
+			if err != nil {
+
+				return err
+			}
+
+			return dbStoreBlock(dbTx, block)
+		})
+
+		if err != nil {
+
+			teardownFunc()
+			t.Fatalf("%s: unexpected error storing the block: %v", test.name,
+				err)
+		}
+
+		chain.Index.AddNode(node)
+		chain.Index.SetStatusFlags(node, statusDataStored|statusValid)
+		chain.bestChain.SetTip(node)
+		chain.snapshot = &snapshotState{height: 1}
+
+		chain.chainLock.Lock()
+		err = chain.connectBackgroundBlocks()
+		chain.chainLock.Unlock()
+
+		if err != ErrUtxoSnapshotInvalid {
+
+			teardownFunc()
+			t.Fatalf("%s: got error %v, want %v", test.name, err,
+				ErrUtxoSnapshotInvalid)
+		}
+
+		want := SnapshotValidationState{Height: 1, Failed: true}
+
+		if got := chain.SnapshotValidation(); got == nil || got.Height !=
+			want.Height || !got.Failed {
+
+			teardownFunc()
+			t.Fatalf("%s: got validation state %+v, want %+v", test.name,
+				got, want)
+		}
+
+		if err := chain.ProcessBackgroundBlock(block); err !=
+			ErrUtxoSnapshotInvalid {
+
+			teardownFunc()
+			t.Fatalf("%s: ProcessBackgroundBlock: got error %v, want %v",
+				test.name, err, ErrUtxoSnapshotInvalid)
+		}
+
+		// The failure is persisted, so the chain refuses to be opened again.
+		chain.snapshot = nil
+
+		if err := chain.loadSnapshotState(); err != ErrUtxoSnapshotInvalid {
+
+			teardownFunc()
+			t.Fatalf("%s: loadSnapshotState: got error %v, want %v",
+				test.name, err, ErrUtxoSnapshotInvalid)
+		}
+
+		teardownFunc()
+	}
+}
@@ -71,10 +71,11 @@ func (
 	height := block.Height()
 
 	// log <- cl.Info{"height", height}
-	algoname := fork.GetAlgoName(algo, height)
+	forks := b.HardForks()
+	algoname := forks.GetAlgoName(algo, height)
 
 	// log <- cl.Info{"algoname", algoname}
-	powLimit := fork.GetMinDiff(algoname, height)
+	powLimit := forks.GetMinDiff(algoname, height)
 
 	// log <- cl.Infof{"powLimit %064x", powLimit}
 
@@ -90,7 +91,7 @@ func (
 		str := fmt.Sprintf("previous block must be the current chain tip %v, instead got %v", tip.hash, header.PrevBlock)
 		return ruleError(ErrPrevBlockNotBest, str)
 	}
-	err := checkBlockSanity(block, powLimit, b.timeSource, flags, true, block.Height())
+	err := checkBlockSanity(block, powLimit, b.timeSource, flags, true, block.Height(), forks)
 
 	if err != nil {
 
@@ -108,8 +109,8 @@ func (
 	// Leave the spent txouts entry nil in the state since the information is not needed and thus extra work can be avoided.
 	view := NewUtxoViewpoint()
 	view.SetBestHash(&tip.hash)
-	newNode := newBlockNode(&header, tip)
-	return b.checkConnectBlock(newNode, block, view, nil)
+	newNode := newBlockNode(&header, tip, forks)
+	return b.checkConnectBlock(newNode, block, view, nil, BFNone)
 }
 
 // checkBIP0030 ensures blocks do not contain duplicate transactions which 'overwrite' older transactions that are not fully spent.  This prevents an attack where a coinbase and all of its dependent transactions could be duplicated to effectively revert the overwritten transactions to a single confirmation thereby making them vulnerable to a double spend.
@@ -183,77 +184,93 @@ func (
 
 	if !fastAdd {
 
-		// Obtain the latest state of the deployed CSV soft-fork in order to properly guard the new validation behavior based on the current BIP 9 version bits state.
-		csvState, err := b.deploymentState(prevNode, chaincfg.DeploymentCSV)
-
-		if err != nil {
+		return b.checkBlockTransactionsContext(block, prevNode)
+	}
+	return nil
+}
 
-			return err
-		}
-		// Once the CSV soft-fork is fully active, we'll switch to using the current median time past of the past block's timestamps for all lock-time based checks.
-		blockTime := header.Timestamp
+// checkBlockTransactionsContext performs the validation checks of checkBlockContext on the transactions of the block which depend on its position within the block chain, which are whether the transactions are finalized, the BIP0034 coinbase height and the witness commitment and weight once segwit is active.
+// This function MUST be called with the chain state lock held (for writes).
+func (
+	b *BlockChain,
+) checkBlockTransactionsContext(
+	block *util.Block,
+	prevNode *blockNode,
 
-		if csvState == ThresholdActive {
+) error {
 
-			blockTime = prevNode.CalcPastMedianTime()
-		}
-		// The height of this block is one more than the referenced previous block.
-		blockHeight := prevNode.height + 1
-		// Ensure all transactions in the block are finalized.
+	header := &block.MsgBlock().Header
 
-		for _, tx := range block.Transactions() {
+	// Obtain the latest state of the deployed CSV soft-fork in order to properly guard the new validation behavior based on the current BIP 9 version bits state.
+	csvState, err := b.deploymentState(prevNode, chaincfg.DeploymentCSV)
 
-			if !IsFinalizedTransaction(tx, blockHeight,
+	if err != nil {
 
-				blockTime) {
+		return err
+	}
+	// Once the CSV soft-fork is fully active, we'll switch to using the current median time past of the past block's timestamps for all lock-time based checks.
+	blockTime := header.Timestamp
 
-				str := fmt.Sprintf("block contains unfinalized "+
-					"transaction %v", tx.Hash())
-				return ruleError(ErrUnfinalizedTx, str)
-			}
-		}
-		// Ensure coinbase starts with serialized block heights for blocks whose version is the serializedHeightVersion or newer once a majority of the network has upgraded.  This is part of BIP0034.
+	if csvState == ThresholdActive {
 
-		if ShouldHaveSerializedBlockHeight(header) &&
+		blockTime = prevNode.CalcPastMedianTime()
+	}
+	// The height of this block is one more than the referenced previous block.
+	blockHeight := prevNode.height + 1
+	// Ensure all transactions in the block are finalized.
 
-			blockHeight >= b.chainParams.BIP0034Height {
+	for _, tx := range block.Transactions() {
 
-			coinbaseTx := block.Transactions()[0]
-			err := checkSerializedHeight(coinbaseTx, blockHeight)
+		if !IsFinalizedTransaction(tx, blockHeight,
 
-			if err != nil {
+			blockTime) {
 
-				return err
-			}
+			str := fmt.Sprintf("block contains unfinalized "+
+				"transaction %v", tx.Hash())
+			return ruleError(ErrUnfinalizedTx, str)
 		}
-		// Query for the Version Bits state for the segwit soft-fork deployment. If segwit is active, we'll switch over to enforcing all the new rules.
-		segwitState, err := b.deploymentState(prevNode,
-			chaincfg.DeploymentSegwit)
+	}
+	// Ensure coinbase starts with serialized block heights for blocks whose version is the serializedHeightVersion or newer once a majority of the network has upgraded.  This is part of BIP0034.
+
+	if ShouldHaveSerializedBlockHeight(header) &&
+
+		blockHeight >= b.chainParams.BIP0034Height {
+
+		coinbaseTx := block.Transactions()[0]
+		err := checkSerializedHeight(coinbaseTx, blockHeight)
 
 		if err != nil {
 
 			return err
 		}
-		// If segwit is active, then we'll need to fully validate the new witness commitment for adherence to the rules.
+	}
+	// Query for the Version Bits state for the segwit soft-fork deployment. If segwit is active, we'll switch over to enforcing all the new rules.
+	segwitState, err := b.deploymentState(prevNode,
+		chaincfg.DeploymentSegwit)
 
-		if segwitState == ThresholdActive {
+	if err != nil {
 
-			// Validate the witness commitment (if any) within the block.  This involves asserting that if the coinbase contains the special commitment output, then this merkle root matches a computed merkle root of all the wtxid's of the transactions within the block. In addition, various other checks against the coinbase's witness stack.
+		return err
+	}
+	// If segwit is active, then we'll need to fully validate the new witness commitment for adherence to the rules.
 
-			if err := ValidateWitnessCommitment(block); err != nil {
+	if segwitState == ThresholdActive {
 
-				return err
-			}
-			// Once the witness commitment, witness nonce, and sig op cost have been validated, we can finally assert that the block's weight doesn't exceed the current consensus parameter.
-			blockWeight := GetBlockWeight(block)
+		// Validate the witness commitment (if any) within the block.  This involves asserting that if the coinbase contains the special commitment output, then this merkle root matches a computed merkle root of all the wtxid's of the transactions within the block. In addition, various other checks against the coinbase's witness stack.
 
-			if blockWeight > MaxBlockWeight {
+		if err := ValidateWitnessCommitment(block); err != nil {
 
-				str := fmt.Sprintf(
-					"block's weight metric is too high - got %v, max %v",
-					blockWeight, MaxBlockWeight)
-				return ruleError(ErrBlockWeightTooHigh, str)
-			}
+			return err
+		}
+		// Once the witness commitment, witness nonce, and sig op cost have been validated, we can finally assert that the block's weight doesn't exceed the current consensus parameter.
+		blockWeight := GetBlockWeight(block)
+
+		if blockWeight > MaxBlockWeight {
+
+			str := fmt.Sprintf(
+				"block's weight metric is too high - got %v, max %v",
+				blockWeight, MaxBlockWeight)
+			return ruleError(ErrBlockWeightTooHigh, str)
 		}
 	}
 	return nil
@@ -280,7 +297,7 @@ func (
 	if !fastAdd {
 
 		// Ensure the difficulty specified in the block header matches the calculated difficulty based on the previous block and difficulty retarget rules.
-		a := fork.GetAlgoName(header.Version, prevNode.height+1)
+		a := b.HardForks().GetAlgoName(header.Version, prevNode.height+1)
 
 		log <- cl.Infof{
 			"algo %s %d %8x %d", a, header.Version, header.Bits, prevNode.height + 1}
@@ -363,7 +380,8 @@ func (
 	b *BlockChain,
 ) checkConnectBlock(
 
-	node *blockNode, block *util.Block, view *UtxoViewpoint, stxos *[]SpentTxOut) error {
+	node *blockNode, block *util.Block, view *UtxoViewpoint, stxos *[]SpentTxOut,
+	flags BehaviorFlags) error {
 
 	// If the side chain blocks end up in the database, a call to CheckBlockSanity should be done here in case a previous version allowed a block that is no longer valid.  However, since the implementation only currently uses memory for the side chain blocks, it isn't currently necessary. The coinbase for the Genesis block is not spendable, so just return an error now.
 
@@ -498,6 +516,12 @@ func (
 		runScripts = false
 	}
 
+	// Likewise don't run scripts for the assumed valid block and its ancestors, either when the caller has already linked the block to the assumed valid block through the headers or when the assumed valid block is in the index with this node as its ancestor.  Every other check is still performed, so a block that is not in the assumed valid chain is fully validated.
+	if flags&BFAssumeValid == BFAssumeValid || b.isAssumeValidAncestor(node) {
+
+		runScripts = false
+	}
+
 	// Blocks created after the BIP0016 activation time need to have the pay-to-script-hash checks enabled.
 	var scriptFlags txscript.ScriptFlags
 
@@ -574,7 +598,7 @@ func (
 	if runScripts {
 
 		err := checkBlockScripts(block, view, scriptFlags, b.sigCache,
-			b.hashCache)
+			b.hashCache, b.scriptThreads)
 
 		if err != nil {
 
@@ -602,7 +626,7 @@ func CalcBlockSubsidy(
 
 	// Equivalent to: baseSubsidy / 2^(height/subsidyHalvingInterval)
 
-	switch fork.GetCurrent(height) {
+	switch HardForkSchedule(chainParams).GetCurrent(height) {
 
 	case 0:
 		return baseSubsidy >> uint(height/chainParams.SubsidyReductionInterval)
@@ -613,23 +637,24 @@ func CalcBlockSubsidy(
 	return
 }
 
-// CheckBlockSanity performs some preliminary checks on a block to ensure it is sane before continuing with block processing.  These checks are context free.
+// CheckBlockSanity performs some preliminary checks on a block to ensure it is sane before continuing with block processing.  These checks are context free apart from the hard fork schedule of the network, which selects the algorithm and minimum difficulty at the height of the block.
 func CheckBlockSanity(
 
-	block *util.Block, powLimit *big.Int, timeSource MedianTimeSource, DoNotCheckPow bool, height int32, testnet bool) error {
+	block *util.Block, powLimit *big.Int, timeSource MedianTimeSource, DoNotCheckPow bool, height int32, forks fork.Schedule) error {
 
-	return checkBlockSanity(block, powLimit, timeSource, BFNone, DoNotCheckPow, height)
+	return checkBlockSanity(block, powLimit, timeSource, BFNone, DoNotCheckPow, height, forks)
 }
 
-// CheckProofOfWork ensures the block header bits which indicate the target difficulty is in min/max range and that the block hash is less than the target difficulty as claimed.
+// CheckProofOfWork ensures the block header bits which indicate the target difficulty is in min/max range and that the block hash is less than the target difficulty as claimed, using the algorithm the passed hard fork schedule assigns to the block version at the height.
 func CheckProofOfWork(
 	block *util.Block,
 	powLimit *big.Int,
 	height int32,
+	forks fork.Schedule,
 
 ) error {
 
-	return checkProofOfWork(&block.MsgBlock().Header, powLimit, BFNone, height)
+	return checkProofOfWork(&block.MsgBlock().Header, powLimit, BFNone, height, forks)
 }
 
 // CheckTransactionInputs performs a series of checks on the inputs to a transaction to ensure they are valid.  An example of some of the checks include verifying all inputs exist, ensuring the coinbase seasoning requirements are met, detecting double spends, validating all values and fees are in the legal range and the total output amount doesn't exceed the input amount, and verifying the signatures to prove the spender was the owner of the bitcoins and therefore allowed to spend them.  As it checks the inputs, it also calculates the total fees for the transaction and returns that value.
@@ -1078,15 +1103,15 @@ func ShouldHaveSerializedBlockHeight(
 	return header.Version >= serializedHeightVersion
 }
 
-// checkBlockHeaderSanity performs some preliminary checks on a block header to ensure it is sane before continuing with processing.  These checks are context free. The flags do not modify the behavior of this function directly, however they are needed to pass along to checkProofOfWork.
+// checkBlockHeaderSanity performs some preliminary checks on a block header to ensure it is sane before continuing with processing.  These checks are context free. The flags and hard fork schedule do not modify the behavior of this function directly, however they are needed to pass along to checkProofOfWork.
 func checkBlockHeaderSanity(
 
-	header *wire.BlockHeader, powLimit *big.Int, timeSource MedianTimeSource, flags BehaviorFlags, height int32, testnet bool) error {
+	header *wire.BlockHeader, powLimit *big.Int, timeSource MedianTimeSource, flags BehaviorFlags, height int32, forks fork.Schedule) error {
 
 	log <- cl.Trc("checkBlockHeaderSanity")
 
 	// Ensure the proof of work bits in the block header is in min/max range and the block hash is less than the target value described by the bits.
-	err := checkProofOfWork(header, powLimit, flags, height)
+	err := checkProofOfWork(header, powLimit, flags, height, forks)
 
 	if err != nil {
 
@@ -1118,13 +1143,13 @@ func checkBlockHeaderSanity(
 // checkBlockSanity performs some preliminary checks on a block to ensure it is sane before continuing with block processing.  These checks are context free. The flags do not modify the behavior of this function directly, however they are needed to pass along to checkBlockHeaderSanity.
 func checkBlockSanity(
 
-	block *util.Block, powLimit *big.Int, timeSource MedianTimeSource, flags BehaviorFlags, DoNotCheckPow bool, height int32) error {
+	block *util.Block, powLimit *big.Int, timeSource MedianTimeSource, flags BehaviorFlags, DoNotCheckPow bool, height int32, forks fork.Schedule) error {
 
 	log <- cl.Trc("checkBlockSanity")
 
 	msgBlock := block.MsgBlock()
 	header := &msgBlock.Header
-	err := checkBlockHeaderSanity(header, powLimit, timeSource, flags, height, fork.IsTestnet)
+	err := checkBlockHeaderSanity(header, powLimit, timeSource, flags, height, forks)
 
 	if err != nil {
 
@@ -1247,11 +1272,13 @@ func checkBlockSanity(
 
 // checkProofOfWork ensures the block header bits which indicate the target difficulty is in min/max range and that the block hash is less than the target difficulty as claimed. The flags modify the behavior of this function as follows:
 //  - BFNoPoWCheck: The check to ensure the block hash is less than the target difficulty is not performed.
+// The hash of the header is computed with the algorithm the hard fork schedule assigns to its version at the given height.
 func checkProofOfWork(
 	header *wire.BlockHeader,
 	powLimit *big.Int,
 	flags BehaviorFlags,
 	height int32,
+	forks fork.Schedule,
 
 ) error {
 
@@ -1289,7 +1316,7 @@ func checkProofOfWork(
 	if flags&BFNoPoWCheck != BFNoPoWCheck {
 
 		// The block hash must be less than the claimed target. Unless there is less than 10 previous with the same version (algo)...
-		hash := header.BlockHashWithAlgos(height)
+		hash := header.BlockHashWithSchedule(forks, height)
 		// log <- cl.Debug{"blockhashwithalgos", hash}
 		hashNum := HashToBig(&hash)
 
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	chaincfg "git.parallelcoin.io/dev/pod/pkg/chain/config"
+	"git.parallelcoin.io/dev/pod/pkg/chain/fork"
 	chainhash "git.parallelcoin.io/dev/pod/pkg/chain/hash"
 	"git.parallelcoin.io/dev/pod/pkg/chain/wire"
 	"git.parallelcoin.io/dev/pod/pkg/util"
@@ -184,7 +185,7 @@ func TestCheckBlockSanity(
 	powLimit := chaincfg.MainNetParams.PowLimit
 	block := util.NewBlock(&Block100000)
 	timeSource := NewMedianTime()
-	err := CheckBlockSanity(block, powLimit, timeSource, false, 1, false)
+	err := CheckBlockSanity(block, powLimit, timeSource, false, 1, fork.List)
 
 	if err != nil {
 
@@ -196,7 +197,7 @@ func TestCheckBlockSanity(
 	// second fails.
 	timestamp := block.MsgBlock().Header.Timestamp
 	block.MsgBlock().Header.Timestamp = timestamp.Add(time.Nanosecond)
-	err = CheckBlockSanity(block, powLimit, timeSource, false, 1, false)
+	err = CheckBlockSanity(block, powLimit, timeSource, false, 1, fork.List)
 
 	if err == nil {
 
@@ -48,15 +48,16 @@ func (h *BlockHeader) BlockHash() (out chainhash.Hash) {
 	return
 }
 
-// BlockHashWithAlgos computes the block identifier hash for the given block header. This function is additional because the sync manager and the parallelcoin protocol only use SHA256D hashes for inventories and calculating the scrypt (or other) hash for these blocks when requested via that route causes an 'unrequested block' error.
-func (h *BlockHeader) BlockHashWithAlgos(height int32) (out chainhash.Hash) {
+// BlockHashWithSchedule computes the proof of work hash for the given block header using the algorithm that the given hard fork schedule assigns to the header version at the given height. This function is additional because the sync manager and the parallelcoin protocol only use SHA256D hashes for inventories and calculating the scrypt (or other) hash for these blocks when requested via that route causes an 'unrequested block' error.
+func (h *BlockHeader) BlockHashWithSchedule(
+	forks fork.Schedule, height int32) (out chainhash.Hash) {
 
 	// Encode the header and double sha256 everything prior to the number of transactions.  Ignore the error returns since there is no way the encode could fail except being out of memory which would cause a run-time panic.
 	buf := bytes.NewBuffer(make([]byte, 0, MaxBlockHeaderPayload))
 	_ = writeBlockHeader(buf, 0, h)
 	vers := h.Version
-	algo := fork.GetAlgoName(vers, height)
-	out = fork.Hash(buf.Bytes(), algo, height)
+	algo := forks.GetAlgoName(vers, height)
+	out = forks.Hash(buf.Bytes(), algo, height)
 	return
 }
 
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 
+	"git.parallelcoin.io/dev/pod/pkg/chain/fork"
 	chainhash "git.parallelcoin.io/dev/pod/pkg/chain/hash"
 )
 
@@ -236,10 +237,10 @@ func (msg *MsgBlock) BlockHash() chainhash.Hash {
 	return msg.Header.BlockHash()
 }
 
-// BlockHashWithAlgos computes the block identifier hash for this block.
-func (msg *MsgBlock) BlockHashWithAlgos(h int32) chainhash.Hash {
+// BlockHashWithSchedule computes the proof of work hash for this block using the algorithm the given hard fork schedule assigns to its version at the given height.
+func (msg *MsgBlock) BlockHashWithSchedule(forks fork.Schedule, h int32) chainhash.Hash {
 
-	return msg.Header.BlockHashWithAlgos(h)
+	return msg.Header.BlockHashWithSchedule(forks, h)
 }
 
 // TxHashes returns a slice of hashes of all of transactions in this block.
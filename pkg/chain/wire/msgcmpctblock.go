@@ -0,0 +1,310 @@
+package wire
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"io"
+
+	chainhash "git.parallelcoin.io/dev/pod/pkg/chain/hash"
+	"github.com/aead/siphash"
+)
+
+// ShortIDSize is the number of bytes of a short transaction id in a compact block.
+const ShortIDSize = 6
+
+// maxCmpctBlockTxs is the maximum number of transactions a compact block can describe, which is the same as a full block.
+const maxCmpctBlockTxs = maxTxPerBlock
+
+// PrefilledTx is a transaction sent in full in a compact block, usually the coinbase or transactions the receiving peer is unlikely to have, along with its index in the block.
+
+type PrefilledTx struct {
+	Index uint32
+	Tx    *MsgTx
+}
+
+// MsgCmpctBlock implements the Message interface and represents a bitcoin cmpctblock message.  It is used to relay a block as its header, the short transaction ids of most of its transactions and a few prefilled transactions, so that the receiving peer can rebuild the block from the transactions in its memory pool (BIP0152).  Transactions that are missing can be requested with a getblocktxn message (MsgGetBlockTxn).
+// The indexes of the prefilled transactions are the absolute indexes in the block, they are only differentially encoded on the wire.
+
+type MsgCmpctBlock struct {
+	Header       BlockHeader
+	Nonce        uint64
+	ShortIDs     []uint64
+	PrefilledTxs []PrefilledTx
+}
+
+// TxCount returns the number of transactions in the block described by the compact block.
+func (msg *MsgCmpctBlock) TxCount() int {
+
+	return len(msg.ShortIDs) + len(msg.PrefilledTxs)
+}
+
+// ShortIDKey returns the siphash key used to calculate the short transaction ids of the compact block, which is the first 16 bytes of the single sha256 of the block header and the nonce.
+func (msg *MsgCmpctBlock) ShortIDKey() [16]byte {
+
+	var buf bytes.Buffer
+	buf.Grow(blockHeaderLen + 8)
+
+	// Writing to a bytes.Buffer never fails.
+	_ = writeBlockHeader(&buf, 0, &msg.Header)
+	_ = writeElement(&buf, msg.Nonce)
+	hash := sha256.Sum256(buf.Bytes())
+	var key [16]byte
+	copy(key[:], hash[:16])
+	return key
+}
+
+// ShortTxID returns the short transaction id of the transaction with the passed hash for the siphash key of a compact block, which is the lower 6 bytes of the siphash-2-4 of the hash.
+func ShortTxID(
+	key *[16]byte, txHash *chainhash.Hash) uint64 {
+
+	return siphash.Sum64(txHash[:], key) & 0xffffffffffff
+}
+
+// BtcDecode decodes r using the bitcoin protocol encoding into the receiver. This is part of the Message interface implementation.
+func (msg *MsgCmpctBlock) BtcDecode(r io.Reader, pver uint32, enc MessageEncoding) error {
+
+	if pver < ShortIDsBlocksVersion {
+
+		str := fmt.Sprintf("cmpctblock message invalid for protocol "+
+			"version %d", pver)
+		return messageError("MsgCmpctBlock.BtcDecode", str)
+	}
+
+	err := readBlockHeader(r, pver, &msg.Header)
+
+	if err != nil {
+
+		return err
+	}
+
+	err = readElement(r, &msg.Nonce)
+
+	if err != nil {
+
+		return err
+	}
+
+	count, err := ReadVarInt(r, pver)
+
+	if err != nil {
+
+		return err
+	}
+
+	// Prevent more short ids than there could be transactions in a block.
+
+	if count > maxCmpctBlockTxs {
+
+		str := fmt.Sprintf("too many short ids for message "+
+			"[count %d, max %d]", count, maxCmpctBlockTxs)
+		return messageError("MsgCmpctBlock.BtcDecode", str)
+	}
+
+	msg.ShortIDs = make([]uint64, 0, count)
+
+	for i := uint64(0); i < count; i++ {
+
+		shortID, err := readShortID(r)
+
+		if err != nil {
+
+			return err
+		}
+		msg.ShortIDs = append(msg.ShortIDs, shortID)
+	}
+
+	count, err = ReadVarInt(r, pver)
+
+	if err != nil {
+
+		return err
+	}
+
+	if count+uint64(len(msg.ShortIDs)) > maxCmpctBlockTxs {
+
+		str := fmt.Sprintf("too many prefilled transactions for message "+
+			"[count %d, max %d]", count, maxCmpctBlockTxs-len(msg.ShortIDs))
+		return messageError("MsgCmpctBlock.BtcDecode", str)
+	}
+
+	msg.PrefilledTxs = make([]PrefilledTx, 0, count)
+	var index uint64
+
+	for i := uint64(0); i < count; i++ {
+
+		diff, err := ReadVarInt(r, pver)
+
+		if err != nil {
+
+			return err
+		}
+
+		index += diff
+
+		if index >= maxCmpctBlockTxs {
+
+			str := fmt.Sprintf("prefilled transaction index %d is out "+
+				"of range", index)
+			return messageError("MsgCmpctBlock.BtcDecode", str)
+		}
+
+		tx := MsgTx{}
+		err = tx.BtcDecode(r, pver, enc)
+
+		if err != nil {
+
+			return err
+		}
+		msg.PrefilledTxs = append(msg.PrefilledTxs,
+			PrefilledTx{Index: uint32(index), Tx: &tx})
+		index++
+	}
+	return nil
+}
+
+// BtcEncode encodes the receiver to w using the bitcoin protocol encoding. This is part of the Message interface implementation.
+func (msg *MsgCmpctBlock) BtcEncode(w io.Writer, pver uint32, enc MessageEncoding) error {
+
+	if pver < ShortIDsBlocksVersion {
+
+		str := fmt.Sprintf("cmpctblock message invalid for protocol "+
+			"version %d", pver)
+		return messageError("MsgCmpctBlock.BtcEncode", str)
+	}
+
+	if msg.TxCount() > maxCmpctBlockTxs {
+
+		str := fmt.Sprintf("too many transactions for message "+
+			"[count %d, max %d]", msg.TxCount(), maxCmpctBlockTxs)
+		return messageError("MsgCmpctBlock.BtcEncode", str)
+	}
+
+	err := writeBlockHeader(w, pver, &msg.Header)
+
+	if err != nil {
+
+		return err
+	}
+
+	err = writeElement(w, msg.Nonce)
+
+	if err != nil {
+
+		return err
+	}
+
+	err = WriteVarInt(w, pver, uint64(len(msg.ShortIDs)))
+
+	if err != nil {
+
+		return err
+	}
+
+	for _, shortID := range msg.ShortIDs {
+
+		err := writeShortID(w, shortID)
+
+		if err != nil {
+
+			return err
+		}
+	}
+
+	err = WriteVarInt(w, pver, uint64(len(msg.PrefilledTxs)))
+
+	if err != nil {
+
+		return err
+	}
+
+	// The indexes are written as the difference from the index after the previous prefilled transaction, so they must be in ascending order.
+	var next uint32
+
+	for _, prefilled := range msg.PrefilledTxs {
+
+		if prefilled.Index < next {
+
+			str := "prefilled transaction indexes are not in ascending order"
+			return messageError("MsgCmpctBlock.BtcEncode", str)
+		}
+
+		err := WriteVarInt(w, pver, uint64(prefilled.Index-next))
+
+		if err != nil {
+
+			return err
+		}
+
+		err = prefilled.Tx.BtcEncode(w, pver, enc)
+
+		if err != nil {
+
+			return err
+		}
+		next = prefilled.Index + 1
+	}
+	return nil
+}
+
+// Command returns the protocol command string for the message.  This is part of the Message interface implementation.
+func (msg *MsgCmpctBlock) Command() string {
+
+	return CmdCmpctBlock
+}
+
+// MaxPayloadLength returns the maximum length the payload can be for the receiver.  This is part of the Message interface implementation.
+func (msg *MsgCmpctBlock) MaxPayloadLength(pver uint32) uint32 {
+
+	// A compact block is never larger than the block it describes.
+	return MaxBlockPayload
+}
+
+// NewMsgCmpctBlock returns a new bitcoin cmpctblock message describing the passed block with the passed nonce, with the coinbase transaction prefilled and short ids for the rest of the transactions.  See MsgCmpctBlock for details.
+func NewMsgCmpctBlock(
+	block *MsgBlock, nonce uint64) *MsgCmpctBlock {
+
+	msg := &MsgCmpctBlock{
+		Header: block.Header,
+		Nonce:  nonce,
+	}
+
+	if len(block.Transactions) == 0 {
+
+		return msg
+	}
+
+	msg.PrefilledTxs = []PrefilledTx{{Index: 0, Tx: block.Transactions[0]}}
+	msg.ShortIDs = make([]uint64, 0, len(block.Transactions)-1)
+	key := msg.ShortIDKey()
+
+	for _, tx := range block.Transactions[1:] {
+
+		txHash := tx.TxHash()
+		msg.ShortIDs = append(msg.ShortIDs, ShortTxID(&key, &txHash))
+	}
+	return msg
+}
+
+// readShortID reads a 6 byte little endian short transaction id from r.
+func readShortID(
+	r io.Reader) (uint64, error) {
+
+	var buf [8]byte
+
+	if _, err := io.ReadFull(r, buf[:ShortIDSize]); err != nil {
+
+		return 0, err
+	}
+	return littleEndian.Uint64(buf[:]), nil
+}
+
+// writeShortID writes the lower 6 bytes of a short transaction id to w in little endian order.
+func writeShortID(
+	w io.Writer, shortID uint64) error {
+
+	var buf [8]byte
+	littleEndian.PutUint64(buf[:], shortID)
+	_, err := w.Write(buf[:ShortIDSize])
+	return err
+}
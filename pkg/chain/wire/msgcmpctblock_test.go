@@ -0,0 +1,190 @@
+package wire
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	chainhash "git.parallelcoin.io/dev/pod/pkg/chain/hash"
+	"github.com/davecgh/go-spew/spew"
+)
+
+// TestSendCmpctWire tests the MsgSendCmpct wire encode and decode and that it is rejected before ShortIDsBlocksVersion.
+func TestSendCmpctWire(
+	t *testing.T) {
+
+	msg := NewMsgSendCmpct(true, CmpctBlockVersion)
+	wantBuf := []byte{0x01, 0x01, 0, 0, 0, 0, 0, 0, 0}
+	var buf bytes.Buffer
+
+	if err := msg.BtcEncode(&buf, ProtocolVersion, BaseEncoding); err != nil {
+
+		t.Fatalf("BtcEncode: unexpected error: %v", err)
+	}
+
+	if !bytes.Equal(buf.Bytes(), wantBuf) {
+
+		t.Fatalf("BtcEncode: got %x, want %x", buf.Bytes(), wantBuf)
+	}
+
+	var readmsg MsgSendCmpct
+
+	if err := readmsg.BtcDecode(&buf, ProtocolVersion, BaseEncoding); err != nil {
+
+		t.Fatalf("BtcDecode: unexpected error: %v", err)
+	}
+
+	if readmsg != *msg {
+
+		t.Fatalf("BtcDecode: got %v, want %v", readmsg, *msg)
+	}
+
+	if err := msg.BtcEncode(&buf, FeeFilterVersion, BaseEncoding); err == nil {
+
+		t.Fatalf("BtcEncode: expected an error for protocol version %d",
+			FeeFilterVersion)
+	}
+}
+
+// TestCmpctBlockWire tests that compact blocks round trip through the wire encoding with their prefilled transaction indexes intact, and that the short ids match the transactions of the block.
+func TestCmpctBlockWire(
+	t *testing.T) {
+
+	// Build a block of the transaction of block one and a few variations of it.
+	block := NewMsgBlock(&blockOne.Header)
+	block.AddTransaction(blockOne.Transactions[0])
+
+	for i := uint32(1); i < 5; i++ {
+
+		tx := blockOne.Transactions[0].Copy()
+		tx.LockTime = i
+		block.AddTransaction(tx)
+	}
+
+	msg := NewMsgCmpctBlock(block, 0x0102030405060708)
+
+	if msg.TxCount() != len(block.Transactions) || len(msg.PrefilledTxs) != 1 {
+
+		t.Fatalf("NewMsgCmpctBlock: got %d short ids and %d prefilled "+
+			"transactions", len(msg.ShortIDs), len(msg.PrefilledTxs))
+	}
+
+	// Prefill a transaction in the middle as well to test the differential encoding of the indexes.
+	msg.PrefilledTxs = append(msg.PrefilledTxs,
+		PrefilledTx{Index: 3, Tx: block.Transactions[3]})
+	msg.ShortIDs = append(msg.ShortIDs[:2], msg.ShortIDs[3:]...)
+
+	var buf bytes.Buffer
+
+	if err := msg.BtcEncode(&buf, ProtocolVersion, BaseEncoding); err != nil {
+
+		t.Fatalf("BtcEncode: unexpected error: %v", err)
+	}
+
+	var readmsg MsgCmpctBlock
+
+	if err := readmsg.BtcDecode(&buf, ProtocolVersion, BaseEncoding); err != nil {
+
+		t.Fatalf("BtcDecode: unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(&readmsg, msg) {
+
+		t.Fatalf("BtcDecode: got %v, want %v", spew.Sdump(&readmsg),
+			spew.Sdump(msg))
+	}
+
+	key := readmsg.ShortIDKey()
+
+	for i, txIndex := range []int{1, 2, 4} {
+
+		txHash := block.Transactions[txIndex].TxHash()
+		shortID := ShortTxID(&key, &txHash)
+
+		if shortID>>48 != 0 {
+
+			t.Fatalf("short id %x is more than 6 bytes", shortID)
+		}
+
+		if readmsg.ShortIDs[i] != shortID {
+
+			t.Fatalf("short id %d: got %x, want %x", i, readmsg.ShortIDs[i],
+				shortID)
+		}
+	}
+
+	// Prefilled transactions that are out of order can't be encoded.
+	msg.PrefilledTxs[0], msg.PrefilledTxs[1] = msg.PrefilledTxs[1], msg.PrefilledTxs[0]
+
+	if err := msg.BtcEncode(&buf, ProtocolVersion, BaseEncoding); err == nil {
+
+		t.Fatalf("BtcEncode: expected an error for unordered indexes")
+	}
+}
+
+// TestBlockTxnWire tests that getblocktxn and blocktxn messages round trip through the wire encoding.
+func TestBlockTxnWire(
+	t *testing.T) {
+
+	blockHash := blockOne.BlockHash()
+	getMsg := NewMsgGetBlockTxn(&blockHash, []uint32{0, 1, 5, 300})
+
+	// Indexes 0, 1, 5 and 300 are encoded as differences 0, 0, 3 and 294.
+	wantBuf := append(append([]byte{}, blockHash[:]...),
+		0x04, 0x00, 0x00, 0x03, 0xfd, 0x26, 0x01)
+	var buf bytes.Buffer
+
+	if err := getMsg.BtcEncode(&buf, ProtocolVersion, BaseEncoding); err != nil {
+
+		t.Fatalf("BtcEncode: unexpected error: %v", err)
+	}
+
+	if !bytes.Equal(buf.Bytes(), wantBuf) {
+
+		t.Fatalf("BtcEncode: got %x, want %x", buf.Bytes(), wantBuf)
+	}
+
+	var readGetMsg MsgGetBlockTxn
+
+	if err := readGetMsg.BtcDecode(&buf, ProtocolVersion, BaseEncoding); err != nil {
+
+		t.Fatalf("BtcDecode: unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(&readGetMsg, getMsg) {
+
+		t.Fatalf("BtcDecode: got %v, want %v", readGetMsg, *getMsg)
+	}
+
+	txnMsg := NewMsgBlockTxn(&blockHash, blockOne.Transactions)
+	buf.Reset()
+
+	if err := txnMsg.BtcEncode(&buf, ProtocolVersion, BaseEncoding); err != nil {
+
+		t.Fatalf("BtcEncode: unexpected error: %v", err)
+	}
+
+	var readTxnMsg MsgBlockTxn
+
+	if err := readTxnMsg.BtcDecode(&buf, ProtocolVersion, BaseEncoding); err != nil {
+
+		t.Fatalf("BtcDecode: unexpected error: %v", err)
+	}
+
+	if readTxnMsg.BlockHash != blockHash ||
+		len(readTxnMsg.Transactions) != len(blockOne.Transactions) ||
+		readTxnMsg.Transactions[0].TxHash() != blockOne.Transactions[0].TxHash() {
+
+		t.Fatalf("BtcDecode: got %v", spew.Sdump(&readTxnMsg))
+	}
+
+	// The index differences can't add up to more transactions than fit into a block.
+	buf.Reset()
+	buf.Write(make([]byte, chainhash.HashSize))
+	buf.Write([]byte{0x02, 0xfe, 0xff, 0xff, 0xff, 0x7f, 0x00})
+
+	if err := readGetMsg.BtcDecode(&buf, ProtocolVersion, BaseEncoding); err == nil {
+
+		t.Fatalf("BtcDecode: expected an error for an out of range index")
+	}
+}
@@ -0,0 +1,151 @@
+package wire
+
+import (
+	"fmt"
+	"io"
+
+	chainhash "git.parallelcoin.io/dev/pod/pkg/chain/hash"
+)
+
+// MsgGetBlockTxn implements the Message interface and represents a bitcoin getblocktxn message.  It is used to request the transactions of a compact block (MsgCmpctBlock) that could not be found in the memory pool by their indexes in the block, which are answered with a blocktxn message (MsgBlockTxn).
+// The indexes are the absolute indexes in the block, they are only differentially encoded on the wire.
+
+type MsgGetBlockTxn struct {
+	BlockHash chainhash.Hash
+	Indexes   []uint32
+}
+
+// BtcDecode decodes r using the bitcoin protocol encoding into the receiver. This is part of the Message interface implementation.
+func (msg *MsgGetBlockTxn) BtcDecode(r io.Reader, pver uint32, enc MessageEncoding) error {
+
+	if pver < ShortIDsBlocksVersion {
+
+		str := fmt.Sprintf("getblocktxn message invalid for protocol "+
+			"version %d", pver)
+		return messageError("MsgGetBlockTxn.BtcDecode", str)
+	}
+
+	err := readElement(r, &msg.BlockHash)
+
+	if err != nil {
+
+		return err
+	}
+
+	count, err := ReadVarInt(r, pver)
+
+	if err != nil {
+
+		return err
+	}
+
+	// Prevent more indexes than there could be transactions in a block.
+
+	if count > maxCmpctBlockTxs {
+
+		str := fmt.Sprintf("too many transaction indexes for message "+
+			"[count %d, max %d]", count, maxCmpctBlockTxs)
+		return messageError("MsgGetBlockTxn.BtcDecode", str)
+	}
+
+	msg.Indexes = make([]uint32, 0, count)
+	var index uint64
+
+	for i := uint64(0); i < count; i++ {
+
+		diff, err := ReadVarInt(r, pver)
+
+		if err != nil {
+
+			return err
+		}
+
+		index += diff
+
+		if index >= maxCmpctBlockTxs {
+
+			str := fmt.Sprintf("transaction index %d is out of range",
+				index)
+			return messageError("MsgGetBlockTxn.BtcDecode", str)
+		}
+		msg.Indexes = append(msg.Indexes, uint32(index))
+		index++
+	}
+	return nil
+}
+
+// BtcEncode encodes the receiver to w using the bitcoin protocol encoding. This is part of the Message interface implementation.
+func (msg *MsgGetBlockTxn) BtcEncode(w io.Writer, pver uint32, enc MessageEncoding) error {
+
+	if pver < ShortIDsBlocksVersion {
+
+		str := fmt.Sprintf("getblocktxn message invalid for protocol "+
+			"version %d", pver)
+		return messageError("MsgGetBlockTxn.BtcEncode", str)
+	}
+
+	if len(msg.Indexes) > maxCmpctBlockTxs {
+
+		str := fmt.Sprintf("too many transaction indexes for message "+
+			"[count %d, max %d]", len(msg.Indexes), maxCmpctBlockTxs)
+		return messageError("MsgGetBlockTxn.BtcEncode", str)
+	}
+
+	err := writeElement(w, &msg.BlockHash)
+
+	if err != nil {
+
+		return err
+	}
+
+	err = WriteVarInt(w, pver, uint64(len(msg.Indexes)))
+
+	if err != nil {
+
+		return err
+	}
+
+	// The indexes are written as the difference from the index after the previous one, so they must be in ascending order.
+	var next uint32
+
+	for _, index := range msg.Indexes {
+
+		if index < next {
+
+			str := "transaction indexes are not in ascending order"
+			return messageError("MsgGetBlockTxn.BtcEncode", str)
+		}
+
+		err := WriteVarInt(w, pver, uint64(index-next))
+
+		if err != nil {
+
+			return err
+		}
+		next = index + 1
+	}
+	return nil
+}
+
+// Command returns the protocol command string for the message.  This is part of the Message interface implementation.
+func (msg *MsgGetBlockTxn) Command() string {
+
+	return CmdGetBlockTxn
+}
+
+// MaxPayloadLength returns the maximum length the payload can be for the receiver.  This is part of the Message interface implementation.
+func (msg *MsgGetBlockTxn) MaxPayloadLength(pver uint32) uint32 {
+
+	// Block hash + num indexes (varInt) + max indexes, each of which is a varInt of at most 3 bytes as they are below maxCmpctBlockTxs.
+	return chainhash.HashSize + MaxVarIntPayload + maxCmpctBlockTxs*3
+}
+
+// NewMsgGetBlockTxn returns a new bitcoin getblocktxn message requesting the transactions at the passed indexes of the block with the passed hash.  See MsgGetBlockTxn for details.
+func NewMsgGetBlockTxn(
+	blockHash *chainhash.Hash, indexes []uint32) *MsgGetBlockTxn {
+
+	return &MsgGetBlockTxn{
+		BlockHash: *blockHash,
+		Indexes:   indexes,
+	}
+}
@@ -0,0 +1,63 @@
+package wire
+
+import (
+	"fmt"
+	"io"
+)
+
+// CmpctBlockVersion is the version of compact blocks (BIP0152) this package supports, which uses the non-witness transaction hashes for the short transaction ids.
+const CmpctBlockVersion uint64 = 1
+
+// MsgSendCmpct implements the Message interface and represents a bitcoin sendcmpct message.  It is used to tell a peer which version of compact blocks is supported and whether new blocks should be announced by sending the compact block directly (high-bandwidth mode) rather than with an inv or headers message (low-bandwidth mode). This message was not added until protocol versions starting with ShortIDsBlocksVersion.
+
+type MsgSendCmpct struct {
+	AnnounceUsingCmpctBlock bool
+	CmpctBlockVersion       uint64
+}
+
+// BtcDecode decodes r using the bitcoin protocol encoding into the receiver. This is part of the Message interface implementation.
+func (msg *MsgSendCmpct) BtcDecode(r io.Reader, pver uint32, enc MessageEncoding) error {
+
+	if pver < ShortIDsBlocksVersion {
+
+		str := fmt.Sprintf("sendcmpct message invalid for protocol "+
+			"version %d", pver)
+		return messageError("MsgSendCmpct.BtcDecode", str)
+	}
+	return readElements(r, &msg.AnnounceUsingCmpctBlock, &msg.CmpctBlockVersion)
+}
+
+// BtcEncode encodes the receiver to w using the bitcoin protocol encoding. This is part of the Message interface implementation.
+func (msg *MsgSendCmpct) BtcEncode(w io.Writer, pver uint32, enc MessageEncoding) error {
+
+	if pver < ShortIDsBlocksVersion {
+
+		str := fmt.Sprintf("sendcmpct message invalid for protocol "+
+			"version %d", pver)
+		return messageError("MsgSendCmpct.BtcEncode", str)
+	}
+	return writeElements(w, msg.AnnounceUsingCmpctBlock, msg.CmpctBlockVersion)
+}
+
+// Command returns the protocol command string for the message.  This is part of the Message interface implementation.
+func (msg *MsgSendCmpct) Command() string {
+
+	return CmdSendCmpct
+}
+
+// MaxPayloadLength returns the maximum length the payload can be for the receiver.  This is part of the Message interface implementation.
+func (msg *MsgSendCmpct) MaxPayloadLength(pver uint32) uint32 {
+
+	// Announce flag 1 byte + version 8 bytes.
+	return 9
+}
+
+// NewMsgSendCmpct returns a new bitcoin sendcmpct message that conforms to the Message interface.  See MsgSendCmpct for details.
+func NewMsgSendCmpct(
+	announce bool, version uint64) *MsgSendCmpct {
+
+	return &MsgSendCmpct{
+		AnnounceUsingCmpctBlock: announce,
+		CmpctBlockVersion:       version,
+	}
+}
@@ -10,7 +10,7 @@ import (
 const (
 
 	// ProtocolVersion is the latest protocol version this package supports.
-	ProtocolVersion uint32 = 70013
+	ProtocolVersion uint32 = 70014
 
 	// MultipleAddressVersion is the protocol version which added multiple addresses per message (pver >= MultipleAddressVersion).
 	MultipleAddressVersion uint32 = 209
@@ -38,6 +38,9 @@ const (
 
 	// FeeFilterVersion is the protocol version which added a new feefilter message.
 	FeeFilterVersion uint32 = 70013
+
+	// ShortIDsBlocksVersion is the protocol version which added the compact block relay messages sendcmpct, cmpctblock, getblocktxn and blocktxn (BIP0152).
+	ShortIDsBlocksVersion uint32 = 70014
 )
 
 // ServiceFlag identifies services supported by a bitcoin peer.
@@ -69,6 +72,9 @@ const (
 
 	// SFNode2X is a flag used to indicate a peer is running the Segwit2X software.
 	SFNode2X
+
+	// SFNodeNetworkLimited is a flag used to indicate a peer only serves the most recent blocks as it has pruned the older ones (BIP0159).
+	SFNodeNetworkLimited ServiceFlag = 1 << 10
 )
 
 // Map of service flags back to their constant names for pretty printing.
@@ -81,6 +87,8 @@ var sfStrings = map[ServiceFlag]string{
 	SFNodeBit5:    "SFNodeBit5",
 	SFNodeCF:      "SFNodeCF",
 	SFNode2X:      "SFNode2X",
+
+	SFNodeNetworkLimited: "SFNodeNetworkLimited",
 }
 
 // orderedSFStrings is an ordered list of service flags from highest to lowest.
@@ -93,6 +101,7 @@ var orderedSFStrings = []ServiceFlag{
 	SFNodeBit5,
 	SFNodeCF,
 	SFNode2X,
+	SFNodeNetworkLimited,
 }
 
 // String returns the ServiceFlag in human-readable form.
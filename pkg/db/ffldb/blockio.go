@@ -8,6 +8,8 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"sync"
 
 	chainhash "git.parallelcoin.io/dev/pod/pkg/chain/hash"
@@ -129,6 +131,9 @@ type blockStore struct {
 	// writeCursor houses the state for the current file and location that new blocks are written to.
 	writeCursor *writeCursor
 
+	// firstFileNum is the number of the oldest block file, which is only above zero once blocks have been pruned.  It is only changed while pruning, which is done with the database write lock held.
+	firstFileNum uint32
+
 	// These functions are set to openFile, openWriteFile, and deleteFile by default, but are exposed here to allow the whitebox tests to replace them when working with mock files.
 	openFileFunc      func(fileNum uint32) (*lockableFile, error)
 	openWriteFileFunc func(
@@ -262,6 +267,46 @@ func (s *blockStore) deleteFile(fileNum uint32) error {
 	return nil
 }
 
+// pruneFiles closes and deletes the block files from the oldest one up to but not including the passed file number, which becomes the oldest file.  The block index entries of the blocks in the deleted files must already be removed so that nothing reads from them anymore.
+// This function MUST be called with the database write lock held.
+func (s *blockStore) pruneFiles(firstKept uint32) error {
+
+	// Close the files being deleted that are open for reading.
+	s.obfMutex.Lock()
+	s.lruMutex.Lock()
+
+	for fileNum := s.firstFileNum; fileNum < firstKept; fileNum++ {
+
+		obf, ok := s.openBlockFiles[fileNum]
+
+		if !ok {
+
+			continue
+		}
+
+		obf.Lock()
+		_ = obf.file.Close()
+		obf.Unlock()
+		s.openBlocksLRU.Remove(s.fileNumToLRUElem[fileNum])
+		delete(s.openBlockFiles, fileNum)
+		delete(s.fileNumToLRUElem, fileNum)
+	}
+
+	s.lruMutex.Unlock()
+	s.obfMutex.Unlock()
+
+	for ; s.firstFileNum < firstKept; s.firstFileNum++ {
+
+		if err := s.deleteFileFunc(s.firstFileNum); err != nil {
+
+			return err
+		}
+
+	}
+
+	return nil
+}
+
 // blockFile attempts to return an existing file handle for the passed flat file number if it is already open as well as marking it as most recently used.  It will also open the file when it's not already open subject to the rules described in openFile.
 // NOTE: The returned block file will already have the read lock acquired and the caller MUST call .RUnlock() to release it once it has finished all read operations.  This is necessary because otherwise it would be possible for a separate goroutine to close the file after it is returned from here, but before the caller has acquired a read lock.
 func (s *blockStore) blockFile(fileNum uint32) (*lockableFile, error) {
@@ -671,14 +716,51 @@ func (s *blockStore) handleRollback(oldBlockFileNum, oldBlockOffset uint32) {
 
 }
 
-// scanBlockFiles searches the database directory for all flat block files to find the end of the most recent file.  This position is considered the current write cursor which is also stored in the metadata.  Thus, it is used to detect unexpected shutdowns in the middle of writes so the block files can be reconciled.
+// firstBlockFile returns the number of the oldest flat block file in the database directory, which is above zero once blocks have been pruned, or zero when there are no block files.
+func firstBlockFile(
+	dbPath string) uint32 {
+
+	paths, err := filepath.Glob(filepath.Join(dbPath, "*.fdb"))
+
+	if err != nil {
+
+		return 0
+	}
+
+	first := -1
+
+	for _, path := range paths {
+
+		name := strings.TrimSuffix(filepath.Base(path), ".fdb")
+		fileNum, err := strconv.ParseUint(name, 10, 32)
+
+		if err != nil || fmt.Sprintf(blockFilenameTemplate, fileNum) != filepath.Base(path) {
+
+			continue
+		}
+
+		if first == -1 || int(fileNum) < first {
+
+			first = int(fileNum)
+		}
+	}
+
+	if first == -1 {
+
+		return 0
+	}
+
+	return uint32(first)
+}
+
+// scanBlockFiles searches the database directory for all flat block files from the passed oldest one to find the end of the most recent file.  This position is considered the current write cursor which is also stored in the metadata.  Thus, it is used to detect unexpected shutdowns in the middle of writes so the block files can be reconciled.
 func scanBlockFiles(
-	dbPath string) (int, uint32) {
+	dbPath string, firstFile uint32) (int, uint32) {
 
 	lastFile := -1
 	fileLen := uint32(0)
 
-	for i := 0; ; i++ {
+	for i := int(firstFile); ; i++ {
 
 		filePath := blockFilePath(dbPath, uint32(i))
 		st, err := os.Stat(filePath)
@@ -708,8 +790,9 @@ func newBlockStore(
 
 	// Look for the end of the latest block to file to determine what the write cursor position is from the viewpoing of the block files on
 
-	// disk.
-	fileNum, fileOff := scanBlockFiles(basePath)
+	// disk.  The oldest files are missing when blocks have been pruned.
+	firstFile := firstBlockFile(basePath)
+	fileNum, fileOff := scanBlockFiles(basePath, firstFile)
 
 	if fileNum == -1 {
 
@@ -729,6 +812,7 @@ func newBlockStore(
 			curFileNum: uint32(fileNum),
 			curOffset:  fileOff,
 		},
+		firstFileNum: firstFile,
 	}
 
 	store.openFileFunc = store.openFile
@@ -63,6 +63,9 @@ var (
 
 	// writeLocKeyName is the key used to store the current write file location.
 	writeLocKeyName = []byte("ffldb-writeloc")
+
+	// prunedKeyName is the key used to store the number of the oldest block file once blocks have been pruned.
+	prunedKeyName = []byte("ffldb-pruned")
 )
 
 // Common error strings.
@@ -1905,6 +1908,148 @@ func (db *db) Update(fn func(database.Tx) error) error {
 	return tx.Commit()
 }
 
+// PruneBlocks deletes the oldest block files until the block files take up no more than the target size in bytes, returning the hashes of the blocks in the deleted files.  Blocks are only appended to the newest file, so the file holding the block with the passed hash and every file after it are kept, as is the current write file.  Whole files are deleted, so the block files can stay up to a file size above the target. This function is part of the database.DB interface implementation.
+func (db *db) PruneBlocks(targetSize uint64, keepHash *chainhash.Hash) ([]chainhash.Hash, error) {
+
+	tx, err := db.begin(true)
+
+	if err != nil {
+
+		return nil, err
+	}
+
+	blockRow, err := tx.fetchBlockRow(keepHash)
+
+	if err != nil {
+
+		_ = tx.Rollback()
+		return nil, err
+	}
+
+	keepFile := deserializeBlockLoc(blockRow).blockFileNum
+
+	// Total up the size of the block files and find the first file to keep.
+	store := db.store
+	wc := store.writeCursor
+	wc.RLock()
+	curFileNum := wc.curFileNum
+	totalSize := uint64(wc.curOffset)
+	wc.RUnlock()
+	fileSizes := make([]uint64, 0, curFileNum-store.firstFileNum)
+
+	for fileNum := store.firstFileNum; fileNum < curFileNum; fileNum++ {
+
+		var size uint64
+
+		if st, err := os.Stat(blockFilePath(store.basePath, fileNum)); err == nil {
+
+			size = uint64(st.Size())
+		}
+
+		fileSizes = append(fileSizes, size)
+		totalSize += size
+	}
+
+	firstKept := store.firstFileNum
+
+	for firstKept < keepFile && firstKept < curFileNum && totalSize > targetSize {
+
+		totalSize -= fileSizes[firstKept-store.firstFileNum]
+		firstKept++
+	}
+
+	if firstKept == store.firstFileNum {
+
+		return nil, tx.Rollback()
+	}
+
+	// Remove the block index entries of the blocks in the files to delete, and record that the database has been pruned.
+	var pruned []chainhash.Hash
+	err = tx.blockIdxBucket.ForEach(func(k, v []byte) error {
+
+		if deserializeBlockLoc(v).blockFileNum < firstKept {
+
+			var hash chainhash.Hash
+			copy(hash[:], k)
+			pruned = append(pruned, hash)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+
+		_ = tx.Rollback()
+		return nil, err
+	}
+
+	for i := range pruned {
+
+		if err := tx.blockIdxBucket.Delete(pruned[i][:]); err != nil {
+
+			_ = tx.Rollback()
+			return nil, err
+		}
+
+	}
+
+	var prunedRow [4]byte
+	byteOrder.PutUint32(prunedRow[:], firstKept)
+
+	if err := tx.metaBucket.Put(prunedKeyName, prunedRow[:]); err != nil {
+
+		_ = tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+
+		return nil, err
+	}
+
+	// The removed block index entries are flushed to disk before the files are deleted, so that they never refer to missing files after an unexpected shutdown.  A shutdown after the flush just leaves files that are deleted by the next prune.
+	db.writeLock.Lock()
+	defer db.writeLock.Unlock()
+	db.closeLock.RLock()
+	defer db.closeLock.RUnlock()
+
+	if db.closed {
+
+		return nil, makeDbErr(database.ErrDbNotOpen, errDbNotOpenStr, nil)
+	}
+
+	if err := db.cache.flush(); err != nil {
+
+		return nil, err
+	}
+
+	log <- cl.Debugf{
+
+		"pruning block files %d to %d holding %d blocks",
+		store.firstFileNum, firstKept - 1, len(pruned),
+	}
+
+	if err := store.pruneFiles(firstKept); err != nil {
+
+		return nil, err
+	}
+
+	return pruned, nil
+}
+
+// BeenPruned returns whether blocks have ever been deleted from the database by PruneBlocks. This function is part of the database.DB interface implementation.
+func (db *db) BeenPruned() (bool, error) {
+
+	var pruned bool
+	err := db.View(func(tx database.Tx) error {
+
+		pruned = tx.Metadata().Get(prunedKeyName) != nil
+		return nil
+	})
+
+	return pruned, err
+}
+
 // Close cleanly shuts down the database and syncs all data.  It will block until all database transactions have been finalized (rolled back or committed). This function is part of the database.DB interface implementation.
 func (db *db) Close() error {
 
@@ -0,0 +1,211 @@
+// This file is part of the ffldb package rather than the ffldb_test package as it provides whitebox testing.
+package ffldb
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	chainhash "git.parallelcoin.io/dev/pod/pkg/chain/hash"
+	"git.parallelcoin.io/dev/pod/pkg/chain/wire"
+	database "git.parallelcoin.io/dev/pod/pkg/db"
+	"git.parallelcoin.io/dev/pod/pkg/util"
+)
+
+// TestPruneBlocks ensures pruning deletes the oldest block files down to the target size without deleting the file of the block to keep, removes the deleted blocks from the block index and leaves a database that can be reopened.
+func TestPruneBlocks(
+	t *testing.T) {
+
+	// Create a chain of blocks with a coinbase each, which are about a hundred bytes in size.
+	blocks := make([]*util.Block, 0, 256)
+	var prevHash chainhash.Hash
+
+	for i := 0; i < 256; i++ {
+
+		msgBlock := wire.NewMsgBlock(wire.NewBlockHeader(1, &prevHash,
+			&chainhash.Hash{}, 0x1d00ffff, uint32(i)))
+		coinbase := wire.NewMsgTx(1)
+		coinbase.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{},
+			wire.MaxPrevOutIndex), []byte{byte(i), byte(i >> 8)}, nil))
+		coinbase.AddTxOut(wire.NewTxOut(50e8, []byte{0x51}))
+
+		if err := msgBlock.AddTransaction(coinbase); err != nil {
+
+			t.Fatalf("failed to add the coinbase: %v", err)
+		}
+
+		block := util.NewBlock(msgBlock)
+		blocks = append(blocks, block)
+		prevHash = *block.Hash()
+	}
+
+	dbPath, err := ioutil.TempDir("", "ffldb-prune")
+
+	if err != nil {
+
+		t.Fatalf("failed to create a temporary directory: %v", err)
+	}
+
+	defer os.RemoveAll(dbPath)
+
+	idb, err := openDB(dbPath, blockDataNet, true)
+
+	if err != nil {
+
+		t.Fatalf("failed to create the database: %v", err)
+	}
+
+	pdb := idb.(*db)
+
+	if pruned, err := pdb.BeenPruned(); err != nil || pruned {
+
+		t.Fatalf("BeenPruned: got %v, %v for a new database", pruned, err)
+	}
+
+	// Store the blocks a dozen or so at a time in each of the small files.
+	const fileSize = 2048
+	pdb.store.maxBlockFileSize = fileSize
+
+	for _, block := range blocks {
+
+		err := pdb.Update(func(tx database.Tx) error {
+
+			return tx.StoreBlock(block)
+		})
+
+		if err != nil {
+
+			pdb.Close()
+			t.Fatalf("failed to store block %s: %v", block.Hash(), err)
+		}
+	}
+
+	hasBlocks := func() []bool {
+
+		hashes := make([]chainhash.Hash, len(blocks))
+
+		for i, block := range blocks {
+
+			hashes[i] = *block.Hash()
+		}
+
+		var has []bool
+		err := pdb.View(func(tx database.Tx) error {
+
+			var err error
+			has, err = tx.HasBlocks(hashes)
+			return err
+		})
+
+		if err != nil {
+
+			t.Fatalf("failed to check for blocks: %v", err)
+		}
+
+		return has
+	}
+
+	// Prune down to a few files, well below the block to keep.
+	keep := blocks[200].Hash()
+	pruned, err := pdb.PruneBlocks(10*fileSize, keep)
+
+	if err != nil {
+
+		pdb.Close()
+		t.Fatalf("PruneBlocks: unexpected error: %v", err)
+	}
+
+	if len(pruned) == 0 {
+
+		pdb.Close()
+		t.Fatalf("PruneBlocks: no blocks were pruned")
+	}
+
+	has := hasBlocks()
+
+	for i := range blocks {
+
+		if has[i] != (i >= len(pruned)) {
+
+			pdb.Close()
+			t.Fatalf("block %d: got stored %v after pruning the %d oldest blocks",
+				i, has[i], len(pruned))
+		}
+	}
+
+	if _, err := os.Stat(blockFilePath(dbPath, 0)); !os.IsNotExist(err) {
+
+		pdb.Close()
+		t.Fatalf("the oldest block file was not deleted: %v", err)
+	}
+
+	// Pruning everything keeps the file of the block to keep.
+	if _, err := pdb.PruneBlocks(0, keep); err != nil {
+
+		pdb.Close()
+		t.Fatalf("PruneBlocks: unexpected error: %v", err)
+	}
+
+	has = hasBlocks()
+
+	for i := 200; i < len(blocks); i++ {
+
+		if !has[i] {
+
+			pdb.Close()
+			t.Fatalf("block %d was pruned while keeping block 200", i)
+		}
+	}
+
+	if has[0] || has[150] {
+
+		pdb.Close()
+		t.Fatalf("blocks far below the block to keep were not pruned")
+	}
+
+	firstFile := pdb.store.firstFileNum
+
+	if err := pdb.Close(); err != nil {
+
+		t.Fatalf("failed to close the database: %v", err)
+	}
+
+	// The pruned database reopens with the oldest file and write cursor found again.
+	idb, err = openDB(dbPath, blockDataNet, false)
+
+	if err != nil {
+
+		t.Fatalf("failed to reopen the pruned database: %v", err)
+	}
+
+	pdb = idb.(*db)
+	defer pdb.Close()
+
+	if pdb.store.firstFileNum != firstFile {
+
+		t.Fatalf("got first block file %d after reopening, want %d",
+			pdb.store.firstFileNum, firstFile)
+	}
+
+	if pruned, err := pdb.BeenPruned(); err != nil || !pruned {
+
+		t.Fatalf("BeenPruned: got %v, %v for a pruned database", pruned, err)
+	}
+
+	err = pdb.View(func(tx database.Tx) error {
+
+		_, err := tx.FetchBlock(blocks[len(blocks)-1].Hash())
+		return err
+	})
+
+	if err != nil {
+
+		t.Fatalf("failed to fetch the newest block after reopening: %v", err)
+	}
+
+	if matches, _ := filepath.Glob(filepath.Join(dbPath, "*.fdb")); len(matches) == 0 {
+
+		t.Fatalf("no block files are left")
+	}
+}
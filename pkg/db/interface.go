@@ -770,6 +770,24 @@ type DB interface {
 	// user-supplied function will result in a panic.
 	Update(fn func(tx Tx) error) error
 
+	// PruneBlocks deletes the oldest stored blocks until the blocks take up
+
+	// no more than the target size in bytes, returning the hashes of the
+
+	// deleted blocks.  The block with the passed hash and every block
+
+	// stored after it are kept, so fewer blocks than needed to reach the
+
+	// target may be deleted.  How many blocks are deleted at a time is up
+
+	// to the implementation.
+	PruneBlocks(targetSize uint64, keepHash *chainhash.Hash) ([]chainhash.Hash, error)
+
+	// BeenPruned returns whether blocks have ever been deleted from the
+
+	// database by PruneBlocks.
+	BeenPruned() (bool, error)
+
 	// Close cleanly shuts down the database and syncs all data.  It will
 
 	// block until all database transactions have been finalized (rolled
@@ -12,6 +12,7 @@ import (
 	"net"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -1065,6 +1066,36 @@ func (a *AddrManager) AddLocalAddress(na *wire.NetAddress, priority AddressPrior
 	return nil
 }
 
+// LocalAddr describes a known local address and the priority of the method it was discovered with.
+
+type LocalAddr struct {
+	Address string
+	Port    uint16
+	Score   AddressPriority
+}
+
+// LocalAddresses returns the known local addresses that are advertised to peers, ordered by descending score.
+func (a *AddrManager) LocalAddresses() []LocalAddr {
+
+	a.lamtx.Lock()
+	defer a.lamtx.Unlock()
+	addrs := make([]LocalAddr, 0, len(a.localAddresses))
+
+	for _, la := range a.localAddresses {
+
+		addrs = append(addrs, LocalAddr{
+			Address: ipString(la.na),
+			Port:    la.na.Port,
+			Score:   la.score,
+		})
+	}
+	sort.Slice(addrs, func(i, j int) bool {
+
+		return addrs[i].Score > addrs[j].Score
+	})
+	return addrs
+}
+
 // getReachabilityFrom returns the relative reachability of the provided local address to the provided remote address.
 func getReachabilityFrom(
 	localAddr, remoteAddr *wire.NetAddress) int {
@@ -223,6 +223,26 @@ func TestAddLocalAddress(
 			continue
 		}
 	}
+
+	// Local addresses are listed once each, ordered by descending score, where an address that is added again with a higher priority scores above that priority.
+	amgr = addrmgr.New("testlocaladdresses", nil)
+	amgr.AddLocalAddress(&wire.NetAddress{IP: net.ParseIP("2620:100::1")},
+		addrmgr.InterfacePrio)
+	amgr.AddLocalAddress(&wire.NetAddress{IP: net.ParseIP("204.124.1.1")},
+		addrmgr.InterfacePrio)
+	amgr.AddLocalAddress(&wire.NetAddress{IP: net.ParseIP("204.124.1.1")},
+		addrmgr.BoundPrio)
+	wantAddrs := []addrmgr.LocalAddr{
+		{Address: "204.124.1.1", Score: addrmgr.BoundPrio + 1},
+		{Address: "2620:100::1", Score: addrmgr.InterfacePrio},
+	}
+	localAddrs := amgr.LocalAddresses()
+
+	if !reflect.DeepEqual(localAddrs, wantAddrs) {
+
+		t.Errorf("TestAddLocalAddress: got local addresses %v, want %v",
+			localAddrs, wantAddrs)
+	}
 }
 func TestAttempt(
 	t *testing.T) {
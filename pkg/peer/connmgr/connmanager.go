@@ -17,6 +17,9 @@ const maxFailedAttempts = 3
 //ErrDialNil is used to indicate that Dial cannot be nil in the configuration.
 var ErrDialNil = errors.New("config: Dial cannot be nil")
 
+// ErrBanned is used to indicate that a connection was not attempted because the address is banned.
+var ErrBanned = errors.New("address is banned")
+
 // maxRetryDuration is the max duration of time retrying of a persistent
 // connection is allowed to grow to.  This is necessary since the retry logic
 // uses a backoff mechanism which increases the interval base times
@@ -124,6 +127,9 @@ type Config struct {
 
 	// Dial connects to the address on the named network. It cannot be nil.
 	Dial func(net.Addr) (net.Conn, error)
+
+	// IsBanned reports whether an address is banned.  Connections to banned addresses are failed without being dialed.  If nil, no addresses are banned.
+	IsBanned func(net.Addr) bool
 }
 
 // registerPending is used to register a pending connection attempt. By registering pending connection attempts we allow callers to cancel pending connection attempts before their successful or in the case they're not longer wanted.
@@ -455,6 +461,18 @@ func (cm *ConnManager) Connect(c *ConnReq) {
 		}
 	}
 
+	if cm.cfg.IsBanned != nil && cm.cfg.IsBanned(c.Addr) {
+
+		select {
+
+		case cm.requests <- handleFailed{c, ErrBanned}:
+
+		case <-cm.quit:
+
+		}
+		return
+	}
+
 	log <- cl.Debugf{"attempting to connect to '%s'", c.Addr}
 
 	conn, err := cm.cfg.Dial(c.Addr)
@@ -584,6 +602,20 @@ func (cm *ConnManager) Start() {
 	}
 }
 
+// Active returns whether the connection manager is running and takes part in the network, either by accepting connections on its listeners or by making outbound connections.
+func (cm *ConnManager) Active() bool {
+
+	if atomic.LoadInt32(&cm.start) == 0 || atomic.LoadInt32(&cm.stop) != 0 {
+
+		return false
+	}
+
+	accepts := cm.cfg.OnAccept != nil && len(cm.cfg.Listeners) > 0
+	dials := atomic.LoadUint64(&cm.connReqCount) > 0 ||
+		(cm.cfg.TargetOutbound > 0 && cm.cfg.GetNewAddress != nil)
+	return accepts || dials
+}
+
 // Wait blocks until the connection manager halts gracefully.
 func (cm *ConnManager) Wait() {
 
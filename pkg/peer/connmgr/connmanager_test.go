@@ -121,10 +121,25 @@ func TestStartStop(
 
 		t.Fatalf("New error: %v", err)
 	}
+
+	if cmgr.Active() {
+
+		t.Fatal("start/stop: active before start")
+	}
 	cmgr.Start()
 	gotConnReq := <-connected
+
+	if !cmgr.Active() {
+
+		t.Fatal("start/stop: not active after start")
+	}
 	cmgr.Stop()
 
+	if cmgr.Active() {
+
+		t.Fatal("start/stop: active after stop")
+	}
+
 	// already stopped
 	cmgr.Stop()
 
@@ -205,6 +220,54 @@ func TestConnectMode(
 	cmgr.Stop()
 }
 
+// TestBannedAddress tests that connections to banned addresses fail without being dialed.
+func TestBannedAddress(
+	t *testing.T) {
+
+	dialed := make(chan net.Addr, 1)
+	cmgr, err := New(&Config{
+		Dial: func(addr net.Addr) (net.Conn, error) {
+
+			dialed <- addr
+			return mockDialer(addr)
+		},
+		IsBanned: func(addr net.Addr) bool {
+
+			return true
+		},
+	})
+
+	if err != nil {
+
+		t.Fatalf("New error: %v", err)
+	}
+	cr := &ConnReq{
+		Addr: &net.TCPAddr{
+			IP:   net.ParseIP("127.0.0.1"),
+			Port: 18555,
+		},
+	}
+	cmgr.Start()
+	cmgr.Connect(cr)
+	deadline := time.Now().Add(time.Second)
+
+	for cr.State() != ConnFailing {
+
+		if time.Now().After(deadline) {
+
+			t.Fatalf("banned address: want state %v, got state %v", ConnFailing, cr.State())
+		}
+		time.Sleep(time.Millisecond)
+	}
+	select {
+
+	case addr := <-dialed:
+		t.Fatalf("banned address: unexpected dial to %v", addr)
+	default:
+	}
+	cmgr.Stop()
+}
+
 // TestTargetOutbound tests the target number of outbound connections. We wait until all connections are established, then test they there are the only connections made.
 func TestTargetOutbound(
 	t *testing.T) {
@@ -699,6 +762,11 @@ func TestListeners(
 	}
 	cmgr.Start()
 
+	if !cmgr.Active() {
+
+		t.Fatal("listeners: not active when accepting connections")
+	}
+
 	// Fake a couple of mock connections to each of the listeners.
 	go func() {
 
@@ -216,6 +216,18 @@ func messageSummary(
 	case *wire.MsgCFHeaders:
 		return fmt.Sprintf("stop_hash=%v, num_filter_hashes=%d",
 			msg.StopHash, len(msg.FilterHashes))
+	case *wire.MsgSendCmpct:
+		return fmt.Sprintf("announce %v, version %d",
+			msg.AnnounceUsingCmpctBlock, msg.CmpctBlockVersion)
+	case *wire.MsgCmpctBlock:
+		return fmt.Sprintf("hash %s, %d short ids, %d prefilled",
+			msg.Header.BlockHash(), len(msg.ShortIDs), len(msg.PrefilledTxs))
+	case *wire.MsgGetBlockTxn:
+		return fmt.Sprintf("hash %s, %d indexes", msg.BlockHash,
+			len(msg.Indexes))
+	case *wire.MsgBlockTxn:
+		return fmt.Sprintf("hash %s, %d tx", msg.BlockHash,
+			len(msg.Transactions))
 	case *wire.MsgReject:
 
 		// Ensure the variable length strings don't contain any characters which are even remotely dangerous such as HTML control characters, etc.  Also limit them to sane length for logging.
@@ -26,7 +26,7 @@ import (
 const (
 
 	// MaxProtocolVersion is the max protocol version the peer supports.
-	MaxProtocolVersion = wire.FeeFilterVersion
+	MaxProtocolVersion = wire.ShortIDsBlocksVersion
 
 	// DefaultTrickleInterval is the min time between attempts to send an inv message to a peer.
 	DefaultTrickleInterval = time.Second * 9
@@ -169,6 +169,18 @@ type MessageListeners struct {
 	// OnSendHeaders is invoked when a peer receives a sendheaders bitcoin message.
 	OnSendHeaders func(p *Peer, msg *wire.MsgSendHeaders)
 
+	// OnSendCmpct is invoked when a peer receives a sendcmpct bitcoin message.
+	OnSendCmpct func(p *Peer, msg *wire.MsgSendCmpct)
+
+	// OnCmpctBlock is invoked when a peer receives a cmpctblock bitcoin message.
+	OnCmpctBlock func(p *Peer, msg *wire.MsgCmpctBlock)
+
+	// OnGetBlockTxn is invoked when a peer receives a getblocktxn bitcoin message.
+	OnGetBlockTxn func(p *Peer, msg *wire.MsgGetBlockTxn)
+
+	// OnBlockTxn is invoked when a peer receives a blocktxn bitcoin message.
+	OnBlockTxn func(p *Peer, msg *wire.MsgBlockTxn)
+
 	// OnRead is invoked when a peer receives a bitcoin message.  It consists of the number of bytes read, the message, and whether or not an error in the read occurred.  Typically, callers will opt to use the callbacks for the specific message types, however this can be useful for circumstances such as keeping track of server-wide byte counts or working with custom message types for which the peer does not directly provide a callback.
 	OnRead func(p *Peer, bytesRead int, msg wire.Message, err error)
 
@@ -336,6 +348,24 @@ type StatsSnap struct {
 	LastPingNonce  uint64
 	LastPingTime   time.Time
 	LastPingMicros int64
+
+	// CmpctBlockVersion is the version of compact blocks negotiated with the peer, or zero when the peer doesn't support compact blocks.
+	CmpctBlockVersion uint64
+
+	// CmpctHighBandwidthTo is whether the peer asked for new blocks to be announced to it with compact blocks, and CmpctHighBandwidthFrom whether the peer was asked to announce new blocks that way.
+	CmpctHighBandwidthTo   bool
+	CmpctHighBandwidthFrom bool
+
+	// CmpctBlocksSent and CmpctBlocksRecv are the number of compact blocks sent to and received from the peer.
+	CmpctBlocksSent uint64
+	CmpctBlocksRecv uint64
+
+	// CmpctBlocksReconstructed is the number of compact blocks from the peer that were rebuilt from the memory pool alone, and CmpctTxnRequested the number of transactions that had to be requested from the peer to rebuild the rest.
+	CmpctBlocksReconstructed uint64
+	CmpctTxnRequested        uint64
+
+	// CmpctBlockLatency is the average time from receiving a compact block from the peer until the whole block was rebuilt.
+	CmpctBlockLatency time.Duration
 }
 
 // HashFunc is a function which returns a block hash, height and error It is used as a callback to get newest block details.
@@ -389,6 +419,9 @@ type Peer struct {
 	advertisedProtoVer   uint32 // protocol version advertised by remote
 	protocolVersion      uint32 // negotiated protocol version
 	sendHeadersPreferred bool   // peer sent a sendheaders message
+	cmpctBlockVersion    uint64 // compact block version sent with sendcmpct
+	cmpctHBTo            bool   // peer wants new blocks announced with cmpctblock
+	cmpctHBFrom          bool   // peer was asked to announce new blocks with cmpctblock
 	verAckReceived       bool
 	witnessEnabled       bool
 	wireEncoding         wire.MessageEncoding
@@ -410,15 +443,23 @@ type Peer struct {
 	lastPingNonce      uint64    // Set to nonce if we have a pending ping.
 	lastPingTime       time.Time // Time we sent last ping.
 	lastPingMicros     int64     // Time for last ping to return.
-	stallControl       chan stallControlMsg
-	outputQueue        chan outMsg
-	sendQueue          chan outMsg
-	sendDoneQueue      chan struct{}
-	outputInvChan      chan *wire.InvVect
-	inQuit             chan struct{}
-	queueQuit          chan struct{}
-	outQuit            chan struct{}
-	quit               chan struct{}
+
+	// These fields keep track of the compact blocks exchanged with the peer and are also protected by the statsMtx mutex.
+	cmpctBlocksSent          uint64
+	cmpctBlocksRecv          uint64
+	cmpctBlocksReconstructed uint64
+	cmpctTxnRequested        uint64
+	cmpctBlocksRebuilt       uint64
+	cmpctRebuildTime         time.Duration
+	stallControl             chan stallControlMsg
+	outputQueue              chan outMsg
+	sendQueue                chan outMsg
+	sendDoneQueue            chan struct{}
+	outputInvChan            chan *wire.InvVect
+	inQuit                   chan struct{}
+	queueQuit                chan struct{}
+	outQuit                  chan struct{}
+	quit                     chan struct{}
 }
 
 // String returns the peer's address and directionality as a human-readable string. This function is safe for concurrent access.
@@ -454,12 +495,34 @@ func (p *Peer) UpdateLastAnnouncedBlock(blkHash *chainhash.Hash) {
 	p.statsMtx.Unlock()
 }
 
+// UpdateCmpctBlockStats records that a compact block from the peer was rebuilt after the passed time since it was received, with the passed number of transactions requested from the peer as they were not in the memory pool. This function is safe for concurrent access.
+func (p *Peer) UpdateCmpctBlockStats(txnRequested int, latency time.Duration) {
+
+	p.statsMtx.Lock()
+
+	if txnRequested == 0 {
+
+		p.cmpctBlocksReconstructed++
+	}
+
+	p.cmpctTxnRequested += uint64(txnRequested)
+	p.cmpctBlocksRebuilt++
+	p.cmpctRebuildTime += latency
+	p.statsMtx.Unlock()
+}
+
 // AddKnownInventory adds the passed inventory to the cache of known inventory for the peer. This function is safe for concurrent access.
 func (p *Peer) AddKnownInventory(invVect *wire.InvVect) {
 
 	p.knownInventory.Add(invVect)
 }
 
+// HasKnownInventory returns whether the passed inventory is in the cache of known inventory for the peer. This function is safe for concurrent access.
+func (p *Peer) HasKnownInventory(invVect *wire.InvVect) bool {
+
+	return p.knownInventory.Exists(invVect)
+}
+
 // StatsSnapshot returns a snapshot of the current peer flags and statistics. This function is safe for concurrent access.
 func (p *Peer) StatsSnapshot() *StatsSnap {
 
@@ -470,8 +533,19 @@ func (p *Peer) StatsSnapshot() *StatsSnap {
 	userAgent := p.userAgent
 	services := p.services
 	protocolVersion := p.advertisedProtoVer
+	cmpctBlockVersion := p.cmpctBlockVersion
+	cmpctHBTo := p.cmpctHBTo
+	cmpctHBFrom := p.cmpctHBFrom
 	p.flagsMtx.Unlock()
 
+	var cmpctBlockLatency time.Duration
+
+	if p.cmpctBlocksRebuilt > 0 {
+
+		cmpctBlockLatency = p.cmpctRebuildTime /
+			time.Duration(p.cmpctBlocksRebuilt)
+	}
+
 	// Get a copy of all relevant flags and stats.
 
 	statsSnap := &StatsSnap{
@@ -493,6 +567,15 @@ func (p *Peer) StatsSnapshot() *StatsSnap {
 		LastPingNonce:  p.lastPingNonce,
 		LastPingMicros: p.lastPingMicros,
 		LastPingTime:   p.lastPingTime,
+
+		CmpctBlockVersion:        cmpctBlockVersion,
+		CmpctHighBandwidthTo:     cmpctHBTo,
+		CmpctHighBandwidthFrom:   cmpctHBFrom,
+		CmpctBlocksSent:          p.cmpctBlocksSent,
+		CmpctBlocksRecv:          p.cmpctBlocksRecv,
+		CmpctBlocksReconstructed: p.cmpctBlocksReconstructed,
+		CmpctTxnRequested:        p.cmpctTxnRequested,
+		CmpctBlockLatency:        cmpctBlockLatency,
 	}
 
 	p.statsMtx.RUnlock()
@@ -693,6 +776,24 @@ func (p *Peer) WantsHeaders() bool {
 	return sendHeadersPreferred
 }
 
+// CmpctBlockVersion returns the version of compact blocks the peer announced support for with a sendcmpct message, or zero when it doesn't support a version known to this package. This function is safe for concurrent access.
+func (p *Peer) CmpctBlockVersion() uint64 {
+
+	p.flagsMtx.Lock()
+	cmpctBlockVersion := p.cmpctBlockVersion
+	p.flagsMtx.Unlock()
+	return cmpctBlockVersion
+}
+
+// WantsCmpctBlocks returns if the peer wants new blocks to be announced by sending the compact block directly instead of an inventory vector or header (high-bandwidth mode). This function is safe for concurrent access.
+func (p *Peer) WantsCmpctBlocks() bool {
+
+	p.flagsMtx.Lock()
+	cmpctHBTo := p.cmpctHBTo
+	p.flagsMtx.Unlock()
+	return cmpctHBTo
+}
+
 // IsWitnessEnabled returns true if the peer has signalled that it supports segregated witness. This function is safe for concurrent access.
 func (p *Peer) IsWitnessEnabled() bool {
 
@@ -1003,6 +1104,24 @@ func (p *Peer) writeMessage(msg wire.Message, enc wire.MessageEncoding) error {
 		p.ProtocolVersion(), p.cfg.ChainParams.Net, enc)
 	atomic.AddUint64(&p.bytesSent, uint64(n))
 
+	// Keep track of the compact blocks sent and whether the peer was asked to announce new blocks with them.
+
+	if err == nil {
+
+		switch msg := msg.(type) {
+
+		case *wire.MsgCmpctBlock:
+			p.statsMtx.Lock()
+			p.cmpctBlocksSent++
+			p.statsMtx.Unlock()
+
+		case *wire.MsgSendCmpct:
+			p.flagsMtx.Lock()
+			p.cmpctHBFrom = msg.AnnounceUsingCmpctBlock
+			p.flagsMtx.Unlock()
+		}
+	}
+
 	if p.cfg.Listeners.OnWrite != nil {
 
 		p.cfg.Listeners.OnWrite(p, n, msg, err)
@@ -1097,8 +1216,13 @@ func (p *Peer) maybeAddDeadline(pendingResponses map[string]time.Time, msgCmd st
 		// Expects a block, merkleblock, tx, or notfound message.
 		pendingResponses[wire.CmdBlock] = deadline
 		pendingResponses[wire.CmdMerkleBlock] = deadline
+		pendingResponses[wire.CmdCmpctBlock] = deadline
 		pendingResponses[wire.CmdTx] = deadline
 		pendingResponses[wire.CmdNotFound] = deadline
+	case wire.CmdGetBlockTxn:
+
+		// Expects a blocktxn message.
+		pendingResponses[wire.CmdBlockTxn] = deadline
 	case wire.CmdGetHeaders:
 
 		// Expects a headers message.  Use a longer deadline since it can take a while for the remote peer to load all of the headers.
@@ -1152,11 +1276,14 @@ out:
 					fallthrough
 				case wire.CmdMerkleBlock:
 					fallthrough
+				case wire.CmdCmpctBlock:
+					fallthrough
 				case wire.CmdTx:
 					fallthrough
 				case wire.CmdNotFound:
 					delete(pendingResponses, wire.CmdBlock)
 					delete(pendingResponses, wire.CmdMerkleBlock)
+					delete(pendingResponses, wire.CmdCmpctBlock)
 					delete(pendingResponses, wire.CmdTx)
 					delete(pendingResponses, wire.CmdNotFound)
 				default:
@@ -1563,6 +1690,47 @@ out:
 				p.cfg.Listeners.OnSendHeaders(p, msg)
 			}
 
+		case *wire.MsgSendCmpct:
+
+			// Only versions this package knows how to build short ids for are used, other versions are ignored.
+
+			if msg.CmpctBlockVersion == wire.CmpctBlockVersion {
+
+				p.flagsMtx.Lock()
+				p.cmpctBlockVersion = msg.CmpctBlockVersion
+				p.cmpctHBTo = msg.AnnounceUsingCmpctBlock
+				p.flagsMtx.Unlock()
+			}
+
+			if p.cfg.Listeners.OnSendCmpct != nil {
+
+				p.cfg.Listeners.OnSendCmpct(p, msg)
+			}
+
+		case *wire.MsgCmpctBlock:
+			p.statsMtx.Lock()
+			p.cmpctBlocksRecv++
+			p.statsMtx.Unlock()
+
+			if p.cfg.Listeners.OnCmpctBlock != nil {
+
+				p.cfg.Listeners.OnCmpctBlock(p, msg)
+			}
+
+		case *wire.MsgGetBlockTxn:
+
+			if p.cfg.Listeners.OnGetBlockTxn != nil {
+
+				p.cfg.Listeners.OnGetBlockTxn(p, msg)
+			}
+
+		case *wire.MsgBlockTxn:
+
+			if p.cfg.Listeners.OnBlockTxn != nil {
+
+				p.cfg.Listeners.OnBlockTxn(p, msg)
+			}
+
 		default:
 
 			log <- cl.Debugf{
@@ -578,6 +578,26 @@ func TestPeerListeners(
 
 				ok <- msg
 			},
+
+			OnSendCmpct: func(p *peer.Peer, msg *wire.MsgSendCmpct) {
+
+				ok <- msg
+			},
+
+			OnCmpctBlock: func(p *peer.Peer, msg *wire.MsgCmpctBlock) {
+
+				ok <- msg
+			},
+
+			OnGetBlockTxn: func(p *peer.Peer, msg *wire.MsgGetBlockTxn) {
+
+				ok <- msg
+			},
+
+			OnBlockTxn: func(p *peer.Peer, msg *wire.MsgBlockTxn) {
+
+				ok <- msg
+			},
 		},
 
 		UserAgentName:     "peer",
@@ -790,6 +810,31 @@ func TestPeerListeners(
 			"OnSendHeaders",
 			wire.NewMsgSendHeaders(),
 		},
+
+		{
+
+			"OnSendCmpct",
+			wire.NewMsgSendCmpct(true, wire.CmpctBlockVersion),
+		},
+
+		{
+
+			"OnCmpctBlock",
+			wire.NewMsgCmpctBlock(wire.NewMsgBlock(wire.NewBlockHeader(1,
+				&chainhash.Hash{}, &chainhash.Hash{}, 1, 1)), 1),
+		},
+
+		{
+
+			"OnGetBlockTxn",
+			wire.NewMsgGetBlockTxn(&chainhash.Hash{}, []uint32{1, 2}),
+		},
+
+		{
+
+			"OnBlockTxn",
+			wire.NewMsgBlockTxn(&chainhash.Hash{}, nil),
+		},
 	}
 
 	t.Logf("Running %d tests", len(tests))
@@ -809,6 +854,20 @@ func TestPeerListeners(
 
 	}
 
+	// The sendcmpct and cmpctblock messages must have been recorded.
+
+	if inPeer.CmpctBlockVersion() != wire.CmpctBlockVersion ||
+		!inPeer.WantsCmpctBlocks() {
+
+		t.Errorf("TestPeerListeners: compact blocks not negotiated")
+	}
+
+	if stats := inPeer.StatsSnapshot(); stats.CmpctBlocksRecv != 1 {
+
+		t.Errorf("TestPeerListeners: got %d compact blocks received, "+
+			"want 1", stats.CmpctBlocksRecv)
+	}
+
 	inPeer.Disconnect()
 	outPeer.Disconnect()
 }
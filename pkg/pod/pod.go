@@ -35,6 +35,7 @@ type Config struct {
 	RPCMaxClients            *int
 	RPCMaxWebsockets         *int
 	RPCMaxConcurrentReqs     *int
+	RPCMaxBatchSize          *int
 	RPCQuirks                *bool
 	DisableRPC               *bool
 	TLS                      *bool
@@ -53,21 +54,31 @@ type Config struct {
 	SimNet                   *bool
 	AddCheckpoints           *cli.StringSlice
 	DisableCheckpoints       *bool
+	AssumeValid              *string
+	SkipCheckpointPoW        *bool
+	ScriptThreads            *int
+	Prune                    *int
+	LoadSnapshot             *string
+	SnapshotHash             *string
 	DbType                   *string
 	Profile                  *string
 	CPUProfile               *string
+	MetricsListener          *string
 	Upnp                     *bool
 	MinRelayTxFee            *float64
 	FreeTxRelayLimit         *float64
 	NoRelayPriority          *bool
 	TrickleInterval          *time.Duration
 	MaxOrphanTxs             *int
+	MaxMempool               *int
+	RejectReplacement        *bool
 	Algo                     *string
 	Generate                 *bool
 	GenThreads               *int
 	MiningAddrs              *cli.StringSlice
 	MinerListener            *string
 	MinerPass                *string
+	BenchProfile             *string
 	BlockMinSize             *int
 	BlockMaxSize             *int
 	BlockMinWeight           *int
@@ -86,6 +97,9 @@ type Config struct {
 	Wallet                   *bool
 	NoInitialLoad            *bool
 	WalletPass               *string
+	CoinSelection            *string
+	WalletRBF                *bool
+	WalletBackend            *string
 	WalletServer             *string
 	CAFile                   *string
 	OneTimeTLSKey            *bool
@@ -93,5 +107,7 @@ type Config struct {
 	LegacyRPCListeners       *cli.StringSlice
 	LegacyRPCMaxClients      *int
 	LegacyRPCMaxWebsockets   *int
+	LegacyRPCMaxBatchSize    *int
+	WalletMetricsListener    *string
 	ExperimentalRPCListeners *cli.StringSlice
 }
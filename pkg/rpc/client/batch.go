@@ -0,0 +1,192 @@
+package rpcclient
+
+import (
+	js "encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+
+	"git.parallelcoin.io/dev/pod/pkg/rpc/json"
+	cl "git.parallelcoin.io/dev/pod/pkg/util/cl"
+)
+
+// ErrBatchNoResponse is an error to describe the condition where the server did not include a response to a request of a batch in its reply.
+var ErrBatchNoResponse = errors.New("no response to the request in the batch reply")
+
+// Batch is a set of commands that are sent to the server as a single JSON-RPC batch request, which takes one HTTP round trip for all of them when the client is running in HTTP POST mode.  Commands are added with Add, which returns a future for each of them, and are all sent with Send.  The futures deliver their results once Send returns.
+
+type Batch struct {
+	client   *Client
+	requests []*jsonRequest
+}
+
+// NewBatch returns a new empty batch of commands that is sent with the client.
+func (c *Client) NewBatch() *Batch {
+
+	return &Batch{client: c}
+}
+
+// Add adds the passed command to the batch and returns a future that delivers its raw result once the batch is sent.  The future can be converted to the future type of the Async function of the command to receive the result in its usual form, for example FutureGetBlockCountResult(batch.Add(json.NewGetBlockCountCmd())).
+func (b *Batch) Add(cmd interface{}) FutureRawResult {
+
+	// Get the method associated with the command.
+	method, err := json.CmdMethod(cmd)
+
+	if err != nil {
+
+		return newFutureError(err)
+	}
+
+	// Marshal the command.
+	id := b.client.NextID()
+	marshalledJSON, err := json.MarshalCmd(id, cmd)
+
+	if err != nil {
+
+		return newFutureError(err)
+	}
+	responseChan := make(chan *response, 1)
+	b.requests = append(b.requests, &jsonRequest{
+		id:             id,
+		method:         method,
+		cmd:            cmd,
+		marshalledJSON: marshalledJSON,
+		responseChan:   responseChan,
+	})
+	return responseChan
+}
+
+// Len returns the number of commands that were added to the batch.
+func (b *Batch) Len() int {
+
+	return len(b.requests)
+}
+
+// Send sends all the commands of the batch to the server and delivers the responses to their futures.  In HTTP POST mode this is a single JSON-RPC batch request, and it blocks until the server replies.  In websocket mode the commands are sent individually as websocket connections don't need a round trip per command.  The returned error is any error that prevented the whole batch from being sent, which is also delivered to every future.  The batch is empty once it is sent.
+func (b *Batch) Send() error {
+
+	requests := b.requests
+	b.requests = nil
+
+	if len(requests) == 0 {
+
+		return nil
+	}
+
+	c := b.client
+
+	if !c.config.HTTPPostMode {
+
+		for _, jReq := range requests {
+
+			c.sendRequest(jReq)
+		}
+		return nil
+	}
+
+	err := c.sendPostBatch(requests)
+
+	if err != nil {
+
+		for _, jReq := range requests {
+
+			jReq.responseChan <- &response{err: err}
+		}
+	}
+	return err
+}
+
+// batchResponse is a partially-unmarshaled JSON-RPC response of a batch, which carries the id to match it to its request.
+
+type batchResponse struct {
+	ID *uint64 `json:"id"`
+	rawResponse
+}
+
+// sendPostBatch sends the passed requests to the server in a single HTTP POST request and delivers each of the responses in the reply to the request with the same id.  Requests the reply has no response to are answered with ErrBatchNoResponse.  An error is returned when the batch could not be sent or the reply is not a batch of responses, and nothing is delivered to the requests then.
+func (c *Client) sendPostBatch(requests []*jsonRequest) error {
+
+	// Don't send the batch if shutting down.
+	select {
+
+	case <-c.shutdown:
+		return ErrClientShutdown
+	default:
+	}
+
+	marshalledJSON := make([]js.RawMessage, 0, len(requests))
+
+	for _, jReq := range requests {
+
+		marshalledJSON = append(marshalledJSON, jReq.marshalledJSON)
+	}
+	body, err := js.Marshal(marshalledJSON)
+
+	if err != nil {
+
+		return err
+	}
+	httpReq, err := c.newPostRequest(body)
+
+	if err != nil {
+
+		return err
+	}
+
+	log <- cl.Tracef{"sending batch of %d commands", len(requests)}
+
+	httpResponse, err := c.httpClient.Do(httpReq)
+
+	if err != nil {
+
+		return err
+	}
+
+	// Read the raw bytes and close the response.
+	respBytes, err := ioutil.ReadAll(httpResponse.Body)
+	httpResponse.Body.Close()
+
+	if err != nil {
+
+		return fmt.Errorf("error reading json reply: %v", err)
+	}
+
+	var responses []batchResponse
+
+	if err := js.Unmarshal(respBytes, &responses); err != nil {
+
+		// The server replies to a batch it rejects as a whole, such as one that is too large, with a single error response.
+		var resp rawResponse
+
+		if err := js.Unmarshal(respBytes, &resp); err == nil && resp.Error != nil {
+
+			return resp.Error
+		}
+		return fmt.Errorf("status code: %d, response: %q",
+			httpResponse.StatusCode, string(respBytes))
+	}
+
+	byID := make(map[uint64]*batchResponse, len(responses))
+
+	for i := range responses {
+
+		if responses[i].ID != nil {
+
+			byID[*responses[i].ID] = &responses[i]
+		}
+	}
+
+	for _, jReq := range requests {
+
+		resp, ok := byID[jReq.id]
+
+		if !ok {
+
+			jReq.responseChan <- &response{err: ErrBatchNoResponse}
+			continue
+		}
+		res, err := resp.result()
+		jReq.responseChan <- &response{result: res, err: err}
+	}
+	return nil
+}
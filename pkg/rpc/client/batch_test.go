@@ -0,0 +1,115 @@
+package rpcclient_test
+
+import (
+	"net"
+	"testing"
+
+	rpcclient "git.parallelcoin.io/dev/pod/pkg/rpc/client"
+	"git.parallelcoin.io/dev/pod/pkg/rpc/json"
+	legacyrpc "git.parallelcoin.io/dev/pod/pkg/rpc/legacy"
+)
+
+// TestBatch ensures a batch sent in HTTP POST mode to the batch-capable wallet RPC server delivers the result or error of each command to its own future, and that a batch the server rejects as a whole delivers its error to every future.
+func TestBatch(
+	t *testing.T) {
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+
+	if err != nil {
+
+		t.Fatal(err)
+	}
+
+	// Without a wallet or chain server the wallet RPC server only answers stop itself, and every other command with an error.
+	server := legacyrpc.NewServer(&legacyrpc.Options{
+
+		Username:       "user",
+		Password:       "pass",
+		MaxPOSTClients: 1,
+		MaxBatchSize:   2,
+	}, nil, []net.Listener{listener})
+	defer server.Stop()
+
+	client, err := rpcclient.New(&rpcclient.ConnConfig{
+
+		Host:         listener.Addr().String(),
+		User:         "user",
+		Pass:         "pass",
+		HTTPPostMode: true,
+	}, nil)
+
+	if err != nil {
+
+		t.Fatal(err)
+	}
+
+	defer client.Shutdown()
+
+	batch := client.NewBatch()
+
+	if err := batch.Send(); err != nil {
+
+		t.Fatalf("Send: unexpected error for an empty batch: %v", err)
+	}
+
+	blockCount := rpcclient.FutureGetBlockCountResult(
+		batch.Add(json.NewGetBlockCountCmd()))
+	stop := batch.Add(json.NewStopCmd())
+
+	if batch.Len() != 2 {
+
+		t.Fatalf("Len: got %d commands, want 2", batch.Len())
+	}
+
+	if err := batch.Send(); err != nil {
+
+		t.Fatalf("Send: unexpected error: %v", err)
+	}
+
+	if batch.Len() != 0 {
+
+		t.Fatalf("Len: got %d commands after sending, want none", batch.Len())
+	}
+
+	if _, err := blockCount.Receive(); err == nil {
+
+		t.Error("getblockcount: expected an error without a chain server")
+	}
+
+	result, err := stop.Receive()
+
+	if err != nil {
+
+		t.Fatalf("stop: unexpected error: %v", err)
+	}
+
+	if string(result) != `"mod stopping"` {
+
+		t.Errorf("stop: got result %s, want \"mod stopping\"", result)
+	}
+
+	// A batch larger than the server allows is rejected as a whole.
+	var futures []rpcclient.FutureRawResult
+
+	for i := 0; i < 3; i++ {
+
+		futures = append(futures, batch.Add(json.NewStopCmd()))
+	}
+
+	err = batch.Send()
+	rpcErr, ok := err.(*json.RPCError)
+
+	if !ok || rpcErr.Code != json.ErrRPCInvalidRequest.Code {
+
+		t.Fatalf("Send: got error %v for an oversized batch, want code %d",
+			err, json.ErrRPCInvalidRequest.Code)
+	}
+
+	for i, future := range futures {
+
+		if _, err := future.Receive(); err != rpcErr {
+
+			t.Errorf("command %d: got error %v, want %v", i, err, rpcErr)
+		}
+	}
+}
@@ -462,6 +462,78 @@ func (c *Client) GetMempoolEntry(txHash string) (*json.GetMempoolEntryResult, er
 	return c.GetMempoolEntryAsync(txHash).Receive()
 }
 
+// FutureSaveMempoolResult is a future promise to deliver the result of a SaveMempoolAsync RPC invocation (or an applicable error).
+
+type FutureSaveMempoolResult chan *response
+
+// Receive waits for the response promised by the future and returns the path of the mempool file and the number of transactions written to it.
+func (r FutureSaveMempoolResult) Receive() (*json.SaveMempoolResult, error) {
+
+	res, err := receiveFuture(r)
+
+	if err != nil {
+
+		return nil, err
+	}
+	var saveMempoolResult json.SaveMempoolResult
+	err = js.Unmarshal(res, &saveMempoolResult)
+
+	if err != nil {
+
+		return nil, err
+	}
+	return &saveMempoolResult, nil
+}
+
+// SaveMempoolAsync returns an instance of a type that can be used to get the result of the RPC at some future time by invoking the Receive function on the returned instance. See SaveMempool for the blocking version and more details.
+func (c *Client) SaveMempoolAsync() FutureSaveMempoolResult {
+
+	cmd := json.NewSaveMempoolCmd()
+	return c.sendCmd(cmd)
+}
+
+// SaveMempool writes the transactions in the memory pool of the server to the mempool file in its data directory.
+func (c *Client) SaveMempool() (*json.SaveMempoolResult, error) {
+
+	return c.SaveMempoolAsync().Receive()
+}
+
+// FutureImportMempoolResult is a future promise to deliver the result of an ImportMempoolAsync RPC invocation (or an applicable error).
+
+type FutureImportMempoolResult chan *response
+
+// Receive waits for the response promised by the future and returns the number of transactions that were accepted into the memory pool and the number that were not.
+func (r FutureImportMempoolResult) Receive() (*json.ImportMempoolResult, error) {
+
+	res, err := receiveFuture(r)
+
+	if err != nil {
+
+		return nil, err
+	}
+	var importMempoolResult json.ImportMempoolResult
+	err = js.Unmarshal(res, &importMempoolResult)
+
+	if err != nil {
+
+		return nil, err
+	}
+	return &importMempoolResult, nil
+}
+
+// ImportMempoolAsync returns an instance of a type that can be used to get the result of the RPC at some future time by invoking the Receive function on the returned instance. See ImportMempool for the blocking version and more details.
+func (c *Client) ImportMempoolAsync(filePath string) FutureImportMempoolResult {
+
+	cmd := json.NewImportMempoolCmd(filePath)
+	return c.sendCmd(cmd)
+}
+
+// ImportMempool loads the transactions in a mempool file written by SaveMempool, which must be readable by the server, into its memory pool.
+func (c *Client) ImportMempool(filePath string) (*json.ImportMempoolResult, error) {
+
+	return c.ImportMempoolAsync(filePath).Receive()
+}
+
 // FutureGetRawMempoolResult is a future promise to deliver the result of a GetRawMempoolAsync RPC invocation (or an applicable error).
 
 type FutureGetRawMempoolResult chan *response
@@ -705,6 +777,78 @@ func (c *Client) GetTxOut(txHash *chainhash.Hash, index uint32, mempool bool) (*
 	return c.GetTxOutAsync(txHash, index, mempool).Receive()
 }
 
+// FutureGetTxOutSetInfoResult is a future promise to deliver the result of a GetTxOutSetInfoAsync RPC invocation (or an applicable error).
+
+type FutureGetTxOutSetInfoResult chan *response
+
+// Receive waits for the response promised by the future and returns the statistics of the unspent transaction output set.
+func (r FutureGetTxOutSetInfoResult) Receive() (*json.GetTxOutSetInfoResult, error) {
+
+	res, err := receiveFuture(r)
+
+	if err != nil {
+
+		return nil, err
+	}
+	var txOutSetInfo json.GetTxOutSetInfoResult
+	err = js.Unmarshal(res, &txOutSetInfo)
+
+	if err != nil {
+
+		return nil, err
+	}
+	return &txOutSetInfo, nil
+}
+
+// GetTxOutSetInfoAsync returns an instance of a type that can be used to get the result of the RPC at some future time by invoking the Receive function on the returned instance. See GetTxOutSetInfo for the blocking version and more details.
+func (c *Client) GetTxOutSetInfoAsync() FutureGetTxOutSetInfoResult {
+
+	cmd := json.NewGetTxOutSetInfoCmd()
+	return c.sendCmd(cmd)
+}
+
+// GetTxOutSetInfo returns the statistics of the unspent transaction output set at the end of the main chain of the server, including the hash compared when loading utxo snapshots.
+func (c *Client) GetTxOutSetInfo() (*json.GetTxOutSetInfoResult, error) {
+
+	return c.GetTxOutSetInfoAsync().Receive()
+}
+
+// FutureDumpTxOutSetResult is a future promise to deliver the result of a DumpTxOutSetAsync RPC invocation (or an applicable error).
+
+type FutureDumpTxOutSetResult chan *response
+
+// Receive waits for the response promised by the future and returns the path, block and hash of the utxo snapshot written.
+func (r FutureDumpTxOutSetResult) Receive() (*json.DumpTxOutSetResult, error) {
+
+	res, err := receiveFuture(r)
+
+	if err != nil {
+
+		return nil, err
+	}
+	var dumpTxOutSetResult json.DumpTxOutSetResult
+	err = js.Unmarshal(res, &dumpTxOutSetResult)
+
+	if err != nil {
+
+		return nil, err
+	}
+	return &dumpTxOutSetResult, nil
+}
+
+// DumpTxOutSetAsync returns an instance of a type that can be used to get the result of the RPC at some future time by invoking the Receive function on the returned instance. See DumpTxOutSet for the blocking version and more details.
+func (c *Client) DumpTxOutSetAsync(path string) FutureDumpTxOutSetResult {
+
+	cmd := json.NewDumpTxOutSetCmd(path)
+	return c.sendCmd(cmd)
+}
+
+// DumpTxOutSet writes a utxo snapshot of the end of the main chain of the server to the passed path, which is relative to its data directory unless absolute.  The snapshot can bootstrap a new node with the loadsnapshot option.
+func (c *Client) DumpTxOutSet(path string) (*json.DumpTxOutSetResult, error) {
+
+	return c.DumpTxOutSetAsync(path).Receive()
+}
+
 // FutureRescanBlocksResult is a future promise to deliver the result of a RescanBlocksAsync RPC invocation (or an applicable error). NOTE: This is a btcsuite extension ported from github.com/decred/dcrrpcclient.
 
 type FutureRescanBlocksResult chan *response
@@ -777,6 +921,104 @@ func (c *Client) InvalidateBlock(blockHash *chainhash.Hash) error {
 	return c.InvalidateBlockAsync(blockHash).Receive()
 }
 
+// FutureReconsiderBlockResult is a future promise to deliver the result of a ReconsiderBlockAsync RPC invocation (or an applicable error).
+
+type FutureReconsiderBlockResult chan *response
+
+// Receive waits for the response promised by the future and returns an error if the block could not be reconsidered.
+func (r FutureReconsiderBlockResult) Receive() error {
+
+	_, err := receiveFuture(r)
+	return err
+}
+
+// ReconsiderBlockAsync returns an instance of a type that can be used to get the result of the RPC at some future time by invoking the Receive function on the returned instance. See ReconsiderBlock for the blocking version and more details.
+func (c *Client) ReconsiderBlockAsync(blockHash *chainhash.Hash) FutureReconsiderBlockResult {
+
+	hash := ""
+
+	if blockHash != nil {
+
+		hash = blockHash.String()
+	}
+	cmd := json.NewReconsiderBlockCmd(hash)
+	return c.sendCmd(cmd)
+}
+
+// ReconsiderBlock removes the invalid status from a specific block and its descendants.
+func (c *Client) ReconsiderBlock(blockHash *chainhash.Hash) error {
+
+	return c.ReconsiderBlockAsync(blockHash).Receive()
+}
+
+// FuturePreciousBlockResult is a future promise to deliver the result of a PreciousBlockAsync RPC invocation (or an applicable error).
+
+type FuturePreciousBlockResult chan *response
+
+// Receive waits for the response promised by the future and returns an error if the block could not be preferred.
+func (r FuturePreciousBlockResult) Receive() error {
+
+	_, err := receiveFuture(r)
+	return err
+}
+
+// PreciousBlockAsync returns an instance of a type that can be used to get the result of the RPC at some future time by invoking the Receive function on the returned instance. See PreciousBlock for the blocking version and more details.
+func (c *Client) PreciousBlockAsync(blockHash *chainhash.Hash) FuturePreciousBlockResult {
+
+	hash := ""
+
+	if blockHash != nil {
+
+		hash = blockHash.String()
+	}
+	cmd := json.NewPreciousBlockCmd(hash)
+	return c.sendCmd(cmd)
+}
+
+// PreciousBlock treats a specific block as if it was received before other blocks with the same amount of work.
+func (c *Client) PreciousBlock(blockHash *chainhash.Hash) error {
+
+	return c.PreciousBlockAsync(blockHash).Receive()
+}
+
+// FutureGetChainTipsResult is a future promise to deliver the result of a GetChainTipsAsync RPC invocation (or an applicable error).
+
+type FutureGetChainTipsResult chan *response
+
+// Receive waits for the response promised by the future and returns the tips of the main chain and of all known side chains.
+func (r FutureGetChainTipsResult) Receive() ([]json.GetChainTipsResult, error) {
+
+	res, err := receiveFuture(r)
+
+	if err != nil {
+
+		return nil, err
+	}
+
+	// Unmarshal result as an array of chain tips.
+	var tips []json.GetChainTipsResult
+	err = js.Unmarshal(res, &tips)
+
+	if err != nil {
+
+		return nil, err
+	}
+	return tips, nil
+}
+
+// GetChainTipsAsync returns an instance of a type that can be used to get the result of the RPC at some future time by invoking the Receive function on the returned instance. See GetChainTips for the blocking version and more details.
+func (c *Client) GetChainTipsAsync() FutureGetChainTipsResult {
+
+	cmd := json.NewGetChainTipsCmd()
+	return c.sendCmd(cmd)
+}
+
+// GetChainTips returns information about the tips of the main chain and of all known side chains.
+func (c *Client) GetChainTips() ([]json.GetChainTipsResult, error) {
+
+	return c.GetChainTipsAsync().Receive()
+}
+
 // FutureGetCFilterResult is a future promise to deliver the result of a GetCFilterAsync RPC invocation (or an applicable error).
 
 type FutureGetCFilterResult chan *response
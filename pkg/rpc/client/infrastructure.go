@@ -811,8 +811,8 @@ func receiveFuture(
 	return r.result, r.err
 }
 
-// sendPost sends the passed request to the server by issuing an HTTP POST request using the provided response channel for the reply.  Typically a new connection is opened and closed for each command when using this method, however, the underlying HTTP client might coalesce multiple commands depending on several factors including the remote server configuration.
-func (c *Client) sendPost(jReq *jsonRequest) {
+// newPostRequest returns an HTTP POST request of the passed marshalled JSON-RPC request body to the configured RPC server, with the basic access authorization of the client.
+func (c *Client) newPostRequest(body []byte) (*http.Request, error) {
 
 	// Generate a request to the configured RPC server.
 	protocol := "http"
@@ -822,19 +822,31 @@ func (c *Client) sendPost(jReq *jsonRequest) {
 		protocol = "https"
 	}
 	url := protocol + "://" + c.config.Host
-	bodyReader := bytes.NewReader(jReq.marshalledJSON)
+	bodyReader := bytes.NewReader(body)
 	httpReq, err := http.NewRequest("POST", url, bodyReader)
 
 	if err != nil {
 
-		jReq.responseChan <- &response{result: nil, err: err}
-		return
+		return nil, err
 	}
 	httpReq.Close = true
 	httpReq.Header.Set("Content-Type", "application/json")
 
 	// Configure basic access authorization.
 	httpReq.SetBasicAuth(c.config.User, c.config.Pass)
+	return httpReq, nil
+}
+
+// sendPost sends the passed request to the server by issuing an HTTP POST request using the provided response channel for the reply.  Typically a new connection is opened and closed for each command when using this method, however, the underlying HTTP client might coalesce multiple commands depending on several factors including the remote server configuration.
+func (c *Client) sendPost(jReq *jsonRequest) {
+
+	httpReq, err := c.newPostRequest(jReq.marshalledJSON)
+
+	if err != nil {
+
+		jReq.responseChan <- &response{result: nil, err: err}
+		return
+	}
 
 	log <- cl.Tracef{"sending command [%s] with id %d", jReq.method, jReq.id}
 
@@ -263,6 +263,82 @@ func (c *Client) GetNetworkHashPS3(blocks, height int) (int64, error) {
 	return c.GetNetworkHashPS3Async(blocks, height).Receive()
 }
 
+// FutureGetAlgoStatsResult is a future promise to deliver the result of a GetAlgoStatsAsync RPC invocation (or an applicable error).
+
+type FutureGetAlgoStatsResult chan *response
+
+// Receive waits for the response promised by the future and returns the per-algorithm statistics.
+func (r FutureGetAlgoStatsResult) Receive() (*json.GetAlgoStatsResult, error) {
+
+	res, err := receiveFuture(r)
+
+	if err != nil {
+
+		return nil, err
+	}
+
+	// Unmarshal result as a getalgostats result object.
+	var statsResult json.GetAlgoStatsResult
+	err = js.Unmarshal(res, &statsResult)
+
+	if err != nil {
+
+		return nil, err
+	}
+	return &statsResult, nil
+}
+
+// GetAlgoStatsAsync returns an instance of a type that can be used to get the result of the RPC at some future time by invoking the Receive function on the returned instance. See GetAlgoStats for the blocking version and more details.
+func (c *Client) GetAlgoStatsAsync(blocks, height int) FutureGetAlgoStatsResult {
+
+	cmd := json.NewGetAlgoStatsCmd(&blocks, &height)
+	return c.sendCmd(cmd)
+}
+
+// GetAlgoStats returns the block count, difficulty, estimated network hashes per second and average block interval of each mining algorithm for the specified number of blocks ending at the specified height.  The height can be -1 to use the current best block height.
+func (c *Client) GetAlgoStats(blocks, height int) (*json.GetAlgoStatsResult, error) {
+
+	return c.GetAlgoStatsAsync(blocks, height).Receive()
+}
+
+// FutureGetWorkerStatsResult is a future promise to deliver the result of a GetWorkerStatsAsync RPC invocation (or an applicable error).
+
+type FutureGetWorkerStatsResult chan *response
+
+// Receive waits for the response promised by the future and returns the share accounting of the miner controller workers.
+func (r FutureGetWorkerStatsResult) Receive() ([]json.GetWorkerStatsResult, error) {
+
+	res, err := receiveFuture(r)
+
+	if err != nil {
+
+		return nil, err
+	}
+
+	// Unmarshal result as an array of getworkerstats result objects.
+	var stats []json.GetWorkerStatsResult
+	err = js.Unmarshal(res, &stats)
+
+	if err != nil {
+
+		return nil, err
+	}
+	return stats, nil
+}
+
+// GetWorkerStatsAsync returns an instance of a type that can be used to get the result of the RPC at some future time by invoking the Receive function on the returned instance. See GetWorkerStats for the blocking version and more details.
+func (c *Client) GetWorkerStatsAsync() FutureGetWorkerStatsResult {
+
+	cmd := json.NewGetWorkerStatsCmd()
+	return c.sendCmd(cmd)
+}
+
+// GetWorkerStats returns the share accounting of each worker that has logged in to the miner controller of the server.
+func (c *Client) GetWorkerStats() ([]json.GetWorkerStatsResult, error) {
+
+	return c.GetWorkerStatsAsync().Receive()
+}
+
 // FutureGetWork is a future promise to deliver the result of a GetWorkAsync RPC invocation (or an applicable error).
 
 type FutureGetWork chan *response
@@ -292,3 +292,127 @@ func (c *Client) GetNetTotals() (*json.GetNetTotalsResult, error) {
 
 	return c.GetNetTotalsAsync().Receive()
 }
+
+// FutureGetNetworkInfoResult is a future promise to deliver the result of a GetNetworkInfoAsync RPC invocation (or an applicable error).
+
+type FutureGetNetworkInfoResult chan *response
+
+// Receive waits for the response promised by the future and returns information about the P2P networking state of the server.
+func (r FutureGetNetworkInfoResult) Receive() (*json.GetNetworkInfoResult, error) {
+
+	res, err := receiveFuture(r)
+
+	if err != nil {
+
+		return nil, err
+	}
+
+	// Unmarshal result as a getnetworkinfo result object.
+	var info json.GetNetworkInfoResult
+	err = js.Unmarshal(res, &info)
+
+	if err != nil {
+
+		return nil, err
+	}
+	return &info, nil
+}
+
+// GetNetworkInfoAsync returns an instance of a type that can be used to get the result of the RPC at some future time by invoking the Receive function on the returned instance. See GetNetworkInfo for the blocking version and more details.
+func (c *Client) GetNetworkInfoAsync() FutureGetNetworkInfoResult {
+
+	cmd := json.NewGetNetworkInfoCmd()
+	return c.sendCmd(cmd)
+}
+
+// GetNetworkInfo returns information about the P2P networking state of the server, such as its version, connection counts, relay fee, reachable networks and local addresses.
+func (c *Client) GetNetworkInfo() (*json.GetNetworkInfoResult, error) {
+
+	return c.GetNetworkInfoAsync().Receive()
+}
+
+// FutureSetBanResult is a future promise to deliver the result of a SetBanAsync RPC invocation (or an applicable error).
+
+type FutureSetBanResult chan *response
+
+// Receive waits for the response promised by the future and returns an error if any occurred when performing the specified command.
+func (r FutureSetBanResult) Receive() error {
+
+	_, err := receiveFuture(r)
+	return err
+}
+
+// SetBanAsync returns an instance of a type that can be used to get the result of the RPC at some future time by invoking the Receive function on the returned instance. See SetBan for the blocking version and more details.
+func (c *Client) SetBanAsync(subnet string, command json.SetBanSubCmd, banTime *int64, absolute *bool) FutureSetBanResult {
+
+	cmd := json.NewSetBanCmd(subnet, command, banTime, absolute)
+	return c.sendCmd(cmd)
+}
+
+// SetBan bans an address or subnet in CIDR notation, or lifts its ban. The ban time is in seconds, or a unix time when absolute is true, and passing nil or zero uses the ban duration of the server.
+func (c *Client) SetBan(subnet string, command json.SetBanSubCmd, banTime *int64, absolute *bool) error {
+
+	return c.SetBanAsync(subnet, command, banTime, absolute).Receive()
+}
+
+// FutureListBannedResult is a future promise to deliver the result of a ListBannedAsync RPC invocation (or an applicable error).
+
+type FutureListBannedResult chan *response
+
+// Receive waits for the response promised by the future and returns the banned addresses and subnets.
+func (r FutureListBannedResult) Receive() ([]json.ListBannedResult, error) {
+
+	res, err := receiveFuture(r)
+
+	if err != nil {
+
+		return nil, err
+	}
+
+	// Unmarshal result as an array of listbanned result objects.
+	var bans []json.ListBannedResult
+	err = js.Unmarshal(res, &bans)
+
+	if err != nil {
+
+		return nil, err
+	}
+	return bans, nil
+}
+
+// ListBannedAsync returns an instance of a type that can be used to get the result of the RPC at some future time by invoking the Receive function on the returned instance. See ListBanned for the blocking version and more details.
+func (c *Client) ListBannedAsync() FutureListBannedResult {
+
+	cmd := json.NewListBannedCmd()
+	return c.sendCmd(cmd)
+}
+
+// ListBanned returns the banned addresses and subnets along with when and why they were banned.
+func (c *Client) ListBanned() ([]json.ListBannedResult, error) {
+
+	return c.ListBannedAsync().Receive()
+}
+
+// FutureClearBannedResult is a future promise to deliver the result of a ClearBannedAsync RPC invocation (or an applicable error).
+
+type FutureClearBannedResult chan *response
+
+// Receive waits for the response promised by the future and returns an error if any occurred when performing the specified command.
+func (r FutureClearBannedResult) Receive() error {
+
+	_, err := receiveFuture(r)
+	return err
+}
+
+// ClearBannedAsync returns an instance of a type that can be used to get the result of the RPC at some future time by invoking the Receive function on the returned instance. See ClearBanned for the blocking version and more details.
+func (c *Client) ClearBannedAsync() FutureClearBannedResult {
+
+	cmd := json.NewClearBannedCmd()
+	return c.sendCmd(cmd)
+}
+
+// ClearBanned lifts all bans of addresses and subnets.
+func (c *Client) ClearBanned() error {
+
+	return c.ClearBannedAsync().Receive()
+}
@@ -623,7 +623,7 @@ func (r FutureSendToAddressResult) Receive() (*chainhash.Hash, error) {
 func (c *Client) SendToAddressAsync(address util.Address, amount util.Amount) FutureSendToAddressResult {
 
 	addr := address.EncodeAddress()
-	cmd := json.NewSendToAddressCmd(addr, amount.ToDUO(), nil, nil)
+	cmd := json.NewSendToAddressCmd(addr, amount.ToDUO(), nil, nil, nil, nil)
 	return c.sendCmd(cmd)
 }
 
@@ -656,7 +656,7 @@ func (c *Client) SendToAddressCommentAsync(address util.Address,
 
 	addr := address.EncodeAddress()
 	cmd := json.NewSendToAddressCmd(addr, amount.ToDUO(), &comment,
-		&commentTo)
+		&commentTo, nil, nil)
 	return c.sendCmd(cmd)
 }
 
@@ -727,7 +727,7 @@ func (c *Client) SendFromAsync(fromAccount string, toAddress util.Address, amoun
 
 	addr := toAddress.EncodeAddress()
 	cmd := json.NewSendFromCmd(fromAccount, addr, amount.ToDUO(), nil,
-		nil, nil)
+		nil, nil, nil, nil)
 	return c.sendCmd(cmd)
 }
 
@@ -758,7 +758,7 @@ func (c *Client) SendFromMinConfAsync(fromAccount string, toAddress util.Address
 
 	addr := toAddress.EncodeAddress()
 	cmd := json.NewSendFromCmd(fromAccount, addr, amount.ToDUO(),
-		&minConfirms, nil, nil)
+		&minConfirms, nil, nil, nil, nil)
 	return c.sendCmd(cmd)
 }
 
@@ -794,7 +794,7 @@ func (c *Client) SendFromCommentAsync(fromAccount string,
 
 	addr := toAddress.EncodeAddress()
 	cmd := json.NewSendFromCmd(fromAccount, addr, amount.ToDUO(),
-		&minConfirms, &comment, &commentTo)
+		&minConfirms, &comment, &commentTo, nil, nil)
 	return c.sendCmd(cmd)
 }
 
@@ -871,7 +871,7 @@ func (c *Client) SendManyAsync(fromAccount string, amounts map[util.Address]util
 
 		convertedAmounts[addr.EncodeAddress()] = amount.ToDUO()
 	}
-	cmd := json.NewSendManyCmd(fromAccount, convertedAmounts, nil, nil)
+	cmd := json.NewSendManyCmd(fromAccount, convertedAmounts, nil, nil, nil, nil)
 	return c.sendCmd(cmd)
 }
 
@@ -909,7 +909,7 @@ func (c *Client) SendManyMinConfAsync(fromAccount string,
 		convertedAmounts[addr.EncodeAddress()] = amount.ToDUO()
 	}
 	cmd := json.NewSendManyCmd(fromAccount, convertedAmounts,
-		&minConfirms, nil)
+		&minConfirms, nil, nil, nil)
 	return c.sendCmd(cmd)
 }
 
@@ -951,7 +951,7 @@ func (c *Client) SendManyCommentAsync(fromAccount string,
 		convertedAmounts[addr.EncodeAddress()] = amount.ToDUO()
 	}
 	cmd := json.NewSendManyCmd(fromAccount, convertedAmounts,
-		&minConfirms, &comment)
+		&minConfirms, &comment, nil, nil)
 	return c.sendCmd(cmd)
 }
 
@@ -978,6 +978,290 @@ func (c *Client) SendManyComment(fromAccount string,
 		comment).Receive()
 }
 
+// **************************************
+
+// Partially Signed Transaction Functions
+
+// **************************************
+
+// FutureWalletCreateFundedPsbtResult is a future promise to deliver the result
+
+// of a WalletCreateFundedPsbtAsync RPC invocation (or an applicable error).
+
+type FutureWalletCreateFundedPsbtResult chan *response
+
+// Receive waits for the response promised by the future and returns the
+
+// funded partially signed transaction, its fee and the index of its change
+
+// output.
+func (r FutureWalletCreateFundedPsbtResult) Receive() (*json.WalletCreateFundedPsbtResult, error) {
+
+	res, err := receiveFuture(r)
+
+	if err != nil {
+
+		return nil, err
+	}
+
+	// Unmarshal result as a walletcreatefundedpsbt result object.
+	var result json.WalletCreateFundedPsbtResult
+	err = js.Unmarshal(res, &result)
+
+	if err != nil {
+
+		return nil, err
+	}
+	return &result, nil
+}
+
+// WalletCreateFundedPsbtAsync returns an instance of a type that can be used
+
+// to get the result of the RPC at some future time by invoking the Receive
+
+// function on the returned instance.
+
+// See WalletCreateFundedPsbt for the blocking version and more details.
+func (c *Client) WalletCreateFundedPsbtAsync(inputs []json.PsbtInput,
+	amounts map[util.Address]util.Amount, lockTime *uint32,
+	options *json.WalletCreateFundedPsbtOpts) FutureWalletCreateFundedPsbtResult {
+
+	convertedAmts := make(map[string]float64, len(amounts))
+
+	for addr, amount := range amounts {
+
+		convertedAmts[addr.String()] = amount.ToDUO()
+	}
+	cmd := json.NewWalletCreateFundedPsbtCmd(inputs, convertedAmts, lockTime,
+		options)
+	return c.sendCmd(cmd)
+}
+
+// WalletCreateFundedPsbt creates a partially signed transaction paying the
+
+// passed amounts, funded by the passed inputs and by outputs of the default
+
+// account when those do not cover the amounts and the fee.
+func (c *Client) WalletCreateFundedPsbt(inputs []json.PsbtInput,
+	amounts map[util.Address]util.Amount, lockTime *uint32,
+	options *json.WalletCreateFundedPsbtOpts) (*json.WalletCreateFundedPsbtResult, error) {
+
+	return c.WalletCreateFundedPsbtAsync(inputs, amounts, lockTime,
+		options).Receive()
+}
+
+// FutureWalletProcessPsbtResult is a future promise to deliver the result of
+
+// a WalletProcessPsbtAsync RPC invocation (or an applicable error).
+
+type FutureWalletProcessPsbtResult chan *response
+
+// Receive waits for the response promised by the future and returns the
+
+// updated partially signed transaction and whether it is complete.
+func (r FutureWalletProcessPsbtResult) Receive() (*json.WalletProcessPsbtResult, error) {
+
+	res, err := receiveFuture(r)
+
+	if err != nil {
+
+		return nil, err
+	}
+
+	// Unmarshal result as a walletprocesspsbt result object.
+	var result json.WalletProcessPsbtResult
+	err = js.Unmarshal(res, &result)
+
+	if err != nil {
+
+		return nil, err
+	}
+	return &result, nil
+}
+
+// WalletProcessPsbtAsync returns an instance of a type that can be used to get
+
+// the result of the RPC at some future time by invoking the Receive function
+
+// on the returned instance.
+
+// See WalletProcessPsbt for the blocking version and more details.
+func (c *Client) WalletProcessPsbtAsync(psbt string, sign bool,
+	sigHashType string) FutureWalletProcessPsbtResult {
+
+	cmd := json.NewWalletProcessPsbtCmd(psbt, &sign, &sigHashType)
+	return c.sendCmd(cmd)
+}
+
+// WalletProcessPsbt adds what the wallet knows about the inputs of the base64
+
+// encoded partially signed transaction to it and, when sign is set, signs and
+
+// finalizes the inputs the wallet holds the keys for.
+
+// NOTE: Signing requires the wallet to be unlocked.  See the
+
+// WalletPassphrase function for more details.
+func (c *Client) WalletProcessPsbt(psbt string, sign bool,
+	sigHashType string) (*json.WalletProcessPsbtResult, error) {
+
+	return c.WalletProcessPsbtAsync(psbt, sign, sigHashType).Receive()
+}
+
+// FutureFinalizePsbtResult is a future promise to deliver the result of a
+
+// FinalizePsbtAsync RPC invocation (or an applicable error).
+
+type FutureFinalizePsbtResult chan *response
+
+// Receive waits for the response promised by the future and returns the
+
+// finalized partially signed transaction, or the network transaction when it
+
+// was extracted.
+func (r FutureFinalizePsbtResult) Receive() (*json.FinalizePsbtResult, error) {
+
+	res, err := receiveFuture(r)
+
+	if err != nil {
+
+		return nil, err
+	}
+
+	// Unmarshal result as a finalizepsbt result object.
+	var result json.FinalizePsbtResult
+	err = js.Unmarshal(res, &result)
+
+	if err != nil {
+
+		return nil, err
+	}
+	return &result, nil
+}
+
+// FinalizePsbtAsync returns an instance of a type that can be used to get the
+
+// result of the RPC at some future time by invoking the Receive function on the
+
+// returned instance.
+
+// See FinalizePsbt for the blocking version and more details.
+func (c *Client) FinalizePsbtAsync(psbt string, extract bool) FutureFinalizePsbtResult {
+
+	cmd := json.NewFinalizePsbtCmd(psbt, &extract)
+	return c.sendCmd(cmd)
+}
+
+// FinalizePsbt finalizes the inputs of the base64 encoded partially signed
+
+// transaction that hold all their signatures.  When extract is set and every
+
+// input is finalized the network transaction is returned instead.
+func (c *Client) FinalizePsbt(psbt string, extract bool) (*json.FinalizePsbtResult, error) {
+
+	return c.FinalizePsbtAsync(psbt, extract).Receive()
+}
+
+// FutureCombinePsbtResult is a future promise to deliver the result of a
+
+// CombinePsbtAsync RPC invocation (or an applicable error).
+
+type FutureCombinePsbtResult chan *response
+
+// Receive waits for the response promised by the future and returns the
+
+// combined partially signed transaction encoded as base64.
+func (r FutureCombinePsbtResult) Receive() (string, error) {
+
+	res, err := receiveFuture(r)
+
+	if err != nil {
+
+		return "", err
+	}
+
+	// Unmarshal result as a string.
+	var b64 string
+	err = js.Unmarshal(res, &b64)
+
+	if err != nil {
+
+		return "", err
+	}
+	return b64, nil
+}
+
+// CombinePsbtAsync returns an instance of a type that can be used to get the
+
+// result of the RPC at some future time by invoking the Receive function on the
+
+// returned instance.
+
+// See CombinePsbt for the blocking version and more details.
+func (c *Client) CombinePsbtAsync(psbts []string) FutureCombinePsbtResult {
+
+	cmd := json.NewCombinePsbtCmd(psbts)
+	return c.sendCmd(cmd)
+}
+
+// CombinePsbt merges base64 encoded partially signed transactions of the same
+
+// transaction into one.
+func (c *Client) CombinePsbt(psbts []string) (string, error) {
+
+	return c.CombinePsbtAsync(psbts).Receive()
+}
+
+// FutureDecodePsbtResult is a future promise to deliver the result of a
+
+// DecodePsbtAsync RPC invocation (or an applicable error).
+
+type FutureDecodePsbtResult chan *response
+
+// Receive waits for the response promised by the future and returns the
+
+// decoded partially signed transaction.
+func (r FutureDecodePsbtResult) Receive() (*json.DecodePsbtResult, error) {
+
+	res, err := receiveFuture(r)
+
+	if err != nil {
+
+		return nil, err
+	}
+
+	// Unmarshal result as a decodepsbt result object.
+	var result json.DecodePsbtResult
+	err = js.Unmarshal(res, &result)
+
+	if err != nil {
+
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DecodePsbtAsync returns an instance of a type that can be used to get the
+
+// result of the RPC at some future time by invoking the Receive function on the
+
+// returned instance.
+
+// See DecodePsbt for the blocking version and more details.
+func (c *Client) DecodePsbtAsync(psbt string) FutureDecodePsbtResult {
+
+	cmd := json.NewDecodePsbtCmd(psbt)
+	return c.sendCmd(cmd)
+}
+
+// DecodePsbt returns a description of the base64 encoded partially signed
+
+// transaction.
+func (c *Client) DecodePsbt(psbt string) (*json.DecodePsbtResult, error) {
+
+	return c.DecodePsbtAsync(psbt).Receive()
+}
+
 // *************************
 
 // Address/Account Functions
@@ -1421,6 +1705,154 @@ func (c *Client) SetAccount(address util.Address, account string) error {
 	return c.SetAccountAsync(address, account).Receive()
 }
 
+// FutureSetLabelResult is a future promise to deliver the result of a
+
+// SetLabelAsync RPC invocation (or an applicable error).
+
+type FutureSetLabelResult chan *response
+
+// Receive waits for the response promised by the future and returns the result
+
+// of setting the label of the passed address.
+func (r FutureSetLabelResult) Receive() error {
+
+	_, err := receiveFuture(r)
+	return err
+}
+
+// SetLabelAsync returns an instance of a type that can be used to get the
+
+// result of the RPC at some future time by invoking the Receive function on the
+
+// returned instance.
+
+// See SetLabel for the blocking version and more details.
+func (c *Client) SetLabelAsync(address util.Address, label string) FutureSetLabelResult {
+
+	addr := address.EncodeAddress()
+	cmd := json.NewSetLabelCmd(addr, label)
+	return c.sendCmd(cmd)
+}
+
+// SetLabel sets the label of the passed address.  An empty label removes the
+
+// label.
+func (c *Client) SetLabel(address util.Address, label string) error {
+
+	return c.SetLabelAsync(address, label).Receive()
+}
+
+// FutureSetTxLabelResult is a future promise to deliver the result of a
+
+// SetTxLabelAsync RPC invocation (or an applicable error).
+
+type FutureSetTxLabelResult chan *response
+
+// Receive waits for the response promised by the future and returns the result
+
+// of setting the label of a wallet transaction.
+func (r FutureSetTxLabelResult) Receive() error {
+
+	_, err := receiveFuture(r)
+	return err
+}
+
+// SetTxLabelAsync returns an instance of a type that can be used to get the
+
+// result of the RPC at some future time by invoking the Receive function on the
+
+// returned instance.
+
+// See SetTxLabel for the blocking version and more details.
+
+// NOTE: This is a btcwallet extension.
+func (c *Client) SetTxLabelAsync(txHash *chainhash.Hash, label string) FutureSetTxLabelResult {
+
+	hash := ""
+
+	if txHash != nil {
+
+		hash = txHash.String()
+	}
+	cmd := json.NewSetTxLabelCmd(hash, label)
+	return c.sendCmd(cmd)
+}
+
+// SetTxLabel sets the label of a transaction recorded by the wallet.  An empty
+
+// label removes the label.
+
+// NOTE: This is a btcwallet extension.
+func (c *Client) SetTxLabel(txHash *chainhash.Hash, label string) error {
+
+	return c.SetTxLabelAsync(txHash, label).Receive()
+}
+
+// FutureBumpFeeResult is a future promise to deliver the result of a
+
+// BumpFeeAsync RPC invocation (or an applicable error).
+
+type FutureBumpFeeResult chan *response
+
+// Receive waits for the response promised by the future and returns the hash
+
+// and fee of the replacement transaction along with the fee it replaced.
+func (r FutureBumpFeeResult) Receive() (*json.BumpFeeResult, error) {
+
+	res, err := receiveFuture(r)
+
+	if err != nil {
+
+		return nil, err
+	}
+
+	// Unmarshal result as a bumpfee result object.
+	var result json.BumpFeeResult
+	err = js.Unmarshal(res, &result)
+
+	if err != nil {
+
+		return nil, err
+	}
+	return &result, nil
+}
+
+// BumpFeeAsync returns an instance of a type that can be used to get the
+
+// result of the RPC at some future time by invoking the Receive function on the
+
+// returned instance.
+
+// See BumpFee for the blocking version and more details.
+func (c *Client) BumpFeeAsync(txHash *chainhash.Hash, feeRate util.Amount) FutureBumpFeeResult {
+
+	hash := ""
+
+	if txHash != nil {
+
+		hash = txHash.String()
+	}
+
+	var rate *float64
+
+	if feeRate != 0 {
+
+		rate = json.Float64(feeRate.ToDUO())
+	}
+	cmd := json.NewBumpFeeCmd(hash, rate)
+	return c.sendCmd(cmd)
+}
+
+// BumpFee replaces an unconfirmed wallet transaction that signals
+
+// replaceability with one paying the same outputs at a higher fee rate.  A
+
+// zero feeRate lets the wallet choose one just above that of the original.
+func (c *Client) BumpFee(txHash *chainhash.Hash, feeRate util.Amount) (*json.BumpFeeResult, error) {
+
+	return c.BumpFeeAsync(txHash, feeRate).Receive()
+}
+
 // FutureGetAddressesByAccountResult is a future promise to deliver the result
 
 // of a GetAddressesByAccountAsync RPC invocation (or an applicable error).
@@ -2916,6 +3348,102 @@ func (c *Client) ImportPubKeyRescan(pubKey string, rescan bool) error {
 	return c.ImportPubKeyRescanAsync(pubKey, rescan).Receive()
 }
 
+// FutureImportXpubResult is a future promise to deliver the result of an
+
+// ImportXpubAsync RPC invocation (or an applicable error).
+
+type FutureImportXpubResult chan *response
+
+// Receive waits for the response promised by the future and returns the result
+
+// of importing the passed extended public key.
+func (r FutureImportXpubResult) Receive() error {
+
+	_, err := receiveFuture(r)
+	return err
+}
+
+// ImportXpubAsync returns an instance of a type that can be used to get the
+
+// result of the RPC at some future time by invoking the Receive function on the
+
+// returned instance.
+
+// See ImportXpub for the blocking version and more details.
+
+// NOTE: This is a btcwallet extension.
+func (c *Client) ImportXpubAsync(xpub, account string, rescan bool) FutureImportXpubResult {
+
+	cmd := json.NewImportXpubCmd(xpub, account, &rescan)
+	return c.sendCmd(cmd)
+}
+
+// ImportXpub creates the watch-only account named account from the passed
+
+// extended public key of an account of another wallet.  When rescan is true,
+
+// the block history is scanned for transactions of the account.
+
+// NOTE: This is a btcwallet extension.
+func (c *Client) ImportXpub(xpub, account string, rescan bool) error {
+
+	return c.ImportXpubAsync(xpub, account, rescan).Receive()
+}
+
+// FutureGetSpvSyncInfoResult is a future promise to deliver the result of a
+
+// GetSpvSyncInfoAsync RPC invocation (or an applicable error).
+
+type FutureGetSpvSyncInfoResult chan *response
+
+// Receive waits for the response promised by the future and returns how far
+
+// the light client of the wallet has synced.
+func (r FutureGetSpvSyncInfoResult) Receive() (*json.GetSpvSyncInfoResult, error) {
+
+	res, err := receiveFuture(r)
+
+	if err != nil {
+
+		return nil, err
+	}
+
+	var syncInfo json.GetSpvSyncInfoResult
+	err = js.Unmarshal(res, &syncInfo)
+
+	if err != nil {
+
+		return nil, err
+	}
+
+	return &syncInfo, nil
+}
+
+// GetSpvSyncInfoAsync returns an instance of a type that can be used to get the
+
+// result of the RPC at some future time by invoking the Receive function on the
+
+// returned instance.
+
+// See GetSpvSyncInfo for the blocking version and more details.
+
+// NOTE: This is a btcwallet extension.
+func (c *Client) GetSpvSyncInfoAsync() FutureGetSpvSyncInfoResult {
+
+	cmd := json.NewGetSpvSyncInfoCmd()
+	return c.sendCmd(cmd)
+}
+
+// GetSpvSyncInfo returns how far the light client of a wallet using the spv
+
+// backend has synced the block headers and compact filter headers.
+
+// NOTE: This is a btcwallet extension.
+func (c *Client) GetSpvSyncInfo() (*json.GetSpvSyncInfoResult, error) {
+
+	return c.GetSpvSyncInfoAsync().Receive()
+}
+
 // ***********************
 
 // Miscellaneous Functions
@@ -1,6 +1,7 @@
 // Copyright (c) 2015 The btcsuite developers
 
-//+build !generate
+//go:build !generate
+// +build !generate
 
 package rpchelp
 
@@ -13,6 +14,22 @@ var helpDescsEnUS = map[string]string{
 	"addmultisigaddress-nrequired": "The number of signatures required to redeem outputs paid to this address",
 	"addmultisigaddress--result0":  "The imported pay-to-script-hash address",
 
+	// BumpFeeCmd help.
+	"bumpfee--synopsis": "Replaces an unconfirmed wallet transaction that signals replaceability (BIP 125) with one paying the same outputs at a higher fee.\n" +
+		"Any further inputs the higher fee requires are taken from confirmed outputs of the account the original transaction returned change to.",
+	"bumpfee-txid":    "The hash of the transaction to replace",
+	"bumpfee-feerate": "The fee rate of the replacement valued in bitcoin per kilobyte (default=the fee rate of the original transaction plus the relay fee)",
+
+	// BumpFeeResult help.
+	"bumpfeeresult-txid":    "The hash of the replacement transaction",
+	"bumpfeeresult-origfee": "The fee of the replaced transaction valued in bitcoin",
+	"bumpfeeresult-fee":     "The fee of the replacement transaction valued in bitcoin",
+
+	// CombinePsbtCmd help.
+	"combinepsbt--synopsis": "Combines several partially signed transactions (BIP 174) of the same transaction into one holding the signatures and input and output information of all of them.",
+	"combinepsbt-psbts":     "The base64 encoded partially signed transactions to combine",
+	"combinepsbt--result0":  "The combined partially signed transaction encoded as a base64 string",
+
 	// CreateMultisigCmd help.
 	"createmultisig--synopsis": "Generate a multisig address and redeem script.",
 	"createmultisig-keys":      "Pubkeys and/or pay-to-pubkey-hash addresses to partially control the multisig address",
@@ -22,11 +39,108 @@ var helpDescsEnUS = map[string]string{
 	"createmultisigresult-address":      "The generated pay-to-script-hash address",
 	"createmultisigresult-redeemScript": "The script required to redeem outputs paid to the multisig address",
 
+	// DecodePsbtCmd help.
+	"decodepsbt--synopsis": "Returns a JSON object describing a partially signed transaction (BIP 174).",
+	"decodepsbt-psbt":      "The partially signed transaction encoded as a base64 string",
+
+	// DecodePsbtResult help.
+	"decodepsbtresult-tx":             "The unsigned transaction as a JSON object",
+	"decodepsbtresult-unknown":        "The hex-encoded keys and values of the global map that are not understood",
+	"decodepsbtresult-unknown--desc":  "JSON object using hex-encoded keys of the global map as keys and their hex-encoded values as values",
+	"decodepsbtresult-unknown--key":   "The hex-encoded key",
+	"decodepsbtresult-unknown--value": "The hex-encoded value",
+	"decodepsbtresult-inputs":         "The information held about each input",
+	"decodepsbtresult-outputs":        "The information held about each output",
+	"decodepsbtresult-fee":            "The fee of the transaction valued in bitcoin (only when the outputs spent by all inputs are known)",
+
+	// DecodePsbtInput help.
+	"decodepsbtinput-non_witness_utxo":          "The transaction whose output the input spends",
+	"decodepsbtinput-witness_utxo":              "The output spent by a segregated witness input",
+	"decodepsbtinput-partial_signatures":        "The hex-encoded signatures of the input keyed by the hex-encoded public keys they verify with",
+	"decodepsbtinput-partial_signatures--desc":  "JSON object using hex-encoded public keys as keys and hex-encoded signatures as values",
+	"decodepsbtinput-partial_signatures--key":   "The hex-encoded public key",
+	"decodepsbtinput-partial_signatures--value": "The hex-encoded signature",
+	"decodepsbtinput-sighash":                   "The sighash flags signers of the input must use",
+	"decodepsbtinput-redeem_script":             "The redeem script of a pay-to-script-hash input",
+	"decodepsbtinput-witness_script":            "The witness script of a pay-to-witness-script-hash input",
+	"decodepsbtinput-bip32_derivs":              "The derivation paths of the public keys of the input",
+	"decodepsbtinput-final_scriptSig":           "The final signature script of a finalized input",
+	"decodepsbtinput-final_scriptwitness":       "The hex-encoded items of the final witness of a finalized input",
+	"decodepsbtinput-unknown":                   "The hex-encoded keys and values of the input map that are not understood",
+	"decodepsbtinput-unknown--desc":             "JSON object using hex-encoded keys of the input map as keys and their hex-encoded values as values",
+	"decodepsbtinput-unknown--key":              "The hex-encoded key",
+	"decodepsbtinput-unknown--value":            "The hex-encoded value",
+
+	// DecodePsbtOutput help.
+	"decodepsbtoutput-redeem_script":  "The redeem script of a pay-to-script-hash output",
+	"decodepsbtoutput-witness_script": "The witness script of a pay-to-witness-script-hash output",
+	"decodepsbtoutput-bip32_derivs":   "The derivation paths of the public keys of the output",
+	"decodepsbtoutput-unknown":        "The hex-encoded keys and values of the output map that are not understood",
+	"decodepsbtoutput-unknown--desc":  "JSON object using hex-encoded keys of the output map as keys and their hex-encoded values as values",
+	"decodepsbtoutput-unknown--key":   "The hex-encoded key",
+	"decodepsbtoutput-unknown--value": "The hex-encoded value",
+
+	// DecodePsbtWitnessUtxo help.
+	"decodepsbtwitnessutxo-amount":       "The value of the output valued in bitcoin",
+	"decodepsbtwitnessutxo-scriptPubKey": "The public key script of the output as a JSON object",
+
+	// DecodePsbtScript help.
+	"decodepsbtscript-asm":  "Disassembly of the script",
+	"decodepsbtscript-hex":  "Hex-encoded bytes of the script",
+	"decodepsbtscript-type": "The type of the script (e.g. 'multisig')",
+
+	// DecodePsbtBip32Deriv help.
+	"decodepsbtbip32deriv-pubkey":             "The hex-encoded public key",
+	"decodepsbtbip32deriv-master_fingerprint": "The hex-encoded fingerprint of the master key the public key derives from",
+	"decodepsbtbip32deriv-path":               "The derivation path of the public key, with ' marking hardened steps",
+
+	// TxRawDecodeResult help.
+	"txrawdecoderesult-txid":     "The hash of the transaction",
+	"txrawdecoderesult-version":  "The transaction version",
+	"txrawdecoderesult-locktime": "The transaction lock time",
+	"txrawdecoderesult-vin":      "The transaction inputs as JSON objects",
+	"txrawdecoderesult-vout":     "The transaction outputs as JSON objects",
+
+	// Vin help.
+	"vin-coinbase":    "The hex-encoded bytes of the signature script (coinbase txns only)",
+	"vin-txid":        "The hash of the origin transaction (non-coinbase txns only)",
+	"vin-vout":        "The index of the output being redeemed from the origin transaction (non-coinbase txns only)",
+	"vin-scriptSig":   "The signature script used to redeem the origin transaction as a JSON object (non-coinbase txns only)",
+	"vin-txinwitness": "The witness used to redeem the input encoded as a string array of its items",
+	"vin-sequence":    "The script sequence number",
+
+	// ScriptSig help.
+	"scriptsig-asm": "Disassembly of the script",
+	"scriptsig-hex": "Hex-encoded bytes of the script",
+
+	// Vout help.
+	"vout-value":        "The amount in DUO",
+	"vout-n":            "The index of this transaction output",
+	"vout-scriptPubKey": "The public key script used to pay coins as a JSON object",
+
+	// ScriptPubKeyResult help.
+	"scriptpubkeyresult-asm":       "Disassembly of the script",
+	"scriptpubkeyresult-hex":       "Hex-encoded bytes of the script",
+	"scriptpubkeyresult-reqSigs":   "The number of required signatures",
+	"scriptpubkeyresult-type":      "The type of the script (e.g. 'pubkeyhash')",
+	"scriptpubkeyresult-addresses": "The bitcoin addresses associated with this script",
+
 	// DumpPrivKeyCmd help.
 	"dumpprivkey--synopsis": "Returns the private key in WIF encoding that controls some wallet address.",
 	"dumpprivkey-address":   "The address to return a private key for",
 	"dumpprivkey--result0":  "The WIF-encoded private key",
 
+	// FinalizePsbtCmd help.
+	"finalizepsbt--synopsis": "Builds the final signature scripts and witnesses of the inputs of a partially signed transaction (BIP 174) that hold all the signatures they need.\n" +
+		"When every input is finalized the network transaction is returned in place of the partially signed transaction, unless extract is false.",
+	"finalizepsbt-psbt":    "The partially signed transaction encoded as a base64 string",
+	"finalizepsbt-extract": "Return the network transaction encoded as a hexadecimal string when every input is finalized",
+
+	// FinalizePsbtResult help.
+	"finalizepsbtresult-psbt":     "The partially signed transaction encoded as a base64 string (unless the network transaction is returned)",
+	"finalizepsbtresult-hex":      "The network transaction encoded as a hexadecimal string (only when complete and extracted)",
+	"finalizepsbtresult-complete": "Whether every input of the transaction is finalized",
+
 	// GetAccountCmd help.
 	"getaccount--synopsis": "DEPRECATED -- Lookup the account name that some wallet address belongs to.",
 	"getaccount-address":   "The address to query the account for",
@@ -129,6 +243,9 @@ var helpDescsEnUS = map[string]string{
 	"gettransactionresult-timereceived":    "The earliest Unix time this transaction was known to exist",
 	"gettransactionresult-details":         "Additional details for each recorded wallet credit and debit",
 	"gettransactionresult-hex":             "The transaction encoded as a hexadecimal string",
+	"gettransactionresult-txlabel":         "The label of the transaction",
+	"gettransactionresult-comment":         "The comment recorded for the transaction",
+	"gettransactionresult-to":              "The name of the payee recorded for the transaction",
 
 	// GetTransactionDetailsResult help.
 	"gettransactiondetailsresult-account":           "DEPRECATED -- Unset",
@@ -138,6 +255,7 @@ var helpDescsEnUS = map[string]string{
 	"gettransactiondetailsresult-fee":               "The included fee for a sent transaction",
 	"gettransactiondetailsresult-vout":              "The transaction output index",
 	"gettransactiondetailsresult-involveswatchonly": "Unset",
+	"gettransactiondetailsresult-label":             "The label of the payment address",
 
 	// ImportPrivKeyCmd help.
 	"importprivkey--synopsis": "Imports a WIF-encoded private key to the 'imported' account.",
@@ -219,7 +337,10 @@ var helpDescsEnUS = map[string]string{
 	"listtransactionsresult-time":               "The earliest Unix time this transaction was known to exist",
 	"listtransactionsresult-timereceived":       "The earliest Unix time this transaction was known to exist",
 	"listtransactionsresult-involveswatchonly":  "Unset",
-	"listtransactionsresult-comment":            "Unset",
+	"listtransactionsresult-comment":            "The comment recorded for the transaction",
+	"listtransactionsresult-to":                 "The name of the payee recorded for the transaction",
+	"listtransactionsresult-label":              "The label of the payment address",
+	"listtransactionsresult-txlabel":            "The label of the transaction",
 	"listtransactionsresult-otheraccount":       "Unset",
 	"listtransactionsresult-trusted":            "Unset",
 	"listtransactionsresult-bip125-replaceable": "Unset",
@@ -261,13 +382,15 @@ var helpDescsEnUS = map[string]string{
 	// SendFromCmd help.
 	"sendfrom--synopsis": "DEPRECATED -- Authors, signs, and sends a transaction that outputs some amount to a payment address.\n" +
 		"A change output is automatically included to send extra output value back to the original account.",
-	"sendfrom-fromaccount": "Account to pick unspent outputs from",
-	"sendfrom-toaddress":   "Address to pay",
-	"sendfrom-amount":      "Amount to send to the payment address valued in bitcoin",
-	"sendfrom-minconf":     "Minimum number of block confirmations required before a transaction output is eligible to be spent",
-	"sendfrom-comment":     "Unused",
-	"sendfrom-commentto":   "Unused",
-	"sendfrom--result0":    "The transaction hash of the sent transaction",
+	"sendfrom-fromaccount":   "Account to pick unspent outputs from",
+	"sendfrom-toaddress":     "Address to pay",
+	"sendfrom-amount":        "Amount to send to the payment address valued in bitcoin",
+	"sendfrom-minconf":       "Minimum number of block confirmations required before a transaction output is eligible to be spent",
+	"sendfrom-comment":       "A comment to record for the transaction, such as the purpose of the payment",
+	"sendfrom-commentto":     "The name of the payee to record for the transaction",
+	"sendfrom-coinselection": "The coin selection strategy choosing the outputs spent: largest, bnb (changeless branch and bound), privacy or consolidate, defaulting to the wallet's coinselection option",
+	"sendfrom-replaceable":   "Signal that the transaction may be replaced by one paying a higher fee (BIP 125), defaulting to the wallet's walletrbf option",
+	"sendfrom--result0":      "The transaction hash of the sent transaction",
 
 	// SendManyCmd help.
 	"sendmany--synopsis": "Authors, signs, and sends a transaction that outputs to many payment addresses.\n" +
@@ -278,18 +401,28 @@ var helpDescsEnUS = map[string]string{
 	"sendmany-amounts--key":   "Address to pay",
 	"sendmany-amounts--value": "Amount to send to the payment address valued in bitcoin",
 	"sendmany-minconf":        "Minimum number of block confirmations required before a transaction output is eligible to be spent",
-	"sendmany-comment":        "Unused",
+	"sendmany-comment":        "A comment to record for the transaction, such as the purpose of the payment",
+	"sendmany-coinselection":  "The coin selection strategy choosing the outputs spent: largest, bnb (changeless branch and bound), privacy or consolidate, defaulting to the wallet's coinselection option",
+	"sendmany-replaceable":    "Signal that the transaction may be replaced by one paying a higher fee (BIP 125), defaulting to the wallet's walletrbf option",
 	"sendmany--result0":       "The transaction hash of the sent transaction",
 
 	// SendToAddressCmd help.
 	"sendtoaddress--synopsis": "Authors, signs, and sends a transaction that outputs some amount to a payment address.\n" +
 		"Unlike sendfrom, outputs are always chosen from the default account.\n" +
 		"A change output is automatically included to send extra output value back to the original account.",
-	"sendtoaddress-address":   "Address to pay",
-	"sendtoaddress-amount":    "Amount to send to the payment address valued in bitcoin",
-	"sendtoaddress-comment":   "Unused",
-	"sendtoaddress-commentto": "Unused",
-	"sendtoaddress--result0":  "The transaction hash of the sent transaction",
+	"sendtoaddress-address":       "Address to pay",
+	"sendtoaddress-amount":        "Amount to send to the payment address valued in bitcoin",
+	"sendtoaddress-comment":       "A comment to record for the transaction, such as the purpose of the payment",
+	"sendtoaddress-commentto":     "The name of the payee to record for the transaction",
+	"sendtoaddress-coinselection": "The coin selection strategy choosing the outputs spent: largest, bnb (changeless branch and bound), privacy or consolidate, defaulting to the wallet's coinselection option",
+	"sendtoaddress-replaceable":   "Signal that the transaction may be replaced by one paying a higher fee (BIP 125), defaulting to the wallet's walletrbf option",
+	"sendtoaddress--result0":      "The transaction hash of the sent transaction",
+
+	// SetLabelCmd help.
+	"setlabel--synopsis": "Sets the label of a payment address.\n" +
+		"Addresses that do not belong to the wallet, such as those of payees, may be labeled as well.",
+	"setlabel-address": "The payment address to label",
+	"setlabel-label":   "The label to assign to the address, or the empty string to remove the label",
 
 	// SetTxFeeCmd help.
 	"settxfee--synopsis": "Modify the increment used each time more fee is required for an authored transaction.",
@@ -352,6 +485,34 @@ var helpDescsEnUS = map[string]string{
 	"verifymessage-message":   "The message to verify",
 	"verifymessage--result0":  "Whether the message was signed with the private key of 'address'",
 
+	// WalletCreateFundedPsbtCmd help.
+	"walletcreatefundedpsbt--synopsis": "Creates a partially signed transaction (BIP 174) paying the requested outputs, funded by outputs of the default account.\n" +
+		"Any inputs given are spent first, and unspent outputs with at least one confirmation are added when they do not cover the outputs and the fee.\n" +
+		"Inputs signal replaceability (BIP 125) when the replaceable option or the wallet's walletrbf option is set, unless given another sequence number.",
+	"walletcreatefundedpsbt-inputs":         "Wallet outputs to spend, as JSON objects with the txid and vout of the output and an optional input sequence number",
+	"walletcreatefundedpsbt-outputs":        "Pairs of payment addresses and the output amount to pay each",
+	"walletcreatefundedpsbt-outputs--desc":  "JSON object using payment addresses as keys and output amounts valued in bitcoin to send to each address",
+	"walletcreatefundedpsbt-outputs--key":   "Address to pay",
+	"walletcreatefundedpsbt-outputs--value": "Amount to send to the payment address valued in bitcoin",
+	"walletcreatefundedpsbt-locktime":       "The lock time of the transaction",
+	"walletcreatefundedpsbt-options":        "JSON object with an optional changeAddress to send change to (default=a new change address), feeRate valued in bitcoin per kilobyte, lockUnspents to lock the spent outputs and replaceable to signal replaceability (default=the wallet's walletrbf option)",
+
+	// PsbtInput help.
+	"psbtinput-txid":     "The transaction hash of the output to spend",
+	"psbtinput-vout":     "The output index of the output to spend",
+	"psbtinput-sequence": "The sequence number of the input (default=4294967293, which signals replaceability, when the transaction is replaceable and 4294967295 otherwise)",
+
+	// WalletCreateFundedPsbtOpts help.
+	"walletcreatefundedpsbtopts-changeAddress": "The address to send change to (default=a new change address)",
+	"walletcreatefundedpsbtopts-feeRate":       "The fee rate valued in bitcoin per kilobyte (default=the relay fee)",
+	"walletcreatefundedpsbtopts-lockUnspents":  "Lock the outputs spent by the transaction",
+	"walletcreatefundedpsbtopts-replaceable":   "Signal that the transaction may be replaced by one paying a higher fee (BIP 125) (default=the wallet's walletrbf option)",
+
+	// WalletCreateFundedPsbtResult help.
+	"walletcreatefundedpsbtresult-psbt":      "The partially signed transaction encoded as a base64 string",
+	"walletcreatefundedpsbtresult-fee":       "The fee of the transaction valued in bitcoin",
+	"walletcreatefundedpsbtresult-changepos": "The index of the change output, or -1 if there is none",
+
 	// WalletLockCmd help.
 	"walletlock--synopsis": "Lock the wallet.",
 
@@ -365,6 +526,17 @@ var helpDescsEnUS = map[string]string{
 	"walletpassphrasechange-oldpassphrase": "The old wallet passphrase",
 	"walletpassphrasechange-newpassphrase": "The new wallet passphrase",
 
+	// WalletProcessPsbtCmd help.
+	"walletprocesspsbt--synopsis": "Adds what the wallet knows about the inputs of a partially signed transaction (BIP 174) to it, and signs and finalizes the inputs it holds the keys for.\n" +
+		"The valid sighashtype options are ALL, NONE, SINGLE, ALL|ANYONECANPAY, NONE|ANYONECANPAY, and SINGLE|ANYONECANPAY.",
+	"walletprocesspsbt-psbt":        "The partially signed transaction encoded as a base64 string",
+	"walletprocesspsbt-sign":        "Sign the inputs the wallet holds the keys for",
+	"walletprocesspsbt-sighashtype": "Sighash flags to sign with, unless an input requires others",
+
+	// WalletProcessPsbtResult help.
+	"walletprocesspsbtresult-psbt":     "The partially signed transaction encoded as a base64 string",
+	"walletprocesspsbtresult-complete": "Whether every input of the transaction is finalized",
+
 	// CreateNewAccountCmd help.
 	"createnewaccount--synopsis": "Creates a new account.\n" +
 		"The wallet must be unlocked for this request to succeed.",
@@ -383,11 +555,29 @@ var helpDescsEnUS = map[string]string{
 	"getbestblockresult-hash":   "The hash of the block",
 	"getbestblockresult-height": "The blockchain height of the block",
 
+	// GetSpvSyncInfoCmd help.
+	"getspvsyncinfo--synopsis": "Returns how far the light client of a wallet using the spv backend has synced the block headers and compact filter headers.",
+
+	// GetSpvSyncInfoResult help.
+	"getspvsyncinforesult-headers":        "The height of the last block header synced",
+	"getspvsyncinforesult-bestheaderhash": "The hash of the last block header synced",
+	"getspvsyncinforesult-filterheaders":  "The height of the last compact filter header synced",
+	"getspvsyncinforesult-current":        "Whether the block headers and filter headers are synced with the peers",
+	"getspvsyncinforesult-peers":          "The number of connected peers",
+	"getspvsyncinforesult-walletsynced":   "Whether the wallet has finished syncing with the light client",
+
 	// GetUnconfirmedBalanceCmd help.
 	"getunconfirmedbalance--synopsis": "Calculates the unspent output value of all unmined transaction outputs for an account.",
 	"getunconfirmedbalance-account":   "The account to query the unconfirmed balance for (default=\"default\")",
 	"getunconfirmedbalance--result0":  "Total amount of all unmined unspent outputs of the account valued in bitcoin.",
 
+	// ImportXpubCmd help.
+	"importxpub--synopsis": "Creates a watch-only account from the extended public key of an account of another wallet, such as an offline cold storage wallet.\n" +
+		"The balance and history of the account are tracked, and transactions spending from it can be created with walletcreatefundedpsbt to be signed by the other wallet.",
+	"importxpub-xpub":    "The extended public key of the account",
+	"importxpub-account": "Name of the new watch-only account",
+	"importxpub-rescan":  "Rescan the blockchain (since the genesis block) for transactions of the account",
+
 	// ListAddressTransactionsCmd help.
 	"listaddresstransactions--synopsis": "Returns a JSON array of objects containing verbose details for wallet transactions pertaining some addresses.",
 	"listaddresstransactions-addresses": "Addresses to filter transaction results by",
@@ -402,6 +592,11 @@ var helpDescsEnUS = map[string]string{
 	"renameaccount-oldaccount": "The old account name to rename",
 	"renameaccount-newaccount": "The new name for the account",
 
+	// SetTxLabelCmd help.
+	"settxlabel--synopsis": "Sets the label of a transaction recorded by the wallet.",
+	"settxlabel-txid":      "The hash of the transaction to label",
+	"settxlabel-label":     "The label to assign to the transaction, or the empty string to remove the label",
+
 	// WalletIsLockedCmd help.
 	"walletislocked--synopsis": "Returns whether or not the wallet is locked.",
 	"walletislocked--result0":  "Whether the wallet is locked",
@@ -23,8 +23,12 @@ var Methods = []struct {
 	ResultTypes []interface{}
 }{
 	{"addmultisigaddress", returnsString},
+	{"bumpfee", []interface{}{(*json.BumpFeeResult)(nil)}},
+	{"combinepsbt", returnsString},
 	{"createmultisig", []interface{}{(*json.CreateMultiSigResult)(nil)}},
+	{"decodepsbt", []interface{}{(*json.DecodePsbtResult)(nil)}},
 	{"dumpprivkey", returnsString},
+	{"finalizepsbt", []interface{}{(*json.FinalizePsbtResult)(nil)}},
 	{"getaccount", returnsString},
 	{"getaccountaddress", returnsString},
 	{"getaddressesbyaccount", returnsStringArray},
@@ -51,21 +55,27 @@ var Methods = []struct {
 	{"sendfrom", returnsString},
 	{"sendmany", returnsString},
 	{"sendtoaddress", returnsString},
+	{"setlabel", nil},
 	{"settxfee", returnsBool},
 	{"signmessage", returnsString},
 	{"signrawtransaction", []interface{}{(*json.SignRawTransactionResult)(nil)}},
 	{"validateaddress", []interface{}{(*json.ValidateAddressWalletResult)(nil)}},
 	{"verifymessage", returnsBool},
+	{"walletcreatefundedpsbt", []interface{}{(*json.WalletCreateFundedPsbtResult)(nil)}},
 	{"walletlock", nil},
 	{"walletpassphrase", nil},
 	{"walletpassphrasechange", nil},
+	{"walletprocesspsbt", []interface{}{(*json.WalletProcessPsbtResult)(nil)}},
 	{"createnewaccount", nil},
 	{"exportwatchingwallet", returnsString},
 	{"getbestblock", []interface{}{(*json.GetBestBlockResult)(nil)}},
+	{"getspvsyncinfo", []interface{}{(*json.GetSpvSyncInfoResult)(nil)}},
 	{"getunconfirmedbalance", returnsNumber},
+	{"importxpub", nil},
 	{"listaddresstransactions", returnsLTRArray},
 	{"listalltransactions", returnsLTRArray},
 	{"renameaccount", nil},
+	{"settxlabel", nil},
 	{"walletislocked", returnsBool},
 }
 
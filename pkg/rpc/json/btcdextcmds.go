@@ -66,6 +66,33 @@ func NewGenerateCmd(
 	}
 }
 
+// GetAlgoStatsCmd defines the getalgostats JSON-RPC command.  This command is not a standard Bitcoin command.  It is an extension for pod.
+
+type GetAlgoStatsCmd struct {
+	Blocks *int `jsonrpcdefault:"1000"`
+	Height *int `jsonrpcdefault:"-1"`
+}
+
+// NewGetAlgoStatsCmd returns a new instance which can be used to issue a getalgostats JSON-RPC command. The parameters which are pointers indicate they are optional.  Passing nil for optional parameters will use the default value.
+func NewGetAlgoStatsCmd(
+	blocks, height *int) *GetAlgoStatsCmd {
+
+	return &GetAlgoStatsCmd{
+		Blocks: blocks,
+		Height: height,
+	}
+}
+
+// GetWorkerStatsCmd defines the getworkerstats JSON-RPC command.  This command is not a standard Bitcoin command.  It is an extension for pod.
+
+type GetWorkerStatsCmd struct{}
+
+// NewGetWorkerStatsCmd returns a new instance which can be used to issue a getworkerstats JSON-RPC command.
+func NewGetWorkerStatsCmd() *GetWorkerStatsCmd {
+
+	return &GetWorkerStatsCmd{}
+}
+
 // GetBestBlockCmd defines the getbestblock JSON-RPC command.
 
 type GetBestBlockCmd struct{}
@@ -121,8 +148,10 @@ func init() {
 	MustRegisterCmd("debuglevel", (*DebugLevelCmd)(nil), flags)
 	MustRegisterCmd("node", (*NodeCmd)(nil), flags)
 	MustRegisterCmd("generate", (*GenerateCmd)(nil), flags)
+	MustRegisterCmd("getalgostats", (*GetAlgoStatsCmd)(nil), flags)
 	MustRegisterCmd("getbestblock", (*GetBestBlockCmd)(nil), flags)
 	MustRegisterCmd("getcurrentnet", (*GetCurrentNetCmd)(nil), flags)
 	MustRegisterCmd("getheaders", (*GetHeadersCmd)(nil), flags)
+	MustRegisterCmd("getworkerstats", (*GetWorkerStatsCmd)(nil), flags)
 	MustRegisterCmd("version", (*VersionCmd)(nil), flags)
 }
@@ -2,12 +2,12 @@ package json_test
 
 import (
 	"bytes"
-	"encoding/json"
+	js "encoding/json"
 	"fmt"
 	"reflect"
 	"testing"
 
-	json "git.parallelcoin.io/dev/pod/pkg/rpc/json"
+	"git.parallelcoin.io/dev/pod/pkg/rpc/json"
 )
 
 // TestPodExtCmds tests all of the pod extended commands marshal and unmarshal into valid results include handling of optional fields being omitted in the marshalled command, while optional fields with defaults have the default assigned on unmarshalled commands.
@@ -119,6 +119,38 @@ func TestPodExtCmds(
 				NumBlocks: 1,
 			},
 		},
+		{
+			name: "getalgostats",
+			newCmd: func() (interface{}, error) {
+
+				return json.NewCmd("getalgostats")
+			},
+			staticCmd: func() interface{} {
+
+				return json.NewGetAlgoStatsCmd(nil, nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getalgostats","params":[],"id":1}`,
+			unmarshalled: &json.GetAlgoStatsCmd{
+				Blocks: json.Int(1000),
+				Height: json.Int(-1),
+			},
+		},
+		{
+			name: "getalgostats optional",
+			newCmd: func() (interface{}, error) {
+
+				return json.NewCmd("getalgostats", 200, 250100)
+			},
+			staticCmd: func() interface{} {
+
+				return json.NewGetAlgoStatsCmd(json.Int(200), json.Int(250100))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"getalgostats","params":[200,250100],"id":1}`,
+			unmarshalled: &json.GetAlgoStatsCmd{
+				Blocks: json.Int(200),
+				Height: json.Int(250100),
+			},
+		},
 		{
 			name: "getbestblock",
 			newCmd: func() (interface{}, error) {
@@ -164,6 +196,19 @@ func TestPodExtCmds(
 				HashStop:      "",
 			},
 		},
+		{
+			name: "getworkerstats",
+			newCmd: func() (interface{}, error) {
+
+				return json.NewCmd("getworkerstats")
+			},
+			staticCmd: func() interface{} {
+
+				return json.NewGetWorkerStatsCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"getworkerstats","params":[],"id":1}`,
+			unmarshalled: &json.GetWorkerStatsCmd{},
+		},
 		{
 			name: "getheaders - with arguments",
 			newCmd: func() (interface{}, error) {
@@ -251,7 +296,7 @@ func TestPodExtCmds(
 		}
 		var request json.Request
 
-		if err := json.Unmarshal(marshalled, &request); err != nil {
+		if err := js.Unmarshal(marshalled, &request); err != nil {
 
 			t.Errorf("Test #%d (%s) unexpected error while "+
 				"unmarshalling JSON-RPC request: %v", i,
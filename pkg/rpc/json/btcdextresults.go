@@ -10,3 +10,39 @@ type VersionResult struct {
 	Prerelease    string `json:"prerelease"`
 	BuildMetadata string `json:"buildmetadata"`
 }
+
+// GetAlgoStatsResult models the data from the getalgostats command.
+
+type GetAlgoStatsResult struct {
+	StartHeight int32             `json:"startheight"`
+	EndHeight   int32             `json:"endheight"`
+	Algos       []AlgoStatsResult `json:"algos"`
+}
+
+// AlgoStatsResult models the per-algorithm data returned as part of the getalgostats command.
+
+type AlgoStatsResult struct {
+	Algo          string  `json:"algo"`
+	Version       int32   `json:"version"`
+	Blocks        int32   `json:"blocks"`
+	LastHeight    int32   `json:"lastheight"`
+	Bits          string  `json:"bits"`
+	Difficulty    float64 `json:"difficulty"`
+	NetworkHashPS int64   `json:"networkhashps"`
+	AvgInterval   float64 `json:"avginterval"`
+}
+
+// GetWorkerStatsResult models the per-worker data returned by the getworkerstats command.
+
+type GetWorkerStatsResult struct {
+	Worker     string           `json:"worker"`
+	Sessions   int              `json:"sessions"`
+	LoginTime  int64            `json:"logintime"`
+	LastShare  int64            `json:"lastshare"`
+	Accepted   int64            `json:"accepted"`
+	Stale      int64            `json:"stale"`
+	Duplicate  int64            `json:"duplicate"`
+	Invalid    int64            `json:"invalid"`
+	Blocks     int64            `json:"blocks"`
+	AlgoShares map[string]int64 `json:"algoshares"`
+}
@@ -31,6 +31,16 @@ func NewDumpWalletCmd(
 	}
 }
 
+// GetSpvSyncInfoCmd defines the getspvsyncinfo JSON-RPC command.
+
+type GetSpvSyncInfoCmd struct{}
+
+// NewGetSpvSyncInfoCmd returns a new instance which can be used to issue a getspvsyncinfo JSON-RPC command.
+func NewGetSpvSyncInfoCmd() *GetSpvSyncInfoCmd {
+
+	return &GetSpvSyncInfoCmd{}
+}
+
 // ImportAddressCmd defines the importaddress JSON-RPC command.
 
 type ImportAddressCmd struct {
@@ -67,6 +77,25 @@ func NewImportPubKeyCmd(
 	}
 }
 
+// ImportXpubCmd defines the importxpub JSON-RPC command.
+
+type ImportXpubCmd struct {
+	Xpub    string
+	Account string
+	Rescan  *bool `jsonrpcdefault:"true"`
+}
+
+// NewImportXpubCmd returns a new instance which can be used to issue an importxpub JSON-RPC command.
+func NewImportXpubCmd(
+	xpub string, account string, rescan *bool) *ImportXpubCmd {
+
+	return &ImportXpubCmd{
+		Xpub:    xpub,
+		Account: account,
+		Rescan:  rescan,
+	}
+}
+
 // ImportWalletCmd defines the importwallet JSON-RPC command.
 
 type ImportWalletCmd struct {
@@ -98,14 +127,34 @@ func NewRenameAccountCmd(
 		NewAccount: newAccount,
 	}
 }
+// SetTxLabelCmd defines the settxlabel JSON-RPC command.
+
+type SetTxLabelCmd struct {
+	TxID  string
+	Label string
+}
+
+// NewSetTxLabelCmd returns a new instance which can be used to issue a settxlabel JSON-RPC command.
+func NewSetTxLabelCmd(
+	txID, label string) *SetTxLabelCmd {
+
+	return &SetTxLabelCmd{
+		TxID:  txID,
+		Label: label,
+	}
+}
+
 func init() {
 
 	// The commands in this file are only usable with a wallet server.
 	flags := UFWalletOnly
 	MustRegisterCmd("createnewaccount", (*CreateNewAccountCmd)(nil), flags)
 	MustRegisterCmd("dumpwallet", (*DumpWalletCmd)(nil), flags)
+	MustRegisterCmd("getspvsyncinfo", (*GetSpvSyncInfoCmd)(nil), flags)
 	MustRegisterCmd("importaddress", (*ImportAddressCmd)(nil), flags)
 	MustRegisterCmd("importpubkey", (*ImportPubKeyCmd)(nil), flags)
 	MustRegisterCmd("importwallet", (*ImportWalletCmd)(nil), flags)
+	MustRegisterCmd("importxpub", (*ImportXpubCmd)(nil), flags)
 	MustRegisterCmd("renameaccount", (*RenameAccountCmd)(nil), flags)
+	MustRegisterCmd("settxlabel", (*SetTxLabelCmd)(nil), flags)
 }
@@ -2,7 +2,7 @@ package json_test
 
 import (
 	"bytes"
-	"encoding/json"
+	js "encoding/json"
 	"fmt"
 	"reflect"
 	"testing"
@@ -86,6 +86,53 @@ func TestBtcWalletExtCmds(
 				Rescan:  json.Bool(false),
 			},
 		},
+		{
+			name: "getspvsyncinfo",
+			newCmd: func() (interface{}, error) {
+
+				return json.NewCmd("getspvsyncinfo")
+			},
+			staticCmd: func() interface{} {
+
+				return json.NewGetSpvSyncInfoCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"getspvsyncinfo","params":[],"id":1}`,
+			unmarshalled: &json.GetSpvSyncInfoCmd{},
+		},
+		{
+			name: "importxpub",
+			newCmd: func() (interface{}, error) {
+
+				return json.NewCmd("importxpub", "xpub", "cold")
+			},
+			staticCmd: func() interface{} {
+
+				return json.NewImportXpubCmd("xpub", "cold", nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"importxpub","params":["xpub","cold"],"id":1}`,
+			unmarshalled: &json.ImportXpubCmd{
+				Xpub:    "xpub",
+				Account: "cold",
+				Rescan:  json.Bool(true),
+			},
+		},
+		{
+			name: "importxpub optional",
+			newCmd: func() (interface{}, error) {
+
+				return json.NewCmd("importxpub", "xpub", "cold", false)
+			},
+			staticCmd: func() interface{} {
+
+				return json.NewImportXpubCmd("xpub", "cold", json.Bool(false))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"importxpub","params":["xpub","cold",false],"id":1}`,
+			unmarshalled: &json.ImportXpubCmd{
+				Xpub:    "xpub",
+				Account: "cold",
+				Rescan:  json.Bool(false),
+			},
+		},
 		{
 			name: "importpubkey",
 			newCmd: func() (interface{}, error) {
@@ -199,7 +246,7 @@ func TestBtcWalletExtCmds(
 		}
 		var request json.Request
 
-		if err := json.Unmarshal(marshalled, &request); err != nil {
+		if err := js.Unmarshal(marshalled, &request); err != nil {
 
 			t.Errorf("Test #%d (%s) unexpected error while "+
 				"unmarshalling JSON-RPC request: %v", i,
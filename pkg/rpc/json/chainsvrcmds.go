@@ -40,6 +40,16 @@ func NewAddNodeCmd(
 	}
 }
 
+// ClearBannedCmd defines the clearbanned JSON-RPC command.
+
+type ClearBannedCmd struct{}
+
+// NewClearBannedCmd returns a new instance which can be used to issue a clearbanned JSON-RPC command.
+func NewClearBannedCmd() *ClearBannedCmd {
+
+	return &ClearBannedCmd{}
+}
+
 // TransactionInput represents the inputs to a transaction.  Specifically a transaction hash and output number pair.
 
 type TransactionInput struct {
@@ -97,6 +107,21 @@ func NewDecodeScriptCmd(
 	}
 }
 
+// DumpTxOutSetCmd defines the dumptxoutset JSON-RPC command.
+
+type DumpTxOutSetCmd struct {
+	Path string
+}
+
+// NewDumpTxOutSetCmd returns a new instance which can be used to issue a dumptxoutset JSON-RPC command.
+func NewDumpTxOutSetCmd(
+	path string) *DumpTxOutSetCmd {
+
+	return &DumpTxOutSetCmd{
+		Path: path,
+	}
+}
+
 // GetAddedNodeInfoCmd defines the getaddednodeinfo JSON-RPC command.
 
 type GetAddedNodeInfoCmd struct {
@@ -577,6 +602,21 @@ func NewHelpCmd(
 	}
 }
 
+// ImportMempoolCmd defines the importmempool JSON-RPC command.
+
+type ImportMempoolCmd struct {
+	FilePath string
+}
+
+// NewImportMempoolCmd returns a new instance which can be used to issue an importmempool JSON-RPC command.
+func NewImportMempoolCmd(
+	filePath string) *ImportMempoolCmd {
+
+	return &ImportMempoolCmd{
+		FilePath: filePath,
+	}
+}
+
 // InvalidateBlockCmd defines the invalidateblock JSON-RPC command.
 
 type InvalidateBlockCmd struct {
@@ -592,6 +632,16 @@ func NewInvalidateBlockCmd(
 	}
 }
 
+// ListBannedCmd defines the listbanned JSON-RPC command.
+
+type ListBannedCmd struct{}
+
+// NewListBannedCmd returns a new instance which can be used to issue a listbanned JSON-RPC command.
+func NewListBannedCmd() *ListBannedCmd {
+
+	return &ListBannedCmd{}
+}
+
 // PingCmd defines the ping JSON-RPC command.
 
 type PingCmd struct{}
@@ -632,6 +682,16 @@ func NewReconsiderBlockCmd(
 	}
 }
 
+// SaveMempoolCmd defines the savemempool JSON-RPC command.
+
+type SaveMempoolCmd struct{}
+
+// NewSaveMempoolCmd returns a new instance which can be used to issue a savemempool JSON-RPC command.
+func NewSaveMempoolCmd() *SaveMempoolCmd {
+
+	return &SaveMempoolCmd{}
+}
+
 // SearchRawTransactionsCmd defines the searchrawtransactions JSON-RPC command.
 
 type SearchRawTransactionsCmd struct {
@@ -676,6 +736,40 @@ func NewSendRawTransactionCmd(
 	}
 }
 
+// SetBanSubCmd defines the type used in the setban JSON-RPC command for the sub command field.
+
+type SetBanSubCmd string
+
+const (
+
+	// SBAdd indicates the specified address or subnet should be banned.
+	SBAdd SetBanSubCmd = "add"
+
+	// SBRemove indicates the ban of the specified address or subnet should be lifted.
+	SBRemove SetBanSubCmd = "remove"
+)
+
+// SetBanCmd defines the setban JSON-RPC command.
+
+type SetBanCmd struct {
+	Subnet   string
+	SubCmd   SetBanSubCmd `jsonrpcusage:"\"add|remove\""`
+	BanTime  *int64       `jsonrpcdefault:"0"`
+	Absolute *bool        `jsonrpcdefault:"false"`
+}
+
+// NewSetBanCmd returns a new instance which can be used to issue a setban JSON-RPC command. The ban time is in seconds, or a unix time when absolute is true, and a ban time of zero uses the ban duration of the server. The parameters which are pointers indicate they are optional.  Passing nil for optional parameters will use the default value.
+func NewSetBanCmd(
+	subnet string, subCmd SetBanSubCmd, banTime *int64, absolute *bool) *SetBanCmd {
+
+	return &SetBanCmd{
+		Subnet:   subnet,
+		SubCmd:   subCmd,
+		BanTime:  banTime,
+		Absolute: absolute,
+	}
+}
+
 // SetGenerateCmd defines the setgenerate JSON-RPC command.
 
 type SetGenerateCmd struct {
@@ -808,9 +902,11 @@ func init() {
 	// No special flags for commands in this file.
 	flags := UsageFlag(0)
 	MustRegisterCmd("addnode", (*AddNodeCmd)(nil), flags)
+	MustRegisterCmd("clearbanned", (*ClearBannedCmd)(nil), flags)
 	MustRegisterCmd("createrawtransaction", (*CreateRawTransactionCmd)(nil), flags)
 	MustRegisterCmd("decoderawtransaction", (*DecodeRawTransactionCmd)(nil), flags)
 	MustRegisterCmd("decodescript", (*DecodeScriptCmd)(nil), flags)
+	MustRegisterCmd("dumptxoutset", (*DumpTxOutSetCmd)(nil), flags)
 	MustRegisterCmd("getaddednodeinfo", (*GetAddedNodeInfoCmd)(nil), flags)
 	MustRegisterCmd("getbestblockhash", (*GetBestBlockHashCmd)(nil), flags)
 	MustRegisterCmd("getblock", (*GetBlockCmd)(nil), flags)
@@ -841,12 +937,16 @@ func init() {
 	MustRegisterCmd("gettxoutsetinfo", (*GetTxOutSetInfoCmd)(nil), flags)
 	MustRegisterCmd("getwork", (*GetWorkCmd)(nil), flags)
 	MustRegisterCmd("help", (*HelpCmd)(nil), flags)
+	MustRegisterCmd("importmempool", (*ImportMempoolCmd)(nil), flags)
 	MustRegisterCmd("invalidateblock", (*InvalidateBlockCmd)(nil), flags)
+	MustRegisterCmd("listbanned", (*ListBannedCmd)(nil), flags)
 	MustRegisterCmd("ping", (*PingCmd)(nil), flags)
 	MustRegisterCmd("preciousblock", (*PreciousBlockCmd)(nil), flags)
 	MustRegisterCmd("reconsiderblock", (*ReconsiderBlockCmd)(nil), flags)
+	MustRegisterCmd("savemempool", (*SaveMempoolCmd)(nil), flags)
 	MustRegisterCmd("searchrawtransactions", (*SearchRawTransactionsCmd)(nil), flags)
 	MustRegisterCmd("sendrawtransaction", (*SendRawTransactionCmd)(nil), flags)
+	MustRegisterCmd("setban", (*SetBanCmd)(nil), flags)
 	MustRegisterCmd("setgenerate", (*SetGenerateCmd)(nil), flags)
 	MustRegisterCmd("stop", (*StopCmd)(nil), flags)
 	MustRegisterCmd("submitblock", (*SubmitBlockCmd)(nil), flags)
@@ -2,7 +2,7 @@ package json_test
 
 import (
 	"bytes"
-	"encoding/json"
+	js "encoding/json"
 	"fmt"
 	"reflect"
 	"testing"
@@ -37,6 +37,19 @@ func TestChainSvrCmds(
 			marshalled:   `{"jsonrpc":"1.0","method":"addnode","params":["127.0.0.1","remove"],"id":1}`,
 			unmarshalled: &json.AddNodeCmd{Addr: "127.0.0.1", SubCmd: json.ANRemove},
 		},
+		{
+			name: "clearbanned",
+			newCmd: func() (interface{}, error) {
+
+				return json.NewCmd("clearbanned")
+			},
+			staticCmd: func() interface{} {
+
+				return json.NewClearBannedCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"clearbanned","params":[],"id":1}`,
+			unmarshalled: &json.ClearBannedCmd{},
+		},
 		{
 			name: "createrawtransaction",
 			newCmd: func() (interface{}, error) {
@@ -106,6 +119,19 @@ func TestChainSvrCmds(
 			marshalled:   `{"jsonrpc":"1.0","method":"decodescript","params":["00"],"id":1}`,
 			unmarshalled: &json.DecodeScriptCmd{HexScript: "00"},
 		},
+		{
+			name: "dumptxoutset",
+			newCmd: func() (interface{}, error) {
+
+				return json.NewCmd("dumptxoutset", "utxo.dat")
+			},
+			staticCmd: func() interface{} {
+
+				return json.NewDumpTxOutSetCmd("utxo.dat")
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"dumptxoutset","params":["utxo.dat"],"id":1}`,
+			unmarshalled: &json.DumpTxOutSetCmd{Path: "utxo.dat"},
+		},
 		{
 			name: "getaddednodeinfo",
 			newCmd: func() (interface{}, error) {
@@ -792,6 +818,21 @@ func TestChainSvrCmds(
 				Command: json.String("getblock"),
 			},
 		},
+		{
+			name: "importmempool",
+			newCmd: func() (interface{}, error) {
+
+				return json.NewCmd("importmempool", "/tmp/mempool.dat")
+			},
+			staticCmd: func() interface{} {
+
+				return json.NewImportMempoolCmd("/tmp/mempool.dat")
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"importmempool","params":["/tmp/mempool.dat"],"id":1}`,
+			unmarshalled: &json.ImportMempoolCmd{
+				FilePath: "/tmp/mempool.dat",
+			},
+		},
 		{
 			name: "invalidateblock",
 			newCmd: func() (interface{}, error) {
@@ -807,6 +848,19 @@ func TestChainSvrCmds(
 				BlockHash: "123",
 			},
 		},
+		{
+			name: "listbanned",
+			newCmd: func() (interface{}, error) {
+
+				return json.NewCmd("listbanned")
+			},
+			staticCmd: func() interface{} {
+
+				return json.NewListBannedCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"listbanned","params":[],"id":1}`,
+			unmarshalled: &json.ListBannedCmd{},
+		},
 		{
 			name: "ping",
 			newCmd: func() (interface{}, error) {
@@ -850,6 +904,19 @@ func TestChainSvrCmds(
 				BlockHash: "123",
 			},
 		},
+		{
+			name: "savemempool",
+			newCmd: func() (interface{}, error) {
+
+				return json.NewCmd("savemempool")
+			},
+			staticCmd: func() interface{} {
+
+				return json.NewSaveMempoolCmd()
+			},
+			marshalled:   `{"jsonrpc":"1.0","method":"savemempool","params":[],"id":1}`,
+			unmarshalled: &json.SaveMempoolCmd{},
+		},
 		{
 			name: "searchrawtransactions",
 			newCmd: func() (interface{}, error) {
@@ -1035,6 +1102,42 @@ func TestChainSvrCmds(
 				AllowHighFees: json.Bool(false),
 			},
 		},
+		{
+			name: "setban",
+			newCmd: func() (interface{}, error) {
+
+				return json.NewCmd("setban", "192.168.0.0/16", "add")
+			},
+			staticCmd: func() interface{} {
+
+				return json.NewSetBanCmd("192.168.0.0/16", json.SBAdd, nil, nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"setban","params":["192.168.0.0/16","add"],"id":1}`,
+			unmarshalled: &json.SetBanCmd{
+				Subnet:   "192.168.0.0/16",
+				SubCmd:   json.SBAdd,
+				BanTime:  json.Int64(0),
+				Absolute: json.Bool(false),
+			},
+		},
+		{
+			name: "setban optional",
+			newCmd: func() (interface{}, error) {
+
+				return json.NewCmd("setban", "127.0.0.1", "remove", 3600, true)
+			},
+			staticCmd: func() interface{} {
+
+				return json.NewSetBanCmd("127.0.0.1", json.SBRemove, json.Int64(3600), json.Bool(true))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"setban","params":["127.0.0.1","remove",3600,true],"id":1}`,
+			unmarshalled: &json.SetBanCmd{
+				Subnet:   "127.0.0.1",
+				SubCmd:   json.SBRemove,
+				BanTime:  json.Int64(3600),
+				Absolute: json.Bool(true),
+			},
+		},
 		{
 			name: "setgenerate",
 			newCmd: func() (interface{}, error) {
@@ -1278,7 +1381,7 @@ func TestChainSvrCmds(
 		}
 		var request json.Request
 
-		if err := json.Unmarshal(marshalled, &request); err != nil {
+		if err := js.Unmarshal(marshalled, &request); err != nil {
 
 			t.Errorf("Test #%d (%s) unexpected error while "+
 				"unmarshalling JSON-RPC request: %v", i,
@@ -1320,7 +1423,7 @@ func TestChainSvrCmdErrors(
 			name:       "template request with invalid type",
 			result:     &json.TemplateRequest{},
 			marshalled: `{"mode":1}`,
-			err:        &json.UnmarshalTypeError{},
+			err:        &js.UnmarshalTypeError{},
 		},
 		{
 			name:       "invalid template request sigoplimit field",
@@ -1339,7 +1442,7 @@ func TestChainSvrCmdErrors(
 
 	for i, test := range tests {
 
-		err := json.Unmarshal([]byte(test.marshalled), &test.result)
+		err := js.Unmarshal([]byte(test.marshalled), &test.result)
 
 		if reflect.TypeOf(err) != reflect.TypeOf(test.err) {
 
@@ -31,6 +31,16 @@ type DecodeScriptResult struct {
 	P2sh      string   `json:"p2sh,omitempty"`
 }
 
+// DumpTxOutSetResult models the data returned from the dumptxoutset command.
+
+type DumpTxOutSetResult struct {
+	CoinsWritten int64  `json:"coins_written"`
+	BaseHash     string `json:"base_hash"`
+	BaseHeight   int32  `json:"base_height"`
+	Path         string `json:"path"`
+	TxOutSetHash string `json:"txoutset_hash"`
+}
+
 // GetAddedNodeInfoResult models the data from the getaddednodeinfo command.
 
 type GetAddedNodeInfoResult struct {
@@ -61,6 +71,15 @@ type GetBlockChainInfoResult struct {
 	ChainWork            string                              `json:"chainwork,omitempty"`
 	SoftForks            []*SoftForkDescription              `json:"softforks"`
 	Bip9SoftForks        map[string]*Bip9SoftForkDescription `json:"bip9_softforks"`
+	UtxoSnapshot         *UtxoSnapshotValidationResult       `json:"utxosnapshot,omitempty"`
+}
+
+// UtxoSnapshotValidationResult models the state of the background validation of the blocks before a loaded utxo snapshot in the data returned from the getblockchaininfo command.
+
+type UtxoSnapshotValidationResult struct {
+	Height          int32 `json:"height"`
+	ValidatedHeight int32 `json:"validatedheight"`
+	Failed          bool  `json:"failed"`
 }
 
 // GetBlockHeaderVerboseResult models the data from the getblockheader command when the verbose flag is set.  When the verbose flag is not set, getblockheader returns a hex-encoded string.
@@ -159,78 +178,127 @@ type GetBlockVerboseResult struct {
 	NextHash      string        `json:"nextblockhash,omitempty"`
 }
 
+// GetChainTipsResult models the data of each chain tip returned from the getchaintips command.
+
+type GetChainTipsResult struct {
+	Height    int32  `json:"height"`
+	Hash      string `json:"hash"`
+	BranchLen int32  `json:"branchlen"`
+	Status    string `json:"status"`
+}
+
 // GetMempoolEntryResult models the data returned from the getmempoolentry command.
 
 type GetMempoolEntryResult struct {
-	Size             int32    `json:"size"`
-	Fee              float64  `json:"fee"`
-	ModifiedFee      float64  `json:"modifiedfee"`
-	Time             int64    `json:"time"`
-	Height           int64    `json:"height"`
-	StartingPriority float64  `json:"startingpriority"`
-	CurrentPriority  float64  `json:"currentpriority"`
-	DescendantCount  int64    `json:"descendantcount"`
-	DescendantSize   int64    `json:"descendantsize"`
-	DescendantFees   float64  `json:"descendantfees"`
-	AncestorCount    int64    `json:"ancestorcount"`
-	AncestorSize     int64    `json:"ancestorsize"`
-	AncestorFees     float64  `json:"ancestorfees"`
-	Depends          []string `json:"depends"`
+	Size              int32       `json:"size"`
+	VSize             int32       `json:"vsize"`
+	Weight            int32       `json:"weight"`
+	Fee               float64     `json:"fee"`
+	ModifiedFee       float64     `json:"modifiedfee"`
+	Time              int64       `json:"time"`
+	Height            int64       `json:"height"`
+	StartingPriority  float64     `json:"startingpriority"`
+	CurrentPriority   float64     `json:"currentpriority"`
+	DescendantCount   int64       `json:"descendantcount"`
+	DescendantSize    int64       `json:"descendantsize"`
+	DescendantFees    float64     `json:"descendantfees"`
+	AncestorCount     int64       `json:"ancestorcount"`
+	AncestorSize      int64       `json:"ancestorsize"`
+	AncestorFees      float64     `json:"ancestorfees"`
+	WTxID             string      `json:"wtxid"`
+	Fees              MempoolFees `json:"fees"`
+	Depends           []string    `json:"depends"`
+	SpentBy           []string    `json:"spentby"`
+	BIP125Replaceable bool        `json:"bip125-replaceable"`
+}
+
+// MempoolFees models the fees of a transaction and of its in-pool ancestors and descendants in the getmempoolentry command.
+
+type MempoolFees struct {
+	Base       float64 `json:"base"`
+	Modified   float64 `json:"modified"`
+	Ancestor   float64 `json:"ancestor"`
+	Descendant float64 `json:"descendant"`
 }
 
 // GetMempoolInfoResult models the data returned from the getmempoolinfo command.
 
 type GetMempoolInfoResult struct {
-	Size  int64 `json:"size"`
-	Bytes int64 `json:"bytes"`
+	Size          int64   `json:"size"`
+	Bytes         int64   `json:"bytes"`
+	Usage         int64   `json:"usage"`
+	MaxMempool    int64   `json:"maxmempool"`
+	MempoolMinFee float64 `json:"mempoolminfee"`
+	MinRelayTxFee float64 `json:"minrelaytxfee"`
+}
+
+// AlgoScheduleResult models the algorithm chosen by the algorithm scheduler of the block template generator, as part of the getmininginfo command.
+
+type AlgoScheduleResult struct {
+	Height  int32             `json:"height"`
+	Algo    string            `json:"algo"`
+	Version int32             `json:"version"`
+	Scores  []AlgoScoreResult `json:"scores"`
+}
+
+// AlgoScoreResult models the evaluation of one algorithm by the algorithm scheduler.
+
+type AlgoScoreResult struct {
+	Algo         string  `json:"algo"`
+	Version      int32   `json:"version"`
+	Bits         string  `json:"bits"`
+	NSPerOp      int64   `json:"nsperop"`
+	SecsPerBlock float64 `json:"secsperblock"`
 }
 
 // GetMiningInfoResult models the data from the getmininginfo command.
 
 type GetMiningInfoResult struct {
-	Blocks              int64   `json:"blocks"`
-	CurrentBlockSize    uint64  `json:"currentblocksize"`
-	CurrentBlockWeight  uint64  `json:"currentblockweight"`
-	CurrentBlockTx      uint64  `json:"currentblocktx"`
-	PowAlgoID           uint32  `json:"pow_algo_id"`
-	PowAlgo             string  `json:"pow_algo"`
-	Difficulty          float64 `json:"difficulty"`
-	DifficultyBlake2b   float64 `json:"difficulty_blake2b"`
-	DifficultyBlake14lr float64 `json:"difficulty_blake14lr"`
-	DifficultyBlake2s   float64 `json:"difficulty_blake2s"`
-	DifficultyKeccak    float64 `json:"difficulty_keccak"`
-	DifficultyScrypt    float64 `json:"difficulty_scrypt"`
-	DifficultySHA256D   float64 `json:"difficulty_sha256d"`
-	DifficultySkein     float64 `json:"difficulty_skein"`
-	DifficultyStribog   float64 `json:"difficulty_stribog"`
-	DifficultyX11       float64 `json:"difficulty_x11"`
-	Errors              string  `json:"errors"`
-	Generate            bool    `json:"generate"`
-	GenAlgo             string  `json:"genalgo"`
-	GenProcLimit        int32   `json:"genproclimit"`
-	HashesPerSec        int64   `json:"hashespersec"`
-	NetworkHashPS       int64   `json:"networkhashps"`
-	PooledTx            uint64  `json:"pooledtx"`
-	TestNet             bool    `json:"testnet"`
+	Blocks              int64               `json:"blocks"`
+	CurrentBlockSize    uint64              `json:"currentblocksize"`
+	CurrentBlockWeight  uint64              `json:"currentblockweight"`
+	CurrentBlockTx      uint64              `json:"currentblocktx"`
+	PowAlgoID           uint32              `json:"pow_algo_id"`
+	PowAlgo             string              `json:"pow_algo"`
+	Difficulty          float64             `json:"difficulty"`
+	DifficultyBlake2b   float64             `json:"difficulty_blake2b"`
+	DifficultyBlake14lr float64             `json:"difficulty_blake14lr"`
+	DifficultyBlake2s   float64             `json:"difficulty_blake2s"`
+	DifficultyKeccak    float64             `json:"difficulty_keccak"`
+	DifficultyScrypt    float64             `json:"difficulty_scrypt"`
+	DifficultySHA256D   float64             `json:"difficulty_sha256d"`
+	DifficultySkein     float64             `json:"difficulty_skein"`
+	DifficultyStribog   float64             `json:"difficulty_stribog"`
+	DifficultyX11       float64             `json:"difficulty_x11"`
+	Errors              string              `json:"errors"`
+	Generate            bool                `json:"generate"`
+	GenAlgo             string              `json:"genalgo"`
+	GenProcLimit        int32               `json:"genproclimit"`
+	HashesPerSec        int64               `json:"hashespersec"`
+	NetworkHashPS       int64               `json:"networkhashps"`
+	PooledTx            uint64              `json:"pooledtx"`
+	TestNet             bool                `json:"testnet"`
+	AlgoSchedule        *AlgoScheduleResult `json:"algoschedule,omitempty"`
 }
 
 type GetMiningInfoResult0 struct {
-	Blocks             int64   `json:"blocks"`
-	CurrentBlockSize   uint64  `json:"currentblocksize"`
-	CurrentBlockWeight uint64  `json:"currentblockweight"`
-	CurrentBlockTx     uint64  `json:"currentblocktx"`
-	PowAlgoID          uint32  `json:"pow_algo_id"`
-	PowAlgo            string  `json:"pow_algo"`
-	Difficulty         float64 `json:"difficulty"`
-	DifficultySHA256D  float64 `json:"difficulty_sha256d"`
-	DifficultyScrypt   float64 `json:"difficulty_scrypt"`
-	Errors             string  `json:"errors"`
-	Generate           bool    `json:"generate"`
-	GenProcLimit       int32   `json:"genproclimit"`
-	HashesPerSec       int64   `json:"hashespersec"`
-	NetworkHashPS      int64   `json:"networkhashps"`
-	PooledTx           uint64  `json:"pooledtx"`
-	TestNet            bool    `json:"testnet"`
+	Blocks             int64               `json:"blocks"`
+	CurrentBlockSize   uint64              `json:"currentblocksize"`
+	CurrentBlockWeight uint64              `json:"currentblockweight"`
+	CurrentBlockTx     uint64              `json:"currentblocktx"`
+	PowAlgoID          uint32              `json:"pow_algo_id"`
+	PowAlgo            string              `json:"pow_algo"`
+	Difficulty         float64             `json:"difficulty"`
+	DifficultySHA256D  float64             `json:"difficulty_sha256d"`
+	DifficultyScrypt   float64             `json:"difficulty_scrypt"`
+	Errors             string              `json:"errors"`
+	Generate           bool                `json:"generate"`
+	GenProcLimit       int32               `json:"genproclimit"`
+	HashesPerSec       int64               `json:"hashespersec"`
+	NetworkHashPS      int64               `json:"networkhashps"`
+	PooledTx           uint64              `json:"pooledtx"`
+	TestNet            bool                `json:"testnet"`
+	AlgoSchedule       *AlgoScheduleResult `json:"algoschedule,omitempty"`
 }
 
 // GetNetTotalsResult models the data returned from the getnettotals command.
@@ -251,6 +319,8 @@ type GetNetworkInfoResult struct {
 	LocalRelay      bool                   `json:"localrelay"`
 	TimeOffset      int64                  `json:"timeoffset"`
 	Connections     int32                  `json:"connections"`
+	ConnectionsIn   int32                  `json:"connections_in"`
+	ConnectionsOut  int32                  `json:"connections_out"`
 	NetworkActive   bool                   `json:"networkactive"`
 	Networks        []NetworksResult       `json:"networks"`
 	RelayFee        float64                `json:"relayfee"`
@@ -283,6 +353,15 @@ type GetPeerInfoResult struct {
 	BanScore       int32   `json:"banscore"`
 	FeeFilter      int64   `json:"feefilter"`
 	SyncNode       bool    `json:"syncnode"`
+
+	CmpctBlockVersion        uint64  `json:"cmpctblockversion,omitempty"`
+	CmpctHBTo                bool    `json:"bip152_hb_to"`
+	CmpctHBFrom              bool    `json:"bip152_hb_from"`
+	CmpctBlocksSent          uint64  `json:"cmpctblockssent"`
+	CmpctBlocksRecv          uint64  `json:"cmpctblocksrecv"`
+	CmpctBlocksReconstructed uint64  `json:"cmpctblocksreconstructed"`
+	CmpctTxnRequested        uint64  `json:"cmpcttxnrequested"`
+	CmpctBlockLatency        float64 `json:"cmpctblocklatency"`
 }
 
 // GetRawMempoolVerboseResult models the data returned from the getrawmempool command when the verbose flag is set.  When the verbose flag is not set, getrawmempool returns an array of transaction hashes.
@@ -308,6 +387,18 @@ type GetTxOutResult struct {
 	Coinbase      bool               `json:"coinbase"`
 }
 
+// GetTxOutSetInfoResult models the data from the gettxoutsetinfo command.
+
+type GetTxOutSetInfoResult struct {
+	Height          int32   `json:"height"`
+	BestBlock       string  `json:"bestblock"`
+	Transactions    int64   `json:"transactions"`
+	TxOuts          int64   `json:"txouts"`
+	BytesSerialized int64   `json:"bytes_serialized"`
+	HashSerialized  string  `json:"hash_serialized"`
+	TotalAmount     float64 `json:"total_amount"`
+}
+
 // GetWorkResult models the data from the getwork command.
 
 type GetWorkResult struct {
@@ -317,6 +408,13 @@ type GetWorkResult struct {
 	Target   string `json:"target"`
 }
 
+// ImportMempoolResult models the data returned from the importmempool command.
+
+type ImportMempoolResult struct {
+	Accepted int `json:"accepted"`
+	Failed   int `json:"failed"`
+}
+
 // InfoChainResult models the data returned by the chain server getinfo command.
 
 type InfoChainResult struct {
@@ -360,6 +458,15 @@ type InfoChainResult0 struct {
 	Errors            string  `json:"errors"`
 }
 
+// ListBannedResult models the data of each ban returned from the listbanned command.
+
+type ListBannedResult struct {
+	Address     string `json:"address"`
+	BanCreated  int64  `json:"ban_created"`
+	BannedUntil int64  `json:"banned_until"`
+	BanReason   string `json:"ban_reason"`
+}
+
 // LocalAddressesResult models the localaddresses data from the getnetworkinfo command.
 
 type LocalAddressesResult struct {
@@ -385,6 +492,13 @@ type PrevOut struct {
 	Value     float64  `json:"value"`
 }
 
+// SaveMempoolResult models the data returned from the savemempool command.
+
+type SaveMempoolResult struct {
+	Filename     string `json:"filename"`
+	Transactions int    `json:"transactions"`
+}
+
 // ScriptPubKeyResult models the scriptPubKey data of a tx script. It is defined separately since it is used by multiple commands.
 
 type ScriptPubKeyResult struct {
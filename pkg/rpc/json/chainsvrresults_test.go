@@ -1,7 +1,7 @@
 package json_test
 
 import (
-	"encoding/json"
+	js "encoding/json"
 	"testing"
 
 	"git.parallelcoin.io/dev/pod/pkg/rpc/json"
@@ -68,7 +68,7 @@ func TestChainSvrCustomResults(
 
 	for i, test := range tests {
 
-		marshalled, err := json.Marshal(test.result)
+		marshalled, err := js.Marshal(test.result)
 
 		if err != nil {
 
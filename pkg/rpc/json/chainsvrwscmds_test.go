@@ -2,7 +2,7 @@ package json_test
 
 import (
 	"bytes"
-	"encoding/json"
+	js "encoding/json"
 	"fmt"
 	"reflect"
 	"testing"
@@ -297,7 +297,7 @@ func TestChainSvrWsCmds(
 		}
 		var request json.Request
 
-		if err := json.Unmarshal(marshalled, &request); err != nil {
+		if err := js.Unmarshal(marshalled, &request); err != nil {
 
 			t.Errorf("Test #%d (%s) unexpected error while "+
 				"unmarshalling JSON-RPC request: %v", i,
@@ -1,7 +1,7 @@
 package json_test
 
 import (
-	"encoding/json"
+	js "encoding/json"
 	"math"
 	"reflect"
 	"testing"
@@ -210,7 +210,7 @@ func TestAssignFieldErrors(
 	}{
 		{
 			name: "general incompatible int -> string",
-			dest: string(0),
+			dest: "",
 			src:  int(0),
 			err:  json.Error{ErrorCode: json.ErrInvalidType},
 		},
@@ -500,7 +500,7 @@ func TestUnmarshalCmdErrors(
 			request: json.Request{
 				Jsonrpc: "1.0",
 				Method:  "getblockcount",
-				Params:  []json.RawMessage{[]byte(`"bogusparam"`)},
+				Params:  []js.RawMessage{[]byte(`"bogusparam"`)},
 				ID:      nil,
 			},
 			err: json.Error{ErrorCode: json.ErrNumParams},
@@ -510,7 +510,7 @@ func TestUnmarshalCmdErrors(
 			request: json.Request{
 				Jsonrpc: "1.0",
 				Method:  "getblock",
-				Params:  []json.RawMessage{[]byte("1")},
+				Params:  []js.RawMessage{[]byte("1")},
 				ID:      nil,
 			},
 			err: json.Error{ErrorCode: json.ErrInvalidType},
@@ -520,7 +520,7 @@ func TestUnmarshalCmdErrors(
 			request: json.Request{
 				Jsonrpc: "1.0",
 				Method:  "getblock",
-				Params:  []json.RawMessage{[]byte(`"1`)},
+				Params:  []js.RawMessage{[]byte(`"1`)},
 				ID:      nil,
 			},
 			err: json.Error{ErrorCode: json.ErrInvalidType},
@@ -1,7 +1,7 @@
 package json_test
 
 import (
-	"encoding/json"
+	js "encoding/json"
 	"fmt"
 
 	"git.parallelcoin.io/dev/pod/pkg/rpc/json"
@@ -39,7 +39,7 @@ func ExampleUnmarshalCmd() {
 	// Unmarshal the raw bytes from the wire into a JSON-RPC request.
 	var request json.Request
 
-	if err := json.Unmarshal(data, &request); err != nil {
+	if err := js.Unmarshal(data, &request); err != nil {
 
 		fmt.Println(err)
 		return
@@ -83,11 +83,8 @@ func ExampleUnmarshalCmd() {
 	fmt.Println("VerboseTx:", *gbCmd.VerboseTx)
 
 	// Output:
-
 	// Hash: 000000000019d6689c085ae165831e934ff763ae46a2a6c172b3f1b60a8ce26f
-
 	// Verbose: false
-
 	// VerboseTx: false
 }
 
@@ -118,7 +115,7 @@ func Example_unmarshalResponse() {
 	// Unmarshal the raw bytes from the wire into a JSON-RPC response.
 	var response json.Response
 
-	if err := json.Unmarshal(data, &response); err != nil {
+	if err := js.Unmarshal(data, &response); err != nil {
 
 		fmt.Println("Malformed JSON-RPC response:", err)
 		return
@@ -135,7 +132,7 @@ func Example_unmarshalResponse() {
 	// Unmarshal the result into the expected type for the response.
 	var blockHeight int32
 
-	if err := json.Unmarshal(response.Result, &blockHeight); err != nil {
+	if err := js.Unmarshal(response.Result, &blockHeight); err != nil {
 
 		fmt.Printf("Unexpected result type: %T\n", response.Result)
 		return
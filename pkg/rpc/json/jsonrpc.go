@@ -1,10 +1,14 @@
 package json
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 )
 
+// RPCVersion2 is the value of the jsonrpc member of JSON-RPC 2.0 requests and responses.  Requests without it are handled as JSON-RPC 1.0 requests.
+const RPCVersion2 = "2.0"
+
 // RPCError represents an error that is used as a part of a JSON-RPC Response object.
 
 type RPCError struct {
@@ -33,6 +37,15 @@ type Response struct {
 	ID     *interface{}    `json:"id"`
 }
 
+// responseV2 is the form of a JSON-RPC 2.0 response, which carries the version and exactly one of the result and the error.
+
+type responseV2 struct {
+	Jsonrpc string          `json:"jsonrpc"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *RPCError       `json:"error,omitempty"`
+	ID      *interface{}    `json:"id"`
+}
+
 // Guarantee RPCError satisifies the builtin error interface.
 var _, _ error = RPCError{}, (*RPCError)(nil)
 
@@ -44,6 +57,40 @@ func (
 	return fmt.Sprintf("%d: %s", e.Code, e.Message)
 }
 
+// IsBatchRequest returns whether the passed raw request body is a batch of JSON-RPC requests, which is a JSON array of request objects, rather than a single request.
+func IsBatchRequest(
+	data []byte) bool {
+
+	data = bytes.TrimLeft(data, " \t\r\n")
+	return len(data) > 0 && data[0] == '['
+}
+
+// IsNotification returns whether the passed raw JSON-RPC request, which was unmarshalled into request, is a notification that does not get a response.  JSON-RPC 1.0 notifications are requests with a null id, while JSON-RPC 2.0 notifications are requests without an id member, as null is a valid JSON-RPC 2.0 id.
+func IsNotification(
+	data []byte, request *Request) bool {
+
+	if request.ID != nil {
+
+		return false
+	}
+
+	if request.Jsonrpc != RPCVersion2 {
+
+		return true
+	}
+
+	// A null id and a missing id both unmarshal to nil, so look at the raw id to tell them apart.
+	var rawID struct {
+		ID json.RawMessage `json:"id"`
+	}
+
+	if err := json.Unmarshal(data, &rawID); err != nil {
+
+		return true
+	}
+	return rawID.ID == nil
+}
+
 // IsValidIDType checks that the ID field (which can go in any of the JSON-RPC requests, responses, or notifications) is valid.  JSON-RPC 1.0 allows any valid JSON type.  JSON-RPC 2.0 (which bitcoind follows for some parts) only allows string, number, or null, so this function restricts the allowed types to that list.  This function is only provided in case the caller is manually marshalling for some reason.
 // The functions which accept an ID in this package already call this function to ensure the provided id is valid.
 func IsValidIDType(
@@ -81,6 +128,52 @@ func MarshalResponse(
 	return json.Marshal(&response)
 }
 
+// MarshalResponseVersion marshals the passed id, result, and RPCError to a JSON-RPC response of the passed JSON-RPC version, which is the jsonrpc member of the request.  JSON-RPC 2.0 responses carry the version and only one of the result and the error, while responses to other versions are the same as those of MarshalResponse.
+func MarshalResponseVersion(
+	rpcVersion string, id interface{}, result interface{}, rpcErr *RPCError) ([]byte, error) {
+
+	if rpcVersion != RPCVersion2 {
+
+		return MarshalResponse(id, result, rpcErr)
+	}
+
+	if !IsValidIDType(id) {
+
+		str := fmt.Sprintf("the id of type '%T' is invalid", id)
+		return nil, makeError(ErrInvalidType, str)
+	}
+	response := responseV2{
+		Jsonrpc: RPCVersion2,
+		Error:   rpcErr,
+		ID:      &id,
+	}
+
+	if rpcErr == nil {
+
+		marshalledResult, err := json.Marshal(result)
+
+		if err != nil {
+
+			return nil, err
+		}
+		response.Result = marshalledResult
+	}
+	return json.Marshal(&response)
+}
+
+// MarshalBatchResponse marshals the passed marshalled responses to the requests of a batch into the JSON array that is the response to the batch.
+func MarshalBatchResponse(
+	responses [][]byte) ([]byte, error) {
+
+	rawResponses := make([]json.RawMessage, 0, len(responses))
+
+	for _, response := range responses {
+
+		rawResponses = append(rawResponses, response)
+	}
+	return json.Marshal(rawResponses)
+}
+
 // NewRPCError constructs and returns a new JSON-RPC error that is suitable for use in a JSON-RPC Response object.
 func NewRPCError(
 	code RPCErrorCode, message string) *RPCError {
@@ -1,7 +1,7 @@
 package json_test
 
 import (
-	"encoding/json"
+	js "encoding/json"
 	"reflect"
 	"testing"
 
@@ -105,6 +105,143 @@ func TestMarshalResponse(
 	}
 }
 
+// TestMarshalResponseVersion ensures the MarshalResponseVersion function only includes one of the result and the error in JSON-RPC 2.0 responses, and is the same as MarshalResponse for other versions.
+func TestMarshalResponseVersion(
+	t *testing.T) {
+
+	t.Parallel()
+	tests := []struct {
+		name       string
+		rpcVersion string
+		id         interface{}
+		result     interface{}
+		jsonErr    *json.RPCError
+		expected   []byte
+	}{
+		{
+			name:       "1.0 result",
+			rpcVersion: "1.0",
+			id:         1,
+			result:     true,
+			expected:   []byte(`{"result":true,"error":null,"id":1}`),
+		},
+		{
+			name:       "2.0 result",
+			rpcVersion: json.RPCVersion2,
+			id:         "a",
+			result:     true,
+			expected:   []byte(`{"jsonrpc":"2.0","result":true,"id":"a"}`),
+		},
+		{
+			name:       "2.0 null result with null id",
+			rpcVersion: json.RPCVersion2,
+			id:         nil,
+			result:     nil,
+			expected:   []byte(`{"jsonrpc":"2.0","result":null,"id":null}`),
+		},
+		{
+			name:       "2.0 error",
+			rpcVersion: json.RPCVersion2,
+			id:         1,
+			result:     true,
+			jsonErr:    json.ErrRPCMethodNotFound,
+			expected: []byte(`{"jsonrpc":"2.0","error":{"code":-32601,` +
+				`"message":"Method not found"},"id":1}`),
+		},
+	}
+	t.Logf("Running %d tests", len(tests))
+
+	for i, test := range tests {
+
+		marshalled, err := json.MarshalResponseVersion(test.rpcVersion,
+			test.id, test.result, test.jsonErr)
+
+		if err != nil {
+
+			t.Errorf("Test #%d (%s) unexpected error: %v", i,
+				test.name, err)
+			continue
+		}
+
+		if !reflect.DeepEqual(marshalled, test.expected) {
+
+			t.Errorf("Test #%d (%s) mismatched result - got %s, "+
+				"want %s", i, test.name, marshalled,
+				test.expected)
+		}
+	}
+
+	// The replies to the requests of a batch are returned as an array.
+	batch, err := json.MarshalBatchResponse([][]byte{[]byte(`{"id":1}`),
+		[]byte(`{"id":2}`)})
+
+	if err != nil || string(batch) != `[{"id":1},{"id":2}]` {
+
+		t.Errorf("MarshalBatchResponse: got %s (err %v)", batch, err)
+	}
+}
+
+// TestIsNotification ensures batches and notifications of both JSON-RPC versions are detected as expected.
+func TestIsNotification(
+	t *testing.T) {
+
+	t.Parallel()
+	tests := []struct {
+		data         string
+		request      json.Request
+		batch        bool
+		notification bool
+	}{
+		{
+			data:    `{"jsonrpc":"1.0","method":"getinfo","params":[],"id":1}`,
+			request: json.Request{Jsonrpc: "1.0", Method: "getinfo", ID: 1.0},
+		},
+		{
+			data:         `{"jsonrpc":"1.0","method":"getinfo","params":[],"id":null}`,
+			request:      json.Request{Jsonrpc: "1.0", Method: "getinfo"},
+			notification: true,
+		},
+		{
+			data:         `{"method":"getinfo","params":[]}`,
+			request:      json.Request{Method: "getinfo"},
+			notification: true,
+		},
+		{
+			data:    `{"jsonrpc":"2.0","method":"getinfo","id":null}`,
+			request: json.Request{Jsonrpc: "2.0", Method: "getinfo"},
+		},
+		{
+			data:         `{"jsonrpc":"2.0","method":"getinfo"}`,
+			request:      json.Request{Jsonrpc: "2.0", Method: "getinfo"},
+			notification: true,
+		},
+		{
+			data:  " \n[{\"jsonrpc\":\"2.0\",\"method\":\"getinfo\",\"id\":1}]",
+			batch: true,
+		},
+	}
+	t.Logf("Running %d tests", len(tests))
+
+	for i, test := range tests {
+
+		data := []byte(test.data)
+
+		if json.IsBatchRequest(data) != test.batch {
+
+			t.Errorf("Test #%d IsBatchRequest: got %v, want %v", i,
+				!test.batch, test.batch)
+			continue
+		}
+
+		if !test.batch &&
+			json.IsNotification(data, &test.request) != test.notification {
+
+			t.Errorf("Test #%d IsNotification: got %v, want %v", i,
+				!test.notification, test.notification)
+		}
+	}
+}
+
 // TestMiscErrors tests a few error conditions not covered elsewhere.
 func TestMiscErrors(
 	t *testing.T) {
@@ -138,9 +275,9 @@ func TestMiscErrors(
 	// Force an error in MarshalResponse by giving it a result type that can't be marshalled.
 	_, err = json.MarshalResponse(1, make(chan int), nil)
 
-	if _, ok := err.(*json.UnsupportedTypeError); !ok {
+	if _, ok := err.(*js.UnsupportedTypeError); !ok {
 
-		wantErr := &json.UnsupportedTypeError{}
+		wantErr := &js.UnsupportedTypeError{}
 		t.Errorf("MarshalResult: did not receive expected error - got "+
 			"%v (%[1]T), want %T", err, wantErr)
 		return
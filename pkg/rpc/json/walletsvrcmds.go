@@ -35,6 +35,38 @@ func NewAddWitnessAddressCmd(
 	}
 }
 
+// BumpFeeCmd defines the bumpfee JSON-RPC command.
+
+type BumpFeeCmd struct {
+	TxID    string
+	FeeRate *float64
+}
+
+// NewBumpFeeCmd returns a new instance which can be used to issue a bumpfee JSON-RPC command. The parameters which are pointers indicate they are optional.  Passing nil for optional parameters will use the default value.
+func NewBumpFeeCmd(
+	txID string, feeRate *float64) *BumpFeeCmd {
+
+	return &BumpFeeCmd{
+		TxID:    txID,
+		FeeRate: feeRate,
+	}
+}
+
+// CombinePsbtCmd defines the combinepsbt JSON-RPC command.
+
+type CombinePsbtCmd struct {
+	Psbts []string
+}
+
+// NewCombinePsbtCmd returns a new instance which can be used to issue a combinepsbt JSON-RPC command.
+func NewCombinePsbtCmd(
+	psbts []string) *CombinePsbtCmd {
+
+	return &CombinePsbtCmd{
+		Psbts: psbts,
+	}
+}
+
 // CreateMultisigCmd defines the createmultisig JSON-RPC command.
 
 type CreateMultisigCmd struct {
@@ -52,6 +84,21 @@ func NewCreateMultisigCmd(
 	}
 }
 
+// DecodePsbtCmd defines the decodepsbt JSON-RPC command.
+
+type DecodePsbtCmd struct {
+	Psbt string
+}
+
+// NewDecodePsbtCmd returns a new instance which can be used to issue a decodepsbt JSON-RPC command.
+func NewDecodePsbtCmd(
+	psbt string) *DecodePsbtCmd {
+
+	return &DecodePsbtCmd{
+		Psbt: psbt,
+	}
+}
+
 // DumpPrivKeyCmd defines the dumpprivkey JSON-RPC command.
 
 type DumpPrivKeyCmd struct {
@@ -112,6 +159,23 @@ func NewEstimatePriorityCmd(
 	}
 }
 
+// FinalizePsbtCmd defines the finalizepsbt JSON-RPC command.
+
+type FinalizePsbtCmd struct {
+	Psbt    string
+	Extract *bool `jsonrpcdefault:"true"`
+}
+
+// NewFinalizePsbtCmd returns a new instance which can be used to issue a finalizepsbt JSON-RPC command. The parameters which are pointers indicate they are optional.  Passing nil for optional parameters will use the default value.
+func NewFinalizePsbtCmd(
+	psbt string, extract *bool) *FinalizePsbtCmd {
+
+	return &FinalizePsbtCmd{
+		Psbt:    psbt,
+		Extract: extract,
+	}
+}
+
 // GetAccountCmd defines the getaccount JSON-RPC command.
 
 type GetAccountCmd struct {
@@ -474,67 +538,79 @@ func NewMoveCmd(
 // SendFromCmd defines the sendfrom JSON-RPC command.
 
 type SendFromCmd struct {
-	FromAccount string
-	ToAddress   string
-	Amount      float64 // In DUO
-	MinConf     *int    `jsonrpcdefault:"1"`
-	Comment     *string
-	CommentTo   *string
+	FromAccount   string
+	ToAddress     string
+	Amount        float64 // In DUO
+	MinConf       *int    `jsonrpcdefault:"1"`
+	Comment       *string
+	CommentTo     *string
+	CoinSelection *string
+	Replaceable   *bool
 }
 
 // NewSendFromCmd returns a new instance which can be used to issue a sendfrom JSON-RPC command. The parameters which are pointers indicate they are optional.  Passing nil for optional parameters will use the default value.
 func NewSendFromCmd(
-	fromAccount, toAddress string, amount float64, minConf *int, comment, commentTo *string) *SendFromCmd {
+	fromAccount, toAddress string, amount float64, minConf *int, comment, commentTo, coinSelection *string, replaceable *bool) *SendFromCmd {
 
 	return &SendFromCmd{
-		FromAccount: fromAccount,
-		ToAddress:   toAddress,
-		Amount:      amount,
-		MinConf:     minConf,
-		Comment:     comment,
-		CommentTo:   commentTo,
+		FromAccount:   fromAccount,
+		ToAddress:     toAddress,
+		Amount:        amount,
+		MinConf:       minConf,
+		Comment:       comment,
+		CommentTo:     commentTo,
+		CoinSelection: coinSelection,
+		Replaceable:   replaceable,
 	}
 }
 
 // SendManyCmd defines the sendmany JSON-RPC command.
 
 type SendManyCmd struct {
-	FromAccount string
-	Amounts     map[string]float64 `jsonrpcusage:"{\"address\":amount,...}"` // In DUO
-	MinConf     *int               `jsonrpcdefault:"1"`
-	Comment     *string
+	FromAccount   string
+	Amounts       map[string]float64 `jsonrpcusage:"{\"address\":amount,...}"` // In DUO
+	MinConf       *int               `jsonrpcdefault:"1"`
+	Comment       *string
+	CoinSelection *string
+	Replaceable   *bool
 }
 
 // NewSendManyCmd returns a new instance which can be used to issue a sendmany JSON-RPC command. The parameters which are pointers indicate they are optional.  Passing nil for optional parameters will use the default value.
 func NewSendManyCmd(
-	fromAccount string, amounts map[string]float64, minConf *int, comment *string) *SendManyCmd {
+	fromAccount string, amounts map[string]float64, minConf *int, comment, coinSelection *string, replaceable *bool) *SendManyCmd {
 
 	return &SendManyCmd{
-		FromAccount: fromAccount,
-		Amounts:     amounts,
-		MinConf:     minConf,
-		Comment:     comment,
+		FromAccount:   fromAccount,
+		Amounts:       amounts,
+		MinConf:       minConf,
+		Comment:       comment,
+		CoinSelection: coinSelection,
+		Replaceable:   replaceable,
 	}
 }
 
 // SendToAddressCmd defines the sendtoaddress JSON-RPC command.
 
 type SendToAddressCmd struct {
-	Address   string
-	Amount    float64
-	Comment   *string
-	CommentTo *string
+	Address       string
+	Amount        float64
+	Comment       *string
+	CommentTo     *string
+	CoinSelection *string
+	Replaceable   *bool
 }
 
 // NewSendToAddressCmd returns a new instance which can be used to issue a sendtoaddress JSON-RPC command. The parameters which are pointers indicate they are optional. Passing nil for optional parameters will use the default value.
 func NewSendToAddressCmd(
-	address string, amount float64, comment, commentTo *string) *SendToAddressCmd {
+	address string, amount float64, comment, commentTo, coinSelection *string, replaceable *bool) *SendToAddressCmd {
 
 	return &SendToAddressCmd{
-		Address:   address,
-		Amount:    amount,
-		Comment:   comment,
-		CommentTo: commentTo,
+		Address:       address,
+		Amount:        amount,
+		Comment:       comment,
+		CommentTo:     commentTo,
+		CoinSelection: coinSelection,
+		Replaceable:   replaceable,
 	}
 }
 
@@ -555,6 +631,23 @@ func NewSetAccountCmd(
 	}
 }
 
+// SetLabelCmd defines the setlabel JSON-RPC command.
+
+type SetLabelCmd struct {
+	Address string
+	Label   string
+}
+
+// NewSetLabelCmd returns a new instance which can be used to issue a setlabel JSON-RPC command.
+func NewSetLabelCmd(
+	address, label string) *SetLabelCmd {
+
+	return &SetLabelCmd{
+		Address: address,
+		Label:   label,
+	}
+}
+
 // SetTxFeeCmd defines the settxfee JSON-RPC command.
 
 type SetTxFeeCmd struct {
@@ -617,6 +710,45 @@ func NewSignRawTransactionCmd(
 	}
 }
 
+// PsbtInput models an input of the transaction funded by the walletcreatefundedpsbt JSON-RPC command.
+
+type PsbtInput struct {
+	Txid     string  `json:"txid"`
+	Vout     uint32  `json:"vout"`
+	Sequence *uint32 `json:"sequence,omitempty"`
+}
+
+// WalletCreateFundedPsbtOpts models the options of the walletcreatefundedpsbt JSON-RPC command.
+
+type WalletCreateFundedPsbtOpts struct {
+	ChangeAddress *string  `json:"changeAddress,omitempty"`
+	FeeRate       *float64 `json:"feeRate,omitempty"`
+	LockUnspents  *bool    `json:"lockUnspents,omitempty"`
+	Replaceable   *bool    `json:"replaceable,omitempty"`
+}
+
+// WalletCreateFundedPsbtCmd defines the walletcreatefundedpsbt JSON-RPC command.
+
+type WalletCreateFundedPsbtCmd struct {
+	Inputs   []PsbtInput
+	Outputs  map[string]float64 `jsonrpcusage:"{\"address\":amount,...}"` // In DUO
+	Locktime *uint32
+	Options  *WalletCreateFundedPsbtOpts
+}
+
+// NewWalletCreateFundedPsbtCmd returns a new instance which can be used to issue a walletcreatefundedpsbt JSON-RPC command. The parameters which are pointers indicate they are optional.  Passing nil for optional parameters will use the default value.
+func NewWalletCreateFundedPsbtCmd(
+	inputs []PsbtInput, outputs map[string]float64, locktime *uint32,
+	options *WalletCreateFundedPsbtOpts) *WalletCreateFundedPsbtCmd {
+
+	return &WalletCreateFundedPsbtCmd{
+		Inputs:   inputs,
+		Outputs:  outputs,
+		Locktime: locktime,
+		Options:  options,
+	}
+}
+
 // WalletLockCmd defines the walletlock JSON-RPC command.
 
 type WalletLockCmd struct{}
@@ -660,17 +792,40 @@ func NewWalletPassphraseChangeCmd(
 		NewPassphrase: newPassphrase,
 	}
 }
+
+// WalletProcessPsbtCmd defines the walletprocesspsbt JSON-RPC command.
+
+type WalletProcessPsbtCmd struct {
+	Psbt        string
+	Sign        *bool   `jsonrpcdefault:"true"`
+	SighashType *string `jsonrpcdefault:"\"ALL\""`
+}
+
+// NewWalletProcessPsbtCmd returns a new instance which can be used to issue a walletprocesspsbt JSON-RPC command. The parameters which are pointers indicate they are optional.  Passing nil for optional parameters will use the default value.
+func NewWalletProcessPsbtCmd(
+	psbt string, sign *bool, sighashType *string) *WalletProcessPsbtCmd {
+
+	return &WalletProcessPsbtCmd{
+		Psbt:        psbt,
+		Sign:        sign,
+		SighashType: sighashType,
+	}
+}
 func init() {
 
 	// The commands in this file are only usable with a wallet server.
 	flags := UFWalletOnly
 	MustRegisterCmd("addmultisigaddress", (*AddMultisigAddressCmd)(nil), flags)
 	MustRegisterCmd("addwitnessaddress", (*AddWitnessAddressCmd)(nil), flags)
+	MustRegisterCmd("bumpfee", (*BumpFeeCmd)(nil), flags)
+	MustRegisterCmd("combinepsbt", (*CombinePsbtCmd)(nil), flags)
 	MustRegisterCmd("createmultisig", (*CreateMultisigCmd)(nil), flags)
+	MustRegisterCmd("decodepsbt", (*DecodePsbtCmd)(nil), flags)
 	MustRegisterCmd("dumpprivkey", (*DumpPrivKeyCmd)(nil), flags)
 	MustRegisterCmd("encryptwallet", (*EncryptWalletCmd)(nil), flags)
 	MustRegisterCmd("estimatefee", (*EstimateFeeCmd)(nil), flags)
 	MustRegisterCmd("estimatepriority", (*EstimatePriorityCmd)(nil), flags)
+	MustRegisterCmd("finalizepsbt", (*FinalizePsbtCmd)(nil), flags)
 	MustRegisterCmd("getaccount", (*GetAccountCmd)(nil), flags)
 	MustRegisterCmd("getaccountaddress", (*GetAccountAddressCmd)(nil), flags)
 	MustRegisterCmd("getaddressesbyaccount", (*GetAddressesByAccountCmd)(nil), flags)
@@ -697,10 +852,13 @@ func init() {
 	MustRegisterCmd("sendmany", (*SendManyCmd)(nil), flags)
 	MustRegisterCmd("sendtoaddress", (*SendToAddressCmd)(nil), flags)
 	MustRegisterCmd("setaccount", (*SetAccountCmd)(nil), flags)
+	MustRegisterCmd("setlabel", (*SetLabelCmd)(nil), flags)
 	MustRegisterCmd("settxfee", (*SetTxFeeCmd)(nil), flags)
 	MustRegisterCmd("signmessage", (*SignMessageCmd)(nil), flags)
 	MustRegisterCmd("signrawtransaction", (*SignRawTransactionCmd)(nil), flags)
+	MustRegisterCmd("walletcreatefundedpsbt", (*WalletCreateFundedPsbtCmd)(nil), flags)
 	MustRegisterCmd("walletlock", (*WalletLockCmd)(nil), flags)
 	MustRegisterCmd("walletpassphrase", (*WalletPassphraseCmd)(nil), flags)
 	MustRegisterCmd("walletpassphrasechange", (*WalletPassphraseChangeCmd)(nil), flags)
+	MustRegisterCmd("walletprocesspsbt", (*WalletProcessPsbtCmd)(nil), flags)
 }
@@ -2,7 +2,7 @@ package json_test
 
 import (
 	"bytes"
-	"encoding/json"
+	js "encoding/json"
 	"fmt"
 	"reflect"
 	"testing"
@@ -74,6 +74,53 @@ func TestWalletSvrCmds(
 				Address: "1address",
 			},
 		},
+		{
+			name: "bumpfee",
+			newCmd: func() (interface{}, error) {
+
+				return json.NewCmd("bumpfee", "123")
+			},
+			staticCmd: func() interface{} {
+
+				return json.NewBumpFeeCmd("123", nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"bumpfee","params":["123"],"id":1}`,
+			unmarshalled: &json.BumpFeeCmd{
+				TxID:    "123",
+				FeeRate: nil,
+			},
+		},
+		{
+			name: "bumpfee optional",
+			newCmd: func() (interface{}, error) {
+
+				return json.NewCmd("bumpfee", "123", 0.0002)
+			},
+			staticCmd: func() interface{} {
+
+				return json.NewBumpFeeCmd("123", json.Float64(0.0002))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"bumpfee","params":["123",0.0002],"id":1}`,
+			unmarshalled: &json.BumpFeeCmd{
+				TxID:    "123",
+				FeeRate: json.Float64(0.0002),
+			},
+		},
+		{
+			name: "combinepsbt",
+			newCmd: func() (interface{}, error) {
+
+				return json.NewCmd("combinepsbt", []string{"cHNidP8B", "cHNidP8C"})
+			},
+			staticCmd: func() interface{} {
+
+				return json.NewCombinePsbtCmd([]string{"cHNidP8B", "cHNidP8C"})
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"combinepsbt","params":[["cHNidP8B","cHNidP8C"]],"id":1}`,
+			unmarshalled: &json.CombinePsbtCmd{
+				Psbts: []string{"cHNidP8B", "cHNidP8C"},
+			},
+		},
 		{
 			name: "createmultisig",
 			newCmd: func() (interface{}, error) {
@@ -91,6 +138,21 @@ func TestWalletSvrCmds(
 				Keys:      []string{"031234", "035678"},
 			},
 		},
+		{
+			name: "decodepsbt",
+			newCmd: func() (interface{}, error) {
+
+				return json.NewCmd("decodepsbt", "cHNidP8B")
+			},
+			staticCmd: func() interface{} {
+
+				return json.NewDecodePsbtCmd("cHNidP8B")
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"decodepsbt","params":["cHNidP8B"],"id":1}`,
+			unmarshalled: &json.DecodePsbtCmd{
+				Psbt: "cHNidP8B",
+			},
+		},
 		{
 			name: "dumpprivkey",
 			newCmd: func() (interface{}, error) {
@@ -151,6 +213,38 @@ func TestWalletSvrCmds(
 				NumBlocks: 6,
 			},
 		},
+		{
+			name: "finalizepsbt",
+			newCmd: func() (interface{}, error) {
+
+				return json.NewCmd("finalizepsbt", "cHNidP8B")
+			},
+			staticCmd: func() interface{} {
+
+				return json.NewFinalizePsbtCmd("cHNidP8B", nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"finalizepsbt","params":["cHNidP8B"],"id":1}`,
+			unmarshalled: &json.FinalizePsbtCmd{
+				Psbt:    "cHNidP8B",
+				Extract: json.Bool(true),
+			},
+		},
+		{
+			name: "finalizepsbt optional",
+			newCmd: func() (interface{}, error) {
+
+				return json.NewCmd("finalizepsbt", "cHNidP8B", false)
+			},
+			staticCmd: func() interface{} {
+
+				return json.NewFinalizePsbtCmd("cHNidP8B", json.Bool(false))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"finalizepsbt","params":["cHNidP8B",false],"id":1}`,
+			unmarshalled: &json.FinalizePsbtCmd{
+				Psbt:    "cHNidP8B",
+				Extract: json.Bool(false),
+			},
+		},
 		{
 			name: "getaccount",
 			newCmd: func() (interface{}, error) {
@@ -1001,7 +1095,7 @@ func TestWalletSvrCmds(
 			},
 			staticCmd: func() interface{} {
 
-				return json.NewSendFromCmd("from", "1Address", 0.5, nil, nil, nil)
+				return json.NewSendFromCmd("from", "1Address", 0.5, nil, nil, nil, nil, nil)
 			},
 			marshalled: `{"jsonrpc":"1.0","method":"sendfrom","params":["from","1Address",0.5],"id":1}`,
 			unmarshalled: &json.SendFromCmd{
@@ -1021,7 +1115,7 @@ func TestWalletSvrCmds(
 			},
 			staticCmd: func() interface{} {
 
-				return json.NewSendFromCmd("from", "1Address", 0.5, json.Int(6), nil, nil)
+				return json.NewSendFromCmd("from", "1Address", 0.5, json.Int(6), nil, nil, nil, nil)
 			},
 			marshalled: `{"jsonrpc":"1.0","method":"sendfrom","params":["from","1Address",0.5,6],"id":1}`,
 			unmarshalled: &json.SendFromCmd{
@@ -1042,7 +1136,7 @@ func TestWalletSvrCmds(
 			staticCmd: func() interface{} {
 
 				return json.NewSendFromCmd("from", "1Address", 0.5, json.Int(6),
-					json.String("comment"), nil)
+					json.String("comment"), nil, nil, nil)
 			},
 			marshalled: `{"jsonrpc":"1.0","method":"sendfrom","params":["from","1Address",0.5,6,"comment"],"id":1}`,
 			unmarshalled: &json.SendFromCmd{
@@ -1063,7 +1157,7 @@ func TestWalletSvrCmds(
 			staticCmd: func() interface{} {
 
 				return json.NewSendFromCmd("from", "1Address", 0.5, json.Int(6),
-					json.String("comment"), json.String("commentto"))
+					json.String("comment"), json.String("commentto"), nil, nil)
 			},
 			marshalled: `{"jsonrpc":"1.0","method":"sendfrom","params":["from","1Address",0.5,6,"comment","commentto"],"id":1}`,
 			unmarshalled: &json.SendFromCmd{
@@ -1075,6 +1169,52 @@ func TestWalletSvrCmds(
 				CommentTo:   json.String("commentto"),
 			},
 		},
+		{
+			name: "sendfrom optional4",
+			newCmd: func() (interface{}, error) {
+
+				return json.NewCmd("sendfrom", "from", "1Address", 0.5, 6, "comment", "commentto", "bnb")
+			},
+			staticCmd: func() interface{} {
+
+				return json.NewSendFromCmd("from", "1Address", 0.5, json.Int(6),
+					json.String("comment"), json.String("commentto"), json.String("bnb"), nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"sendfrom","params":["from","1Address",0.5,6,"comment","commentto","bnb"],"id":1}`,
+			unmarshalled: &json.SendFromCmd{
+				FromAccount:   "from",
+				ToAddress:     "1Address",
+				Amount:        0.5,
+				MinConf:       json.Int(6),
+				Comment:       json.String("comment"),
+				CommentTo:     json.String("commentto"),
+				CoinSelection: json.String("bnb"),
+			},
+		},
+		{
+			name: "sendfrom optional5",
+			newCmd: func() (interface{}, error) {
+
+				return json.NewCmd("sendfrom", "from", "1Address", 0.5, 6, "comment", "commentto", "bnb", true)
+			},
+			staticCmd: func() interface{} {
+
+				return json.NewSendFromCmd("from", "1Address", 0.5, json.Int(6),
+					json.String("comment"), json.String("commentto"), json.String("bnb"),
+					json.Bool(true))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"sendfrom","params":["from","1Address",0.5,6,"comment","commentto","bnb",true],"id":1}`,
+			unmarshalled: &json.SendFromCmd{
+				FromAccount:   "from",
+				ToAddress:     "1Address",
+				Amount:        0.5,
+				MinConf:       json.Int(6),
+				Comment:       json.String("comment"),
+				CommentTo:     json.String("commentto"),
+				CoinSelection: json.String("bnb"),
+				Replaceable:   json.Bool(true),
+			},
+		},
 		{
 			name: "sendmany",
 			newCmd: func() (interface{}, error) {
@@ -1084,7 +1224,7 @@ func TestWalletSvrCmds(
 			staticCmd: func() interface{} {
 
 				amounts := map[string]float64{"1Address": 0.5}
-				return json.NewSendManyCmd("from", amounts, nil, nil)
+				return json.NewSendManyCmd("from", amounts, nil, nil, nil, nil)
 			},
 			marshalled: `{"jsonrpc":"1.0","method":"sendmany","params":["from",{"1Address":0.5}],"id":1}`,
 			unmarshalled: &json.SendManyCmd{
@@ -1103,7 +1243,7 @@ func TestWalletSvrCmds(
 			staticCmd: func() interface{} {
 
 				amounts := map[string]float64{"1Address": 0.5}
-				return json.NewSendManyCmd("from", amounts, json.Int(6), nil)
+				return json.NewSendManyCmd("from", amounts, json.Int(6), nil, nil, nil)
 			},
 			marshalled: `{"jsonrpc":"1.0","method":"sendmany","params":["from",{"1Address":0.5},6],"id":1}`,
 			unmarshalled: &json.SendManyCmd{
@@ -1122,7 +1262,7 @@ func TestWalletSvrCmds(
 			staticCmd: func() interface{} {
 
 				amounts := map[string]float64{"1Address": 0.5}
-				return json.NewSendManyCmd("from", amounts, json.Int(6), json.String("comment"))
+				return json.NewSendManyCmd("from", amounts, json.Int(6), json.String("comment"), nil, nil)
 			},
 			marshalled: `{"jsonrpc":"1.0","method":"sendmany","params":["from",{"1Address":0.5},6,"comment"],"id":1}`,
 			unmarshalled: &json.SendManyCmd{
@@ -1132,6 +1272,49 @@ func TestWalletSvrCmds(
 				Comment:     json.String("comment"),
 			},
 		},
+		{
+			name: "sendmany optional3",
+			newCmd: func() (interface{}, error) {
+
+				return json.NewCmd("sendmany", "from", `{"1Address":0.5}`, 6, "comment", "privacy")
+			},
+			staticCmd: func() interface{} {
+
+				amounts := map[string]float64{"1Address": 0.5}
+				return json.NewSendManyCmd("from", amounts, json.Int(6), json.String("comment"),
+					json.String("privacy"), nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"sendmany","params":["from",{"1Address":0.5},6,"comment","privacy"],"id":1}`,
+			unmarshalled: &json.SendManyCmd{
+				FromAccount:   "from",
+				Amounts:       map[string]float64{"1Address": 0.5},
+				MinConf:       json.Int(6),
+				Comment:       json.String("comment"),
+				CoinSelection: json.String("privacy"),
+			},
+		},
+		{
+			name: "sendmany optional4",
+			newCmd: func() (interface{}, error) {
+
+				return json.NewCmd("sendmany", "from", `{"1Address":0.5}`, 6, "comment", "privacy", true)
+			},
+			staticCmd: func() interface{} {
+
+				amounts := map[string]float64{"1Address": 0.5}
+				return json.NewSendManyCmd("from", amounts, json.Int(6), json.String("comment"),
+					json.String("privacy"), json.Bool(true))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"sendmany","params":["from",{"1Address":0.5},6,"comment","privacy",true],"id":1}`,
+			unmarshalled: &json.SendManyCmd{
+				FromAccount:   "from",
+				Amounts:       map[string]float64{"1Address": 0.5},
+				MinConf:       json.Int(6),
+				Comment:       json.String("comment"),
+				CoinSelection: json.String("privacy"),
+				Replaceable:   json.Bool(true),
+			},
+		},
 		{
 			name: "sendtoaddress",
 			newCmd: func() (interface{}, error) {
@@ -1140,7 +1323,7 @@ func TestWalletSvrCmds(
 			},
 			staticCmd: func() interface{} {
 
-				return json.NewSendToAddressCmd("1Address", 0.5, nil, nil)
+				return json.NewSendToAddressCmd("1Address", 0.5, nil, nil, nil, nil)
 			},
 			marshalled: `{"jsonrpc":"1.0","method":"sendtoaddress","params":["1Address",0.5],"id":1}`,
 			unmarshalled: &json.SendToAddressCmd{
@@ -1159,7 +1342,7 @@ func TestWalletSvrCmds(
 			staticCmd: func() interface{} {
 
 				return json.NewSendToAddressCmd("1Address", 0.5, json.String("comment"),
-					json.String("commentto"))
+					json.String("commentto"), nil, nil)
 			},
 			marshalled: `{"jsonrpc":"1.0","method":"sendtoaddress","params":["1Address",0.5,"comment","commentto"],"id":1}`,
 			unmarshalled: &json.SendToAddressCmd{
@@ -1169,6 +1352,47 @@ func TestWalletSvrCmds(
 				CommentTo: json.String("commentto"),
 			},
 		},
+		{
+			name: "sendtoaddress optional2",
+			newCmd: func() (interface{}, error) {
+
+				return json.NewCmd("sendtoaddress", "1Address", 0.5, "comment", "commentto", "consolidate")
+			},
+			staticCmd: func() interface{} {
+
+				return json.NewSendToAddressCmd("1Address", 0.5, json.String("comment"),
+					json.String("commentto"), json.String("consolidate"), nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"sendtoaddress","params":["1Address",0.5,"comment","commentto","consolidate"],"id":1}`,
+			unmarshalled: &json.SendToAddressCmd{
+				Address:       "1Address",
+				Amount:        0.5,
+				Comment:       json.String("comment"),
+				CommentTo:     json.String("commentto"),
+				CoinSelection: json.String("consolidate"),
+			},
+		},
+		{
+			name: "sendtoaddress optional3",
+			newCmd: func() (interface{}, error) {
+
+				return json.NewCmd("sendtoaddress", "1Address", 0.5, "comment", "commentto", "consolidate", true)
+			},
+			staticCmd: func() interface{} {
+
+				return json.NewSendToAddressCmd("1Address", 0.5, json.String("comment"),
+					json.String("commentto"), json.String("consolidate"), json.Bool(true))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"sendtoaddress","params":["1Address",0.5,"comment","commentto","consolidate",true],"id":1}`,
+			unmarshalled: &json.SendToAddressCmd{
+				Address:       "1Address",
+				Amount:        0.5,
+				Comment:       json.String("comment"),
+				CommentTo:     json.String("commentto"),
+				CoinSelection: json.String("consolidate"),
+				Replaceable:   json.Bool(true),
+			},
+		},
 		{
 			name: "setaccount",
 			newCmd: func() (interface{}, error) {
@@ -1308,6 +1532,82 @@ func TestWalletSvrCmds(
 				Flags:    json.String("ALL"),
 			},
 		},
+		{
+			name: "walletcreatefundedpsbt",
+			newCmd: func() (interface{}, error) {
+
+				return json.NewCmd("walletcreatefundedpsbt",
+					`[{"txid":"123","vout":1}]`, `{"456":0.0123}`)
+			},
+			staticCmd: func() interface{} {
+
+				inputs := []json.PsbtInput{
+					{
+						Txid: "123",
+						Vout: 1,
+					},
+				}
+				outputs := map[string]float64{"456": .0123}
+				return json.NewWalletCreateFundedPsbtCmd(inputs, outputs, nil, nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"walletcreatefundedpsbt","params":[[{"txid":"123","vout":1}],{"456":0.0123}],"id":1}`,
+			unmarshalled: &json.WalletCreateFundedPsbtCmd{
+				Inputs: []json.PsbtInput{
+					{
+						Txid: "123",
+						Vout: 1,
+					},
+				},
+				Outputs: map[string]float64{"456": .0123},
+			},
+		},
+		{
+			name: "walletcreatefundedpsbt optional",
+			newCmd: func() (interface{}, error) {
+
+				return json.NewCmd("walletcreatefundedpsbt",
+					`[{"txid":"123","vout":1,"sequence":4294967293}]`,
+					`{"456":0.0123}`, 100,
+					`{"changeAddress":"789","feeRate":0.0002,"lockUnspents":true,"replaceable":true}`)
+			},
+			staticCmd: func() interface{} {
+
+				inputs := []json.PsbtInput{
+					{
+						Txid:     "123",
+						Vout:     1,
+						Sequence: json.Uint32(4294967293),
+					},
+				}
+				outputs := map[string]float64{"456": .0123}
+				options := &json.WalletCreateFundedPsbtOpts{
+					ChangeAddress: json.String("789"),
+					FeeRate:       json.Float64(0.0002),
+					LockUnspents:  json.Bool(true),
+					Replaceable:   json.Bool(true),
+				}
+				return json.NewWalletCreateFundedPsbtCmd(inputs, outputs,
+					json.Uint32(100), options)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"walletcreatefundedpsbt","params":[[{"txid":"123","vout":1,"sequence":4294967293}],{"456":0.0123},100,{"changeAddress":"789","feeRate":0.0002,"lockUnspents":true,"replaceable":true}],"id":1}`,
+			unmarshalled: &json.WalletCreateFundedPsbtCmd{
+				Inputs: []json.PsbtInput{
+					{
+						Txid:     "123",
+						Vout:     1,
+						Sequence: json.Uint32(4294967293),
+					},
+				},
+				Outputs:  map[string]float64{"456": .0123},
+				Locktime: json.Uint32(100),
+				Options: &json.WalletCreateFundedPsbtOpts{
+					ChangeAddress: json.String("789"),
+					FeeRate:       json.Float64(0.0002),
+					LockUnspents:  json.Bool(true),
+					Replaceable:   json.Bool(true),
+				},
+			},
+		},
 		{
 			name: "walletlock",
 			newCmd: func() (interface{}, error) {
@@ -1353,6 +1653,41 @@ func TestWalletSvrCmds(
 				NewPassphrase: "new",
 			},
 		},
+		{
+			name: "walletprocesspsbt",
+			newCmd: func() (interface{}, error) {
+
+				return json.NewCmd("walletprocesspsbt", "cHNidP8B")
+			},
+			staticCmd: func() interface{} {
+
+				return json.NewWalletProcessPsbtCmd("cHNidP8B", nil, nil)
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"walletprocesspsbt","params":["cHNidP8B"],"id":1}`,
+			unmarshalled: &json.WalletProcessPsbtCmd{
+				Psbt:        "cHNidP8B",
+				Sign:        json.Bool(true),
+				SighashType: json.String("ALL"),
+			},
+		},
+		{
+			name: "walletprocesspsbt optional",
+			newCmd: func() (interface{}, error) {
+
+				return json.NewCmd("walletprocesspsbt", "cHNidP8B", false, "SINGLE|ANYONECANPAY")
+			},
+			staticCmd: func() interface{} {
+
+				return json.NewWalletProcessPsbtCmd("cHNidP8B", json.Bool(false),
+					json.String("SINGLE|ANYONECANPAY"))
+			},
+			marshalled: `{"jsonrpc":"1.0","method":"walletprocesspsbt","params":["cHNidP8B",false,"SINGLE|ANYONECANPAY"],"id":1}`,
+			unmarshalled: &json.WalletProcessPsbtCmd{
+				Psbt:        "cHNidP8B",
+				Sign:        json.Bool(false),
+				SighashType: json.String("SINGLE|ANYONECANPAY"),
+			},
+		},
 	}
 	t.Logf("Running %d tests", len(tests))
 
@@ -1401,7 +1736,7 @@ func TestWalletSvrCmds(
 		}
 		var request json.Request
 
-		if err := json.Unmarshal(marshalled, &request); err != nil {
+		if err := js.Unmarshal(marshalled, &request); err != nil {
 
 			t.Errorf("Test #%d (%s) unexpected error while "+
 				"unmarshalling JSON-RPC request: %v", i,
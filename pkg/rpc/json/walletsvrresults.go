@@ -1,5 +1,78 @@
 package json
 
+// BumpFeeResult models the data from the bumpfee command.
+
+type BumpFeeResult struct {
+	TxID    string  `json:"txid"`
+	OrigFee float64 `json:"origfee"`
+	Fee     float64 `json:"fee"`
+}
+
+// DecodePsbtScript models a redeem or witness script in the data from the decodepsbt command.
+
+type DecodePsbtScript struct {
+	Asm  string `json:"asm"`
+	Hex  string `json:"hex"`
+	Type string `json:"type"`
+}
+
+// DecodePsbtBip32Deriv models the derivation path of a public key in the data from the decodepsbt command.
+
+type DecodePsbtBip32Deriv struct {
+	PubKey            string `json:"pubkey"`
+	MasterFingerprint string `json:"master_fingerprint"`
+	Path              string `json:"path"`
+}
+
+// DecodePsbtWitnessUtxo models the output spent by a segregated witness input in the data from the decodepsbt command.
+
+type DecodePsbtWitnessUtxo struct {
+	Amount       float64            `json:"amount"`
+	ScriptPubKey ScriptPubKeyResult `json:"scriptPubKey"`
+}
+
+// DecodePsbtInput models the data of an input from the decodepsbt command.
+
+type DecodePsbtInput struct {
+	NonWitnessUtxo     *TxRawDecodeResult     `json:"non_witness_utxo,omitempty"`
+	WitnessUtxo        *DecodePsbtWitnessUtxo `json:"witness_utxo,omitempty"`
+	PartialSignatures  map[string]string      `json:"partial_signatures,omitempty"`
+	Sighash            string                 `json:"sighash,omitempty"`
+	RedeemScript       *DecodePsbtScript      `json:"redeem_script,omitempty"`
+	WitnessScript      *DecodePsbtScript      `json:"witness_script,omitempty"`
+	Bip32Derivs        []DecodePsbtBip32Deriv `json:"bip32_derivs,omitempty"`
+	FinalScriptSig     *ScriptSig             `json:"final_scriptSig,omitempty"`
+	FinalScriptWitness []string               `json:"final_scriptwitness,omitempty"`
+	Unknown            map[string]string      `json:"unknown,omitempty"`
+}
+
+// DecodePsbtOutput models the data of an output from the decodepsbt command.
+
+type DecodePsbtOutput struct {
+	RedeemScript  *DecodePsbtScript      `json:"redeem_script,omitempty"`
+	WitnessScript *DecodePsbtScript      `json:"witness_script,omitempty"`
+	Bip32Derivs   []DecodePsbtBip32Deriv `json:"bip32_derivs,omitempty"`
+	Unknown       map[string]string      `json:"unknown,omitempty"`
+}
+
+// DecodePsbtResult models the data from the decodepsbt command.  Fee is only set when the outputs spent by every input are known.
+
+type DecodePsbtResult struct {
+	Tx      TxRawDecodeResult  `json:"tx"`
+	Unknown map[string]string  `json:"unknown"`
+	Inputs  []DecodePsbtInput  `json:"inputs"`
+	Outputs []DecodePsbtOutput `json:"outputs"`
+	Fee     *float64           `json:"fee,omitempty"`
+}
+
+// FinalizePsbtResult models the data from the finalizepsbt command.  Hex is only set when the PSBT is complete and extraction was requested, in which case Psbt is not set.
+
+type FinalizePsbtResult struct {
+	Psbt     string `json:"psbt,omitempty"`
+	Hex      string `json:"hex,omitempty"`
+	Complete bool   `json:"complete"`
+}
+
 // GetTransactionDetailsResult models the details data from the gettransaction command. This models the "short" version of the ListTransactionsResult type, which excludes fields common to the transaction.  These common fields are instead part of the GetTransactionResult.
 
 type GetTransactionDetailsResult struct {
@@ -10,6 +83,7 @@ type GetTransactionDetailsResult struct {
 	InvolvesWatchOnly bool     `json:"involveswatchonly,omitempty"`
 	Fee               *float64 `json:"fee,omitempty"`
 	Vout              uint32   `json:"vout"`
+	Label             string   `json:"label,omitempty"`
 }
 
 // GetTransactionResult models the data from the gettransaction command.
@@ -27,6 +101,9 @@ type GetTransactionResult struct {
 	TimeReceived    int64                         `json:"timereceived"`
 	Details         []GetTransactionDetailsResult `json:"details"`
 	Hex             string                        `json:"hex"`
+	TxLabel         string                        `json:"txlabel,omitempty"`
+	Comment         string                        `json:"comment,omitempty"`
+	To              string                        `json:"to,omitempty"`
 }
 
 // InfoWalletResult models the data returned by the wallet server getinfo command.
@@ -74,6 +151,9 @@ type ListTransactionsResult struct {
 	WalletConflicts   []string `json:"walletconflicts"`
 	Comment           string   `json:"comment,omitempty"`
 	OtherAccount      string   `json:"otheraccount,omitempty"`
+	Label             string   `json:"label,omitempty"`
+	TxLabel           string   `json:"txlabel,omitempty"`
+	To                string   `json:"to,omitempty"`
 }
 
 // ListReceivedByAccountResult models the data from the listreceivedbyaccount command.
@@ -151,6 +231,32 @@ type ValidateAddressWalletResult struct {
 	SigsRequired int32    `json:"sigsrequired,omitempty"`
 }
 
+// WalletCreateFundedPsbtResult models the data from the walletcreatefundedpsbt command.
+
+type WalletCreateFundedPsbtResult struct {
+	Psbt      string  `json:"psbt"`
+	Fee       float64 `json:"fee"`
+	ChangePos int64   `json:"changepos"`
+}
+
+// WalletProcessPsbtResult models the data from the walletprocesspsbt command.
+
+type WalletProcessPsbtResult struct {
+	Psbt     string `json:"psbt"`
+	Complete bool   `json:"complete"`
+}
+
+// GetSpvSyncInfoResult models the data from the getspvsyncinfo command.
+
+type GetSpvSyncInfoResult struct {
+	Headers        int32  `json:"headers"`
+	BestHeaderHash string `json:"bestheaderhash"`
+	FilterHeaders  int32  `json:"filterheaders"`
+	Current        bool   `json:"current"`
+	Peers          int32  `json:"peers"`
+	WalletSynced   bool   `json:"walletsynced"`
+}
+
 // GetBestBlockResult models the data from the getbestblock command.
 
 type GetBestBlockResult struct {
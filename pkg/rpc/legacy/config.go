@@ -8,4 +8,5 @@ type Options struct {
 
 	MaxPOSTClients      int64
 	MaxWebsocketClients int64
+	MaxBatchSize        int
 }
@@ -6,15 +6,18 @@ package legacyrpc
 import (
 	"bytes"
 	"encoding/base64"
+	"encoding/binary"
 	"encoding/hex"
 	js "encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
 	chaincfg "git.parallelcoin.io/dev/pod/pkg/chain/config"
 	chainhash "git.parallelcoin.io/dev/pod/pkg/chain/hash"
+	txauthor "git.parallelcoin.io/dev/pod/pkg/chain/tx/author"
 	wtxmgr "git.parallelcoin.io/dev/pod/pkg/chain/tx/mgr"
 	txrules "git.parallelcoin.io/dev/pod/pkg/chain/tx/rules"
 	txscript "git.parallelcoin.io/dev/pod/pkg/chain/tx/script"
@@ -24,9 +27,12 @@ import (
 	"git.parallelcoin.io/dev/pod/pkg/util"
 	cl "git.parallelcoin.io/dev/pod/pkg/util/cl"
 	ec "git.parallelcoin.io/dev/pod/pkg/util/elliptic"
+	"git.parallelcoin.io/dev/pod/pkg/util/hdkeychain"
+	"git.parallelcoin.io/dev/pod/pkg/util/psbt"
 	"git.parallelcoin.io/dev/pod/pkg/wallet"
 	waddrmgr "git.parallelcoin.io/dev/pod/pkg/wallet/addrmgr"
 	chain "git.parallelcoin.io/dev/pod/pkg/wallet/chain"
+	wmeta "git.parallelcoin.io/dev/pod/pkg/wallet/meta"
 )
 
 // confirmed checks whether a transaction at height txHeight has met minconf
@@ -80,8 +86,12 @@ var rpcHandlers = map[string]struct {
 }{
 	// Reference implementation wallet methods (implemented)
 	"addmultisigaddress":     {handler: addMultiSigAddress},
+	"bumpfee":                {handler: bumpFee},
+	"combinepsbt":            {handler: combinePsbt},
 	"createmultisig":         {handler: createMultiSig},
+	"decodepsbt":             {handler: decodePsbt},
 	"dumpprivkey":            {handler: dumpPrivKey},
+	"finalizepsbt":           {handler: finalizePsbt},
 	"getaccount":             {handler: getAccount},
 	"getaccountaddress":      {handler: getAccountAddress},
 	"getaddressesbyaccount":  {handler: getAddressesByAccount},
@@ -108,14 +118,17 @@ var rpcHandlers = map[string]struct {
 	"sendfrom":               {handlerWithChain: sendFrom},
 	"sendmany":               {handler: sendMany},
 	"sendtoaddress":          {handler: sendToAddress},
+	"setlabel":               {handler: setLabel},
 	"settxfee":               {handler: setTxFee},
 	"signmessage":            {handler: signMessage},
 	"signrawtransaction":     {handlerWithChain: signRawTransaction},
 	"validateaddress":        {handler: validateAddress},
 	"verifymessage":          {handler: verifyMessage},
+	"walletcreatefundedpsbt": {handler: walletCreateFundedPsbt},
 	"walletlock":             {handler: walletLock},
 	"walletpassphrase":       {handler: walletPassphrase},
 	"walletpassphrasechange": {handler: walletPassphraseChange},
+	"walletprocesspsbt":      {handler: walletProcessPsbt},
 
 	// Reference implementation methods (still unimplemented)
 	"backupwallet":         {handler: unimplemented, noHelp: true},
@@ -133,14 +146,17 @@ var rpcHandlers = map[string]struct {
 	// Extensions to the reference client JSON-RPC API
 	"createnewaccount": {handler: createNewAccount},
 	"getbestblock":     {handler: getBestBlock},
+	"getspvsyncinfo":   {handler: getSpvSyncInfo},
 	// This was an extension but the reference implementation added it as
 	// well, but with a different API (no account parameter).  It's listed
 	// here because it hasn't been update to use the reference
 	// implemenation's API.
 	"getunconfirmedbalance":   {handler: getUnconfirmedBalance},
+	"importxpub":              {handler: importXpub},
 	"listaddresstransactions": {handler: listAddressTransactions},
 	"listalltransactions":     {handler: listAllTransactions},
 	"renameaccount":           {handler: renameAccount},
+	"settxlabel":              {handler: setTxLabel},
 	"walletislocked":          {handler: walletIsLocked},
 }
 
@@ -582,6 +598,39 @@ func getBestBlock(
 	return result, nil
 }
 
+// getSpvSyncInfo handles a getspvsyncinfo request by returning how far the
+// light client of the spv backend has synced the block headers and compact
+// filter headers.
+func getSpvSyncInfo(
+	icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+
+	client, ok := w.ChainClient().(*chain.NeutrinoClient)
+
+	if !ok {
+
+		return nil, &json.RPCError{
+			Code:    json.ErrRPCMisc,
+			Message: "The wallet is not using the spv backend",
+		}
+	}
+
+	status, err := client.CS.SyncStatus()
+
+	if err != nil {
+
+		return nil, err
+	}
+
+	return &json.GetSpvSyncInfoResult{
+		Headers:        status.BlockHeaderHeight,
+		BestHeaderHash: status.BlockHeaderHash.String(),
+		FilterHeaders:  status.FilterHeaderHeight,
+		Current:        status.Current,
+		Peers:          status.Peers,
+		WalletSynced:   w.ChainSynced(),
+	}, nil
+}
+
 // getBestBlockHash handles a getbestblockhash request by returning the hash
 // of the most recently processed block.
 func getBestBlockHash(
@@ -833,6 +882,45 @@ func createNewAccount(
 	return nil, err
 }
 
+// importXpub handles an importxpub request by creating a watch-only account
+// from the extended public key of an account of another wallet.
+func importXpub(
+	icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+
+	cmd := icmd.(*json.ImportXpubCmd)
+
+	// The wildcard * is reserved by the rpc server with the special meaning
+	// of "all accounts", so disallow naming accounts to this string.
+
+	if cmd.Account == "*" {
+
+		return nil, &ErrReservedAccountName
+	}
+
+	acctKeyPub, err := hdkeychain.NewKeyFromString(cmd.Xpub)
+
+	if err != nil {
+
+		return nil, &json.RPCError{
+			Code:    json.ErrRPCInvalidAddressOrKey,
+			Message: "Extended key decode failed: " + err.Error(),
+		}
+	}
+
+	if acctKeyPub.IsPrivate() || !acctKeyPub.IsForNet(w.ChainParams()) {
+
+		return nil, &json.RPCError{
+			Code: json.ErrRPCInvalidAddressOrKey,
+			Message: "Key is not an extended public key intended for " +
+				w.ChainParams().Name,
+		}
+	}
+
+	_, err = w.ImportXpub(waddrmgr.KeyScopeBIP0044, cmd.Account, acctKeyPub,
+		nil, *cmd.Rescan)
+	return nil, err
+}
+
 // renameAccount handles a renameaccount request by renaming an account.
 // If the account does not exist an appropiate error will be returned.
 func renameAccount(
@@ -858,6 +946,32 @@ func renameAccount(
 	return nil, w.RenameAccount(waddrmgr.KeyScopeBIP0044, account, cmd.NewAccount)
 }
 
+// setTxLabel handles a settxlabel request by setting the label of a
+// transaction recorded by the wallet.  An empty label removes the label.
+func setTxLabel(
+	icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+
+	cmd := icmd.(*json.SetTxLabelCmd)
+
+	txHash, err := chainhash.NewHashFromStr(cmd.TxID)
+
+	if err != nil {
+
+		return nil, &json.RPCError{
+			Code:    json.ErrRPCDecodeHexString,
+			Message: "Transaction hash string decode failed: " + err.Error(),
+		}
+	}
+
+	err = w.SetTxLabel(txHash, cmd.Label)
+
+	if err == wallet.ErrNoTx {
+
+		return nil, &ErrNoTransactionInfo
+	}
+	return nil, err
+}
+
 // getNewAddress handles a getnewaddress request by returning a new
 // address for an account.  If the account does not exist an appropiate
 // error is returned.
@@ -1041,6 +1155,20 @@ func getTransaction(
 		ret.Confirmations = int64(confirms(details.Block.Height, syncBlock.Height))
 	}
 
+	meta, err := w.TxMeta(txHash)
+
+	if err != nil {
+
+		return nil, err
+	}
+
+	if meta != nil {
+
+		ret.TxLabel = meta.Label
+		ret.Comment = meta.Comment
+		ret.To = meta.CommentTo
+	}
+
 	var (
 		debitTotal  util.Amount
 		creditTotal util.Amount // Excludes change
@@ -1115,6 +1243,7 @@ func getTransaction(
 
 		var address string
 		var accountName string
+		var label string
 		_, addrs, _, err := txscript.ExtractPkScriptAddrs(
 			details.MsgTx.TxOut[cred.Index].PkScript, w.ChainParams())
 
@@ -1122,6 +1251,7 @@ func getTransaction(
 
 			addr := addrs[0]
 			address = addr.EncodeAddress()
+			label, _ = w.AddressLabel(addr)
 			account, err := w.AccountOfAddress(addr)
 
 			if err == nil {
@@ -1144,6 +1274,7 @@ func getTransaction(
 			Category: credCat,
 			Amount:   cred.Amount.ToDUO(),
 			Vout:     cred.Index,
+			Label:    label,
 		})
 	}
 
@@ -1722,9 +1853,12 @@ func makeOutputs(
 // sendPairs creates and sends payment transactions.
 // It returns the transaction hash in string format upon success
 // All errors are returned in json.RPCError format
+// The comments in meta, if any, are recorded for the sent transaction.
 func sendPairs(
 	w *wallet.Wallet, amounts map[string]util.Amount,
-	account uint32, minconf int32, feeSatPerKb util.Amount) (string, error) {
+	account uint32, minconf int32, feeSatPerKb util.Amount,
+	meta *wmeta.TxMeta, strategy wallet.CoinSelectionStrategy,
+	replaceable bool) (string, error) {
 
 	outputs, err := makeOutputs(amounts, w.ChainParams())
 
@@ -1732,7 +1866,8 @@ func sendPairs(
 
 		return "", err
 	}
-	txHash, err := w.SendOutputs(outputs, account, minconf, feeSatPerKb)
+	txHash, err := w.SendOutputsWithStrategy(outputs, account, minconf,
+		feeSatPerKb, strategy, replaceable)
 
 	if err != nil {
 
@@ -1762,6 +1897,19 @@ func sendPairs(
 
 	log <- cl.Info{"successfully sent transaction", txHashStr}
 
+	// The transaction has already been sent, so a failure to record the
+	// comments is not reported as a failure of the send.
+	if meta != nil && !meta.IsEmpty() {
+
+		err = w.PutTxMeta(txHash, meta)
+
+		if err != nil {
+
+			log <- cl.Warn{"failed to record comments of transaction",
+				txHashStr, err}
+		}
+	}
+
 	return txHashStr, nil
 }
 func isNilOrEmpty(
@@ -1770,6 +1918,58 @@ func isNilOrEmpty(
 	return s == nil || *s == ""
 }
 
+// coinSelection returns the coin selection strategy named by an optional
+// parameter, or the strategy of the wallet when it is not passed.
+func coinSelection(
+	w *wallet.Wallet, name *string) (wallet.CoinSelectionStrategy, error) {
+
+	if isNilOrEmpty(name) {
+
+		return w.CoinSelection(), nil
+	}
+
+	strategy, err := wallet.ParseCoinSelectionStrategy(*name)
+
+	if err != nil {
+
+		return "", InvalidParameterError{err}
+	}
+
+	return strategy, nil
+}
+
+// replaceable returns whether a transaction signals replaceability as set by
+// an optional parameter, or as the wallet does when it is not passed.
+func replaceable(
+	w *wallet.Wallet, flag *bool) bool {
+
+	if flag == nil {
+
+		return w.Replaceable()
+	}
+
+	return *flag
+}
+
+// txComments returns the metadata holding the comment and comment to
+// parameters of a send request.
+func txComments(
+	comment, commentTo *string) *wmeta.TxMeta {
+
+	meta := new(wmeta.TxMeta)
+
+	if !isNilOrEmpty(comment) {
+
+		meta.Comment = *comment
+	}
+
+	if !isNilOrEmpty(commentTo) {
+
+		meta.CommentTo = *commentTo
+	}
+	return meta
+}
+
 // sendFrom handles a sendfrom RPC request by creating a new transaction
 // spending unspent transaction outputs for a wallet to another payment
 // address.  Leftover inputs not sent to the payment address or a fee for
@@ -1780,17 +1980,6 @@ func sendFrom(
 
 	cmd := icmd.(*json.SendFromCmd)
 
-	// Transaction comments are not yet supported.  Error instead of
-	// pretending to save them.
-
-	if !isNilOrEmpty(cmd.Comment) || !isNilOrEmpty(cmd.CommentTo) {
-
-		return nil, &json.RPCError{
-			Code:    json.ErrRPCUnimplemented,
-			Message: "Transaction comments are not yet supported",
-		}
-	}
-
 	account, err := w.AccountNumber(
 		waddrmgr.KeyScopeBIP0044, cmd.FromAccount,
 	)
@@ -1822,9 +2011,16 @@ func sendFrom(
 	pairs := map[string]util.Amount{
 		cmd.ToAddress: amt,
 	}
+	strategy, err := coinSelection(w, cmd.CoinSelection)
+
+	if err != nil {
+
+		return nil, err
+	}
 
 	return sendPairs(w, pairs, account, minConf,
-		txrules.DefaultRelayFeePerKb)
+		txrules.DefaultRelayFeePerKb, txComments(cmd.Comment, cmd.CommentTo),
+		strategy, replaceable(w, cmd.Replaceable))
 }
 
 // sendMany handles a sendmany RPC request by creating a new transaction
@@ -1837,17 +2033,6 @@ func sendMany(
 
 	cmd := icmd.(*json.SendManyCmd)
 
-	// Transaction comments are not yet supported.  Error instead of
-	// pretending to save them.
-
-	if !isNilOrEmpty(cmd.Comment) {
-
-		return nil, &json.RPCError{
-			Code:    json.ErrRPCUnimplemented,
-			Message: "Transaction comments are not yet supported",
-		}
-	}
-
 	account, err := w.AccountNumber(waddrmgr.KeyScopeBIP0044, cmd.FromAccount)
 
 	if err != nil {
@@ -1877,7 +2062,15 @@ func sendMany(
 		pairs[k] = amt
 	}
 
-	return sendPairs(w, pairs, account, minConf, txrules.DefaultRelayFeePerKb)
+	strategy, err := coinSelection(w, cmd.CoinSelection)
+
+	if err != nil {
+
+		return nil, err
+	}
+
+	return sendPairs(w, pairs, account, minConf, txrules.DefaultRelayFeePerKb,
+		txComments(cmd.Comment, nil), strategy, replaceable(w, cmd.Replaceable))
 }
 
 // sendToAddress handles a sendtoaddress RPC request by creating a new
@@ -1890,17 +2083,6 @@ func sendToAddress(
 
 	cmd := icmd.(*json.SendToAddressCmd)
 
-	// Transaction comments are not yet supported.  Error instead of
-	// pretending to save them.
-
-	if !isNilOrEmpty(cmd.Comment) || !isNilOrEmpty(cmd.CommentTo) {
-
-		return nil, &json.RPCError{
-			Code:    json.ErrRPCUnimplemented,
-			Message: "Transaction comments are not yet supported",
-		}
-	}
-
 	amt, err := util.NewAmount(cmd.Amount)
 
 	if err != nil {
@@ -1920,9 +2102,35 @@ func sendToAddress(
 		cmd.Address: amt,
 	}
 
+	strategy, err := coinSelection(w, cmd.CoinSelection)
+
+	if err != nil {
+
+		return nil, err
+	}
+
 	// sendtoaddress always spends from the default account, this matches bitcoind
 	return sendPairs(w, pairs, waddrmgr.DefaultAccountNum, 1,
-		txrules.DefaultRelayFeePerKb)
+		txrules.DefaultRelayFeePerKb, txComments(cmd.Comment, cmd.CommentTo),
+		strategy, replaceable(w, cmd.Replaceable))
+}
+
+// setLabel handles a setlabel request by setting the label of a payment
+// address.  The address does not have to belong to the wallet, so that payees
+// can be labeled as well.  An empty label removes the label.
+func setLabel(
+	icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+
+	cmd := icmd.(*json.SetLabelCmd)
+
+	addr, err := decodeAddress(cmd.Address, w.ChainParams())
+
+	if err != nil {
+
+		return nil, err
+	}
+
+	return nil, w.SetAddressLabel(addr, cmd.Label)
 }
 
 // setTxFee sets the transaction fee per kilobyte added to transactions.
@@ -1942,82 +2150,757 @@ func setTxFee(
 	return true, nil
 }
 
-// signMessage signs the given message with the private key for the given
-// address
-func signMessage(
+// bumpFee handles a bumpfee request by replacing an unconfirmed wallet
+// transaction with one paying the same outputs at a higher fee rate.
+func bumpFee(
 	icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 
-	cmd := icmd.(*json.SignMessageCmd)
+	cmd := icmd.(*json.BumpFeeCmd)
 
-	addr, err := decodeAddress(cmd.Address, w.ChainParams())
+	txHash, err := chainhash.NewHashFromStr(cmd.TxID)
 
 	if err != nil {
 
-		return nil, err
+		return nil, &json.RPCError{
+			Code:    json.ErrRPCDecodeHexString,
+			Message: "Transaction hash string decode failed: " + err.Error(),
+		}
 	}
 
-	privKey, err := w.PrivKeyForAddress(addr)
+	// A zero fee rate lets the wallet pick one just above the fee rate of
+	// the transaction being replaced.
+	var feeSatPerKb util.Amount
 
-	if err != nil {
+	if cmd.FeeRate != nil {
 
-		return nil, err
+		if *cmd.FeeRate <= 0 {
+
+			return nil, ErrNeedPositiveAmount
+		}
+
+		feeSatPerKb, err = util.NewAmount(*cmd.FeeRate)
+
+		if err != nil {
+
+			return nil, err
+		}
 	}
 
-	var buf bytes.Buffer
-	wire.WriteVarString(&buf, 0, "Bitcoin Signed Message:\n")
-	wire.WriteVarString(&buf, 0, cmd.Message)
-	messageHash := chainhash.DoubleHashB(buf.Bytes())
-	sigbytes, err := ec.SignCompact(ec.S256(), privKey,
-		messageHash, true)
+	replacement, origFee, fee, err := w.BumpFee(txHash, feeSatPerKb)
+
+	switch {
+
+	case err == wallet.ErrNoTx:
+		return nil, &ErrNoTransactionInfo
+
+	case waddrmgr.IsError(err, waddrmgr.ErrLocked):
+		return nil, &ErrWalletUnlockNeeded
+
+	case err != nil:
+		return nil, &json.RPCError{
+			Code:    json.ErrRPCWallet,
+			Message: err.Error(),
+		}
+	}
+
+	return &json.BumpFeeResult{
+		TxID:    replacement.String(),
+		OrigFee: origFee.ToDUO(),
+		Fee:     fee.ToDUO(),
+	}, nil
+}
+
+// combinePsbt handles a combinepsbt request by merging the input and output
+// information and the signatures of several PSBTs of the same transaction.
+func combinePsbt(
+	icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+
+	cmd := icmd.(*json.CombinePsbtCmd)
+
+	if len(cmd.Psbts) == 0 {
+
+		return nil, InvalidParameterError{errors.New("no PSBTs to combine")}
+	}
+
+	packets := make([]*psbt.Packet, len(cmd.Psbts))
+
+	for i, b64 := range cmd.Psbts {
+
+		p, err := decodePsbtStr(b64)
+
+		if err != nil {
+
+			return nil, err
+		}
+
+		packets[i] = p
+	}
+
+	combined, err := psbt.Combine(packets...)
 
 	if err != nil {
 
-		return nil, err
+		return nil, InvalidParameterError{err}
 	}
 
-	return base64.StdEncoding.EncodeToString(sigbytes), nil
+	return combined.B64Encode()
 }
 
-// signRawTransaction handles the signrawtransaction command.
-func signRawTransaction(
-	icmd interface{}, w *wallet.Wallet, chainClient *chain.RPCClient) (interface{}, error) {
+// decodePsbt handles a decodepsbt request by returning the unsigned
+// transaction of a PSBT and the information it holds about each input and
+// output.
+func decodePsbt(
+	icmd interface{}, w *wallet.Wallet) (interface{}, error) {
 
-	cmd := icmd.(*json.SignRawTransactionCmd)
+	cmd := icmd.(*json.DecodePsbtCmd)
 
-	serializedTx, err := decodeHexStr(cmd.RawTx)
+	p, err := decodePsbtStr(cmd.Psbt)
 
 	if err != nil {
 
 		return nil, err
 	}
-	var tx wire.MsgTx
-	err = tx.Deserialize(bytes.NewBuffer(serializedTx))
 
-	if err != nil {
+	params := w.ChainParams()
+	tx := p.UnsignedTx
 
-		e := errors.New("TX decode failed")
-		return nil, DeserializationError{e}
+	result := &json.DecodePsbtResult{
+		Tx:      psbtTxResult(tx, params),
+		Unknown: psbtUnknowns(p.Unknowns),
+		Inputs:  make([]json.DecodePsbtInput, len(p.Inputs)),
+		Outputs: make([]json.DecodePsbtOutput, len(p.Outputs)),
 	}
 
-	var hashType txscript.SigHashType
+	for i := range p.Inputs {
 
-	switch *cmd.Flags {
+		pInput := &p.Inputs[i]
+		input := &result.Inputs[i]
 
-	case "ALL":
-		hashType = txscript.SigHashAll
-	case "NONE":
-		hashType = txscript.SigHashNone
-	case "SINGLE":
-		hashType = txscript.SigHashSingle
-	case "ALL|ANYONECANPAY":
-		hashType = txscript.SigHashAll | txscript.SigHashAnyOneCanPay
-	case "NONE|ANYONECANPAY":
-		hashType = txscript.SigHashNone | txscript.SigHashAnyOneCanPay
-	case "SINGLE|ANYONECANPAY":
-		hashType = txscript.SigHashSingle | txscript.SigHashAnyOneCanPay
-	default:
-		e := errors.New("Invalid sighash parameter")
-		return nil, InvalidParameterError{e}
+		if pInput.NonWitnessUtxo != nil {
+
+			prevTx := psbtTxResult(pInput.NonWitnessUtxo, params)
+			input.NonWitnessUtxo = &prevTx
+		}
+
+		if pInput.WitnessUtxo != nil {
+
+			input.WitnessUtxo = &json.DecodePsbtWitnessUtxo{
+				Amount:       util.Amount(pInput.WitnessUtxo.Value).ToDUO(),
+				ScriptPubKey: psbtPkScriptResult(pInput.WitnessUtxo.PkScript, params),
+			}
+		}
+
+		if len(pInput.PartialSigs) != 0 {
+
+			input.PartialSignatures = make(map[string]string, len(pInput.PartialSigs))
+
+			for _, sig := range pInput.PartialSigs {
+
+				input.PartialSignatures[hex.EncodeToString(sig.PubKey)] =
+					hex.EncodeToString(sig.Signature)
+			}
+		}
+
+		if pInput.SighashType != 0 {
+
+			input.Sighash = sigHashTypeString(pInput.SighashType)
+		}
+
+		input.RedeemScript = psbtScriptResult(pInput.RedeemScript)
+		input.WitnessScript = psbtScriptResult(pInput.WitnessScript)
+		input.Bip32Derivs = psbtBip32Derivs(pInput.Bip32Derivation)
+
+		if pInput.FinalScriptSig != nil {
+
+			// The disassembled string will contain [error] inline if the
+			// script doesn't fully parse, so ignore the error here.
+			disbuf, _ := txscript.DisasmString(pInput.FinalScriptSig)
+			input.FinalScriptSig = &json.ScriptSig{
+				Asm: disbuf,
+				Hex: hex.EncodeToString(pInput.FinalScriptSig),
+			}
+		}
+
+		if pInput.FinalScriptWitness != nil {
+
+			input.FinalScriptWitness = psbtWitness(pInput.FinalScriptWitness)
+		}
+
+		if len(pInput.Unknowns) != 0 {
+
+			input.Unknown = psbtUnknowns(pInput.Unknowns)
+		}
+	}
+
+	for i := range p.Outputs {
+
+		pOutput := &p.Outputs[i]
+		output := &result.Outputs[i]
+		output.RedeemScript = psbtScriptResult(pOutput.RedeemScript)
+		output.WitnessScript = psbtScriptResult(pOutput.WitnessScript)
+		output.Bip32Derivs = psbtBip32Derivs(pOutput.Bip32Derivation)
+
+		if len(pOutput.Unknowns) != 0 {
+
+			output.Unknown = psbtUnknowns(pOutput.Unknowns)
+		}
+	}
+
+	if fee, err := p.Fee(); err == nil {
+
+		feeDUO := util.Amount(fee).ToDUO()
+		result.Fee = &feeDUO
+	}
+
+	return result, nil
+}
+
+// finalizePsbt handles a finalizepsbt request by building the final
+// signature scripts and witnesses of the inputs of a PSBT that have all the
+// signatures they need.  The network transaction is returned in place of the
+// PSBT when every input is finalized and extraction was not disabled.
+func finalizePsbt(
+	icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+
+	cmd := icmd.(*json.FinalizePsbtCmd)
+
+	p, err := decodePsbtStr(cmd.Psbt)
+
+	if err != nil {
+
+		return nil, err
+	}
+
+	// Inputs which are still missing signatures are left as they are.
+	for i := range p.Inputs {
+
+		psbt.MaybeFinalize(p, i)
+	}
+
+	complete := p.IsComplete()
+
+	if complete && *cmd.Extract {
+
+		tx, err := psbt.Extract(p)
+
+		if err != nil {
+
+			return nil, &json.RPCError{
+				Code:    json.ErrRPCWallet,
+				Message: err.Error(),
+			}
+		}
+
+		var buf bytes.Buffer
+		buf.Grow(tx.SerializeSize())
+
+		if err := tx.Serialize(&buf); err != nil {
+
+			return nil, err
+		}
+
+		return &json.FinalizePsbtResult{
+			Hex:      hex.EncodeToString(buf.Bytes()),
+			Complete: true,
+		}, nil
+	}
+
+	b64, err := p.B64Encode()
+
+	if err != nil {
+
+		return nil, err
+	}
+
+	return &json.FinalizePsbtResult{
+		Psbt:     b64,
+		Complete: complete,
+	}, nil
+}
+
+// walletCreateFundedPsbt handles a walletcreatefundedpsbt request by creating
+// a PSBT paying the requested outputs and funding it from the default
+// account.  Any inputs given are spent first, and more are added from the
+// wallet's unspent outputs if they do not cover the outputs and the fee.
+func walletCreateFundedPsbt(
+	icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+
+	cmd := icmd.(*json.WalletCreateFundedPsbtCmd)
+
+	// The inputs signal replaceability as the wallet does unless the
+	// options say otherwise, and those passed may set their own sequence.
+	var replaceableOpt *bool
+
+	if cmd.Options != nil {
+
+		replaceableOpt = cmd.Options.Replaceable
+	}
+
+	rbf := replaceable(w, replaceableOpt)
+	sequence := wire.MaxTxInSequenceNum
+
+	if rbf {
+
+		sequence = txauthor.ReplaceableSequence
+	}
+
+	inputs := make([]*wire.TxIn, 0, len(cmd.Inputs))
+
+	for _, input := range cmd.Inputs {
+
+		txHash, err := chainhash.NewHashFromStr(input.Txid)
+
+		if err != nil {
+
+			return nil, &json.RPCError{
+				Code:    json.ErrRPCDecodeHexString,
+				Message: "Transaction hash string decode failed: " + err.Error(),
+			}
+		}
+
+		txIn := wire.NewTxIn(wire.NewOutPoint(txHash, input.Vout), nil, nil)
+		txIn.Sequence = sequence
+
+		if input.Sequence != nil {
+
+			txIn.Sequence = *input.Sequence
+		}
+
+		inputs = append(inputs, txIn)
+	}
+
+	pairs := make(map[string]util.Amount, len(cmd.Outputs))
+
+	for addr, v := range cmd.Outputs {
+
+		amt, err := util.NewAmount(v)
+
+		if err != nil {
+
+			return nil, err
+		}
+
+		if amt <= 0 {
+
+			return nil, ErrNeedPositiveAmount
+		}
+
+		pairs[addr] = amt
+	}
+
+	outputs, err := makeOutputs(pairs, w.ChainParams())
+
+	if err != nil {
+
+		return nil, InvalidParameterError{err}
+	}
+
+	var lockTime uint32
+
+	if cmd.Locktime != nil {
+
+		lockTime = *cmd.Locktime
+	}
+
+	feeSatPerKb := txrules.DefaultRelayFeePerKb
+	var changeAddr util.Address
+	var lockUnspents bool
+
+	if opts := cmd.Options; opts != nil {
+
+		if opts.ChangeAddress != nil {
+
+			changeAddr, err = decodeAddress(*opts.ChangeAddress, w.ChainParams())
+
+			if err != nil {
+
+				return nil, err
+			}
+		}
+
+		if opts.FeeRate != nil {
+
+			if *opts.FeeRate <= 0 {
+
+				return nil, ErrNeedPositiveAmount
+			}
+
+			feeSatPerKb, err = util.NewAmount(*opts.FeeRate)
+
+			if err != nil {
+
+				return nil, err
+			}
+		}
+
+		if opts.LockUnspents != nil {
+
+			lockUnspents = *opts.LockUnspents
+		}
+	}
+
+	p, fee, changePos, err := w.FundPsbt(inputs, outputs, lockTime,
+		waddrmgr.DefaultAccountNum, 1, feeSatPerKb, changeAddr, lockUnspents,
+		rbf)
+
+	switch {
+
+	case waddrmgr.IsError(err, waddrmgr.ErrLocked):
+		return nil, &ErrWalletUnlockNeeded
+
+	case err != nil:
+		return nil, &json.RPCError{
+			Code:    json.ErrRPCWallet,
+			Message: err.Error(),
+		}
+	}
+
+	b64, err := p.B64Encode()
+
+	if err != nil {
+
+		return nil, err
+	}
+
+	return &json.WalletCreateFundedPsbtResult{
+		Psbt:      b64,
+		Fee:       fee.ToDUO(),
+		ChangePos: int64(changePos),
+	}, nil
+}
+
+// walletProcessPsbt handles a walletprocesspsbt request by adding what the
+// wallet knows about the inputs of a PSBT to it, and by signing and
+// finalizing the inputs it holds the keys for unless signing is disabled.
+func walletProcessPsbt(
+	icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+
+	cmd := icmd.(*json.WalletProcessPsbtCmd)
+
+	p, err := decodePsbtStr(cmd.Psbt)
+
+	if err != nil {
+
+		return nil, err
+	}
+
+	hashType, err := parseSigHashType(*cmd.SighashType)
+
+	if err != nil {
+
+		return nil, err
+	}
+
+	complete, err := w.ProcessPsbt(p, *cmd.Sign, hashType)
+
+	switch {
+
+	case waddrmgr.IsError(err, waddrmgr.ErrLocked):
+		return nil, &ErrWalletUnlockNeeded
+
+	case err != nil:
+		return nil, &json.RPCError{
+			Code:    json.ErrRPCWallet,
+			Message: err.Error(),
+		}
+	}
+
+	b64, err := p.B64Encode()
+
+	if err != nil {
+
+		return nil, err
+	}
+
+	return &json.WalletProcessPsbtResult{
+		Psbt:     b64,
+		Complete: complete,
+	}, nil
+}
+
+// decodePsbtStr parses a base64 encoded PSBT passed as an RPC parameter.
+func decodePsbtStr(
+	b64 string) (*psbt.Packet, error) {
+
+	p, err := psbt.NewFromRawBytes(strings.NewReader(b64), true)
+
+	if err != nil {
+
+		return nil, DeserializationError{
+			fmt.Errorf("PSBT decode failed: %v", err),
+		}
+	}
+
+	return p, nil
+}
+
+// psbtTxResult returns the decoded form of a transaction held by a PSBT.
+func psbtTxResult(
+	tx *wire.MsgTx, chainParams *chaincfg.Params) json.TxRawDecodeResult {
+
+	vin := make([]json.Vin, len(tx.TxIn))
+
+	for i, txIn := range tx.TxIn {
+
+		// The disassembled string will contain [error] inline if the script
+		// doesn't fully parse, so ignore the error here.
+		disbuf, _ := txscript.DisasmString(txIn.SignatureScript)
+		vin[i] = json.Vin{
+			Txid:     txIn.PreviousOutPoint.Hash.String(),
+			Vout:     txIn.PreviousOutPoint.Index,
+			Sequence: txIn.Sequence,
+			ScriptSig: &json.ScriptSig{
+				Asm: disbuf,
+				Hex: hex.EncodeToString(txIn.SignatureScript),
+			},
+		}
+	}
+
+	vout := make([]json.Vout, len(tx.TxOut))
+
+	for i, txOut := range tx.TxOut {
+
+		vout[i] = json.Vout{
+			Value:        util.Amount(txOut.Value).ToDUO(),
+			N:            uint32(i),
+			ScriptPubKey: psbtPkScriptResult(txOut.PkScript, chainParams),
+		}
+	}
+
+	return json.TxRawDecodeResult{
+		Txid:     tx.TxHash().String(),
+		Version:  tx.Version,
+		Locktime: tx.LockTime,
+		Vin:      vin,
+		Vout:     vout,
+	}
+}
+
+// psbtPkScriptResult returns the decoded form of an output script held by a
+// PSBT.
+func psbtPkScriptResult(
+	pkScript []byte, chainParams *chaincfg.Params) json.ScriptPubKeyResult {
+
+	// The disassembled string will contain [error] inline if the script
+	// doesn't fully parse, so ignore the error here.
+	disbuf, _ := txscript.DisasmString(pkScript)
+	// Ignore the error here since an error means the script couldn't parse
+	// and there is no additional information about it anyways.
+	class, addrs, reqSigs, _ := txscript.ExtractPkScriptAddrs(pkScript,
+		chainParams)
+	encodedAddrs := make([]string, len(addrs))
+
+	for i, addr := range addrs {
+
+		encodedAddrs[i] = addr.EncodeAddress()
+	}
+
+	return json.ScriptPubKeyResult{
+		Asm:       disbuf,
+		Hex:       hex.EncodeToString(pkScript),
+		ReqSigs:   int32(reqSigs),
+		Type:      class.String(),
+		Addresses: encodedAddrs,
+	}
+}
+
+// psbtScriptResult returns the decoded form of a redeem or witness script
+// held by a PSBT, or nil when there is no script.
+func psbtScriptResult(
+	script []byte) *json.DecodePsbtScript {
+
+	if script == nil {
+
+		return nil
+	}
+
+	disbuf, _ := txscript.DisasmString(script)
+	return &json.DecodePsbtScript{
+		Asm:  disbuf,
+		Hex:  hex.EncodeToString(script),
+		Type: txscript.GetScriptClass(script).String(),
+	}
+}
+
+// psbtBip32Derivs returns the decoded form of the key derivation paths held
+// by a PSBT, using ' to mark hardened path elements.
+func psbtBip32Derivs(
+	derivations []*psbt.Bip32Derivation) []json.DecodePsbtBip32Deriv {
+
+	var derivs []json.DecodePsbtBip32Deriv
+
+	for _, d := range derivations {
+
+		var fingerprint [4]byte
+		binary.LittleEndian.PutUint32(fingerprint[:], d.MasterKeyFingerprint)
+		path := "m"
+
+		for _, index := range d.Bip32Path {
+
+			if index >= hdkeychain.HardenedKeyStart {
+
+				path += fmt.Sprintf("/%d'", index-hdkeychain.HardenedKeyStart)
+				continue
+			}
+
+			path += fmt.Sprintf("/%d", index)
+		}
+
+		derivs = append(derivs, json.DecodePsbtBip32Deriv{
+			PubKey:            hex.EncodeToString(d.PubKey),
+			MasterFingerprint: hex.EncodeToString(fingerprint[:]),
+			Path:              path,
+		})
+	}
+
+	return derivs
+}
+
+// psbtWitness returns the hex encoded items of the serialized final witness
+// of a PSBT input.  The witness was checked when the PSBT was parsed.
+func psbtWitness(
+	serialized []byte) []string {
+
+	r := bytes.NewReader(serialized)
+	count, _ := wire.ReadVarInt(r, 0)
+	items := make([]string, 0, count)
+
+	for i := uint64(0); i < count; i++ {
+
+		item, err := wire.ReadVarBytes(r, 0, psbt.MaxPsbtValueLength,
+			"witness item")
+
+		if err != nil {
+
+			break
+		}
+
+		items = append(items, hex.EncodeToString(item))
+	}
+
+	return items
+}
+
+// psbtUnknowns returns the hex encoded keys and values of a PSBT map that
+// are not understood.
+func psbtUnknowns(
+	unknowns []*psbt.Unknown) map[string]string {
+
+	m := make(map[string]string, len(unknowns))
+
+	for _, u := range unknowns {
+
+		m[hex.EncodeToString(u.Key)] = hex.EncodeToString(u.Value)
+	}
+
+	return m
+}
+
+// signMessage signs the given message with the private key for the given
+// address
+func signMessage(
+	icmd interface{}, w *wallet.Wallet) (interface{}, error) {
+
+	cmd := icmd.(*json.SignMessageCmd)
+
+	addr, err := decodeAddress(cmd.Address, w.ChainParams())
+
+	if err != nil {
+
+		return nil, err
+	}
+
+	privKey, err := w.PrivKeyForAddress(addr)
+
+	if err != nil {
+
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	wire.WriteVarString(&buf, 0, "Bitcoin Signed Message:\n")
+	wire.WriteVarString(&buf, 0, cmd.Message)
+	messageHash := chainhash.DoubleHashB(buf.Bytes())
+	sigbytes, err := ec.SignCompact(ec.S256(), privKey,
+		messageHash, true)
+
+	if err != nil {
+
+		return nil, err
+	}
+
+	return base64.StdEncoding.EncodeToString(sigbytes), nil
+}
+
+// sigHashTypes maps the names of signature hash types accepted by RPC
+// requests to the types.
+var sigHashTypes = map[string]txscript.SigHashType{
+	"ALL":                 txscript.SigHashAll,
+	"NONE":                txscript.SigHashNone,
+	"SINGLE":              txscript.SigHashSingle,
+	"ALL|ANYONECANPAY":    txscript.SigHashAll | txscript.SigHashAnyOneCanPay,
+	"NONE|ANYONECANPAY":   txscript.SigHashNone | txscript.SigHashAnyOneCanPay,
+	"SINGLE|ANYONECANPAY": txscript.SigHashSingle | txscript.SigHashAnyOneCanPay,
+}
+
+// parseSigHashType returns the signature hash type named by an RPC
+// parameter.
+func parseSigHashType(
+	name string) (txscript.SigHashType, error) {
+
+	hashType, ok := sigHashTypes[name]
+
+	if !ok {
+
+		e := errors.New("Invalid sighash parameter")
+		return 0, InvalidParameterError{e}
+	}
+
+	return hashType, nil
+}
+
+// sigHashTypeString returns the RPC name of a signature hash type, or its
+// number if it has no name.
+func sigHashTypeString(
+	hashType txscript.SigHashType) string {
+
+	for name, t := range sigHashTypes {
+
+		if t == hashType {
+
+			return name
+		}
+	}
+
+	return fmt.Sprintf("%d", hashType)
+}
+
+// signRawTransaction handles the signrawtransaction command.
+func signRawTransaction(
+	icmd interface{}, w *wallet.Wallet, chainClient *chain.RPCClient) (interface{}, error) {
+
+	cmd := icmd.(*json.SignRawTransactionCmd)
+
+	serializedTx, err := decodeHexStr(cmd.RawTx)
+
+	if err != nil {
+
+		return nil, err
+	}
+	var tx wire.MsgTx
+	err = tx.Deserialize(bytes.NewBuffer(serializedTx))
+
+	if err != nil {
+
+		e := errors.New("TX decode failed")
+		return nil, DeserializationError{e}
+	}
+
+	hashType, err := parseSigHashType(*cmd.Flags)
+
+	if err != nil {
+
+		return nil, err
 	}
 
 	// TODO: really we probably should look these up with pod anyway to
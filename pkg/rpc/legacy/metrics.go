@@ -0,0 +1,26 @@
+package legacyrpc
+
+import (
+	"time"
+
+	"git.parallelcoin.io/dev/pod/pkg/rpc/json"
+	"git.parallelcoin.io/dev/pod/pkg/util/metrics"
+)
+
+// rpcRequestSeconds is the time taken to handle wallet RPC requests by
+// method.
+var rpcRequestSeconds = metrics.NewHistogram(
+	"pod_wallet_rpc_request_duration_seconds",
+	"Time taken to handle wallet RPC requests, by method", nil, "method")
+
+// observeRPCRequest adds the time taken to handle a request to the RPC
+// latency metrics.  Methods that are not registered are counted together
+// so clients can't create any number of series.
+func observeRPCRequest(method string, elapsed time.Duration) {
+
+	if _, err := json.MethodUsageFlags(method); err != nil {
+
+		method = "unknown"
+	}
+	rpcRequestSeconds.Observe(elapsed.Seconds(), method)
+}
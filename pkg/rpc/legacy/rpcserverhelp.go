@@ -6,8 +6,12 @@ func helpDescsEnUS() map[string]string {
 
 	return map[string]string{
 		"addmultisigaddress":      "addmultisigaddress nrequired [\"key\",...] (\"account\")\n\nGenerates and imports a multisig address and redeeming script to the 'imported' account.\n\nArguments:\n1. nrequired (numeric, required)         The number of signatures required to redeem outputs paid to this address\n2. keys      (array of string, required) Pubkeys and/or pay-to-pubkey-hash addresses to partially control the multisig address\n3. account   (string, optional)          DEPRECATED -- Unused (all imported addresses belong to the imported account)\n\nResult:\n\"value\" (string) The imported pay-to-script-hash address\n",
+		"bumpfee":                 "bumpfee \"txid\" (feerate)\n\nReplaces an unconfirmed wallet transaction that signals replaceability (BIP 125) with one paying the same outputs at a higher fee.\nAny further inputs the higher fee requires are taken from confirmed outputs of the account the original transaction returned change to.\n\nArguments:\n1. txid    (string, required)  The hash of the transaction to replace\n2. feerate (numeric, optional) The fee rate of the replacement valued in bitcoin per kilobyte (default=the fee rate of the original transaction plus the relay fee)\n\nResult:\n{\n \"txid\": \"value\",  (string)  The hash of the replacement transaction\n \"origfee\": n.nnn, (numeric) The fee of the replaced transaction valued in bitcoin\n \"fee\": n.nnn,     (numeric) The fee of the replacement transaction valued in bitcoin\n}                  \n",
+		"combinepsbt":             "combinepsbt [\"psbt\",...]\n\nCombines several partially signed transactions (BIP 174) of the same transaction into one holding the signatures and input and output information of all of them.\n\nArguments:\n1. psbts (array of string, required) The base64 encoded partially signed transactions to combine\n\nResult:\n\"value\" (string) The combined partially signed transaction encoded as a base64 string\n",
 		"createmultisig":          "createmultisig nrequired [\"key\",...]\n\nGenerate a multisig address and redeem script.\n\nArguments:\n1. nrequired (numeric, required)         The number of signatures required to redeem outputs paid to this address\n2. keys      (array of string, required) Pubkeys and/or pay-to-pubkey-hash addresses to partially control the multisig address\n\nResult:\n{\n \"address\": \"value\",      (string) The generated pay-to-script-hash address\n \"redeemScript\": \"value\", (string) The script required to redeem outputs paid to the multisig address\n}                         \n",
+		"decodepsbt":              "decodepsbt \"psbt\"\n\nReturns a JSON object describing a partially signed transaction (BIP 174).\n\nArguments:\n1. psbt (string, required) The partially signed transaction encoded as a base64 string\n\nResult:\n{\n \"tx\": {                         (object)          The unsigned transaction as a JSON object\n  \"txid\": \"value\",               (string)          The hash of the transaction\n  \"version\": n,                  (numeric)         The transaction version\n  \"locktime\": n,                 (numeric)         The transaction lock time\n  \"vin\": [{                      (array of object) The transaction inputs as JSON objects\n   \"coinbase\": \"value\",          (string)          The hex-encoded bytes of the signature script (coinbase txns only)\n   \"txid\": \"value\",              (string)          The hash of the origin transaction (non-coinbase txns only)\n   \"vout\": n,                    (numeric)         The index of the output being redeemed from the origin transaction (non-coinbase txns only)\n   \"scriptSig\": {                (object)          The signature script used to redeem the origin transaction as a JSON object (non-coinbase txns only)\n    \"asm\": \"value\",              (string)          Disassembly of the script\n    \"hex\": \"value\",              (string)          Hex-encoded bytes of the script\n   },                                              \n   \"sequence\": n,                (numeric)         The script sequence number\n   \"txinwitness\": [\"value\",...], (array of string) The witness used to redeem the input encoded as a string array of its items\n  },...],                                          \n  \"vout\": [{                     (array of object) The transaction outputs as JSON objects\n   \"value\": n.nnn,               (numeric)         The amount in DUO\n   \"n\": n,                       (numeric)         The index of this transaction output\n   \"scriptPubKey\": {             (object)          The public key script used to pay coins as a JSON object\n    \"asm\": \"value\",              (string)          Disassembly of the script\n    \"hex\": \"value\",              (string)          Hex-encoded bytes of the script\n    \"reqSigs\": n,                (numeric)         The number of required signatures\n    \"type\": \"value\",             (string)          The type of the script (e.g. 'pubkeyhash')\n    \"addresses\": [\"value\",...],  (array of string) The bitcoin addresses associated with this script\n   },                                              \n  },...],                                          \n },                                                \n \"unknown\": {                    (object)          The hex-encoded keys and values of the global map that are not understood\n  \"The hex-encoded key\": The hex-encoded value, (object) JSON object using hex-encoded keys of the global map as keys and their hex-encoded values as values\n  ...\n }\n \"inputs\": [{                     (array of object) The information held about each input\n  \"non_witness_utxo\": {           (object)          The transaction whose output the input spends\n   \"txid\": \"value\",               (string)          The hash of the transaction\n   \"version\": n,                  (numeric)         The transaction version\n   \"locktime\": n,                 (numeric)         The transaction lock time\n   \"vin\": [{                      (array of object) The transaction inputs as JSON objects\n    \"coinbase\": \"value\",          (string)          The hex-encoded bytes of the signature script (coinbase txns only)\n    \"txid\": \"value\",              (string)          The hash of the origin transaction (non-coinbase txns only)\n    \"vout\": n,                    (numeric)         The index of the output being redeemed from the origin transaction (non-coinbase txns only)\n    \"scriptSig\": {                (object)          The signature script used to redeem the origin transaction as a JSON object (non-coinbase txns only)\n     \"asm\": \"value\",              (string)          Disassembly of the script\n     \"hex\": \"value\",              (string)          Hex-encoded bytes of the script\n    },                                              \n    \"sequence\": n,                (numeric)         The script sequence number\n    \"txinwitness\": [\"value\",...], (array of string) The witness used to redeem the input encoded as a string array of its items\n   },...],                                          \n   \"vout\": [{                     (array of object) The transaction outputs as JSON objects\n    \"value\": n.nnn,               (numeric)         The amount in DUO\n    \"n\": n,                       (numeric)         The index of this transaction output\n    \"scriptPubKey\": {             (object)          The public key script used to pay coins as a JSON object\n     \"asm\": \"value\",              (string)          Disassembly of the script\n     \"hex\": \"value\",              (string)          Hex-encoded bytes of the script\n     \"reqSigs\": n,                (numeric)         The number of required signatures\n     \"type\": \"value\",             (string)          The type of the script (e.g. 'pubkeyhash')\n     \"addresses\": [\"value\",...],  (array of string) The bitcoin addresses associated with this script\n    },                                              \n   },...],                                          \n  },                                                \n  \"witness_utxo\": {               (object)          The output spent by a segregated witness input\n   \"amount\": n.nnn,               (numeric)         The value of the output valued in bitcoin\n   \"scriptPubKey\": {              (object)          The public key script of the output as a JSON object\n    \"asm\": \"value\",               (string)          Disassembly of the script\n    \"hex\": \"value\",               (string)          Hex-encoded bytes of the script\n    \"reqSigs\": n,                 (numeric)         The number of required signatures\n    \"type\": \"value\",              (string)          The type of the script (e.g. 'pubkeyhash')\n    \"addresses\": [\"value\",...],   (array of string) The bitcoin addresses associated with this script\n   },                                               \n  },                                                \n  \"partial_signatures\": {         (object)          The hex-encoded signatures of the input keyed by the hex-encoded public keys they verify with\n   \"The hex-encoded public key\": The hex-encoded signature, (object) JSON object using hex-encoded public keys as keys and hex-encoded signatures as values\n   ...\n  }\n  \"sighash\": \"value\",                   (string)          The sighash flags signers of the input must use\n  \"redeem_script\": {                    (object)          The redeem script of a pay-to-script-hash input\n   \"asm\": \"value\",                      (string)          Disassembly of the script\n   \"hex\": \"value\",                      (string)          Hex-encoded bytes of the script\n   \"type\": \"value\",                     (string)          The type of the script (e.g. 'multisig')\n  },                                                      \n  \"witness_script\": {                   (object)          The witness script of a pay-to-witness-script-hash input\n   \"asm\": \"value\",                      (string)          Disassembly of the script\n   \"hex\": \"value\",                      (string)          Hex-encoded bytes of the script\n   \"type\": \"value\",                     (string)          The type of the script (e.g. 'multisig')\n  },                                                      \n  \"bip32_derivs\": [{                    (array of object) The derivation paths of the public keys of the input\n   \"pubkey\": \"value\",                   (string)          The hex-encoded public key\n   \"master_fingerprint\": \"value\",       (string)          The hex-encoded fingerprint of the master key the public key derives from\n   \"path\": \"value\",                     (string)          The derivation path of the public key, with ' marking hardened steps\n  },...],                                                 \n  \"final_scriptSig\": {                  (object)          The final signature script of a finalized input\n   \"asm\": \"value\",                      (string)          Disassembly of the script\n   \"hex\": \"value\",                      (string)          Hex-encoded bytes of the script\n  },                                                      \n  \"final_scriptwitness\": [\"value\",...], (array of string) The hex-encoded items of the final witness of a finalized input\n  \"unknown\": {                          (object)          The hex-encoded keys and values of the input map that are not understood\n   \"The hex-encoded key\": The hex-encoded value, (object) JSON object using hex-encoded keys of the input map as keys and their hex-encoded values as values\n   ...\n  }\n },...],                                            \n \"outputs\": [{                    (array of object) The information held about each output\n  \"redeem_script\": {              (object)          The redeem script of a pay-to-script-hash output\n   \"asm\": \"value\",                (string)          Disassembly of the script\n   \"hex\": \"value\",                (string)          Hex-encoded bytes of the script\n   \"type\": \"value\",               (string)          The type of the script (e.g. 'multisig')\n  },                                                \n  \"witness_script\": {             (object)          The witness script of a pay-to-witness-script-hash output\n   \"asm\": \"value\",                (string)          Disassembly of the script\n   \"hex\": \"value\",                (string)          Hex-encoded bytes of the script\n   \"type\": \"value\",               (string)          The type of the script (e.g. 'multisig')\n  },                                                \n  \"bip32_derivs\": [{              (array of object) The derivation paths of the public keys of the output\n   \"pubkey\": \"value\",             (string)          The hex-encoded public key\n   \"master_fingerprint\": \"value\", (string)          The hex-encoded fingerprint of the master key the public key derives from\n   \"path\": \"value\",               (string)          The derivation path of the public key, with ' marking hardened steps\n  },...],                                           \n  \"unknown\": {                    (object)          The hex-encoded keys and values of the output map that are not understood\n   \"The hex-encoded key\": The hex-encoded value, (object) JSON object using hex-encoded keys of the output map as keys and their hex-encoded values as values\n   ...\n  }\n },...],                 \n \"fee\": n.nnn, (numeric) The fee of the transaction valued in bitcoin (only when the outputs spent by all inputs are known)\n}              \n",
 		"dumpprivkey":             "dumpprivkey \"address\"\n\nReturns the private key in WIF encoding that controls some wallet address.\n\nArguments:\n1. address (string, required) The address to return a private key for\n\nResult:\n\"value\" (string) The WIF-encoded private key\n",
+		"finalizepsbt":            "finalizepsbt \"psbt\" (extract=true)\n\nBuilds the final signature scripts and witnesses of the inputs of a partially signed transaction (BIP 174) that hold all the signatures they need.\nWhen every input is finalized the network transaction is returned in place of the partially signed transaction, unless extract is false.\n\nArguments:\n1. psbt    (string, required)                The partially signed transaction encoded as a base64 string\n2. extract (boolean, optional, default=true) Return the network transaction encoded as a hexadecimal string when every input is finalized\n\nResult:\n{\n \"psbt\": \"value\",        (string)  The partially signed transaction encoded as a base64 string (unless the network transaction is returned)\n \"hex\": \"value\",         (string)  The network transaction encoded as a hexadecimal string (only when complete and extracted)\n \"complete\": true|false, (boolean) Whether every input of the transaction is finalized\n}                        \n",
 		"getaccount":              "getaccount \"address\"\n\nDEPRECATED -- Lookup the account name that some wallet address belongs to.\n\nArguments:\n1. address (string, required) The address to query the account for\n\nResult:\n\"value\" (string) The name of the account that 'address' belongs to\n",
 		"getaccountaddress":       "getaccountaddress \"account\"\n\nDEPRECATED -- Returns the most recent external payment address for an account that has not been seen publicly.\nA new address is generated for the account if the most recently generated address has been seen on the blockchain or in mempool.\n\nArguments:\n1. account (string, required) The account of the returned address\n\nResult:\n\"value\" (string) The unused address for 'account'\n",
 		"getaddressesbyaccount":   "getaddressesbyaccount \"account\"\n\nDEPRECATED -- Returns all addresses strings controlled by a single account.\n\nArguments:\n1. account (string, required) Account name to fetch addresses for\n\nResult:\n[\"value\",...] (array of string) All addresses controlled by 'account'\n",
@@ -19,7 +23,7 @@ func helpDescsEnUS() map[string]string {
 		"getrawchangeaddress":     "getrawchangeaddress (\"account\")\n\nGenerates and returns a new internal payment address for use as a change address in raw transactions.\n\nArguments:\n1. account (string, optional) Account name the new internal address will belong to (default=\"default\")\n\nResult:\n\"value\" (string) The internal payment address\n",
 		"getreceivedbyaccount":    "getreceivedbyaccount \"account\" (minconf=1)\n\nDEPRECATED -- Returns the total amount received by addresses of some account, including spent outputs.\n\nArguments:\n1. account (string, required)             Account name to query total received amount for\n2. minconf (numeric, optional, default=1) Minimum number of block confirmations required before an output's value is included in the total\n\nResult:\nn.nnn (numeric) The total received amount valued in bitcoin\n",
 		"getreceivedbyaddress":    "getreceivedbyaddress \"address\" (minconf=1)\n\nReturns the total amount received by a single address, including spent outputs.\n\nArguments:\n1. address (string, required)             Payment address which received outputs to include in total\n2. minconf (numeric, optional, default=1) Minimum number of block confirmations required before an output's value is included in the total\n\nResult:\nn.nnn (numeric) The total received amount valued in bitcoin\n",
-		"gettransaction":          "gettransaction \"txid\" (includewatchonly=false)\n\nReturns a JSON object with details regarding a transaction relevant to this wallet.\n\nArguments:\n1. txid             (string, required)                 Hash of the transaction to query\n2. includewatchonly (boolean, optional, default=false) Also consider transactions involving watched addresses\n\nResult:\n{\n \"amount\": n.nnn,                  (numeric)         The total amount this transaction credits to the wallet, valued in bitcoin\n \"fee\": n.nnn,                     (numeric)         The total input value minus the total output value, or 0 if 'txid' is not a sent transaction\n \"confirmations\": n,               (numeric)         The number of block confirmations of the transaction\n \"blockhash\": \"value\",             (string)          The hash of the block this transaction is mined in, or the empty string if unmined\n \"blockindex\": n,                  (numeric)         Unset\n \"blocktime\": n,                   (numeric)         The Unix time of the block header this transaction is mined in, or 0 if unmined\n \"txid\": \"value\",                  (string)          The transaction hash\n \"walletconflicts\": [\"value\",...], (array of string) Unset\n \"time\": n,                        (numeric)         The earliest Unix time this transaction was known to exist\n \"timereceived\": n,                (numeric)         The earliest Unix time this transaction was known to exist\n \"details\": [{                     (array of object) Additional details for each recorded wallet credit and debit\n  \"account\": \"value\",              (string)          DEPRECATED -- Unset\n  \"address\": \"value\",              (string)          The address an output was paid to, or the empty string if the output is nonstandard or this detail is regarding a transaction input\n  \"amount\": n.nnn,                 (numeric)         The amount of a received output\n  \"category\": \"value\",             (string)          The kind of detail: \"send\" for sent transactions, \"immature\" for immature coinbase outputs, \"generate\" for mature coinbase outputs, or \"recv\" for all other received outputs\n  \"involveswatchonly\": true|false, (boolean)         Unset\n  \"fee\": n.nnn,                    (numeric)         The included fee for a sent transaction\n  \"vout\": n,                       (numeric)         The transaction output index\n },...],                                             \n \"hex\": \"value\",                   (string)          The transaction encoded as a hexadecimal string\n}                                  \n",
+		"gettransaction":          "gettransaction \"txid\" (includewatchonly=false)\n\nReturns a JSON object with details regarding a transaction relevant to this wallet.\n\nArguments:\n1. txid             (string, required)                 Hash of the transaction to query\n2. includewatchonly (boolean, optional, default=false) Also consider transactions involving watched addresses\n\nResult:\n{\n \"amount\": n.nnn,                  (numeric)         The total amount this transaction credits to the wallet, valued in bitcoin\n \"fee\": n.nnn,                     (numeric)         The total input value minus the total output value, or 0 if 'txid' is not a sent transaction\n \"confirmations\": n,               (numeric)         The number of block confirmations of the transaction\n \"blockhash\": \"value\",             (string)          The hash of the block this transaction is mined in, or the empty string if unmined\n \"blockindex\": n,                  (numeric)         Unset\n \"blocktime\": n,                   (numeric)         The Unix time of the block header this transaction is mined in, or 0 if unmined\n \"txid\": \"value\",                  (string)          The transaction hash\n \"walletconflicts\": [\"value\",...], (array of string) Unset\n \"time\": n,                        (numeric)         The earliest Unix time this transaction was known to exist\n \"timereceived\": n,                (numeric)         The earliest Unix time this transaction was known to exist\n \"details\": [{                     (array of object) Additional details for each recorded wallet credit and debit\n  \"account\": \"value\",              (string)          DEPRECATED -- Unset\n  \"address\": \"value\",              (string)          The address an output was paid to, or the empty string if the output is nonstandard or this detail is regarding a transaction input\n  \"amount\": n.nnn,                 (numeric)         The amount of a received output\n  \"category\": \"value\",             (string)          The kind of detail: \"send\" for sent transactions, \"immature\" for immature coinbase outputs, \"generate\" for mature coinbase outputs, or \"recv\" for all other received outputs\n  \"involveswatchonly\": true|false, (boolean)         Unset\n  \"fee\": n.nnn,                    (numeric)         The included fee for a sent transaction\n  \"vout\": n,                       (numeric)         The transaction output index\n  \"label\": \"value\",                (string)          The label of the payment address\n },...],                                             \n \"hex\": \"value\",                   (string)          The transaction encoded as a hexadecimal string\n \"txlabel\": \"value\",               (string)          The label of the transaction\n \"comment\": \"value\",               (string)          The comment recorded for the transaction\n \"to\": \"value\",                    (string)          The name of the payee recorded for the transaction\n}                                  \n",
 		"help":                    "help (\"command\")\n\nReturns a list of all commands or help for a specified command.\n\nArguments:\n1. command (string, optional) The command to retrieve help for\n\nResult (no command provided):\n\"value\" (string) List of commands\n\nResult (command specified):\n\"value\" (string) Help for specified command\n",
 		"importprivkey":           "importprivkey \"privkey\" (\"label\" rescan=true)\n\nImports a WIF-encoded private key to the 'imported' account.\n\nArguments:\n1. privkey (string, required)                The WIF-encoded private key\n2. label   (string, optional)                Unused (must be unset or 'imported')\n3. rescan  (boolean, optional, default=true) Rescan the blockchain (since the genesis block) for outputs controlled by the imported key\n\nResult:\nNothing\n",
 		"keypoolrefill":           "keypoolrefill (newsize=100)\n\nDEPRECATED -- This request does nothing since no keypool is maintained.\n\nArguments:\n1. newsize (numeric, optional, default=100) Unused\n\nResult:\nNothing\n",
@@ -27,28 +31,34 @@ func helpDescsEnUS() map[string]string {
 		"listlockunspent":         "listlockunspent\n\nReturns a JSON array of outpoints marked as locked (with lockunspent) for this wallet session.\n\nArguments:\nNone\n\nResult:\n[{\n \"txid\": \"value\", (string)  The transaction hash of the referenced output\n \"vout\": n,       (numeric) The output index of the referenced output\n},...]\n",
 		"listreceivedbyaccount":   "listreceivedbyaccount (minconf=1 includeempty=false includewatchonly=false)\n\nDEPRECATED -- Returns a JSON array of objects listing all accounts and the total amount received by each account.\n\nArguments:\n1. minconf          (numeric, optional, default=1)     Minimum number of block confirmations required before a transaction is considered\n2. includeempty     (boolean, optional, default=false) Unused\n3. includewatchonly (boolean, optional, default=false) Unused\n\nResult:\n[{\n \"account\": \"value\", (string)  The name of the account\n \"amount\": n.nnn,    (numeric) Total amount received by payment addresses of the account valued in bitcoin\n \"confirmations\": n, (numeric) Number of block confirmations of the most recent transaction relevant to the account\n},...]\n",
 		"listreceivedbyaddress":   "listreceivedbyaddress (minconf=1 includeempty=false includewatchonly=false)\n\nReturns a JSON array of objects listing wallet payment addresses and their total received amounts.\n\nArguments:\n1. minconf          (numeric, optional, default=1)     Minimum number of block confirmations required before a transaction is considered\n2. includeempty     (boolean, optional, default=false) Unused\n3. includewatchonly (boolean, optional, default=false) Unused\n\nResult:\n[{\n \"account\": \"value\",              (string)          DEPRECATED -- Unset\n \"address\": \"value\",              (string)          The payment address\n \"amount\": n.nnn,                 (numeric)         Total amount received by the payment address valued in bitcoin\n \"confirmations\": n,              (numeric)         Number of block confirmations of the most recent transaction relevant to the address\n \"txids\": [\"value\",...],          (array of string) Transaction hashes of all transactions involving this address\n \"involvesWatchonly\": true|false, (boolean)         Unset\n},...]\n",
-		"listsinceblock":          "listsinceblock (\"blockhash\" targetconfirmations=1 includewatchonly=false)\n\nReturns a JSON array of objects listing details of all wallet transactions after some block.\n\nArguments:\n1. blockhash           (string, optional)                 Hash of the parent block of the first block to consider transactions from, or unset to list all transactions\n2. targetconfirmations (numeric, optional, default=1)     Minimum number of block confirmations of the last block in the result object.  Must be 1 or greater.  Note: The transactions array in the result object is not affected by this parameter\n3. includewatchonly    (boolean, optional, default=false) Unused\n\nResult:\n{\n \"transactions\": [{                 (array of object) JSON array of objects containing verbose details of the each transaction\n  \"abandoned\": true|false,          (boolean)         Unset\n  \"account\": \"value\",               (string)          DEPRECATED -- Unset\n  \"address\": \"value\",               (string)          Payment address for a transaction output\n  \"amount\": n.nnn,                  (numeric)         The value of the transaction output valued in bitcoin\n  \"bip125-replaceable\": \"value\",    (string)          Unset\n  \"blockhash\": \"value\",             (string)          The hash of the block this transaction is mined in, or the empty string if unmined\n  \"blockindex\": n,                  (numeric)         Unset\n  \"blocktime\": n,                   (numeric)         The Unix time of the block header this transaction is mined in, or 0 if unmined\n  \"category\": \"value\",              (string)          The kind of transaction: \"send\" for sent transactions, \"immature\" for immature coinbase outputs, \"generate\" for mature coinbase outputs, or \"recv\" for all other received outputs.  Note: A single output may be included multiple times under different categories\n  \"confirmations\": n,               (numeric)         The number of block confirmations of the transaction\n  \"fee\": n.nnn,                     (numeric)         The total input value minus the total output value for sent transactions\n  \"generated\": true|false,          (boolean)         Whether the transaction output is a coinbase output\n  \"involveswatchonly\": true|false,  (boolean)         Unset\n  \"time\": n,                        (numeric)         The earliest Unix time this transaction was known to exist\n  \"timereceived\": n,                (numeric)         The earliest Unix time this transaction was known to exist\n  \"trusted\": true|false,            (boolean)         Unset\n  \"txid\": \"value\",                  (string)          The hash of the transaction\n  \"vout\": n,                        (numeric)         The transaction output index\n  \"walletconflicts\": [\"value\",...], (array of string) Unset\n  \"comment\": \"value\",               (string)          Unset\n  \"otheraccount\": \"value\",          (string)          Unset\n },...],                                              \n \"lastblock\": \"value\",              (string)          Hash of the latest-synced block to be used in later calls to listsinceblock\n}                                   \n",
-		"listtransactions":        "listtransactions (\"account\" count=10 from=0 includewatchonly=false)\n\nReturns a JSON array of objects containing verbose details for wallet transactions.\n\nArguments:\n1. account          (string, optional)                 DEPRECATED -- Unused (must be unset or \"*\")\n2. count            (numeric, optional, default=10)    Maximum number of transactions to create results from\n3. from             (numeric, optional, default=0)     Number of transactions to skip before results are created\n4. includewatchonly (boolean, optional, default=false) Unused\n\nResult:\n[{\n \"abandoned\": true|false,          (boolean)         Unset\n \"account\": \"value\",               (string)          DEPRECATED -- Unset\n \"address\": \"value\",               (string)          Payment address for a transaction output\n \"amount\": n.nnn,                  (numeric)         The value of the transaction output valued in bitcoin\n \"bip125-replaceable\": \"value\",    (string)          Unset\n \"blockhash\": \"value\",             (string)          The hash of the block this transaction is mined in, or the empty string if unmined\n \"blockindex\": n,                  (numeric)         Unset\n \"blocktime\": n,                   (numeric)         The Unix time of the block header this transaction is mined in, or 0 if unmined\n \"category\": \"value\",              (string)          The kind of transaction: \"send\" for sent transactions, \"immature\" for immature coinbase outputs, \"generate\" for mature coinbase outputs, or \"recv\" for all other received outputs.  Note: A single output may be included multiple times under different categories\n \"confirmations\": n,               (numeric)         The number of block confirmations of the transaction\n \"fee\": n.nnn,                     (numeric)         The total input value minus the total output value for sent transactions\n \"generated\": true|false,          (boolean)         Whether the transaction output is a coinbase output\n \"involveswatchonly\": true|false,  (boolean)         Unset\n \"time\": n,                        (numeric)         The earliest Unix time this transaction was known to exist\n \"timereceived\": n,                (numeric)         The earliest Unix time this transaction was known to exist\n \"trusted\": true|false,            (boolean)         Unset\n \"txid\": \"value\",                  (string)          The hash of the transaction\n \"vout\": n,                        (numeric)         The transaction output index\n \"walletconflicts\": [\"value\",...], (array of string) Unset\n \"comment\": \"value\",               (string)          Unset\n \"otheraccount\": \"value\",          (string)          Unset\n},...]\n",
+		"listsinceblock":          "listsinceblock (\"blockhash\" targetconfirmations=1 includewatchonly=false)\n\nReturns a JSON array of objects listing details of all wallet transactions after some block.\n\nArguments:\n1. blockhash           (string, optional)                 Hash of the parent block of the first block to consider transactions from, or unset to list all transactions\n2. targetconfirmations (numeric, optional, default=1)     Minimum number of block confirmations of the last block in the result object.  Must be 1 or greater.  Note: The transactions array in the result object is not affected by this parameter\n3. includewatchonly    (boolean, optional, default=false) Unused\n\nResult:\n{\n \"transactions\": [{                 (array of object) JSON array of objects containing verbose details of the each transaction\n  \"abandoned\": true|false,          (boolean)         Unset\n  \"account\": \"value\",               (string)          DEPRECATED -- Unset\n  \"address\": \"value\",               (string)          Payment address for a transaction output\n  \"amount\": n.nnn,                  (numeric)         The value of the transaction output valued in bitcoin\n  \"bip125-replaceable\": \"value\",    (string)          Unset\n  \"blockhash\": \"value\",             (string)          The hash of the block this transaction is mined in, or the empty string if unmined\n  \"blockindex\": n,                  (numeric)         Unset\n  \"blocktime\": n,                   (numeric)         The Unix time of the block header this transaction is mined in, or 0 if unmined\n  \"category\": \"value\",              (string)          The kind of transaction: \"send\" for sent transactions, \"immature\" for immature coinbase outputs, \"generate\" for mature coinbase outputs, or \"recv\" for all other received outputs.  Note: A single output may be included multiple times under different categories\n  \"confirmations\": n,               (numeric)         The number of block confirmations of the transaction\n  \"fee\": n.nnn,                     (numeric)         The total input value minus the total output value for sent transactions\n  \"generated\": true|false,          (boolean)         Whether the transaction output is a coinbase output\n  \"involveswatchonly\": true|false,  (boolean)         Unset\n  \"time\": n,                        (numeric)         The earliest Unix time this transaction was known to exist\n  \"timereceived\": n,                (numeric)         The earliest Unix time this transaction was known to exist\n  \"trusted\": true|false,            (boolean)         Unset\n  \"txid\": \"value\",                  (string)          The hash of the transaction\n  \"vout\": n,                        (numeric)         The transaction output index\n  \"walletconflicts\": [\"value\",...], (array of string) Unset\n  \"comment\": \"value\",               (string)          The comment recorded for the transaction\n  \"otheraccount\": \"value\",          (string)          Unset\n  \"label\": \"value\",                 (string)          The label of the payment address\n  \"txlabel\": \"value\",               (string)          The label of the transaction\n  \"to\": \"value\",                    (string)          The name of the payee recorded for the transaction\n },...],                                              \n \"lastblock\": \"value\",              (string)          Hash of the latest-synced block to be used in later calls to listsinceblock\n}                                   \n",
+		"listtransactions":        "listtransactions (\"account\" count=10 from=0 includewatchonly=false)\n\nReturns a JSON array of objects containing verbose details for wallet transactions.\n\nArguments:\n1. account          (string, optional)                 DEPRECATED -- Unused (must be unset or \"*\")\n2. count            (numeric, optional, default=10)    Maximum number of transactions to create results from\n3. from             (numeric, optional, default=0)     Number of transactions to skip before results are created\n4. includewatchonly (boolean, optional, default=false) Unused\n\nResult:\n[{\n \"abandoned\": true|false,          (boolean)         Unset\n \"account\": \"value\",               (string)          DEPRECATED -- Unset\n \"address\": \"value\",               (string)          Payment address for a transaction output\n \"amount\": n.nnn,                  (numeric)         The value of the transaction output valued in bitcoin\n \"bip125-replaceable\": \"value\",    (string)          Unset\n \"blockhash\": \"value\",             (string)          The hash of the block this transaction is mined in, or the empty string if unmined\n \"blockindex\": n,                  (numeric)         Unset\n \"blocktime\": n,                   (numeric)         The Unix time of the block header this transaction is mined in, or 0 if unmined\n \"category\": \"value\",              (string)          The kind of transaction: \"send\" for sent transactions, \"immature\" for immature coinbase outputs, \"generate\" for mature coinbase outputs, or \"recv\" for all other received outputs.  Note: A single output may be included multiple times under different categories\n \"confirmations\": n,               (numeric)         The number of block confirmations of the transaction\n \"fee\": n.nnn,                     (numeric)         The total input value minus the total output value for sent transactions\n \"generated\": true|false,          (boolean)         Whether the transaction output is a coinbase output\n \"involveswatchonly\": true|false,  (boolean)         Unset\n \"time\": n,                        (numeric)         The earliest Unix time this transaction was known to exist\n \"timereceived\": n,                (numeric)         The earliest Unix time this transaction was known to exist\n \"trusted\": true|false,            (boolean)         Unset\n \"txid\": \"value\",                  (string)          The hash of the transaction\n \"vout\": n,                        (numeric)         The transaction output index\n \"walletconflicts\": [\"value\",...], (array of string) Unset\n \"comment\": \"value\",               (string)          The comment recorded for the transaction\n \"otheraccount\": \"value\",          (string)          Unset\n \"label\": \"value\",                 (string)          The label of the payment address\n \"txlabel\": \"value\",               (string)          The label of the transaction\n \"to\": \"value\",                    (string)          The name of the payee recorded for the transaction\n},...]\n",
 		"listunspent":             "listunspent (minconf=1 maxconf=9999999 [\"address\",...])\n\nReturns a JSON array of objects representing unlocked unspent outputs controlled by wallet keys.\n\nArguments:\n1. minconf   (numeric, optional, default=1)       Minimum number of block confirmations required before a transaction output is considered\n2. maxconf   (numeric, optional, default=9999999) Maximum number of block confirmations required before a transaction output is excluded\n3. addresses (array of string, optional)          If set, limits the returned details to unspent outputs received by any of these payment addresses\n\nResult:\n{\n \"txid\": \"value\",         (string)  The transaction hash of the referenced output\n \"vout\": n,               (numeric) The output index of the referenced output\n \"address\": \"value\",      (string)  The payment address that received the output\n \"account\": \"value\",      (string)  The account associated with the receiving payment address\n \"scriptPubKey\": \"value\", (string)  The output script encoded as a hexadecimal string\n \"redeemScript\": \"value\", (string)  Unset\n \"amount\": n.nnn,         (numeric) The amount of the output valued in bitcoin\n \"confirmations\": n,      (numeric) The number of block confirmations of the transaction\n \"spendable\": true|false, (boolean) Whether the output is entirely controlled by wallet keys/scripts (false for partially controlled multisig outputs or outputs to watch-only addresses)\n}                         \n",
 		"lockunspent":             "lockunspent unlock [{\"txid\":\"value\",\"vout\":n},...]\n\nLocks or unlocks an unspent output.\nLocked outputs are not chosen for transaction inputs of authored transactions and are not included in 'listunspent' results.\nLocked outputs are volatile and are not saved across wallet restarts.\nIf unlock is true and no transaction outputs are specified, all locked outputs are marked unlocked.\n\nArguments:\n1. unlock       (boolean, required)         True to unlock outputs, false to lock\n2. transactions (array of object, required) Transaction outputs to lock or unlock\n[{\n \"txid\": \"value\", (string)  The transaction hash of the referenced output\n \"vout\": n,       (numeric) The output index of the referenced output\n},...]\n\nResult:\ntrue|false (boolean) The boolean 'true'\n",
-		"sendfrom":                "sendfrom \"fromaccount\" \"toaddress\" amount (minconf=1 \"comment\" \"commentto\")\n\nDEPRECATED -- Authors, signs, and sends a transaction that outputs some amount to a payment address.\nA change output is automatically included to send extra output value back to the original account.\n\nArguments:\n1. fromaccount (string, required)             Account to pick unspent outputs from\n2. toaddress   (string, required)             Address to pay\n3. amount      (numeric, required)            Amount to send to the payment address valued in bitcoin\n4. minconf     (numeric, optional, default=1) Minimum number of block confirmations required before a transaction output is eligible to be spent\n5. comment     (string, optional)             Unused\n6. commentto   (string, optional)             Unused\n\nResult:\n\"value\" (string) The transaction hash of the sent transaction\n",
-		"sendmany":                "sendmany \"fromaccount\" {\"address\":amount,...} (minconf=1 \"comment\")\n\nAuthors, signs, and sends a transaction that outputs to many payment addresses.\nA change output is automatically included to send extra output value back to the original account.\n\nArguments:\n1. fromaccount (string, required) DEPRECATED -- Account to pick unspent outputs from\n2. amounts     (object, required) Pairs of payment addresses and the output amount to pay each\n{\n \"Address to pay\": Amount to send to the payment address valued in bitcoin, (object) JSON object using payment addresses as keys and output amounts valued in bitcoin to send to each address\n ...\n}\n3. minconf (numeric, optional, default=1) Minimum number of block confirmations required before a transaction output is eligible to be spent\n4. comment (string, optional)             Unused\n\nResult:\n\"value\" (string) The transaction hash of the sent transaction\n",
-		"sendtoaddress":           "sendtoaddress \"address\" amount (\"comment\" \"commentto\")\n\nAuthors, signs, and sends a transaction that outputs some amount to a payment address.\nUnlike sendfrom, outputs are always chosen from the default account.\nA change output is automatically included to send extra output value back to the original account.\n\nArguments:\n1. address   (string, required)  Address to pay\n2. amount    (numeric, required) Amount to send to the payment address valued in bitcoin\n3. comment   (string, optional)  Unused\n4. commentto (string, optional)  Unused\n\nResult:\n\"value\" (string) The transaction hash of the sent transaction\n",
+		"sendfrom":                "sendfrom \"fromaccount\" \"toaddress\" amount (minconf=1 \"comment\" \"commentto\" \"coinselection\" replaceable)\n\nDEPRECATED -- Authors, signs, and sends a transaction that outputs some amount to a payment address.\nA change output is automatically included to send extra output value back to the original account.\n\nArguments:\n1. fromaccount   (string, required)             Account to pick unspent outputs from\n2. toaddress     (string, required)             Address to pay\n3. amount        (numeric, required)            Amount to send to the payment address valued in bitcoin\n4. minconf       (numeric, optional, default=1) Minimum number of block confirmations required before a transaction output is eligible to be spent\n5. comment       (string, optional)             A comment to record for the transaction, such as the purpose of the payment\n6. commentto     (string, optional)             The name of the payee to record for the transaction\n7. coinselection (string, optional)             The coin selection strategy choosing the outputs spent: largest, bnb (changeless branch and bound), privacy or consolidate, defaulting to the wallet's coinselection option\n8. replaceable   (boolean, optional)            Signal that the transaction may be replaced by one paying a higher fee (BIP 125), defaulting to the wallet's walletrbf option\n\nResult:\n\"value\" (string) The transaction hash of the sent transaction\n",
+		"sendmany":                "sendmany \"fromaccount\" {\"address\":amount,...} (minconf=1 \"comment\" \"coinselection\" replaceable)\n\nAuthors, signs, and sends a transaction that outputs to many payment addresses.\nA change output is automatically included to send extra output value back to the original account.\n\nArguments:\n1. fromaccount (string, required) DEPRECATED -- Account to pick unspent outputs from\n2. amounts     (object, required) Pairs of payment addresses and the output amount to pay each\n{\n \"Address to pay\": Amount to send to the payment address valued in bitcoin, (object) JSON object using payment addresses as keys and output amounts valued in bitcoin to send to each address\n ...\n}\n3. minconf       (numeric, optional, default=1) Minimum number of block confirmations required before a transaction output is eligible to be spent\n4. comment       (string, optional)             A comment to record for the transaction, such as the purpose of the payment\n5. coinselection (string, optional)             The coin selection strategy choosing the outputs spent: largest, bnb (changeless branch and bound), privacy or consolidate, defaulting to the wallet's coinselection option\n6. replaceable   (boolean, optional)            Signal that the transaction may be replaced by one paying a higher fee (BIP 125), defaulting to the wallet's walletrbf option\n\nResult:\n\"value\" (string) The transaction hash of the sent transaction\n",
+		"sendtoaddress":           "sendtoaddress \"address\" amount (\"comment\" \"commentto\" \"coinselection\" replaceable)\n\nAuthors, signs, and sends a transaction that outputs some amount to a payment address.\nUnlike sendfrom, outputs are always chosen from the default account.\nA change output is automatically included to send extra output value back to the original account.\n\nArguments:\n1. address       (string, required)  Address to pay\n2. amount        (numeric, required) Amount to send to the payment address valued in bitcoin\n3. comment       (string, optional)  A comment to record for the transaction, such as the purpose of the payment\n4. commentto     (string, optional)  The name of the payee to record for the transaction\n5. coinselection (string, optional)  The coin selection strategy choosing the outputs spent: largest, bnb (changeless branch and bound), privacy or consolidate, defaulting to the wallet's coinselection option\n6. replaceable   (boolean, optional) Signal that the transaction may be replaced by one paying a higher fee (BIP 125), defaulting to the wallet's walletrbf option\n\nResult:\n\"value\" (string) The transaction hash of the sent transaction\n",
+		"setlabel":                "setlabel \"address\" \"label\"\n\nSets the label of a payment address.\nAddresses that do not belong to the wallet, such as those of payees, may be labeled as well.\n\nArguments:\n1. address (string, required) The payment address to label\n2. label   (string, required) The label to assign to the address, or the empty string to remove the label\n\nResult:\nNothing\n",
 		"settxfee":                "settxfee amount\n\nModify the increment used each time more fee is required for an authored transaction.\n\nArguments:\n1. amount (numeric, required) The new fee increment valued in bitcoin\n\nResult:\ntrue|false (boolean) The boolean 'true'\n",
 		"signmessage":             "signmessage \"address\" \"message\"\n\nSigns a message using the private key of a payment address.\n\nArguments:\n1. address (string, required) Payment address of private key used to sign the message with\n2. message (string, required) Message to sign\n\nResult:\n\"value\" (string) The signed message encoded as a base64 string\n",
 		"signrawtransaction":      "signrawtransaction \"rawtx\" ([{\"txid\":\"value\",\"vout\":n,\"scriptpubkey\":\"value\",\"redeemscript\":\"value\"},...] [\"privkey\",...] flags=\"ALL\")\n\nSigns transaction inputs using private keys from this wallet and request.\nThe valid flags options are ALL, NONE, SINGLE, ALL|ANYONECANPAY, NONE|ANYONECANPAY, and SINGLE|ANYONECANPAY.\n\nArguments:\n1. rawtx    (string, required)                Unsigned or partially unsigned transaction to sign encoded as a hexadecimal string\n2. inputs   (array of object, optional)       Additional data regarding inputs that this wallet may not be tracking\n3. privkeys (array of string, optional)       Additional WIF-encoded private keys to use when creating signatures\n4. flags    (string, optional, default=\"ALL\") Sighash flags\n\nResult:\n{\n \"hex\": \"value\",         (string)          The resulting transaction encoded as a hexadecimal string\n \"complete\": true|false, (boolean)         Whether all input signatures have been created\n \"errors\": [{            (array of object) Script verification errors (if exists)\n  \"txid\": \"value\",       (string)          The transaction hash of the referenced previous output\n  \"vout\": n,             (numeric)         The output index of the referenced previous output\n  \"scriptSig\": \"value\",  (string)          The hex-encoded signature script\n  \"sequence\": n,         (numeric)         Script sequence number\n  \"error\": \"value\",      (string)          Verification or signing error related to the input\n },...],                                   \n}                        \n",
 		"validateaddress":         "validateaddress \"address\"\n\nVerify that an address is valid.\nExtra details are returned if the address is controlled by this wallet.\nThe following fields are valid only when the address is controlled by this wallet (ismine=true): isscript, pubkey, iscompressed, account, addresses, hex, script, and sigsrequired.\nThe following fields are only valid when address has an associated public key: pubkey, iscompressed.\nThe following fields are only valid when address is a pay-to-script-hash address: addresses, hex, and script.\nIf the address is a multisig address controlled by this wallet, the multisig fields will be left unset if the wallet is locked since the redeem script cannot be decrypted.\n\nArguments:\n1. address (string, required) Address to validate\n\nResult:\n{\n \"isvalid\": true|false,      (boolean)         Whether or not the address is valid\n \"address\": \"value\",         (string)          The payment address (only when isvalid is true)\n \"ismine\": true|false,       (boolean)         Whether this address is controlled by the wallet (only when isvalid is true)\n \"iswatchonly\": true|false,  (boolean)         Unset\n \"isscript\": true|false,     (boolean)         Whether the payment address is a pay-to-script-hash address (only when isvalid is true)\n \"pubkey\": \"value\",          (string)          The associated public key of the payment address, if any (only when isvalid is true)\n \"iscompressed\": true|false, (boolean)         Whether the address was created by hashing a compressed public key, if any (only when isvalid is true)\n \"account\": \"value\",         (string)          The account this payment address belongs to (only when isvalid is true)\n \"addresses\": [\"value\",...], (array of string) All associated payment addresses of the script if address is a multisig address (only when isvalid is true)\n \"hex\": \"value\",             (string)          The redeem script \n \"script\": \"value\",          (string)          The class of redeem script for a multisig address\n \"sigsrequired\": n,          (numeric)         The number of required signatures to redeem outputs to the multisig address\n}                            \n",
 		"verifymessage":           "verifymessage \"address\" \"signature\" \"message\"\n\nVerify a message was signed with the associated private key of some address.\n\nArguments:\n1. address   (string, required) Address used to sign message\n2. signature (string, required) The signature to verify\n3. message   (string, required) The message to verify\n\nResult:\ntrue|false (boolean) Whether the message was signed with the private key of 'address'\n",
+		"walletcreatefundedpsbt":  "walletcreatefundedpsbt [{\"txid\":\"value\",\"vout\":n,\"sequence\":sequence},...] {\"address\":amount,...} (locktime {\"changeaddress\":changeaddress,\"feerate\":feerate,\"lockunspents\":lockunspents,\"replaceable\":replaceable})\n\nCreates a partially signed transaction (BIP 174) paying the requested outputs, funded by outputs of the default account.\nAny inputs given are spent first, and unspent outputs with at least one confirmation are added when they do not cover the outputs and the fee.\nInputs signal replaceability (BIP 125) when the replaceable option or the wallet's walletrbf option is set, unless given another sequence number.\n\nArguments:\n1. inputs (array of object, required) Wallet outputs to spend, as JSON objects with the txid and vout of the output and an optional input sequence number\n[{\n \"txid\": \"value\", (string)  The transaction hash of the output to spend\n \"vout\": n,       (numeric) The output index of the output to spend\n \"sequence\": n,   (numeric) The sequence number of the input (default=4294967293, which signals replaceability, when the transaction is replaceable and 4294967295 otherwise)\n},...]\n2. outputs (object, required) Pairs of payment addresses and the output amount to pay each\n{\n \"Address to pay\": Amount to send to the payment address valued in bitcoin, (object) JSON object using payment addresses as keys and output amounts valued in bitcoin to send to each address\n ...\n}\n3. locktime (numeric, optional) The lock time of the transaction\n4. options  (object, optional)  JSON object with an optional changeAddress to send change to (default=a new change address), feeRate valued in bitcoin per kilobyte, lockUnspents to lock the spent outputs and replaceable to signal replaceability (default=the wallet's walletrbf option)\n{\n \"changeAddress\": \"value\",   (string)  The address to send change to (default=a new change address)\n \"feeRate\": n.nnn,           (numeric) The fee rate valued in bitcoin per kilobyte (default=the relay fee)\n \"lockUnspents\": true|false, (boolean) Lock the outputs spent by the transaction\n \"replaceable\": true|false,  (boolean) Signal that the transaction may be replaced by one paying a higher fee (BIP 125) (default=the wallet's walletrbf option)\n}                            \n\nResult:\n{\n \"psbt\": \"value\", (string)  The partially signed transaction encoded as a base64 string\n \"fee\": n.nnn,    (numeric) The fee of the transaction valued in bitcoin\n \"changepos\": n,  (numeric) The index of the change output, or -1 if there is none\n}                 \n",
 		"walletlock":              "walletlock\n\nLock the wallet.\n\nArguments:\nNone\n\nResult:\nNothing\n",
 		"walletpassphrase":        "walletpassphrase \"passphrase\" timeout\n\nUnlock the wallet.\n\nArguments:\n1. passphrase (string, required)  The wallet passphrase\n2. timeout    (numeric, required) The number of seconds to wait before the wallet automatically locks\n\nResult:\nNothing\n",
 		"walletpassphrasechange":  "walletpassphrasechange \"oldpassphrase\" \"newpassphrase\"\n\nChange the wallet passphrase.\n\nArguments:\n1. oldpassphrase (string, required) The old wallet passphrase\n2. newpassphrase (string, required) The new wallet passphrase\n\nResult:\nNothing\n",
+		"walletprocesspsbt":       "walletprocesspsbt \"psbt\" (sign=true sighashtype=\"ALL\")\n\nAdds what the wallet knows about the inputs of a partially signed transaction (BIP 174) to it, and signs and finalizes the inputs it holds the keys for.\nThe valid sighashtype options are ALL, NONE, SINGLE, ALL|ANYONECANPAY, NONE|ANYONECANPAY, and SINGLE|ANYONECANPAY.\n\nArguments:\n1. psbt        (string, required)                The partially signed transaction encoded as a base64 string\n2. sign        (boolean, optional, default=true) Sign the inputs the wallet holds the keys for\n3. sighashtype (string, optional, default=\"ALL\") Sighash flags to sign with, unless an input requires others\n\nResult:\n{\n \"psbt\": \"value\",        (string)  The partially signed transaction encoded as a base64 string\n \"complete\": true|false, (boolean) Whether every input of the transaction is finalized\n}                        \n",
 		"createnewaccount":        "createnewaccount \"account\"\n\nCreates a new account.\nThe wallet must be unlocked for this request to succeed.\n\nArguments:\n1. account (string, required) Name of the new account\n\nResult:\nNothing\n",
 		"exportwatchingwallet":    "exportwatchingwallet (\"account\" download=false)\n\nCreates and returns a duplicate of the wallet database without any private keys to be used as a watching-only wallet.\n\nArguments:\n1. account  (string, optional)                 Unused (must be unset or \"*\")\n2. download (boolean, optional, default=false) Unused\n\nResult:\n\"value\" (string) The watching-only database encoded as a base64 string\n",
 		"getbestblock":            "getbestblock\n\nReturns the hash and height of the newest block in the best chain that wallet has finished syncing with.\n\nArguments:\nNone\n\nResult:\n{\n \"hash\": \"value\", (string)  The hash of the block\n \"height\": n,     (numeric) The blockchain height of the block\n}                 \n",
+		"getspvsyncinfo":          "getspvsyncinfo\n\nReturns how far the light client of a wallet using the spv backend has synced the block headers and compact filter headers.\n\nArguments:\nNone\n\nResult:\n{\n \"headers\": n,               (numeric) The height of the last block header synced\n \"bestheaderhash\": \"value\",  (string)  The hash of the last block header synced\n \"filterheaders\": n,         (numeric) The height of the last compact filter header synced\n \"current\": true|false,      (boolean) Whether the block headers and filter headers are synced with the peers\n \"peers\": n,                 (numeric) The number of connected peers\n \"walletsynced\": true|false, (boolean) Whether the wallet has finished syncing with the light client\n}                            \n",
 		"getunconfirmedbalance":   "getunconfirmedbalance (\"account\")\n\nCalculates the unspent output value of all unmined transaction outputs for an account.\n\nArguments:\n1. account (string, optional) The account to query the unconfirmed balance for (default=\"default\")\n\nResult:\nn.nnn (numeric) Total amount of all unmined unspent outputs of the account valued in bitcoin.\n",
-		"listaddresstransactions": "listaddresstransactions [\"address\",...] (\"account\")\n\nReturns a JSON array of objects containing verbose details for wallet transactions pertaining some addresses.\n\nArguments:\n1. addresses (array of string, required) Addresses to filter transaction results by\n2. account   (string, optional)          Unused (must be unset or \"*\")\n\nResult:\n[{\n \"abandoned\": true|false,          (boolean)         Unset\n \"account\": \"value\",               (string)          DEPRECATED -- Unset\n \"address\": \"value\",               (string)          Payment address for a transaction output\n \"amount\": n.nnn,                  (numeric)         The value of the transaction output valued in bitcoin\n \"bip125-replaceable\": \"value\",    (string)          Unset\n \"blockhash\": \"value\",             (string)          The hash of the block this transaction is mined in, or the empty string if unmined\n \"blockindex\": n,                  (numeric)         Unset\n \"blocktime\": n,                   (numeric)         The Unix time of the block header this transaction is mined in, or 0 if unmined\n \"category\": \"value\",              (string)          The kind of transaction: \"send\" for sent transactions, \"immature\" for immature coinbase outputs, \"generate\" for mature coinbase outputs, or \"recv\" for all other received outputs.  Note: A single output may be included multiple times under different categories\n \"confirmations\": n,               (numeric)         The number of block confirmations of the transaction\n \"fee\": n.nnn,                     (numeric)         The total input value minus the total output value for sent transactions\n \"generated\": true|false,          (boolean)         Whether the transaction output is a coinbase output\n \"involveswatchonly\": true|false,  (boolean)         Unset\n \"time\": n,                        (numeric)         The earliest Unix time this transaction was known to exist\n \"timereceived\": n,                (numeric)         The earliest Unix time this transaction was known to exist\n \"trusted\": true|false,            (boolean)         Unset\n \"txid\": \"value\",                  (string)          The hash of the transaction\n \"vout\": n,                        (numeric)         The transaction output index\n \"walletconflicts\": [\"value\",...], (array of string) Unset\n \"comment\": \"value\",               (string)          Unset\n \"otheraccount\": \"value\",          (string)          Unset\n},...]\n",
-		"listalltransactions":     "listalltransactions (\"account\")\n\nReturns a JSON array of objects in the same format as 'listtransactions' without limiting the number of returned objects.\n\nArguments:\n1. account (string, optional) Unused (must be unset or \"*\")\n\nResult:\n[{\n \"abandoned\": true|false,          (boolean)         Unset\n \"account\": \"value\",               (string)          DEPRECATED -- Unset\n \"address\": \"value\",               (string)          Payment address for a transaction output\n \"amount\": n.nnn,                  (numeric)         The value of the transaction output valued in bitcoin\n \"bip125-replaceable\": \"value\",    (string)          Unset\n \"blockhash\": \"value\",             (string)          The hash of the block this transaction is mined in, or the empty string if unmined\n \"blockindex\": n,                  (numeric)         Unset\n \"blocktime\": n,                   (numeric)         The Unix time of the block header this transaction is mined in, or 0 if unmined\n \"category\": \"value\",              (string)          The kind of transaction: \"send\" for sent transactions, \"immature\" for immature coinbase outputs, \"generate\" for mature coinbase outputs, or \"recv\" for all other received outputs.  Note: A single output may be included multiple times under different categories\n \"confirmations\": n,               (numeric)         The number of block confirmations of the transaction\n \"fee\": n.nnn,                     (numeric)         The total input value minus the total output value for sent transactions\n \"generated\": true|false,          (boolean)         Whether the transaction output is a coinbase output\n \"involveswatchonly\": true|false,  (boolean)         Unset\n \"time\": n,                        (numeric)         The earliest Unix time this transaction was known to exist\n \"timereceived\": n,                (numeric)         The earliest Unix time this transaction was known to exist\n \"trusted\": true|false,            (boolean)         Unset\n \"txid\": \"value\",                  (string)          The hash of the transaction\n \"vout\": n,                        (numeric)         The transaction output index\n \"walletconflicts\": [\"value\",...], (array of string) Unset\n \"comment\": \"value\",               (string)          Unset\n \"otheraccount\": \"value\",          (string)          Unset\n},...]\n",
+		"importxpub":              "importxpub \"xpub\" \"account\" (rescan=true)\n\nCreates a watch-only account from the extended public key of an account of another wallet, such as an offline cold storage wallet.\nThe balance and history of the account are tracked, and transactions spending from it can be created with walletcreatefundedpsbt to be signed by the other wallet.\n\nArguments:\n1. xpub    (string, required)                The extended public key of the account\n2. account (string, required)                Name of the new watch-only account\n3. rescan  (boolean, optional, default=true) Rescan the blockchain (since the genesis block) for transactions of the account\n\nResult:\nNothing\n",
+		"listaddresstransactions": "listaddresstransactions [\"address\",...] (\"account\")\n\nReturns a JSON array of objects containing verbose details for wallet transactions pertaining some addresses.\n\nArguments:\n1. addresses (array of string, required) Addresses to filter transaction results by\n2. account   (string, optional)          Unused (must be unset or \"*\")\n\nResult:\n[{\n \"abandoned\": true|false,          (boolean)         Unset\n \"account\": \"value\",               (string)          DEPRECATED -- Unset\n \"address\": \"value\",               (string)          Payment address for a transaction output\n \"amount\": n.nnn,                  (numeric)         The value of the transaction output valued in bitcoin\n \"bip125-replaceable\": \"value\",    (string)          Unset\n \"blockhash\": \"value\",             (string)          The hash of the block this transaction is mined in, or the empty string if unmined\n \"blockindex\": n,                  (numeric)         Unset\n \"blocktime\": n,                   (numeric)         The Unix time of the block header this transaction is mined in, or 0 if unmined\n \"category\": \"value\",              (string)          The kind of transaction: \"send\" for sent transactions, \"immature\" for immature coinbase outputs, \"generate\" for mature coinbase outputs, or \"recv\" for all other received outputs.  Note: A single output may be included multiple times under different categories\n \"confirmations\": n,               (numeric)         The number of block confirmations of the transaction\n \"fee\": n.nnn,                     (numeric)         The total input value minus the total output value for sent transactions\n \"generated\": true|false,          (boolean)         Whether the transaction output is a coinbase output\n \"involveswatchonly\": true|false,  (boolean)         Unset\n \"time\": n,                        (numeric)         The earliest Unix time this transaction was known to exist\n \"timereceived\": n,                (numeric)         The earliest Unix time this transaction was known to exist\n \"trusted\": true|false,            (boolean)         Unset\n \"txid\": \"value\",                  (string)          The hash of the transaction\n \"vout\": n,                        (numeric)         The transaction output index\n \"walletconflicts\": [\"value\",...], (array of string) Unset\n \"comment\": \"value\",               (string)          The comment recorded for the transaction\n \"otheraccount\": \"value\",          (string)          Unset\n \"label\": \"value\",                 (string)          The label of the payment address\n \"txlabel\": \"value\",               (string)          The label of the transaction\n \"to\": \"value\",                    (string)          The name of the payee recorded for the transaction\n},...]\n",
+		"listalltransactions":     "listalltransactions (\"account\")\n\nReturns a JSON array of objects in the same format as 'listtransactions' without limiting the number of returned objects.\n\nArguments:\n1. account (string, optional) Unused (must be unset or \"*\")\n\nResult:\n[{\n \"abandoned\": true|false,          (boolean)         Unset\n \"account\": \"value\",               (string)          DEPRECATED -- Unset\n \"address\": \"value\",               (string)          Payment address for a transaction output\n \"amount\": n.nnn,                  (numeric)         The value of the transaction output valued in bitcoin\n \"bip125-replaceable\": \"value\",    (string)          Unset\n \"blockhash\": \"value\",             (string)          The hash of the block this transaction is mined in, or the empty string if unmined\n \"blockindex\": n,                  (numeric)         Unset\n \"blocktime\": n,                   (numeric)         The Unix time of the block header this transaction is mined in, or 0 if unmined\n \"category\": \"value\",              (string)          The kind of transaction: \"send\" for sent transactions, \"immature\" for immature coinbase outputs, \"generate\" for mature coinbase outputs, or \"recv\" for all other received outputs.  Note: A single output may be included multiple times under different categories\n \"confirmations\": n,               (numeric)         The number of block confirmations of the transaction\n \"fee\": n.nnn,                     (numeric)         The total input value minus the total output value for sent transactions\n \"generated\": true|false,          (boolean)         Whether the transaction output is a coinbase output\n \"involveswatchonly\": true|false,  (boolean)         Unset\n \"time\": n,                        (numeric)         The earliest Unix time this transaction was known to exist\n \"timereceived\": n,                (numeric)         The earliest Unix time this transaction was known to exist\n \"trusted\": true|false,            (boolean)         Unset\n \"txid\": \"value\",                  (string)          The hash of the transaction\n \"vout\": n,                        (numeric)         The transaction output index\n \"walletconflicts\": [\"value\",...], (array of string) Unset\n \"comment\": \"value\",               (string)          The comment recorded for the transaction\n \"otheraccount\": \"value\",          (string)          Unset\n \"label\": \"value\",                 (string)          The label of the payment address\n \"txlabel\": \"value\",               (string)          The label of the transaction\n \"to\": \"value\",                    (string)          The name of the payee recorded for the transaction\n},...]\n",
 		"renameaccount":           "renameaccount \"oldaccount\" \"newaccount\"\n\nRenames an account.\n\nArguments:\n1. oldaccount (string, required) The old account name to rename\n2. newaccount (string, required) The new name for the account\n\nResult:\nNothing\n",
+		"settxlabel":              "settxlabel \"txid\" \"label\"\n\nSets the label of a transaction recorded by the wallet.\n\nArguments:\n1. txid  (string, required) The hash of the transaction to label\n2. label (string, required) The label to assign to the transaction, or the empty string to remove the label\n\nResult:\nNothing\n",
 		"walletislocked":          "walletislocked\n\nReturns whether or not the wallet is locked.\n\nArguments:\nNone\n\nResult:\ntrue|false (boolean) Whether the wallet is locked\n",
 	}
 }
@@ -57,4 +67,4 @@ var localeHelpDescs = map[string]func() map[string]string{
 	"en_US": helpDescsEnUS,
 }
 
-var requestUsages = "addmultisigaddress nrequired [\"key\",...] (\"account\")\ncreatemultisig nrequired [\"key\",...]\ndumpprivkey \"address\"\ngetaccount \"address\"\ngetaccountaddress \"account\"\ngetaddressesbyaccount \"account\"\ngetbalance (\"account\" minconf=1)\ngetbestblockhash\ngetblockcount\ngetinfo\ngetnewaddress (\"account\")\ngetrawchangeaddress (\"account\")\ngetreceivedbyaccount \"account\" (minconf=1)\ngetreceivedbyaddress \"address\" (minconf=1)\ngettransaction \"txid\" (includewatchonly=false)\nhelp (\"command\")\nimportprivkey \"privkey\" (\"label\" rescan=true)\nkeypoolrefill (newsize=100)\nlistaccounts (minconf=1)\nlistlockunspent\nlistreceivedbyaccount (minconf=1 includeempty=false includewatchonly=false)\nlistreceivedbyaddress (minconf=1 includeempty=false includewatchonly=false)\nlistsinceblock (\"blockhash\" targetconfirmations=1 includewatchonly=false)\nlisttransactions (\"account\" count=10 from=0 includewatchonly=false)\nlistunspent (minconf=1 maxconf=9999999 [\"address\",...])\nlockunspent unlock [{\"txid\":\"value\",\"vout\":n},...]\nsendfrom \"fromaccount\" \"toaddress\" amount (minconf=1 \"comment\" \"commentto\")\nsendmany \"fromaccount\" {\"address\":amount,...} (minconf=1 \"comment\")\nsendtoaddress \"address\" amount (\"comment\" \"commentto\")\nsettxfee amount\nsignmessage \"address\" \"message\"\nsignrawtransaction \"rawtx\" ([{\"txid\":\"value\",\"vout\":n,\"scriptpubkey\":\"value\",\"redeemscript\":\"value\"},...] [\"privkey\",...] flags=\"ALL\")\nvalidateaddress \"address\"\nverifymessage \"address\" \"signature\" \"message\"\nwalletlock\nwalletpassphrase \"passphrase\" timeout\nwalletpassphrasechange \"oldpassphrase\" \"newpassphrase\"\ncreatenewaccount \"account\"\nexportwatchingwallet (\"account\" download=false)\ngetbestblock\ngetunconfirmedbalance (\"account\")\nlistaddresstransactions [\"address\",...] (\"account\")\nlistalltransactions (\"account\")\nrenameaccount \"oldaccount\" \"newaccount\"\nwalletislocked"
+var requestUsages = "addmultisigaddress nrequired [\"key\",...] (\"account\")\nbumpfee \"txid\" (feerate)\ncombinepsbt [\"psbt\",...]\ncreatemultisig nrequired [\"key\",...]\ndecodepsbt \"psbt\"\ndumpprivkey \"address\"\nfinalizepsbt \"psbt\" (extract=true)\ngetaccount \"address\"\ngetaccountaddress \"account\"\ngetaddressesbyaccount \"account\"\ngetbalance (\"account\" minconf=1)\ngetbestblockhash\ngetblockcount\ngetinfo\ngetnewaddress (\"account\")\ngetrawchangeaddress (\"account\")\ngetreceivedbyaccount \"account\" (minconf=1)\ngetreceivedbyaddress \"address\" (minconf=1)\ngettransaction \"txid\" (includewatchonly=false)\nhelp (\"command\")\nimportprivkey \"privkey\" (\"label\" rescan=true)\nkeypoolrefill (newsize=100)\nlistaccounts (minconf=1)\nlistlockunspent\nlistreceivedbyaccount (minconf=1 includeempty=false includewatchonly=false)\nlistreceivedbyaddress (minconf=1 includeempty=false includewatchonly=false)\nlistsinceblock (\"blockhash\" targetconfirmations=1 includewatchonly=false)\nlisttransactions (\"account\" count=10 from=0 includewatchonly=false)\nlistunspent (minconf=1 maxconf=9999999 [\"address\",...])\nlockunspent unlock [{\"txid\":\"value\",\"vout\":n},...]\nsendfrom \"fromaccount\" \"toaddress\" amount (minconf=1 \"comment\" \"commentto\" \"coinselection\" replaceable)\nsendmany \"fromaccount\" {\"address\":amount,...} (minconf=1 \"comment\" \"coinselection\" replaceable)\nsendtoaddress \"address\" amount (\"comment\" \"commentto\" \"coinselection\" replaceable)\nsetlabel \"address\" \"label\"\nsettxfee amount\nsignmessage \"address\" \"message\"\nsignrawtransaction \"rawtx\" ([{\"txid\":\"value\",\"vout\":n,\"scriptpubkey\":\"value\",\"redeemscript\":\"value\"},...] [\"privkey\",...] flags=\"ALL\")\nvalidateaddress \"address\"\nverifymessage \"address\" \"signature\" \"message\"\nwalletcreatefundedpsbt [{\"txid\":\"value\",\"vout\":n,\"sequence\":sequence},...] {\"address\":amount,...} (locktime {\"changeaddress\":changeaddress,\"feerate\":feerate,\"lockunspents\":lockunspents,\"replaceable\":replaceable})\nwalletlock\nwalletpassphrase \"passphrase\" timeout\nwalletpassphrasechange \"oldpassphrase\" \"newpassphrase\"\nwalletprocesspsbt \"psbt\" (sign=true sighashtype=\"ALL\")\ncreatenewaccount \"account\"\nexportwatchingwallet (\"account\" download=false)\ngetbestblock\ngetspvsyncinfo\ngetunconfirmedbalance (\"account\")\nimportxpub \"xpub\" \"account\" (rescan=true)\nlistaddresstransactions [\"address\",...] (\"account\")\nlistalltransactions (\"account\")\nrenameaccount \"oldaccount\" \"newaccount\"\nsettxlabel \"txid\" \"label\"\nwalletislocked"
@@ -74,6 +74,7 @@ type Server struct {
 
 	maxPostClients      int64 // Max concurrent HTTP POST clients.
 	maxWebsocketClients int64 // Max concurrent websocket clients.
+	maxBatchSize        int   // Max requests in a JSON-RPC batch.
 
 	wg      sync.WaitGroup
 	quit    chan struct{}
@@ -109,6 +110,7 @@ func NewServer(
 		walletLoader:        walletLoader,
 		maxPostClients:      opts.MaxPOSTClients,
 		maxWebsocketClients: opts.MaxWebsocketClients,
+		maxBatchSize:        opts.MaxBatchSize,
 		listeners:           listeners,
 		// A hash of the HTTP basic auth string is used for a constant
 		// time comparison.
@@ -329,7 +331,15 @@ func (s *Server) handlerClosure(request *json.Request) lazyHandler {
 	}
 	s.handlerMu.Unlock()
 
-	return lazyApplyHandler(request, wallet, chainClient)
+	handler := lazyApplyHandler(request, wallet, chainClient)
+	method := request.Method
+	return func() (interface{}, *json.RPCError) {
+
+		start := time.Now()
+		res, jsonErr := handler()
+		observeRPCRequest(method, time.Since(start))
+		return res, jsonErr
+	}
 }
 
 // ErrNoAuth represents an error where authentication could not succeed
@@ -405,7 +415,7 @@ func sanitizeRequest(
 
 	switch r.Method {
 
-	case "encryptwallet", "importprivkey", "importwallet",
+	case "encryptwallet", "importprivkey", "importwallet", "importxpub",
 		"signrawtransaction", "walletpassphrase",
 		"walletpassphrasechange":
 
@@ -586,7 +596,8 @@ out:
 				go func() {
 
 					resp, jsonErr := f()
-					mresp, err := json.MarshalResponse(req.ID, resp, jsonErr)
+					mresp, err := json.MarshalResponseVersion(req.Jsonrpc,
+						req.ID, resp, jsonErr)
 
 					if err != nil {
 
@@ -700,7 +711,8 @@ func (s *Server) websocketClientRPC(wsc *websocketClient) {
 // that may be read from a client.  This is currently limited to 4MB.
 const maxRequestSize = 1024 * 1024 * 4
 
-// postClientRPC processes and replies to a JSON-RPC client request.
+// postClientRPC processes and replies to a JSON-RPC client request or a
+// batch of requests.
 func (s *Server) postClientRPC(w http.ResponseWriter, r *http.Request) {
 
 	body := http.MaxBytesReader(w, r.Body, maxRequestSize)
@@ -714,37 +726,149 @@ func (s *Server) postClientRPC(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// First check whether wallet has a handler for this request's method.
-	// If unfound, the request is sent to the chain server for further
-	// processing.  While checking the methods, disallow authenticate
-	// requests, as they are invalid for HTTP POST clients.
-	var req json.Request
-	err = js.Unmarshal(rpcRequest, &req)
+	var mresp []byte
+	var stop bool
+
+	if json.IsBatchRequest(rpcRequest) {
+
+		mresp, stop, err = s.postClientBatch(rpcRequest)
+
+	} else {
+
+		mresp, stop, err = s.postClientRequest(rpcRequest)
+	}
 
 	if err != nil {
 
-		resp, err := json.MarshalResponse(req.ID, nil, json.ErrRPCInvalidRequest)
+		log <- cl.Error{
+
+			"unable to marshal response:", err,
+		}
+		http.Error(w, "500 Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	// Notifications are not replied to.
+
+	if mresp != nil {
+
+		_, err = w.Write(mresp)
 
 		if err != nil {
 
-			log <- cl.Error{
+			log <- cl.Warn{
 
-				"Unable to marshal response:", err,
+				"unable to respond to client:", err,
 			}
-			http.Error(w, "500 Internal Server Error",
-				http.StatusInternalServerError)
-			return
 		}
-		_, err = w.Write(resp)
+	}
+
+	if stop {
+
+		s.requestProcessShutdown()
+	}
+}
+
+// postClientBatch processes the requests of a JSON-RPC batch in order and
+// returns the marshalled array of their responses, or nil when all of them
+// are notifications.  Responses that fail to marshal are logged and left
+// out.  The returned bool is whether a stop request was part of the batch.
+func (s *Server) postClientBatch(rpcRequest []byte) ([]byte, bool, error) {
+
+	var requests []js.RawMessage
+	var jsonErr *json.RPCError
+
+	if err := js.Unmarshal(rpcRequest, &requests); err != nil {
+
+		jsonErr = &json.RPCError{
+			Code:    json.ErrRPCParse.Code,
+			Message: "Failed to parse request: " + err.Error(),
+		}
+
+	} else if len(requests) == 0 {
+
+		jsonErr = &json.RPCError{
+			Code:    json.ErrRPCInvalidRequest.Code,
+			Message: "Empty batch request",
+		}
+
+	} else if len(requests) > s.maxBatchSize {
+
+		jsonErr = &json.RPCError{
+			Code: json.ErrRPCInvalidRequest.Code,
+			Message: fmt.Sprintf("Batch of %d requests exceeds the "+
+				"maximum of %d", len(requests), s.maxBatchSize),
+		}
+	}
+
+	if jsonErr != nil {
+
+		mresp, err := json.MarshalResponseVersion(json.RPCVersion2, nil,
+			nil, jsonErr)
+		return mresp, false, err
+	}
+
+	var responses [][]byte
+	var stop bool
+
+	for _, request := range requests {
+
+		mresp, reqStop, err := s.postClientRequest(request)
+		stop = stop || reqStop
+
+		// A reply that can't be marshalled is left out rather than failing
+		// the replies to the other requests of the batch.
 
 		if err != nil {
 
-			log <- cl.Warn{
+			log <- cl.Error{
 
-				"cannot write invalid request request to client:", err,
+				"unable to marshal response:", err,
 			}
+			continue
 		}
-		return
+
+		if mresp != nil {
+
+			responses = append(responses, mresp)
+		}
+	}
+
+	if len(responses) == 0 {
+
+		return nil, stop, nil
+	}
+	mresp, err := json.MarshalBatchResponse(responses)
+	return mresp, stop, err
+}
+
+// postClientRequest processes a single JSON-RPC request and returns the
+// marshalled response, or nil when the request is a notification or is
+// dropped.  The returned bool is whether the request was a stop request.
+func (s *Server) postClientRequest(rpcRequest []byte) ([]byte, bool, error) {
+
+	// First check whether wallet has a handler for this request's method.
+	// If unfound, the request is sent to the chain server for further
+	// processing.  While checking the methods, disallow authenticate
+	// requests, as they are invalid for HTTP POST clients.
+	var req json.Request
+	err := js.Unmarshal(rpcRequest, &req)
+
+	if err != nil {
+
+		mresp, err := json.MarshalResponseVersion(req.Jsonrpc, req.ID, nil,
+			json.ErrRPCInvalidRequest)
+		return mresp, false, err
+	}
+
+	// JSON-RPC 2.0 notifications are not replied to, so they are dropped.
+	// JSON-RPC 1.0 requests with a null id are still answered as they
+	// always have been.
+
+	if req.Jsonrpc == json.RPCVersion2 &&
+		json.IsNotification(rpcRequest, &req) {
+
+		return nil, false, nil
 	}
 
 	// Create the response and error from the request.  Two special cases
@@ -757,7 +881,7 @@ func (s *Server) postClientRPC(w http.ResponseWriter, r *http.Request) {
 
 	case "authenticate":
 		// Drop it.
-		return
+		return nil, false, nil
 	case "stop":
 		stop = true
 		res = "mod stopping"
@@ -766,31 +890,9 @@ func (s *Server) postClientRPC(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Marshal and send.
-	mresp, err := json.MarshalResponse(req.ID, res, jsonErr)
-
-	if err != nil {
-
-		log <- cl.Error{
-
-			"unable to marshal response:", err,
-		}
-		http.Error(w, "500 Internal Server Error", http.StatusInternalServerError)
-		return
-	}
-	_, err = w.Write(mresp)
-
-	if err != nil {
-
-		log <- cl.Warn{
-
-			"unable to respond to client:", err,
-		}
-	}
-
-	if stop {
-
-		s.requestProcessShutdown()
-	}
+	mresp, err := json.MarshalResponseVersion(req.Jsonrpc, req.ID, res,
+		jsonErr)
+	return mresp, stop, err
 }
 func (s *Server) requestProcessShutdown() {
 
@@ -4,8 +4,6 @@ import (
 	"encoding/binary"
 	"errors"
 	"fmt"
-	"hash/crc32"
-	"math/rand"
 	"net"
 	"time"
 )
@@ -16,17 +14,63 @@ import (
 func NewBase(
 	cfg BaseCfg) (b *Base) {
 
-	b = &Base{
-		cfg:       cfg,
-		packets:   make(chan Packet, baseChanBufs),
-		incoming:  make(chan Bundle, baseChanBufs),
-		returning: make(chan Bundle, baseChanBufs),
-		trash:     make(chan Bundle),
-		quit:      make(chan bool),
-	}
+	b = &Base{}
+	b.init(cfg)
 	return
 }
 
+// init sets up the channels and fills in the defaults of the configuration
+func (b *Base) init(
+	cfg BaseCfg) {
+
+	if cfg.BufferSize < 1 {
+
+		cfg.BufferSize = defaultBufferSize
+	}
+
+	if cfg.Heartbeat <= 0 {
+
+		cfg.Heartbeat = defaultHeartbeat
+	}
+
+	if cfg.HistorySize < 1 {
+
+		cfg.HistorySize = defaultHistorySize
+	}
+
+	if cfg.MaxSubscribers < 1 {
+
+		cfg.MaxSubscribers = defaultMaxSubscribers
+	}
+	b.cfg = cfg
+	b.packets = make(chan Packet, baseChanBufs)
+	b.message = make(chan Message, baseChanBufs)
+	b.quit = make(chan struct{})
+	b.bundles = make(map[bundleKey]*Bundle)
+
+	if len(cfg.Password) > 0 {
+
+		b.crypt = newCrypter(cfg.Password)
+	}
+}
+
+// SetPassword changes the password while running. Packets encrypted with the key of the previous password are still accepted until the password is changed again, so a node can be switched first and its workers one by one afterwards without losing messages. Encryption can not be turned on or off while running.
+func (b *Base) SetPassword(
+	password []byte) error {
+
+	if len(password) == 0 {
+
+		return errNoPassword
+	}
+
+	if b.crypt == nil {
+
+		return errors.New("encryption was not enabled at start")
+	}
+	b.crypt.rotate(password)
+	return nil
+}
+
 // Start attempts to open a listener and commences receiving packets and assembling them into messages
 func (b *Base) Start() (err error) {
 
@@ -35,39 +79,36 @@ func (b *Base) Start() (err error) {
 
 	if err != nil {
 
-		panic(err)
+		return
 	}
 	b.listener, err = net.ListenUDP(uNet, addr)
 
 	if err != nil {
 
-		panic(err)
+		return
 	}
+	b.wg.Add(3)
 
 	// Start up reader to push packets into packet channel
 	go b.readFromSocket()
 	go b.processPackets()
-	go b.processBundles()
 	go func() {
 
+		defer b.wg.Done()
+
 		for {
 
 			select {
 
 			case <-b.quit:
-
-				// fmt.Println("chan:<-b.quit")
-				break
-			default:
-			}
-			select {
-
+				return
 			case msg := <-b.message:
 
-				// fmt.Println("chan:msg := <-b.message")
-				go b.cfg.Handler(msg)
-				continue
-			default:
+				// Messages are handled one at a time so that they are seen in sequence order
+				if b.cfg.Handler != nil {
+
+					b.cfg.Handler(msg)
+				}
 			}
 		}
 	}()
@@ -77,170 +118,275 @@ func (b *Base) Start() (err error) {
 // Stop shuts down the listener
 func (b *Base) Stop() {
 
-	b.quit <- true
+	close(b.quit)
 	b.listener.Close()
+	b.wg.Wait()
+}
+
+// Addr returns the address the listener is bound to
+func (b *Base) Addr() *net.UDPAddr {
+
+	return b.listener.LocalAddr().(*net.UDPAddr)
+}
+
+// Stats returns a copy of the counters of the Base
+func (b *Base) Stats() Stats {
+
+	b.Lock()
+	defer b.Unlock()
+	return b.stats
 }
 func (b *Base) readFromSocket() {
 
-	for {
+	defer b.wg.Done()
 
-		select {
+	for {
 
-		case <-b.quit:
-			break
-		default:
-		}
 		var data = make([]byte, b.cfg.BufferSize)
-		count, _, err := b.listener.ReadFromUDP(data[0:])
+		count, sender, err := b.listener.ReadFromUDP(data[0:])
 
 		if err != nil {
 
+			select {
+
+			case <-b.quit:
+				return
+			default:
+			}
 			continue
 		}
+		data = data[:count]
 
-		if count > 12 {
+		if b.filter != nil && b.filter(data) {
+
+			continue
+		}
+		var key *cipherKey
 
-			data = data[:count]
-			sender := string(data[:6])
-			body := data[:count-4]
-			check := data[count-4:]
-			checkSum := binary.LittleEndian.Uint32(check)
-			cs := crc32.Checksum(body, crc32.MakeTable(crc32.Castagnoli))
+		if b.crypt != nil {
 
-			if cs != checkSum {
+			data, key, err = b.crypt.open(data, time.Now())
+		}
+		var p Packet
 
-				continue
-			}
-			b.packets <- Packet{
-				sender: sender,
-				bytes:  data,
-			}
+		if err == nil {
+
+			p, err = decodePacket(data)
+		}
+		b.Lock()
+		b.stats.PacketsReceived++
+
+		switch err {
+
+		case nil:
+		case errReplay, errStale:
+			b.stats.PacketsReplayed++
+		case errUnknownKey, errUnauthentic:
+			b.stats.PacketsUnauthentic++
+		default:
+			b.stats.PacketsInvalid++
+		}
+		b.Unlock()
+
+		if err != nil {
+
+			continue
+		}
+		p.sender = sender
+		p.key = key
+
+		select {
+
+		case b.packets <- p:
+		case <-b.quit:
+			return
 		}
 	}
 }
+
+// processPackets assembles data packets into messages and passes the other packets to the handler of the node or worker. All protocol state is changed from this goroutine only.
 func (b *Base) processPackets() {
 
+	defer b.wg.Done()
+	ticker := time.NewTicker(tickInterval)
+	defer ticker.Stop()
+
 	for {
 
 		select {
 
 		case <-b.quit:
-			break
-		default:
+			return
+		case p := <-b.packets:
+
+			if p.kind == kindData {
+
+				b.addPiece(p, time.Now())
+			} else if b.handle != nil {
+
+				b.handle(p)
+			}
+		case now := <-ticker.C:
+			b.expireBundles(now)
+
+			if b.tick != nil {
+
+				b.tick(now)
+			}
 		}
-		select {
+	}
+}
 
-		case p := <-b.packets:
-			sender := string(p.bytes[:6])
-			go func() {
+// addPiece adds a data packet to the bundle of its message and decodes the message as soon as enough pieces have arrived
+func (b *Base) addPiece(
+	p Packet, now time.Time) {
 
-				for {
+	if len(p.bytes) < 5 {
 
-					select {
+		b.Lock()
+		b.stats.PacketsInvalid++
+		b.Unlock()
+		return
+	}
+	key := bundleKey{p.sender.String(), p.epoch, p.channel, p.seq}
+	bundle, ok := b.bundles[key]
+
+	if !ok {
+
+		bundle = &Bundle{
+			sender:   key.sender,
+			epoch:    p.epoch,
+			channel:  p.channel,
+			seq:      p.seq,
+			received: now,
+			numbers:  make(map[byte]bool),
+		}
+		b.bundles[key] = bundle
+	}
+	number := p.bytes[0]
 
-					case <-b.doneRet:
+	if bundle.numbers[number] || int(number) >= rsTotal {
 
-						for i := range b.returning {
+		return
+	}
+	bundle.numbers[number] = true
 
-							b.incoming <- i
-						}
-						break
-					case <-b.returning:
-						continue
-					case <-b.trash:
-						continue
-					}
-				}
-			}()
+	if bundle.decoded {
 
-			for bi := range b.incoming {
+		return
+	}
+	bundle.packets = append(bundle.packets, p.bytes)
 
-				if bi.sender == sender {
+	if len(bundle.packets) < rsRequired {
 
-					bi.packets = append(bi.packets, p.bytes)
-					b.returning <- bi
-					break
-				}
+		return
+	}
+	data, err := rsDecode(bundle.packets)
 
-				if len(bi.packets) > 2 {
+	if err != nil {
 
-					b.incoming <- bi
-					continue
-				}
+		return
+	}
+	data, err = unpadData(data)
 
-				if bi.received.Sub(time.Now()) > latencyMax {
+	if err != nil {
 
-					b.trash <- bi
-					break
-				} else {
+		return
+	}
+	bundle.decoded = true
+	bundle.packets = nil
+
+	if b.deliver != nil {
+
+		b.deliver(Message{
+			Channel:  p.channel,
+			Seq:      p.seq,
+			Sender:   key.sender,
+			Epoch:    p.epoch,
+			Received: bundle.received,
+			Bytes:    data,
+		})
+	}
+}
+
+// expireBundles drops the bundles older than latencyMax and counts the pieces that never arrived. A message that was decoded even though pieces were missing has been recovered by FEC
+func (b *Base) expireBundles(
+	now time.Time) {
+
+	b.Lock()
+	defer b.Unlock()
+
+	for key, bundle := range b.bundles {
+
+		if now.Sub(bundle.received) < latencyMax {
 
-					b.incoming <- bi
-				}
-				b.doneRet <- true
-			}
 			continue
 		}
+		b.stats.PiecesExpected += uint64(rsTotal)
+		b.stats.PiecesReceived += uint64(len(bundle.numbers))
+
+		if bundle.decoded && len(bundle.numbers) < rsTotal {
+
+			b.stats.FECRecovered++
+		}
+		delete(b.bundles, key)
 	}
 }
-func (b *Base) processBundles() {
 
-	for {
+// sendPacket sends one packet to an address from the listener, so that replies come back to it. When encryption is enabled the packet is encrypted with key, or the current key if key is nil
+func (b *Base) sendPacket(
+	addr *net.UDPAddr, key *cipherKey, kind byte, channel uint16, seq uint64,
+	body []byte) (err error) {
 
-		select {
+	data := encodePacket(kind, b.epoch, channel, seq, body)
 
-		case <-b.quit:
-			break
-		default:
-		}
-		var uuid int32
-		select {
+	if b.crypt != nil {
+
+		data = b.crypt.seal(key, data, time.Now())
+	}
+	_, err = b.listener.WriteToUDP(data, addr)
+	return
+}
 
-		case bundle := <-b.incoming:
-			data, err := rsDecode(bundle.packets)
+// sendMessage sends all FEC pieces of a message to an address
+func (b *Base) sendMessage(
+	addr *net.UDPAddr, key *cipherKey, channel uint16, seq uint64,
+	pieces [][]byte) (err error) {
 
-			if err == nil &&
+	for i := range pieces {
 
-				bundle.uuid != uuid {
+		if e := b.sendPacket(addr, key, kindData, channel, seq, pieces[i]); e != nil {
 
-				rand.Seed(time.Now().Unix())
-				uuid = rand.Int31()
-				b.message <- Message{
-					uuid:      bundle.uuid,
-					sender:    bundle.sender,
-					timestamp: bundle.received,
-					bytes:     data,
-				}
-				uuid = bundle.uuid
-				b.trash <- bundle
-			}
+			err = e
 		}
 	}
+	return
 }
 
-// Send a message of up to maxMessageSize bytes to a given UDP address
-func (b *Base) Send(data []byte, addr *net.UDPAddr) (err error) {
+// encodeMessage splits a message of up to maxMessageSize bytes into FEC pieces
+func encodeMessage(
+	data []byte) ([][]byte, error) {
 
-	if len(data) > 3072 {
+	if len(data)+2 > maxMessageSize {
 
-		err = errors.New("maximum message size is " + fmt.Sprint(maxMessageSize) + " bytes")
+		return nil, errors.New("maximum message size is " + fmt.Sprint(maxMessageSize-2) + " bytes")
 	}
-	addr, err = net.ResolveUDPAddr(uNet, addr.String())
-
-	if err != nil {
+	return rsEncode(data), nil
+}
 
-		panic(err)
-	}
-	conn, err := net.DialUDP(uNet, nil, addr)
+// unpadData removes the length prefix and padding added by padData
+func unpadData(
+	data []byte) ([]byte, error) {
 
-	if err != nil {
+	if len(data) < 2 {
 
-		panic(err)
+		return nil, errBadBody
 	}
-	_, err = conn.Write(data)
+	dataLen := int(binary.LittleEndian.Uint16(data))
 
-	if err != nil {
+	if dataLen > len(data)-2 {
 
-		panic(err)
+		return nil, errBadBody
 	}
-	return
+	return data[2 : dataLen+2], nil
 }
@@ -1,10 +1,409 @@
 package sub
 
 import (
+	"bytes"
+	"fmt"
+	"net"
+	"sync"
 	"testing"
+	"time"
 )
 
-func TestBase(
+// testWait is how long the tests wait for messages to arrive
+const testWait = 5 * time.Second
+
+// collector records the messages passed to a handler
+
+type collector struct {
+	sync.Mutex
+	messages []Message
+}
+
+func (c *collector) handle(m Message) {
+
+	c.Lock()
+	c.messages = append(c.messages, m)
+	c.Unlock()
+}
+
+func (c *collector) seqs() (out []uint64) {
+
+	c.Lock()
+	defer c.Unlock()
+
+	for _, m := range c.messages {
+
+		out = append(out, m.Seq)
+	}
+	return
+}
+
+// waitFor polls cond until it returns true or testWait has passed
+func waitFor(
+	t *testing.T, what string, cond func() bool) {
+
+	deadline := time.Now().Add(testWait)
+
+	for !cond() {
+
+		if time.Now().After(deadline) {
+
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+// testPair starts a node and a worker subscribed to it on the loopback interface. The filter, if not nil, is applied to the packets received by the worker.
+func testPair(
+	t *testing.T, cfg, wcfg BaseCfg, filter func(p Packet) bool) (*Node, *Worker, *collector) {
+
+	cfg.Listener = "127.0.0.1:0"
+	n := NewNode(cfg)
+
+	if err := n.Start(); err != nil {
+
+		t.Fatal(err)
+	}
+	c := &collector{}
+	wcfg.Listener = "127.0.0.1:0"
+	wcfg.Handler = c.handle
+	w, err := NewWorker(wcfg, n.Addr().String())
+
+	if err != nil {
+
+		t.Fatal(err)
+	}
+
+	if filter != nil {
+
+		w.filter = func(data []byte) bool {
+
+			p, err := decodePacket(data)
+			return err == nil && filter(p)
+		}
+	}
+
+	if err := w.Start(); err != nil {
+
+		t.Fatal(err)
+	}
+	waitFor(t, "subscription", func() bool {
+
+		return len(n.Subscribers()) == 1 && w.Connected()
+	})
+	return n, w, c
+}
+
+// publish publishes count messages on a channel, each holding its index
+func publish(
+	t *testing.T, n *Node, channel uint16, count int) {
+
+	for i := 0; i < count; i++ {
+
+		if _, err := n.Publish(channel, []byte(fmt.Sprint("message ", i))); err != nil {
+
+			t.Fatal(err)
+		}
+	}
+}
+
+// checkSeqs fails the test if the collected sequence numbers are not want
+func checkSeqs(
+	t *testing.T, c *collector, want []uint64) {
+
+	got := c.seqs()
+
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+
+		t.Errorf("got messages %v, want %v", got, want)
+	}
+}
+
+func TestPacket(
+	t *testing.T) {
+
+	data := encodePacket(kindNack, 9, 7, 42, encodeSeqs([]uint64{3, 5}))
+	p, err := decodePacket(data)
+
+	if err != nil {
+
+		t.Fatal(err)
+	}
+	seqs, err := decodeSeqs(p.bytes)
+
+	if err != nil || p.kind != kindNack || p.epoch != 9 || p.channel != 7 || p.seq != 42 ||
+		fmt.Sprint(seqs) != "[3 5]" {
+
+		t.Errorf("decoded %+v with seqs %v, error %v", p, seqs, err)
+	}
+	tampered := append([]byte{}, data...)
+	tampered[headerSize] ^= 1
+
+	if _, err := decodePacket(tampered); err != errChecksum {
+
+		t.Errorf("tampered packet: got error %v, want %v", err, errChecksum)
+	}
+
+	if _, err := decodePacket(data[:headerSize]); err != errShortPacket {
+
+		t.Errorf("truncated packet: got error %v, want %v", err, errShortPacket)
+	}
+
+	if _, err := decodeSeqs(make([]byte, 8*(maxNackSeqs+1))); err == nil {
+
+		t.Error("accepted oversized retransmit request")
+	}
+}
+
+func TestDelivery(
+	t *testing.T) {
+
+	n, w, c := testPair(t, BaseCfg{}, BaseCfg{}, nil)
+	defer n.Stop()
+	defer w.Stop()
+	publish(t, n, 1, 3)
+	waitFor(t, "messages", func() bool { return len(c.seqs()) == 3 })
+	checkSeqs(t, c, []uint64{1, 2, 3})
+
+	if !bytes.Equal(c.messages[1].Bytes, []byte("message 1")) || c.messages[1].Channel != 1 {
+
+		t.Errorf("got message %+v", c.messages[1])
+	}
+
+	if _, err := n.Publish(1, make([]byte, maxMessageSize)); err == nil {
+
+		t.Error("published oversized message")
+	}
+}
+
+func TestRetransmit(
+	t *testing.T) {
+
+	// The first transmission of message 2 is lost entirely, and six of the nine pieces of message 3 are lost for good.
+	var mx sync.Mutex
+	dropped := 0
+	n, w, c := testPair(t, BaseCfg{}, BaseCfg{}, func(p Packet) bool {
+
+		if p.kind != kindData {
+
+			return false
+		}
+		mx.Lock()
+		defer mx.Unlock()
+
+		switch {
+
+		case p.seq == 2 && dropped < rsTotal:
+			dropped++
+			return true
+		case p.seq == 3 && p.bytes[0] >= byte(rsRequired):
+			return true
+		}
+		return false
+	})
+	defer n.Stop()
+	defer w.Stop()
+	publish(t, n, 1, 4)
+	waitFor(t, "messages", func() bool { return len(c.seqs()) == 4 })
+	checkSeqs(t, c, []uint64{1, 2, 3, 4})
+	waitFor(t, "bundle expiry", func() bool {
+
+		stats := w.Stats()
+		return stats.FECRecovered == 1
+	})
+	stats := w.Stats()
+
+	if stats.Recovered != 1 || stats.NacksSent < 1 || stats.Lost != 0 || stats.Delivered != 4 {
+
+		t.Errorf("unexpected worker stats %+v", stats)
+	}
+
+	if stats.PacketLossRate() <= 0 || stats.LossRate() != 0 {
+
+		t.Errorf("got packet loss %f and message loss %f", stats.PacketLossRate(), stats.LossRate())
+	}
+
+	if ns := n.Stats(); ns.Retransmitted != 1 || ns.NacksReceived < 1 || ns.Published != 4 {
+
+		t.Errorf("unexpected node stats %+v", ns)
+	}
+}
+
+func TestGone(
 	t *testing.T) {
 
+	// Message 1 never arrives and has left the shorter history of the node by the time it is requested.
+	n, w, c := testPair(t, BaseCfg{HistorySize: 2}, BaseCfg{}, func(p Packet) bool {
+
+		return p.kind == kindData && p.seq == 1
+	})
+	defer n.Stop()
+	defer w.Stop()
+	publish(t, n, 1, 3)
+	waitFor(t, "messages", func() bool { return len(c.seqs()) == 2 })
+	checkSeqs(t, c, []uint64{2, 3})
+
+	if stats := w.Stats(); stats.Lost != 1 || stats.LossRate() == 0 {
+
+		t.Errorf("unexpected worker stats %+v", stats)
+	}
+}
+
+func TestTailLoss(
+	t *testing.T) {
+
+	// The first transmission of the last message is lost, which is noticed from the confirmation of the next heartbeat.
+	var mx sync.Mutex
+	dropped := 0
+	cfg := BaseCfg{Heartbeat: 50 * time.Millisecond}
+	n, w, c := testPair(t, cfg, cfg, func(p Packet) bool {
+
+		mx.Lock()
+		defer mx.Unlock()
+
+		if p.kind == kindData && p.seq == 2 && dropped < rsTotal {
+
+			dropped++
+			return true
+		}
+		return false
+	})
+	defer n.Stop()
+	defer w.Stop()
+	publish(t, n, 5, 2)
+	waitFor(t, "messages", func() bool { return len(c.seqs()) == 2 })
+	checkSeqs(t, c, []uint64{1, 2})
+
+	if stats := w.Stats(); stats.Recovered != 1 {
+
+		t.Errorf("unexpected worker stats %+v", stats)
+	}
+}
+
+func TestNodeRestart(
+	t *testing.T) {
+
+	// A restarted node numbers its messages from 1 again, which the worker must deliver rather than drop as old.
+	cfg := BaseCfg{Heartbeat: 50 * time.Millisecond}
+	n, w, c := testPair(t, cfg, cfg, nil)
+	defer w.Stop()
+	publish(t, n, 1, 3)
+	waitFor(t, "messages", func() bool { return len(c.seqs()) == 3 })
+	n.Stop()
+	cfg.Listener = n.Addr().String()
+	restarted := NewNode(cfg)
+
+	if err := restarted.Start(); err != nil {
+
+		t.Fatal(err)
+	}
+	defer restarted.Stop()
+	waitFor(t, "subscription", func() bool { return len(restarted.Subscribers()) == 1 })
+	publish(t, restarted, 1, 2)
+	waitFor(t, "messages", func() bool { return len(c.seqs()) == 5 })
+	checkSeqs(t, c, []uint64{1, 2, 3, 1, 2})
+
+	if stats := w.Stats(); stats.NodeRestarts != 1 || stats.Lost != 0 {
+
+		t.Errorf("unexpected worker stats %+v", stats)
+	}
+}
+
+func TestSkip(
+	t *testing.T) {
+
+	// A message with a sequence number far ahead makes the worker give up on everything before the history of the node without stepping through each sequence number.
+	w, err := NewWorker(BaseCfg{HistorySize: 4}, "127.0.0.1:1")
+
+	if err != nil {
+
+		t.Fatal(err)
+	}
+	sender := w.node.String()
+	w.receive(Message{Channel: 1, Seq: 1, Sender: sender})
+	w.receive(Message{Channel: 1, Seq: 3, Sender: sender})
+	far := uint64(1) << 62
+	done := make(chan struct{})
+
+	go func() {
+
+		w.receive(Message{Channel: 1, Seq: far, Sender: sender})
+		close(done)
+	}()
+
+	select {
+
+	case <-done:
+	case <-time.After(testWait):
+		t.Fatal("timed out skipping to a far sequence number")
+	}
+	var delivered []uint64
+
+	for len(w.message) > 0 {
+
+		delivered = append(delivered, (<-w.message).Seq)
+	}
+
+	if fmt.Sprint(delivered) != "[1 3]" {
+
+		t.Errorf("got messages %v, want [1 3]", delivered)
+	}
+	s := w.channels[1]
+
+	if s.next != far-3 || len(s.pending) != 1 || len(s.missing) != 0 {
+
+		t.Errorf("got next %d with %d pending and %d missing, want next %d with 1 pending",
+			s.next, len(s.pending), len(s.missing), far-3)
+	}
+
+	if stats := w.Stats(); stats.Lost != far-3-1-2 {
+
+		t.Errorf("got %d lost, want %d", stats.Lost, far-3-1-2)
+	}
+}
+
+func TestSubscribers(
+	t *testing.T) {
+
+	n := NewNode(BaseCfg{
+		Listener:       "127.0.0.1:0",
+		Heartbeat:      50 * time.Millisecond,
+		MaxSubscribers: 1,
+	})
+
+	if err := n.Start(); err != nil {
+
+		t.Fatal(err)
+	}
+	defer n.Stop()
+	subscribe := func() *net.UDPConn {
+
+		conn, err := net.DialUDP(uNet, nil, n.Addr())
+
+		if err != nil {
+
+			t.Fatal(err)
+		}
+
+		if _, err = conn.Write(encodePacket(kindSubscribe, 0, 0, 0, nil)); err != nil {
+
+			t.Fatal(err)
+		}
+		return conn
+	}
+	first := subscribe()
+	defer first.Close()
+	waitFor(t, "subscription", func() bool { return len(n.Subscribers()) == 1 })
+	second := subscribe()
+	defer second.Close()
+	waitFor(t, "refusal", func() bool { return n.Stats().SubscribersRefused == 1 })
+
+	// Without heartbeats the subscriber is dropped after three heartbeat intervals.
+	waitFor(t, "expiry", func() bool { return len(n.Subscribers()) == 0 })
+
+	if stats := n.Stats(); stats.SubscribersExpired != 1 || stats.Subscribers != 0 {
+
+		t.Errorf("unexpected node stats %+v", stats)
+	}
 }
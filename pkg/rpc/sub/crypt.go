@@ -0,0 +1,189 @@
+package sub
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// AES-256-GCM authenticated encryption of packets with a key derived from the pre-shared password
+
+const (
+
+	// keyIDSize is the size of the key identifier at the start of an encrypted packet
+	keyIDSize = 4
+
+	// nonceSize is the size of the GCM nonce that follows the key identifier. It holds the send time in nanoseconds and a counter
+	nonceSize = 12
+
+	// sealOverhead is the number of bytes encryption adds to a packet
+	sealOverhead = keyIDSize + nonceSize + 16
+
+	// replayWindow is how far the send time of a packet may be from the local time. Nonces are remembered for this long, so a packet can be accepted only once
+	replayWindow = 30 * time.Second
+)
+
+var (
+	errUnknownKey  = errors.New("packet encrypted with unknown key")
+	errUnauthentic = errors.New("packet failed authentication")
+	errReplay      = errors.New("packet was replayed")
+	errStale       = errors.New("packet send time outside of replay window")
+	errNoPassword  = errors.New("password is empty")
+	keySalt        = []byte("parallelcoin pkg/rpc/sub")
+)
+
+// cipherKey is a key derived from a password
+
+type cipherKey struct {
+	id   [keyIDSize]byte
+	aead cipher.AEAD
+}
+
+// deriveKey derives the packet encryption key from a password using argon2id
+func deriveKey(
+	password []byte) *cipherKey {
+
+	key := argon2.IDKey(password, keySalt, 1, 32*1024, 1, 32)
+	block, err := aes.NewCipher(key)
+
+	if err != nil {
+
+		panic(err)
+	}
+	aead, err := cipher.NewGCM(block)
+
+	if err != nil {
+
+		panic(err)
+	}
+	k := &cipherKey{aead: aead}
+	id := sha256.Sum256(key)
+	copy(k.id[:], id[:])
+	return k
+}
+
+// crypter seals and opens packets. It accepts packets sealed with the current or the previous key, so that the two ends of a link do not have to change the password at the same moment
+
+type crypter struct {
+	sync.Mutex
+	current   *cipherKey
+	previous  *cipherKey
+	counter   uint32
+	seen      map[[nonceSize]byte]time.Time
+	lastPrune time.Time
+}
+
+// newCrypter returns a crypter for a password
+func newCrypter(
+	password []byte) *crypter {
+
+	c := &crypter{
+		current: deriveKey(password),
+		seen:    make(map[[nonceSize]byte]time.Time),
+	}
+	// The counter starts at a random value so that two senders sharing the key are unlikely to produce the same nonce even within the same nanosecond
+	var start [4]byte
+	rand.Read(start[:])
+	c.counter = binary.LittleEndian.Uint32(start[:])
+	return c
+}
+
+// rotate makes a key derived from password the current key and keeps the current key as the previous one
+func (c *crypter) rotate(
+	password []byte) {
+
+	k := deriveKey(password)
+	c.Lock()
+	c.previous, c.current = c.current, k
+	c.Unlock()
+}
+
+// seal encrypts a packet with key, or the current key if key is nil
+func (c *crypter) seal(
+	key *cipherKey, data []byte, now time.Time) []byte {
+
+	c.Lock()
+
+	if key == nil {
+
+		key = c.current
+	}
+	c.counter++
+	counter := c.counter
+	c.Unlock()
+	out := make([]byte, keyIDSize+nonceSize, sealOverhead+len(data))
+	copy(out, key.id[:])
+	nonce := out[keyIDSize:]
+	binary.LittleEndian.PutUint64(nonce, uint64(now.UnixNano()))
+	binary.LittleEndian.PutUint32(nonce[8:], counter)
+	return key.aead.Seal(out, nonce, data, out[:keyIDSize])
+}
+
+// open authenticates and decrypts a packet, refusing packets that were sent outside the replay window or have been seen before, and returns the key it was sealed with
+func (c *crypter) open(
+	data []byte, now time.Time) ([]byte, *cipherKey, error) {
+
+	if len(data) < sealOverhead {
+
+		return nil, nil, errShortPacket
+	}
+	c.Lock()
+	defer c.Unlock()
+	var key *cipherKey
+
+	for _, k := range []*cipherKey{c.current, c.previous} {
+
+		if k != nil && string(k.id[:]) == string(data[:keyIDSize]) {
+
+			key = k
+			break
+		}
+	}
+
+	if key == nil {
+
+		return nil, nil, errUnknownKey
+	}
+	var nonce [nonceSize]byte
+	copy(nonce[:], data[keyIDSize:keyIDSize+nonceSize])
+	out, err := key.aead.Open(nil, nonce[:], data[keyIDSize+nonceSize:], data[:keyIDSize])
+
+	if err != nil {
+
+		return nil, nil, errUnauthentic
+	}
+	sent := time.Unix(0, int64(binary.LittleEndian.Uint64(nonce[:8])))
+
+	if sent.Before(now.Add(-replayWindow)) || sent.After(now.Add(replayWindow)) {
+
+		return nil, nil, errStale
+	}
+
+	if _, ok := c.seen[nonce]; ok {
+
+		return nil, nil, errReplay
+	}
+	c.seen[nonce] = sent
+
+	// Nonces older than the window are refused by their send time, so they no longer need to be remembered
+	if now.Sub(c.lastPrune) > time.Second {
+
+		c.lastPrune = now
+
+		for n, t := range c.seen {
+
+			if t.Before(now.Add(-replayWindow)) {
+
+				delete(c.seen, n)
+			}
+		}
+	}
+	return out, key, nil
+}
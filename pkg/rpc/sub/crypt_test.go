@@ -0,0 +1,167 @@
+package sub
+
+import (
+	"net"
+	"testing"
+	"time"
+)
+
+func TestSealOpen(
+	t *testing.T) {
+
+	sender, receiver := newCrypter([]byte("password")), newCrypter([]byte("password"))
+	now := time.Now()
+	packet := encodePacket(kindSubscribe, 0, 0, 0, nil)
+	sealed := sender.seal(nil, packet, now)
+	opened, key, err := receiver.open(sealed, now)
+
+	if err != nil || string(opened) != string(packet) || key != receiver.current {
+
+		t.Fatalf("got %x with error %v, want %x", opened, err, packet)
+	}
+	modify := func(f func(b []byte) []byte) []byte {
+
+		return f(append([]byte{}, sender.seal(nil, packet, now)...))
+	}
+	tests := []struct {
+		name string
+		data []byte
+		want error
+	}{
+		{"replayed", sealed, errReplay},
+		{"tampered", modify(func(b []byte) []byte {
+
+			b[keyIDSize+nonceSize] ^= 1
+			return b
+		}), errUnauthentic},
+		{"tampered nonce", modify(func(b []byte) []byte {
+
+			b[keyIDSize+8] ^= 1
+			return b
+		}), errUnauthentic},
+		{"tampered key id", modify(func(b []byte) []byte {
+
+			b[0] ^= 1
+			return b
+		}), errUnknownKey},
+		{"truncated tag", modify(func(b []byte) []byte {
+
+			return b[:len(b)-1]
+		}), errUnauthentic},
+		{"truncated", modify(func(b []byte) []byte {
+
+			return b[:sealOverhead-1]
+		}), errShortPacket},
+		{"stale", sender.seal(nil, packet, now.Add(-2*replayWindow)), errStale},
+		{"future", sender.seal(nil, packet, now.Add(2*replayWindow)), errStale},
+		{"other password", newCrypter([]byte("other")).seal(nil, packet, now), errUnknownKey},
+	}
+
+	for _, test := range tests {
+
+		if _, _, err := receiver.open(test.data, now); err != test.want {
+
+			t.Errorf("%s: got error %v, want %v", test.name, err, test.want)
+		}
+	}
+}
+
+func TestRotate(
+	t *testing.T) {
+
+	sender, receiver := newCrypter([]byte("first")), newCrypter([]byte("first"))
+	now := time.Now()
+	check := func(what string, want error) {
+
+		if _, _, err := receiver.open(sender.seal(nil, []byte("packet"), now), now); err != want {
+
+			t.Errorf("%s: got error %v, want %v", what, err, want)
+		}
+	}
+	receiver.rotate([]byte("second"))
+	check("previous key", nil)
+	sender.rotate([]byte("second"))
+	check("current key", nil)
+	receiver.rotate([]byte("third"))
+	check("previous key after second rotation", nil)
+	sender.rotate([]byte("first"))
+	check("key two rotations old", errUnknownKey)
+}
+
+func TestEncryptedLink(
+	t *testing.T) {
+
+	cfg := BaseCfg{Password: []byte("first"), Heartbeat: 50 * time.Millisecond}
+	n, w, c := testPair(t, cfg, cfg, nil)
+	defer n.Stop()
+	defer w.Stop()
+	publish(t, n, 1, 2)
+	waitFor(t, "messages", func() bool { return len(c.seqs()) == 2 })
+
+	// An authentic packet is accepted only once.
+	conn, err := net.DialUDP(uNet, nil, w.Addr())
+
+	if err != nil {
+
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	sealed := newCrypter(cfg.Password).seal(nil, encodePacket(kindGone, 0, 2, 0, nil), time.Now())
+
+	for i := 0; i < 2; i++ {
+
+		if _, err = conn.Write(sealed); err != nil {
+
+			t.Fatal(err)
+		}
+	}
+	waitFor(t, "replay", func() bool { return w.Stats().PacketsReplayed == 1 })
+
+	// After the node changes its password it keeps answering the worker with the old key until the worker changes too.
+	if err := n.SetPassword([]byte("second")); err != nil {
+
+		t.Fatal(err)
+	}
+	publish(t, n, 1, 1)
+	waitFor(t, "message after node rotation", func() bool { return len(c.seqs()) == 3 })
+
+	if err := w.SetPassword([]byte("second")); err != nil {
+
+		t.Fatal(err)
+	}
+	time.Sleep(2 * cfg.Heartbeat)
+	publish(t, n, 1, 1)
+	waitFor(t, "message after worker rotation", func() bool { return len(c.seqs()) == 4 })
+	checkSeqs(t, c, []uint64{1, 2, 3, 4})
+
+	if stats := w.Stats(); stats.Lost != 0 || stats.PacketsUnauthentic != 0 {
+
+		t.Errorf("unexpected worker stats %+v", stats)
+	}
+
+	// A worker with the wrong password can not subscribe.
+	intruder, err := NewWorker(BaseCfg{Listener: "127.0.0.1:0", Password: []byte("wrong")},
+		n.Addr().String())
+
+	if err != nil {
+
+		t.Fatal(err)
+	}
+
+	if err := intruder.Start(); err != nil {
+
+		t.Fatal(err)
+	}
+	defer intruder.Stop()
+	waitFor(t, "refusal", func() bool { return n.Stats().PacketsUnauthentic > 0 })
+
+	if subs := n.Subscribers(); len(subs) != 1 || intruder.Connected() {
+
+		t.Errorf("intruder subscribed, subscribers %v", subs)
+	}
+
+	if err := w.SetPassword(nil); err != errNoPassword {
+
+		t.Errorf("empty password: got error %v, want %v", err, errNoPassword)
+	}
+}
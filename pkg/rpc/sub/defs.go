@@ -2,6 +2,7 @@ package sub
 
 import (
 	"net"
+	"sync"
 	"time"
 )
 
@@ -17,8 +18,29 @@ var (
 	// default channel buffer sizes for Base
 	baseChanBufs = 128
 
-	// latency maximum
+	// latency maximum, after which the pieces of a message that have not arrived are counted as lost
 	latencyMax = time.Millisecond * 250
+
+	// tickInterval is how often a Base checks for expired bundles, missing messages and idle subscribers
+	tickInterval = time.Millisecond * 25
+
+	// defaultHeartbeat is how often a worker renews its subscription when no interval is configured
+	defaultHeartbeat = time.Second * 2
+
+	// defaultHistorySize is the number of messages per channel a node keeps for retransmission when no size is configured
+	defaultHistorySize = 256
+
+	// defaultMaxSubscribers is the number of subscribers a node accepts when no limit is configured
+	defaultMaxSubscribers = 1024
+
+	// nackInterval is how long a worker waits for a requested retransmission before asking again
+	nackInterval = time.Millisecond * 100
+
+	// nackRetries is how many times a worker asks for a missing message before it gives up on it
+	nackRetries = 5
+
+	// maxNackSeqs is the largest number of sequence numbers in one retransmit request
+	maxNackSeqs = 64
 )
 
 // BaseInterface is the core functions required for a Base
@@ -30,75 +52,126 @@ type BaseInterface interface {
 // BaseCfg is the configuration for a Base
 
 type BaseCfg struct {
-	Handler    func(message Message)
-	Listener   string
-	Password   []byte
+
+	// Handler is called with each message in sequence order of its channel
+	Handler func(message Message)
+
+	// Listener is the UDP address to listen on
+	Listener string
+
+	// Password is the pre-shared secret of the node and its workers. When it is set, all packets are encrypted and authenticated with a key derived from it, and packets that fail authentication or are replayed are dropped
+	Password []byte
+
+	// BufferSize is the size of the receive buffer, which must hold the largest packet
 	BufferSize int
+
+	// Heartbeat is how often workers renew their subscription. A node drops subscribers it has not heard from for three heartbeats
+	Heartbeat time.Duration
+
+	// HistorySize is the number of messages per channel a node keeps to answer retransmit requests
+	HistorySize int
+
+	// MaxSubscribers is the number of subscribers a node accepts at once
+	MaxSubscribers int
 }
 
 // Base is the common structure between a worker and a node
 
 type Base struct {
-	cfg       BaseCfg
-	listener  *net.UDPConn
-	packets   chan Packet
-	incoming  chan Bundle
-	returning chan Bundle
-	trash     chan Bundle
-	doneRet   chan bool
-	message   chan Message
-	quit      chan bool
+	sync.Mutex
+	cfg      BaseCfg
+	listener *net.UDPConn
+	packets  chan Packet
+	message  chan Message
+	quit     chan struct{}
+	wg       sync.WaitGroup
+	bundles  map[bundleKey]*Bundle
+	stats    Stats
+	crypt    *crypter
+	// epoch is sent in the header of every packet. Nodes pick a random one when they are created, so their workers notice restarts, which start the sequence numbers again. Workers send zero
+	epoch uint32
+	// handle is called from the processing goroutine with each control packet
+	handle func(p Packet)
+	// deliver is called from the processing goroutine with each decoded message
+	deliver func(m Message)
+	// tick is called from the processing goroutine every tickInterval
+	tick func(now time.Time)
+	// filter drops received datagrams it returns true for, and is used by the tests to simulate a lossy link
+	filter func(data []byte) bool
 }
 
 // A Node is a server with some number of subscribers
 
 type Node struct {
 	Base
-	subscribers []*net.UDPAddr
+	subscribers map[string]*subscriber
+	channels    map[uint16]*history
 }
 
 // A Worker is a node that subscribes to a Node's messages
 
 type Worker struct {
 	Base
-	node *net.UDPAddr
+	node      *net.UDPAddr
+	nodeEpoch uint32 // epoch of the node the channels are sequenced for, zero until the node is first heard
+	lastHeard time.Time
+	lastSent  time.Time
+	channels  map[uint16]*sequencer
 }
 
-// Packet is the structure of individual encoded packets of the message. These are made from a 9/3 Reed Solomon code and 9 are sent in distinct packets and only 3 are required to guarantee retransmit-free delivery.
+// Packet is a received datagram. Data packets carry one of the 9 pieces of a 9/3 Reed Solomon encoded message, of which only 3 are required to reconstruct it, the other kinds carry control messages of the subscription and retransmit protocol.
 
 type Packet struct {
-	sender string // address packet was received from
-	bytes  []byte // raw FEC encoded bytes of packet
+	sender  *net.UDPAddr // address packet was received from
+	key     *cipherKey   // key the packet was encrypted with, if any
+	kind    byte         // kind of packet
+	epoch   uint32       // epoch of the sender
+	channel uint16       // channel of the message the packet refers to
+	seq     uint64       // sequence number of the message the packet refers to
+	bytes   []byte       // body of the packet
 }
 
-// A Bundle is a collection of the received packets received from the same sender with up to 9 pieces.
+// A Bundle is a collection of the received packets of one message from the same sender with up to 9 pieces.
 
 type Bundle struct {
-	uuid     int32
 	sender   string
+	epoch    uint32
+	channel  uint16
+	seq      uint64
 	received time.Time
 	packets  [][]byte
+	numbers  map[byte]bool
+	decoded  bool
 }
 
-// Message is the data reconstructed from a complete Bundle, containing data in messagepack format
+// bundleKey identifies the message a data packet belongs to
 
-type Message struct {
-	uuid      int32
-	sender    string
-	timestamp time.Time
-	bytes     []byte
+type bundleKey struct {
+	sender  string
+	epoch   uint32
+	channel uint16
+	seq     uint64
 }
 
-// Subscription is the message sent by a worker node to request updates from the node
+// Message is the data reconstructed from a complete Bundle
 
-type Subscription struct {
-	address string
-	pubKey  []byte
-}
+type Message struct {
+
+	// Channel is the channel the message was published on
+	Channel uint16
+
+	// Seq is the sequence number of the message in its channel, starting from 1
+	Seq uint64
+
+	// Sender is the address of the node that published the message
+	Sender string
+
+	// Epoch identifies the run of the node that published the message. It changes when the node is restarted, and the sequence numbers start from 1 again
+	Epoch uint32
 
-// Confirmation is the reply message for a subscription request
+	// Received is when the first piece of the message arrived
+	Received time.Time
 
-type Confirmation struct {
-	subscriber string // confirming address of subscriber
-	pubKey     []byte // public key of server for message verification
+	// Bytes is the payload of the message
+	Bytes []byte
 }
@@ -2,7 +2,11 @@
 //
 // To prevent retransmits for messages up to 3kb in size, data sent in a burst as 9 packets containing a 9/3 Reed Solomon encoding such that any 3 packets received guarantee retransmit-less delivery, covering the worst case for packet loss and corruption over a network
 //
-// Payload can be encrypted via AES-256 encryption using a pre-shared key known by both ends to function as both access control and security against eavesdropping and spoofing attacks.
+// Payload can be encrypted via AES-256 encryption using a pre-shared key known by both ends to function as both access control and security against eavesdropping and spoofing attacks. When a password is configured, every packet is sealed with AES-256-GCM under a key derived from the password with argon2id. Each packet carries its send time in its nonce, and receivers drop packets sent outside a short window around their own clock as well as nonces they have already seen, so captured packets can not be replayed. The password can be changed while running, and the key of the previous password stays valid until the next change, so that a node and its workers can be switched over one at a time.
 //
-// Authentication of data is done using an ED25119 EC key for which each known endpoint has shared the public key as part of the subscription request
+// Authentication of data is done using an ED25119 EC key for which each known endpoint has shared the public key as part of the subscription request.
+//
+// Messages are published on numbered channels, and each message carries a sequence number of its channel. Workers deliver messages in sequence order, and when a message is missing, because all but two of its pieces were lost, they request it again from the node, which keeps a bounded history of each channel for retransmission. Messages that have left the history are reported gone and skipped, so a worker never waits for a message that can not arrive. Every packet of a node carries a random epoch chosen when the node starts, and a worker that sees a new epoch starts its channels over, as a restarted node numbers its messages from 1 again.
+//
+// Workers renew their subscription every heartbeat, and the node confirms it with the last sequence number of each channel, which reveals lost messages even when no later message follows them. Nodes drop subscribers they have not heard from for three heartbeats.
 package sub
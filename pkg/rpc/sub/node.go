@@ -0,0 +1,249 @@
+package sub
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"net"
+	"time"
+)
+
+// subscriber is a worker subscribed to a node
+
+type subscriber struct {
+	addr     *net.UDPAddr
+	key      *cipherKey // key of the last packet of the subscriber, which it is answered with
+	lastSeen time.Time
+}
+
+// history is a ring of the most recent messages of a channel, kept to answer retransmit requests
+
+type history struct {
+	last   uint64
+	seqs   []uint64
+	pieces [][][]byte
+}
+
+// newHistory returns an empty history for size messages
+func newHistory(
+	size int) *history {
+
+	return &history{
+		seqs:   make([]uint64, size),
+		pieces: make([][][]byte, size),
+	}
+}
+
+// add stores the pieces of the next message and returns its sequence number, overwriting the oldest message once the ring is full
+func (h *history) add(
+	pieces [][]byte) uint64 {
+
+	h.last++
+	i := h.last % uint64(len(h.seqs))
+	h.seqs[i] = h.last
+	h.pieces[i] = pieces
+	return h.last
+}
+
+// get returns the pieces of a message if it is still in the ring
+func (h *history) get(
+	seq uint64) ([][]byte, bool) {
+
+	i := seq % uint64(len(h.seqs))
+
+	if seq == 0 || h.seqs[i] != seq {
+
+		return nil, false
+	}
+	return h.pieces[i], true
+}
+
+// NewNode creates a node that publishes messages to the workers that subscribe to it
+func NewNode(
+	cfg BaseCfg) (n *Node) {
+
+	n = &Node{
+		subscribers: make(map[string]*subscriber),
+		channels:    make(map[uint16]*history),
+	}
+	n.init(cfg)
+	n.epoch = newEpoch()
+	n.handle = n.handlePacket
+	n.tick = n.expireSubscribers
+	return
+}
+
+// newEpoch returns a random non-zero epoch for a node
+func newEpoch() (epoch uint32) {
+
+	var b [4]byte
+
+	for epoch == 0 {
+
+		rand.Read(b[:])
+		epoch = binary.LittleEndian.Uint32(b[:])
+	}
+	return
+}
+
+// Publish sends a message of up to maxMessageSize bytes to all subscribers on a channel and returns its sequence number. The message is kept in the history of the channel so that subscribers that lose it can request it again.
+func (n *Node) Publish(
+	channel uint16, data []byte) (seq uint64, err error) {
+
+	pieces, err := encodeMessage(data)
+
+	if err != nil {
+
+		return
+	}
+	n.Lock()
+	h, ok := n.channels[channel]
+
+	if !ok {
+
+		h = newHistory(n.cfg.HistorySize)
+		n.channels[channel] = h
+	}
+	seq = h.add(pieces)
+	n.stats.Published++
+	subs := make([]subscriber, 0, len(n.subscribers))
+
+	for _, s := range n.subscribers {
+
+		subs = append(subs, *s)
+	}
+	n.Unlock()
+
+	for _, s := range subs {
+
+		if e := n.sendMessage(s.addr, s.key, channel, seq, pieces); e != nil {
+
+			err = e
+		}
+	}
+	return
+}
+
+// Subscribers returns the addresses of the current subscribers
+func (n *Node) Subscribers() (out []string) {
+
+	n.Lock()
+	defer n.Unlock()
+
+	for addr := range n.subscribers {
+
+		out = append(out, addr)
+	}
+	return
+}
+
+// handlePacket answers the subscription and retransmit requests of workers
+func (n *Node) handlePacket(
+	p Packet) {
+
+	addr := p.sender.String()
+
+	switch p.kind {
+
+	case kindSubscribe:
+		n.Lock()
+		s, ok := n.subscribers[addr]
+
+		if !ok {
+
+			if len(n.subscribers) >= n.cfg.MaxSubscribers {
+
+				n.stats.SubscribersRefused++
+				n.Unlock()
+				return
+			}
+			s = &subscriber{addr: p.sender}
+			n.subscribers[addr] = s
+			n.stats.Subscribers = len(n.subscribers)
+		}
+		s.key = p.key
+		s.lastSeen = time.Now()
+		last := make(map[uint16]uint64, len(n.channels))
+
+		for channel, h := range n.channels {
+
+			last[channel] = h.last
+		}
+		n.Unlock()
+		n.sendPacket(p.sender, p.key, kindConfirm, 0, 0, encodeLast(last))
+	case kindUnsubscribe:
+		n.Lock()
+		delete(n.subscribers, addr)
+		n.stats.Subscribers = len(n.subscribers)
+		n.Unlock()
+	case kindNack:
+		seqs, err := decodeSeqs(p.bytes)
+
+		if err != nil {
+
+			return
+		}
+		n.Lock()
+		s, ok := n.subscribers[addr]
+
+		// Only subscribers may request retransmissions, as they are answered with many times the data of the request
+		if !ok {
+
+			n.Unlock()
+			return
+		}
+		s.key = p.key
+		s.lastSeen = time.Now()
+		n.stats.NacksReceived++
+		h := n.channels[p.channel]
+		var resend [][][]byte
+		var resendSeqs, gone []uint64
+
+		for _, seq := range seqs {
+
+			if h == nil {
+
+				gone = append(gone, seq)
+				continue
+			}
+
+			if pieces, ok := h.get(seq); ok {
+
+				resend = append(resend, pieces)
+				resendSeqs = append(resendSeqs, seq)
+			} else if seq <= h.last {
+
+				gone = append(gone, seq)
+			}
+		}
+		n.stats.Retransmitted += uint64(len(resend))
+		n.Unlock()
+
+		for i := range resend {
+
+			n.sendMessage(p.sender, p.key, p.channel, resendSeqs[i], resend[i])
+		}
+
+		if len(gone) > 0 {
+
+			n.sendPacket(p.sender, p.key, kindGone, p.channel, 0, encodeSeqs(gone))
+		}
+	}
+}
+
+// expireSubscribers drops the subscribers that have not renewed their subscription for three heartbeats
+func (n *Node) expireSubscribers(
+	now time.Time) {
+
+	n.Lock()
+	defer n.Unlock()
+
+	for addr, s := range n.subscribers {
+
+		if now.Sub(s.lastSeen) > 3*n.cfg.Heartbeat {
+
+			delete(n.subscribers, addr)
+			n.stats.SubscribersExpired++
+		}
+	}
+	n.stats.Subscribers = len(n.subscribers)
+}
@@ -0,0 +1,139 @@
+package sub
+
+import (
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+)
+
+// Packet kinds. Every datagram starts with a header of the kind, the epoch of the sender, the channel and the sequence number of the message it refers to, and ends with a CRC32 of the rest
+const (
+
+	// kindData carries one FEC piece of a message
+	kindData byte = iota + 1
+
+	// kindSubscribe is sent by a worker to subscribe and, repeated every heartbeat, to keep the subscription alive
+	kindSubscribe
+
+	// kindConfirm is the reply of a node to a subscribe, listing the last sequence number of each channel so a worker notices messages it missed. Its epoch tells a worker when the node was restarted
+	kindConfirm
+
+	// kindNack is sent by a worker to request the retransmission of the messages of a channel listed in its body
+	kindNack
+
+	// kindGone is the reply of a node to a retransmit request for messages that are no longer in its history
+	kindGone
+
+	// kindUnsubscribe is sent by a worker that is shutting down
+	kindUnsubscribe
+)
+
+// headerSize is the size of the kind, epoch, channel and sequence number at the start of a packet
+const headerSize = 15
+
+var (
+	errShortPacket = errors.New("packet too short")
+	errChecksum    = errors.New("packet checksum mismatch")
+	errBadBody     = errors.New("malformed packet body")
+	crcTable       = crc32.MakeTable(crc32.Castagnoli)
+)
+
+// encodePacket returns the datagram for a packet
+func encodePacket(
+	kind byte, epoch uint32, channel uint16, seq uint64, body []byte) (out []byte) {
+
+	out = make([]byte, headerSize, headerSize+len(body)+4)
+	out[0] = kind
+	binary.LittleEndian.PutUint32(out[1:5], epoch)
+	binary.LittleEndian.PutUint16(out[5:7], channel)
+	binary.LittleEndian.PutUint64(out[7:15], seq)
+	out = append(out, body...)
+	checkbytes := make([]byte, 4)
+	binary.LittleEndian.PutUint32(checkbytes, crc32.Checksum(out, crcTable))
+	return append(out, checkbytes...)
+}
+
+// decodePacket parses a datagram, checking its checksum
+func decodePacket(
+	data []byte) (p Packet, err error) {
+
+	if len(data) < headerSize+4 {
+
+		return p, errShortPacket
+	}
+	body := data[:len(data)-4]
+
+	if crc32.Checksum(body, crcTable) != binary.LittleEndian.Uint32(data[len(data)-4:]) {
+
+		return p, errChecksum
+	}
+	p.kind = body[0]
+	p.epoch = binary.LittleEndian.Uint32(body[1:5])
+	p.channel = binary.LittleEndian.Uint16(body[5:7])
+	p.seq = binary.LittleEndian.Uint64(body[7:15])
+	p.bytes = body[headerSize:]
+	return
+}
+
+// encodeSeqs returns the body of a nack or gone packet
+func encodeSeqs(
+	seqs []uint64) (out []byte) {
+
+	out = make([]byte, 8*len(seqs))
+
+	for i, seq := range seqs {
+
+		binary.LittleEndian.PutUint64(out[8*i:], seq)
+	}
+	return
+}
+
+// decodeSeqs parses the body of a nack or gone packet
+func decodeSeqs(
+	body []byte) (seqs []uint64, err error) {
+
+	if len(body)%8 != 0 || len(body)/8 > maxNackSeqs {
+
+		return nil, errBadBody
+	}
+	seqs = make([]uint64, len(body)/8)
+
+	for i := range seqs {
+
+		seqs[i] = binary.LittleEndian.Uint64(body[8*i:])
+	}
+	return
+}
+
+// encodeLast returns the body of a confirm packet from the last sequence number of each channel
+func encodeLast(
+	last map[uint16]uint64) (out []byte) {
+
+	out = make([]byte, 0, 10*len(last))
+
+	for channel, seq := range last {
+
+		entry := make([]byte, 10)
+		binary.LittleEndian.PutUint16(entry, channel)
+		binary.LittleEndian.PutUint64(entry[2:], seq)
+		out = append(out, entry...)
+	}
+	return
+}
+
+// decodeLast parses the body of a confirm packet
+func decodeLast(
+	body []byte) (last map[uint16]uint64, err error) {
+
+	if len(body)%10 != 0 {
+
+		return nil, errBadBody
+	}
+	last = make(map[uint16]uint64, len(body)/10)
+
+	for i := 0; i < len(body); i += 10 {
+
+		last[binary.LittleEndian.Uint16(body[i:])] = binary.LittleEndian.Uint64(body[i+2:])
+	}
+	return
+}
@@ -0,0 +1,78 @@
+package sub
+
+// Stats are the counters of a node or worker since it was started
+
+type Stats struct {
+
+	// PacketsReceived is the number of datagrams received
+	PacketsReceived uint64
+
+	// PacketsInvalid is the number of received datagrams that failed their checksum or could not be parsed
+	PacketsInvalid uint64
+
+	// PacketsUnauthentic is the number of received datagrams that were not encrypted with a known key or failed authentication
+	PacketsUnauthentic uint64
+
+	// PacketsReplayed is the number of authentic datagrams that were dropped because they had been received before or were sent outside the replay window
+	PacketsReplayed uint64
+
+	// PiecesExpected and PiecesReceived count the FEC pieces of the messages received, from which the packet loss rate is computed
+	PiecesExpected uint64
+	PiecesReceived uint64
+
+	// FECRecovered is the number of messages that were reconstructed although some of their pieces were lost
+	FECRecovered uint64
+
+	// Published is the number of messages published by a node
+	Published uint64
+
+	// Delivered is the number of messages passed to the handler of a worker
+	Delivered uint64
+
+	// Lost is the number of messages a worker gave up on, because the node no longer had them or did not answer retransmit requests
+	Lost uint64
+
+	// NacksSent is the number of retransmit requests sent by a worker
+	NacksSent uint64
+
+	// NacksReceived is the number of retransmit requests received by a node
+	NacksReceived uint64
+
+	// Retransmitted is the number of messages a node sent again on request
+	Retransmitted uint64
+
+	// Recovered is the number of messages a worker received after requesting their retransmission
+	Recovered uint64
+
+	// NodeRestarts is the number of times a worker noticed that its node was restarted and started its channels over
+	NodeRestarts uint64
+
+	// Subscribers is the number of current subscribers of a node
+	Subscribers int
+
+	// SubscribersExpired is the number of subscribers a node dropped because their heartbeat stopped
+	SubscribersExpired uint64
+
+	// SubscribersRefused is the number of subscriptions a node refused because it had reached MaxSubscribers
+	SubscribersRefused uint64
+}
+
+// LossRate returns the fraction of the messages due to a worker that were lost for good
+func (s *Stats) LossRate() float64 {
+
+	if s.Delivered+s.Lost == 0 {
+
+		return 0
+	}
+	return float64(s.Lost) / float64(s.Delivered+s.Lost)
+}
+
+// PacketLossRate returns the fraction of FEC pieces that never arrived
+func (s *Stats) PacketLossRate() float64 {
+
+	if s.PiecesExpected == 0 {
+
+		return 0
+	}
+	return 1 - float64(s.PiecesReceived)/float64(s.PiecesExpected)
+}
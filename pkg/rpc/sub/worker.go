@@ -0,0 +1,398 @@
+package sub
+
+import (
+	"net"
+	"sort"
+	"time"
+)
+
+// sequencer puts the messages of one channel in order and tracks the ones that are missing
+
+type sequencer struct {
+	next    uint64             // next sequence number to deliver
+	highest uint64             // highest sequence number known to exist
+	pending map[uint64]Message // received ahead of next, with empty placeholders for messages given up on
+	missing map[uint64]*nackState
+}
+
+// nackState is the retransmit request state of a missing message
+
+type nackState struct {
+	since   time.Time // when the message was noticed missing or last requested
+	retries int
+}
+
+// newSequencer returns a sequencer that delivers from sequence number next
+func newSequencer(
+	next uint64) *sequencer {
+
+	return &sequencer{
+		next:    next,
+		highest: next - 1,
+		pending: make(map[uint64]Message),
+		missing: make(map[uint64]*nackState),
+	}
+}
+
+// NewWorker creates a worker that subscribes to the node at the given address
+func NewWorker(
+	cfg BaseCfg, node string) (w *Worker, err error) {
+
+	w = &Worker{
+		channels: make(map[uint16]*sequencer),
+	}
+	w.node, err = net.ResolveUDPAddr(uNet, node)
+
+	if err != nil {
+
+		return nil, err
+	}
+	w.init(cfg)
+	w.handle = w.handlePacket
+	w.deliver = w.receive
+	w.tick = w.heartbeat
+	return
+}
+
+// Start opens the listener and subscribes to the node
+func (w *Worker) Start() (err error) {
+
+	if err = w.Base.Start(); err != nil {
+
+		return
+	}
+	w.lastSent = time.Now()
+	return w.sendPacket(w.node, nil, kindSubscribe, 0, 0, nil)
+}
+
+// Stop unsubscribes from the node and shuts down the listener
+func (w *Worker) Stop() {
+
+	w.sendPacket(w.node, nil, kindUnsubscribe, 0, 0, nil)
+	w.Base.Stop()
+}
+
+// Connected returns whether the node has confirmed the subscription within the last three heartbeats
+func (w *Worker) Connected() bool {
+
+	w.Lock()
+	defer w.Unlock()
+	return time.Since(w.lastHeard) <= 3*w.cfg.Heartbeat
+}
+
+// synced returns whether the node has confirmed the subscription at least once. It must only be called from the processing goroutine.
+func (w *Worker) synced() bool {
+
+	w.Lock()
+	defer w.Unlock()
+	return !w.lastHeard.IsZero()
+}
+
+// receive takes a decoded message from the node and delivers it and the messages it unblocks in sequence order
+func (w *Worker) receive(
+	m Message) {
+
+	if m.Sender != w.node.String() {
+
+		return
+	}
+	w.checkEpoch(m.Epoch)
+	s, ok := w.channels[m.Channel]
+
+	if !ok {
+
+		// A channel that did not exist when the subscription was confirmed starts from the first message, otherwise from the first message seen
+		next := m.Seq
+
+		if w.synced() {
+
+			next = 1
+		}
+		s = newSequencer(next)
+		w.channels[m.Channel] = s
+	}
+
+	if _, ok := s.pending[m.Seq]; ok || m.Seq < s.next {
+
+		return
+	}
+
+	if _, ok := s.missing[m.Seq]; ok {
+
+		delete(s.missing, m.Seq)
+
+		w.Lock()
+		w.stats.Recovered++
+		w.Unlock()
+	}
+	s.pending[m.Seq] = m
+
+	if m.Seq > s.highest {
+
+		s.highest = m.Seq
+	}
+	w.advance(s)
+}
+
+// advance delivers the pending messages of a channel that are next in sequence, giving up on messages that can no longer be retransmitted
+func (w *Worker) advance(
+	s *sequencer) {
+
+	// Messages further back than the history of the node can not be recovered
+	if s.highest >= s.next+uint64(w.cfg.HistorySize) {
+
+		w.skip(s, s.highest-uint64(w.cfg.HistorySize)+1)
+	}
+
+	for {
+
+		m, ok := s.pending[s.next]
+
+		if !ok {
+
+			return
+		}
+		delete(s.pending, s.next)
+		s.next++
+
+		if m.Seq == 0 {
+
+			continue
+		}
+
+		select {
+
+		case w.message <- m:
+		case <-w.quit:
+			return
+		}
+		w.Lock()
+		w.stats.Delivered++
+		w.Unlock()
+	}
+}
+
+// skip gives up on the missing messages of a channel before sequence number to, and delivers the messages before it that did arrive. It works through the pending and missing messages rather than every sequence number up to to, which may be far ahead when the packet that revealed it was forged
+func (w *Worker) skip(
+	s *sequencer, to uint64) {
+
+	lost := to - s.next
+	var seqs []uint64
+
+	for seq := range s.pending {
+
+		if seq < to {
+
+			seqs = append(seqs, seq)
+		}
+	}
+	sort.Slice(seqs, func(i, j int) bool { return seqs[i] < seqs[j] })
+
+	for seq := range s.missing {
+
+		if seq < to {
+
+			delete(s.missing, seq)
+		}
+	}
+	s.next = to
+
+	// Placeholders of messages that were given up on are already counted as lost
+	lost -= uint64(len(seqs))
+	w.Lock()
+	w.stats.Lost += lost
+	w.Unlock()
+
+	for _, seq := range seqs {
+
+		m := s.pending[seq]
+		delete(s.pending, seq)
+
+		if m.Seq == 0 {
+
+			continue
+		}
+
+		select {
+
+		case w.message <- m:
+		case <-w.quit:
+			return
+		}
+		w.Lock()
+		w.stats.Delivered++
+		w.Unlock()
+	}
+}
+
+// checkEpoch starts the channels over when a packet shows the node has been restarted since they were sequenced, as the new run of the node numbers its messages from 1 again
+func (w *Worker) checkEpoch(
+	epoch uint32) {
+
+	if epoch == w.nodeEpoch {
+
+		return
+	}
+
+	if w.nodeEpoch != 0 {
+
+		w.channels = make(map[uint16]*sequencer)
+
+		w.Lock()
+		w.stats.NodeRestarts++
+		w.Unlock()
+	}
+	w.nodeEpoch = epoch
+}
+
+// handlePacket processes the control packets of the node
+func (w *Worker) handlePacket(
+	p Packet) {
+
+	if p.sender.String() != w.node.String() {
+
+		return
+	}
+
+	switch p.kind {
+
+	case kindConfirm:
+		last, err := decodeLast(p.bytes)
+
+		if err != nil {
+
+			return
+		}
+		w.checkEpoch(p.epoch)
+		synced := w.synced()
+		w.Lock()
+		w.lastHeard = time.Now()
+		w.Unlock()
+
+		for channel, seq := range last {
+
+			s, ok := w.channels[channel]
+
+			if !ok {
+
+				// On the first confirmation delivery starts with the next message, later ones reveal channels whose every message was lost
+				next := seq + 1
+
+				if synced {
+
+					next = 1
+				}
+				s = newSequencer(next)
+				w.channels[channel] = s
+			}
+
+			if seq > s.highest {
+
+				s.highest = seq
+			}
+			w.advance(s)
+		}
+	case kindGone:
+		seqs, err := decodeSeqs(p.bytes)
+
+		// Messages of an earlier run of the node are no longer sequenced
+		if err != nil || p.epoch != w.nodeEpoch {
+
+			return
+		}
+		s, ok := w.channels[p.channel]
+
+		if !ok {
+
+			return
+		}
+		var lost uint64
+
+		for _, seq := range seqs {
+
+			if _, ok := s.missing[seq]; ok {
+
+				delete(s.missing, seq)
+				s.pending[seq] = Message{}
+				lost++
+			}
+		}
+		w.Lock()
+		w.stats.Lost += lost
+		w.Unlock()
+		w.advance(s)
+	}
+}
+
+// heartbeat renews the subscription and requests the retransmission of missing messages
+func (w *Worker) heartbeat(
+	now time.Time) {
+
+	if now.Sub(w.lastSent) >= w.cfg.Heartbeat {
+
+		w.lastSent = now
+		w.sendPacket(w.node, nil, kindSubscribe, 0, 0, nil)
+	}
+
+	for channel, s := range w.channels {
+
+		var request []uint64
+		var lost uint64
+
+		for seq := s.next; seq <= s.highest; seq++ {
+
+			if _, ok := s.pending[seq]; ok {
+
+				continue
+			}
+			n, ok := s.missing[seq]
+
+			if !ok {
+
+				// A message is only requested once it has been missing for a while, as its pieces may just be late
+				s.missing[seq] = &nackState{since: now}
+				continue
+			}
+
+			if now.Sub(n.since) < nackInterval {
+
+				continue
+			}
+
+			if n.retries >= nackRetries {
+
+				delete(s.missing, seq)
+				s.pending[seq] = Message{}
+				lost++
+				continue
+			}
+			n.retries++
+			n.since = now
+			request = append(request, seq)
+		}
+
+		if lost > 0 {
+
+			w.Lock()
+			w.stats.Lost += lost
+			w.Unlock()
+			w.advance(s)
+		}
+
+		for len(request) > 0 {
+
+			count := len(request)
+
+			if count > maxNackSeqs {
+
+				count = maxNackSeqs
+			}
+			w.sendPacket(w.node, nil, kindNack, channel, 0, encodeSeqs(request[:count]))
+			request = request[count:]
+
+			w.Lock()
+			w.stats.NacksSent++
+			w.Unlock()
+		}
+	}
+}
@@ -0,0 +1,200 @@
+package bip39
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"errors"
+	"strings"
+
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	// MinEntropyBits is the smallest entropy a mnemonic can encode, giving 12 words.
+	MinEntropyBits = 128
+
+	// MaxEntropyBits is the largest entropy a mnemonic can encode, giving 24 words.
+	MaxEntropyBits = 256
+
+	// RecommendedEntropyBits is the entropy of new wallet mnemonics, matching the recommended length of hdkeychain seeds.
+	RecommendedEntropyBits = 256
+
+	// SeedBytes is the length of the seed derived from a mnemonic.
+	SeedBytes = 64
+
+	// seedIterations is the number of PBKDF2 rounds used to derive a seed.
+	seedIterations = 2048
+
+	// bitsPerWord is the number of bits each word of a mnemonic encodes.
+	bitsPerWord = 11
+)
+
+var (
+	// ErrInvalidEntropyLength describes entropy that is not a multiple of 32 bits between MinEntropyBits and MaxEntropyBits.
+	ErrInvalidEntropyLength = errors.New("entropy must be a multiple of 32 bits between 128 and 256 bits")
+
+	// ErrInvalidWordCount describes a mnemonic that does not have 12, 15, 18, 21 or 24 words.
+	ErrInvalidWordCount = errors.New("mnemonic must have 12, 15, 18, 21 or 24 words")
+
+	// ErrUnknownWord describes a mnemonic with a word that is not in the word list.
+	ErrUnknownWord = errors.New("mnemonic contains a word that is not in the word list")
+
+	// ErrChecksumMismatch describes a mnemonic whose words are all known but whose checksum does not match, usually because a word was mistyped or the words are out of order.
+	ErrChecksumMismatch = errors.New("mnemonic checksum does not match")
+)
+
+// validEntropyBits reports whether entropy of the given number of bits can be encoded as a mnemonic.
+func validEntropyBits(
+	bits int) bool {
+
+	return bits%32 == 0 && bits >= MinEntropyBits && bits <= MaxEntropyBits
+}
+
+// NewEntropy returns bits of cryptographically random entropy for a new mnemonic.
+func NewEntropy(
+	bits int) ([]byte, error) {
+
+	if !validEntropyBits(bits) {
+
+		return nil, ErrInvalidEntropyLength
+	}
+
+	entropy := make([]byte, bits/8)
+
+	if _, err := rand.Read(entropy); err != nil {
+
+		return nil, err
+	}
+
+	return entropy, nil
+}
+
+// NewMnemonic returns the words encoding entropy, separated by single spaces.  The last word also carries the first bits of the SHA256 hash of the entropy as a checksum.
+func NewMnemonic(
+	entropy []byte) (string, error) {
+
+	bits := len(entropy) * 8
+
+	if !validEntropyBits(bits) {
+
+		return "", ErrInvalidEntropyLength
+	}
+
+	// The checksum is one bit for every 32 bits of entropy, so it never
+	// needs more than the first byte of the hash.
+	hash := sha256.Sum256(entropy)
+	data := append(append([]byte{}, entropy...), hash[0])
+	words := make([]string, (bits+bits/32)/bitsPerWord)
+
+	for i := range words {
+
+		words[i] = English[readBits(data, i*bitsPerWord)]
+	}
+
+	return strings.Join(words, " "), nil
+}
+
+// EntropyFromMnemonic returns the entropy encoded by the words of mnemonic after checking that every word is known and that the checksum matches.
+func EntropyFromMnemonic(
+	mnemonic string) ([]byte, error) {
+
+	words := strings.Fields(normalize(mnemonic))
+
+	if len(words)%3 != 0 || len(words) < 12 || len(words) > 24 {
+
+		return nil, ErrInvalidWordCount
+	}
+
+	totalBits := len(words) * bitsPerWord
+	checksumBits := totalBits / 33
+	entropyBytes := (totalBits - checksumBits) / 8
+	data := make([]byte, (totalBits+7)/8)
+
+	for i, word := range words {
+
+		value, ok := wordIndex[word]
+
+		if !ok {
+
+			return nil, ErrUnknownWord
+		}
+
+		writeBits(data, i*bitsPerWord, value)
+	}
+
+	entropy := data[:entropyBytes]
+	hash := sha256.Sum256(entropy)
+	mask := byte(0xff << uint(8-checksumBits))
+
+	if data[entropyBytes]&mask != hash[0]&mask {
+
+		return nil, ErrChecksumMismatch
+	}
+
+	return entropy, nil
+}
+
+// IsMnemonicValid reports whether mnemonic decodes to entropy.
+func IsMnemonicValid(
+	mnemonic string) bool {
+
+	_, err := EntropyFromMnemonic(mnemonic)
+	return err == nil
+}
+
+// NewSeed returns the wallet seed derived from mnemonic and passphrase, which may be empty.  The mnemonic is checked first so that a mistyped phrase does not silently give the seed of another wallet.
+func NewSeed(
+	mnemonic, passphrase string) ([]byte, error) {
+
+	if _, err := EntropyFromMnemonic(mnemonic); err != nil {
+
+		return nil, err
+	}
+
+	password := []byte(strings.Join(strings.Fields(normalize(mnemonic)), " "))
+	salt := []byte("mnemonic" + norm.NFKD.String(passphrase))
+	return pbkdf2.Key(password, salt, seedIterations, SeedBytes,
+		sha512.New), nil
+}
+
+// normalize returns mnemonic in the compatibility decomposed form BIP 39 derives seeds from, in lower case to match the word list.
+func normalize(
+	mnemonic string) string {
+
+	return norm.NFKD.String(strings.ToLower(mnemonic))
+}
+
+// readBits returns the big endian word value stored in data at bit offset.
+func readBits(
+	data []byte, offset int) int {
+
+	var value int
+
+	for i := offset; i < offset+bitsPerWord; i++ {
+
+		value <<= 1
+
+		if data[i/8]&(0x80>>uint(i%8)) != 0 {
+
+			value |= 1
+		}
+	}
+
+	return value
+}
+
+// writeBits stores the word value in data at bit offset, big endian.
+func writeBits(
+	data []byte, offset int, value int) {
+
+	for i := 0; i < bitsPerWord; i++ {
+
+		if value&(1<<uint(bitsPerWord-1-i)) != 0 {
+
+			bit := offset + i
+			data[bit/8] |= 0x80 >> uint(bit%8)
+		}
+	}
+}
@@ -0,0 +1,16 @@
+/*
+Package bip39 implements mnemonic seed phrases as described by BIP 39.
+
+# Overview
+
+A wallet seed is a random string of bytes that is easy to mistype when written down in hexadecimal.  BIP 39 encodes the random entropy a seed is derived from as a sequence of words from a fixed list of 2048, with a checksum folded into the last word so that most transcription errors are caught when the words are entered again.
+
+# Mnemonics
+
+NewEntropy creates random entropy of an allowed size, and NewMnemonic encodes entropy as its words.  EntropyFromMnemonic reverses the encoding after checking every word and the checksum, and IsMnemonicValid reports whether a phrase would decode.  Only the English word list is supported.
+
+# Seeds
+
+The wallet seed is not the entropy itself but is derived from the mnemonic and an optional passphrase by NewSeed.  Different passphrases give entirely different seeds from the same words, so the passphrase must be kept along with the words to restore a wallet.
+*/
+package bip39
@@ -0,0 +1,15 @@
+/*
+Package metrics implements counters, gauges and histograms that are exported over HTTP in the Prometheus text exposition format, so the node, the wallet and the miner controller can be scraped by Prometheus and compatible monitoring systems.
+
+Metrics are usually created as package level variables with the package level functions, which add them to DefaultRegistry:
+
+	var blocksProcessed = metrics.NewCounter("pod_blocks_processed_total",
+		"Number of blocks processed", "result")
+
+	blocksProcessed.Inc("accepted")
+
+State that is kept elsewhere, such as the height of the chain, is better copied into gauges just before each scrape with OnCollect than updated on every change.
+
+Serve starts a listener that exports DefaultRegistry on /metrics.
+*/
+package metrics
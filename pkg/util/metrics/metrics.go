@@ -0,0 +1,408 @@
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ContentType is the content type of the Prometheus text exposition format written by a Registry.
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// DefaultBuckets are the upper bounds in seconds of the histogram buckets used for durations when no buckets are given, from 1ms to 10s.
+var DefaultBuckets = []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// DefaultRegistry is the registry the metrics created with the package level functions are added to, and which Serve exports.
+var DefaultRegistry = NewRegistry()
+
+// Registry is a set of metric families that are written in the Prometheus text exposition format on each scrape. Functions added with OnCollect run before each scrape so gauges that mirror state kept elsewhere can be updated only when they are needed.
+
+type Registry struct {
+	sync.Mutex
+	families  map[string]*family
+	collect   map[int]func()
+	collectID int
+}
+
+// Counter is a metric that only goes up, such as a number of bytes or requests. It may have labels, in which case each combination of label values is a separate series.
+
+type Counter struct {
+	*family
+}
+
+// Gauge is a metric that can go up and down, such as a number of peers. It may have labels, in which case each combination of label values is a separate series.
+
+type Gauge struct {
+	*family
+}
+
+// Histogram is a metric that counts observations, such as durations, in buckets by their value and keeps their sum. It may have labels, in which case each combination of label values is a separate series.
+
+type Histogram struct {
+	*family
+}
+
+// family is a named metric with all of its series.
+
+type family struct {
+	sync.Mutex
+	name    string
+	help    string
+	kind    string
+	labels  []string
+	buckets []float64
+	series  map[string]*series
+}
+
+// series is the value of one combination of label values of a metric. Histograms use counts, with one count per bucket, and sum, the others only use value.
+
+type series struct {
+	labelValues []string
+	value       float64
+	counts      []uint64
+	count       uint64
+	sum         float64
+}
+
+// NewRegistry returns a new empty registry.
+func NewRegistry() *Registry {
+
+	return &Registry{
+		families: make(map[string]*family),
+		collect:  make(map[int]func()),
+	}
+}
+
+// NewCounter adds a new counter with the passed name, help text and label names to the registry. It panics if a metric with the name already exists, as that is a programming error.
+func (r *Registry) NewCounter(name, help string, labels ...string) *Counter {
+
+	return &Counter{r.add(name, help, "counter", labels, nil)}
+}
+
+// NewGauge adds a new gauge with the passed name, help text and label names to the registry. It panics if a metric with the name already exists.
+func (r *Registry) NewGauge(name, help string, labels ...string) *Gauge {
+
+	return &Gauge{r.add(name, help, "gauge", labels, nil)}
+}
+
+// NewHistogram adds a new histogram with the passed name, help text, bucket upper bounds and label names to the registry. DefaultBuckets are used when buckets is empty. It panics if a metric with the name already exists.
+func (r *Registry) NewHistogram(name, help string, buckets []float64, labels ...string) *Histogram {
+
+	if len(buckets) == 0 {
+
+		buckets = DefaultBuckets
+	}
+	buckets = append([]float64(nil), buckets...)
+	sort.Float64s(buckets)
+	return &Histogram{r.add(name, help, "histogram", labels, buckets)}
+}
+
+// OnCollect adds a function that runs before each scrape of the registry, and returns a function that removes it again. It is meant to set gauges from state that is kept elsewhere, such as the height of the chain.
+func (r *Registry) OnCollect(f func()) (remove func()) {
+
+	r.Lock()
+	defer r.Unlock()
+	id := r.collectID
+	r.collectID++
+	r.collect[id] = f
+	return func() {
+
+		r.Lock()
+		delete(r.collect, id)
+		r.Unlock()
+	}
+}
+
+// ServeHTTP writes all the metrics of the registry in the Prometheus text exposition format. This is part of the http.Handler interface implementation.
+func (r *Registry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+
+	w.Header().Set("Content-Type", ContentType)
+	r.Write(w)
+}
+
+// Write runs the collect functions of the registry and writes all of its metrics, ordered by name, to w in the Prometheus text exposition format.
+func (r *Registry) Write(w io.Writer) error {
+
+	r.Lock()
+	collect := make([]func(), 0, len(r.collect))
+
+	for _, f := range r.collect {
+
+		collect = append(collect, f)
+	}
+	families := make([]*family, 0, len(r.families))
+
+	for _, f := range r.families {
+
+		families = append(families, f)
+	}
+	r.Unlock()
+
+	for _, f := range collect {
+
+		f()
+	}
+	sort.Slice(families, func(i, j int) bool {
+
+		return families[i].name < families[j].name
+	})
+	bw := bufio.NewWriter(w)
+
+	for _, f := range families {
+
+		f.write(bw)
+	}
+	return bw.Flush()
+}
+
+// add adds a new metric family to the registry.
+func (r *Registry) add(name, help, kind string, labels []string, buckets []float64) *family {
+
+	r.Lock()
+	defer r.Unlock()
+
+	if _, exists := r.families[name]; exists {
+
+		panic(fmt.Sprintf("metric %s is already registered", name))
+	}
+	f := &family{
+		name:    name,
+		help:    help,
+		kind:    kind,
+		labels:  labels,
+		buckets: buckets,
+		series:  make(map[string]*series),
+	}
+	r.families[name] = f
+	return f
+}
+
+// NewCounter adds a new counter to DefaultRegistry. See Registry.NewCounter.
+func NewCounter(name, help string, labels ...string) *Counter {
+
+	return DefaultRegistry.NewCounter(name, help, labels...)
+}
+
+// NewGauge adds a new gauge to DefaultRegistry. See Registry.NewGauge.
+func NewGauge(name, help string, labels ...string) *Gauge {
+
+	return DefaultRegistry.NewGauge(name, help, labels...)
+}
+
+// NewHistogram adds a new histogram to DefaultRegistry. See Registry.NewHistogram.
+func NewHistogram(name, help string, buckets []float64, labels ...string) *Histogram {
+
+	return DefaultRegistry.NewHistogram(name, help, buckets, labels...)
+}
+
+// OnCollect adds a function that runs before each scrape of DefaultRegistry. See Registry.OnCollect.
+func OnCollect(f func()) (remove func()) {
+
+	return DefaultRegistry.OnCollect(f)
+}
+
+// Serve starts an HTTP server that exports DefaultRegistry on /metrics at the passed listen address, and returns it so it can be closed on shutdown.
+func Serve(listenAddr string) (*http.Server, error) {
+
+	listener, err := net.Listen("tcp", listenAddr)
+
+	if err != nil {
+
+		return nil, err
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", DefaultRegistry)
+	server := &http.Server{Handler: mux}
+	go server.Serve(listener)
+	return server, nil
+}
+
+// Add adds v, which must not be negative, to the series of the counter with the passed label values.
+func (c *Counter) Add(v float64, labelValues ...string) {
+
+	if v < 0 {
+
+		panic(fmt.Sprintf("counter %s can't be decreased", c.name))
+	}
+	c.Lock()
+	c.get(labelValues).value += v
+	c.Unlock()
+}
+
+// Inc adds one to the series of the counter with the passed label values.
+func (c *Counter) Inc(labelValues ...string) {
+
+	c.Add(1, labelValues...)
+}
+
+// Set sets the series of the gauge with the passed label values to v.
+func (g *Gauge) Set(v float64, labelValues ...string) {
+
+	g.Lock()
+	g.get(labelValues).value = v
+	g.Unlock()
+}
+
+// Add adds v, which may be negative, to the series of the gauge with the passed label values.
+func (g *Gauge) Add(v float64, labelValues ...string) {
+
+	g.Lock()
+	g.get(labelValues).value += v
+	g.Unlock()
+}
+
+// Reset removes all the series of the gauge, for when the set of label values is rebuilt on each scrape.
+func (g *Gauge) Reset() {
+
+	g.Lock()
+	g.series = make(map[string]*series)
+	g.Unlock()
+}
+
+// Observe adds the observation v to the series of the histogram with the passed label values.
+func (h *Histogram) Observe(v float64, labelValues ...string) {
+
+	h.Lock()
+	s := h.get(labelValues)
+
+	for i, bound := range h.buckets {
+
+		if v <= bound {
+
+			s.counts[i]++
+		}
+	}
+	s.count++
+	s.sum += v
+	h.Unlock()
+}
+
+// get returns the series of the family with the passed label values, creating it when it does not exist yet. It panics when the number of label values does not match the labels of the family. It must be called with the family lock held.
+func (f *family) get(labelValues []string) *series {
+
+	if len(labelValues) != len(f.labels) {
+
+		panic(fmt.Sprintf("metric %s has %d labels, got %d values", f.name,
+			len(f.labels), len(labelValues)))
+	}
+	key := strings.Join(labelValues, "\xff")
+	s, ok := f.series[key]
+
+	if !ok {
+
+		s = &series{labelValues: append([]string(nil), labelValues...)}
+
+		if f.buckets != nil {
+
+			s.counts = make([]uint64, len(f.buckets))
+		}
+		f.series[key] = s
+	}
+	return s
+}
+
+// write writes the family to w in the Prometheus text exposition format, with its series ordered by their label values.
+func (f *family) write(w *bufio.Writer) {
+
+	f.Lock()
+	defer f.Unlock()
+
+	keys := make([]string, 0, len(f.series))
+
+	for key := range f.series {
+
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	fmt.Fprintf(w, "# HELP %s %s\n", f.name, escapeHelp(f.help))
+	fmt.Fprintf(w, "# TYPE %s %s\n", f.name, f.kind)
+
+	for _, key := range keys {
+
+		s := f.series[key]
+
+		if f.kind != "histogram" {
+
+			writeSample(w, f.name, f.labels, s.labelValues, "", "", s.value)
+			continue
+		}
+
+		for i, bound := range f.buckets {
+
+			writeSample(w, f.name+"_bucket", f.labels, s.labelValues, "le",
+				formatFloat(bound), float64(s.counts[i]))
+		}
+		writeSample(w, f.name+"_bucket", f.labels, s.labelValues, "le", "+Inf",
+			float64(s.count))
+		writeSample(w, f.name+"_sum", f.labels, s.labelValues, "", "", s.sum)
+		writeSample(w, f.name+"_count", f.labels, s.labelValues, "", "",
+			float64(s.count))
+	}
+}
+
+// writeSample writes one line of a metric with its labels, and an extra label when extraLabel is not empty.
+func writeSample(w *bufio.Writer, name string, labels, labelValues []string, extraLabel, extraValue string, v float64) {
+
+	w.WriteString(name)
+
+	if len(labels) > 0 || extraLabel != "" {
+
+		w.WriteByte('{')
+
+		for i, label := range labels {
+
+			if i > 0 {
+
+				w.WriteByte(',')
+			}
+			fmt.Fprintf(w, "%s=\"%s\"", label, escapeLabelValue(labelValues[i]))
+		}
+
+		if extraLabel != "" {
+
+			if len(labels) > 0 {
+
+				w.WriteByte(',')
+			}
+			fmt.Fprintf(w, "%s=\"%s\"", extraLabel, extraValue)
+		}
+		w.WriteByte('}')
+	}
+	w.WriteByte(' ')
+	w.WriteString(formatFloat(v))
+	w.WriteByte('\n')
+}
+
+// formatFloat formats a sample value the way the exposition format expects, including the special values.
+func formatFloat(v float64) string {
+
+	switch {
+
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+// escapeHelp escapes the backslashes and line feeds of a help text.
+func escapeHelp(s string) string {
+
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(s)
+}
+
+// escapeLabelValue escapes the backslashes, double quotes and line feeds of a label value.
+func escapeLabelValue(s string) string {
+
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+}
@@ -0,0 +1,150 @@
+package metrics_test
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"git.parallelcoin.io/dev/pod/pkg/util/metrics"
+)
+
+// TestRegistryWrite tests that counters, gauges and histograms are written in the Prometheus text exposition format, ordered by name and label values.
+func TestRegistryWrite(t *testing.T) {
+
+	r := metrics.NewRegistry()
+	peers := r.NewGauge("test_peers", "Number of peers", "direction")
+	bytesSent := r.NewCounter("test_sent_bytes_total", "Bytes sent")
+	latency := r.NewHistogram("test_latency_seconds", "Request latency",
+		[]float64{1, 0.1}, "method")
+	height := r.NewGauge("test_height", "Chain height")
+
+	remove := r.OnCollect(func() {
+
+		height.Set(42)
+	})
+
+	peers.Set(3, "outbound")
+	peers.Set(1, "inbound")
+	peers.Add(1, "inbound")
+	bytesSent.Add(1500)
+	bytesSent.Inc()
+	latency.Observe(0.05, "getinfo")
+	latency.Observe(0.5, "getinfo")
+	latency.Observe(2, "getinfo")
+	latency.Observe(0.01, `say "hi"`)
+
+	want := `# HELP test_height Chain height
+# TYPE test_height gauge
+test_height 42
+# HELP test_latency_seconds Request latency
+# TYPE test_latency_seconds histogram
+test_latency_seconds_bucket{method="getinfo",le="0.1"} 1
+test_latency_seconds_bucket{method="getinfo",le="1"} 2
+test_latency_seconds_bucket{method="getinfo",le="+Inf"} 3
+test_latency_seconds_sum{method="getinfo"} 2.55
+test_latency_seconds_count{method="getinfo"} 3
+test_latency_seconds_bucket{method="say \"hi\"",le="0.1"} 1
+test_latency_seconds_bucket{method="say \"hi\"",le="1"} 1
+test_latency_seconds_bucket{method="say \"hi\"",le="+Inf"} 1
+test_latency_seconds_sum{method="say \"hi\""} 0.01
+test_latency_seconds_count{method="say \"hi\""} 1
+# HELP test_peers Number of peers
+# TYPE test_peers gauge
+test_peers{direction="inbound"} 2
+test_peers{direction="outbound"} 3
+# HELP test_sent_bytes_total Bytes sent
+# TYPE test_sent_bytes_total counter
+test_sent_bytes_total 1501
+`
+	var buf bytes.Buffer
+
+	if err := r.Write(&buf); err != nil {
+
+		t.Fatalf("Write: unexpected error: %v", err)
+	}
+
+	if buf.String() != want {
+
+		t.Fatalf("Write: got\n%s\nwant\n%s", buf.String(), want)
+	}
+
+	// The collect function no longer runs once it is removed.
+	remove()
+	height.Set(7)
+	buf.Reset()
+	r.Write(&buf)
+
+	if !strings.Contains(buf.String(), "test_height 7\n") {
+
+		t.Fatalf("Write: collect function ran after it was removed:\n%s",
+			buf.String())
+	}
+}
+
+// TestRegistryServeHTTP tests that a registry is served with the content type of the exposition format.
+func TestRegistryServeHTTP(t *testing.T) {
+
+	r := metrics.NewRegistry()
+	r.NewCounter("test_requests_total", "Requests").Inc()
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+
+	if ct := rec.Header().Get("Content-Type"); ct != metrics.ContentType {
+
+		t.Fatalf("ServeHTTP: got content type %q, want %q", ct,
+			metrics.ContentType)
+	}
+
+	if !strings.Contains(rec.Body.String(), "test_requests_total 1\n") {
+
+		t.Fatalf("ServeHTTP: unexpected body:\n%s", rec.Body.String())
+	}
+}
+
+// TestRegistryPanics tests that registering a name twice and using the wrong number of label values panic.
+func TestRegistryPanics(t *testing.T) {
+
+	tests := []struct {
+		name string
+		f    func(r *metrics.Registry)
+	}{
+		{
+			name: "duplicate name",
+			f: func(r *metrics.Registry) {
+
+				r.NewGauge("test_dup", "")
+				r.NewCounter("test_dup", "")
+			},
+		},
+		{
+			name: "wrong label count",
+			f: func(r *metrics.Registry) {
+
+				r.NewGauge("test_labels", "", "a", "b").Set(1, "x")
+			},
+		},
+		{
+			name: "negative counter",
+			f: func(r *metrics.Registry) {
+
+				r.NewCounter("test_neg", "").Add(-1)
+			},
+		},
+	}
+
+	for _, test := range tests {
+
+		func() {
+
+			defer func() {
+
+				if recover() == nil {
+
+					t.Errorf("%s: expected a panic", test.name)
+				}
+			}()
+			test.f(metrics.NewRegistry())
+		}()
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"os"
 	"strings"
 
+	"git.parallelcoin.io/dev/pod/pkg/util/bip39"
 	"git.parallelcoin.io/dev/pod/pkg/util/hdkeychain"
 	"git.parallelcoin.io/dev/pod/pkg/util/legacy/keystore"
 	"github.com/btcsuite/golangcrypto/ssh/terminal"
@@ -349,16 +350,22 @@ func PublicPass(
 
 // Seed prompts the user whether they want to use an existing wallet generation
 
-// seed.  When the user answers no, a seed will be generated and displayed to
+// seed.  When the user answers no, mnemonic seed words are generated and
 
-// the user along with prompting them for confirmation.  When the user answers
+// displayed to the user, optionally protected by a seed passphrase, along with
 
-// yes, a the user is prompted for it.  All prompts are repeated until the user
+// prompting them for confirmation.  When the user answers yes, they are
 
-// enters a valid response.
+// prompted for the seed words of the existing wallet and its seed passphrase,
+
+// or for a hexadecimal seed, and the returned bool is true to indicate that the
+
+// wallet is being restored.  All prompts are repeated until the user enters a
+
+// valid response.
 func Seed(
 
-	reader *bufio.Reader) ([]byte, error) {
+	reader *bufio.Reader) ([]byte, bool, error) {
 
 	// Ascertain the wallet generation seed.
 	useUserSeed, err := promptListBool(reader, "Do you have an "+
@@ -366,32 +373,65 @@ func Seed(
 
 	if err != nil {
 
-		return nil, err
+		return nil, false, err
 	}
 
 	if !useUserSeed {
 
-		seed, err := hdkeychain.GenerateSeed(hdkeychain.RecommendedSeedLen)
+		entropy, err := bip39.NewEntropy(bip39.RecommendedEntropyBits)
 
 		if err != nil {
 
-			return nil, err
+			return nil, false, err
+		}
+
+		mnemonic, err := bip39.NewMnemonic(entropy)
+
+		if err != nil {
+
+			return nil, false, err
+		}
+
+		seedPass, err := seedPassphrase(reader, "Do you want to protect "+
+			"your seed words with an additional passphrase?")
+
+		if err != nil {
+
+			return nil, false, err
+		}
+
+		fmt.Print("\nYour wallet seed words are:\n\n")
+		words := strings.Fields(mnemonic)
+
+		for i, word := range words {
+
+			fmt.Printf("%2d. %-10s", i+1, word)
+
+			if i%6 == 5 || i == len(words)-1 {
+
+				fmt.Println()
+			}
 		}
 
-		fmt.Println("\nYour wallet generation seed is:")
-		fmt.Printf("\n%x\n\n", seed)
-		fmt.Print("IMPORTANT: Keep the seed in a safe place as you will NOT be able to restore your wallet without it.\n\n")
-		fmt.Print("Please keep in mind that anyone who has access to the seed can also restore your wallet thereby giving them access to all your funds, so it is imperative that you keep it in a secure location.\n\n")
+		fmt.Println()
+		fmt.Print("IMPORTANT: Write the words down in order and keep them in a safe place as you will NOT be able to restore your wallet without them.\n\n")
+
+		if len(seedPass) != 0 {
+
+			fmt.Print("The seed passphrase is needed along with the words to restore your wallet, so keep it safe as well.\n\n")
+		}
+
+		fmt.Print("Please keep in mind that anyone who has access to the words can also restore your wallet thereby giving them access to all your funds, so it is imperative that you keep them in a secure location.\n\n")
 
 		for {
 
-			fmt.Print(`Once you have stored the seed in a safe ` +
+			fmt.Print(`Once you have stored the words in a safe ` +
 				`and secure location, enter "OK" to continue: `)
 			confirmSeed, err := reader.ReadString('\n')
 
 			if err != nil {
 
-				return nil, err
+				return nil, false, err
 			}
 			confirmSeed = strings.TrimSpace(confirmSeed)
 			confirmSeed = strings.Trim(confirmSeed, `"`)
@@ -402,33 +442,77 @@ func Seed(
 			}
 		}
 
-		return seed, nil
+		seed, err := bip39.NewSeed(mnemonic, string(seedPass))
+		return seed, false, err
 	}
 
 	for {
 
-		fmt.Print("Enter existing wallet seed: ")
+		fmt.Print("Enter your wallet seed words, or an existing " +
+			"hexadecimal seed: ")
 		seedStr, err := reader.ReadString('\n')
 
 		if err != nil {
 
-			return nil, err
+			return nil, false, err
 		}
 		seedStr = strings.TrimSpace(strings.ToLower(seedStr))
 
-		seed, err := hex.DecodeString(seedStr)
+		// A single word is taken to be a seed from before seed words
+		// were supported.
+		if len(strings.Fields(seedStr)) == 1 {
 
-		if err != nil || len(seed) < hdkeychain.MinSeedBytes ||
+			seed, err := hex.DecodeString(seedStr)
 
-			len(seed) > hdkeychain.MaxSeedBytes {
+			if err != nil || len(seed) < hdkeychain.MinSeedBytes ||
 
-			fmt.Printf("Invalid seed specified.  Must be a "+
-				"hexadecimal value that is at least %d bits and "+
-				"at most %d bits\n", hdkeychain.MinSeedBytes*8,
-				hdkeychain.MaxSeedBytes*8)
+				len(seed) > hdkeychain.MaxSeedBytes {
+
+				fmt.Printf("Invalid seed specified.  Must be seed "+
+					"words or a hexadecimal value that is at least "+
+					"%d bits and at most %d bits\n",
+					hdkeychain.MinSeedBytes*8,
+					hdkeychain.MaxSeedBytes*8)
+				continue
+			}
+
+			return seed, true, nil
+		}
+
+		if _, err := bip39.EntropyFromMnemonic(seedStr); err != nil {
+
+			fmt.Printf("Invalid seed words specified: %v\n", err)
 			continue
 		}
 
-		return seed, nil
+		seedPass, err := seedPassphrase(reader, "Are your seed words "+
+			"protected with an additional passphrase?")
+
+		if err != nil {
+
+			return nil, false, err
+		}
+
+		seed, err := bip39.NewSeed(seedStr, string(seedPass))
+		return seed, true, err
+	}
+}
+
+// seedPassphrase asks the user the given question about protecting seed words
+
+// with a passphrase, and prompts for the passphrase when they answer yes.  An
+
+// empty passphrase is returned when they answer no.
+func seedPassphrase(
+
+	reader *bufio.Reader, question string) ([]byte, error) {
+
+	useSeedPass, err := promptListBool(reader, question, "no")
+
+	if err != nil || !useSeedPass {
+
+		return nil, err
 	}
+
+	return promptPass(reader, "Enter the seed passphrase", true)
 }
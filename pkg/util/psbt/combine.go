@@ -0,0 +1,195 @@
+package psbt
+
+import (
+	"bytes"
+)
+
+// Combine merges PSBTs for the same transaction, such as copies signed by different signers, into a new PSBT holding the information of all of them.  Where the PSBTs disagree about a field, the value of the earliest one wins.
+func Combine(
+	packets ...*Packet) (*Packet, error) {
+
+	if len(packets) == 0 {
+
+		return nil, ErrInvalidPsbtFormat
+	}
+
+	first := packets[0]
+	txHash := first.UnsignedTx.TxHash()
+
+	for _, p := range packets[1:] {
+
+		if p.UnsignedTx.TxHash() != txHash {
+
+			return nil, ErrDifferentTransactions
+		}
+	}
+
+	combined := &Packet{
+		UnsignedTx: first.UnsignedTx.Copy(),
+		Inputs:     make([]PInput, len(first.Inputs)),
+		Outputs:    make([]POutput, len(first.Outputs)),
+	}
+
+	for _, p := range packets {
+
+		if err := p.SanityCheck(); err != nil {
+
+			return nil, err
+		}
+
+		combined.Unknowns = mergeUnknowns(combined.Unknowns, p.Unknowns)
+
+		for i := range p.Inputs {
+
+			combined.Inputs[i].merge(&p.Inputs[i])
+		}
+
+		for i := range p.Outputs {
+
+			combined.Outputs[i].merge(&p.Outputs[i])
+		}
+	}
+
+	// Signatures and scripts are of no further use once an input is
+	// finalized, whichever PSBT it was finalized in.
+	for i := range combined.Inputs {
+
+		if combined.Inputs[i].IsFinalized() {
+
+			combined.Inputs[i].clearSigningData()
+		}
+	}
+
+	return combined, nil
+}
+
+// merge adds the fields of other that pi lacks to pi.
+func (pi *PInput) merge(
+	other *PInput) {
+
+	if pi.NonWitnessUtxo == nil {
+
+		pi.NonWitnessUtxo = other.NonWitnessUtxo
+	}
+
+	if pi.WitnessUtxo == nil {
+
+		pi.WitnessUtxo = other.WitnessUtxo
+	}
+
+	for _, sig := range other.PartialSigs {
+
+		found := false
+
+		for _, x := range pi.PartialSigs {
+
+			if bytes.Equal(x.PubKey, sig.PubKey) {
+
+				found = true
+				break
+			}
+		}
+
+		if !found {
+
+			pi.PartialSigs = append(pi.PartialSigs, sig)
+		}
+	}
+
+	if pi.SighashType == 0 {
+
+		pi.SighashType = other.SighashType
+	}
+
+	if pi.RedeemScript == nil {
+
+		pi.RedeemScript = other.RedeemScript
+	}
+
+	if pi.WitnessScript == nil {
+
+		pi.WitnessScript = other.WitnessScript
+	}
+
+	pi.Bip32Derivation = mergeBip32Derivations(pi.Bip32Derivation,
+		other.Bip32Derivation)
+
+	if !pi.IsFinalized() {
+
+		pi.FinalScriptSig = other.FinalScriptSig
+		pi.FinalScriptWitness = other.FinalScriptWitness
+	}
+
+	pi.Unknowns = mergeUnknowns(pi.Unknowns, other.Unknowns)
+}
+
+// merge adds the fields of other that po lacks to po.
+func (po *POutput) merge(
+	other *POutput) {
+
+	if po.RedeemScript == nil {
+
+		po.RedeemScript = other.RedeemScript
+	}
+
+	if po.WitnessScript == nil {
+
+		po.WitnessScript = other.WitnessScript
+	}
+
+	po.Bip32Derivation = mergeBip32Derivations(po.Bip32Derivation,
+		other.Bip32Derivation)
+	po.Unknowns = mergeUnknowns(po.Unknowns, other.Unknowns)
+}
+
+// mergeBip32Derivations returns the derivations of a followed by those of b for public keys a does not have.
+func mergeBip32Derivations(
+	a, b []*Bip32Derivation) []*Bip32Derivation {
+
+	for _, derivation := range b {
+
+		found := false
+
+		for _, x := range a {
+
+			if bytes.Equal(x.PubKey, derivation.PubKey) {
+
+				found = true
+				break
+			}
+		}
+
+		if !found {
+
+			a = append(a, derivation)
+		}
+	}
+
+	return a
+}
+
+// mergeUnknowns returns the unknowns of a followed by those of b with keys a does not have.
+func mergeUnknowns(
+	a, b []*Unknown) []*Unknown {
+
+	for _, unknown := range b {
+
+		found := false
+
+		for _, x := range a {
+
+			if bytes.Equal(x.Key, unknown.Key) {
+
+				found = true
+				break
+			}
+		}
+
+		if !found {
+
+			a = append(a, unknown)
+		}
+	}
+
+	return a
+}
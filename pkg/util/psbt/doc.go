@@ -0,0 +1,16 @@
+/*
+Package psbt implements partially signed transactions as described by BIP 174.
+
+Overview
+
+A partially signed transaction (PSBT) carries an unsigned transaction together with the information each participant needs to sign it: the outputs spent by its inputs, redeem and witness scripts, key derivation paths and the signatures collected so far.  This allows transactions to be signed by several parties, or by a signer that is not connected to the network, without passing raw transactions and scripts around by hand.
+
+Roles
+
+BIP 174 describes the life of a PSBT as a sequence of roles.  The creator builds a Packet from an unsigned transaction with NewFromUnsignedTx.  Updaters add the outputs spent by the inputs and any scripts or derivation paths they know of with an Updater, and signers add partial signatures with Updater.AddInSig.  Packets signed separately are merged with Combine.  The finalizer turns the partial signatures of each input into its final script signature and witness with MaybeFinalize or MaybeFinalizeAll, and the extractor produces the network transaction with Extract.
+
+Serialization
+
+Packets are exchanged in the binary format of BIP 174, or base64 encoded as most wallets display them.  NewFromRawBytes parses either form, and Packet.Serialize and Packet.B64Encode produce them.  Fields that are not understood are kept as unknowns and serialized again unchanged.
+*/
+package psbt
@@ -0,0 +1,284 @@
+package psbt
+
+import (
+	"bytes"
+
+	txscript "git.parallelcoin.io/dev/pod/pkg/chain/tx/script"
+	"git.parallelcoin.io/dev/pod/pkg/chain/wire"
+)
+
+// MaybeFinalize finalizes input inIndex of the PSBT if it is not finalized already, and reports whether it is finalized afterwards.
+func MaybeFinalize(
+	p *Packet, inIndex int) (bool, error) {
+
+	if inIndex < 0 || inIndex >= len(p.Inputs) {
+
+		return false, ErrInvalidInputIndex
+	}
+
+	if p.Inputs[inIndex].IsFinalized() {
+
+		return true, nil
+	}
+
+	if err := Finalize(p, inIndex); err != nil {
+
+		return false, err
+	}
+
+	return true, nil
+}
+
+// MaybeFinalizeAll finalizes every input of the PSBT that is not finalized already.  It stops at the first input that can not be finalized.
+func MaybeFinalizeAll(
+	p *Packet) error {
+
+	for i := range p.Inputs {
+
+		if _, err := MaybeFinalize(p, i); err != nil {
+
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Finalize builds the final signature script and witness of input inIndex from its partial signatures and scripts, and removes the information only signers need.  Inputs spending pay to pubkey, pay to pubkey hash and bare multisig outputs are supported, directly or through pay to script hash, pay to witness pubkey hash or pay to witness script hash.
+func Finalize(
+	p *Packet, inIndex int) error {
+
+	if inIndex < 0 || inIndex >= len(p.Inputs) {
+
+		return ErrInvalidInputIndex
+	}
+
+	pInput := &p.Inputs[inIndex]
+
+	if pInput.IsFinalized() {
+
+		return ErrInputAlreadyFinalized
+	}
+
+	prevOut := p.PrevOutput(inIndex)
+
+	if prevOut == nil {
+
+		return ErrMissingUtxo
+	}
+
+	// Unwrap a pay to script hash output to the script it commits to.
+	script := prevOut.PkScript
+	nested := txscript.IsPayToScriptHash(script)
+
+	if nested {
+
+		if pInput.RedeemScript == nil {
+
+			return ErrNotFinalizable
+		}
+
+		script = pInput.RedeemScript
+	}
+
+	var scriptSig [][]byte
+	var witness wire.TxWitness
+
+	switch {
+
+	case txscript.IsPayToWitnessPubKeyHash(script):
+
+		if len(pInput.PartialSigs) != 1 {
+
+			return ErrNotFinalizable
+		}
+
+		sig := pInput.PartialSigs[0]
+		witness = wire.TxWitness{sig.Signature, sig.PubKey}
+
+	case txscript.IsPayToWitnessScriptHash(script):
+
+		if pInput.WitnessScript == nil {
+
+			return ErrNotFinalizable
+		}
+
+		stack, err := satisfyScript(pInput.WitnessScript, pInput.PartialSigs)
+
+		if err != nil {
+
+			return err
+		}
+
+		witness = append(stack, pInput.WitnessScript)
+
+	default:
+		stack, err := satisfyScript(script, pInput.PartialSigs)
+
+		if err != nil {
+
+			return err
+		}
+
+		scriptSig = stack
+	}
+
+	// The redeem script is pushed last for the pay to script hash check.
+	if nested {
+
+		scriptSig = append(scriptSig, pInput.RedeemScript)
+	}
+
+	builder := txscript.NewScriptBuilder()
+
+	for _, data := range scriptSig {
+
+		builder.AddData(data)
+	}
+
+	finalScriptSig, err := builder.Script()
+
+	if err != nil {
+
+		return err
+	}
+
+	if len(finalScriptSig) != 0 {
+
+		pInput.FinalScriptSig = finalScriptSig
+	}
+
+	if witness != nil {
+
+		var buf bytes.Buffer
+
+		if err := writeTxWitness(&buf, witness); err != nil {
+
+			return err
+		}
+
+		pInput.FinalScriptWitness = buf.Bytes()
+	}
+
+	pInput.clearSigningData()
+	return nil
+}
+
+// satisfyScript returns the stack of data that satisfies a pay to pubkey, pay to pubkey hash or multisig script with the passed signatures.
+func satisfyScript(
+	script []byte, sigs []*PartialSig) ([][]byte, error) {
+
+	switch txscript.GetScriptClass(script) {
+
+	case txscript.PubKeyTy:
+		pushes, err := txscript.PushedData(script)
+
+		if err != nil || len(pushes) != 1 {
+
+			return nil, ErrUnsupportedScriptType
+		}
+
+		for _, sig := range sigs {
+
+			if bytes.Equal(sig.PubKey, pushes[0]) {
+
+				return [][]byte{sig.Signature}, nil
+			}
+		}
+
+		return nil, ErrNotFinalizable
+
+	case txscript.PubKeyHashTy:
+
+		if len(sigs) != 1 {
+
+			return nil, ErrNotFinalizable
+		}
+
+		return [][]byte{sigs[0].Signature, sigs[0].PubKey}, nil
+
+	case txscript.MultiSigTy:
+		_, nRequired, err := txscript.CalcMultiSigStats(script)
+
+		if err != nil {
+
+			return nil, err
+		}
+
+		pubKeys, err := txscript.PushedData(script)
+
+		if err != nil {
+
+			return nil, err
+		}
+
+		// The signatures must appear in the order of the keys in the
+		// script.  The leading empty item is consumed by the extra pop
+		// of OP_CHECKMULTISIG.
+		stack := [][]byte{nil}
+
+		for _, pubKey := range pubKeys {
+
+			for _, sig := range sigs {
+
+				if bytes.Equal(sig.PubKey, pubKey) {
+
+					stack = append(stack, sig.Signature)
+					break
+				}
+			}
+
+			if len(stack)-1 == nRequired {
+
+				return stack, nil
+			}
+		}
+
+		return nil, ErrNotFinalizable
+
+	default:
+		return nil, ErrUnsupportedScriptType
+	}
+}
+
+// clearSigningData removes the fields of a finalized input that only signers need.
+func (pi *PInput) clearSigningData() {
+
+	pi.PartialSigs = nil
+	pi.SighashType = 0
+	pi.RedeemScript = nil
+	pi.WitnessScript = nil
+	pi.Bip32Derivation = nil
+}
+
+// Extract returns the network transaction of a PSBT whose inputs are all finalized.
+func Extract(
+	p *Packet) (*wire.MsgTx, error) {
+
+	if !p.IsComplete() {
+
+		return nil, ErrIncompletePSBT
+	}
+
+	finalTx := p.UnsignedTx.Copy()
+
+	for i, tin := range finalTx.TxIn {
+
+		pInput := &p.Inputs[i]
+		tin.SignatureScript = pInput.FinalScriptSig
+
+		if pInput.FinalScriptWitness != nil {
+
+			witness, err := readTxWitness(pInput.FinalScriptWitness)
+
+			if err != nil {
+
+				return nil, err
+			}
+
+			tin.Witness = witness
+		}
+	}
+
+	return finalTx, nil
+}
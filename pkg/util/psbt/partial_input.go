@@ -0,0 +1,384 @@
+package psbt
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+
+	txscript "git.parallelcoin.io/dev/pod/pkg/chain/tx/script"
+	"git.parallelcoin.io/dev/pod/pkg/chain/wire"
+)
+
+// PInput holds the information a PSBT carries about one input of its unsigned transaction.
+type PInput struct {
+	NonWitnessUtxo     *wire.MsgTx
+	WitnessUtxo        *wire.TxOut
+	PartialSigs        []*PartialSig
+	SighashType        txscript.SigHashType
+	RedeemScript       []byte
+	WitnessScript      []byte
+	Bip32Derivation    []*Bip32Derivation
+	FinalScriptSig     []byte
+	FinalScriptWitness []byte
+	Unknowns           []*Unknown
+}
+
+// IsFinalized reports whether the input holds its final signature script or witness.
+func (pi *PInput) IsFinalized() bool {
+
+	return pi.FinalScriptSig != nil || pi.FinalScriptWitness != nil
+}
+
+// deserialize reads the map of the input from r, up to and including its separator.
+func (pi *PInput) deserialize(
+	r io.Reader) error {
+
+	for {
+
+		keyint, keydata, err := getKey(r)
+
+		if err != nil {
+
+			return err
+		}
+
+		if keyint == -1 {
+
+			break
+		}
+
+		value, err := readValue(r)
+
+		if err != nil {
+
+			return err
+		}
+
+		switch InputType(keyint) {
+
+		case NonWitnessUtxoType:
+
+			if pi.NonWitnessUtxo != nil {
+
+				return ErrDuplicateKey
+			}
+
+			if len(keydata) != 0 {
+
+				return ErrInvalidKeydata
+			}
+
+			tx := wire.NewMsgTx(2)
+
+			if err := tx.Deserialize(bytes.NewReader(value)); err != nil {
+
+				return ErrInvalidPsbtFormat
+			}
+
+			pi.NonWitnessUtxo = tx
+
+		case WitnessUtxoType:
+
+			if pi.WitnessUtxo != nil {
+
+				return ErrDuplicateKey
+			}
+
+			if len(keydata) != 0 {
+
+				return ErrInvalidKeydata
+			}
+
+			txout, err := readTxOut(value)
+
+			if err != nil {
+
+				return err
+			}
+
+			pi.WitnessUtxo = txout
+
+		case PartialSigType:
+			newPartialSig := &PartialSig{PubKey: keydata, Signature: value}
+
+			if !newPartialSig.checkValid() {
+
+				return ErrInvalidPsbtFormat
+			}
+
+			for _, x := range pi.PartialSigs {
+
+				if bytes.Equal(x.PubKey, newPartialSig.PubKey) {
+
+					return ErrDuplicateKey
+				}
+			}
+
+			pi.PartialSigs = append(pi.PartialSigs, newPartialSig)
+
+		case SighashType:
+
+			if pi.SighashType != 0 {
+
+				return ErrDuplicateKey
+			}
+
+			if len(keydata) != 0 {
+
+				return ErrInvalidKeydata
+			}
+
+			if len(value) != 4 {
+
+				return ErrInvalidPsbtFormat
+			}
+
+			pi.SighashType = txscript.SigHashType(
+				binary.LittleEndian.Uint32(value))
+
+		case RedeemScriptInputType:
+
+			if pi.RedeemScript != nil {
+
+				return ErrDuplicateKey
+			}
+
+			if len(keydata) != 0 {
+
+				return ErrInvalidKeydata
+			}
+
+			pi.RedeemScript = value
+
+		case WitnessScriptInputType:
+
+			if pi.WitnessScript != nil {
+
+				return ErrDuplicateKey
+			}
+
+			if len(keydata) != 0 {
+
+				return ErrInvalidKeydata
+			}
+
+			pi.WitnessScript = value
+
+		case Bip32DerivationInputType:
+
+			if !validatePubkey(keydata) {
+
+				return ErrInvalidPsbtFormat
+			}
+
+			master, derivationPath, err := readBip32Derivation(value)
+
+			if err != nil {
+
+				return err
+			}
+
+			for _, x := range pi.Bip32Derivation {
+
+				if bytes.Equal(x.PubKey, keydata) {
+
+					return ErrDuplicateKey
+				}
+			}
+
+			pi.Bip32Derivation = append(pi.Bip32Derivation, &Bip32Derivation{
+				PubKey:               keydata,
+				MasterKeyFingerprint: master,
+				Bip32Path:            derivationPath,
+			})
+
+		case FinalScriptSigType:
+
+			if pi.FinalScriptSig != nil {
+
+				return ErrDuplicateKey
+			}
+
+			if len(keydata) != 0 {
+
+				return ErrInvalidKeydata
+			}
+
+			pi.FinalScriptSig = value
+
+		case FinalScriptWitnessType:
+
+			if pi.FinalScriptWitness != nil {
+
+				return ErrDuplicateKey
+			}
+
+			if len(keydata) != 0 {
+
+				return ErrInvalidKeydata
+			}
+
+			if _, err := readTxWitness(value); err != nil {
+
+				return err
+			}
+
+			pi.FinalScriptWitness = value
+
+		default:
+			key := append([]byte{byte(keyint)}, keydata...)
+
+			for _, x := range pi.Unknowns {
+
+				if bytes.Equal(x.Key, key) {
+
+					return ErrDuplicateKey
+				}
+			}
+
+			pi.Unknowns = append(pi.Unknowns, &Unknown{Key: key, Value: value})
+		}
+	}
+
+	return nil
+}
+
+// serialize writes the map of the input to w, followed by its separator.
+func (pi *PInput) serialize(
+	w io.Writer) error {
+
+	if pi.NonWitnessUtxo != nil {
+
+		var buf bytes.Buffer
+
+		if err := pi.NonWitnessUtxo.Serialize(&buf); err != nil {
+
+			return err
+		}
+
+		err := serializeKVPairWithType(w, uint8(NonWitnessUtxoType), nil,
+			buf.Bytes())
+
+		if err != nil {
+
+			return err
+		}
+	}
+
+	if pi.WitnessUtxo != nil {
+
+		var buf bytes.Buffer
+
+		if err := wire.WriteTxOut(&buf, 0, 0, pi.WitnessUtxo); err != nil {
+
+			return err
+		}
+
+		err := serializeKVPairWithType(w, uint8(WitnessUtxoType), nil,
+			buf.Bytes())
+
+		if err != nil {
+
+			return err
+		}
+	}
+
+	if !pi.IsFinalized() {
+
+		sortPartialSigs(pi.PartialSigs)
+
+		for _, ps := range pi.PartialSigs {
+
+			err := serializeKVPairWithType(w, uint8(PartialSigType),
+				ps.PubKey, ps.Signature)
+
+			if err != nil {
+
+				return err
+			}
+		}
+
+		if pi.SighashType != 0 {
+
+			var shtBytes [4]byte
+			binary.LittleEndian.PutUint32(shtBytes[:], uint32(pi.SighashType))
+			err := serializeKVPairWithType(w, uint8(SighashType), nil,
+				shtBytes[:])
+
+			if err != nil {
+
+				return err
+			}
+		}
+
+		if pi.RedeemScript != nil {
+
+			err := serializeKVPairWithType(w, uint8(RedeemScriptInputType),
+				nil, pi.RedeemScript)
+
+			if err != nil {
+
+				return err
+			}
+		}
+
+		if pi.WitnessScript != nil {
+
+			err := serializeKVPairWithType(w, uint8(WitnessScriptInputType),
+				nil, pi.WitnessScript)
+
+			if err != nil {
+
+				return err
+			}
+		}
+
+		sortBip32Derivations(pi.Bip32Derivation)
+
+		for _, kd := range pi.Bip32Derivation {
+
+			err := serializeKVPairWithType(w,
+				uint8(Bip32DerivationInputType), kd.PubKey,
+				serializeBip32Derivation(kd.MasterKeyFingerprint,
+					kd.Bip32Path))
+
+			if err != nil {
+
+				return err
+			}
+		}
+	}
+
+	if pi.FinalScriptSig != nil {
+
+		err := serializeKVPairWithType(w, uint8(FinalScriptSigType), nil,
+			pi.FinalScriptSig)
+
+		if err != nil {
+
+			return err
+		}
+	}
+
+	if pi.FinalScriptWitness != nil {
+
+		err := serializeKVPairWithType(w, uint8(FinalScriptWitnessType), nil,
+			pi.FinalScriptWitness)
+
+		if err != nil {
+
+			return err
+		}
+	}
+
+	for _, kv := range pi.Unknowns {
+
+		if err := serializeKVPair(w, kv.Key, kv.Value); err != nil {
+
+			return err
+		}
+	}
+
+	_, err := w.Write([]byte{0x00})
+	return err
+}
@@ -0,0 +1,167 @@
+package psbt
+
+import (
+	"bytes"
+	"io"
+)
+
+// POutput holds the information a PSBT carries about one output of its unsigned transaction, so that signers can recognize their change.
+type POutput struct {
+	RedeemScript    []byte
+	WitnessScript   []byte
+	Bip32Derivation []*Bip32Derivation
+	Unknowns        []*Unknown
+}
+
+// deserialize reads the map of the output from r, up to and including its separator.
+func (po *POutput) deserialize(
+	r io.Reader) error {
+
+	for {
+
+		keyint, keydata, err := getKey(r)
+
+		if err != nil {
+
+			return err
+		}
+
+		if keyint == -1 {
+
+			break
+		}
+
+		value, err := readValue(r)
+
+		if err != nil {
+
+			return err
+		}
+
+		switch OutputType(keyint) {
+
+		case RedeemScriptOutputType:
+
+			if po.RedeemScript != nil {
+
+				return ErrDuplicateKey
+			}
+
+			if len(keydata) != 0 {
+
+				return ErrInvalidKeydata
+			}
+
+			po.RedeemScript = value
+
+		case WitnessScriptOutputType:
+
+			if po.WitnessScript != nil {
+
+				return ErrDuplicateKey
+			}
+
+			if len(keydata) != 0 {
+
+				return ErrInvalidKeydata
+			}
+
+			po.WitnessScript = value
+
+		case Bip32DerivationOutputType:
+
+			if !validatePubkey(keydata) {
+
+				return ErrInvalidKeydata
+			}
+
+			master, derivationPath, err := readBip32Derivation(value)
+
+			if err != nil {
+
+				return err
+			}
+
+			for _, x := range po.Bip32Derivation {
+
+				if bytes.Equal(x.PubKey, keydata) {
+
+					return ErrDuplicateKey
+				}
+			}
+
+			po.Bip32Derivation = append(po.Bip32Derivation, &Bip32Derivation{
+				PubKey:               keydata,
+				MasterKeyFingerprint: master,
+				Bip32Path:            derivationPath,
+			})
+
+		default:
+			key := append([]byte{byte(keyint)}, keydata...)
+
+			for _, x := range po.Unknowns {
+
+				if bytes.Equal(x.Key, key) {
+
+					return ErrDuplicateKey
+				}
+			}
+
+			po.Unknowns = append(po.Unknowns, &Unknown{Key: key, Value: value})
+		}
+	}
+
+	return nil
+}
+
+// serialize writes the map of the output to w, followed by its separator.
+func (po *POutput) serialize(
+	w io.Writer) error {
+
+	if po.RedeemScript != nil {
+
+		err := serializeKVPairWithType(w, uint8(RedeemScriptOutputType), nil,
+			po.RedeemScript)
+
+		if err != nil {
+
+			return err
+		}
+	}
+
+	if po.WitnessScript != nil {
+
+		err := serializeKVPairWithType(w, uint8(WitnessScriptOutputType), nil,
+			po.WitnessScript)
+
+		if err != nil {
+
+			return err
+		}
+	}
+
+	sortBip32Derivations(po.Bip32Derivation)
+
+	for _, kd := range po.Bip32Derivation {
+
+		err := serializeKVPairWithType(w, uint8(Bip32DerivationOutputType),
+			kd.PubKey, serializeBip32Derivation(kd.MasterKeyFingerprint,
+				kd.Bip32Path))
+
+		if err != nil {
+
+			return err
+		}
+	}
+
+	for _, kv := range po.Unknowns {
+
+		if err := serializeKVPair(w, kv.Key, kv.Value); err != nil {
+
+			return err
+		}
+	}
+
+	_, err := w.Write([]byte{0x00})
+	return err
+}
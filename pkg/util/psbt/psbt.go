@@ -0,0 +1,415 @@
+package psbt
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"io"
+	"io/ioutil"
+
+	"git.parallelcoin.io/dev/pod/pkg/chain/wire"
+)
+
+// psbtMagic is the magic prefix of every serialized PSBT, "psbt" followed by 0xff.
+var psbtMagic = [5]byte{0x70, 0x73, 0x62, 0x74, 0xff}
+
+const (
+	// MaxPsbtValueLength is the largest value accepted in a serialized PSBT, bounding the memory a malformed PSBT can claim.
+	MaxPsbtValueLength = 4000000
+
+	// MaxPsbtKeyLength is the largest key accepted in a serialized PSBT.
+	MaxPsbtKeyLength = 10000
+)
+
+var (
+	// ErrInvalidPsbtFormat is returned when a PSBT does not follow the serialization format of BIP 174.
+	ErrInvalidPsbtFormat = errors.New("invalid PSBT serialization format")
+
+	// ErrDuplicateKey is returned when a map of a PSBT holds the same key twice.
+	ErrDuplicateKey = errors.New("invalid PSBT due to duplicate key")
+
+	// ErrInvalidKeydata is returned when a key of a PSBT carries key data its type does not allow.
+	ErrInvalidKeydata = errors.New("invalid key data")
+
+	// ErrInvalidMagicBytes is returned when a serialized PSBT does not start with the PSBT magic bytes.
+	ErrInvalidMagicBytes = errors.New("invalid PSBT magic bytes")
+
+	// ErrInvalidRawTxSigned is returned when the transaction of a PSBT has signature scripts or witnesses.
+	ErrInvalidRawTxSigned = errors.New("invalid PSBT, raw transaction must be unsigned")
+
+	// ErrInvalidPrevOutNonWitnessTransaction is returned when the non-witness utxo of an input is not the transaction the input spends from.
+	ErrInvalidPrevOutNonWitnessTransaction = errors.New("prevout hash does not match the provided non-witness utxo serialization")
+
+	// ErrInvalidSignatureForInput is returned when a signature is added to an input with a signature hash type other than the one the input requires.
+	ErrInvalidSignatureForInput = errors.New("signature does not correspond to this input")
+
+	// ErrInputAlreadyFinalized is returned when an input is updated after it was finalized.
+	ErrInputAlreadyFinalized = errors.New("cannot update an input that has already been finalized")
+
+	// ErrInvalidInputIndex is returned when an input or output index is out of range.
+	ErrInvalidInputIndex = errors.New("input or output index out of range")
+
+	// ErrIncompletePSBT is returned when a transaction is extracted from a PSBT whose inputs are not all finalized.
+	ErrIncompletePSBT = errors.New("PSBT cannot be extracted as it is incomplete")
+
+	// ErrNotFinalizable is returned when an input lacks the information or signatures needed to finalize it.
+	ErrNotFinalizable = errors.New("PSBT input cannot be finalized")
+
+	// ErrMissingUtxo is returned when an input does not carry the output it spends.
+	ErrMissingUtxo = errors.New("PSBT input does not carry the output it spends")
+
+	// ErrUnsupportedScriptType is returned when an input spends an output of a type the finalizer does not know how to satisfy.
+	ErrUnsupportedScriptType = errors.New("unsupported script type")
+
+	// ErrDifferentTransactions is returned when PSBTs for different transactions are combined.
+	ErrDifferentTransactions = errors.New("PSBTs are for different transactions")
+)
+
+// Packet is a partially signed transaction: an unsigned transaction together with the information about each of its inputs and outputs that signers need.
+type Packet struct {
+	UnsignedTx *wire.MsgTx
+	Inputs     []PInput
+	Outputs    []POutput
+	Unknowns   []*Unknown
+}
+
+// validateUnsignedTX reports whether none of the inputs of tx carry a signature script or witness.
+func validateUnsignedTX(
+	tx *wire.MsgTx) bool {
+
+	for _, tin := range tx.TxIn {
+
+		if len(tin.SignatureScript) != 0 || len(tin.Witness) != 0 {
+
+			return false
+		}
+	}
+
+	return true
+}
+
+// NewFromUnsignedTx creates a PSBT for tx, which must not be signed, with empty maps for each of its inputs and outputs.
+func NewFromUnsignedTx(
+	tx *wire.MsgTx) (*Packet, error) {
+
+	if !validateUnsignedTX(tx) {
+
+		return nil, ErrInvalidRawTxSigned
+	}
+
+	return &Packet{
+		UnsignedTx: tx,
+		Inputs:     make([]PInput, len(tx.TxIn)),
+		Outputs:    make([]POutput, len(tx.TxOut)),
+	}, nil
+}
+
+// NewFromRawBytes parses a serialized PSBT from r, base64 encoded when b64 is set.
+func NewFromRawBytes(
+	r io.Reader, b64 bool) (*Packet, error) {
+
+	if b64 {
+
+		encoded, err := ioutil.ReadAll(r)
+
+		if err != nil {
+
+			return nil, err
+		}
+
+		decoded, err := base64.StdEncoding.DecodeString(
+			string(bytes.TrimSpace(encoded)))
+
+		if err != nil {
+
+			return nil, err
+		}
+
+		r = bytes.NewReader(decoded)
+	}
+
+	var magic [5]byte
+
+	if _, err := io.ReadFull(r, magic[:]); err != nil {
+
+		return nil, ErrInvalidPsbtFormat
+	}
+
+	if magic != psbtMagic {
+
+		return nil, ErrInvalidMagicBytes
+	}
+
+	// The unsigned transaction must come first in the global map.
+	keyint, keydata, err := getKey(r)
+
+	if err != nil {
+
+		return nil, err
+	}
+
+	if GlobalType(keyint) != UnsignedTxType || len(keydata) != 0 {
+
+		return nil, ErrInvalidPsbtFormat
+	}
+
+	value, err := readValue(r)
+
+	if err != nil {
+
+		return nil, err
+	}
+
+	msgTx := wire.NewMsgTx(2)
+	txReader := bytes.NewReader(value)
+
+	if err := msgTx.DeserializeNoWitness(txReader); err != nil {
+
+		return nil, ErrInvalidPsbtFormat
+	}
+
+	if txReader.Len() != 0 {
+
+		return nil, ErrInvalidPsbtFormat
+	}
+
+	if !validateUnsignedTX(msgTx) {
+
+		return nil, ErrInvalidRawTxSigned
+	}
+
+	// Any other global keys are kept as unknowns.
+	var unknowns []*Unknown
+
+	for {
+
+		keyint, keydata, err := getKey(r)
+
+		if err != nil {
+
+			return nil, err
+		}
+
+		if keyint == -1 {
+
+			break
+		}
+
+		value, err := readValue(r)
+
+		if err != nil {
+
+			return nil, err
+		}
+
+		key := append([]byte{byte(keyint)}, keydata...)
+
+		for _, x := range unknowns {
+
+			if bytes.Equal(x.Key, key) {
+
+				return nil, ErrDuplicateKey
+			}
+		}
+
+		unknowns = append(unknowns, &Unknown{Key: key, Value: value})
+	}
+
+	inputs := make([]PInput, len(msgTx.TxIn))
+
+	for i := range inputs {
+
+		if err := inputs[i].deserialize(r); err != nil {
+
+			return nil, err
+		}
+	}
+
+	outputs := make([]POutput, len(msgTx.TxOut))
+
+	for i := range outputs {
+
+		if err := outputs[i].deserialize(r); err != nil {
+
+			return nil, err
+		}
+	}
+
+	p := &Packet{
+		UnsignedTx: msgTx,
+		Inputs:     inputs,
+		Outputs:    outputs,
+		Unknowns:   unknowns,
+	}
+
+	if err := p.SanityCheck(); err != nil {
+
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// Serialize writes the PSBT to w in the binary format of BIP 174.
+func (p *Packet) Serialize(
+	w io.Writer) error {
+
+	if _, err := w.Write(psbtMagic[:]); err != nil {
+
+		return err
+	}
+
+	var tx bytes.Buffer
+
+	if err := p.UnsignedTx.SerializeNoWitness(&tx); err != nil {
+
+		return err
+	}
+
+	err := serializeKVPairWithType(w, uint8(UnsignedTxType), nil, tx.Bytes())
+
+	if err != nil {
+
+		return err
+	}
+
+	for _, kv := range p.Unknowns {
+
+		if err := serializeKVPair(w, kv.Key, kv.Value); err != nil {
+
+			return err
+		}
+	}
+
+	if _, err := w.Write([]byte{0x00}); err != nil {
+
+		return err
+	}
+
+	for i := range p.Inputs {
+
+		if err := p.Inputs[i].serialize(w); err != nil {
+
+			return err
+		}
+	}
+
+	for i := range p.Outputs {
+
+		if err := p.Outputs[i].serialize(w); err != nil {
+
+			return err
+		}
+	}
+
+	return nil
+}
+
+// B64Encode returns the PSBT serialized and base64 encoded.
+func (p *Packet) B64Encode() (string, error) {
+
+	var b bytes.Buffer
+
+	if err := p.Serialize(&b); err != nil {
+
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(b.Bytes()), nil
+}
+
+// IsComplete reports whether every input of the PSBT is finalized, so the transaction can be extracted.
+func (p *Packet) IsComplete() bool {
+
+	for i := range p.Inputs {
+
+		if !p.Inputs[i].IsFinalized() {
+
+			return false
+		}
+	}
+
+	return true
+}
+
+// SanityCheck checks that the PSBT describes each input and output of its transaction, and that the non-witness utxos of the inputs are the transactions they spend from.
+func (p *Packet) SanityCheck() error {
+
+	if !validateUnsignedTX(p.UnsignedTx) {
+
+		return ErrInvalidRawTxSigned
+	}
+
+	if len(p.Inputs) != len(p.UnsignedTx.TxIn) ||
+		len(p.Outputs) != len(p.UnsignedTx.TxOut) {
+
+		return ErrInvalidPsbtFormat
+	}
+
+	for i, tin := range p.UnsignedTx.TxIn {
+
+		utxo := p.Inputs[i].NonWitnessUtxo
+
+		if utxo == nil {
+
+			continue
+		}
+
+		if utxo.TxHash() != tin.PreviousOutPoint.Hash ||
+			int(tin.PreviousOutPoint.Index) >= len(utxo.TxOut) {
+
+			return ErrInvalidPrevOutNonWitnessTransaction
+		}
+	}
+
+	return nil
+}
+
+// PrevOutput returns the output spent by input inIndex of the PSBT, or nil if the input does not carry it.
+func (p *Packet) PrevOutput(
+	inIndex int) *wire.TxOut {
+
+	pInput := &p.Inputs[inIndex]
+
+	if pInput.WitnessUtxo != nil {
+
+		return pInput.WitnessUtxo
+	}
+
+	if pInput.NonWitnessUtxo != nil {
+
+		prevIndex := p.UnsignedTx.TxIn[inIndex].PreviousOutPoint.Index
+
+		if int(prevIndex) < len(pInput.NonWitnessUtxo.TxOut) {
+
+			return pInput.NonWitnessUtxo.TxOut[prevIndex]
+		}
+	}
+
+	return nil
+}
+
+// Fee returns the fee paid by the transaction of the PSBT.  It fails when an input does not carry the output it spends.
+func (p *Packet) Fee() (int64, error) {
+
+	var inputTotal, outputTotal int64
+
+	for i := range p.Inputs {
+
+		prevOut := p.PrevOutput(i)
+
+		if prevOut == nil {
+
+			return 0, ErrMissingUtxo
+		}
+
+		inputTotal += prevOut.Value
+	}
+
+	for _, txOut := range p.UnsignedTx.TxOut {
+
+		outputTotal += txOut.Value
+	}
+
+	return inputTotal - outputTotal, nil
+}
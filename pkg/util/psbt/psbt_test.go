@@ -0,0 +1,416 @@
+package psbt
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"reflect"
+	"testing"
+
+	chaincfg "git.parallelcoin.io/dev/pod/pkg/chain/config"
+	txscript "git.parallelcoin.io/dev/pod/pkg/chain/tx/script"
+	"git.parallelcoin.io/dev/pod/pkg/chain/wire"
+	"git.parallelcoin.io/dev/pod/pkg/util"
+	ec "git.parallelcoin.io/dev/pod/pkg/util/elliptic"
+)
+
+// testKey is a private key together with its compressed public key.
+type testKey struct {
+	priv   *ec.PrivateKey
+	pubKey []byte
+}
+
+// newTestKey generates a new random key.
+func newTestKey(
+	t *testing.T) *testKey {
+
+	priv, err := ec.NewPrivateKey(ec.S256())
+
+	if err != nil {
+
+		t.Fatalf("unable to generate key: %v", err)
+	}
+
+	return &testKey{priv: priv, pubKey: priv.PubKey().SerializeCompressed()}
+}
+
+// fundingTx returns a transaction paying value to each of the passed scripts.
+func fundingTx(
+	value int64, pkScripts ...[]byte) *wire.MsgTx {
+
+	tx := wire.NewMsgTx(2)
+	tx.AddTxIn(wire.NewTxIn(&wire.OutPoint{Index: 7}, []byte{0x51}, nil))
+
+	for _, pkScript := range pkScripts {
+
+		tx.AddTxOut(wire.NewTxOut(value, pkScript))
+	}
+
+	return tx
+}
+
+// spendingPacket returns a PSBT for a transaction spending every output of prevTx to a single output.
+func spendingPacket(
+	t *testing.T, prevTx *wire.MsgTx) *Packet {
+
+	tx := wire.NewMsgTx(2)
+	prevHash := prevTx.TxHash()
+	var total int64
+
+	for i, txOut := range prevTx.TxOut {
+
+		tx.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&prevHash, uint32(i)), nil,
+			nil))
+		total += txOut.Value
+	}
+
+	tx.AddTxOut(wire.NewTxOut(total-1000, []byte{0x51}))
+	p, err := NewFromUnsignedTx(tx)
+
+	if err != nil {
+
+		t.Fatalf("NewFromUnsignedTx: %v", err)
+	}
+
+	return p
+}
+
+// TestRoundTrip tests that every field of a PSBT survives serialization in both the binary and the base64 encoding.
+func TestRoundTrip(
+	t *testing.T) {
+
+	key := newTestKey(t)
+	prevTx := fundingTx(100000, []byte{0x51}, []byte{0x00, 0x14})
+	p := spendingPacket(t, prevTx)
+	u, err := NewUpdater(p)
+
+	if err != nil {
+
+		t.Fatalf("NewUpdater: %v", err)
+	}
+
+	sig, err := txscript.RawTxInSignature(p.UnsignedTx, 0, []byte{0x51},
+		txscript.SigHashAll, key.priv)
+
+	if err != nil {
+
+		t.Fatalf("unable to sign: %v", err)
+	}
+
+	steps := []error{
+		u.AddInNonWitnessUtxo(prevTx, 0),
+		u.AddInSighashType(txscript.SigHashAll, 0),
+		u.AddInSig(0, sig, key.pubKey),
+		u.AddInBip32Derivation(0xdeadbeef, []uint32{44, 0, 1}, key.pubKey, 0),
+		u.AddInWitnessUtxo(prevTx.TxOut[1], 1),
+		u.AddInRedeemScript([]byte{0x00, 0x14}, 1),
+		u.AddInWitnessScript([]byte{0x52}, 1),
+		u.AddOutRedeemScript([]byte{0x53}, 0),
+		u.AddOutBip32Derivation(1, []uint32{2, 3}, key.pubKey, 0),
+	}
+
+	for i, err := range steps {
+
+		if err != nil {
+
+			t.Fatalf("update %d: %v", i, err)
+		}
+	}
+
+	p.Unknowns = []*Unknown{{Key: []byte{0x70, 0x01}, Value: []byte{0x02}}}
+	p.Outputs[0].Unknowns = []*Unknown{{Key: []byte{0x09}, Value: nil}}
+
+	var buf bytes.Buffer
+
+	if err := p.Serialize(&buf); err != nil {
+
+		t.Fatalf("Serialize: %v", err)
+	}
+
+	decoded, err := NewFromRawBytes(bytes.NewReader(buf.Bytes()), false)
+
+	if err != nil {
+
+		t.Fatalf("NewFromRawBytes: %v", err)
+	}
+
+	var rebuf bytes.Buffer
+
+	if err := decoded.Serialize(&rebuf); err != nil {
+
+		t.Fatalf("Serialize: %v", err)
+	}
+
+	if !bytes.Equal(buf.Bytes(), rebuf.Bytes()) {
+
+		t.Fatalf("serialization changed after a round trip")
+	}
+
+	if !reflect.DeepEqual(decoded.Inputs[0].PartialSigs, p.Inputs[0].PartialSigs) ||
+		decoded.Inputs[0].SighashType != txscript.SigHashAll ||
+		!reflect.DeepEqual(decoded.Inputs[1].WitnessUtxo, prevTx.TxOut[1]) ||
+		!reflect.DeepEqual(decoded.Outputs[0].Bip32Derivation,
+			p.Outputs[0].Bip32Derivation) {
+
+		t.Fatalf("decoded PSBT differs from the original")
+	}
+
+	b64, err := p.B64Encode()
+
+	if err != nil {
+
+		t.Fatalf("B64Encode: %v", err)
+	}
+
+	fromB64, err := NewFromRawBytes(bytes.NewReader([]byte(b64)), true)
+
+	if err != nil {
+
+		t.Fatalf("NewFromRawBytes base64: %v", err)
+	}
+
+	rebuf.Reset()
+
+	if err := fromB64.Serialize(&rebuf); err != nil {
+
+		t.Fatalf("Serialize: %v", err)
+	}
+
+	if !bytes.Equal(buf.Bytes(), rebuf.Bytes()) {
+
+		t.Fatalf("base64 round trip changed the PSBT")
+	}
+}
+
+// TestInvalidPackets tests that malformed PSBTs are rejected.
+func TestInvalidPackets(
+	t *testing.T) {
+
+	prevTx := fundingTx(100000, []byte{0x51})
+	p := spendingPacket(t, prevTx)
+	var buf bytes.Buffer
+
+	if err := p.Serialize(&buf); err != nil {
+
+		t.Fatalf("Serialize: %v", err)
+	}
+
+	valid := buf.Bytes()
+
+	badMagic := append([]byte{}, valid...)
+	badMagic[0] = 'x'
+
+	if _, err := NewFromRawBytes(bytes.NewReader(badMagic), false); err != ErrInvalidMagicBytes {
+
+		t.Fatalf("bad magic: got %v, want %v", err, ErrInvalidMagicBytes)
+	}
+
+	truncated := valid[:len(valid)-1]
+
+	if _, err := NewFromRawBytes(bytes.NewReader(truncated), false); err == nil {
+
+		t.Fatalf("truncated PSBT was accepted")
+	}
+
+	// A second sighash type for the same input is a duplicate key.
+	duplicate := append([]byte{}, valid[:len(valid)-2]...)
+	duplicate = append(duplicate,
+		0x01, 0x03, 0x04, 0x01, 0x00, 0x00, 0x00,
+		0x01, 0x03, 0x04, 0x01, 0x00, 0x00, 0x00,
+		0x00, 0x00)
+
+	if _, err := NewFromRawBytes(bytes.NewReader(duplicate), false); err != ErrDuplicateKey {
+
+		t.Fatalf("duplicate key: got %v, want %v", err, ErrDuplicateKey)
+	}
+
+	signed := p.UnsignedTx.Copy()
+	signed.TxIn[0].SignatureScript = []byte{0x51}
+
+	if _, err := NewFromUnsignedTx(signed); err != ErrInvalidRawTxSigned {
+
+		t.Fatalf("signed transaction: got %v, want %v", err,
+			ErrInvalidRawTxSigned)
+	}
+
+	u, err := NewUpdater(p)
+
+	if err != nil {
+
+		t.Fatalf("NewUpdater: %v", err)
+	}
+
+	if err := u.AddInNonWitnessUtxo(fundingTx(1, []byte{0x52}), 0); err != ErrInvalidPrevOutNonWitnessTransaction {
+
+		t.Fatalf("wrong non-witness utxo: got %v, want %v", err,
+			ErrInvalidPrevOutNonWitnessTransaction)
+	}
+}
+
+// TestCombineFinalizeExtract signs a transaction spending pay to pubkey hash, pay to witness pubkey hash, nested pay to witness pubkey hash and 2-of-2 multisig pay to witness script hash outputs in two separate PSBTs, then combines, finalizes and extracts it and checks the result with the script engine.
+func TestCombineFinalizeExtract(
+	t *testing.T) {
+
+	params := &chaincfg.MainNetParams
+	alice, bob := newTestKey(t), newTestKey(t)
+
+	p2pkhAddr, _ := util.NewAddressPubKeyHash(util.Hash160(alice.pubKey), params)
+	p2wpkhAddr, _ := util.NewAddressWitnessPubKeyHash(
+		util.Hash160(bob.pubKey), params)
+	p2pkh, _ := txscript.PayToAddrScript(p2pkhAddr)
+	p2wpkh, _ := txscript.PayToAddrScript(p2wpkhAddr)
+
+	nestedAddr, _ := util.NewAddressScriptHash(p2wpkh, params)
+	nested, _ := txscript.PayToAddrScript(nestedAddr)
+
+	alicePub, _ := util.NewAddressPubKey(alice.pubKey, params)
+	bobPub, _ := util.NewAddressPubKey(bob.pubKey, params)
+	multiSig, err := txscript.MultiSigScript(
+		[]*util.AddressPubKey{alicePub, bobPub}, 2)
+
+	if err != nil {
+
+		t.Fatalf("MultiSigScript: %v", err)
+	}
+
+	scriptHash := sha256.Sum256(multiSig)
+	p2wshAddr, _ := util.NewAddressWitnessScriptHash(scriptHash[:], params)
+	p2wsh, _ := txscript.PayToAddrScript(p2wshAddr)
+
+	prevTx := fundingTx(100000, p2pkh, p2wpkh, nested, p2wsh)
+	p := spendingPacket(t, prevTx)
+	u, _ := NewUpdater(p)
+
+	steps := []error{
+		u.AddInNonWitnessUtxo(prevTx, 0),
+		u.AddInWitnessUtxo(prevTx.TxOut[1], 1),
+		u.AddInWitnessUtxo(prevTx.TxOut[2], 2),
+		u.AddInRedeemScript(p2wpkh, 2),
+		u.AddInWitnessUtxo(prevTx.TxOut[3], 3),
+		u.AddInWitnessScript(multiSig, 3),
+	}
+
+	for i, err := range steps {
+
+		if err != nil {
+
+			t.Fatalf("update %d: %v", i, err)
+		}
+	}
+
+	// Each signer works on a copy of the updated PSBT.
+	var buf bytes.Buffer
+
+	if err := p.Serialize(&buf); err != nil {
+
+		t.Fatalf("Serialize: %v", err)
+	}
+
+	aliceP, _ := NewFromRawBytes(bytes.NewReader(buf.Bytes()), false)
+	bobP, _ := NewFromRawBytes(bytes.NewReader(buf.Bytes()), false)
+	tx := p.UnsignedTx
+	hashes := txscript.NewTxSigHashes(tx)
+
+	sign := func(packet *Packet, key *testKey, idx int, witness bool,
+		script []byte) {
+
+		var sig []byte
+		var err error
+
+		if witness {
+
+			sig, err = txscript.RawTxInWitnessSignature(tx, hashes, idx,
+				prevTx.TxOut[idx].Value, script, txscript.SigHashAll, key.priv)
+
+		} else {
+
+			sig, err = txscript.RawTxInSignature(tx, idx, script,
+				txscript.SigHashAll, key.priv)
+		}
+
+		if err != nil {
+
+			t.Fatalf("unable to sign input %d: %v", idx, err)
+		}
+
+		u, _ := NewUpdater(packet)
+
+		if err := u.AddInSig(idx, sig, key.pubKey); err != nil {
+
+			t.Fatalf("AddInSig %d: %v", idx, err)
+		}
+	}
+
+	sign(aliceP, alice, 0, false, p2pkh)
+	sign(bobP, bob, 1, true, p2wpkh)
+	sign(bobP, bob, 2, true, p2wpkh)
+	sign(aliceP, alice, 3, true, multiSig)
+	sign(bobP, bob, 3, true, multiSig)
+
+	// Neither PSBT alone has both signatures of the multisig input.
+	if _, err := MaybeFinalize(aliceP, 3); err != ErrNotFinalizable {
+
+		t.Fatalf("finalizing half signed multisig: got %v, want %v", err,
+			ErrNotFinalizable)
+	}
+
+	combined, err := Combine(aliceP, bobP)
+
+	if err != nil {
+
+		t.Fatalf("Combine: %v", err)
+	}
+
+	if _, err := Extract(combined); err != ErrIncompletePSBT {
+
+		t.Fatalf("extracting unfinalized PSBT: got %v, want %v", err,
+			ErrIncompletePSBT)
+	}
+
+	if err := MaybeFinalizeAll(combined); err != nil {
+
+		t.Fatalf("MaybeFinalizeAll: %v", err)
+	}
+
+	if !combined.IsComplete() || combined.Inputs[3].PartialSigs != nil {
+
+		t.Fatalf("finalized PSBT is incomplete or kept partial signatures")
+	}
+
+	fee, err := combined.Fee()
+
+	if err != nil || fee != 1000 {
+
+		t.Fatalf("Fee: got %d, %v, want 1000", fee, err)
+	}
+
+	finalTx, err := Extract(combined)
+
+	if err != nil {
+
+		t.Fatalf("Extract: %v", err)
+	}
+
+	finalHashes := txscript.NewTxSigHashes(finalTx)
+
+	for i, txOut := range prevTx.TxOut {
+
+		vm, err := txscript.NewEngine(txOut.PkScript, finalTx, i,
+			txscript.StandardVerifyFlags, nil, finalHashes, txOut.Value)
+
+		if err != nil {
+
+			t.Fatalf("input %d: NewEngine: %v", i, err)
+		}
+
+		if err := vm.Execute(); err != nil {
+
+			t.Fatalf("input %d does not verify: %v", i, err)
+		}
+	}
+
+	if _, err := Combine(combined, spendingPacket(t, fundingTx(1, p2pkh))); err != ErrDifferentTransactions {
+
+		t.Fatalf("combining different transactions: got %v, want %v", err,
+			ErrDifferentTransactions)
+	}
+}
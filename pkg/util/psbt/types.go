@@ -0,0 +1,55 @@
+package psbt
+
+// GlobalType is the type of a key in the global map of a PSBT.
+type GlobalType uint8
+
+const (
+	// UnsignedTxType is the key type of the unsigned transaction, serialized without witnesses.
+	UnsignedTxType GlobalType = 0
+)
+
+// InputType is the type of a key in the map of a PSBT input.
+type InputType uint8
+
+const (
+	// NonWitnessUtxoType is the key type of the full transaction whose output the input spends.
+	NonWitnessUtxoType InputType = 0
+
+	// WitnessUtxoType is the key type of the output spent by a segregated witness input.
+	WitnessUtxoType InputType = 1
+
+	// PartialSigType is the key type of a signature, keyed by the public key it verifies with.
+	PartialSigType InputType = 2
+
+	// SighashType is the key type of the signature hash type signers must use.
+	SighashType InputType = 3
+
+	// RedeemScriptInputType is the key type of the redeem script of a pay to script hash input.
+	RedeemScriptInputType InputType = 4
+
+	// WitnessScriptInputType is the key type of the witness script of a pay to witness script hash input.
+	WitnessScriptInputType InputType = 5
+
+	// Bip32DerivationInputType is the key type of the derivation path of a public key of the input, keyed by the public key.
+	Bip32DerivationInputType InputType = 6
+
+	// FinalScriptSigType is the key type of the complete signature script of a finalized input.
+	FinalScriptSigType InputType = 7
+
+	// FinalScriptWitnessType is the key type of the complete witness of a finalized input.
+	FinalScriptWitnessType InputType = 8
+)
+
+// OutputType is the type of a key in the map of a PSBT output.
+type OutputType uint8
+
+const (
+	// RedeemScriptOutputType is the key type of the redeem script of a pay to script hash output.
+	RedeemScriptOutputType OutputType = 0
+
+	// WitnessScriptOutputType is the key type of the witness script of a pay to witness script hash output.
+	WitnessScriptOutputType OutputType = 1
+
+	// Bip32DerivationOutputType is the key type of the derivation path of a public key of the output, keyed by the public key.
+	Bip32DerivationOutputType OutputType = 2
+)
@@ -0,0 +1,279 @@
+package psbt
+
+import (
+	"bytes"
+
+	txscript "git.parallelcoin.io/dev/pod/pkg/chain/tx/script"
+	"git.parallelcoin.io/dev/pod/pkg/chain/wire"
+)
+
+// Updater adds information about inputs and outputs to a PSBT, and the signatures of signers.
+type Updater struct {
+	Upsbt *Packet
+}
+
+// NewUpdater returns an Updater for p, which must pass its sanity check.
+func NewUpdater(
+	p *Packet) (*Updater, error) {
+
+	if err := p.SanityCheck(); err != nil {
+
+		return nil, err
+	}
+
+	return &Updater{Upsbt: p}, nil
+}
+
+// input returns the map of input inIndex, which must exist and not be finalized.
+func (u *Updater) input(
+	inIndex int) (*PInput, error) {
+
+	if inIndex < 0 || inIndex >= len(u.Upsbt.Inputs) {
+
+		return nil, ErrInvalidInputIndex
+	}
+
+	pInput := &u.Upsbt.Inputs[inIndex]
+
+	if pInput.IsFinalized() {
+
+		return nil, ErrInputAlreadyFinalized
+	}
+
+	return pInput, nil
+}
+
+// output returns the map of output outIndex, which must exist.
+func (u *Updater) output(
+	outIndex int) (*POutput, error) {
+
+	if outIndex < 0 || outIndex >= len(u.Upsbt.Outputs) {
+
+		return nil, ErrInvalidInputIndex
+	}
+
+	return &u.Upsbt.Outputs[outIndex], nil
+}
+
+// AddInNonWitnessUtxo records tx as the transaction input inIndex spends from.
+func (u *Updater) AddInNonWitnessUtxo(
+	tx *wire.MsgTx, inIndex int) error {
+
+	pInput, err := u.input(inIndex)
+
+	if err != nil {
+
+		return err
+	}
+
+	prevOut := u.Upsbt.UnsignedTx.TxIn[inIndex].PreviousOutPoint
+
+	if tx.TxHash() != prevOut.Hash || int(prevOut.Index) >= len(tx.TxOut) {
+
+		return ErrInvalidPrevOutNonWitnessTransaction
+	}
+
+	pInput.NonWitnessUtxo = tx
+	return nil
+}
+
+// AddInWitnessUtxo records txout as the output spent by the segregated witness input inIndex.
+func (u *Updater) AddInWitnessUtxo(
+	txout *wire.TxOut, inIndex int) error {
+
+	pInput, err := u.input(inIndex)
+
+	if err != nil {
+
+		return err
+	}
+
+	pInput.WitnessUtxo = txout
+	return nil
+}
+
+// AddInSighashType records the signature hash type signers of input inIndex must use.
+func (u *Updater) AddInSighashType(
+	sighashType txscript.SigHashType, inIndex int) error {
+
+	pInput, err := u.input(inIndex)
+
+	if err != nil {
+
+		return err
+	}
+
+	pInput.SighashType = sighashType
+	return nil
+}
+
+// AddInRedeemScript records the redeem script of the pay to script hash input inIndex.
+func (u *Updater) AddInRedeemScript(
+	redeemScript []byte, inIndex int) error {
+
+	pInput, err := u.input(inIndex)
+
+	if err != nil {
+
+		return err
+	}
+
+	pInput.RedeemScript = redeemScript
+	return nil
+}
+
+// AddInWitnessScript records the witness script of the pay to witness script hash input inIndex.
+func (u *Updater) AddInWitnessScript(
+	witnessScript []byte, inIndex int) error {
+
+	pInput, err := u.input(inIndex)
+
+	if err != nil {
+
+		return err
+	}
+
+	pInput.WitnessScript = witnessScript
+	return nil
+}
+
+// AddInBip32Derivation records the derivation path of a public key of input inIndex.
+func (u *Updater) AddInBip32Derivation(
+	masterKeyFingerprint uint32, bip32Path []uint32, pubKey []byte,
+	inIndex int) error {
+
+	pInput, err := u.input(inIndex)
+
+	if err != nil {
+
+		return err
+	}
+
+	derivation := &Bip32Derivation{
+		PubKey:               pubKey,
+		MasterKeyFingerprint: masterKeyFingerprint,
+		Bip32Path:            bip32Path,
+	}
+
+	if !derivation.checkValid() {
+
+		return ErrInvalidKeydata
+	}
+
+	for _, x := range pInput.Bip32Derivation {
+
+		if bytes.Equal(x.PubKey, pubKey) {
+
+			return ErrDuplicateKey
+		}
+	}
+
+	pInput.Bip32Derivation = append(pInput.Bip32Derivation, derivation)
+	return nil
+}
+
+// AddInSig records the signature of input inIndex by the key of pubKey.  The signature must end with the signature hash type the input requires, if it requires one.  Adding a signature that is already recorded does nothing.
+func (u *Updater) AddInSig(
+	inIndex int, sig []byte, pubKey []byte) error {
+
+	pInput, err := u.input(inIndex)
+
+	if err != nil {
+
+		return err
+	}
+
+	partialSig := &PartialSig{PubKey: pubKey, Signature: sig}
+
+	if !partialSig.checkValid() {
+
+		return ErrInvalidPsbtFormat
+	}
+
+	if pInput.SighashType != 0 &&
+		txscript.SigHashType(sig[len(sig)-1]) != pInput.SighashType {
+
+		return ErrInvalidSignatureForInput
+	}
+
+	for _, x := range pInput.PartialSigs {
+
+		if bytes.Equal(x.PubKey, pubKey) {
+
+			if bytes.Equal(x.Signature, sig) {
+
+				return nil
+			}
+
+			return ErrDuplicateKey
+		}
+	}
+
+	pInput.PartialSigs = append(pInput.PartialSigs, partialSig)
+	return nil
+}
+
+// AddOutRedeemScript records the redeem script of the pay to script hash output outIndex.
+func (u *Updater) AddOutRedeemScript(
+	redeemScript []byte, outIndex int) error {
+
+	pOutput, err := u.output(outIndex)
+
+	if err != nil {
+
+		return err
+	}
+
+	pOutput.RedeemScript = redeemScript
+	return nil
+}
+
+// AddOutWitnessScript records the witness script of the pay to witness script hash output outIndex.
+func (u *Updater) AddOutWitnessScript(
+	witnessScript []byte, outIndex int) error {
+
+	pOutput, err := u.output(outIndex)
+
+	if err != nil {
+
+		return err
+	}
+
+	pOutput.WitnessScript = witnessScript
+	return nil
+}
+
+// AddOutBip32Derivation records the derivation path of a public key of output outIndex.
+func (u *Updater) AddOutBip32Derivation(
+	masterKeyFingerprint uint32, bip32Path []uint32, pubKey []byte,
+	outIndex int) error {
+
+	pOutput, err := u.output(outIndex)
+
+	if err != nil {
+
+		return err
+	}
+
+	derivation := &Bip32Derivation{
+		PubKey:               pubKey,
+		MasterKeyFingerprint: masterKeyFingerprint,
+		Bip32Path:            bip32Path,
+	}
+
+	if !derivation.checkValid() {
+
+		return ErrInvalidKeydata
+	}
+
+	for _, x := range pOutput.Bip32Derivation {
+
+		if bytes.Equal(x.PubKey, pubKey) {
+
+			return ErrDuplicateKey
+		}
+	}
+
+	pOutput.Bip32Derivation = append(pOutput.Bip32Derivation, derivation)
+	return nil
+}
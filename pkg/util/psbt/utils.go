@@ -0,0 +1,251 @@
+package psbt
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"sort"
+
+	"git.parallelcoin.io/dev/pod/pkg/chain/wire"
+	ec "git.parallelcoin.io/dev/pod/pkg/util/elliptic"
+)
+
+// Unknown is a key value pair of a PSBT map whose key type is not understood, kept so that it is serialized again unchanged.
+type Unknown struct {
+	Key   []byte
+	Value []byte
+}
+
+// PartialSig is a signature of an input together with the public key it verifies with.
+type PartialSig struct {
+	PubKey    []byte
+	Signature []byte
+}
+
+// checkValid reports whether the public key parses and the signature is a DER encoded signature followed by a signature hash type.
+func (ps *PartialSig) checkValid() bool {
+
+	if !validatePubkey(ps.PubKey) || len(ps.Signature) < 2 {
+
+		return false
+	}
+
+	_, err := ec.ParseDERSignature(ps.Signature[:len(ps.Signature)-1],
+		ec.S256())
+	return err == nil
+}
+
+// Bip32Derivation records the master key fingerprint and the BIP 32 derivation path of a public key.
+type Bip32Derivation struct {
+	PubKey               []byte
+	MasterKeyFingerprint uint32
+	Bip32Path            []uint32
+}
+
+// checkValid reports whether the public key of the derivation parses.
+func (pb *Bip32Derivation) checkValid() bool {
+
+	return validatePubkey(pb.PubKey)
+}
+
+// validatePubkey reports whether pubKey is a valid serialized public key.
+func validatePubkey(
+	pubKey []byte) bool {
+
+	_, err := ec.ParsePubKey(pubKey, ec.S256())
+	return err == nil
+}
+
+// readBip32Derivation parses the value of a BIP 32 derivation key, a master key fingerprint followed by the path elements, all little endian.
+func readBip32Derivation(
+	path []byte) (uint32, []uint32, error) {
+
+	if len(path)%4 != 0 || len(path)/4-1 < 1 {
+
+		return 0, nil, ErrInvalidPsbtFormat
+	}
+
+	masterKeyFingerprint := binary.LittleEndian.Uint32(path[:4])
+	var paths []uint32
+
+	for i := 4; i < len(path); i += 4 {
+
+		paths = append(paths, binary.LittleEndian.Uint32(path[i:i+4]))
+	}
+
+	return masterKeyFingerprint, paths, nil
+}
+
+// serializeBip32Derivation serializes the value of a BIP 32 derivation key.
+func serializeBip32Derivation(
+	masterKeyFingerprint uint32, bip32Path []uint32) []byte {
+
+	derivationPath := make([]byte, 4*(len(bip32Path)+1))
+	binary.LittleEndian.PutUint32(derivationPath[:4], masterKeyFingerprint)
+
+	for i, path := range bip32Path {
+
+		binary.LittleEndian.PutUint32(derivationPath[4*(i+1):], path)
+	}
+
+	return derivationPath
+}
+
+// sortPartialSigs orders partial signatures by public key so that serialization is deterministic.
+func sortPartialSigs(
+	sigs []*PartialSig) {
+
+	sort.Slice(sigs, func(i, j int) bool {
+
+		return bytes.Compare(sigs[i].PubKey, sigs[j].PubKey) < 0
+	})
+}
+
+// sortBip32Derivations orders derivations by public key so that serialization is deterministic.
+func sortBip32Derivations(
+	derivations []*Bip32Derivation) {
+
+	sort.Slice(derivations, func(i, j int) bool {
+
+		return bytes.Compare(derivations[i].PubKey, derivations[j].PubKey) < 0
+	})
+}
+
+// getKey reads the next key of a PSBT map from r and returns its type and key data.  A type of -1 with a nil error is returned for the separator that terminates the map.
+func getKey(
+	r io.Reader) (int, []byte, error) {
+
+	count, err := wire.ReadVarInt(r, 0)
+
+	if err != nil {
+
+		return -1, nil, ErrInvalidPsbtFormat
+	}
+
+	if count == 0 {
+
+		return -1, nil, nil
+	}
+
+	if count > MaxPsbtKeyLength {
+
+		return -1, nil, ErrInvalidPsbtFormat
+	}
+
+	key := make([]byte, count)
+
+	if _, err := io.ReadFull(r, key); err != nil {
+
+		return -1, nil, ErrInvalidPsbtFormat
+	}
+
+	return int(key[0]), key[1:], nil
+}
+
+// readValue reads the value of the key last read by getKey from r.
+func readValue(
+	r io.Reader) ([]byte, error) {
+
+	value, err := wire.ReadVarBytes(r, 0, MaxPsbtValueLength, "PSBT value")
+
+	if err != nil {
+
+		return nil, ErrInvalidPsbtFormat
+	}
+
+	return value, nil
+}
+
+// serializeKVPair writes a key and its value to w.
+func serializeKVPair(
+	w io.Writer, key []byte, value []byte) error {
+
+	if err := wire.WriteVarBytes(w, 0, key); err != nil {
+
+		return err
+	}
+
+	return wire.WriteVarBytes(w, 0, value)
+}
+
+// serializeKVPairWithType writes a key made of a key type and key data, and its value to w.
+func serializeKVPairWithType(
+	w io.Writer, kt uint8, keydata []byte, value []byte) error {
+
+	key := append([]byte{kt}, keydata...)
+	return serializeKVPair(w, key, value)
+}
+
+// readTxOut parses a serialized transaction output, the value of a witness utxo key.
+func readTxOut(
+	txout []byte) (*wire.TxOut, error) {
+
+	if len(txout) < 10 {
+
+		return nil, ErrInvalidPsbtFormat
+	}
+
+	r := bytes.NewReader(txout[8:])
+	script, err := wire.ReadVarBytes(r, 0, MaxPsbtValueLength, "pkScript")
+
+	if err != nil || r.Len() != 0 {
+
+		return nil, ErrInvalidPsbtFormat
+	}
+
+	value := int64(binary.LittleEndian.Uint64(txout[:8]))
+	return wire.NewTxOut(value, script), nil
+}
+
+// writeTxWitness serializes a witness stack as the value of a final script witness key.
+func writeTxWitness(
+	w io.Writer, wit wire.TxWitness) error {
+
+	if err := wire.WriteVarInt(w, 0, uint64(len(wit))); err != nil {
+
+		return err
+	}
+
+	for _, item := range wit {
+
+		if err := wire.WriteVarBytes(w, 0, item); err != nil {
+
+			return err
+		}
+	}
+
+	return nil
+}
+
+// readTxWitness parses the value of a final script witness key.
+func readTxWitness(
+	b []byte) (wire.TxWitness, error) {
+
+	r := bytes.NewReader(b)
+	count, err := wire.ReadVarInt(r, 0)
+
+	if err != nil || count > uint64(len(b)) {
+
+		return nil, ErrInvalidPsbtFormat
+	}
+
+	wit := make(wire.TxWitness, count)
+
+	for i := range wit {
+
+		wit[i], err = wire.ReadVarBytes(r, 0, MaxPsbtValueLength,
+			"witness item")
+
+		if err != nil {
+
+			return nil, ErrInvalidPsbtFormat
+		}
+	}
+
+	if r.Len() != 0 {
+
+		return nil, ErrInvalidPsbtFormat
+	}
+
+	return wit, nil
+}
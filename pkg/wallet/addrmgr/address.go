@@ -176,6 +176,13 @@ func (a *managedAddress) unlock(key EncryptorDecryptor) ([]byte, error) {
 
 	if len(a.privKeyCT) == 0 {
 
+		// Addresses of watch-only accounts have no private key.
+
+		if len(a.privKeyEncrypted) == 0 {
+
+			return nil, managerError(ErrWatchingOnly, errWatchingOnly, nil)
+		}
+
 		privKey, err := key.Decrypt(a.privKeyEncrypted)
 
 		if err != nil {
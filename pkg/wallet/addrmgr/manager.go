@@ -199,6 +199,14 @@ type accountInfo struct {
 	lastInternalAddr  ManagedAddress
 }
 
+// watchOnly returns whether the account has no private extended key, either
+// because it was created from an extended public key or because the address
+// manager is watching-only.
+func (a *accountInfo) watchOnly() bool {
+
+	return len(a.acctKeyEncrypted) == 0
+}
+
 // AccountProperties contains properties associated with each account, such as
 // the account name, number, and the nubmer of derived and imported keys.
 
@@ -208,6 +216,7 @@ type AccountProperties struct {
 	ExternalKeyCount uint32
 	InternalKeyCount uint32
 	ImportedKeyCount uint32
+	WatchOnly        bool
 }
 
 // unlockDeriveInfo houses the information needed to derive a private key for a
@@ -1368,6 +1377,14 @@ func (m *Manager) Unlock(ns walletdb.ReadBucket, passphrase []byte) error {
 
 		for account, acctInfo := range manager.acctInfo {
 
+			// Accounts created from an extended public key have no
+			// private key to decrypt.
+
+			if acctInfo.watchOnly() {
+
+				continue
+			}
+
 			decrypted, err := m.cryptoKeyPriv.Decrypt(acctInfo.acctKeyEncrypted)
 
 			if err != nil {
@@ -1396,6 +1413,17 @@ func (m *Manager) Unlock(ns walletdb.ReadBucket, passphrase []byte) error {
 
 		for _, info := range manager.deriveOnUnlock {
 
+			// The private keys of watch-only accounts can never be
+			// derived, so their addresses are dropped from the list.
+			acctInfo, ok := manager.acctInfo[info.managedAddr.Account()]
+
+			if ok && acctInfo.watchOnly() {
+
+				manager.deriveOnUnlock[0] = nil
+				manager.deriveOnUnlock = manager.deriveOnUnlock[1:]
+				continue
+			}
+
 			addressKey, err := manager.deriveKeyFromPath(
 				ns, info.managedAddr.Account(), info.branch,
 				info.index, true,
@@ -2292,3 +2320,161 @@ func Create(
 	// Use 48 hours as margin of safety for wallet birthday.
 	return putBirthday(ns, birthday.Add(-48*time.Hour))
 }
+
+// CreateWatchingOnly creates a new watching-only address manager in the given
+// namespace.  Instead of being derived from a seed, the default account of the
+// passed key scope uses the extended public key of an account, such as one
+// exported by another wallet holding its private keys.  No other key scopes
+// are created and no private keys are ever available to the manager, so it
+// can only track the addresses of the account and its balance and history.
+//
+// The public passphrase is required on subsequent opens of the address
+// manager.  If a config structure is passed to the function, that
+// configuration will override the defaults.
+//
+// A ManagerError with an error code of ErrAlreadyExists will be returned the
+// address manager already exists in the specified namespace.
+func CreateWatchingOnly(
+	ns walletdb.ReadWriteBucket, acctKeyPub *hdkeychain.ExtendedKey,
+	scope KeyScope, pubPassphrase []byte, chainParams *chaincfg.Params,
+	config *ScryptOptions, birthday time.Time) error {
+
+	if managerExists(ns) {
+
+		return managerError(ErrAlreadyExists, errAlreadyExists, nil)
+	}
+
+	scopeSchema, ok := ScopeAddrMap[scope]
+
+	if !ok {
+
+		str := fmt.Sprintf("no address schema for scope %v", scope)
+		return managerError(ErrScopeNotFound, str, nil)
+	}
+
+	if acctKeyPub.IsPrivate() {
+
+		str := "account key for a watching-only manager must be public"
+		return managerError(ErrKeyChain, str, nil)
+	}
+
+	if !acctKeyPub.IsForNet(chainParams) {
+
+		str := fmt.Sprintf("account key is not for the same network "+
+			"as the address manager (%s)", chainParams.Name)
+		return managerError(ErrWrongNet, str, nil)
+	}
+
+	if err := checkBranchKeys(acctKeyPub); err != nil {
+
+		str := "failed to derive branch keys of account key"
+		return managerError(ErrKeyChain, str, err)
+	}
+
+	// Perform the initial bucket creation and database namespace setup.
+	err := createManagerNS(ns, map[KeyScope]ScopeAddrSchema{
+		scope: scopeSchema,
+	})
+
+	if err != nil {
+
+		return maybeConvertDbError(err)
+	}
+
+	if config == nil {
+
+		config = &DefaultScryptOptions
+	}
+
+	// Only the master and crypto public keys are generated, as there is
+
+	// no private data to protect.
+	masterKeyPub, err := newSecretKey(&pubPassphrase, config)
+
+	if err != nil {
+
+		str := "failed to master public key"
+		return managerError(ErrCrypto, str, err)
+	}
+	cryptoKeyPub, err := newCryptoKey()
+
+	if err != nil {
+
+		str := "failed to generate crypto public key"
+		return managerError(ErrCrypto, str, err)
+	}
+	cryptoKeyPubEnc, err := masterKeyPub.Encrypt(cryptoKeyPub.Bytes())
+
+	if err != nil {
+
+		str := "failed to encrypt crypto public key"
+		return managerError(ErrCrypto, str, err)
+	}
+
+	err = putMasterKeyParams(ns, masterKeyPub.Marshal(), nil)
+
+	if err != nil {
+
+		return maybeConvertDbError(err)
+	}
+
+	err = putCryptoKeys(ns, cryptoKeyPubEnc, nil, nil)
+
+	if err != nil {
+
+		return maybeConvertDbError(err)
+	}
+
+	// Save the account key as the default account of the scope.
+	acctPubEnc, err := cryptoKeyPub.Encrypt([]byte(acctKeyPub.String()))
+
+	if err != nil {
+
+		str := "failed to encrypt public key for account 0"
+		return managerError(ErrCrypto, str, err)
+	}
+
+	err = putAccountInfo(
+		ns, &scope, DefaultAccountNum, acctPubEnc, nil, 0, 0,
+		defaultAccountName,
+	)
+
+	if err != nil {
+
+		return maybeConvertDbError(err)
+	}
+
+	err = putAccountInfo(
+		ns, &scope, ImportedAddrAccount, nil, nil, 0, 0,
+		ImportedAddrAccountName,
+	)
+
+	if err != nil {
+
+		return maybeConvertDbError(err)
+	}
+
+	if err := putWatchingOnly(ns, true); err != nil {
+
+		return maybeConvertDbError(err)
+	}
+
+	// Use the genesis block for the passed chain as the created at block.
+	createdAt := &BlockStamp{Hash: *chainParams.GenesisHash, Height: 0}
+	syncInfo := newSyncState(createdAt, createdAt)
+	err = putSyncedTo(ns, &syncInfo.syncedTo)
+
+	if err != nil {
+
+		return maybeConvertDbError(err)
+	}
+	err = putStartBlock(ns, &syncInfo.startBlock)
+
+	if err != nil {
+
+		return maybeConvertDbError(err)
+	}
+
+	// Use 48 hours as margin of safety for wallet birthday.
+	return putBirthday(ns, birthday.Add(-48*time.Hour))
+}
@@ -14,6 +14,7 @@ import (
 	chaincfg "git.parallelcoin.io/dev/pod/pkg/chain/config"
 	chainhash "git.parallelcoin.io/dev/pod/pkg/chain/hash"
 	"git.parallelcoin.io/dev/pod/pkg/util"
+	"git.parallelcoin.io/dev/pod/pkg/util/hdkeychain"
 	"git.parallelcoin.io/dev/pod/pkg/util/snacl"
 	waddrmgr "git.parallelcoin.io/dev/pod/pkg/wallet/addrmgr"
 	walletdb "git.parallelcoin.io/dev/pod/pkg/wallet/db"
@@ -3167,3 +3168,266 @@ func TestNewRawAccount(
 			accountTargetAddr.AddrHash())
 	}
 }
+
+// watchOnlyAcctKey returns an extended public key to create watch-only
+// accounts from, along with the address of its first external key.
+func watchOnlyAcctKey(
+	t *testing.T) (*hdkeychain.ExtendedKey, string) {
+
+	otherSeed := bytes.Repeat([]byte{0x5a}, hdkeychain.RecommendedSeedLen)
+	master, err := hdkeychain.NewMaster(otherSeed, &chaincfg.MainNetParams)
+
+	if err != nil {
+
+		t.Fatalf("unable to create master key: %v", err)
+	}
+
+	acctKeyPub, err := master.Neuter()
+
+	if err != nil {
+
+		t.Fatalf("unable to neuter master key: %v", err)
+	}
+
+	branchKey, err := acctKeyPub.Child(waddrmgr.ExternalBranch)
+
+	if err != nil {
+
+		t.Fatalf("unable to derive branch key: %v", err)
+	}
+
+	addrKey, err := branchKey.Child(0)
+
+	if err != nil {
+
+		t.Fatalf("unable to derive address key: %v", err)
+	}
+
+	addr, err := addrKey.Address(&chaincfg.MainNetParams)
+
+	if err != nil {
+
+		t.Fatalf("unable to create address: %v", err)
+	}
+
+	return acctKeyPub, addr.EncodeAddress()
+}
+
+// TestNewWatchOnlyAccount tests that accounts created from an extended public
+// key derive the addresses of the key, have no private keys, and do not stop
+// the manager from being locked and unlocked.
+func TestNewWatchOnlyAccount(
+	t *testing.T) {
+
+	t.Parallel()
+
+	teardown, db, mgr := setupManager(t)
+	defer teardown()
+
+	acctKeyPub, wantAddr := watchOnlyAcctKey(t)
+	scopedMgr, err := mgr.FetchScopedKeyManager(waddrmgr.KeyScopeBIP0044)
+
+	if err != nil {
+
+		t.Fatalf("unable to fetch scope: %v", err)
+	}
+
+	// A private extended key must be rejected.
+	otherSeed := bytes.Repeat([]byte{0x5a}, hdkeychain.RecommendedSeedLen)
+	master, err := hdkeychain.NewMaster(otherSeed, &chaincfg.MainNetParams)
+
+	if err != nil {
+
+		t.Fatalf("unable to create master key: %v", err)
+	}
+
+	err = walletdb.Update(db, func(tx walletdb.ReadWriteTx) error {
+
+		ns := tx.ReadWriteBucket(waddrmgrNamespaceKey)
+		_, err := scopedMgr.NewWatchOnlyAccount(ns, "private", master)
+		return err
+	})
+	checkManagerError(t, "NewWatchOnlyAccount", err, waddrmgr.ErrKeyChain)
+
+	// The account is created while the manager is unlocked, so that new
+
+	// addresses of the other accounts would be derived from private keys.
+	err = walletdb.View(db, func(tx walletdb.ReadTx) error {
+
+		ns := tx.ReadBucket(waddrmgrNamespaceKey)
+		return mgr.Unlock(ns, privPassphrase)
+	})
+
+	if err != nil {
+
+		t.Fatalf("unable to unlock manager: %v", err)
+	}
+
+	var account uint32
+	var addr waddrmgr.ManagedAddress
+	var props *waddrmgr.AccountProperties
+	err = walletdb.Update(db, func(tx walletdb.ReadWriteTx) error {
+
+		ns := tx.ReadWriteBucket(waddrmgrNamespaceKey)
+		var err error
+		account, err = scopedMgr.NewWatchOnlyAccount(ns, "cold", acctKeyPub)
+
+		if err != nil {
+
+			return err
+		}
+
+		addrs, err := scopedMgr.NextExternalAddresses(ns, account, 1)
+
+		if err != nil {
+
+			return err
+		}
+
+		addr = addrs[0]
+		props, err = scopedMgr.AccountProperties(ns, account)
+		return err
+	})
+
+	if err != nil {
+
+		t.Fatalf("unable to create watch-only account: %v", err)
+	}
+
+	if account != 1 {
+
+		t.Fatalf("account number: got %d, want 1", account)
+	}
+
+	if addr.Address().EncodeAddress() != wantAddr {
+
+		t.Fatalf("address: got %v, want %v", addr.Address(), wantAddr)
+	}
+
+	if !props.WatchOnly || props.ExternalKeyCount != 1 {
+
+		t.Fatalf("unexpected account properties: %v", spew.Sdump(props))
+	}
+
+	_, err = addr.(waddrmgr.ManagedPubKeyAddress).PrivKey()
+	checkManagerError(t, "PrivKey", err, waddrmgr.ErrWatchingOnly)
+
+	// Addresses derived for the account while the manager is locked must
+
+	// not stop it from being unlocked again.
+
+	if err := mgr.Lock(); err != nil {
+
+		t.Fatalf("unable to lock manager: %v", err)
+	}
+
+	err = walletdb.Update(db, func(tx walletdb.ReadWriteTx) error {
+
+		ns := tx.ReadWriteBucket(waddrmgrNamespaceKey)
+		_, err := scopedMgr.NextExternalAddresses(ns, account, 1)
+
+		if err != nil {
+
+			return err
+		}
+
+		_, err = scopedMgr.NextExternalAddresses(ns, 0, 1)
+
+		if err != nil {
+
+			return err
+		}
+
+		return mgr.Unlock(ns, privPassphrase)
+	})
+
+	if err != nil {
+
+		t.Fatalf("unable to unlock manager: %v", err)
+	}
+}
+
+// TestCreateWatchingOnly tests that a watching-only manager created from an
+// extended public key derives the addresses of the key in its default account.
+func TestCreateWatchingOnly(
+	t *testing.T) {
+
+	t.Parallel()
+
+	teardown, db := emptyDB(t)
+	defer teardown()
+
+	acctKeyPub, wantAddr := watchOnlyAcctKey(t)
+	var mgr *waddrmgr.Manager
+	err := walletdb.Update(db, func(tx walletdb.ReadWriteTx) error {
+
+		ns, err := tx.CreateTopLevelBucket(waddrmgrNamespaceKey)
+
+		if err != nil {
+
+			return err
+		}
+
+		err = waddrmgr.CreateWatchingOnly(
+			ns, acctKeyPub, waddrmgr.KeyScopeBIP0044, pubPassphrase,
+			&chaincfg.MainNetParams, fastScrypt, time.Time{},
+		)
+
+		if err != nil {
+
+			return err
+		}
+
+		mgr, err = waddrmgr.Open(ns, pubPassphrase, &chaincfg.MainNetParams)
+		return err
+	})
+
+	if err != nil {
+
+		t.Fatalf("create/open: unexpected error: %v", err)
+	}
+	defer mgr.Close()
+
+	if !mgr.WatchOnly() {
+
+		t.Fatalf("manager is not watching-only")
+	}
+
+	_, err = mgr.FetchScopedKeyManager(waddrmgr.KeyScopeBIP0084)
+	checkManagerError(t, "FetchScopedKeyManager", err,
+		waddrmgr.ErrScopeNotFound)
+
+	scopedMgr, err := mgr.FetchScopedKeyManager(waddrmgr.KeyScopeBIP0044)
+
+	if err != nil {
+
+		t.Fatalf("unable to fetch scope: %v", err)
+	}
+
+	var addr waddrmgr.ManagedAddress
+	err = walletdb.Update(db, func(tx walletdb.ReadWriteTx) error {
+
+		ns := tx.ReadWriteBucket(waddrmgrNamespaceKey)
+		addrs, err := scopedMgr.NextExternalAddresses(
+			ns, waddrmgr.DefaultAccountNum, 1,
+		)
+
+		if err != nil {
+
+			return err
+		}
+
+		addr = addrs[0]
+		return nil
+	})
+
+	if err != nil {
+
+		t.Fatalf("unable to derive address: %v", err)
+	}
+
+	if addr.Address().EncodeAddress() != wantAddr {
+
+		t.Fatalf("address: got %v, want %v", addr.Address(), wantAddr)
+	}
+}
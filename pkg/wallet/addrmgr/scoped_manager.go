@@ -315,7 +315,8 @@ func (s *ScopedKeyManager) keyToManaged(derivedKey *hdkeychain.ExtendedKey,
 }
 
 // deriveKey returns either a public or private derived extended key based on
-// the private flag for the given an account info, branch, and index.
+// the private flag for the given an account info, branch, and index.  A public
+// key is always derived for watch-only accounts.
 func (s *ScopedKeyManager) deriveKey(acctInfo *accountInfo, branch,
 	index uint32, private bool) (*hdkeychain.ExtendedKey, error) {
 
@@ -326,7 +327,7 @@ func (s *ScopedKeyManager) deriveKey(acctInfo *accountInfo, branch,
 	// private child derivation.
 	acctKey := acctInfo.acctKeyPub
 
-	if private {
+	if private && acctInfo.acctKeyPriv != nil {
 
 		acctKey = acctInfo.acctKeyPriv
 	}
@@ -420,7 +421,7 @@ func (s *ScopedKeyManager) loadAccountInfo(ns walletdb.ReadBucket,
 		nextInternalIndex: row.nextInternalIndex,
 	}
 
-	if !s.rootManager.isLocked() {
+	if !s.rootManager.isLocked() && !acctInfo.watchOnly() {
 
 		// Use the crypto private key to decrypt the account private
 		// extended keys.
@@ -452,7 +453,7 @@ func (s *ScopedKeyManager) loadAccountInfo(ns walletdb.ReadBucket,
 		index--
 	}
 	lastExtKey, err := s.deriveKey(
-		acctInfo, branch, index, !s.rootManager.isLocked(),
+		acctInfo, branch, index, acctInfo.acctKeyPriv != nil,
 	)
 
 	if err != nil {
@@ -475,7 +476,7 @@ func (s *ScopedKeyManager) loadAccountInfo(ns walletdb.ReadBucket,
 		index--
 	}
 	lastIntKey, err := s.deriveKey(
-		acctInfo, branch, index, !s.rootManager.isLocked(),
+		acctInfo, branch, index, acctInfo.acctKeyPriv != nil,
 	)
 
 	if err != nil {
@@ -538,6 +539,7 @@ func (s *ScopedKeyManager) AccountProperties(ns walletdb.ReadBucket,
 		props.AccountName = acctInfo.acctName
 		props.ExternalKeyCount = acctInfo.nextExternalIndex
 		props.InternalKeyCount = acctInfo.nextInternalIndex
+		props.WatchOnly = acctInfo.watchOnly()
 	} else {
 
 		props.AccountName = ImportedAddrAccountName // reserved, nonchangable
@@ -844,7 +846,7 @@ func (s *ScopedKeyManager) nextAddresses(ns walletdb.ReadWriteBucket,
 	// is locked.
 	acctKey := acctInfo.acctKeyPub
 
-	if !s.rootManager.IsLocked() {
+	if !s.rootManager.IsLocked() && !acctInfo.watchOnly() {
 
 		acctKey = acctInfo.acctKeyPriv
 	}
@@ -1025,7 +1027,7 @@ func (s *ScopedKeyManager) nextAddresses(ns walletdb.ReadWriteBucket,
 		// need their private keys derived when the address manager is
 		// next unlocked.
 
-		if s.rootManager.IsLocked() && !s.rootManager.WatchOnly() {
+		if s.rootManager.IsLocked() && !acctInfo.watchOnly() {
 
 			s.deriveOnUnlock = append(s.deriveOnUnlock, info)
 		}
@@ -1074,7 +1076,7 @@ func (s *ScopedKeyManager) extendAddresses(ns walletdb.ReadWriteBucket,
 	// is locked.
 	acctKey := acctInfo.acctKeyPub
 
-	if !s.rootManager.IsLocked() {
+	if !s.rootManager.IsLocked() && !acctInfo.watchOnly() {
 
 		acctKey = acctInfo.acctKeyPriv
 	}
@@ -1266,7 +1268,7 @@ func (s *ScopedKeyManager) extendAddresses(ns walletdb.ReadWriteBucket,
 		// need their private keys derived when the address manager is
 		// next unlocked.
 
-		if s.rootManager.IsLocked() && !s.rootManager.WatchOnly() {
+		if s.rootManager.IsLocked() && !acctInfo.watchOnly() {
 
 			s.deriveOnUnlock = append(s.deriveOnUnlock, info)
 		}
@@ -1517,6 +1519,90 @@ func (s *ScopedKeyManager) NewAccount(ns walletdb.ReadWriteBucket, name string)
 	return account, nil
 }
 
+// NewWatchOnlyAccount creates and returns a new account stored in the manager
+// whose addresses are derived from the passed extended public key of an
+// account, such as one exported by another wallet holding its private keys.
+// No private keys are ever available for the account, so its outputs can be
+// watched and spent by transactions signed elsewhere, but not signed here.
+// Since only the public crypto key is needed, the manager may be locked or
+// watching-only.  If an account with the same name already exists,
+// ErrDuplicateAccount will be returned.
+func (s *ScopedKeyManager) NewWatchOnlyAccount(ns walletdb.ReadWriteBucket,
+	name string, acctKeyPub *hdkeychain.ExtendedKey) (uint32, error) {
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if acctKeyPub.IsPrivate() {
+
+		str := "account key for a watch-only account must be public"
+		return 0, managerError(ErrKeyChain, str, nil)
+	}
+
+	if !acctKeyPub.IsForNet(s.rootManager.chainParams) {
+
+		str := fmt.Sprintf("account key is not for the same network "+
+			"as the address manager (%s)", s.rootManager.chainParams.Name)
+		return 0, managerError(ErrWrongNet, str, nil)
+	}
+
+	// Ensure the branch keys can be derived from the account key.
+
+	if err := checkBranchKeys(acctKeyPub); err != nil {
+
+		str := "failed to derive branch keys of account key"
+		return 0, managerError(ErrKeyChain, str, err)
+	}
+
+	if err := ValidateAccountName(name); err != nil {
+
+		return 0, err
+	}
+
+	_, err := s.lookupAccount(ns, name)
+
+	if err == nil {
+
+		str := fmt.Sprintf("account with the same name already exists")
+		return 0, managerError(ErrDuplicateAccount, str, err)
+	}
+
+	account, err := fetchLastAccount(ns, &s.scope)
+
+	if err != nil {
+
+		return 0, err
+	}
+	account++
+
+	// Only the public key of the account is stored, which marks it as
+
+	// watch-only.
+	acctPubEnc, err := s.rootManager.cryptoKeyPub.Encrypt(
+		[]byte(acctKeyPub.String()),
+	)
+
+	if err != nil {
+
+		str := "failed to encrypt public key for account"
+		return 0, managerError(ErrCrypto, str, err)
+	}
+
+	err = putAccountInfo(ns, &s.scope, account, acctPubEnc, nil, 0, 0, name)
+
+	if err != nil {
+
+		return 0, err
+	}
+
+	if err := putLastAccount(ns, &s.scope, account); err != nil {
+
+		return 0, err
+	}
+
+	return account, nil
+}
+
 // newAccount is a helper function that derives a new precise account number,
 // and creates a mapping from the passed name to the account number in the
 // database.
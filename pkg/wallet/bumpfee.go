@@ -0,0 +1,455 @@
+package wallet
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	chainhash "git.parallelcoin.io/dev/pod/pkg/chain/hash"
+	txauthor "git.parallelcoin.io/dev/pod/pkg/chain/tx/author"
+	wtxmgr "git.parallelcoin.io/dev/pod/pkg/chain/tx/mgr"
+	txrules "git.parallelcoin.io/dev/pod/pkg/chain/tx/rules"
+	txscript "git.parallelcoin.io/dev/pod/pkg/chain/tx/script"
+	"git.parallelcoin.io/dev/pod/pkg/chain/wire"
+	"git.parallelcoin.io/dev/pod/pkg/util"
+	cl "git.parallelcoin.io/dev/pod/pkg/util/cl"
+	waddrmgr "git.parallelcoin.io/dev/pod/pkg/wallet/addrmgr"
+	walletdb "git.parallelcoin.io/dev/pod/pkg/wallet/db"
+)
+
+var (
+	// ErrTxMined describes an error where a transaction can not be
+
+	// replaced because it is already mined in a block.
+	ErrTxMined = errors.New("transaction is already mined")
+
+	// ErrTxNotReplaceable describes an error where a transaction does not
+
+	// signal that it may be replaced (BIP 125), so nodes would reject any
+
+	// replacement.
+	ErrTxNotReplaceable = errors.New("transaction does not signal " +
+		"replaceability")
+
+	// ErrTxNotFromWallet describes an error where a transaction spends
+
+	// outputs that do not belong to the wallet, so the wallet can not sign
+
+	// a replacement.
+	ErrTxNotFromWallet = errors.New("transaction spends outputs not " +
+		"controlled by the wallet")
+
+	// ErrFeeRateTooLow describes an error where the requested fee rate of a
+
+	// replacement does not exceed the fee rate of the transaction it
+
+	// replaces.
+	ErrFeeRateTooLow = errors.New("fee rate does not exceed the fee rate " +
+		"of the transaction to replace")
+)
+
+// BumpFee replaces the unmined wallet transaction with the given hash by one
+
+// paying the same outputs at a higher fee, and publishes it.  A zero
+
+// feeSatPerKb pays the fee rate of the original transaction increased by the
+
+// default relay fee.  Any further inputs the higher fee requires are chosen
+
+// from confirmed outputs of the account the original transaction returned
+
+// change to.  The hash of the replacement is returned along with the fees of
+
+// the original transaction and the replacement.
+func (w *Wallet) BumpFee(txHash *chainhash.Hash, feeSatPerKb util.Amount) (
+
+	*chainhash.Hash, util.Amount, util.Amount, error) {
+
+	var origFee util.Amount
+
+	err := walletdb.View(w.db, func(dbtx walletdb.ReadTx) error {
+
+		txmgrNs := dbtx.ReadBucket(wtxmgrNamespaceKey)
+		var err error
+		_, origFee, err = w.replaceableTx(txmgrNs, txHash)
+		return err
+	})
+
+	if err != nil {
+
+		return nil, 0, 0, err
+	}
+
+	req := createTxRequest{
+
+		replaces:    txHash,
+		feeSatPerKB: feeSatPerKb,
+		resp:        make(chan createTxResponse),
+	}
+
+	w.createTxRequests <- req
+	resp := <-req.resp
+
+	if resp.err != nil {
+
+		return nil, 0, 0, resp.err
+	}
+
+	fee := resp.tx.TotalInput - util.Amount(sumOutputValues(resp.tx.Tx))
+
+	hash, err := w.publishReplacement(txHash, resp.tx.Tx)
+
+	if err != nil {
+
+		return nil, 0, 0, err
+	}
+
+	return hash, origFee, fee, nil
+}
+
+// replaceableTx returns the details and the fee of the wallet transaction with
+
+// the given hash, or an error if the wallet is unable to replace it.
+func (w *Wallet) replaceableTx(txmgrNs walletdb.ReadBucket,
+
+	txHash *chainhash.Hash) (*wtxmgr.TxDetails, util.Amount, error) {
+
+	details, err := w.TxStore.TxDetails(txmgrNs, txHash)
+
+	if err != nil {
+
+		return nil, 0, err
+	}
+
+	if details == nil {
+
+		return nil, 0, ErrNoTx
+	}
+
+	if details.Block.Height != -1 {
+
+		return nil, 0, ErrTxMined
+	}
+
+	// Every input must spend a wallet output, both to know the fee of the
+
+	// transaction and to be able to sign its replacement.
+	if len(details.Debits) != len(details.MsgTx.TxIn) {
+
+		return nil, 0, ErrTxNotFromWallet
+	}
+
+	replaceable := false
+
+	for _, txIn := range details.MsgTx.TxIn {
+
+		if txIn.Sequence < wire.MaxTxInSequenceNum-1 {
+
+			replaceable = true
+			break
+		}
+
+	}
+
+	if !replaceable {
+
+		return nil, 0, ErrTxNotReplaceable
+	}
+
+	var inputTotal util.Amount
+
+	for _, debit := range details.Debits {
+
+		inputTotal += debit.Amount
+	}
+
+	fee := inputTotal - util.Amount(sumOutputValues(&details.MsgTx))
+	return details, fee, nil
+}
+
+// txToReplacement creates a signed transaction which replaces the unmined
+
+// wallet transaction with the given hash, paying the same outputs at a higher
+
+// fee rate.  The change output of the original transaction, if any, is reused
+
+// for the change of the replacement.  The wallet must be unlocked to create
+
+// the transaction.
+func (w *Wallet) txToReplacement(txHash *chainhash.Hash,
+
+	feeSatPerKb util.Amount) (tx *txauthor.AuthoredTx, err error) {
+
+	chainClient, err := w.requireChainClient()
+
+	if err != nil {
+
+		return nil, err
+	}
+
+	err = walletdb.Update(w.db, func(dbtx walletdb.ReadWriteTx) error {
+
+		addrmgrNs := dbtx.ReadWriteBucket(waddrmgrNamespaceKey)
+		txmgrNs := dbtx.ReadBucket(wtxmgrNamespaceKey)
+
+		details, origFee, err := w.replaceableTx(txmgrNs, txHash)
+
+		if err != nil {
+
+			return err
+		}
+
+		origTx := &details.MsgTx
+
+		// The fee rate of the original transaction is taken over its
+
+		// virtual size, the same measure nodes compare replacements by.
+		baseSize := origTx.SerializeSizeStripped()
+		vsize := (baseSize*3 + origTx.SerializeSize() + 3) / 4
+		origRate := origFee * 1000 / util.Amount(vsize)
+
+		if feeSatPerKb == 0 {
+
+			feeSatPerKb = origRate + txrules.DefaultRelayFeePerKb
+
+		} else if feeSatPerKb <= origRate {
+
+			return ErrFeeRateTooLow
+		}
+
+		origValues := make([]util.Amount, len(origTx.TxIn))
+		origScripts := make([][]byte, len(origTx.TxIn))
+
+		for _, debit := range details.Debits {
+
+			prevOut := &origTx.TxIn[debit.Index].PreviousOutPoint
+			prev, err := w.TxStore.TxDetails(txmgrNs, &prevOut.Hash)
+
+			if err != nil {
+
+				return err
+			}
+
+			if prev == nil || int(prevOut.Index) >= len(prev.MsgTx.TxOut) {
+
+				return ErrTxNotFromWallet
+			}
+
+			origValues[debit.Index] = debit.Amount
+			origScripts[debit.Index] = prev.MsgTx.TxOut[prevOut.Index].PkScript
+		}
+
+		// Every output but the change is paid again by the replacement.
+		changeIndex := -1
+
+		for _, credit := range details.Credits {
+
+			if credit.Change {
+
+				changeIndex = int(credit.Index)
+				break
+			}
+
+		}
+
+		outputs := make([]*wire.TxOut, 0, len(origTx.TxOut))
+
+		for i, txOut := range origTx.TxOut {
+
+			if i != changeIndex {
+
+				outputs = append(outputs, txOut)
+			}
+
+		}
+
+		// Further inputs and new change come from the account of the
+
+		// original change, or that of the first input otherwise.
+		accountScript := origScripts[0]
+
+		if changeIndex >= 0 {
+
+			accountScript = origTx.TxOut[changeIndex].PkScript
+		}
+
+		account := uint32(waddrmgr.DefaultAccountNum)
+		_, addrs, _, err := txscript.ExtractPkScriptAddrs(
+			accountScript, w.chainParams)
+
+		if err == nil && len(addrs) == 1 {
+
+			_, addrAcct, err := w.Manager.AddrAccount(addrmgrNs, addrs[0])
+
+			if err == nil && addrAcct != waddrmgr.ImportedAddrAccount {
+
+				account = addrAcct
+			}
+
+		}
+
+		// Get current block's height and hash.
+		bs, err := chainClient.BlockStamp()
+
+		if err != nil {
+
+			return err
+		}
+
+		eligible, err := w.findEligibleOutputs(dbtx, account, 1, bs)
+
+		if err != nil {
+
+			return err
+		}
+
+		// The replacement signals replaceability like the transaction it
+
+		// replaces, so that it can be bumped again.
+		inputSource := makeInputSource(eligible, txauthor.ReplaceableSequence)
+
+		changeSource := func() ([]byte, error) {
+
+			if changeIndex >= 0 {
+
+				return origTx.TxOut[changeIndex].PkScript, nil
+			}
+
+			changeAddr, err := w.newChangeAddress(addrmgrNs, account)
+
+			if err != nil {
+
+				return nil, err
+			}
+
+			return txscript.PayToAddrScript(changeAddr)
+		}
+
+		tx, err = txauthor.NewReplacementTransaction(outputs, origTx.TxIn,
+			origValues, origScripts, origFee, feeSatPerKb,
+			txrules.DefaultRelayFeePerKb, inputSource, changeSource)
+
+		if err != nil {
+
+			return err
+		}
+
+		if tx.ChangeIndex >= 0 {
+
+			tx.RandomizeChangePosition()
+		}
+
+		return tx.AddAllInputScripts(secretSource{w.Manager, addrmgrNs})
+	})
+
+	if err != nil {
+
+		return nil, err
+	}
+
+	err = validateMsgTx(tx.Tx, tx.PrevScripts, tx.PrevInputValues)
+
+	if err != nil {
+
+		return nil, err
+	}
+
+	return tx, nil
+}
+
+// publishReplacement broadcasts a transaction replacing the unmined wallet
+
+// transaction with hash origHash.  Only once the chain server accepts it is the
+
+// original removed from the store, along with any transactions spending it,
+
+// and the replacement recorded in its place with the label and comments of the
+
+// original.
+func (w *Wallet) publishReplacement(origHash *chainhash.Hash,
+
+	tx *wire.MsgTx) (*chainhash.Hash, error) {
+
+	server, err := w.requireChainClient()
+
+	if err != nil {
+
+		return nil, err
+	}
+
+	txRec, err := wtxmgr.NewTxRecordFromMsgTx(tx, time.Now())
+
+	if err != nil {
+
+		return nil, err
+	}
+
+	txid, err := server.SendRawTransaction(tx, false)
+
+	if err != nil {
+
+		return nil, err
+	}
+
+	err = walletdb.Update(w.db, func(dbtx walletdb.ReadWriteTx) error {
+
+		txmgrNs := dbtx.ReadWriteBucket(wtxmgrNamespaceKey)
+		details, err := w.TxStore.TxDetails(txmgrNs, origHash)
+
+		if err != nil {
+
+			return err
+		}
+
+		if details != nil && details.Block.Height == -1 {
+
+			err = w.TxStore.RemoveUnminedTx(txmgrNs, &details.TxRecord)
+
+			if err != nil {
+
+				return err
+			}
+
+		}
+
+		return w.addRelevantTx(dbtx, txRec, nil)
+	})
+
+	if err != nil {
+
+		return nil, fmt.Errorf("replacement %v was broadcast, but not "+
+			"recorded: %v", txid, err)
+	}
+
+	meta, err := w.TxMeta(origHash)
+
+	if err == nil && meta != nil {
+
+		err = w.PutTxMeta(txid, meta)
+	}
+
+	if err != nil {
+
+		log <- cl.Warnf{
+			"unable to copy label and comments of %v to replacement %v: %v",
+			origHash, txid, err,
+		}
+
+	}
+
+	return txid, nil
+}
+
+// sumOutputValues returns the total value paid to the outputs of tx.
+func sumOutputValues(
+	tx *wire.MsgTx) int64 {
+
+	var total int64
+
+	for _, txOut := range tx.TxOut {
+
+		total += txOut.Value
+	}
+
+	return total
+}
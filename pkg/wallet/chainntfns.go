@@ -8,6 +8,7 @@ import (
 
 	wtxmgr "git.parallelcoin.io/dev/pod/pkg/chain/tx/mgr"
 	txscript "git.parallelcoin.io/dev/pod/pkg/chain/tx/script"
+	"git.parallelcoin.io/dev/pod/pkg/util"
 	waddrmgr "git.parallelcoin.io/dev/pod/pkg/wallet/addrmgr"
 	chain "git.parallelcoin.io/dev/pod/pkg/wallet/chain"
 	walletdb "git.parallelcoin.io/dev/pod/pkg/wallet/db"
@@ -393,6 +394,7 @@ func (w *Wallet) addRelevantTx(dbtx walletdb.ReadWriteTx, rec *wtxmgr.TxRecord,
 	// Check every output to determine whether it is controlled by a wallet
 
 	// key.  If so, mark the output as a credit.
+	var newAddrs []util.Address
 
 	for i, output := range rec.MsgTx.TxOut {
 
@@ -433,6 +435,16 @@ func (w *Wallet) addRelevantTx(dbtx walletdb.ReadWriteTx, rec *wtxmgr.TxRecord,
 
 				log <- cl.Debug{"marked address used:", addr}
 
+				// Keep the gap limit of unused addresses after
+				// the used one for watch-only accounts.
+				derived, err := w.extendWatchOnlyGap(addrmgrNs, ma)
+
+				if err != nil {
+
+					return err
+				}
+
+				newAddrs = append(newAddrs, derived...)
 				continue
 			}
 
@@ -449,6 +461,22 @@ func (w *Wallet) addRelevantTx(dbtx walletdb.ReadWriteTx, rec *wtxmgr.TxRecord,
 
 	}
 
+	if len(newAddrs) != 0 {
+
+		var bs *waddrmgr.BlockStamp
+
+		if block != nil {
+
+			bs = &waddrmgr.BlockStamp{
+
+				Hash:   block.Hash,
+				Height: block.Height,
+			}
+		}
+
+		go w.watchAddresses(newAddrs, bs)
+	}
+
 	// Send notification of mined or unmined transaction to any interested
 
 	// clients.
@@ -0,0 +1,258 @@
+package wallet
+
+import (
+	"fmt"
+
+	chainhash "git.parallelcoin.io/dev/pod/pkg/chain/hash"
+	txauthor "git.parallelcoin.io/dev/pod/pkg/chain/tx/author"
+	wtxmgr "git.parallelcoin.io/dev/pod/pkg/chain/tx/mgr"
+	txrules "git.parallelcoin.io/dev/pod/pkg/chain/tx/rules"
+	txsizes "git.parallelcoin.io/dev/pod/pkg/chain/tx/sizes"
+	"git.parallelcoin.io/dev/pod/pkg/chain/wire"
+	"git.parallelcoin.io/dev/pod/pkg/util"
+	waddrmgr "git.parallelcoin.io/dev/pod/pkg/wallet/addrmgr"
+	"git.parallelcoin.io/dev/pod/pkg/wallet/coinset"
+)
+
+// CoinSelectionStrategy names a way of choosing the unspent outputs that are
+
+// spent by the transactions the wallet creates.
+type CoinSelectionStrategy string
+
+const (
+
+	// CoinSelectLargest spends the largest outputs first, as the wallet
+
+	// always has.
+	CoinSelectLargest CoinSelectionStrategy = "largest"
+
+	// CoinSelectBranchAndBound searches for outputs paying exactly the
+
+	// outputs and fee of the transaction, so that no change output is
+
+	// created, falling back to spending the largest outputs first.
+	CoinSelectBranchAndBound CoinSelectionStrategy = "bnb"
+
+	// CoinSelectPrivacy spends all outputs paid to an address together,
+
+	// linking as few addresses of the wallet as possible.
+	CoinSelectPrivacy CoinSelectionStrategy = "privacy"
+
+	// CoinSelectConsolidate spends as many small outputs as possible to
+
+	// merge them into the change.
+	CoinSelectConsolidate CoinSelectionStrategy = "consolidate"
+)
+
+// maxSelectedInputs is the most inputs selected for one transaction, which
+
+// keeps a transaction spending P2PKH outputs below the maximum standard size.
+const maxSelectedInputs = 500
+
+// maxBranchAndBoundTries limits the steps of the search for a changeless
+
+// selection before falling back to one with change.
+const maxBranchAndBoundTries = 100000
+
+// ParseCoinSelectionStrategy returns the coin selection strategy named s,
+
+// which defaults to CoinSelectLargest when empty.
+func ParseCoinSelectionStrategy(
+
+	s string) (CoinSelectionStrategy, error) {
+
+	switch strategy := CoinSelectionStrategy(s); strategy {
+
+	case "":
+		return CoinSelectLargest, nil
+
+	case CoinSelectLargest, CoinSelectBranchAndBound, CoinSelectPrivacy,
+		CoinSelectConsolidate:
+		return strategy, nil
+	}
+
+	return "", fmt.Errorf("unknown coin selection strategy %q, expected "+
+		"one of %s, %s, %s or %s", s, CoinSelectLargest,
+		CoinSelectBranchAndBound, CoinSelectPrivacy, CoinSelectConsolidate)
+}
+
+// CoinSelection returns the coin selection strategy used when a transaction
+
+// is created without naming one.
+func (w *Wallet) CoinSelection() CoinSelectionStrategy {
+
+	w.coinSelectionMtx.Lock()
+	strategy := w.coinSelection
+	w.coinSelectionMtx.Unlock()
+	return strategy
+}
+
+// SetCoinSelection sets the coin selection strategy used when a transaction
+
+// is created without naming one.
+func (w *Wallet) SetCoinSelection(strategy CoinSelectionStrategy) {
+
+	w.coinSelectionMtx.Lock()
+	w.coinSelection = strategy
+	w.coinSelectionMtx.Unlock()
+}
+
+// creditCoin is an implementation of coinset.Coin for an unspent output of
+
+// the wallet.
+type creditCoin struct {
+	credit   *wtxmgr.Credit
+	numConfs int64
+}
+
+func (c *creditCoin) Hash() *chainhash.Hash { return &c.credit.OutPoint.Hash }
+func (c *creditCoin) Index() uint32         { return c.credit.OutPoint.Index }
+func (c *creditCoin) Value() util.Amount    { return c.credit.Amount }
+func (c *creditCoin) PkScript() []byte      { return c.credit.PkScript }
+func (c *creditCoin) NumConfs() int64       { return c.numConfs }
+func (c *creditCoin) ValueAge() int64       { return c.numConfs * int64(c.credit.Amount) }
+
+// makeSelectorInputSource creates an input source choosing from the eligible
+
+// credits with the selector each time more value is needed.
+func makeSelectorInputSource(selector coinset.CoinSelector,
+
+	eligible []wtxmgr.Credit, bs *waddrmgr.BlockStamp,
+
+	sequence uint32) txauthor.InputSource {
+
+	coins := make([]coinset.Coin, len(eligible))
+
+	for i := range eligible {
+
+		coins[i] = &creditCoin{
+
+			credit:   &eligible[i],
+			numConfs: int64(confirms(eligible[i].Height, bs.Height)),
+		}
+	}
+
+	return func(target util.Amount) (util.Amount, []*wire.TxIn,
+
+		[]util.Amount, [][]byte, error) {
+
+		selected, err := selector.CoinSelect(target, coins)
+
+		// Returning less than the target reports insufficient funds.
+		if err == coinset.ErrCoinsNoSelectionAvailable {
+
+			return 0, nil, nil, nil, nil
+		}
+
+		if err != nil {
+
+			return 0, nil, nil, nil, err
+		}
+
+		return selectedInputs(selected.Coins(), sequence)
+	}
+}
+
+// selectedInputs returns the total value, inputs with the passed sequence
+
+// number, input values and previous output scripts of the selected credits.
+func selectedInputs(selected []coinset.Coin,
+
+	sequence uint32) (util.Amount, []*wire.TxIn,
+
+	[]util.Amount, [][]byte, error) {
+
+	total := util.Amount(0)
+	inputs := make([]*wire.TxIn, 0, len(selected))
+	values := make([]util.Amount, 0, len(selected))
+	scripts := make([][]byte, 0, len(selected))
+
+	for _, coin := range selected {
+
+		credit := coin.(*creditCoin).credit
+		input := wire.NewTxIn(&credit.OutPoint, nil, nil)
+		input.Sequence = sequence
+		total += credit.Amount
+		inputs = append(inputs, input)
+		values = append(values, credit.Amount)
+		scripts = append(scripts, credit.PkScript)
+	}
+
+	return total, inputs, values, scripts, nil
+}
+
+// makeChangelessInputSource creates an input source that searches the
+
+// eligible credits for ones paying the target at feeSatPerKb without change
+
+// with a branch and bound search, for txauthor.NewChangelessTransaction.
+
+// The search works on the value of the credits less the fee for spending
+
+// them, assumed to be the largest P2PKH input, and accepts leftover value
+
+// small enough that it is added to the fee rather than returned as dust
+
+// change.
+func makeChangelessInputSource(eligible []wtxmgr.Credit,
+
+	bs *waddrmgr.BlockStamp, feeSatPerKb util.Amount,
+
+	sequence uint32) txauthor.InputSource {
+
+	costOfChange := txrules.GetDustThreshold(txsizes.P2WPKHPkScriptSize,
+		feeSatPerKb) - 1
+
+	if costOfChange < 0 {
+
+		costOfChange = 0
+	}
+
+	selector := coinset.BranchAndBoundCoinSelector{
+
+		MaxInputs: maxSelectedInputs,
+		MaxTries:  maxBranchAndBoundTries,
+		InputFee: txrules.FeeForSerializeSize(feeSatPerKb,
+			txsizes.RedeemP2PKHInputSize),
+		CostOfChange: costOfChange,
+	}
+
+	return makeSelectorInputSource(selector, eligible, bs, sequence)
+}
+
+// makeStrategyInputSource creates an input source choosing from the eligible
+
+// credits by the coin selection strategy to pay a transaction at feeSatPerKb,
+
+// with inputs that have the passed sequence number.  The branch and bound
+
+// strategy spends the largest outputs first here, as this source is only used
+
+// when no changeless selection was found.
+func makeStrategyInputSource(strategy CoinSelectionStrategy,
+
+	eligible []wtxmgr.Credit, bs *waddrmgr.BlockStamp,
+
+	feeSatPerKb util.Amount, sequence uint32) txauthor.InputSource {
+
+	inputFee := txrules.FeeForSerializeSize(feeSatPerKb,
+		txsizes.RedeemP2PKHInputSize)
+
+	switch strategy {
+
+	case CoinSelectPrivacy:
+		return makeSelectorInputSource(coinset.PrivacyCoinSelector{
+
+			MaxInputs: maxSelectedInputs,
+		}, eligible, bs, sequence)
+
+	case CoinSelectConsolidate:
+		return makeSelectorInputSource(coinset.ConsolidationCoinSelector{
+
+			MaxInputs: maxSelectedInputs,
+			InputFee:  inputFee,
+		}, eligible, bs, sequence)
+	}
+
+	return makeInputSource(eligible, sequence)
+}
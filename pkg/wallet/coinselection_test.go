@@ -0,0 +1,98 @@
+package wallet
+
+import (
+	"testing"
+
+	chaincfg "git.parallelcoin.io/dev/pod/pkg/chain/config"
+	chainhash "git.parallelcoin.io/dev/pod/pkg/chain/hash"
+	txauthor "git.parallelcoin.io/dev/pod/pkg/chain/tx/author"
+	wtxmgr "git.parallelcoin.io/dev/pod/pkg/chain/tx/mgr"
+	txrules "git.parallelcoin.io/dev/pod/pkg/chain/tx/rules"
+	txscript "git.parallelcoin.io/dev/pod/pkg/chain/tx/script"
+	txsizes "git.parallelcoin.io/dev/pod/pkg/chain/tx/sizes"
+	"git.parallelcoin.io/dev/pod/pkg/chain/wire"
+	"git.parallelcoin.io/dev/pod/pkg/util"
+	waddrmgr "git.parallelcoin.io/dev/pod/pkg/wallet/addrmgr"
+)
+
+// TestChangelessInputSource tests that a branch and bound selection of a
+
+// credit paying exactly the outputs and fee of a transaction creates a
+
+// transaction without a change output, and that one that would need change
+
+// is refused so the wallet falls back to a transaction with change.
+func TestChangelessInputSource(t *testing.T) {
+
+	const feeSatPerKb = 1e3
+
+	addr, err := util.NewAddressPubKeyHash(make([]byte, 20),
+		&chaincfg.MainNetParams)
+
+	if err != nil {
+
+		t.Fatal(err)
+	}
+
+	pkScript, err := txscript.PayToAddrScript(addr)
+
+	if err != nil {
+
+		t.Fatal(err)
+	}
+
+	outputs := []*wire.TxOut{wire.NewTxOut(1e6, pkScript)}
+	fee := txrules.FeeForSerializeSize(feeSatPerKb,
+		txsizes.EstimateVirtualSize(1, 0, 0, outputs, false))
+	credit := func(index uint32, amount util.Amount) wtxmgr.Credit {
+
+		return wtxmgr.Credit{
+
+			OutPoint: wire.OutPoint{Hash: chainhash.Hash{1}, Index: index},
+			Amount:   amount,
+			PkScript: pkScript,
+		}
+	}
+
+	bs := &waddrmgr.BlockStamp{Height: 100}
+	eligible := []wtxmgr.Credit{
+
+		credit(0, 5e6),
+		credit(1, 1e6+fee),
+		credit(2, 3e6),
+	}
+
+	tx, err := txauthor.NewChangelessTransaction(outputs, feeSatPerKb,
+		makeChangelessInputSource(eligible, bs, feeSatPerKb,
+			inputSequence(false)))
+
+	if err != nil {
+
+		t.Fatalf("NewChangelessTransaction: unexpected error: %v", err)
+	}
+
+	if tx.ChangeIndex != -1 || len(tx.Tx.TxOut) != 1 {
+
+		t.Fatalf("got %d outputs with change index %d, want no change "+
+			"output", len(tx.Tx.TxOut), tx.ChangeIndex)
+	}
+
+	if len(tx.Tx.TxIn) != 1 || tx.Tx.TxIn[0].PreviousOutPoint.Index != 1 {
+
+		t.Fatalf("got inputs %v, want only the exactly matching credit",
+			tx.Tx.TxIn)
+	}
+
+	// Without a credit close enough to the target the search fails, and
+
+	// the transaction is created with change instead.
+	_, err = txauthor.NewChangelessTransaction(outputs, feeSatPerKb,
+		makeChangelessInputSource([]wtxmgr.Credit{credit(0, 5e6)}, bs,
+			feeSatPerKb, inputSequence(false)))
+
+	if err == nil {
+
+		t.Fatal("NewChangelessTransaction: expected an error for a " +
+			"selection that needs change")
+	}
+}
@@ -332,6 +332,234 @@ func (s MinPriorityCoinSelector) CoinSelect(targetValue util.Amount, coins []Coi
 	return nil, ErrCoinsNoSelectionAvailable
 }
 
+// BranchAndBoundCoinSelector is a CoinSelector that searches for a selection of coins whose total value is at least targetValue and exceeds it by no more than CostOfChange, so that the transaction spending them needs no change output.  Every input is taken to cost InputFee to spend, so the value of a coin counts for its value less InputFee and coins worth no more than that are never selected, while targetValue is expected to exclude the fee of the inputs.  Among the selections found the one wasting the least value is chosen.  The search is a depth-first branch and bound over the coins in decreasing order of value, as used by Bitcoin Core, and gives up after MaxTries steps, in which case ErrCoinsNoSelectionAvailable is returned and the caller is expected to fall back to a selection that creates change.
+
+type BranchAndBoundCoinSelector struct {
+	MaxInputs    int
+	MaxTries     int
+	InputFee     util.Amount
+	CostOfChange util.Amount
+}
+
+// CoinSelect will attempt to select coins using the algorithm described in the BranchAndBoundCoinSelector struct.
+func (s BranchAndBoundCoinSelector) CoinSelect(targetValue util.Amount, coins []Coin) (Coins, error) {
+
+	pool := make([]Coin, 0, len(coins))
+	available := util.Amount(0)
+
+	for _, coin := range coins {
+
+		if coin.Value() > s.InputFee {
+
+			pool = append(pool, coin)
+			available += coin.Value() - s.InputFee
+		}
+	}
+
+	if available < targetValue {
+
+		return nil, ErrCoinsNoSelectionAvailable
+	}
+	sort.Sort(sort.Reverse(byAmount(pool)))
+
+	// selection records for each coin visited on the current branch whether it is included, best is the cheapest selection found so far.
+	selection := make([]bool, 0, len(pool))
+	var best []bool
+	bestWaste := util.Amount(-1)
+	current := util.Amount(0)
+	numInputs := 0
+
+	for tries := 0; tries < s.MaxTries; tries++ {
+
+		backtrack := false
+
+		switch {
+
+		case current+available < targetValue,
+			current > targetValue+s.CostOfChange,
+			numInputs > s.MaxInputs:
+			backtrack = true
+		case current >= targetValue:
+			waste := current - targetValue
+
+			if bestWaste < 0 || waste < bestWaste {
+
+				best = append(best[:0], selection...)
+				bestWaste = waste
+			}
+			backtrack = true
+		}
+
+		if bestWaste == 0 {
+
+			break
+		}
+
+		if backtrack {
+
+			// Walk back to the last included coin and try the branch that omits it.
+
+			for len(selection) > 0 && !selection[len(selection)-1] {
+
+				selection = selection[:len(selection)-1]
+				available += pool[len(selection)].Value() - s.InputFee
+			}
+
+			if len(selection) == 0 {
+
+				break
+			}
+			selection[len(selection)-1] = false
+			current -= pool[len(selection)-1].Value() - s.InputFee
+			numInputs--
+			continue
+		}
+		coin := pool[len(selection)]
+		available -= coin.Value() - s.InputFee
+
+		// Including a coin of the same value as the previous one which was omitted gives a selection already searched.
+
+		if len(selection) > 0 && !selection[len(selection)-1] &&
+			coin.Value() == pool[len(selection)-1].Value() {
+
+			selection = append(selection, false)
+			continue
+		}
+		selection = append(selection, true)
+		current += coin.Value() - s.InputFee
+		numInputs++
+	}
+
+	if best == nil {
+
+		return nil, ErrCoinsNoSelectionAvailable
+	}
+	cs := NewCoinSet(nil)
+
+	for i, included := range best {
+
+		if included {
+
+			cs.PushCoin(pool[i])
+		}
+	}
+	return cs, nil
+}
+
+// PrivacyCoinSelector is a CoinSelector that selects coins by the output script, and so the address, they pay to, always spending all the coins paid to one address together so that the address never links two transactions of the wallet as the spends of its coins.  If the coins of a single address are enough the smallest such group is spent, so that no other addresses are linked to it, otherwise the groups of greatest value are added until targetValue is met.  Groups of more than MaxInputs coins are never selected.
+
+type PrivacyCoinSelector struct {
+	MaxInputs       int
+	MinChangeAmount util.Amount
+}
+
+// CoinSelect will attempt to select coins using the algorithm described in the PrivacyCoinSelector struct.
+func (s PrivacyCoinSelector) CoinSelect(targetValue util.Amount, coins []Coin) (Coins, error) {
+
+	index := make(map[string]int)
+	var groups []*CoinSet
+
+	for _, coin := range coins {
+
+		key := string(coin.PkScript())
+		i, ok := index[key]
+
+		if !ok {
+
+			i = len(groups)
+			index[key] = i
+			groups = append(groups, NewCoinSet(nil))
+		}
+		groups[i].PushCoin(coin)
+	}
+	eligible := groups[:0]
+
+	for _, group := range groups {
+
+		if group.Num() <= s.MaxInputs {
+
+			eligible = append(eligible, group)
+		}
+	}
+	sort.SliceStable(eligible, func(i, j int) bool {
+
+		return eligible[i].TotalValue() < eligible[j].TotalValue()
+	})
+
+	for _, group := range eligible {
+
+		if satisfiesTargetValue(targetValue, s.MinChangeAmount, group.TotalValue()) {
+
+			return group, nil
+		}
+	}
+	cs := NewCoinSet(nil)
+
+	for i := len(eligible) - 1; i >= 0; i-- {
+
+		if cs.Num()+eligible[i].Num() > s.MaxInputs {
+
+			continue
+		}
+
+		for _, coin := range eligible[i].Coins() {
+
+			cs.PushCoin(coin)
+		}
+
+		if satisfiesTargetValue(targetValue, s.MinChangeAmount, cs.TotalValue()) {
+
+			return cs, nil
+		}
+	}
+	return nil, ErrCoinsNoSelectionAvailable
+}
+
+// ConsolidationCoinSelector is a CoinSelector that spends as many coins as possible, up to MaxInputs, to merge the small outputs of a wallet into its change while paying.  The fewest large coins needed to meet targetValue are selected first, then the smallest coins are added.  Coins worth no more than InputFee, the fee for spending an input, would cost more to spend than they are worth and are never selected.
+
+type ConsolidationCoinSelector struct {
+	MaxInputs       int
+	MinChangeAmount util.Amount
+	InputFee        util.Amount
+}
+
+// CoinSelect will attempt to select coins using the algorithm described in the ConsolidationCoinSelector struct.
+func (s ConsolidationCoinSelector) CoinSelect(targetValue util.Amount, coins []Coin) (Coins, error) {
+
+	sortedCoins := make([]Coin, 0, len(coins))
+
+	for _, coin := range coins {
+
+		if coin.Value() > s.InputFee {
+
+			sortedCoins = append(sortedCoins, coin)
+		}
+	}
+	sort.Sort(sort.Reverse(byAmount(sortedCoins)))
+	selected, err := MinIndexCoinSelector{
+		MaxInputs:       s.MaxInputs,
+		MinChangeAmount: s.MinChangeAmount,
+	}.CoinSelect(targetValue, sortedCoins)
+
+	if err != nil {
+
+		return nil, err
+	}
+	cs := NewCoinSet(selected.Coins())
+	numLarge := cs.Num()
+
+	for i := len(sortedCoins) - 1; i >= numLarge && cs.Num() < s.MaxInputs; i-- {
+
+		cs.PushCoin(sortedCoins[i])
+
+		if !satisfiesTargetValue(targetValue, s.MinChangeAmount, cs.TotalValue()) {
+
+			cs.PopCoin()
+		}
+	}
+	return cs, nil
+}
+
 type byValueAge []Coin
 
 func (a byValueAge) Len() int           { return len(a) }
@@ -10,7 +10,7 @@ import (
 	chainhash "git.parallelcoin.io/dev/pod/pkg/chain/hash"
 	"git.parallelcoin.io/dev/pod/pkg/chain/wire"
 	"git.parallelcoin.io/dev/pod/pkg/util"
-	"git.parallelcoin.io/dev/pod/pkg/util/coinset"
+	"git.parallelcoin.io/dev/pod/pkg/wallet/coinset"
 )
 
 type TestCoin struct {
@@ -18,12 +18,13 @@ type TestCoin struct {
 	TxIndex    uint32
 	TxValue    util.Amount
 	TxNumConfs int64
+	TxPkScript []byte
 }
 
 func (c *TestCoin) Hash() *chainhash.Hash { return c.TxHash }
 func (c *TestCoin) Index() uint32         { return c.TxIndex }
 func (c *TestCoin) Value() util.Amount    { return c.TxValue }
-func (c *TestCoin) PkScript() []byte      { return nil }
+func (c *TestCoin) PkScript() []byte      { return c.TxPkScript }
 func (c *TestCoin) NumConfs() int64       { return c.TxNumConfs }
 func (c *TestCoin) ValueAge() int64       { return int64(c.TxValue) * c.TxNumConfs }
 func NewCoin(
@@ -247,6 +248,86 @@ func TestMinPrioritySelector(
 	testCoinSelector(minPriorityTests, t)
 }
 
+var branchAndBoundSelectors = []coinset.BranchAndBoundCoinSelector{
+	{MaxInputs: 10, MaxTries: 100000},
+	{MaxInputs: 10, MaxTries: 100000, CostOfChange: 1000000},
+	{MaxInputs: 10, MaxTries: 100000, InputFee: 1000000},
+	{MaxInputs: 01, MaxTries: 100000},
+	{MaxInputs: 10, MaxTries: 100000, CostOfChange: 20000000},
+}
+var branchAndBoundTests = []coinSelectTest{
+	{branchAndBoundSelectors[0], coins, 35000000, []coinset.Coin{coins[3], coins[1]}, nil},
+	{branchAndBoundSelectors[0], coins, 60000000, []coinset.Coin{coins[2], coins[1]}, nil},
+	{branchAndBoundSelectors[0], coins, 36000000, nil, coinset.ErrCoinsNoSelectionAvailable},
+	{branchAndBoundSelectors[0], coins, 200000000, nil, coinset.ErrCoinsNoSelectionAvailable},
+	{branchAndBoundSelectors[1], coins, 34500000, []coinset.Coin{coins[3], coins[1]}, nil},
+	{branchAndBoundSelectors[2], coins, 33000000, []coinset.Coin{coins[3], coins[1]}, nil},
+	{branchAndBoundSelectors[2], coins, 35000000, nil, coinset.ErrCoinsNoSelectionAvailable},
+	{branchAndBoundSelectors[3], coins, 35000000, nil, coinset.ErrCoinsNoSelectionAvailable},
+	{branchAndBoundSelectors[3], coins, 25000000, []coinset.Coin{coins[3]}, nil},
+	{branchAndBoundSelectors[4], coins, 30000000, []coinset.Coin{coins[3], coins[1]}, nil},
+}
+
+func TestBranchAndBoundSelector(
+	t *testing.T) {
+
+	testCoinSelector(branchAndBoundTests, t)
+}
+
+func NewScriptCoin(
+	index int64, value util.Amount, pkScript string) coinset.Coin {
+
+	c := NewCoin(index, value, 1).(*TestCoin)
+	c.TxPkScript = []byte(pkScript)
+	return c
+}
+
+var addressCoins = []coinset.Coin{
+	NewScriptCoin(5, 30000000, "a"),
+	NewScriptCoin(6, 50000000, "b"),
+	NewScriptCoin(7, 40000000, "a"),
+	NewScriptCoin(8, 10000000, "c"),
+}
+var privacySelectors = []coinset.PrivacyCoinSelector{
+	{MaxInputs: 10, MinChangeAmount: 10000},
+	{MaxInputs: 01, MinChangeAmount: 10000},
+}
+var privacyTests = []coinSelectTest{
+	{privacySelectors[0], addressCoins, 45000000, []coinset.Coin{addressCoins[1]}, nil},
+	{privacySelectors[0], addressCoins, 60000000, []coinset.Coin{addressCoins[0], addressCoins[2]}, nil},
+	{privacySelectors[0], addressCoins, 100000000, []coinset.Coin{addressCoins[0], addressCoins[2], addressCoins[1]}, nil},
+	{privacySelectors[0], addressCoins, 130000000, []coinset.Coin{addressCoins[0], addressCoins[2], addressCoins[1], addressCoins[3]}, nil},
+	{privacySelectors[0], addressCoins, 140000000, nil, coinset.ErrCoinsNoSelectionAvailable},
+	{privacySelectors[1], addressCoins, 45000000, []coinset.Coin{addressCoins[1]}, nil},
+	{privacySelectors[1], addressCoins, 60000000, nil, coinset.ErrCoinsNoSelectionAvailable},
+}
+
+func TestPrivacySelector(
+	t *testing.T) {
+
+	testCoinSelector(privacyTests, t)
+}
+
+var dustCoin = NewCoin(9, 5000, 1)
+var consolidationSelectors = []coinset.ConsolidationCoinSelector{
+	{MaxInputs: 03, MinChangeAmount: 10000},
+	{MaxInputs: 10, MinChangeAmount: 10000},
+	{MaxInputs: 10, MinChangeAmount: 10000, InputFee: 15000000},
+}
+var consolidationTests = []coinSelectTest{
+	{consolidationSelectors[0], coins, 40000000, []coinset.Coin{coins[0], coins[1], coins[3]}, nil},
+	{consolidationSelectors[1], coins, 40000000, []coinset.Coin{coins[0], coins[1], coins[3], coins[2]}, nil},
+	{consolidationSelectors[2], coins, 40000000, []coinset.Coin{coins[0], coins[3], coins[2]}, nil},
+	{consolidationSelectors[1], coins, 200000000, nil, coinset.ErrCoinsNoSelectionAvailable},
+	{consolidationSelectors[1], []coinset.Coin{coins[0], dustCoin}, 100000000, []coinset.Coin{coins[0]}, nil},
+}
+
+func TestConsolidationSelector(
+	t *testing.T) {
+
+	testCoinSelector(consolidationTests, t)
+}
+
 var (
 
 	// should be two outpoints, with 1st one having 0.035DUO value.
@@ -30,7 +30,7 @@ func (s byAmount) Less(i, j int) bool { return s[i].Amount < s[j].Amount }
 func (s byAmount) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
 func makeInputSource(
 
-	eligible []wtxmgr.Credit) txauthor.InputSource {
+	eligible []wtxmgr.Credit, sequence uint32) txauthor.InputSource {
 
 	// Pick largest outputs first.  This is only done for compatibility with
 
@@ -54,6 +54,7 @@ func makeInputSource(
 			nextCredit := &eligible[0]
 			eligible = eligible[1:]
 			nextInput := wire.NewTxIn(&nextCredit.OutPoint, nil, nil)
+			nextInput.Sequence = sequence
 			currentTotal += nextCredit.Amount
 			currentInputs = append(currentInputs, nextInput)
 			currentScripts = append(currentScripts, nextCredit.PkScript)
@@ -65,6 +66,42 @@ func makeInputSource(
 
 }
 
+// inputSequence returns the sequence number of the inputs of a transaction,
+
+// which signals that the transaction may be replaced by one paying a higher
+
+// fee (BIP 125) when replaceable is set, so it can be bumped if it stalls.
+func inputSequence(replaceable bool) uint32 {
+
+	if replaceable {
+
+		return txauthor.ReplaceableSequence
+	}
+
+	return wire.MaxTxInSequenceNum
+}
+
+// Replaceable returns whether the transactions created without saying
+
+// otherwise signal that they may be replaced by one paying a higher fee.
+func (w *Wallet) Replaceable() bool {
+
+	w.coinSelectionMtx.Lock()
+	replaceable := w.replaceable
+	w.coinSelectionMtx.Unlock()
+	return replaceable
+}
+
+// SetReplaceable sets whether the transactions created without saying
+
+// otherwise signal that they may be replaced by one paying a higher fee.
+func (w *Wallet) SetReplaceable(replaceable bool) {
+
+	w.coinSelectionMtx.Lock()
+	w.replaceable = replaceable
+	w.coinSelectionMtx.Unlock()
+}
+
 // secretSource is an implementation of txauthor.SecretSource for the wallet's
 
 // address manager.
@@ -130,10 +167,20 @@ func (s secretSource) GetScript(addr util.Address) ([]byte, error) {
 
 // change to the wallet.  An appropriate fee is included based on the wallet's
 
-// current relay fee.  The wallet must be unlocked to create the transaction.
+// current relay fee.  The previous outputs are chosen by the coin selection
+
+// strategy, and the inputs signal that the transaction may be replaced by one
+
+// paying a higher fee (BIP 125) when replaceable is set.  The wallet must be
+
+// unlocked to create the transaction.
 func (w *Wallet) txToOutputs(outputs []*wire.TxOut, account uint32,
 
-	minconf int32, feeSatPerKb util.Amount) (tx *txauthor.AuthoredTx, err error) {
+	minconf int32, feeSatPerKb util.Amount,
+
+	strategy CoinSelectionStrategy,
+
+	replaceable bool) (tx *txauthor.AuthoredTx, err error) {
 
 	chainClient, err := w.requireChainClient()
 
@@ -161,7 +208,26 @@ func (w *Wallet) txToOutputs(outputs []*wire.TxOut, account uint32,
 			return err
 		}
 
-		inputSource := makeInputSource(eligible)
+		sequence := inputSequence(replaceable)
+
+		// The branch and bound strategy first looks for inputs paying the
+
+		// outputs without change, and falls back to spending the largest
+
+		// outputs with change when there are none.
+		if strategy == CoinSelectBranchAndBound {
+
+			tx, err = txauthor.NewChangelessTransaction(outputs, feeSatPerKb,
+				makeChangelessInputSource(eligible, bs, feeSatPerKb, sequence))
+
+			if err == nil {
+
+				return tx.AddAllInputScripts(secretSource{w.Manager, addrmgrNs})
+			}
+		}
+
+		inputSource := makeStrategyInputSource(strategy, eligible, bs,
+			feeSatPerKb, sequence)
 
 		changeSource := func() ([]byte, error) {
 
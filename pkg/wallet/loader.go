@@ -11,6 +11,7 @@ import (
 
 	chaincfg "git.parallelcoin.io/dev/pod/pkg/chain/config"
 	cl "git.parallelcoin.io/dev/pod/pkg/util/cl"
+	"git.parallelcoin.io/dev/pod/pkg/util/hdkeychain"
 	"git.parallelcoin.io/dev/pod/pkg/util/prompt"
 	waddrmgr "git.parallelcoin.io/dev/pod/pkg/wallet/addrmgr"
 	walletdb "git.parallelcoin.io/dev/pod/pkg/wallet/db"
@@ -73,6 +74,33 @@ func (l *Loader) CreateNewWallet(pubPassphrase, privPassphrase, seed []byte,
 
 	bday time.Time) (*Wallet, error) {
 
+	return l.createWallet(pubPassphrase, func(db walletdb.DB) error {
+
+		return Create(
+			db, pubPassphrase, privPassphrase, seed, l.chainParams, bday,
+		)
+	})
+}
+
+// CreateWatchingOnlyWallet creates a new watching-only wallet using the provided public passphrase, whose default account is created from the extended public key of an account of another wallet.  The birthday of the wallet is the genesis block, so that the recovery rescan made when the wallet is synced looks for the addresses of the account in the whole chain.
+func (l *Loader) CreateWatchingOnlyWallet(pubPassphrase []byte,
+
+	acctKeyPub *hdkeychain.ExtendedKey) (*Wallet, error) {
+
+	bday := l.chainParams.GenesisBlock.Header.Timestamp
+	return l.createWallet(pubPassphrase, func(db walletdb.DB) error {
+
+		return CreateWatchingOnly(
+			db, pubPassphrase, acctKeyPub, l.chainParams, bday,
+		)
+	})
+}
+
+// createWallet creates the wallet database, initializes it with create and opens the new wallet.
+func (l *Loader) createWallet(pubPassphrase []byte,
+
+	create func(db walletdb.DB) error) (*Wallet, error) {
+
 	defer l.mu.Unlock()
 	l.mu.Lock()
 
@@ -110,9 +138,7 @@ func (l *Loader) CreateNewWallet(pubPassphrase, privPassphrase, seed []byte,
 	}
 
 	// Initialize the newly created database for the wallet before opening.
-	err = Create(
-		db, pubPassphrase, privPassphrase, seed, l.chainParams, bday,
-	)
+	err = create(db)
 
 	if err != nil {
 
@@ -133,6 +159,13 @@ func (l *Loader) CreateNewWallet(pubPassphrase, privPassphrase, seed []byte,
 	return w, nil
 }
 
+// RestoreWallet creates a wallet from the seed of an existing one using the provided public and private passphrases.  The birthday of the restored wallet is the genesis block, so that the recovery rescan made when the wallet is synced looks for the addresses of the seed in the whole chain.
+func (l *Loader) RestoreWallet(pubPassphrase, privPassphrase, seed []byte) (*Wallet, error) {
+
+	bday := l.chainParams.GenesisBlock.Header.Timestamp
+	return l.CreateNewWallet(pubPassphrase, privPassphrase, seed, bday)
+}
+
 // LoadedWallet returns the loaded wallet, if any, and a bool for whether the
 
 // wallet has been loaded or not.  If true, the wallet pointer should be safe to
@@ -0,0 +1,102 @@
+package wallet
+
+import (
+	"errors"
+
+	chainhash "git.parallelcoin.io/dev/pod/pkg/chain/hash"
+	"git.parallelcoin.io/dev/pod/pkg/util"
+	walletdb "git.parallelcoin.io/dev/pod/pkg/wallet/db"
+	wmeta "git.parallelcoin.io/dev/pod/pkg/wallet/meta"
+)
+
+// ErrNoTx describes an error where a transaction is not recorded by the
+
+// wallet.
+var ErrNoTx = errors.New("transaction is not recorded by the wallet")
+
+// TxMeta returns the label and comments recorded for the transaction with the
+
+// given hash, or nil if none are recorded.
+func (w *Wallet) TxMeta(txHash *chainhash.Hash) (*wmeta.TxMeta, error) {
+
+	var meta *wmeta.TxMeta
+
+	err := walletdb.View(w.db, func(tx walletdb.ReadTx) error {
+
+		var err error
+		meta, err = w.Meta.TxMeta(tx.ReadBucket(wmetaNamespaceKey), txHash)
+		return err
+	})
+
+	return meta, err
+}
+
+// PutTxMeta records the label and comments of the transaction with the given
+
+// hash, replacing any that were recorded before.
+func (w *Wallet) PutTxMeta(txHash *chainhash.Hash, meta *wmeta.TxMeta) error {
+
+	return walletdb.Update(w.db, func(tx walletdb.ReadWriteTx) error {
+
+		ns := tx.ReadWriteBucket(wmetaNamespaceKey)
+		return w.Meta.PutTxMeta(ns, txHash, meta)
+	})
+}
+
+// SetTxLabel sets the label of the transaction with the given hash.  The
+
+// transaction must be recorded by the wallet.  An empty label removes the
+
+// label.
+func (w *Wallet) SetTxLabel(txHash *chainhash.Hash, label string) error {
+
+	return walletdb.Update(w.db, func(tx walletdb.ReadWriteTx) error {
+
+		txmgrNs := tx.ReadBucket(wtxmgrNamespaceKey)
+		details, err := w.TxStore.TxDetails(txmgrNs, txHash)
+
+		if err != nil {
+
+			return err
+		}
+
+		if details == nil {
+
+			return ErrNoTx
+		}
+
+		ns := tx.ReadWriteBucket(wmetaNamespaceKey)
+		return w.Meta.SetTxLabel(ns, txHash, label)
+	})
+}
+
+// AddressLabel returns the label of a payment address, or the empty string if
+
+// the address has no label.
+func (w *Wallet) AddressLabel(addr util.Address) (string, error) {
+
+	var label string
+
+	err := walletdb.View(w.db, func(tx walletdb.ReadTx) error {
+
+		ns := tx.ReadBucket(wmetaNamespaceKey)
+		label = w.Meta.AddressLabel(ns, addr.EncodeAddress())
+		return nil
+	})
+
+	return label, err
+}
+
+// SetAddressLabel sets the label of a payment address.  Addresses that do not
+
+// belong to the wallet may be labeled as well, so that the payees of sent
+
+// transactions can be recognized.  An empty label removes the label.
+func (w *Wallet) SetAddressLabel(addr util.Address, label string) error {
+
+	return walletdb.Update(w.db, func(tx walletdb.ReadWriteTx) error {
+
+		ns := tx.ReadWriteBucket(wmetaNamespaceKey)
+		return w.Meta.PutAddressLabel(ns, addr.EncodeAddress(), label)
+	})
+}
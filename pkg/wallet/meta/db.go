@@ -0,0 +1,245 @@
+package wmeta
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+
+	chainhash "git.parallelcoin.io/dev/pod/pkg/chain/hash"
+	"git.parallelcoin.io/dev/pod/pkg/chain/wire"
+	walletdb "git.parallelcoin.io/dev/pod/pkg/wallet/db"
+)
+
+// Naming
+//
+// The following variables are commonly used in this file and given
+// reserved names:
+//
+//   ns: The namespace bucket for this package
+//   b:  The primary bucket being operated on
+//   k:  A single bucket key
+//   v:  A single bucket value
+//
+// The functions follow the naming scheme of wtxmgr, where `key` and `value`
+// return the db key and value for some data, `put` inserts or replaces a value,
+// `fetch` reads and returns a value and `delete` removes a k/v pair.
+
+// Big endian is the preferred byte order, due to cursor scans over integer
+// keys iterating in order.
+var byteOrder = binary.BigEndian
+
+// Database versions.  Versions start at 1 and increment for each database
+// change.
+const (
+
+	// LatestVersion is the most recent store version.
+	LatestVersion = 1
+)
+
+// Bucket names
+var (
+	bucketTxMeta        = []byte("t")
+	bucketAddressLabels = []byte("a")
+)
+
+// Root (namespace) bucket keys
+var (
+	rootVersion = []byte("vers")
+)
+
+// The transaction metadata bucket maps transaction hashes to the metadata of
+// the transaction.  The value is serialized as the label, the comment and the
+// comment to, in that order, each as a variable length string:
+//
+//   [0:]   Label (varint length + bytes)
+//   [...]  Comment (varint length + bytes)
+//   [...]  Comment to (varint length + bytes)
+
+func keyTxMeta(
+	hash *chainhash.Hash) []byte {
+
+	return hash[:]
+}
+
+func valueTxMeta(
+	meta *TxMeta) []byte {
+
+	var buf bytes.Buffer
+	// Writes to a bytes.Buffer never fail.
+	_ = wire.WriteVarString(&buf, 0, meta.Label)
+	_ = wire.WriteVarString(&buf, 0, meta.Comment)
+	_ = wire.WriteVarString(&buf, 0, meta.CommentTo)
+	return buf.Bytes()
+}
+
+func readTxMeta(
+	v []byte, meta *TxMeta) error {
+
+	r := bytes.NewReader(v)
+	var err error
+
+	for _, field := range []*string{&meta.Label, &meta.Comment, &meta.CommentTo} {
+
+		*field, err = wire.ReadVarString(r, 0)
+
+		if err != nil {
+
+			str := fmt.Sprintf("%s: malformed transaction metadata "+
+				"(length %d)", bucketTxMeta, len(v))
+			return storeError(ErrData, str, err)
+		}
+	}
+	return nil
+}
+
+func putTxMeta(
+	ns walletdb.ReadWriteBucket, hash *chainhash.Hash, meta *TxMeta) error {
+
+	k := keyTxMeta(hash)
+	v := valueTxMeta(meta)
+	err := ns.NestedReadWriteBucket(bucketTxMeta).Put(k, v)
+
+	if err != nil {
+
+		str := "failed to store transaction metadata"
+		return storeError(ErrDatabase, str, err)
+	}
+	return nil
+}
+
+func fetchTxMeta(
+	ns walletdb.ReadBucket, hash *chainhash.Hash) (*TxMeta, error) {
+
+	k := keyTxMeta(hash)
+	v := ns.NestedReadBucket(bucketTxMeta).Get(k)
+
+	if v == nil {
+
+		return nil, nil
+	}
+	meta := new(TxMeta)
+	err := readTxMeta(v, meta)
+
+	if err != nil {
+
+		return nil, err
+	}
+	return meta, nil
+}
+
+func deleteTxMeta(
+	ns walletdb.ReadWriteBucket, hash *chainhash.Hash) error {
+
+	k := keyTxMeta(hash)
+	err := ns.NestedReadWriteBucket(bucketTxMeta).Delete(k)
+
+	if err != nil {
+
+		str := "failed to delete transaction metadata"
+		return storeError(ErrDatabase, str, err)
+	}
+	return nil
+}
+
+// The address label bucket maps encoded payment addresses to their labels.
+// Both keys and values are the raw bytes of the strings.
+
+func keyAddressLabel(
+	addr string) []byte {
+
+	return []byte(addr)
+}
+
+func putAddressLabel(
+	ns walletdb.ReadWriteBucket, addr, label string) error {
+
+	k := keyAddressLabel(addr)
+	err := ns.NestedReadWriteBucket(bucketAddressLabels).Put(k, []byte(label))
+
+	if err != nil {
+
+		str := "failed to store address label"
+		return storeError(ErrDatabase, str, err)
+	}
+	return nil
+}
+
+func fetchAddressLabel(
+	ns walletdb.ReadBucket, addr string) string {
+
+	return string(ns.NestedReadBucket(bucketAddressLabels).Get(keyAddressLabel(addr)))
+}
+
+func deleteAddressLabel(
+	ns walletdb.ReadWriteBucket, addr string) error {
+
+	k := keyAddressLabel(addr)
+	err := ns.NestedReadWriteBucket(bucketAddressLabels).Delete(k)
+
+	if err != nil {
+
+		str := "failed to delete address label"
+		return storeError(ErrDatabase, str, err)
+	}
+	return nil
+}
+
+// openStore opens an existing metadata store from the passed namespace.
+func openStore(
+	ns walletdb.ReadBucket) error {
+
+	v := ns.Get(rootVersion)
+
+	if len(v) != 4 {
+
+		str := "no metadata store exists in namespace"
+		return storeError(ErrNoExists, str, nil)
+	}
+	version := byteOrder.Uint32(v)
+
+	if version > LatestVersion {
+
+		str := fmt.Sprintf("version recorded version %d is newer that latest "+
+			"understood version %d", version, LatestVersion)
+		return storeError(ErrUnknownVersion, str, nil)
+	}
+	return nil
+}
+
+// createStore creates the metadata store (with the latest db version) in the
+// passed namespace.  If a store already exists, ErrAlreadyExists is returned.
+func createStore(
+	ns walletdb.ReadWriteBucket) error {
+
+	// Ensure that nothing currently exists in the namespace bucket.
+	ck, cv := ns.ReadCursor().First()
+
+	if ck != nil || cv != nil {
+
+		const str = "namespace is not empty"
+		return storeError(ErrAlreadyExists, str, nil)
+	}
+
+	// Write the latest store version.
+	v := make([]byte, 4)
+	byteOrder.PutUint32(v, LatestVersion)
+	err := ns.Put(rootVersion, v)
+
+	if err != nil {
+
+		str := "failed to store latest database version"
+		return storeError(ErrDatabase, str, err)
+	}
+
+	for _, name := range [][]byte{bucketTxMeta, bucketAddressLabels} {
+
+		_, err = ns.CreateBucket(name)
+
+		if err != nil {
+
+			str := fmt.Sprintf("failed to create %s bucket", name)
+			return storeError(ErrDatabase, str, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,11 @@
+// Package wmeta provides a store for metadata that the user attaches to the
+// transactions and addresses of a wallet.  Transactions may be given a label,
+// a comment and the name of the recipient (the comment and comment_to
+// parameters of the send RPCs), and addresses may be given a label.
+//
+// The metadata is kept in its own walletdb namespace, next to the namespaces
+// of the address manager and the transaction store.  It is not derived from
+// the block chain, so it is kept when the transaction history is rebuilt, and
+// it is kept for transactions that are not (or no longer) recorded by the
+// transaction store.
+package wmeta
@@ -0,0 +1,133 @@
+package wmeta
+
+import (
+	chainhash "git.parallelcoin.io/dev/pod/pkg/chain/hash"
+	walletdb "git.parallelcoin.io/dev/pod/pkg/wallet/db"
+)
+
+// TxMeta holds the user supplied metadata of a wallet transaction.
+type TxMeta struct {
+
+	// Label is a short description of the transaction.
+	Label string
+
+	// Comment is a free-form memo, such as the comment parameter of the
+	// sendtoaddress RPC.
+	Comment string
+
+	// CommentTo is the name of the person or organization the transaction
+	// pays to, such as the comment_to parameter of the sendtoaddress RPC.
+	CommentTo string
+}
+
+// IsEmpty returns whether none of the metadata fields are set.
+func (m *TxMeta) IsEmpty() bool {
+
+	return m.Label == "" && m.Comment == "" && m.CommentTo == ""
+}
+
+// Store implements a metadata store for the transactions and addresses of a
+// wallet.  All methods operate on the namespace bucket passed to them, which
+// must be the bucket the store was created in.
+type Store struct{}
+
+// Open opens the wallet metadata store from a walletdb namespace.  If the
+// store does not exist, ErrNoExists is returned.
+func Open(
+	ns walletdb.ReadBucket) (*Store, error) {
+
+	err := openStore(ns)
+
+	if err != nil {
+
+		return nil, err
+	}
+	return &Store{}, nil
+}
+
+// Create creates a new persistent metadata store in the walletdb namespace.
+// Creating the store when one already exists in this namespace will error with
+// ErrAlreadyExists.
+func Create(
+	ns walletdb.ReadWriteBucket) error {
+
+	return createStore(ns)
+}
+
+// TxMeta returns the metadata of the transaction with the given hash, or nil
+// if no metadata is recorded for it.
+func (s *Store) TxMeta(ns walletdb.ReadBucket, hash *chainhash.Hash) (*TxMeta, error) {
+
+	return fetchTxMeta(ns, hash)
+}
+
+// PutTxMeta records the metadata of the transaction with the given hash,
+// replacing any previously recorded metadata.  Recording empty metadata
+// removes the record.
+func (s *Store) PutTxMeta(ns walletdb.ReadWriteBucket, hash *chainhash.Hash,
+	meta *TxMeta) error {
+
+	if meta == nil || meta.IsEmpty() {
+
+		return deleteTxMeta(ns, hash)
+	}
+	return putTxMeta(ns, hash, meta)
+}
+
+// SetTxLabel sets the label of the transaction with the given hash while
+// keeping its other metadata.  An empty label removes the label.
+func (s *Store) SetTxLabel(ns walletdb.ReadWriteBucket, hash *chainhash.Hash,
+	label string) error {
+
+	meta, err := fetchTxMeta(ns, hash)
+
+	if err != nil {
+
+		return err
+	}
+
+	if meta == nil {
+
+		meta = new(TxMeta)
+	}
+	meta.Label = label
+	return s.PutTxMeta(ns, hash, meta)
+}
+
+// AddressLabel returns the label of the encoded payment address, or the empty
+// string if the address has no label.
+func (s *Store) AddressLabel(ns walletdb.ReadBucket, addr string) string {
+
+	return fetchAddressLabel(ns, addr)
+}
+
+// PutAddressLabel sets the label of the encoded payment address, replacing any
+// previous label.  An empty label removes the label.
+func (s *Store) PutAddressLabel(ns walletdb.ReadWriteBucket, addr,
+	label string) error {
+
+	if label == "" {
+
+		return deleteAddressLabel(ns, addr)
+	}
+	return putAddressLabel(ns, addr, label)
+}
+
+// AddressLabels returns the labels of all labeled addresses, keyed by the
+// encoded payment address.
+func (s *Store) AddressLabels(ns walletdb.ReadBucket) (map[string]string, error) {
+
+	labels := make(map[string]string)
+	err := ns.NestedReadBucket(bucketAddressLabels).ForEach(func(k, v []byte) error {
+
+		labels[string(k)] = string(v)
+		return nil
+	})
+
+	if err != nil {
+
+		str := "failed to read address labels"
+		return nil, storeError(ErrDatabase, str, err)
+	}
+	return labels, nil
+}
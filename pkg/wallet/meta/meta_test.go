@@ -0,0 +1,214 @@
+package wmeta_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	chainhash "git.parallelcoin.io/dev/pod/pkg/chain/hash"
+	walletdb "git.parallelcoin.io/dev/pod/pkg/wallet/db"
+	_ "git.parallelcoin.io/dev/pod/pkg/wallet/db/bdb"
+	wmeta "git.parallelcoin.io/dev/pod/pkg/wallet/meta"
+)
+
+var namespaceKey = []byte("wmeta")
+
+func testStore() (*wmeta.Store, walletdb.DB, func(), error) {
+
+	tmpDir, err := ioutil.TempDir("", "wmeta_test")
+
+	if err != nil {
+
+		return nil, nil, func() {}, err
+	}
+	teardown := func() {
+
+		os.RemoveAll(tmpDir)
+	}
+	db, err := walletdb.Create("bdb", filepath.Join(tmpDir, "db"))
+
+	if err != nil {
+
+		teardown()
+		return nil, nil, func() {}, err
+	}
+	var s *wmeta.Store
+	err = walletdb.Update(db, func(tx walletdb.ReadWriteTx) error {
+
+		ns, err := tx.CreateTopLevelBucket(namespaceKey)
+
+		if err != nil {
+
+			return err
+		}
+		err = wmeta.Create(ns)
+
+		if err != nil {
+
+			return err
+		}
+		s, err = wmeta.Open(ns)
+		return err
+	})
+
+	if err != nil {
+
+		db.Close()
+		teardown()
+		return nil, nil, func() {}, err
+	}
+	return s, db, func() {
+
+		db.Close()
+		teardown()
+	}, nil
+}
+
+func TestTxMeta(
+	t *testing.T) {
+
+	t.Parallel()
+	s, db, teardown, err := testStore()
+	defer teardown()
+
+	if err != nil {
+
+		t.Fatal(err)
+	}
+	hash := chainhash.DoubleHashH([]byte("tx"))
+	want := &wmeta.TxMeta{Comment: "invoice 42", CommentTo: "Acme Ltd"}
+
+	err = walletdb.Update(db, func(tx walletdb.ReadWriteTx) error {
+
+		ns := tx.ReadWriteBucket(namespaceKey)
+
+		if meta, err := s.TxMeta(ns, &hash); err != nil || meta != nil {
+
+			t.Errorf("TxMeta: got %v, %v for unknown transaction, want nil",
+				meta, err)
+		}
+
+		if err := s.PutTxMeta(ns, &hash, want); err != nil {
+
+			return err
+		}
+
+		// Setting the label must keep the comments.
+		want.Label = "rent"
+		return s.SetTxLabel(ns, &hash, want.Label)
+	})
+
+	if err != nil {
+
+		t.Fatal(err)
+	}
+
+	err = walletdb.View(db, func(tx walletdb.ReadTx) error {
+
+		meta, err := s.TxMeta(tx.ReadBucket(namespaceKey), &hash)
+
+		if err != nil {
+
+			return err
+		}
+
+		if !reflect.DeepEqual(meta, want) {
+
+			t.Errorf("TxMeta: got %+v, want %+v", meta, want)
+		}
+		return nil
+	})
+
+	if err != nil {
+
+		t.Fatal(err)
+	}
+
+	// Storing empty metadata removes the record.
+	err = walletdb.Update(db, func(tx walletdb.ReadWriteTx) error {
+
+		ns := tx.ReadWriteBucket(namespaceKey)
+
+		if err := s.PutTxMeta(ns, &hash, &wmeta.TxMeta{}); err != nil {
+
+			return err
+		}
+
+		if meta, err := s.TxMeta(ns, &hash); err != nil || meta != nil {
+
+			t.Errorf("TxMeta: got %v, %v after removal, want nil", meta, err)
+		}
+		return nil
+	})
+
+	if err != nil {
+
+		t.Fatal(err)
+	}
+}
+
+func TestAddressLabels(
+	t *testing.T) {
+
+	t.Parallel()
+	s, db, teardown, err := testStore()
+	defer teardown()
+
+	if err != nil {
+
+		t.Fatal(err)
+	}
+	const (
+		addr1 = "aXkgyx8jRHfWj8hxfPgtsJiW3Sm6m4VWjc"
+		addr2 = "aG5ctYrBbiYjgiXqEH8qJvZYn6NFfYV7zs"
+	)
+
+	err = walletdb.Update(db, func(tx walletdb.ReadWriteTx) error {
+
+		ns := tx.ReadWriteBucket(namespaceKey)
+
+		for addr, label := range map[string]string{addr1: "savings", addr2: "payroll"} {
+
+			if err := s.PutAddressLabel(ns, addr, label); err != nil {
+
+				return err
+			}
+		}
+		return s.PutAddressLabel(ns, addr2, "")
+	})
+
+	if err != nil {
+
+		t.Fatal(err)
+	}
+
+	err = walletdb.View(db, func(tx walletdb.ReadTx) error {
+
+		ns := tx.ReadBucket(namespaceKey)
+
+		if label := s.AddressLabel(ns, addr1); label != "savings" {
+
+			t.Errorf("AddressLabel: got %q, want %q", label, "savings")
+		}
+		labels, err := s.AddressLabels(ns)
+
+		if err != nil {
+
+			return err
+		}
+		want := map[string]string{addr1: "savings"}
+
+		if !reflect.DeepEqual(labels, want) {
+
+			t.Errorf("AddressLabels: got %v, want %v", labels, want)
+		}
+		return nil
+	})
+
+	if err != nil {
+
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,574 @@
+package wallet
+
+import (
+	"fmt"
+
+	txauthor "git.parallelcoin.io/dev/pod/pkg/chain/tx/author"
+	txrules "git.parallelcoin.io/dev/pod/pkg/chain/tx/rules"
+	txscript "git.parallelcoin.io/dev/pod/pkg/chain/tx/script"
+	"git.parallelcoin.io/dev/pod/pkg/chain/wire"
+	"git.parallelcoin.io/dev/pod/pkg/util"
+	"git.parallelcoin.io/dev/pod/pkg/util/psbt"
+	waddrmgr "git.parallelcoin.io/dev/pod/pkg/wallet/addrmgr"
+	walletdb "git.parallelcoin.io/dev/pod/pkg/wallet/db"
+)
+
+// FundPsbt creates a partially signed transaction (BIP 174) paying outputs,
+
+// funded by the passed inputs and, if they do not cover the outputs and the
+
+// fee, by outputs of account with at least minconf confirmations.  The inputs
+
+// must spend wallet outputs.  Change is paid to changeAddr, or to a new change
+
+// address of the account if it is nil.  The PSBT carries the outputs spent by
+
+// its inputs and their scripts, so that it can be signed elsewhere.  The spent
+
+// outputs are locked when lockUnspents is set.  The inputs added from the
+
+// account signal replaceability (BIP 125) when replaceable is set.  The fee of
+
+// the transaction and the index of its change output, or -1, are returned
+
+// along with the PSBT.
+func (w *Wallet) FundPsbt(inputs []*wire.TxIn, outputs []*wire.TxOut,
+	lockTime uint32, account uint32, minconf int32, feeSatPerKb util.Amount,
+
+	changeAddr util.Address, lockUnspents,
+
+	replaceable bool) (*psbt.Packet, util.Amount, int, error) {
+
+	for _, output := range outputs {
+
+		if err := txrules.CheckOutput(output, feeSatPerKb); err != nil {
+
+			return nil, 0, 0, err
+		}
+
+	}
+
+	chainClient, err := w.requireChainClient()
+
+	if err != nil {
+
+		return nil, 0, 0, err
+	}
+
+	var tx *txauthor.AuthoredTx
+	var packet *psbt.Packet
+
+	err = walletdb.Update(w.db, func(dbtx walletdb.ReadWriteTx) error {
+
+		addrmgrNs := dbtx.ReadWriteBucket(waddrmgrNamespaceKey)
+		txmgrNs := dbtx.ReadBucket(wtxmgrNamespaceKey)
+
+		// Look up the value and script of each passed input.
+		selected := make(map[wire.OutPoint]struct{}, len(inputs))
+		var selTotal util.Amount
+		selValues := make([]util.Amount, len(inputs))
+		selScripts := make([][]byte, len(inputs))
+
+		for i, txIn := range inputs {
+
+			prevOut := txIn.PreviousOutPoint
+			details, err := w.TxStore.TxDetails(txmgrNs, &prevOut.Hash)
+
+			if err != nil {
+
+				return err
+			}
+
+			if details == nil || int(prevOut.Index) >= len(details.MsgTx.TxOut) {
+
+				return fmt.Errorf("input %v does not spend a wallet output",
+					prevOut)
+			}
+
+			txOut := details.MsgTx.TxOut[prevOut.Index]
+			selected[prevOut] = struct{}{}
+			selTotal += util.Amount(txOut.Value)
+			selValues[i] = util.Amount(txOut.Value)
+			selScripts[i] = txOut.PkScript
+		}
+
+		// Get current block's height and hash.
+		bs, err := chainClient.BlockStamp()
+
+		if err != nil {
+
+			return err
+		}
+
+		eligible, err := w.findEligibleOutputs(dbtx, account, minconf, bs)
+
+		if err != nil {
+
+			return err
+		}
+
+		unselected := eligible[:0]
+
+		for _, credit := range eligible {
+
+			if _, ok := selected[credit.OutPoint]; !ok {
+
+				unselected = append(unselected, credit)
+			}
+
+		}
+
+		moreInputs := makeInputSource(unselected, inputSequence(replaceable))
+
+		// The passed inputs always come first, and only the value they
+
+		// are short of the target is taken from the account.
+		inputSource := func(target util.Amount) (util.Amount, []*wire.TxIn,
+
+			[]util.Amount, [][]byte, error) {
+
+			txIns := make([]*wire.TxIn, 0, len(inputs))
+
+			for _, txIn := range inputs {
+
+				txIns = append(txIns, wire.NewTxIn(&txIn.PreviousOutPoint,
+					nil, nil))
+				txIns[len(txIns)-1].Sequence = txIn.Sequence
+			}
+
+			values := append([]util.Amount(nil), selValues...)
+			scripts := append([][]byte(nil), selScripts...)
+
+			if selTotal >= target {
+
+				return selTotal, txIns, values, scripts, nil
+			}
+
+			total, more, moreValues, moreScripts, err := moreInputs(
+				target - selTotal)
+
+			if err != nil {
+
+				return 0, nil, nil, nil, err
+			}
+
+			return selTotal + total, append(txIns, more...),
+				append(values, moreValues...),
+				append(scripts, moreScripts...), nil
+		}
+
+		changeSource := func() ([]byte, error) {
+
+			if changeAddr != nil {
+
+				return txscript.PayToAddrScript(changeAddr)
+			}
+
+			// As a hack to allow spending from the imported account,
+
+			// change addresses are created from account 0.
+			changeAccount := account
+
+			if account == waddrmgr.ImportedAddrAccount {
+
+				changeAccount = 0
+			}
+
+			addr, err := w.newChangeAddress(addrmgrNs, changeAccount)
+
+			if err != nil {
+
+				return nil, err
+			}
+
+			return txscript.PayToAddrScript(addr)
+		}
+
+		tx, err = txauthor.NewUnsignedTransaction(outputs, feeSatPerKb,
+			inputSource, changeSource)
+
+		if err != nil {
+
+			return err
+		}
+
+		if tx.ChangeIndex >= 0 {
+
+			tx.RandomizeChangePosition()
+		}
+
+		tx.Tx.LockTime = lockTime
+
+		packet, err = psbt.NewFromUnsignedTx(tx.Tx)
+
+		if err != nil {
+
+			return err
+		}
+
+		u, err := psbt.NewUpdater(packet)
+
+		if err != nil {
+
+			return err
+		}
+
+		for i := range packet.Inputs {
+
+			err := w.updatePsbtInput(addrmgrNs, txmgrNs, u, i)
+
+			if err != nil {
+
+				return err
+			}
+
+		}
+
+		return nil
+	})
+
+	if err != nil {
+
+		return nil, 0, 0, err
+	}
+
+	if lockUnspents {
+
+		for _, txIn := range tx.Tx.TxIn {
+
+			w.LockOutpoint(txIn.PreviousOutPoint)
+		}
+
+	}
+
+	fee := tx.TotalInput - util.Amount(sumOutputValues(tx.Tx))
+	return packet, fee, tx.ChangeIndex, nil
+}
+
+// ProcessPsbt adds what the wallet knows about the outputs spent by the inputs
+
+// of a partially signed transaction to it.  When sign is set, each input is
+
+// also signed with every key the wallet holds for it, using hashType unless
+
+// the input requires another, and the inputs that have all their signatures
+
+// are finalized.  The wallet must be unlocked to sign.  It returns whether
+
+// every input of the PSBT is finalized.
+func (w *Wallet) ProcessPsbt(p *psbt.Packet, sign bool,
+
+	hashType txscript.SigHashType) (bool, error) {
+
+	u, err := psbt.NewUpdater(p)
+
+	if err != nil {
+
+		return false, err
+	}
+
+	err = walletdb.View(w.db, func(dbtx walletdb.ReadTx) error {
+
+		addrmgrNs := dbtx.ReadBucket(waddrmgrNamespaceKey)
+		txmgrNs := dbtx.ReadBucket(wtxmgrNamespaceKey)
+
+		for i := range p.Inputs {
+
+			err := w.updatePsbtInput(addrmgrNs, txmgrNs, u, i)
+
+			if err != nil {
+
+				return err
+			}
+
+		}
+
+		if !sign {
+
+			return nil
+		}
+
+		secrets := secretSource{w.Manager, addrmgrNs}
+		hashes := txscript.NewTxSigHashes(p.UnsignedTx)
+
+		for i := range p.Inputs {
+
+			err := w.signPsbtInput(secrets, u, hashes, i, hashType)
+
+			if err != nil {
+
+				return err
+			}
+
+		}
+
+		return nil
+	})
+
+	if err != nil {
+
+		return false, err
+	}
+
+	if sign {
+
+		// An input that can not be finalized yet is missing signatures
+
+		// of other parties, which is not an error here.
+		for i := range p.Inputs {
+
+			psbt.MaybeFinalize(p, i)
+		}
+
+	}
+
+	return p.IsComplete(), nil
+}
+
+// updatePsbtInput adds the transaction holding the output spent by input i of
+
+// the PSBT, the output itself if it is a witness output, and the redeem and
+
+// witness scripts of the output, as far as the wallet knows them and the PSBT
+
+// lacks them.
+func (w *Wallet) updatePsbtInput(addrmgrNs, txmgrNs walletdb.ReadBucket,
+
+	u *psbt.Updater, i int) error {
+
+	pInput := &u.Upsbt.Inputs[i]
+
+	if pInput.IsFinalized() {
+
+		return nil
+	}
+
+	prevOut := u.Upsbt.UnsignedTx.TxIn[i].PreviousOutPoint
+
+	if pInput.NonWitnessUtxo == nil {
+
+		details, err := w.TxStore.TxDetails(txmgrNs, &prevOut.Hash)
+
+		if err != nil {
+
+			return err
+		}
+
+		if details != nil && int(prevOut.Index) < len(details.MsgTx.TxOut) {
+
+			err := u.AddInNonWitnessUtxo(&details.MsgTx, i)
+
+			if err != nil {
+
+				return err
+			}
+
+		}
+
+	}
+
+	txOut := u.Upsbt.PrevOutput(i)
+
+	if txOut == nil {
+
+		return nil
+	}
+
+	script := txOut.PkScript
+
+	if txscript.IsPayToScriptHash(script) {
+
+		if pInput.RedeemScript == nil {
+
+			pInput.RedeemScript = w.redeemScript(addrmgrNs, script)
+		}
+
+		script = pInput.RedeemScript
+	}
+
+	if !txscript.IsWitnessProgram(script) {
+
+		return nil
+	}
+
+	if pInput.WitnessUtxo == nil {
+
+		pInput.WitnessUtxo = txOut
+	}
+
+	if txscript.IsPayToWitnessScriptHash(script) && pInput.WitnessScript == nil {
+
+		pInput.WitnessScript = w.redeemScript(addrmgrNs, script)
+	}
+
+	return nil
+}
+
+// redeemScript returns the script a pay to script hash or pay to witness
+
+// script hash output of the wallet commits to, or nil if it is unknown.
+func (w *Wallet) redeemScript(addrmgrNs walletdb.ReadBucket,
+
+	pkScript []byte) []byte {
+
+	_, addrs, _, err := txscript.ExtractPkScriptAddrs(pkScript, w.chainParams)
+
+	if err != nil || len(addrs) != 1 {
+
+		return nil
+	}
+
+	ma, err := w.Manager.Address(addrmgrNs, addrs[0])
+
+	if err != nil {
+
+		return nil
+	}
+
+	switch ma := ma.(type) {
+
+	case waddrmgr.ManagedScriptAddress:
+		script, err := ma.Script()
+
+		if err != nil {
+
+			return nil
+		}
+
+		return script
+
+	case waddrmgr.ManagedPubKeyAddress:
+
+		// Nested witness addresses commit to the witness program of
+
+		// their key.
+
+		if ma.AddrType() != waddrmgr.NestedWitnessPubKey {
+
+			return nil
+		}
+
+		addr, err := util.NewAddressWitnessPubKeyHash(
+			util.Hash160(ma.PubKey().SerializeCompressed()), w.chainParams)
+
+		if err != nil {
+
+			return nil
+		}
+
+		script, err := txscript.PayToAddrScript(addr)
+
+		if err != nil {
+
+			return nil
+		}
+
+		return script
+	}
+
+	return nil
+}
+
+// signPsbtInput adds a signature to input i of the PSBT for each key of the
+
+// script it spends that the wallet holds.  Inputs whose scripts are unknown
+
+// are skipped.
+func (w *Wallet) signPsbtInput(secrets secretSource, u *psbt.Updater,
+
+	hashes *txscript.TxSigHashes, i int, hashType txscript.SigHashType) error {
+
+	pInput := &u.Upsbt.Inputs[i]
+	txOut := u.Upsbt.PrevOutput(i)
+
+	if pInput.IsFinalized() || txOut == nil {
+
+		return nil
+	}
+
+	if pInput.SighashType != 0 && pInput.SighashType != hashType {
+
+		return fmt.Errorf("input %d requires signature hash type %d", i,
+			pInput.SighashType)
+	}
+
+	script := txOut.PkScript
+
+	if txscript.IsPayToScriptHash(script) {
+
+		script = pInput.RedeemScript
+	}
+
+	// Witness signatures commit to the script code, which is the witness
+
+	// script for pay to witness script hash outputs.
+	subScript := script
+	witness := txscript.IsWitnessProgram(script)
+
+	if txscript.IsPayToWitnessScriptHash(script) {
+
+		subScript = pInput.WitnessScript
+	}
+
+	if subScript == nil {
+
+		return nil
+	}
+
+	_, addrs, _, err := txscript.ExtractPkScriptAddrs(subScript, w.chainParams)
+
+	if err != nil {
+
+		return nil
+	}
+
+	tx := u.Upsbt.UnsignedTx
+
+	for _, addr := range addrs {
+
+		key, compressed, err := secrets.GetKey(addr)
+
+		if waddrmgr.IsError(err, waddrmgr.ErrLocked) {
+
+			return err
+		}
+
+		if err != nil {
+
+			continue
+		}
+
+		var sig []byte
+
+		if witness {
+
+			sig, err = txscript.RawTxInWitnessSignature(tx, hashes, i,
+				txOut.Value, subScript, hashType, key)
+
+		} else {
+
+			sig, err = txscript.RawTxInSignature(tx, i, subScript, hashType,
+				key)
+		}
+
+		if err != nil {
+
+			return err
+		}
+
+		pubKey := key.PubKey().SerializeUncompressed()
+
+		if compressed {
+
+			pubKey = key.PubKey().SerializeCompressed()
+		}
+
+		if err := u.AddInSig(i, sig, pubKey); err != nil {
+
+			return err
+		}
+
+	}
+
+	return nil
+}
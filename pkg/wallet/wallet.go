@@ -33,6 +33,7 @@ import (
 	waddrmgr "git.parallelcoin.io/dev/pod/pkg/wallet/addrmgr"
 	chain "git.parallelcoin.io/dev/pod/pkg/wallet/chain"
 	walletdb "git.parallelcoin.io/dev/pod/pkg/wallet/db"
+	wmeta "git.parallelcoin.io/dev/pod/pkg/wallet/meta"
 )
 
 const (
@@ -75,6 +76,7 @@ var ErrNotSynced = errors.New("wallet is not synchronized with the chain server"
 var (
 	waddrmgrNamespaceKey = []byte("waddrmgr")
 	wtxmgrNamespaceKey   = []byte("wtxmgr")
+	wmetaNamespaceKey    = []byte("wmeta")
 )
 
 // Wallet is a structure containing all the components for a
@@ -90,6 +92,7 @@ type Wallet struct {
 	db      walletdb.DB
 	Manager *waddrmgr.Manager
 	TxStore *wtxmgr.Store
+	Meta    *wmeta.Store
 
 	chainClient        chain.Interface
 	chainClientLock    sync.Mutex
@@ -100,6 +103,13 @@ type Wallet struct {
 
 	recoveryWindow uint32
 
+	// Coin selection strategy of transactions created without naming one,
+
+	// and whether they signal replaceability (BIP 125).
+	coinSelection    CoinSelectionStrategy
+	replaceable      bool
+	coinSelectionMtx sync.Mutex
+
 	// Channels for rescan processing.  Requests are added and merged with
 
 	// any waiting requests, before being sent to another goroutine to
@@ -928,7 +938,7 @@ func (w *Wallet) syncWithChain() error {
 
 // defaultScopeManagers fetches the ScopedKeyManagers from the wallet using the
 
-// default set of key scopes.
+// default set of key scopes, skipping those the wallet does not have.
 func (w *Wallet) defaultScopeManagers() (
 
 	map[waddrmgr.KeyScope]*waddrmgr.ScopedKeyManager, error) {
@@ -939,6 +949,13 @@ func (w *Wallet) defaultScopeManagers() (
 
 		scopedMgr, err := w.Manager.FetchScopedKeyManager(scope)
 
+		// Watching-only wallets only have the scope of their account.
+
+		if waddrmgr.IsError(err, waddrmgr.ErrScopeNotFound) {
+
+			continue
+		}
+
 		if err != nil {
 
 			return nil, err
@@ -1532,6 +1549,9 @@ type (
 		outputs     []*wire.TxOut
 		minconf     int32
 		feeSatPerKB util.Amount
+		strategy    CoinSelectionStrategy
+		replaceable bool
+		replaces    *chainhash.Hash
 		resp        chan createTxResponse
 	}
 
@@ -1578,8 +1598,19 @@ out:
 				continue
 			}
 
-			tx, err := w.txToOutputs(txr.outputs, txr.account,
-				txr.minconf, txr.feeSatPerKB)
+			var tx *txauthor.AuthoredTx
+
+			if txr.replaces != nil {
+
+				tx, err = w.txToReplacement(txr.replaces, txr.feeSatPerKB)
+
+			} else {
+
+				tx, err = w.txToOutputs(txr.outputs, txr.account,
+					txr.minconf, txr.feeSatPerKB, txr.strategy,
+					txr.replaceable)
+			}
+
 			heldUnlock.release()
 			txr.resp <- createTxResponse{tx, err}
 		case <-quit:
@@ -1601,17 +1632,40 @@ out:
 
 // function is serialized to prevent the creation of many transactions which
 
-// spend the same outputs.
+// spend the same outputs.  The outputs spent are chosen by the coin selection
+
+// strategy of the wallet, which also sets whether the transaction signals
+
+// replaceability.
 func (w *Wallet) CreateSimpleTx(account uint32, outputs []*wire.TxOut,
 
 	minconf int32, satPerKb util.Amount) (*txauthor.AuthoredTx, error) {
 
+	return w.CreateSimpleTxWithStrategy(account, outputs, minconf, satPerKb,
+		w.CoinSelection(), w.Replaceable())
+}
+
+// CreateSimpleTxWithStrategy creates a new signed transaction like
+
+// CreateSimpleTx, choosing the outputs spent by the coin selection strategy
+
+// and signalling replaceability (BIP 125) when replaceable is set.
+func (w *Wallet) CreateSimpleTxWithStrategy(account uint32,
+
+	outputs []*wire.TxOut, minconf int32, satPerKb util.Amount,
+
+	strategy CoinSelectionStrategy,
+
+	replaceable bool) (*txauthor.AuthoredTx, error) {
+
 	req := createTxRequest{
 
 		account:     account,
 		outputs:     outputs,
 		minconf:     minconf,
 		feeSatPerKB: satPerKb,
+		strategy:    strategy,
+		replaceable: replaceable,
 		resp:        make(chan createTxResponse),
 	}
 
@@ -2539,9 +2593,20 @@ func RecvCategory(
 func listTransactions(
 	tx walletdb.ReadTx, details *wtxmgr.TxDetails, addrMgr *waddrmgr.Manager,
 
-	syncHeight int32, net *chaincfg.Params) []json.ListTransactionsResult {
+	metaMgr *wmeta.Store, syncHeight int32, net *chaincfg.Params) []json.ListTransactionsResult {
 
 	addrmgrNs := tx.ReadBucket(waddrmgrNamespaceKey)
+	metaNs := tx.ReadBucket(wmetaNamespaceKey)
+
+	// Metadata is only informational, so a failure to read it does not
+
+	// prevent listing the transaction.
+	txMeta, err := metaMgr.TxMeta(metaNs, &details.Hash)
+
+	if err != nil || txMeta == nil {
+
+		txMeta = new(wmeta.TxMeta)
+	}
 
 	var (
 		blockHashStr  string
@@ -2673,6 +2738,10 @@ outputs:
 			WalletConflicts: []string{},
 			Time:            received,
 			TimeReceived:    received,
+			Label:           metaMgr.AddressLabel(metaNs, address),
+			TxLabel:         txMeta.Label,
+			Comment:         txMeta.Comment,
+			To:              txMeta.CommentTo,
 		}
 
 		// Add a received/generated/immature result if this is a credit.
@@ -2733,7 +2802,7 @@ func (w *Wallet) ListSinceBlock(start, end, syncHeight int32) ([]json.ListTransa
 			for _, detail := range details {
 
 				jsonResults := listTransactions(tx, &detail,
-					w.Manager, syncHeight, w.chainParams)
+					w.Manager, w.Meta, syncHeight, w.chainParams)
 				txList = append(txList, jsonResults...)
 			}
 
@@ -2796,7 +2865,7 @@ func (w *Wallet) ListTransactions(from, count int) ([]json.ListTransactionsResul
 				}
 
 				jsonResults := listTransactions(tx, &details[i],
-					w.Manager, syncBlock.Height, w.chainParams)
+					w.Manager, w.Meta, syncBlock.Height, w.chainParams)
 				txList = append(txList, jsonResults...)
 
 				if len(jsonResults) > 0 {
@@ -2870,7 +2939,7 @@ func (w *Wallet) ListAddressTransactions(pkHashes map[string]struct{}) ([]json.L
 					}
 
 					jsonResults := listTransactions(tx, detail,
-						w.Manager, syncBlock.Height, w.chainParams)
+						w.Manager, w.Meta, syncBlock.Height, w.chainParams)
 
 					if err != nil {
 
@@ -2922,7 +2991,7 @@ func (w *Wallet) ListAllTransactions() ([]json.ListTransactionsResult, error) {
 
 			for i := len(details) - 1; i >= 0; i-- {
 
-				jsonResults := listTransactions(tx, &details[i], w.Manager,
+				jsonResults := listTransactions(tx, &details[i], w.Manager, w.Meta,
 					syncBlock.Height, w.chainParams)
 				txList = append(txList, jsonResults...)
 			}
@@ -4454,6 +4523,23 @@ func (w *Wallet) SendOutputs(outputs []*wire.TxOut, account uint32,
 
 	minconf int32, satPerKb util.Amount) (*chainhash.Hash, error) {
 
+	return w.SendOutputsWithStrategy(outputs, account, minconf, satPerKb,
+		w.CoinSelection(), w.Replaceable())
+}
+
+// SendOutputsWithStrategy creates and sends payment transactions like
+
+// SendOutputs, choosing the outputs spent by the coin selection strategy and
+
+// signalling replaceability (BIP 125) when replaceable is set.
+func (w *Wallet) SendOutputsWithStrategy(outputs []*wire.TxOut,
+
+	account uint32, minconf int32, satPerKb util.Amount,
+
+	strategy CoinSelectionStrategy,
+
+	replaceable bool) (*chainhash.Hash, error) {
+
 	// Ensure the outputs to be created adhere to the network's consensus
 
 	// rules.
@@ -4474,7 +4560,8 @@ func (w *Wallet) SendOutputs(outputs []*wire.TxOut, account uint32,
 	// continue to re-broadcast the transaction upon restarts until it has
 
 	// been confirmed.
-	createdTx, err := w.CreateSimpleTx(account, outputs, minconf, satPerKb)
+	createdTx, err := w.CreateSimpleTxWithStrategy(account, outputs, minconf,
+		satPerKb, strategy, replaceable)
 
 	if err != nil {
 
@@ -4868,6 +4955,13 @@ func Create(
 			return err
 		}
 
+		metaNs, err := tx.CreateTopLevelBucket(wmetaNamespaceKey)
+
+		if err != nil {
+
+			return err
+		}
+
 		err = waddrmgr.Create(
 			addrmgrNs, seed, pubPass, privPass, params, nil,
 			birthday,
@@ -4878,7 +4972,71 @@ func Create(
 			return err
 		}
 
-		return wtxmgr.Create(txmgrNs)
+		err = wtxmgr.Create(txmgrNs)
+
+		if err != nil {
+
+			return err
+		}
+
+		return wmeta.Create(metaNs)
+	})
+
+}
+
+// CreateWatchingOnly creates a new watching-only wallet, writing it to an empty
+
+// database.  The default account of the BIP0044 key scope is created from the
+
+// extended public key of an account of another wallet, which holds the private
+
+// keys, and no other key scopes are created.
+func CreateWatchingOnly(
+	db walletdb.DB, pubPass []byte, acctKeyPub *hdkeychain.ExtendedKey,
+
+	params *chaincfg.Params, birthday time.Time) error {
+
+	return walletdb.Update(db, func(tx walletdb.ReadWriteTx) error {
+
+		addrmgrNs, err := tx.CreateTopLevelBucket(waddrmgrNamespaceKey)
+
+		if err != nil {
+
+			return err
+		}
+
+		txmgrNs, err := tx.CreateTopLevelBucket(wtxmgrNamespaceKey)
+
+		if err != nil {
+
+			return err
+		}
+
+		metaNs, err := tx.CreateTopLevelBucket(wmetaNamespaceKey)
+
+		if err != nil {
+
+			return err
+		}
+
+		err = waddrmgr.CreateWatchingOnly(
+			addrmgrNs, acctKeyPub, waddrmgr.KeyScopeBIP0044, pubPass,
+			params, nil, birthday,
+		)
+
+		if err != nil {
+
+			return err
+		}
+
+		err = wtxmgr.Create(txmgrNs)
+
+		if err != nil {
+
+			return err
+		}
+
+		return wmeta.Create(metaNs)
 	})
 
 }
@@ -4940,10 +5098,36 @@ func Open(
 		return nil, err
 	}
 
+	// Wallets created before the metadata store was added do not have its
+
+	// namespace, so create an empty store for them.
+	err = walletdb.Update(db, func(tx walletdb.ReadWriteTx) error {
+
+		if tx.ReadBucket(wmetaNamespaceKey) != nil {
+
+			return nil
+		}
+
+		metaNs, err := tx.CreateTopLevelBucket(wmetaNamespaceKey)
+
+		if err != nil {
+
+			return err
+		}
+
+		return wmeta.Create(metaNs)
+	})
+
+	if err != nil {
+
+		return nil, err
+	}
+
 	// Open database abstraction instances
 	var (
 		addrMgr *waddrmgr.Manager
 		txMgr   *wtxmgr.Store
+		metaMgr *wmeta.Store
 	)
 
 	err = walletdb.View(db, func(tx walletdb.ReadTx) error {
@@ -4959,6 +5143,13 @@ func Open(
 		}
 
 		txMgr, err = wtxmgr.Open(txmgrNs, params)
+
+		if err != nil {
+
+			return err
+		}
+
+		metaMgr, err = wmeta.Open(tx.ReadBucket(wmetaNamespaceKey))
 		return err
 	})
 
@@ -4975,8 +5166,10 @@ func Open(
 		db:                  db,
 		Manager:             addrMgr,
 		TxStore:             txMgr,
+		Meta:                metaMgr,
 		lockedOutpoints:     map[wire.OutPoint]struct{}{},
 		recoveryWindow:      recoveryWindow,
+		coinSelection:       CoinSelectLargest,
 		rescanAddJob:        make(chan *RescanJob),
 		rescanBatch:         make(chan *rescanBatch),
 		rescanNotifications: make(chan interface{}),
@@ -0,0 +1,265 @@
+package wallet
+
+import (
+	"fmt"
+
+	"git.parallelcoin.io/dev/pod/pkg/util"
+	cl "git.parallelcoin.io/dev/pod/pkg/util/cl"
+	"git.parallelcoin.io/dev/pod/pkg/util/hdkeychain"
+	waddrmgr "git.parallelcoin.io/dev/pod/pkg/wallet/addrmgr"
+	walletdb "git.parallelcoin.io/dev/pod/pkg/wallet/db"
+)
+
+// WatchOnlyGapLimit is the number of addresses past the last used one that
+
+// are derived and watched on each branch of a watch-only account, as the
+
+// wallet holding its private keys may hand out any of them (BIP 44).
+const WatchOnlyGapLimit = 20
+
+// ImportXpub creates a watch-only account named name in the key scope from the
+
+// extended public key of an account of another wallet, such as an offline
+
+// cold storage wallet.  The wallet derives and watches the first
+
+// WatchOnlyGapLimit addresses of both branches of the account, and derives
+
+// more as they are used, so that its balance and history are tracked and
+
+// transactions spending from it can be created unsigned, to be signed by the
+
+// other wallet.  When rescan is set, the chain is rescanned for the addresses
+
+// from the block bs, or the genesis block if it is nil.  The number of the new
+
+// account is returned.
+func (w *Wallet) ImportXpub(scope waddrmgr.KeyScope, name string,
+
+	acctKeyPub *hdkeychain.ExtendedKey, bs *waddrmgr.BlockStamp,
+	rescan bool) (uint32, error) {
+
+	manager, err := w.Manager.FetchScopedKeyManager(scope)
+
+	if err != nil {
+
+		return 0, err
+	}
+
+	if bs == nil {
+
+		bs = &waddrmgr.BlockStamp{
+
+			Hash:   *w.chainParams.GenesisHash,
+			Height: 0,
+		}
+	}
+
+	var account uint32
+	var addrs []util.Address
+	var props *waddrmgr.AccountProperties
+
+	err = walletdb.Update(w.db, func(tx walletdb.ReadWriteTx) error {
+
+		addrmgrNs := tx.ReadWriteBucket(waddrmgrNamespaceKey)
+		var err error
+		account, err = manager.NewWatchOnlyAccount(addrmgrNs, name,
+			acctKeyPub)
+
+		if err != nil {
+
+			return err
+		}
+
+		err = manager.ExtendExternalAddresses(addrmgrNs, account,
+			WatchOnlyGapLimit-1)
+
+		if err != nil {
+
+			return err
+		}
+
+		err = manager.ExtendInternalAddresses(addrmgrNs, account,
+			WatchOnlyGapLimit-1)
+
+		if err != nil {
+
+			return err
+		}
+
+		err = manager.ForEachAccountAddress(addrmgrNs, account,
+
+			func(maddr waddrmgr.ManagedAddress) error {
+
+				addrs = append(addrs, maddr.Address())
+				return nil
+			})
+
+		if err != nil {
+
+			return err
+		}
+
+		props, err = manager.AccountProperties(addrmgrNs, account)
+		return err
+	})
+
+	if err != nil {
+
+		return 0, err
+	}
+
+	if rescan {
+
+		job := &RescanJob{
+
+			Addrs:      addrs,
+			OutPoints:  nil,
+			BlockStamp: *bs,
+		}
+
+		// The rescan is not waited for, its success or failure is
+
+		// logged elsewhere.
+		_ = w.SubmitRescan(job)
+
+	} else {
+
+		chainClient, err := w.requireChainClient()
+
+		if err == nil {
+
+			err = chainClient.NotifyReceived(addrs)
+		}
+
+		if err != nil {
+
+			return 0, fmt.Errorf("failed to subscribe for address ntfns "+
+				"for watch-only account %s: %s", name, err)
+		}
+	}
+
+	log <- cl.Infof{"imported watch-only account %q (%d)", name, account}
+
+	w.NtfnServer.notifyAccountProperties(props)
+	return account, nil
+}
+
+// extendWatchOnlyGap derives the addresses needed after the used address ma
+
+// so that WatchOnlyGapLimit unused addresses follow it on its branch, if it
+
+// belongs to a watch-only account.  The newly derived addresses are returned.
+func (w *Wallet) extendWatchOnlyGap(addrmgrNs walletdb.ReadWriteBucket,
+
+	ma waddrmgr.ManagedAddress) ([]util.Address, error) {
+
+	pka, ok := ma.(waddrmgr.ManagedPubKeyAddress)
+
+	if !ok {
+
+		return nil, nil
+	}
+
+	scope, path, ok := pka.DerivationInfo()
+
+	if !ok {
+
+		return nil, nil
+	}
+
+	manager, err := w.Manager.FetchScopedKeyManager(scope)
+
+	if err != nil {
+
+		return nil, err
+	}
+
+	props, err := manager.AccountProperties(addrmgrNs, path.Account)
+
+	if err != nil || !props.WatchOnly {
+
+		return nil, err
+	}
+
+	next := props.ExternalKeyCount
+	extend := manager.ExtendExternalAddresses
+
+	if path.Branch == waddrmgr.InternalBranch {
+
+		next = props.InternalKeyCount
+		extend = manager.ExtendInternalAddresses
+	}
+
+	last := path.Index + WatchOnlyGapLimit
+
+	if last < next {
+
+		return nil, nil
+	}
+
+	if err := extend(addrmgrNs, path.Account, last); err != nil {
+
+		return nil, err
+	}
+
+	var addrs []util.Address
+
+	for index := next; index <= last; index++ {
+
+		derived, err := manager.DeriveFromKeyPath(addrmgrNs,
+
+			waddrmgr.DerivationPath{
+
+				Account: path.Account,
+				Branch:  path.Branch,
+				Index:   index,
+			})
+
+		if err != nil {
+
+			return nil, err
+		}
+
+		addrs = append(addrs, derived.Address())
+	}
+
+	return addrs, nil
+}
+
+// watchAddresses requests notifications for transactions paying to addrs,
+
+// which were derived after a transaction was found in block, or in the
+
+// mempool if it is nil.  Earlier blocks were already scanned for them, so a
+
+// rescan from the block is enough to find any use of them while the wallet
+
+// was catching up.
+func (w *Wallet) watchAddresses(addrs []util.Address,
+
+	block *waddrmgr.BlockStamp) {
+
+	if block != nil {
+
+		_ = w.SubmitRescan(&RescanJob{
+
+			Addrs:      addrs,
+			BlockStamp: *block,
+		})
+
+		return
+	}
+
+	chainClient, err := w.requireChainClient()
+
+	if err == nil {
+
+		err = chainClient.NotifyReceived(addrs)
+	}
+
+	if err != nil {
+
+		log <- cl.Error{"failed to watch new watch-only addresses:", err}
+	}
+}